@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/go-zeromq/zmq4"
+
+	"obj_catalog_fyne_v3/pkg/broker"
 )
 
 type sniffRecord struct {
@@ -35,17 +37,96 @@ type sniffRecord struct {
 func main() {
 	host := flag.String("host", "127.0.0.1", "CASL broker host")
 	pubPort := flag.Int("pub", 27001, "CASL broker PUB port to subscribe to")
+	subPort := flag.Int("sub", 27002, "CASL broker SUB port to publish replayed messages to")
 	topicsRaw := flag.String("topics", "", "comma-separated ZeroMQ topics; empty subscribes to all topics")
 	outPath := flag.String("out", ".tmp/casl-broker-sniffer.ndjson", "NDJSON output path")
 	maxBody := flag.Int("max-body", 1<<20, "maximum decoded payload bytes stored per record")
+	replayPath := flag.String("replay", "", "replay a recorded NDJSON session instead of sniffing")
+	speed := flag.Float64("speed", 1, "replay speed multiplier; 0 publishes without pauses")
+	maxGap := flag.Duration("max-gap", 0, "cap for a single pause between replayed records; 0 keeps recorded gaps")
+	warmup := flag.Duration("warmup", time.Second, "wait before replay so subscribers can connect")
+	serveBroker := flag.Bool("serve-broker", false, "listen on -pub/-sub as a local stand-in CASL broker")
 	flag.Parse()
 
-	if err := run(*host, *pubPort, *topicsRaw, *outPath, *maxBody); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	if *serveBroker {
+		err = serveWith(ctx, *host, *pubPort, *subPort, func(ctx context.Context) error {
+			if *replayPath == "" {
+				return sniff(ctx, *host, *pubPort, *topicsRaw, *outPath, *maxBody)
+			}
+			if err := replay(ctx, *host, *pubPort, *subPort, *replayPath, *topicsRaw, *speed, *maxGap, *warmup); err != nil {
+				return err
+			}
+			log.Printf("replay finished; local broker keeps running until interrupted")
+			<-ctx.Done()
+			return nil
+		})
+	} else if *replayPath != "" {
+		err = replay(ctx, *host, *pubPort, *subPort, *replayPath, *topicsRaw, *speed, *maxGap, *warmup)
+	} else {
+		err = sniff(ctx, *host, *pubPort, *topicsRaw, *outPath, *maxBody)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
 		log.Fatal(err)
 	}
 }
 
-func run(host string, pubPort int, topicsRaw, outPath string, maxBody int) error {
+// serveWith runs a local stand-in broker for the lifetime of fn.
+func serveWith(ctx context.Context, host string, pubPort, subPort int, fn func(context.Context) error) error {
+	proxy, err := broker.NewProxy(ctx, host, pubPort, subPort)
+	if err != nil {
+		return err
+	}
+	defer proxy.Close()
+	log.Printf("local CASL broker listening pub=%s sub=%s", proxy.PubAddr(), proxy.SubAddr())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	proxyErr := make(chan error, 1)
+	go func() {
+		proxyErr <- proxy.Run(nil)
+		cancel()
+	}()
+
+	err = fn(ctx)
+	proxy.Close()
+	if runErr := <-proxyErr; err == nil && !errors.Is(runErr, context.Canceled) {
+		err = runErr
+	}
+	return err
+}
+
+func replay(ctx context.Context, host string, pubPort, subPort int, path, topicsRaw string, speed float64, maxGap, warmup time.Duration) error {
+	records, err := readRecordsFile(path)
+	if err != nil {
+		return err
+	}
+	client, err := broker.New(ctx, host, pubPort, subPort, "casl-broker-sniffer")
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	log.Printf("CASL broker replay of %s (%d records) to %s:%d speed=%g topics=%q", path, len(records), host, subPort, speed, topicsRaw)
+	if err := sleepContext(ctx, warmup); err != nil {
+		return err
+	}
+	stats, err := replayRecords(ctx, client, records, replayOptions{
+		Topics: parseTopics(topicsRaw),
+		Speed:  speed,
+		MaxGap: maxGap,
+	})
+	log.Printf("replay published=%d filtered=%d skipped=%d", stats.Published, stats.Filtered, stats.Skipped)
+	if err != nil {
+		return err
+	}
+	return sleepContext(ctx, replayLinger)
+}
+
+func sniff(ctx context.Context, host string, pubPort int, topicsRaw, outPath string, maxBody int) error {
 	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
 		return fmt.Errorf("create output directory: %w", err)
 	}
@@ -56,9 +137,6 @@ func run(host string, pubPort int, topicsRaw, outPath string, maxBody int) error
 	}
 	defer out.Close()
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
 	sub := zmq4.NewSub(ctx)
 	defer sub.Close()
 	closeOnCancel := context.AfterFunc(ctx, func() {
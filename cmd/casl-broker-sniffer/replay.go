@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// replayOptions controls playback of a recorded session.
+type replayOptions struct {
+	Topics []string
+	// Speed scales the recorded intervals: 1 keeps original timing,
+	// 2 plays twice as fast, 0 publishes without pauses.
+	Speed float64
+	// MaxGap caps a single pause so idle stretches in a capture do not
+	// stall the replay. Zero means no cap.
+	MaxGap time.Duration
+}
+
+// replayLinger gives the PUB socket time to flush queued frames before the
+// client is closed; zmq4 drops unsent messages on Close.
+const replayLinger = 500 * time.Millisecond
+
+type rawPublisher interface {
+	PublishRaw(topic string, body []byte) error
+}
+
+type replayStats struct {
+	Published int
+	Filtered  int
+	Skipped   int
+}
+
+// readRecords reads sniffer NDJSON output. Numbers are kept as json.Number
+// so re-encoding does not lose precision on large identifiers.
+func readRecords(r io.Reader) ([]sniffRecord, error) {
+	reader := bufio.NewReader(r)
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	records := make([]sniffRecord, 0)
+	for line := 1; ; line++ {
+		var record sniffRecord
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode record %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+}
+
+func readRecordsFile(path string) ([]sniffRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open replay file: %w", err)
+	}
+	defer file.Close()
+	return readRecords(file)
+}
+
+// recordPayload rebuilds the payload frame as the broker sent it.
+// ok is false when the record cannot be reproduced faithfully.
+func recordPayload(record sniffRecord) (body []byte, ok bool, err error) {
+	if record.Frames == 0 || record.Truncated {
+		return nil, false, nil
+	}
+	if record.Frames == 1 {
+		return nil, true, nil
+	}
+
+	if record.PayloadJSON != nil {
+		body, err = json.Marshal(record.PayloadJSON)
+		if err != nil {
+			return nil, false, fmt.Errorf("encode payload: %w", err)
+		}
+	} else {
+		body = []byte(record.PayloadText)
+	}
+	if record.PayloadEncoding == "gzip" {
+		body, err = gzipBytes(body)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return body, true, nil
+}
+
+func gzipBytes(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, fmt.Errorf("gzip payload: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("gzip payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// topicAllowed mirrors ZeroMQ subscription semantics: prefix match, "" matches all.
+func topicAllowed(topic string, topics []string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, prefix := range topics {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func replayDelay(prev, current time.Time, opts replayOptions) time.Duration {
+	if opts.Speed <= 0 || prev.IsZero() || current.IsZero() || !current.After(prev) {
+		return 0
+	}
+	delay := time.Duration(float64(current.Sub(prev)) / opts.Speed)
+	if opts.MaxGap > 0 && delay > opts.MaxGap {
+		delay = opts.MaxGap
+	}
+	return delay
+}
+
+func replayRecords(ctx context.Context, publisher rawPublisher, records []sniffRecord, opts replayOptions) (replayStats, error) {
+	var (
+		stats replayStats
+		prev  time.Time
+	)
+	for _, record := range records {
+		if !topicAllowed(record.Topic, opts.Topics) {
+			stats.Filtered++
+			continue
+		}
+		body, ok, err := recordPayload(record)
+		if err != nil {
+			return stats, fmt.Errorf("record topic=%q time=%s: %w", record.Topic, record.Time, err)
+		}
+		if !ok {
+			stats.Skipped++
+			log.Printf("replay: skip record topic=%q time=%s frames=%d truncated=%t", record.Topic, record.Time, record.Frames, record.Truncated)
+			continue
+		}
+
+		recordTime, _ := time.Parse(time.RFC3339Nano, record.Time)
+		if err := sleepContext(ctx, replayDelay(prev, recordTime, opts)); err != nil {
+			return stats, err
+		}
+		if !recordTime.IsZero() {
+			prev = recordTime
+		}
+
+		if err := publisher.PublishRaw(record.Topic, body); err != nil {
+			return stats, err
+		}
+		stats.Published++
+		log.Printf("replay topic=%q frames=%d payload=%s", record.Topic, record.Frames, preview(record))
+	}
+	return stats, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
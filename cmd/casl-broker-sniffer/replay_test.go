@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-zeromq/zmq4"
+)
+
+type capturedPublish struct {
+	topic string
+	body  []byte
+}
+
+type capturePublisher struct {
+	published []capturedPublish
+}
+
+func (p *capturePublisher) PublishRaw(topic string, body []byte) error {
+	p.published = append(p.published, capturedPublish{topic: topic, body: body})
+	return nil
+}
+
+func TestReadRecordsKeepsLargeNumbers(t *testing.T) {
+	t.Parallel()
+
+	input := `{"time":"2026-03-29T12:00:00Z","topic":"ppk_in","frames":2,"payload_encoding":"plain","payload_json":{"obj_id":9007199254740993}}` + "\n"
+	records, err := readRecords(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readRecords() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("len(records) = %d, want 1", len(records))
+	}
+	body, ok, err := recordPayload(records[0])
+	if err != nil || !ok {
+		t.Fatalf("recordPayload() = ok %t err %v", ok, err)
+	}
+	if string(body) != `{"obj_id":9007199254740993}` {
+		t.Fatalf("payload = %s", body)
+	}
+}
+
+func TestRecordPayloadRoundTripsGzipThroughRecorder(t *testing.T) {
+	t.Parallel()
+
+	original, err := gzipBytes([]byte(`{"type":"ppk_in","data":[1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := recordFromMessage(zmq4.NewMsgFrom([]byte("ppk_in"), original), 1024)
+
+	body, ok, err := recordPayload(record)
+	if err != nil || !ok {
+		t.Fatalf("recordPayload() = ok %t err %v", ok, err)
+	}
+	decoded := decodePayload(body, 1024)
+	if decoded.Encoding != "gzip" {
+		t.Fatalf("Encoding = %q, want gzip", decoded.Encoding)
+	}
+	if obj, _ := decoded.JSON.(map[string]any); obj["type"] != "ppk_in" {
+		t.Fatalf("JSON = %#v", decoded.JSON)
+	}
+}
+
+func TestRecordPayloadSkipsTruncatedAndEmptyRecords(t *testing.T) {
+	t.Parallel()
+
+	for _, record := range []sniffRecord{
+		{Topic: "api_out", Frames: 2, PayloadText: `{"lon`, Truncated: true},
+		{Frames: 0},
+	} {
+		if _, ok, err := recordPayload(record); ok || err != nil {
+			t.Fatalf("recordPayload(%+v) = ok %t err %v, want skipped", record, ok, err)
+		}
+	}
+	body, ok, err := recordPayload(sniffRecord{Topic: "ping", Frames: 1, PayloadText: "ping"})
+	if !ok || err != nil || body != nil {
+		t.Fatalf("single frame record = %q ok %t err %v", body, ok, err)
+	}
+}
+
+func TestReplayDelayScalesAndCaps(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 29, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		next time.Time
+		opts replayOptions
+		want time.Duration
+	}{
+		{name: "original timing", next: start.Add(2 * time.Second), opts: replayOptions{Speed: 1}, want: 2 * time.Second},
+		{name: "accelerated", next: start.Add(2 * time.Second), opts: replayOptions{Speed: 4}, want: 500 * time.Millisecond},
+		{name: "no pauses", next: start.Add(2 * time.Second), opts: replayOptions{Speed: 0}, want: 0},
+		{name: "capped gap", next: start.Add(time.Hour), opts: replayOptions{Speed: 1, MaxGap: 5 * time.Second}, want: 5 * time.Second},
+		{name: "clock went back", next: start.Add(-time.Second), opts: replayOptions{Speed: 1}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := replayDelay(start, tt.next, tt.opts); got != tt.want {
+				t.Fatalf("replayDelay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayRecordsFiltersTopicsByPrefix(t *testing.T) {
+	t.Parallel()
+
+	records := []sniffRecord{
+		{Time: "2026-03-29T12:00:00Z", Topic: "ppk_in", Frames: 2, PayloadEncoding: "plain", PayloadText: "a"},
+		{Time: "2026-03-29T12:00:01Z", Topic: "api_out", Frames: 2, PayloadEncoding: "plain", PayloadText: "b"},
+		{Time: "2026-03-29T12:00:02Z", Topic: "ppk_out", Frames: 2, PayloadEncoding: "plain", PayloadText: "c", Truncated: true},
+	}
+	publisher := &capturePublisher{}
+	stats, err := replayRecords(context.Background(), publisher, records, replayOptions{Topics: parseTopics("ppk_")})
+	if err != nil {
+		t.Fatalf("replayRecords() error = %v", err)
+	}
+	if stats.Published != 1 || stats.Filtered != 1 || stats.Skipped != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].topic != "ppk_in" || string(publisher.published[0].body) != "a" {
+		t.Fatalf("published = %#v", publisher.published)
+	}
+}
@@ -34,7 +34,9 @@ func main() {
 	corsOrigin := flag.String("cors-origin", "*", "Access-Control-Allow-Origin value for browser testing")
 	serveCASLUI := flag.Bool("serve-casl-ui", true, "serve CASL web UI static files on the HTTP API port")
 	caslRoot := flag.String("casl-root", defaultCASLRoot(), "CASL http-api directory containing public, configurator_4L, and casl-technic")
-	dataSource := flag.String("data-source", "fixture", "data source: fixture, env, or config")
+	dataSource := flag.String("data-source", "fixture", "data source: fixture, env, config, record, or replay")
+	recordUpstream := flag.String("record-upstream", "", "real CASL server URL proxied and captured by -data-source=record")
+	recordingPath := flag.String("recording", "", "recording bundle written by -data-source=record and served by -data-source=replay")
 	shutdownTimeout := flag.Duration("shutdown-timeout", 5*time.Second, "graceful shutdown timeout")
 	flag.Parse()
 
@@ -44,6 +46,12 @@ func main() {
 		log.Fatal(err)
 	}
 	applyExplicitFlagOverrides(&gatewayCfg, explicitFlags, *addr, *wsAddr, *autoPort, *corsOrigin, *serveCASLUI, *caslRoot, *dataSource, *shutdownTimeout)
+	if explicitFlags["record-upstream"] {
+		gatewayCfg.RecordUpstream = *recordUpstream
+	}
+	if explicitFlags["recording"] {
+		gatewayCfg.RecordingPath = *recordingPath
+	}
 	if createdConfig {
 		log.Printf("created default gateway config: %s", strings.TrimSpace(*configPath))
 	}
@@ -61,7 +69,7 @@ func main() {
 	defer wsListener.Close()
 
 	wsURL := "ws://" + resolvedWSAddr
-	handler, cleanup, err := buildGatewayHandler(strings.TrimSpace(gatewayCfg.DataSource), wsURL, gatewayCfg.Database.toDBConfig())
+	handler, cleanup, err := buildGatewayHandler(gatewayCfg, wsURL)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -153,8 +161,10 @@ func shutdownServers(ctx context.Context, servers ...*http.Server) error {
 	return nil
 }
 
-func buildGatewayHandler(source string, wsURL string, cfg config.DBConfig) (*caslcompat.Handler, func(), error) {
-	switch strings.ToLower(strings.TrimSpace(source)) {
+func buildGatewayHandler(gatewayCfg gatewayConfig, wsURL string) (http.Handler, func(), error) {
+	source := strings.TrimSpace(gatewayCfg.DataSource)
+	cfg := gatewayCfg.Database.toDBConfig()
+	switch strings.ToLower(source) {
 	case "", "fixture":
 		return caslcompat.NewFixtureHandlerWithWSURL(wsURL), func() {}, nil
 	case "env", "config":
@@ -176,6 +186,32 @@ func buildGatewayHandler(source string, wsURL string, cfg config.DBConfig) (*cas
 		handler := caslcompat.NewProviderHandlerWithWSURL(provider, options, wsURL)
 		handler.SetCommandUpstream(upstream)
 		return handler, cleanup, nil
+	case "record":
+		recordingPath := strings.TrimSpace(gatewayCfg.RecordingPath)
+		if recordingPath == "" {
+			return nil, func() {}, loggableError("record data source requires recording_path")
+		}
+		proxy, err := caslcompat.NewRecordingProxy(gatewayCfg.RecordUpstream, wsURL)
+		if err != nil {
+			return nil, func() {}, err
+		}
+		log.Printf("recording CASL session from %s to %s", strings.TrimSpace(gatewayCfg.RecordUpstream), recordingPath)
+		return proxy, func() {
+			recording := proxy.Recording()
+			if err := recording.WriteFile(recordingPath); err != nil {
+				log.Printf("save CASL recording: %v", err)
+				return
+			}
+			log.Printf("saved CASL recording: %s (%d exchanges, %d frames)", recordingPath, len(recording.Exchanges), len(recording.Frames))
+		}, nil
+	case "replay":
+		recording, err := caslcompat.ReadRecordingFile(strings.TrimSpace(gatewayCfg.RecordingPath))
+		if err != nil {
+			return nil, func() {}, err
+		}
+		handler := caslcompat.NewReplayHandler(recording, wsURL)
+		handler.SetFrameSpeed(gatewayCfg.ReplaySpeed)
+		return handler, func() {}, nil
 	default:
 		return nil, func() {}, loggableError("unsupported data source: " + source)
 	}
@@ -279,6 +315,9 @@ type gatewayConfig struct {
 	CASLRoot        string          `json:"casl_root"`
 	DataSource      string          `json:"data_source"`
 	ShutdownTimeout configDuration  `json:"shutdown_timeout"`
+	RecordUpstream  string          `json:"record_upstream,omitempty"`
+	RecordingPath   string          `json:"recording_path,omitempty"`
+	ReplaySpeed     float64         `json:"replay_speed,omitempty"`
 	Database        gatewayDBConfig `json:"database"`
 }
 
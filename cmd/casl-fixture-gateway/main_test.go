@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"obj_catalog_fyne_v3/pkg/caslcompat"
 	"obj_catalog_fyne_v3/pkg/config"
 )
 
@@ -63,3 +64,31 @@ func TestLoadOrCreateGatewayConfigReadsExistingFile(t *testing.T) {
 		t.Fatalf("shutdown timeout = %s, want 3s", got)
 	}
 }
+
+func TestBuildGatewayHandlerRecordAndReplaySources(t *testing.T) {
+	cfg := defaultGatewayConfig()
+	cfg.DataSource = "record"
+	cfg.RecordUpstream = "http://127.0.0.1:50003"
+	if _, _, err := buildGatewayHandler(cfg, "ws://127.0.0.1:23322"); err == nil {
+		t.Fatal("record source without recording_path was accepted")
+	}
+
+	cfg.RecordingPath = filepath.Join(t.TempDir(), "casl-session.json")
+	handler, cleanup, err := buildGatewayHandler(cfg, "ws://127.0.0.1:23322")
+	if err != nil {
+		t.Fatalf("buildGatewayHandler(record) error = %v", err)
+	}
+	if _, ok := handler.(*caslcompat.RecordingProxy); !ok {
+		t.Fatalf("record handler = %T", handler)
+	}
+	cleanup()
+
+	cfg.DataSource = "replay"
+	handler, _, err = buildGatewayHandler(cfg, "ws://127.0.0.1:23322")
+	if err != nil {
+		t.Fatalf("buildGatewayHandler(replay) error = %v", err)
+	}
+	if _, ok := handler.(*caslcompat.ReplayHandler); !ok {
+		t.Fatalf("replay handler = %T", handler)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+type serviceConfig struct {
+	VerifyDB        bool                  `json:"verify_db"`
+	SettleDelay     string                `json:"settle_delay"`
+	PollInterval    string                `json:"poll_interval"`
+	CASLFixtureURL  string                `json:"casl_fixture_url"`
+	CASLFixturePult string                `json:"casl_fixture_pult_id"`
+	PhoenixControl  servicePhoenixControl `json:"phoenix_control"`
+	Database        serviceDatabaseConfig `json:"database"`
+}
+
+// servicePhoenixControl — канал керування Phoenix. Шаблон пакета залежить
+// від стенда; див. eventscenario.PhoenixControlInjector.
+type servicePhoenixControl struct {
+	Addr       string `json:"addr"`
+	Template   string `json:"template"`
+	AckTimeout string `json:"ack_timeout"`
+}
+
+type serviceDatabaseConfig struct {
+	User            string `json:"user"`
+	Password        string `json:"password"`
+	Host            string `json:"host"`
+	Port            string `json:"port"`
+	Path            string `json:"path"`
+	Params          string `json:"params"`
+	FirebirdEnabled bool   `json:"firebird_enabled"`
+	PhoenixEnabled  bool   `json:"phoenix_enabled"`
+	PhoenixUser     string `json:"phoenix_user"`
+	PhoenixPassword string `json:"phoenix_password"`
+	PhoenixHost     string `json:"phoenix_host"`
+	PhoenixPort     string `json:"phoenix_port"`
+	PhoenixInstance string `json:"phoenix_instance"`
+	PhoenixDatabase string `json:"phoenix_database"`
+	PhoenixParams   string `json:"phoenix_params"`
+	CASLEnabled     bool   `json:"casl_enabled"`
+	Mode            string `json:"mode"`
+	CASLBaseURL     string `json:"casl_base_url"`
+	CASLToken       string `json:"casl_token"`
+	CASLEmail       string `json:"casl_email"`
+	CASLPass        string `json:"casl_password"`
+	CASLPultID      int64  `json:"casl_pult_id"`
+	LogLevel        string `json:"log_level"`
+}
+
+func defaultServiceConfig() serviceConfig {
+	return serviceConfig{
+		VerifyDB:       true,
+		SettleDelay:    "3s",
+		PollInterval:   "500ms",
+		CASLFixtureURL: "http://127.0.0.1:50003",
+		PhoenixControl: servicePhoenixControl{
+			AckTimeout: "5s",
+		},
+		Database: serviceDatabaseConfig{
+			User:            "SYSDBA",
+			Password:        "masterkey",
+			Host:            "localhost",
+			Port:            "3050",
+			Path:            "C:/MOST.PM/BASE/MOST5.FDB",
+			Params:          "charset=WIN1251&auth_plugin_name=Srp",
+			FirebirdEnabled: true,
+			PhoenixEnabled:  false,
+			PhoenixUser:     "sa",
+			PhoenixHost:     "localhost",
+			PhoenixInstance: "PHOENIX4",
+			PhoenixDatabase: "Pult4DB",
+			PhoenixParams:   "encrypt=disable&trustservercertificate=true",
+			Mode:            config.BackendModeFirebird,
+			CASLBaseURL:     "http://127.0.0.1:50003",
+			LogLevel:        "info",
+		},
+	}
+}
+
+func loadServiceConfig(path string) (serviceConfig, error) {
+	cfg := defaultServiceConfig()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return serviceConfig{}, fmt.Errorf("read service config %q: %w", path, err)
+	}
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		return serviceConfig{}, fmt.Errorf("decode service config %q: %w", path, err)
+	}
+	cfg.Database.applyDefaults()
+	return cfg, nil
+}
+
+func writeServiceConfig(path string, cfg serviceConfig) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("service config path is empty")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create config directory %q: %w", dir, err)
+		}
+	}
+	body, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode service config: %w", err)
+	}
+	body = append(body, '\n')
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return fmt.Errorf("write service config %q: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *serviceDatabaseConfig) applyDefaults() {
+	defaults := defaultServiceConfig().Database
+	if strings.TrimSpace(cfg.User) == "" {
+		cfg.User = defaults.User
+	}
+	if strings.TrimSpace(cfg.Host) == "" {
+		cfg.Host = defaults.Host
+	}
+	if strings.TrimSpace(cfg.Port) == "" {
+		cfg.Port = defaults.Port
+	}
+	if strings.TrimSpace(cfg.Path) == "" {
+		cfg.Path = defaults.Path
+	}
+	if strings.TrimSpace(cfg.Params) == "" {
+		cfg.Params = defaults.Params
+	}
+	if strings.TrimSpace(cfg.PhoenixUser) == "" {
+		cfg.PhoenixUser = defaults.PhoenixUser
+	}
+	if strings.TrimSpace(cfg.PhoenixHost) == "" {
+		cfg.PhoenixHost = defaults.PhoenixHost
+	}
+	if strings.TrimSpace(cfg.PhoenixInstance) == "" {
+		cfg.PhoenixInstance = defaults.PhoenixInstance
+	}
+	if strings.TrimSpace(cfg.PhoenixDatabase) == "" {
+		cfg.PhoenixDatabase = defaults.PhoenixDatabase
+	}
+	if strings.TrimSpace(cfg.PhoenixParams) == "" {
+		cfg.PhoenixParams = defaults.PhoenixParams
+	}
+	if strings.TrimSpace(cfg.Mode) == "" {
+		cfg.Mode = defaults.Mode
+	}
+	if strings.TrimSpace(cfg.CASLBaseURL) == "" {
+		cfg.CASLBaseURL = defaults.CASLBaseURL
+	}
+	if strings.TrimSpace(cfg.LogLevel) == "" {
+		cfg.LogLevel = defaults.LogLevel
+	}
+}
+
+// settleDelay — пауза після підключення до джерел, щоб провайдери
+// встигли отримати початковий стан до знімка базової лінії.
+func (cfg serviceConfig) settleDelay() time.Duration {
+	return parseDurationOr(cfg.SettleDelay, 0)
+}
+
+func (cfg serviceConfig) pollInterval() time.Duration {
+	return parseDurationOr(cfg.PollInterval, 500*time.Millisecond)
+}
+
+func (cfg serviceConfig) phoenixAckTimeout() time.Duration {
+	return parseDurationOr(cfg.PhoenixControl.AckTimeout, 0)
+}
+
+func parseDurationOr(raw string, fallback time.Duration) time.Duration {
+	parsed, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || parsed <= 0 {
+		return fallback
+	}
+	return parsed
+}
+
+func (cfg serviceConfig) dbConfig() config.DBConfig {
+	return cfg.Database.toDBConfig()
+}
+
+func (cfg serviceDatabaseConfig) toDBConfig() config.DBConfig {
+	return config.DBConfig{
+		User:            cfg.User,
+		Password:        cfg.Password,
+		Host:            cfg.Host,
+		Port:            cfg.Port,
+		Path:            cfg.Path,
+		Params:          cfg.Params,
+		FirebirdEnabled: cfg.FirebirdEnabled,
+		PhoenixEnabled:  cfg.PhoenixEnabled,
+		PhoenixUser:     cfg.PhoenixUser,
+		PhoenixPassword: cfg.PhoenixPassword,
+		PhoenixHost:     cfg.PhoenixHost,
+		PhoenixPort:     cfg.PhoenixPort,
+		PhoenixInstance: cfg.PhoenixInstance,
+		PhoenixDatabase: cfg.PhoenixDatabase,
+		PhoenixParams:   cfg.PhoenixParams,
+		CASLEnabled:     cfg.CASLEnabled,
+		Mode:            cfg.Mode,
+		CASLBaseURL:     cfg.CASLBaseURL,
+		CASLToken:       cfg.CASLToken,
+		CASLEmail:       cfg.CASLEmail,
+		CASLPass:        cfg.CASLPass,
+		CASLPultID:      cfg.CASLPultID,
+		LogLevel:        cfg.LogLevel,
+	}
+}
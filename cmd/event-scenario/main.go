@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/backend"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
+	"obj_catalog_fyne_v3/pkg/eventscenario"
+	"obj_catalog_fyne_v3/pkg/logger"
+	"obj_catalog_fyne_v3/pkg/version"
+)
+
+// errScenarioFailed — хоча б один сценарій не пройшов; код виходу 1.
+var errScenarioFailed = errors.New("scenario failed")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] scenario.json...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	configPath := flag.String("config", "event-scenario.json", "JSON config path")
+	initConfig := flag.Bool("init", false, "write an example config if it does not exist and exit")
+	verifyDB := flag.Bool("verify-db", true, "ping configured databases on startup")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+	visited := visitedFlags()
+
+	ver := version.Current()
+	if *showVersion {
+		fmt.Println(ver.FullText())
+		return
+	}
+
+	logConfig := logger.DefaultConfig()
+	logConfig.LogDir = "log/event-scenario"
+	if err := logger.Setup(logConfig); err != nil {
+		fmt.Printf("Помилка налаштування логера: %v\n", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Interface("panic", r).
+				Str("stack", string(debug.Stack())).
+				Msg("event-scenario: panic")
+			os.Exit(2)
+		}
+	}()
+
+	if *initConfig {
+		if err := writeExampleConfig(strings.TrimSpace(*configPath)); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts := runtimeOptions{
+		ConfigPath:   strings.TrimSpace(*configPath),
+		VerifyDB:     *verifyDB,
+		Scenarios:    flag.Args(),
+		visitedFlags: visited,
+	}
+	if err := run(opts, os.Stdout); err != nil {
+		if !errors.Is(err, errScenarioFailed) {
+			fmt.Println(err)
+			log.Error().Err(err).Msg("event-scenario stopped with error")
+		}
+		os.Exit(1)
+	}
+}
+
+type runtimeOptions struct {
+	ConfigPath   string
+	VerifyDB     bool
+	Scenarios    []string
+	visitedFlags map[string]bool
+}
+
+func run(opts runtimeOptions, out io.Writer) error {
+	if len(opts.Scenarios) == 0 {
+		return errors.New("no scenario files given")
+	}
+	scenarios := make([]eventscenario.Scenario, 0, len(opts.Scenarios))
+	for _, path := range opts.Scenarios {
+		scenario, err := eventscenario.Load(path)
+		if err != nil {
+			return err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+
+	cfg, err := resolveServiceConfig(opts)
+	if err != nil {
+		return err
+	}
+	dbCfg := cfg.dbConfig()
+	dbCfg.LogLevel = logger.SetLogLevel(dbCfg.LogLevel)
+	runtime, err := dataruntime.New(dbCfg, nil, cfg.VerifyDB)
+	if err != nil {
+		return err
+	}
+	defer runtime.Close()
+
+	runner := &eventscenario.Runner{
+		Observer:     runtime.Provider,
+		Injectors:    buildInjectors(cfg, runtime),
+		PollInterval: cfg.pollInterval(),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := sleepContext(ctx, cfg.settleDelay()); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, scenario := range scenarios {
+		report, err := runner.Run(ctx, scenario)
+		if err != nil {
+			return fmt.Errorf("scenario %q: %w", scenario.Name, err)
+		}
+		printReport(out, report)
+		if !report.Passed() {
+			failed++
+		}
+	}
+	fmt.Fprintf(out, "\n%d/%d scenarios passed\n", len(scenarios)-failed, len(scenarios))
+	if failed > 0 {
+		return errScenarioFailed
+	}
+	return nil
+}
+
+// buildInjectors підключає лише ті джерела, які є в конфігурації.
+// Крок на відсутнє джерело завершує прогін помилкою ще до подачі подій.
+func buildInjectors(cfg serviceConfig, runtime *dataruntime.Runtime) map[eventscenario.Target]eventscenario.Injector {
+	injectors := make(map[eventscenario.Target]eventscenario.Injector, 3)
+	if runtime.FirebirdEnabled {
+		if admin, ok := backend.AsAdminProvider(runtime.Provider); ok {
+			injectors[eventscenario.TargetBridge] = eventscenario.BridgeInjector{Emulator: admin}
+		}
+	}
+	if url := strings.TrimSpace(cfg.CASLFixtureURL); url != "" {
+		injectors[eventscenario.TargetCASL] = eventscenario.CASLFixtureInjector{
+			BaseURL: url,
+			PultID:  strings.TrimSpace(cfg.CASLFixturePult),
+		}
+	}
+	if strings.TrimSpace(cfg.PhoenixControl.Addr) != "" && strings.TrimSpace(cfg.PhoenixControl.Template) != "" {
+		injectors[eventscenario.TargetPhoenix] = eventscenario.PhoenixControlInjector{
+			Addr:       cfg.PhoenixControl.Addr,
+			Template:   cfg.PhoenixControl.Template,
+			AckTimeout: cfg.phoenixAckTimeout(),
+		}
+	}
+	return injectors
+}
+
+func printReport(out io.Writer, report eventscenario.Report) {
+	status := "PASS"
+	if !report.Passed() {
+		status = "FAIL"
+	}
+	fmt.Fprintf(out, "%s %s (%s)\n", status, report.Scenario, report.Finished.Sub(report.Started).Round(time.Millisecond))
+	for i, step := range report.Steps {
+		if step.Error != nil {
+			fmt.Fprintf(out, "  step %d (%s): %v\n", i+1, step.Step.Target, step.Error)
+		}
+	}
+	for _, result := range report.Expectations {
+		mark := "ok  "
+		if !result.Passed {
+			mark = "miss"
+		}
+		fmt.Fprintf(out, "  %s %s: matched %d\n", mark, result.Expectation, result.Matched)
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func resolveServiceConfig(opts runtimeOptions) (serviceConfig, error) {
+	if strings.TrimSpace(opts.ConfigPath) == "" {
+		return serviceConfig{}, errors.New("config path is empty")
+	}
+	cfg, err := loadServiceConfig(opts.ConfigPath)
+	if err != nil {
+		return serviceConfig{}, err
+	}
+	if opts.visitedFlags["verify-db"] {
+		cfg.VerifyDB = opts.VerifyDB
+	}
+	return cfg, nil
+}
+
+func writeExampleConfig(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config %q already exists", path)
+	}
+	return writeServiceConfig(path, defaultServiceConfig())
+}
+
+func visitedFlags() map[string]bool {
+	visited := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		visited[f.Name] = true
+	})
+	return visited
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/backend"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
+	"obj_catalog_fyne_v3/pkg/logger"
+	"obj_catalog_fyne_v3/pkg/objectmigrate"
+	"obj_catalog_fyne_v3/pkg/version"
+)
+
+// errMigrationIncomplete — хоча б один об'єкт має конфлікт або не перенесений; код виходу 1.
+var errMigrationIncomplete = errors.New("migration incomplete")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s -from SOURCE -to SOURCE [flags] NUMBER[=TARGET]...\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "sources: bridge, phoenix, casl; without -apply only the plan is printed")
+		flag.PrintDefaults()
+	}
+	configPath := flag.String("config", "object-migrate.json", "JSON config path")
+	initConfig := flag.Bool("init", false, "write an example config if it does not exist and exit")
+	verifyDB := flag.Bool("verify-db", true, "ping configured databases on startup")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	from := flag.String("from", "", "source to copy objects from: bridge, phoenix or casl")
+	to := flag.String("to", "", "source to copy objects to: bridge, phoenix or casl")
+	all := flag.Bool("all", false, "migrate every object of the source")
+	apply := flag.Bool("apply", false, "create target objects (default is a dry-run plan)")
+	decommission := flag.Bool("decommission", false, "delete source objects after the target passed verification")
+	force := flag.Bool("force", false, "with -decommission, also delete sources whose fields the target cannot store")
+	reportPath := flag.String("report", "", "write results as JSON to this path")
+	flag.Parse()
+	visited := visitedFlags()
+
+	ver := version.Current()
+	if *showVersion {
+		fmt.Println(ver.FullText())
+		return
+	}
+
+	logConfig := logger.DefaultConfig()
+	logConfig.LogDir = "log/object-migrate"
+	if err := logger.Setup(logConfig); err != nil {
+		fmt.Printf("Помилка налаштування логера: %v\n", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Interface("panic", r).
+				Str("stack", string(debug.Stack())).
+				Msg("object-migrate: panic")
+			os.Exit(2)
+		}
+	}()
+
+	if *initConfig {
+		if err := writeExampleConfig(strings.TrimSpace(*configPath)); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts := runtimeOptions{
+		ConfigPath:   strings.TrimSpace(*configPath),
+		VerifyDB:     *verifyDB,
+		From:         *from,
+		To:           *to,
+		All:          *all,
+		Objects:      flag.Args(),
+		Apply:        *apply,
+		Decommission: *decommission,
+		Force:        *force,
+		ReportPath:   strings.TrimSpace(*reportPath),
+		visitedFlags: visited,
+	}
+	if err := run(opts, os.Stdout); err != nil {
+		if !errors.Is(err, errMigrationIncomplete) {
+			fmt.Println(err)
+			log.Error().Err(err).Msg("object-migrate stopped with error")
+		}
+		os.Exit(1)
+	}
+}
+
+type runtimeOptions struct {
+	ConfigPath   string
+	VerifyDB     bool
+	From         string
+	To           string
+	All          bool
+	Objects      []string
+	Apply        bool
+	Decommission bool
+	Force        bool
+	ReportPath   string
+	visitedFlags map[string]bool
+}
+
+func run(opts runtimeOptions, out io.Writer) error {
+	from, err := parseSource(opts.From)
+	if err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	to, err := parseSource(opts.To)
+	if err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+	items, err := parseItems(opts.Objects)
+	if err != nil {
+		return err
+	}
+	if opts.All == (len(items) > 0) {
+		return errors.New("give either object numbers or -all")
+	}
+
+	cfg, err := resolveServiceConfig(opts)
+	if err != nil {
+		return err
+	}
+	dbCfg := cfg.dbConfig()
+	dbCfg.LogLevel = logger.SetLogLevel(dbCfg.LogLevel)
+	runtime, err := dataruntime.New(dbCfg, nil, cfg.VerifyDB)
+	if err != nil {
+		return err
+	}
+	defer runtime.Close()
+
+	source, err := buildEndpoint(cfg, runtime, from)
+	if err != nil {
+		return err
+	}
+	target, err := buildEndpoint(cfg, runtime, to)
+	if err != nil {
+		return err
+	}
+	migrator, err := objectmigrate.New(runtime.Provider, source, target, objectmigrate.Options{
+		DryRun:       !opts.Apply,
+		Decommission: opts.Decommission,
+		Force:        opts.Force,
+	})
+	if err != nil {
+		return err
+	}
+	if opts.All {
+		items = migrator.AllItems()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	results := migrator.Run(ctx, items)
+	if !opts.Apply {
+		fmt.Fprintf(out, "dry run: %s -> %s, nothing was changed\n", from, to)
+	}
+	if err := objectmigrate.WriteReport(out, results); err != nil {
+		return err
+	}
+	if opts.ReportPath != "" {
+		if err := writeJSONReport(opts.ReportPath, results); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if incomplete(results) {
+		return errMigrationIncomplete
+	}
+	return nil
+}
+
+// buildEndpoint підключає адмін-контракт потрібного джерела.
+func buildEndpoint(cfg serviceConfig, runtime *dataruntime.Runtime, source contracts.FrontendSource) (objectmigrate.Endpoint, error) {
+	switch source {
+	case contracts.FrontendSourceBridge:
+		if admin, ok := backend.AsAdminProvider(runtime.Provider); ok && runtime.FirebirdEnabled {
+			return objectmigrate.NewBridgeEndpoint(admin, cfg.Bridge.toDefaults()), nil
+		}
+	case contracts.FrontendSourcePhoenix:
+		if admin, ok := backend.AsPhoenixObjectAdmin(runtime.Provider); ok && runtime.PhoenixEnabled {
+			return objectmigrate.NewPhoenixEndpoint(admin), nil
+		}
+	case contracts.FrontendSourceCASL:
+		if editor, ok := runtime.Provider.(contracts.CASLObjectEditorProvider); ok && runtime.CASLEnabled {
+			return objectmigrate.NewCASLEndpoint(editor, cfg.CASL.toDefaults()), nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not enabled in the config", source)
+}
+
+func parseSource(raw string) (contracts.FrontendSource, error) {
+	switch source := contracts.FrontendSource(strings.ToLower(strings.TrimSpace(raw))); source {
+	case contracts.FrontendSourceBridge, contracts.FrontendSourcePhoenix, contracts.FrontendSourceCASL:
+		return source, nil
+	case "mist":
+		return contracts.FrontendSourceBridge, nil
+	default:
+		return "", fmt.Errorf("unknown source %q", raw)
+	}
+}
+
+// parseItems приймає номери як окремі аргументи або через кому; NUMBER=TARGET задає номер у цільовому джерелі.
+func parseItems(args []string) ([]objectmigrate.Item, error) {
+	var items []objectmigrate.Item
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			number, target, _ := strings.Cut(part, "=")
+			number, target = strings.TrimSpace(number), strings.TrimSpace(target)
+			if number == "" {
+				return nil, fmt.Errorf("invalid object %q", part)
+			}
+			items = append(items, objectmigrate.Item{Number: number, TargetNumber: target})
+		}
+	}
+	return items, nil
+}
+
+func incomplete(results []objectmigrate.Result) bool {
+	for _, result := range results {
+		switch result.Status {
+		case objectmigrate.StatusConflict, objectmigrate.StatusVerifyFailed, objectmigrate.StatusFailed:
+			return true
+		}
+		if result.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSONReport(path string, results []objectmigrate.Result) error {
+	body, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	body = append(body, '\n')
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return fmt.Errorf("write report %q: %w", path, err)
+	}
+	return nil
+}
+
+func resolveServiceConfig(opts runtimeOptions) (serviceConfig, error) {
+	if strings.TrimSpace(opts.ConfigPath) == "" {
+		return serviceConfig{}, errors.New("config path is empty")
+	}
+	cfg, err := loadServiceConfig(opts.ConfigPath)
+	if err != nil {
+		return serviceConfig{}, err
+	}
+	if opts.visitedFlags["verify-db"] {
+		cfg.VerifyDB = opts.VerifyDB
+	}
+	return cfg, nil
+}
+
+func writeExampleConfig(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config %q already exists", path)
+	}
+	return writeServiceConfig(path, defaultServiceConfig())
+}
+
+func visitedFlags() map[string]bool {
+	visited := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		visited[f.Name] = true
+	})
+	return visited
+}
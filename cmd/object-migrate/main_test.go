@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/objectmigrate"
+)
+
+func TestParseItems(t *testing.T) {
+	t.Parallel()
+
+	items, err := parseItems([]string{"1234, L00001=100001", "55=", " ,"})
+	if err != nil {
+		t.Fatalf("parseItems: %v", err)
+	}
+	want := []objectmigrate.Item{
+		{Number: "1234"},
+		{Number: "L00001", TargetNumber: "100001"},
+		{Number: "55"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("items = %+v, want %+v", items, want)
+	}
+	if _, err := parseItems([]string{"=100"}); err == nil {
+		t.Fatalf("parseItems accepted an empty source number")
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	t.Parallel()
+
+	for raw, want := range map[string]contracts.FrontendSource{
+		"bridge":  contracts.FrontendSourceBridge,
+		"MIST":    contracts.FrontendSourceBridge,
+		"phoenix": contracts.FrontendSourcePhoenix,
+		" casl ":  contracts.FrontendSourceCASL,
+	} {
+		got, err := parseSource(raw)
+		if err != nil || got != want {
+			t.Fatalf("parseSource(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	if _, err := parseSource("unknown"); err == nil {
+		t.Fatalf("parseSource accepted an unknown source")
+	}
+}
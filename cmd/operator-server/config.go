@@ -0,0 +1,342 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/adminhttp"
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+type serviceConfig struct {
+	Listen             string                 `json:"listen"`
+	AccessToken        string                 `json:"access_token"`
+	MaxClients         int                    `json:"max_clients"`
+	MaxInFlight        int                    `json:"max_in_flight_per_client"`
+	SessionIdleTimeout string                 `json:"session_idle_timeout"`
+	ShutdownTimeout    string                 `json:"shutdown_timeout"`
+	VerifyDB           bool                   `json:"verify_db"`
+	AdminTokens        map[string]string      `json:"admin_tokens"`
+	SavedViewsPath     string                 `json:"saved_views_path"`
+	ObjectGroupsPath   string                 `json:"object_groups_path"`
+	Maintenance        serviceMaintenance     `json:"maintenance"`
+	TestSupervision    serviceTestSupervision `json:"test_supervision"`
+	OpenClose          serviceOpenClose       `json:"open_close"`
+	AlarmPriority      serviceAlarmPriority   `json:"alarm_priority"`
+	Database           serviceDatabaseConfig  `json:"database"`
+}
+
+// serviceMaintenance вмикає вікна обслуговування; порожній windows_path — вимкнено.
+// Шлях варто вказувати на той самий файл, що й у робочих місць операторів.
+type serviceMaintenance struct {
+	WindowsPath   string `json:"windows_path"`
+	ReminderHours int    `json:"reminder_hours"`
+}
+
+// serviceTestSupervision вмикає локальний контроль пропущених тестів по джерелах;
+// типово вимкнено для всіх джерел.
+type serviceTestSupervision struct {
+	MIST    serviceTestSupervisionSource `json:"mist"`
+	Phoenix serviceTestSupervisionSource `json:"phoenix"`
+	CASL    serviceTestSupervisionSource `json:"casl"`
+}
+
+// serviceOpenClose вмикає контроль розкладу відкриття/закриття; порожній
+// schedules_path — вимкнено. escalate_minutes = 0 вимикає ескалацію.
+type serviceOpenClose struct {
+	SchedulesPath   string `json:"schedules_path"`
+	EscalateMinutes int    `json:"escalate_minutes"`
+}
+
+// serviceAlarmPriority налаштовує оцінку пріоритету тривог; типово увімкнена
+// з типовими вагами. Ваги типів тривог доповнюють типові, незадані числа
+// лишаються типовими.
+type serviceAlarmPriority struct {
+	Disabled            bool           `json:"disabled"`
+	TypeWeights         map[string]int `json:"type_weights,omitempty"`
+	ObjectTypeWeights   map[string]int `json:"object_type_weights,omitempty"`
+	ContractWeight      *int           `json:"contract_weight,omitempty"`
+	RepeatWeight        *int           `json:"repeat_weight,omitempty"`
+	RepeatMax           *int           `json:"repeat_max,omitempty"`
+	UnattendedPerMinute *int           `json:"unattended_per_minute,omitempty"`
+	UnattendedMax       *int           `json:"unattended_max,omitempty"`
+	DispatchedPenalty   *int           `json:"dispatched_penalty,omitempty"`
+}
+
+type serviceTestSupervisionSource struct {
+	Enabled      bool `json:"enabled"`
+	GraceMinutes int  `json:"grace_minutes"`
+}
+
+type serviceDatabaseConfig struct {
+	User            string `json:"user"`
+	Password        string `json:"password"`
+	Host            string `json:"host"`
+	Port            string `json:"port"`
+	Path            string `json:"path"`
+	Params          string `json:"params"`
+	FirebirdEnabled bool   `json:"firebird_enabled"`
+	PhoenixEnabled  bool   `json:"phoenix_enabled"`
+	PhoenixUser     string `json:"phoenix_user"`
+	PhoenixPassword string `json:"phoenix_password"`
+	PhoenixHost     string `json:"phoenix_host"`
+	PhoenixPort     string `json:"phoenix_port"`
+	PhoenixInstance string `json:"phoenix_instance"`
+	PhoenixDatabase string `json:"phoenix_database"`
+	PhoenixParams   string `json:"phoenix_params"`
+	CASLEnabled     bool   `json:"casl_enabled"`
+	Mode            string `json:"mode"`
+	CASLBaseURL     string `json:"casl_base_url"`
+	CASLToken       string `json:"casl_token"`
+	CASLEmail       string `json:"casl_email"`
+	CASLPass        string `json:"casl_password"`
+	CASLPultID      int64  `json:"casl_pult_id"`
+	LogLevel        string `json:"log_level"`
+}
+
+func defaultServiceConfig() serviceConfig {
+	return serviceConfig{
+		Listen:             "0.0.0.0:8090",
+		MaxClients:         32,
+		MaxInFlight:        8,
+		SessionIdleTimeout: "30m",
+		ShutdownTimeout:    "15s",
+		VerifyDB:           true,
+		AdminTokens:        map[string]string{},
+		Database: serviceDatabaseConfig{
+			User:            "SYSDBA",
+			Password:        "masterkey",
+			Host:            "localhost",
+			Port:            "3050",
+			Path:            "C:/MOST.PM/BASE/MOST5.FDB",
+			Params:          "charset=WIN1251&auth_plugin_name=Srp",
+			FirebirdEnabled: true,
+			PhoenixEnabled:  false,
+			PhoenixUser:     "sa",
+			PhoenixHost:     "localhost",
+			PhoenixInstance: "PHOENIX4",
+			PhoenixDatabase: "Pult4DB",
+			PhoenixParams:   "encrypt=disable&trustservercertificate=true",
+			Mode:            config.BackendModeFirebird,
+			CASLBaseURL:     "http://127.0.0.1:50003",
+			LogLevel:        "info",
+		},
+	}
+}
+
+func loadServiceConfig(path string) (serviceConfig, error) {
+	cfg := defaultServiceConfig()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return serviceConfig{}, fmt.Errorf("read service config %q: %w", path, err)
+	}
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		return serviceConfig{}, fmt.Errorf("decode service config %q: %w", path, err)
+	}
+	cfg.applyDefaults()
+	if _, err := cfg.adminTokens(); err != nil {
+		return serviceConfig{}, err
+	}
+	if _, err := cfg.AlarmPriority.config(); err != nil {
+		return serviceConfig{}, err
+	}
+	return cfg, nil
+}
+
+func writeServiceConfig(path string, cfg serviceConfig) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("service config path is empty")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create config directory %q: %w", dir, err)
+		}
+	}
+	body, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode service config: %w", err)
+	}
+	body = append(body, '\n')
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return fmt.Errorf("write service config %q: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *serviceConfig) applyDefaults() {
+	defaults := defaultServiceConfig()
+	if strings.TrimSpace(cfg.Listen) == "" {
+		cfg.Listen = defaults.Listen
+	}
+	if cfg.MaxClients <= 0 {
+		cfg.MaxClients = defaults.MaxClients
+	}
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = defaults.MaxInFlight
+	}
+	if strings.TrimSpace(cfg.SessionIdleTimeout) == "" {
+		cfg.SessionIdleTimeout = defaults.SessionIdleTimeout
+	}
+	if strings.TrimSpace(cfg.ShutdownTimeout) == "" {
+		cfg.ShutdownTimeout = defaults.ShutdownTimeout
+	}
+	cfg.Database.applyDefaults()
+}
+
+func (cfg serviceMaintenance) reminderPeriod() time.Duration {
+	return time.Duration(cfg.ReminderHours) * time.Hour
+}
+
+func (cfg serviceOpenClose) escalateAfter() time.Duration {
+	return time.Duration(max(cfg.EscalateMinutes, 0)) * time.Minute
+}
+
+func (cfg serviceAlarmPriority) config() (config.AlarmPriorityConfig, error) {
+	result := config.DefaultAlarmPriorityConfig()
+	result.Enabled = !cfg.Disabled
+	maps.Copy(result.TypeWeights, cfg.TypeWeights)
+	for key, weight := range cfg.ObjectTypeWeights {
+		id, err := strconv.ParseInt(strings.TrimSpace(key), 10, 64)
+		if err != nil || id <= 0 {
+			return config.AlarmPriorityConfig{}, fmt.Errorf("alarm_priority.object_type_weights: invalid OBJTYPEID %q", key)
+		}
+		result.ObjectTypeWeights[id] = weight
+	}
+	override := func(target *int, value *int) {
+		if value != nil && *value >= 0 {
+			*target = *value
+		}
+	}
+	override(&result.ContractWeight, cfg.ContractWeight)
+	override(&result.RepeatWeight, cfg.RepeatWeight)
+	override(&result.RepeatMax, cfg.RepeatMax)
+	override(&result.UnattendedPerMinute, cfg.UnattendedPerMinute)
+	override(&result.UnattendedMax, cfg.UnattendedMax)
+	override(&result.DispatchedPenalty, cfg.DispatchedPenalty)
+	return result, nil
+}
+
+func (cfg serviceTestSupervision) config() config.TestSupervisionConfig {
+	source := func(value serviceTestSupervisionSource) config.TestSupervisionSourceConfig {
+		return config.TestSupervisionSourceConfig{Enabled: value.Enabled, GraceMinutes: max(value.GraceMinutes, 0)}
+	}
+	return config.TestSupervisionConfig{
+		MIST:    source(cfg.MIST),
+		Phoenix: source(cfg.Phoenix),
+		CASL:    source(cfg.CASL),
+	}
+}
+
+func (cfg *serviceDatabaseConfig) applyDefaults() {
+	defaults := defaultServiceConfig().Database
+	if strings.TrimSpace(cfg.User) == "" {
+		cfg.User = defaults.User
+	}
+	if strings.TrimSpace(cfg.Host) == "" {
+		cfg.Host = defaults.Host
+	}
+	if strings.TrimSpace(cfg.Port) == "" {
+		cfg.Port = defaults.Port
+	}
+	if strings.TrimSpace(cfg.Path) == "" {
+		cfg.Path = defaults.Path
+	}
+	if strings.TrimSpace(cfg.Params) == "" {
+		cfg.Params = defaults.Params
+	}
+	if strings.TrimSpace(cfg.PhoenixUser) == "" {
+		cfg.PhoenixUser = defaults.PhoenixUser
+	}
+	if strings.TrimSpace(cfg.PhoenixHost) == "" {
+		cfg.PhoenixHost = defaults.PhoenixHost
+	}
+	if strings.TrimSpace(cfg.PhoenixInstance) == "" {
+		cfg.PhoenixInstance = defaults.PhoenixInstance
+	}
+	if strings.TrimSpace(cfg.PhoenixDatabase) == "" {
+		cfg.PhoenixDatabase = defaults.PhoenixDatabase
+	}
+	if strings.TrimSpace(cfg.PhoenixParams) == "" {
+		cfg.PhoenixParams = defaults.PhoenixParams
+	}
+	if strings.TrimSpace(cfg.Mode) == "" {
+		cfg.Mode = defaults.Mode
+	}
+	if strings.TrimSpace(cfg.CASLBaseURL) == "" {
+		cfg.CASLBaseURL = defaults.CASLBaseURL
+	}
+	if strings.TrimSpace(cfg.LogLevel) == "" {
+		cfg.LogLevel = defaults.LogLevel
+	}
+}
+
+func (cfg serviceConfig) sessionIdleTimeout() time.Duration {
+	return parseDurationOr(cfg.SessionIdleTimeout, 30*time.Minute)
+}
+
+func (cfg serviceConfig) shutdownTimeout() time.Duration {
+	return parseDurationOr(cfg.ShutdownTimeout, 15*time.Second)
+}
+
+func parseDurationOr(raw string, fallback time.Duration) time.Duration {
+	parsed, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || parsed <= 0 {
+		return fallback
+	}
+	return parsed
+}
+
+// adminTokens перетворює admin_tokens (токен → роль) на налаштування adminhttp.
+// Порожня мапа вимикає адмін-API.
+func (cfg serviceConfig) adminTokens() (map[string]adminhttp.Role, error) {
+	result := make(map[string]adminhttp.Role, len(cfg.AdminTokens))
+	for token, rawRole := range cfg.AdminTokens {
+		role, ok := adminhttp.ParseRole(rawRole)
+		if !ok {
+			return nil, fmt.Errorf("admin_tokens: unknown role %q", rawRole)
+		}
+		if token = strings.TrimSpace(token); token != "" {
+			result[token] = role
+		}
+	}
+	return result, nil
+}
+
+func (cfg serviceConfig) dbConfig() config.DBConfig {
+	return cfg.Database.toDBConfig()
+}
+
+func (cfg serviceDatabaseConfig) toDBConfig() config.DBConfig {
+	return config.DBConfig{
+		User:            cfg.User,
+		Password:        cfg.Password,
+		Host:            cfg.Host,
+		Port:            cfg.Port,
+		Path:            cfg.Path,
+		Params:          cfg.Params,
+		FirebirdEnabled: cfg.FirebirdEnabled,
+		PhoenixEnabled:  cfg.PhoenixEnabled,
+		PhoenixUser:     cfg.PhoenixUser,
+		PhoenixPassword: cfg.PhoenixPassword,
+		PhoenixHost:     cfg.PhoenixHost,
+		PhoenixPort:     cfg.PhoenixPort,
+		PhoenixInstance: cfg.PhoenixInstance,
+		PhoenixDatabase: cfg.PhoenixDatabase,
+		PhoenixParams:   cfg.PhoenixParams,
+		CASLEnabled:     cfg.CASLEnabled,
+		Mode:            cfg.Mode,
+		CASLBaseURL:     cfg.CASLBaseURL,
+		CASLToken:       cfg.CASLToken,
+		CASLEmail:       cfg.CASLEmail,
+		CASLPass:        cfg.CASLPass,
+		CASLPultID:      cfg.CASLPultID,
+		LogLevel:        cfg.LogLevel,
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/adminhttp"
+	"obj_catalog_fyne_v3/pkg/alarmpriority"
+	"obj_catalog_fyne_v3/pkg/backend"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/data"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
+	"obj_catalog_fyne_v3/pkg/logger"
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/operatorserver"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
+	"obj_catalog_fyne_v3/pkg/version"
+	"obj_catalog_fyne_v3/pkg/webfrontend"
+)
+
+func main() {
+	configPath := flag.String("config", "operator-server.json", "JSON config path")
+	listen := flag.String("listen", "", "override listen address from config")
+	initConfig := flag.Bool("init", false, "write an example config if it does not exist and exit")
+	verifyDB := flag.Bool("verify-db", true, "ping configured databases on startup")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+	visited := visitedFlags()
+
+	ver := version.Current()
+	if *showVersion {
+		fmt.Println(ver.FullText())
+		return
+	}
+
+	logConfig := logger.DefaultConfig()
+	logConfig.LogDir = "log/operator-server"
+	if err := logger.Setup(logConfig); err != nil {
+		fmt.Printf("Помилка налаштування логера: %v\n", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Interface("panic", r).
+				Str("stack", string(debug.Stack())).
+				Msg("operator-server: panic")
+			os.Exit(2)
+		}
+	}()
+
+	if *initConfig {
+		if err := writeExampleConfig(strings.TrimSpace(*configPath)); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts := runtimeOptions{
+		ConfigPath:   strings.TrimSpace(*configPath),
+		Listen:       strings.TrimSpace(*listen),
+		VerifyDB:     *verifyDB,
+		visitedFlags: visited,
+	}
+	if err := run(opts); err != nil && !errors.Is(err, context.Canceled) {
+		log.Error().Err(err).Msg("operator-server stopped with error")
+		os.Exit(1)
+	}
+	log.Info().Msg("operator-server stopped")
+}
+
+type runtimeOptions struct {
+	ConfigPath   string
+	Listen       string
+	VerifyDB     bool
+	visitedFlags map[string]bool
+}
+
+func run(opts runtimeOptions) error {
+	cfg, err := resolveServiceConfig(opts)
+	if err != nil {
+		return err
+	}
+	adminTokens, err := cfg.adminTokens()
+	if err != nil {
+		return err
+	}
+
+	source, err := buildSource(cfg)
+	if err != nil {
+		return err
+	}
+	frontend := operatorserver.NewReloadableBackend(source)
+	defer frontend.Close()
+	// Без saved_views_path маршрут /api/v1/saved-views відповідає 501.
+	if path := strings.TrimSpace(cfg.SavedViewsPath); path != "" {
+		frontend.SetSavedViews(operatorserver.NewSavedViewsFile(path))
+	}
+	// Без object_groups_path маршрут /api/v1/object-groups і параметр group відповідають 501.
+	if path := strings.TrimSpace(cfg.ObjectGroupsPath); path != "" {
+		store, err := objectgroups.OpenFileStore(path)
+		if err != nil {
+			return fmt.Errorf("object groups: %w", err)
+		}
+		// Групи-вирази обчислюються за списком, який сервер уже завантажив для
+		// клієнтів: окреме повне читання джерел щоразу було б зайвим навантаженням.
+		registry := objectgroups.NewRegistry(store, func() []models.Object {
+			current, release, err := frontend.Provider()
+			if err != nil {
+				return nil
+			}
+			defer release()
+			provider, ok := current.(contracts.LoadedObjectsProvider)
+			if !ok {
+				return nil
+			}
+			return provider.LoadedObjects()
+		})
+		frontend.SetObjectGroups(objectgroups.NewFrontendBackend(registry))
+	}
+
+	var adminHandler http.Handler
+	if len(adminTokens) > 0 {
+		adminHandler = adminhttp.NewHandler(
+			adminhttp.AdminProviderSource(frontend.Provider),
+			adminhttp.WithTokens(adminTokens),
+		)
+	}
+	site, err := webfrontend.NewSiteHandlerWithAdmin(frontend, nil, nil, nil, adminHandler)
+	if err != nil {
+		return fmt.Errorf("web frontend: %w", err)
+	}
+	var reloadMu sync.Mutex
+	reload := func() error {
+		reloadMu.Lock()
+		defer reloadMu.Unlock()
+		return reloadSource(opts, frontend)
+	}
+	sessions := operatorserver.NewSessions(
+		operatorserver.WithMaxClients(cfg.MaxClients),
+		operatorserver.WithMaxInFlight(cfg.MaxInFlight),
+		operatorserver.WithIdleTimeout(cfg.sessionIdleTimeout()),
+		operatorserver.WithAccessToken(cfg.AccessToken),
+		operatorserver.WithReload(reload, adminhttp.TokenAuthenticator(adminTokens)),
+	)
+	server := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           sessions.Middleware(site),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	log.Info().
+		Str("config", opts.ConfigPath).
+		Str("listen", cfg.Listen).
+		Int("maxClients", cfg.MaxClients).
+		Int("maxInFlight", cfg.MaxInFlight).
+		Bool("accessToken", strings.TrimSpace(cfg.AccessToken) != "").
+		Bool("adminAPI", adminHandler != nil).
+		Str("savedViews", cfg.SavedViewsPath).
+		Str("objectGroups", cfg.ObjectGroupsPath).
+		Msg("operator-server started")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case err := <-serveErr:
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return err
+		case <-hangup:
+			go func() { _ = reload() }()
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout())
+			err := server.Shutdown(shutdownCtx)
+			cancel()
+			if err != nil {
+				log.Warn().Err(err).Msg("operator-server: graceful shutdown timed out")
+				_ = server.Close()
+			}
+			return nil
+		}
+	}
+}
+
+// reloadSource перечитує конфіг і перебудовує підключення до джерел без
+// розриву HTTP-сесій. Адреса, ліміти й токени застосовуються лише після перезапуску.
+// Викликається за SIGHUP або через operatorserver.ReloadPath.
+func reloadSource(opts runtimeOptions, frontend *operatorserver.ReloadableBackend) error {
+	cfg, err := resolveServiceConfig(opts)
+	if err != nil {
+		log.Error().Err(err).Msg("operator-server: reload failed, keeping current sources")
+		return err
+	}
+	source, err := buildSource(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("operator-server: reload failed, keeping current sources")
+		return err
+	}
+	frontend.Replace(source)
+	log.Info().Msg("operator-server: sources reloaded")
+	return nil
+}
+
+func buildSource(cfg serviceConfig) (operatorserver.Source, error) {
+	dbCfg := cfg.dbConfig()
+	dbCfg.LogLevel = logger.SetLogLevel(dbCfg.LogLevel)
+
+	runtime, err := dataruntime.New(dbCfg, nil, cfg.VerifyDB)
+	if err != nil {
+		return operatorserver.Source{}, err
+	}
+	if path := strings.TrimSpace(cfg.Maintenance.WindowsPath); path != "" {
+		if combined, ok := runtime.Provider.(*data.CombinedDataProvider); ok {
+			suppressor, err := maintenance.Open(path, cfg.Maintenance.reminderPeriod())
+			if err != nil {
+				runtime.Close()
+				return operatorserver.Source{}, err
+			}
+			combined.SetMaintenanceSuppressor(suppressor)
+		}
+	}
+	if settings := testsupervision.SettingsFromConfig(cfg.TestSupervision.config()); settings.Enabled() {
+		if combined, ok := runtime.Provider.(*data.CombinedDataProvider); ok {
+			combined.SetTestSupervisor(testsupervision.NewSupervisor(settings))
+		}
+	}
+	if path := strings.TrimSpace(cfg.OpenClose.SchedulesPath); path != "" {
+		if combined, ok := runtime.Provider.(*data.CombinedDataProvider); ok {
+			supervisor, err := openclose.Open(path, cfg.OpenClose.escalateAfter())
+			if err != nil {
+				runtime.Close()
+				return operatorserver.Source{}, err
+			}
+			combined.SetOpenCloseSupervisor(supervisor)
+		}
+	}
+	priorityCfg, err := cfg.AlarmPriority.config()
+	if err != nil {
+		runtime.Close()
+		return operatorserver.Source{}, err
+	}
+	if priorityCfg.Enabled {
+		if combined, ok := runtime.Provider.(*data.CombinedDataProvider); ok {
+			combined.SetAlarmPriorityModel(alarmpriority.NewModel(priorityCfg))
+		}
+	}
+	log.Info().
+		Bool("firebirdEnabled", runtime.FirebirdEnabled).
+		Bool("phoenixEnabled", runtime.PhoenixEnabled).
+		Bool("caslEnabled", runtime.CASLEnabled).
+		Msg("operator-server: data sources ready")
+	return operatorserver.Source{
+		Frontend: backend.NewFrontendAdapter(runtime.Provider),
+		Provider: runtime.Provider,
+		Close:    runtime.Close,
+	}, nil
+}
+
+func resolveServiceConfig(opts runtimeOptions) (serviceConfig, error) {
+	if strings.TrimSpace(opts.ConfigPath) == "" {
+		return serviceConfig{}, errors.New("config path is empty")
+	}
+	cfg, err := loadServiceConfig(opts.ConfigPath)
+	if err != nil {
+		return serviceConfig{}, err
+	}
+	if opts.visitedFlags["listen"] && opts.Listen != "" {
+		cfg.Listen = opts.Listen
+	}
+	if opts.visitedFlags["verify-db"] {
+		cfg.VerifyDB = opts.VerifyDB
+	}
+	return cfg, nil
+}
+
+func writeExampleConfig(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config %q already exists", path)
+	}
+	return writeServiceConfig(path, defaultServiceConfig())
+}
+
+func visitedFlags() map[string]bool {
+	visited := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		visited[f.Name] = true
+	})
+	return visited
+}
@@ -14,6 +14,7 @@ import (
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/data"
 	"obj_catalog_fyne_v3/pkg/database"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
 	"obj_catalog_fyne_v3/pkg/ids"
 
 	fyneapp "fyne.io/fyne/v2/app"
@@ -30,7 +31,25 @@ type managedDBResource struct {
 }
 
 func bootstrapFrontendBackend() (contracts.FrontendBackend, func(), error) {
-	cfg := loadRuntimeDBConfig()
+	return buildFrontendBackend(loadRuntimeDBConfig())
+}
+
+// buildFrontendBackend підключає оболонку або до cmd/operator-server,
+// або напряму до джерел даних з конфігу.
+func buildFrontendBackend(cfg config.DBConfig) (contracts.FrontendBackend, func(), error) {
+	if cfg.UsesOperatorServer() {
+		remote, err := backend.NewRemoteFrontendBackend(
+			cfg.OperatorServerURL,
+			backend.WithRemoteFrontendToken(cfg.OperatorServerToken),
+			backend.WithRemoteFrontendClientID(operatorClientID()),
+		)
+		if err != nil {
+			return nil, func() {}, err
+		}
+		log.Info().Str("url", cfg.OperatorServerURL).Msg("Operator Wails: using remote operator server")
+		return remote, func() {}, nil
+	}
+
 	provider, resources, err := buildDataProviderFromEnvConfig(cfg)
 	if err != nil {
 		return nil, func() {}, err
@@ -199,9 +218,33 @@ func applyEnvOverrides(cfg *config.DBConfig) {
 	if value, ok := envInt64Override("MOST_CASL_PULT_ID", cfg.CASLPultID); ok {
 		cfg.CASLPultID = value
 	}
+	if value, ok := lookupEnvTrimmed("MOST_CASL_EXTRA_PULT_IDS"); ok {
+		cfg.CASLExtraPultIDs = config.ParseCASLPultIDs(value)
+	}
+	if value, ok := lookupEnvTrimmed("MOST_SOURCE_INSTANCES"); ok {
+		if instances, err := config.ParseSourceInstances(value); err != nil {
+			log.Warn().Err(err).Str("name", "MOST_SOURCE_INSTANCES").Msg("Invalid source instances, keeping config value")
+		} else {
+			cfg.SourceInstances = instances
+		}
+	}
 	if value, ok := lookupEnvTrimmed("MOST_LOG_LEVEL"); ok {
 		cfg.LogLevel = value
 	}
+	if value, ok := lookupEnvTrimmed("MOST_OPERATOR_SERVER_URL"); ok {
+		cfg.OperatorServerURL = value
+	}
+	if value, ok := lookupEnvTrimmed("MOST_OPERATOR_SERVER_TOKEN"); ok {
+		cfg.OperatorServerToken = value
+	}
+}
+
+func operatorClientID() string {
+	host, err := os.Hostname()
+	if err != nil || strings.TrimSpace(host) == "" {
+		return "operator-wails"
+	}
+	return "operator-wails@" + host
 }
 
 func envBoolOverride(name string, fallback bool) (bool, bool) {
@@ -286,18 +329,15 @@ func buildDataProviderFromEnvConfig(cfg config.DBConfig) (contracts.DataProvider
 	}
 
 	if caslEnabled {
-		casl := backend.NewCASLCloudProvider(
-			cfg.CASLBaseURL,
-			cfg.CASLToken,
-			cfg.CASLPultID,
-			cfg.CASLEmail,
-			cfg.CASLPass,
-		)
-		sources = append(sources, data.ProviderSource{
-			Name:         "casl",
-			Provider:     casl,
-			OwnsObjectID: ids.IsCASLObjectID,
-			OwnsAlarmID:  ids.IsCASLObjectID,
+		sources = append(sources, data.CASLProviderSources(cfg)...)
+	}
+
+	sources, instanceDBs := dataruntime.AppendSourceInstances(sources, cfg, false)
+	for _, instanceDB := range instanceDBs {
+		managed = append(managed, managedDBResource{
+			label:        instanceDB.Label,
+			db:           instanceDB.DB,
+			healthCancel: instanceDB.HealthCancel,
 		})
 	}
 
@@ -347,29 +387,38 @@ func closeManagedDBResources(resources []managedDBResource) {
 
 func loadEnvDBConfig() config.DBConfig {
 	cfg := config.DBConfig{
-		User:            envString("MOST_DB_USER", "SYSDBA"),
-		Password:        envString("MOST_DB_PASSWORD", "masterkey"),
-		Host:            envString("MOST_DB_HOST", "localhost"),
-		Port:            envString("MOST_DB_PORT", "3050"),
-		Path:            envString("MOST_DB_PATH", "C:/MOST.PM/BASE/MOST5.FDB"),
-		Params:          envString("MOST_DB_PARAMS", "charset=WIN1251&auth_plugin_name=Srp"),
-		FirebirdEnabled: envBool("MOST_FIREBIRD_ENABLED", true),
-		PhoenixEnabled:  envBool("MOST_PHOENIX_ENABLED", false),
-		PhoenixUser:     envString("MOST_PHOENIX_USER", "sa"),
-		PhoenixPassword: envString("MOST_PHOENIX_PASSWORD", ""),
-		PhoenixHost:     envString("MOST_PHOENIX_HOST", "localhost"),
-		PhoenixPort:     envString("MOST_PHOENIX_PORT", ""),
-		PhoenixInstance: envString("MOST_PHOENIX_INSTANCE", "PHOENIX4"),
-		PhoenixDatabase: envString("MOST_PHOENIX_DATABASE", "Pult4DB"),
-		PhoenixParams:   envString("MOST_PHOENIX_PARAMS", "encrypt=disable&trustservercertificate=true"),
-		CASLEnabled:     envBool("MOST_CASL_ENABLED", false),
-		Mode:            envString("MOST_BACKEND_MODE", config.BackendModeFirebird),
-		CASLBaseURL:     envString("MOST_CASL_BASE_URL", "http://127.0.0.1:50003"),
-		CASLToken:       envString("MOST_CASL_TOKEN", ""),
-		CASLEmail:       envString("MOST_CASL_EMAIL", ""),
-		CASLPass:        envString("MOST_CASL_PASSWORD", ""),
-		CASLPultID:      envInt64("MOST_CASL_PULT_ID", 0),
-		LogLevel:        envString("MOST_LOG_LEVEL", "info"),
+		User:             envString("MOST_DB_USER", "SYSDBA"),
+		Password:         envString("MOST_DB_PASSWORD", "masterkey"),
+		Host:             envString("MOST_DB_HOST", "localhost"),
+		Port:             envString("MOST_DB_PORT", "3050"),
+		Path:             envString("MOST_DB_PATH", "C:/MOST.PM/BASE/MOST5.FDB"),
+		Params:           envString("MOST_DB_PARAMS", "charset=WIN1251&auth_plugin_name=Srp"),
+		FirebirdEnabled:  envBool("MOST_FIREBIRD_ENABLED", true),
+		PhoenixEnabled:   envBool("MOST_PHOENIX_ENABLED", false),
+		PhoenixUser:      envString("MOST_PHOENIX_USER", "sa"),
+		PhoenixPassword:  envString("MOST_PHOENIX_PASSWORD", ""),
+		PhoenixHost:      envString("MOST_PHOENIX_HOST", "localhost"),
+		PhoenixPort:      envString("MOST_PHOENIX_PORT", ""),
+		PhoenixInstance:  envString("MOST_PHOENIX_INSTANCE", "PHOENIX4"),
+		PhoenixDatabase:  envString("MOST_PHOENIX_DATABASE", "Pult4DB"),
+		PhoenixParams:    envString("MOST_PHOENIX_PARAMS", "encrypt=disable&trustservercertificate=true"),
+		CASLEnabled:      envBool("MOST_CASL_ENABLED", false),
+		Mode:             envString("MOST_BACKEND_MODE", config.BackendModeFirebird),
+		CASLBaseURL:      envString("MOST_CASL_BASE_URL", "http://127.0.0.1:50003"),
+		CASLToken:        envString("MOST_CASL_TOKEN", ""),
+		CASLEmail:        envString("MOST_CASL_EMAIL", ""),
+		CASLPass:         envString("MOST_CASL_PASSWORD", ""),
+		CASLPultID:       envInt64("MOST_CASL_PULT_ID", 0),
+		CASLExtraPultIDs: config.ParseCASLPultIDs(envString("MOST_CASL_EXTRA_PULT_IDS", "")),
+		LogLevel:         envString("MOST_LOG_LEVEL", "info"),
+
+		OperatorServerURL:   envString("MOST_OPERATOR_SERVER_URL", ""),
+		OperatorServerToken: envString("MOST_OPERATOR_SERVER_TOKEN", ""),
+	}
+	if instances, err := config.ParseSourceInstances(envString("MOST_SOURCE_INSTANCES", "")); err != nil {
+		log.Warn().Err(err).Str("name", "MOST_SOURCE_INSTANCES").Msg("Invalid source instances, ignoring")
+	} else {
+		cfg.SourceInstances = instances
 	}
 
 	log.Info().
@@ -6,7 +6,6 @@ import (
 	"sync"
 
 	"obj_catalog_fyne_v3/pkg/ami"
-	"obj_catalog_fyne_v3/pkg/backend"
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/wailsbridge"
@@ -49,18 +48,10 @@ func (c *operatorRuntimeController) replaceBackend(frontendBackend contracts.Fro
 }
 
 func (c *operatorRuntimeController) reloadWithConfig(cfg config.DBConfig) error {
-	provider, resources, err := buildDataProviderFromEnvConfig(cfg)
+	frontendBackend, cleanup, err := buildFrontendBackend(cfg)
 	if err != nil {
 		return err
 	}
-
-	frontendBackend := backend.NewFrontendAdapter(provider)
-	cleanup := func() {
-		closeManagedDBResources(resources)
-		if shutdowner, ok := provider.(contracts.ShutdownProvider); ok {
-			shutdowner.Shutdown()
-		}
-	}
 	c.replaceBackend(frontendBackend, cleanup)
 	return nil
 }
@@ -101,12 +92,15 @@ type OperatorDBSettings struct {
 	PhoenixOperatorPassword string `json:"PhoenixOperatorPassword"`
 	PhoenixClientRole       string `json:"PhoenixClientRole"`
 
-	CASLEnabled bool   `json:"CASLEnabled"`
-	CASLBaseURL string `json:"CASLBaseURL"`
-	CASLToken   string `json:"CASLToken"`
-	CASLEmail   string `json:"CASLEmail"`
-	CASLPass    string `json:"CASLPass"`
-	CASLPultID  int64  `json:"CASLPultID"`
+	CASLEnabled      bool   `json:"CASLEnabled"`
+	CASLBaseURL      string `json:"CASLBaseURL"`
+	CASLToken        string `json:"CASLToken"`
+	CASLEmail        string `json:"CASLEmail"`
+	CASLPass         string `json:"CASLPass"`
+	CASLPultID       int64  `json:"CASLPultID"`
+	CASLExtraPultIDs string `json:"CASLExtraPultIDs"`
+
+	SourceInstances string `json:"SourceInstances"`
 
 	Mode string `json:"Mode"`
 
@@ -146,7 +140,12 @@ func (s *OperatorSettingsService) SaveDBSettings(input OperatorDBSettings) error
 		return err
 	}
 
+	instances, err := config.ParseSourceInstances(input.SourceInstances)
+	if err != nil {
+		return err
+	}
 	cfg := mapConfigFromOperatorDBSettings(input)
+	cfg.SourceInstances = instances
 	if !cfg.FirebirdEnabled && !cfg.PhoenixEnabled && !cfg.CASLEnabled {
 		cfg.FirebirdEnabled = true
 	}
@@ -203,6 +202,10 @@ func mapOperatorDBSettings(cfg config.DBConfig) OperatorDBSettings {
 		CASLPass:    cfg.CASLPass,
 		CASLPultID:  cfg.CASLPultID,
 
+		CASLExtraPultIDs: config.FormatCASLPultIDs(cfg.CASLExtraPultIDs),
+
+		SourceInstances: config.FormatSourceInstances(cfg.SourceInstances),
+
 		Mode: cfg.NormalizedMode(),
 	}
 }
@@ -238,6 +241,8 @@ func mapConfigFromOperatorDBSettings(input OperatorDBSettings) config.DBConfig {
 		CASLPass:    strings.TrimSpace(input.CASLPass),
 		CASLPultID:  input.CASLPultID,
 
+		CASLExtraPultIDs: config.ParseCASLPultIDs(input.CASLExtraPultIDs),
+
 		Mode: strings.TrimSpace(input.Mode),
 	}
 
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
+	"obj_catalog_fyne_v3/pkg/logger"
+	"obj_catalog_fyne_v3/pkg/omnicell"
+	"obj_catalog_fyne_v3/pkg/reportscheduler"
+	"obj_catalog_fyne_v3/pkg/reportupload"
+	"obj_catalog_fyne_v3/pkg/version"
+)
+
+func main() {
+	configPath := flag.String("config", "report-scheduler.json", "JSON config path")
+	outputDir := flag.String("output", "reports", "local directory for generated reports")
+	historyPath := flag.String("history", "log/report-scheduler-history.json", "JSON run history path")
+	runJob := flag.String("run", "", "run the named job once and exit")
+	runAll := flag.Bool("run-all", false, "run every configured job once and exit")
+	initConfig := flag.Bool("init", false, "write an example config if it does not exist and exit")
+	verifyDB := flag.Bool("verify-db", true, "ping configured databases on startup")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+	visited := visitedFlags()
+
+	ver := version.Current()
+	if *showVersion {
+		fmt.Println(ver.FullText())
+		return
+	}
+
+	logConfig := logger.DefaultConfig()
+	logConfig.LogDir = "log/report-scheduler"
+	if err := logger.Setup(logConfig); err != nil {
+		fmt.Printf("Помилка налаштування логера: %v\n", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Interface("panic", r).
+				Str("stack", string(debug.Stack())).
+				Msg("report-scheduler: panic")
+			os.Exit(2)
+		}
+	}()
+
+	if *initConfig {
+		if err := writeExampleConfig(strings.TrimSpace(*configPath)); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts := runtimeOptions{
+		ConfigPath:   strings.TrimSpace(*configPath),
+		OutputDir:    strings.TrimSpace(*outputDir),
+		HistoryPath:  strings.TrimSpace(*historyPath),
+		RunJob:       strings.TrimSpace(*runJob),
+		RunAll:       *runAll,
+		VerifyDB:     *verifyDB,
+		visitedFlags: visited,
+	}
+	if err := run(opts); err != nil && !errors.Is(err, context.Canceled) {
+		log.Error().Err(err).Msg("report-scheduler stopped with error")
+		os.Exit(1)
+	}
+	log.Info().Msg("report-scheduler stopped")
+}
+
+type runtimeOptions struct {
+	ConfigPath   string
+	OutputDir    string
+	HistoryPath  string
+	RunJob       string
+	RunAll       bool
+	VerifyDB     bool
+	visitedFlags map[string]bool
+}
+
+func run(opts runtimeOptions) error {
+	dbCfg, store, runCfg, err := resolveRuntimeConfig(opts)
+	if err != nil {
+		return err
+	}
+	dbCfg.LogLevel = logger.SetLogLevel(dbCfg.LogLevel)
+
+	upload, err := reportupload.New(runCfg.Upload.toConfig())
+	if err != nil {
+		return fmt.Errorf("report upload target: %w", err)
+	}
+	notifiers, err := buildNotifiers(runCfg)
+	if err != nil {
+		return err
+	}
+
+	runtime, err := dataruntime.New(dbCfg, store, runCfg.VerifyDB)
+	if err != nil {
+		return err
+	}
+	defer runtime.Close()
+
+	runner, err := reportscheduler.NewRunner(runtime.Provider, reportscheduler.Options{
+		Jobs:         runCfg.jobs(),
+		OutputDir:    runCfg.OutputDir,
+		HistoryPath:  runCfg.HistoryPath,
+		HistoryLimit: runCfg.HistoryLimit,
+		Upload:       upload,
+		Notifiers:    notifiers,
+		CASLEnabled:  runtime.CASLEnabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	uploadName := "none"
+	if upload != nil {
+		uploadName = upload.Name()
+	}
+	log.Info().
+		Str("config", opts.ConfigPath).
+		Str("output", runCfg.OutputDir).
+		Str("history", runCfg.HistoryPath).
+		Str("upload", uploadName).
+		Int("jobs", len(runCfg.Jobs)).
+		Int("notifiers", len(notifiers)).
+		Bool("firebirdEnabled", runtime.FirebirdEnabled).
+		Bool("phoenixEnabled", runtime.PhoenixEnabled).
+		Bool("caslEnabled", runtime.CASLEnabled).
+		Msg("report-scheduler started")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch {
+	case opts.RunJob != "":
+		_, err := runner.RunJob(ctx, opts.RunJob)
+		return err
+	case opts.RunAll:
+		return runner.RunAll(ctx)
+	default:
+		return runner.Run(ctx)
+	}
+}
+
+func buildNotifiers(cfg serviceConfig) ([]reportscheduler.Notifier, error) {
+	notifiers := make([]reportscheduler.Notifier, 0, 2)
+	if cfg.Email.enabled() {
+		email, err := reportscheduler.NewEmailNotifier(cfg.Email.toConfig())
+		if err != nil {
+			return nil, fmt.Errorf("e-mail notifications: %w", err)
+		}
+		notifiers = append(notifiers, email)
+	}
+	if cfg.SMS.enabled() {
+		sms, err := reportscheduler.NewSMSNotifier(omnicell.NewClient(cfg.SMS.toConfig()), cfg.SMS.Phones)
+		if err != nil {
+			return nil, fmt.Errorf("SMS notifications: %w", err)
+		}
+		notifiers = append(notifiers, sms)
+	}
+	return notifiers, nil
+}
+
+func resolveRuntimeConfig(opts runtimeOptions) (config.DBConfig, dataruntime.ConfigStore, serviceConfig, error) {
+	if strings.TrimSpace(opts.ConfigPath) == "" {
+		return config.DBConfig{}, nil, serviceConfig{}, errors.New("config path is empty")
+	}
+	cfg, err := loadServiceConfig(opts.ConfigPath)
+	if err != nil {
+		return config.DBConfig{}, nil, serviceConfig{}, err
+	}
+	applyRuntimeFlagOverrides(&cfg, opts)
+	return cfg.dbConfig(), newFileConfigStore(opts.ConfigPath, cfg), cfg, nil
+}
+
+func applyRuntimeFlagOverrides(cfg *serviceConfig, opts runtimeOptions) {
+	if cfg == nil {
+		return
+	}
+	if opts.visitedFlags["output"] && strings.TrimSpace(opts.OutputDir) != "" {
+		cfg.OutputDir = strings.TrimSpace(opts.OutputDir)
+	}
+	if opts.visitedFlags["history"] && strings.TrimSpace(opts.HistoryPath) != "" {
+		cfg.HistoryPath = strings.TrimSpace(opts.HistoryPath)
+	}
+	if opts.visitedFlags["verify-db"] {
+		cfg.VerifyDB = opts.VerifyDB
+	}
+	cfg.applyDefaults()
+}
+
+func writeExampleConfig(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config %q already exists", path)
+	}
+	return writeServiceConfig(path, defaultServiceConfig())
+}
+
+func visitedFlags() map[string]bool {
+	visited := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		visited[f.Name] = true
+	})
+	return visited
+}
@@ -615,10 +615,23 @@ export function App() {
       return
     }
 
+    let reason = ''
+    if (activeAlarmRow?.inProgressBy) {
+      const answer = window.prompt(`Тривогу обробляє ${activeAlarmRow.inProgressBy}. Вкажіть причину перехоплення:`)
+      if (answer == null) {
+        return
+      }
+      reason = answer.trim()
+      if (reason === '') {
+        setAlarmWorkflowError('Для перехоплення тривоги потрібно вказати причину.')
+        return
+      }
+    }
+
     setAlarmWorkflowBusy(true)
     setAlarmWorkflowError('')
     try {
-      await api.pickAlarm(alarmID, { user: OPERATOR_NAME })
+      await api.pickAlarm(alarmID, { user: OPERATOR_NAME, reason })
       setLocalPickedAlarmIDs((prev) => new Set([...prev, alarmID]))
       if (activeAlarmRow?.source === 'casl') {
         setAlarmWorkflowError('Команду перехоплення відправлено. Очікуємо оновлення CASL.')
@@ -628,7 +641,7 @@ export function App() {
     } finally {
       setAlarmWorkflowBusy(false)
     }
-  }, [activeAlarmRow?.alarmID, activeAlarmRow?.inProgressByMe, activeAlarmRow?.responseGroupDispatched, activeAlarmRow?.source])
+  }, [activeAlarmRow?.alarmID, activeAlarmRow?.inProgressBy, activeAlarmRow?.inProgressByMe, activeAlarmRow?.responseGroupDispatched, activeAlarmRow?.source])
 
   const handleStandby = useCallback(() => {
     const objectID = activeAlarmRow?.objectID
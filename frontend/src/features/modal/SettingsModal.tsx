@@ -131,6 +131,12 @@ export function SettingsModal({
                   <TextRow label="Email" value={settingsDraft.caslEmail} onChange={(value) => onUpdateDraft({ caslEmail: value })} />
                   <PasswordRow label="Password" value={settingsDraft.caslPass} onChange={(value) => onUpdateDraft({ caslPass: value })} />
                   <NumberRow label="Pult ID" value={settingsDraft.caslPultID} onChange={(value) => onUpdateDraft({ caslPultID: value })} />
+                  <TextRow label="Додаткові пульти" value={settingsDraft.caslExtraPultIDs} onChange={(value) => onUpdateDraft({ caslExtraPultIDs: value })} />
+                </div>
+
+                <div className="isection" style={{ gridColumn: '1 / span 2' }}>
+                  <div className="isect-title">Додаткові джерела (JSON)</div>
+                  <TextAreaRow label="Джерела" value={settingsDraft.sourceInstances} onChange={(value) => onUpdateDraft({ sourceInstances: value })} />
                 </div>
               </div>
             )}
@@ -202,6 +208,15 @@ function TextRow({ label, value, onChange }: { label: string; value: string; onC
   )
 }
 
+function TextAreaRow({ label, value, onChange }: { label: string; value: string; onChange: (value: string) => void }) {
+  return (
+    <div className="irow">
+      <label>{label}</label>
+      <textarea className="note-area" rows={6} value={value} onChange={(event) => onChange(event.target.value)} />
+    </div>
+  )
+}
+
 function PasswordRow({ label, value, onChange }: { label: string; value: string; onChange: (value: string) => void }) {
   return (
     <div className="irow">
@@ -45,6 +45,8 @@ export type JournalRow = {
   responseGroupDispatched: boolean
   responseGroupArrived: boolean
   severity: VisualSeverity
+  priorityScore?: number
+  priorityText?: string
 }
 
 export type TableColumnMeta = {
@@ -59,6 +61,7 @@ export type UnprocessedAlarmGroup = {
   anchorRow: JournalRow
   rows: JournalRow[]
   latestSortTimestampMs: number
+  priorityScore: number
 }
 
 export type UnprocessedRowMeta = {
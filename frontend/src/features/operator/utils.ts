@@ -1,4 +1,11 @@
-import type { FrontendAlarmGroup, FrontendAlarmItem, FrontendEventItem, FrontendObjectSummary, VisualSeverity } from '../../shared/api/types'
+import type {
+  FrontendAlarmGroup,
+  FrontendAlarmItem,
+  FrontendAlarmPriority,
+  FrontendEventItem,
+  FrontendObjectSummary,
+  VisualSeverity,
+} from '../../shared/api/types'
 import { sourceLabel } from '../../shared/ui/source'
 import type { JournalRow, ObjectRow, UnprocessedAlarmGroup, UnprocessedRowMeta } from './types'
 
@@ -101,7 +108,33 @@ export function toAlarmRow(item: FrontendAlarmItem): JournalRow {
     responseGroupDispatched: item.isResponseGroupDispatched,
     responseGroupArrived: item.isResponseGroupArrived,
     severity,
+    priorityScore: item.priority?.score,
+    priorityText: item.priority ? formatAlarmPriority(item.priority) : undefined,
+  }
+}
+
+// formatAlarmPriority повертає розклад оцінки, напр. "185 = тип 100 + об'єкт 20 + повтори 10".
+export function formatAlarmPriority(priority: FrontendAlarmPriority): string {
+  const factors: Array<[string, number]> = [
+    ['тип', priority.type],
+    ["об'єкт", priority.objectType],
+    ['договір', priority.contract],
+    ['повтори', priority.repeats],
+    ['без реакції', priority.unattended],
+    ['МГР', priority.dispatched],
+  ]
+  const parts: string[] = []
+  for (const [label, value] of factors) {
+    if (value === 0) {
+      continue
+    }
+    if (parts.length === 0) {
+      parts.push(`${label} ${value}`)
+    } else {
+      parts.push(`${value < 0 ? '-' : '+'} ${label} ${Math.abs(value)}`)
+    }
   }
+  return parts.length === 0 ? String(priority.score) : `${priority.score} = ${parts.join(' ')}`
 }
 
 export function sliceRecentEvents(items: FrontendEventItem[], limit: number): FrontendEventItem[] {
@@ -156,6 +189,7 @@ export function toUnprocessedAlarmGroup(group: FrontendAlarmGroup): UnprocessedA
     anchorRow,
     rows,
     latestSortTimestampMs: parseDate(group.latestTime).getTime(),
+    priorityScore: Math.max(...group.items.map((item) => item.priority?.score ?? 0), group.primary.priority?.score ?? 0),
   }
 }
 
@@ -216,10 +250,14 @@ export function mergeUnprocessedGroupsByObject(groups: UnprocessedAlarmGroup[]):
       anchorRow: anchor,
       rows: allRows,
       latestSortTimestampMs: Math.max(existing.latestSortTimestampMs, group.latestSortTimestampMs),
+      priorityScore: Math.max(existing.priorityScore, group.priorityScore),
     })
   }
   
   return Array.from(byKey.values()).sort((left, right) => {
+    if (left.priorityScore !== right.priorityScore) {
+      return right.priorityScore - left.priorityScore
+    }
     const prioLeft = getSeverityPriority(left.anchorRow.severity)
     const prioRight = getSeverityPriority(right.anchorRow.severity)
     if (prioLeft !== prioRight) {
@@ -249,6 +249,17 @@ export function BottomEventTables({
         minSize: 90,
         cell: ({ row, getValue }) => <span className={`chip ${resolveJournalStateChipClass(row.original)}`}>{String(getValue())}</span>,
       },
+      {
+        id: 'priority',
+        header: 'Пріор.',
+        size: 56,
+        minSize: 44,
+        cell: ({ row }) => (
+          <span className="mono" title={row.original.priorityText}>
+            {row.original.priorityScore ?? '—'}
+          </span>
+        ),
+      },
       {
         accessorKey: 'details',
         header: 'Опис події',
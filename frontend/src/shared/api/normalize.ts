@@ -3,6 +3,7 @@ import type {
   FrontendCapabilities,
   FrontendAlarmItem,
   FrontendAlarmGroup,
+  FrontendAlarmPriority,
   FrontendAlarmProcessRequest,
   FrontendAlarmPickRequest,
   FrontendAlarmProcessingOption,
@@ -103,6 +104,23 @@ export function normalizeEventItem(input: unknown): FrontendEventItem {
     details: asString(value.details ?? value.Details),
     userName: asString(value.userName ?? value.UserName),
     visualSeverity: asVisualSeverity(value.visualSeverity ?? value.VisualSeverity),
+    priority: normalizeAlarmPriority(value.priority ?? value.Priority),
+  }
+}
+
+export function normalizeAlarmPriority(input: unknown): FrontendAlarmPriority | null {
+  if (input == null || typeof input !== 'object') {
+    return null
+  }
+  const value = asRecord(input)
+  return {
+    score: asNumber(value.score ?? value.Score),
+    type: asNumber(value.type ?? value.Type),
+    objectType: asNumber(value.objectType ?? value.ObjectType),
+    contract: asNumber(value.contract ?? value.Contract),
+    repeats: asNumber(value.repeats ?? value.Repeats),
+    unattended: asNumber(value.unattended ?? value.Unattended),
+    dispatched: asNumber(value.dispatched ?? value.Dispatched),
   }
 }
 
@@ -144,6 +162,7 @@ export function normalizeAlarmPickRequest(input: unknown): FrontendAlarmPickRequ
   const value = asRecord(input)
   return {
     user: asString(value.user ?? value.User),
+    reason: asString(value.reason ?? value.Reason),
   }
 }
 
@@ -203,6 +222,9 @@ export function normalizeDBSettings(input: unknown): FrontendDBSettings {
     caslEmail: asString(value.caslEmail ?? value.CASLEmail),
     caslPass: asString(value.caslPass ?? value.CASLPass),
     caslPultID: asNumber(value.caslPultID ?? value.CASLPultID),
+    caslExtraPultIDs: asString(value.caslExtraPultIDs ?? value.CASLExtraPultIDs),
+
+    sourceInstances: asString(value.sourceInstances ?? value.SourceInstances),
 
     mode: asString(value.mode ?? value.Mode),
 
@@ -112,6 +112,17 @@ export type FrontendAlarmItem = {
   isResponseGroupArrived: boolean
   details: string
   visualSeverity: VisualSeverity
+  priority: FrontendAlarmPriority | null
+}
+
+export type FrontendAlarmPriority = {
+  score: number
+  type: number
+  objectType: number
+  contract: number
+  repeats: number
+  unattended: number
+  dispatched: number
 }
 
 export type FrontendAlarmGroup = {
@@ -141,6 +152,7 @@ export type FrontendAlarmProcessRequest = {
 
 export type FrontendAlarmPickRequest = {
   user: string
+  reason?: string
 }
 
 export type FrontendObjectDetails = {
@@ -208,6 +220,9 @@ export type FrontendDBSettings = {
   caslEmail: string
   caslPass: string
   caslPultID: number
+  caslExtraPultIDs: string
+
+  sourceInstances: string
 
   mode: string
 
@@ -22,6 +22,8 @@ export namespace main {
 	    CASLEmail: string;
 	    CASLPass: string;
 	    CASLPultID: number;
+	    CASLExtraPultIDs: string;
+	    SourceInstances: string;
 	    Mode: string;
 	    AMIEnabled: boolean;
 	    AMIHost: string;
@@ -58,6 +60,8 @@ export namespace main {
 	        this.CASLEmail = source["CASLEmail"];
 	        this.CASLPass = source["CASLPass"];
 	        this.CASLPultID = source["CASLPultID"];
+	        this.CASLExtraPultIDs = source["CASLExtraPultIDs"];
+	        this.SourceInstances = source["SourceInstances"];
 	        this.Mode = source["Mode"];
 	        this.AMIEnabled = source["AMIEnabled"];
 	        this.AMIHost = source["AMIHost"];
@@ -73,6 +77,30 @@ export namespace main {
 
 export namespace v1 {
 	
+	export class AlarmPriority {
+	    Score: number;
+	    Type: number;
+	    ObjectType: number;
+	    Contract: number;
+	    Repeats: number;
+	    Unattended: number;
+	    Dispatched: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AlarmPriority(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Score = source["Score"];
+	        this.Type = source["Type"];
+	        this.ObjectType = source["ObjectType"];
+	        this.Contract = source["Contract"];
+	        this.Repeats = source["Repeats"];
+	        this.Unattended = source["Unattended"];
+	        this.Dispatched = source["Dispatched"];
+	    }
+	}
 	export class AlarmItem {
 	    ID: number;
 	    Source: string;
@@ -99,6 +127,7 @@ export namespace v1 {
 	    IsResponseGroupDispatched: boolean;
 	    IsResponseGroupArrived: boolean;
 	    VisualSeverity: string;
+	    Priority?: AlarmPriority;
 	
 	    static createFrom(source: any = {}) {
 	        return new AlarmItem(source);
@@ -131,7 +160,26 @@ export namespace v1 {
 	        this.IsResponseGroupDispatched = source["IsResponseGroupDispatched"];
 	        this.IsResponseGroupArrived = source["IsResponseGroupArrived"];
 	        this.VisualSeverity = source["VisualSeverity"];
+	        this.Priority = this.convertValues(source["Priority"], AlarmPriority);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class AlarmGroup {
 	    GroupID: string;
@@ -198,6 +246,7 @@ export namespace v1 {
 	
 	export class AlarmPickRequest {
 	    User: string;
+	    Reason?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new AlarmPickRequest(source);
@@ -206,6 +255,7 @@ export namespace v1 {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.User = source["User"];
+	        this.Reason = source["Reason"];
 	    }
 	}
 	export class AlarmProcessRequest {
@@ -0,0 +1,302 @@
+package adminhttp
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	frontendv1 "obj_catalog_fyne_v3/pkg/frontendapi/v1"
+)
+
+const (
+	APIV1BasePath = "/api/admin/v1"
+	OpenAPIPath   = APIV1BasePath + "/openapi.json"
+	contentType   = "application/json; charset=utf-8"
+)
+
+// Role визначає рівень доступу до адмін-API. Вищі ролі включають права нижчих.
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Allows повідомляє, чи роль має права required.
+func (r Role) Allows(required Role) bool {
+	return r.rank() > 0 && r.rank() >= required.rank()
+}
+
+// ParseRole розбирає назву ролі з конфігурації.
+func ParseRole(raw string) (Role, bool) {
+	role := Role(strings.ToLower(strings.TrimSpace(raw)))
+	return role, role.rank() > 0
+}
+
+// Authenticator визначає роль клієнта за запитом. false означає неавтентифікований запит.
+type Authenticator func(r *http.Request) (Role, bool)
+
+type Option func(*Handler)
+
+// WithAuthenticator задає власну перевірку доступу.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(h *Handler) {
+		if h == nil || auth == nil {
+			return
+		}
+		h.auth = auth
+	}
+}
+
+// WithTokens вмикає доступ за токенами: Authorization: Bearer <token> або X-Admin-Token.
+func WithTokens(tokens map[string]Role) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		if auth := TokenAuthenticator(tokens); auth != nil {
+			h.auth = auth
+		}
+	}
+}
+
+// TokenAuthenticator перевіряє токени так само, як WithTokens, для маршрутів
+// поза адмін-API. Без жодного коректного токена повертає nil.
+func TokenAuthenticator(tokens map[string]Role) Authenticator {
+	copied := make(map[string]Role, len(tokens))
+	for token, role := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" || role.rank() == 0 {
+			continue
+		}
+		copied[token] = role
+	}
+	if len(copied) == 0 {
+		return nil
+	}
+	return func(r *http.Request) (Role, bool) {
+		token := requestToken(r)
+		if token == "" {
+			return "", false
+		}
+		for candidate, role := range copied {
+			if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+				return role, true
+			}
+		}
+		return "", false
+	}
+}
+
+func requestToken(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	if header := strings.TrimSpace(r.Header.Get("Authorization")); header != "" {
+		scheme, value, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get("X-Admin-Token"))
+}
+
+// Handler публікує контракти adminapi/v1 як JSON-маршрути /api/admin/v1/*.
+// Без налаштованої автентифікації всі маршрути, крім OpenAPI, відповідають 401.
+// Сайт монтує його лише через webfrontend.NewSiteHandlerWithAdmin.
+type Handler struct {
+	source ProviderSource
+	auth   Authenticator
+	mux    *http.ServeMux
+	routes []route
+}
+
+func NewHandler(source ProviderSource, opts ...Option) *Handler {
+	h := &Handler{source: source, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	h.routes = adminRoutes()
+	for _, item := range h.routes {
+		h.mux.HandleFunc(item.method+" "+APIV1BasePath+item.path, h.wrap(item))
+	}
+	h.mux.HandleFunc("GET "+OpenAPIPath, h.handleOpenAPI)
+	h.mux.HandleFunc(fallbackPattern, h.handleUnmatched)
+	return h
+}
+
+const fallbackPattern = "/"
+
+// handleUnmatched відповідає JSON-помилкою на невідомий маршрут або непідтримуваний метод.
+func (h *Handler) handleUnmatched(w http.ResponseWriter, r *http.Request) {
+	allowed := make([]string, 0, 4)
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		if _, pattern := h.mux.Handler(probe); pattern != "" && pattern != fallbackPattern {
+			allowed = append(allowed, method)
+		}
+	}
+	if len(allowed) == 0 {
+		writeError(w, http.StatusNotFound, "route not found")
+		return
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.mux == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin api is unavailable")
+		return
+	}
+	if r.URL != nil && len(r.URL.Path) > 1 && strings.HasSuffix(r.URL.Path, "/") {
+		r.URL.Path = strings.TrimSuffix(r.URL.Path, "/")
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) wrap(item route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.auth == nil {
+			writeError(w, http.StatusUnauthorized, "admin api authentication is not configured")
+			return
+		}
+		role, ok := h.auth(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if !role.Allows(item.role) {
+			writeError(w, http.StatusForbidden, "role "+string(role)+" cannot access this route")
+			return
+		}
+		if h.source == nil {
+			writeError(w, http.StatusServiceUnavailable, "admin provider is unavailable")
+			return
+		}
+		providers, release, ok := h.source()
+		if !ok {
+			writeError(w, http.StatusServiceUnavailable, "admin provider is unavailable")
+			return
+		}
+		defer release()
+		item.handle(w, r, providers)
+	}
+}
+
+func (h *Handler) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.OpenAPI())
+}
+
+var errProviderUnsupported = errors.New("operation is not supported by the admin provider")
+
+func decodeJSON[T any](w http.ResponseWriter, r *http.Request) (T, bool) {
+	var request T
+	if r.Body == nil {
+		writeError(w, http.StatusBadRequest, "request body is required")
+		return request, false
+	}
+	defer r.Body.Close()
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body")
+		return request, false
+	}
+	if decoder.More() {
+		writeError(w, http.StatusBadRequest, "request body must contain a single json object")
+		return request, false
+	}
+	return request, true
+}
+
+func pathInt64(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	value, err := strconv.ParseInt(strings.TrimSpace(r.PathValue(name)), 10, 64)
+	if err != nil || value <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid "+name)
+		return 0, false
+	}
+	return value, true
+}
+
+func queryInt64(r *http.Request, key string) (*int64, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(key))
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + key)
+	}
+	return &value, nil
+}
+
+func writeProviderError(w http.ResponseWriter, err error) {
+	switch {
+	case err == nil:
+		writeError(w, http.StatusInternalServerError, "unknown admin provider error")
+	case errors.Is(err, errProviderUnsupported):
+		writeError(w, http.StatusNotImplemented, err.Error())
+	default:
+		message := strings.TrimSpace(err.Error())
+		if message == "" {
+			message = "admin request failed"
+		}
+		writeError(w, providerErrorStatus(err), message)
+	}
+}
+
+// providerErrorStatus обирає статус за видом помилки провайдера; решта помилок —
+// збої БД чи зовнішніх сервісів, а не вина клієнта.
+func providerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, contracts.ErrAdminNotFound), errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, contracts.ErrAdminConflict):
+		return http.StatusConflict
+	case errors.Is(err, contracts.ErrAdminValidation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, frontendv1.ErrorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, `{"error":"failed to encode response"}`, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
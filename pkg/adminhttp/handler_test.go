@@ -0,0 +1,234 @@
+package adminhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adminv1 "obj_catalog_fyne_v3/pkg/adminapi/v1"
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+type objectTypesStub struct {
+	items   []adminv1.DictionaryItem
+	added   []string
+	updated map[int64]string
+	err     error
+}
+
+func (s *objectTypesStub) ListObjectTypes() ([]adminv1.DictionaryItem, error) {
+	return s.items, s.err
+}
+
+func (s *objectTypesStub) AddObjectType(name string) error {
+	s.added = append(s.added, name)
+	return s.err
+}
+
+func (s *objectTypesStub) UpdateObjectType(id int64, name string) error {
+	if s.updated == nil {
+		s.updated = map[int64]string{}
+	}
+	s.updated[id] = name
+	return s.err
+}
+
+func (s *objectTypesStub) DeleteObjectType(int64) error {
+	return s.err
+}
+
+var testTokens = map[string]Role{
+	"viewer-token": RoleViewer,
+	"admin-token":  RoleAdmin,
+}
+
+func newTestHandler(providers Providers) *Handler {
+	return NewHandler(StaticProviders(providers), WithTokens(testTokens))
+}
+
+func serve(h http.Handler, method string, path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerRequiresAuthentication(t *testing.T) {
+	stub := &objectTypesStub{}
+	path := APIV1BasePath + "/dictionaries/object-types"
+
+	if rec := serve(NewHandler(StaticProviders(Providers{ObjectTypes: stub})), http.MethodGet, path, "", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status without configured auth = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	handler := newTestHandler(Providers{ObjectTypes: stub})
+	rec := serve(handler, http.MethodGet, path, "wrong-token", "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status with wrong token = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec.Header().Get("WWW-Authenticate") == "" {
+		t.Fatal("WWW-Authenticate header is missing")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("X-Admin-Token", "viewer-token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status with X-Admin-Token = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestHandlerEnforcesRoles(t *testing.T) {
+	stub := &objectTypesStub{}
+	handler := newTestHandler(Providers{ObjectTypes: stub})
+	path := APIV1BasePath + "/dictionaries/object-types"
+
+	rec := serve(handler, http.MethodPost, path, "viewer-token", `{"Name":"Школа"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("viewer POST status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if len(stub.added) != 0 {
+		t.Fatalf("viewer must not reach provider, added = %v", stub.added)
+	}
+
+	rec = serve(handler, http.MethodPost, path, "admin-token", `{"Name":"Школа"}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("admin POST status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+	if len(stub.added) != 1 || stub.added[0] != "Школа" {
+		t.Fatalf("added = %v", stub.added)
+	}
+}
+
+func TestHandlerListsDictionaryItems(t *testing.T) {
+	stub := &objectTypesStub{items: []adminv1.DictionaryItem{{ID: 3, Name: "Банк"}}}
+	rec := serve(newTestHandler(Providers{ObjectTypes: stub}), http.MethodGet, APIV1BasePath+"/dictionaries/object-types/", "viewer-token", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var payload ItemsResponse[adminv1.DictionaryItem]
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(payload.Items) != 1 || payload.Items[0].Name != "Банк" {
+		t.Fatalf("items = %+v", payload.Items)
+	}
+}
+
+func TestHandlerMapsRequestAndProviderErrors(t *testing.T) {
+	stub := &objectTypesStub{}
+	handler := newTestHandler(Providers{ObjectTypes: stub})
+
+	rec := serve(handler, http.MethodPut, APIV1BasePath+"/dictionaries/object-types/abc", "admin-token", `{"Name":"x"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid id status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = serve(handler, http.MethodPut, APIV1BasePath+"/dictionaries/object-types/7", "admin-token", `{"Title":"x"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown field status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("object type 7: %w", contracts.ErrAdminNotFound), http.StatusNotFound},
+		{fmt.Errorf("name is taken: %w", contracts.ErrAdminConflict), http.StatusConflict},
+		{fmt.Errorf("name is empty: %w", contracts.ErrAdminValidation), http.StatusBadRequest},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	} {
+		stub.err = tc.err
+		rec = serve(handler, http.MethodPut, APIV1BasePath+"/dictionaries/object-types/7", "admin-token", `{"Name":"x"}`)
+		if rec.Code != tc.want {
+			t.Fatalf("provider error %q status = %d, want %d", tc.err, rec.Code, tc.want)
+		}
+	}
+	if stub.updated[7] != "x" {
+		t.Fatalf("updated = %v", stub.updated)
+	}
+
+	rec = serve(handler, http.MethodGet, APIV1BasePath+"/dictionaries/regions", "viewer-token", "")
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("missing provider status = %d, want %d", rec.Code, http.StatusNotImplemented)
+	}
+}
+
+func TestHandlerUnavailableProviderSource(t *testing.T) {
+	source := func() (Providers, func(), bool) { return Providers{}, nil, false }
+	handler := NewHandler(source, WithTokens(testTokens))
+
+	rec := serve(handler, http.MethodGet, APIV1BasePath+"/statistics", "viewer-token", "")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestHandlerUnknownRouteAndMethod(t *testing.T) {
+	handler := newTestHandler(Providers{})
+
+	rec := serve(handler, http.MethodGet, APIV1BasePath+"/unknown", "admin-token", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown route status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if !strings.Contains(rec.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("unknown route content type = %q", rec.Header().Get("Content-Type"))
+	}
+
+	rec = serve(handler, http.MethodPatch, APIV1BasePath+"/dictionaries/object-types", "admin-token", "")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("unsupported method status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET, POST" {
+		t.Fatalf("Allow = %q", allow)
+	}
+}
+
+func TestHandlerServesOpenAPIWithoutAuthentication(t *testing.T) {
+	handler := NewHandler(nil)
+	rec := serve(handler, http.MethodGet, OpenAPIPath, "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var document struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &document); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q", document.OpenAPI)
+	}
+	for _, item := range adminRoutes() {
+		operation, ok := document.Paths[APIV1BasePath+item.path][strings.ToLower(item.method)]
+		if !ok {
+			t.Fatalf("operation %s %s is missing in OpenAPI", item.method, item.path)
+		}
+		if operation["x-access-role"] != string(item.role) {
+			t.Fatalf("%s %s x-access-role = %v, want %s", item.method, item.path, operation["x-access-role"], item.role)
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	if role, ok := ParseRole(" Admin "); !ok || role != RoleAdmin {
+		t.Fatalf("ParseRole(Admin) = %q, %v", role, ok)
+	}
+	if _, ok := ParseRole("root"); ok {
+		t.Fatal("ParseRole(root) must fail")
+	}
+	if RoleOperator.Allows(RoleAdmin) || !RoleAdmin.Allows(RoleOperator) {
+		t.Fatal("role hierarchy is broken")
+	}
+}
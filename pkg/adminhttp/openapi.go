@@ -0,0 +1,56 @@
+package adminhttp
+
+import (
+	"net/http"
+
+	"obj_catalog_fyne_v3/pkg/openapi"
+)
+
+// OpenAPI повертає OpenAPI 3.0 опис маршрутів адмін-API.
+// Схеми генеруються з Go-типів запитів і відповідей.
+func (h *Handler) OpenAPI() map[string]any {
+	return openAPIDocument(h.routes)
+}
+
+var adminErrorResponses = map[int]string{
+	http.StatusBadRequest:          "Некоректний запит або дані не пройшли перевірку",
+	http.StatusUnauthorized:        "Потрібна автентифікація",
+	http.StatusForbidden:           "Недостатньо прав",
+	http.StatusNotFound:            "Запис не знайдено",
+	http.StatusConflict:            "Запис конфліктує з наявними даними",
+	http.StatusInternalServerError: "Збій адмін-провайдера",
+	http.StatusNotImplemented:      "Операція не підтримується адмін-провайдером",
+	http.StatusServiceUnavailable:  "Адмін-провайдер недоступний",
+}
+
+func openAPIDocument(routes []route) map[string]any {
+	operations := make([]openapi.Operation, 0, len(routes)+1)
+	for _, item := range routes {
+		operations = append(operations, openapi.Operation{
+			Method:     item.method,
+			Path:       APIV1BasePath + item.path,
+			Tag:        item.tag,
+			Summary:    item.summary,
+			Query:      item.query,
+			Request:    item.request,
+			Response:   item.response,
+			Status:     item.status,
+			Errors:     adminErrorResponses,
+			Extensions: map[string]any{"x-access-role": string(item.role)},
+		})
+	}
+	operations = append(operations, openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        OpenAPIPath,
+		Tag:         "meta",
+		Summary:     "OpenAPI-опис адмін-API",
+		OperationID: "getOpenAPI",
+		Public:      true,
+	})
+
+	return openapi.Document(openapi.Info{
+		Title:       "obj_catalog admin API",
+		Version:     "v1",
+		Description: "Адміністрування об'єктів і довідників МІСТ. Ролі: viewer < operator < admin.",
+	}, operations)
+}
@@ -0,0 +1,76 @@
+package adminhttp
+
+import (
+	adminv1 "obj_catalog_fyne_v3/pkg/adminapi/v1"
+	"obj_catalog_fyne_v3/pkg/backend"
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Providers містить версіоновані адмін-контракти, які публікує HTTP API.
+// Незаповнені поля означають, що відповідні маршрути повертають 501.
+type Providers struct {
+	Statistics      adminv1.StatisticsProvider
+	DisplayBlocking adminv1.DisplayBlockingProvider
+	EventOverride   adminv1.EventOverrideProvider
+	EventEmulation  adminv1.EventEmulationProvider
+	SystemControl   adminv1.SystemControlProvider
+	ObjectTypes     adminv1.ObjectTypesDictionaryProvider
+	Regions         adminv1.RegionsDictionaryProvider
+	AlarmReasons    adminv1.AlarmReasonsDictionaryProvider
+	PPKConstructor  adminv1.PPKConstructorProvider
+	FireMonitoring  adminv1.FireMonitoringProvider
+	ObjectCard      adminv1.ObjectCardProvider
+}
+
+// ProviderSource повертає актуальні провайдери на момент запиту,
+// бо адмін-провайдер з'являється лише після підключення до БД МІСТ.
+// release викликається після завершення запиту, щоб джерело не закрило
+// провайдери, поки ними користуються.
+type ProviderSource func() (providers Providers, release func(), ok bool)
+
+// StaticProviders повертає джерело з незмінним набором провайдерів.
+func StaticProviders(providers Providers) ProviderSource {
+	return func() (Providers, func(), bool) {
+		return providers, func() {}, true
+	}
+}
+
+// ProvidersFromAdmin будує всі v1-провайдери поверх адмін-провайдера БД МІСТ.
+func ProvidersFromAdmin(admin contracts.AdminProvider) Providers {
+	if admin == nil {
+		return Providers{}
+	}
+	return Providers{
+		Statistics:      backend.NewAdminV1StatisticsProvider(admin),
+		DisplayBlocking: backend.NewAdminV1DisplayBlockingProvider(admin),
+		EventOverride:   backend.NewAdminV1EventOverrideProvider(admin),
+		EventEmulation:  backend.NewAdminV1EventEmulationProvider(admin),
+		SystemControl:   backend.NewAdminV1SystemControlProvider(admin),
+		ObjectTypes:     backend.NewAdminV1ObjectTypesDictionaryProvider(admin),
+		Regions:         backend.NewAdminV1RegionsDictionaryProvider(admin),
+		AlarmReasons:    backend.NewAdminV1AlarmReasonsDictionaryProvider(admin),
+		PPKConstructor:  backend.NewAdminV1PPKConstructorProvider(admin),
+		FireMonitoring:  backend.NewAdminV1FireMonitoringProvider(admin),
+		ObjectCard:      backend.NewAdminV1ObjectCardProvider(admin),
+	}
+}
+
+// AdminProviderSource визначає адмін-провайдер із поточного провайдера даних на кожен запит.
+// current повертає провайдер разом із release, як operatorserver.ReloadableBackend.Provider.
+func AdminProviderSource(current func() (contracts.DataProvider, func(), error)) ProviderSource {
+	return func() (Providers, func(), bool) {
+		if current == nil {
+			return Providers{}, nil, false
+		}
+		provider, release, err := current()
+		if err != nil {
+			return Providers{}, nil, false
+		}
+		admin, ok := backend.AsAdminProvider(provider)
+		if !ok {
+			release()
+			return Providers{}, nil, false
+		}
+		return ProvidersFromAdmin(admin), release, true
+	}
+}
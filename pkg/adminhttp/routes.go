@@ -0,0 +1,1080 @@
+package adminhttp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	adminv1 "obj_catalog_fyne_v3/pkg/adminapi/v1"
+	"obj_catalog_fyne_v3/pkg/openapi"
+)
+
+// route описує один маршрут адмін-API. Та сама таблиця використовується
+// для диспетчеризації та для генерації OpenAPI-документа.
+type route struct {
+	method   string
+	path     string
+	role     Role
+	tag      string
+	summary  string
+	query    []openapi.Param
+	request  any
+	response any
+	status   int
+	handle   func(w http.ResponseWriter, r *http.Request, p Providers)
+}
+
+// ItemsResponse — обгортка для списків, як у frontendapi/v1.
+type ItemsResponse[T any] struct {
+	Items []T `json:"items"`
+}
+
+type NameRequest struct {
+	Name string `json:"Name"`
+}
+
+type RegionRequest struct {
+	Name       string `json:"Name"`
+	RegionCode *int64 `json:"RegionCode"`
+}
+
+type MoveRequest struct {
+	Direction int `json:"Direction"`
+}
+
+type DisplayBlockModeRequest struct {
+	Mode adminv1.DisplayBlockMode `json:"Mode"`
+}
+
+type MessageAdminOnlyRequest struct {
+	AdminOnly bool `json:"AdminOnly"`
+}
+
+type MessageCategoryRequest struct {
+	SC1 *int64 `json:"SC1"`
+}
+
+type Message220VModeRequest struct {
+	Mode adminv1.Message220VMode `json:"Mode"`
+}
+
+type EventEmulationRequest struct {
+	ObjN       int64 `json:"ObjN"`
+	Zone       int64 `json:"Zone"`
+	MessageUIN int64 `json:"MessageUIN"`
+}
+
+type PPKConstructorRequest struct {
+	Name      string `json:"Name"`
+	Channel   int64  `json:"Channel"`
+	ZoneCount int64  `json:"ZoneCount"`
+}
+
+type FillZonesRequest struct {
+	Count int64 `json:"Count"`
+}
+
+func items[T any](values []T) ItemsResponse[T] {
+	if values == nil {
+		values = []T{}
+	}
+	return ItemsResponse[T]{Items: values}
+}
+
+func respond(w http.ResponseWriter, status int, payload any, err error) {
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+	if payload == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, status, payload)
+}
+
+func unsupported(w http.ResponseWriter) {
+	writeProviderError(w, errProviderUnsupported)
+}
+
+func adminRoutes() []route {
+	var routes []route
+	routes = append(routes, statisticsRoutes()...)
+	routes = append(routes, dictionaryRoutes()...)
+	routes = append(routes, displayBlockingRoutes()...)
+	routes = append(routes, messageRoutes()...)
+	routes = append(routes, eventEmulationRoutes()...)
+	routes = append(routes, ppkConstructorRoutes()...)
+	routes = append(routes, fireMonitoringRoutes()...)
+	routes = append(routes, systemRoutes()...)
+	routes = append(routes, objectRoutes()...)
+	return routes
+}
+
+func statisticsRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/statistics", role: RoleViewer,
+			tag: "statistics", summary: "Статистика об'єктів",
+			query: []openapi.Param{
+				{Name: "connection", Type: "string", Description: "all | online | offline"},
+				{Name: "protocol", Type: "string", Description: "autodial | most | nova"},
+				{Name: "channel", Type: "integer"},
+				{Name: "guardState", Type: "integer"},
+				{Name: "objType", Type: "integer"},
+				{Name: "region", Type: "integer"},
+				{Name: "blockMode", Type: "string", Description: "none | temporary_off | debug"},
+				{Name: "search", Type: "string"},
+				{Name: "limit", Type: "integer"},
+			},
+			response: ItemsResponse[adminv1.StatisticsRow]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.Statistics == nil {
+					unsupported(w)
+					return
+				}
+				filter, limit, err := statisticsFilterFromQuery(r)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				rows, err := p.Statistics.CollectObjectStatistics(filter, limit)
+				respond(w, http.StatusOK, items(rows), err)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/dictionaries/districts", role: RoleViewer,
+			tag: "dictionaries", summary: "Райони об'єктів",
+			response: ItemsResponse[adminv1.DictionaryItem]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.Statistics == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.Statistics.ListObjectDistricts()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+	}
+}
+
+func statisticsFilterFromQuery(r *http.Request) (adminv1.StatisticsFilter, int, error) {
+	query := r.URL.Query()
+	filter := adminv1.StatisticsFilter{
+		ConnectionMode: adminv1.StatisticsConnectionMode(strings.TrimSpace(query.Get("connection"))),
+		ProtocolFilter: adminv1.StatisticsProtocolFilter(strings.TrimSpace(query.Get("protocol"))),
+		Search:         strings.TrimSpace(query.Get("search")),
+	}
+	if filter.ConnectionMode == "" {
+		filter.ConnectionMode = adminv1.StatisticsConnectionModeAll
+	}
+	var err error
+	for key, target := range map[string]**int64{
+		"channel":    &filter.ChannelCode,
+		"guardState": &filter.GuardState,
+		"objType":    &filter.ObjTypeID,
+		"region":     &filter.RegionID,
+	} {
+		if *target, err = queryInt64(r, key); err != nil {
+			return filter, 0, err
+		}
+	}
+	if raw := strings.TrimSpace(query.Get("blockMode")); raw != "" {
+		mode := adminv1.DisplayBlockMode(raw)
+		filter.BlockMode = &mode
+	}
+	limit, err := queryInt64(r, "limit")
+	if err != nil {
+		return filter, 0, err
+	}
+	if limit == nil {
+		return filter, 0, nil
+	}
+	return filter, int(*limit), nil
+}
+
+func dictionaryRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/dictionaries/object-types", role: RoleViewer,
+			tag: "dictionaries", summary: "Типи об'єктів",
+			response: ItemsResponse[adminv1.DictionaryItem]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.ObjectTypes == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.ObjectTypes.ListObjectTypes()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/dictionaries/object-types", role: RoleAdmin,
+			tag: "dictionaries", summary: "Додати тип об'єкта",
+			request: NameRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectTypes == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[NameRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectTypes.AddObjectType(request.Name))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/dictionaries/object-types/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Перейменувати тип об'єкта",
+			request: NameRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectTypes == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[NameRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectTypes.UpdateObjectType(id, request.Name))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/dictionaries/object-types/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Видалити тип об'єкта", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectTypes == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectTypes.DeleteObjectType(id))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/dictionaries/regions", role: RoleViewer,
+			tag: "dictionaries", summary: "Регіони",
+			response: ItemsResponse[adminv1.DictionaryItem]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.Regions == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.Regions.ListRegions()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/dictionaries/regions", role: RoleAdmin,
+			tag: "dictionaries", summary: "Додати регіон",
+			request: RegionRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.Regions == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[RegionRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.Regions.AddRegion(request.Name, request.RegionCode))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/dictionaries/regions/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Змінити регіон",
+			request: RegionRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.Regions == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[RegionRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.Regions.UpdateRegion(id, request.Name, request.RegionCode))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/dictionaries/regions/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Видалити регіон", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.Regions == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.Regions.DeleteRegion(id))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/dictionaries/alarm-reasons", role: RoleViewer,
+			tag: "dictionaries", summary: "Причини тривог",
+			response: ItemsResponse[adminv1.DictionaryItem]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.AlarmReasons == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.AlarmReasons.ListAlarmReasons()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/dictionaries/alarm-reasons", role: RoleAdmin,
+			tag: "dictionaries", summary: "Додати причину тривоги",
+			request: NameRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.AlarmReasons == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[NameRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.AlarmReasons.AddAlarmReason(request.Name))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/dictionaries/alarm-reasons/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Змінити причину тривоги",
+			request: NameRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.AlarmReasons == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[NameRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.AlarmReasons.UpdateAlarmReason(id, request.Name))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/dictionaries/alarm-reasons/{id}", role: RoleAdmin,
+			tag: "dictionaries", summary: "Видалити причину тривоги", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.AlarmReasons == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.AlarmReasons.DeleteAlarmReason(id))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/dictionaries/alarm-reasons/{id}/move", role: RoleAdmin,
+			tag: "dictionaries", summary: "Перемістити причину тривоги вгору (-1) або вниз (1)",
+			request: MoveRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.AlarmReasons == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[MoveRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.AlarmReasons.MoveAlarmReason(id, request.Direction))
+			},
+		},
+	}
+}
+
+func displayBlockingRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/display-blocking", role: RoleViewer,
+			tag: "display-blocking", summary: "Об'єкти з режимом блокування відображення",
+			query:    []openapi.Param{{Name: "filter", Type: "string"}},
+			response: ItemsResponse[adminv1.DisplayBlockObject]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.DisplayBlocking == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.DisplayBlocking.ListDisplayBlockObjects(strings.TrimSpace(r.URL.Query().Get("filter")))
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPut, path: "/display-blocking/{objn}", role: RoleAdmin,
+			tag: "display-blocking", summary: "Змінити режим блокування відображення",
+			request: DisplayBlockModeRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.DisplayBlocking == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[DisplayBlockModeRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.DisplayBlocking.SetDisplayBlockMode(objn, request.Mode))
+			},
+		},
+	}
+}
+
+func messageRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/messages/protocols", role: RoleViewer,
+			tag: "messages", summary: "Протоколи повідомлень",
+			response: ItemsResponse[int64]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.EventOverride.ListMessageProtocols()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/messages", role: RoleViewer,
+			tag: "messages", summary: "Довідник повідомлень",
+			query: []openapi.Param{
+				{Name: "protocol", Type: "integer"},
+				{Name: "filter", Type: "string"},
+			},
+			response: ItemsResponse[adminv1.Message]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				protocolID, err := queryInt64(r, "protocol")
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				values, err := p.EventOverride.ListMessages(protocolID, strings.TrimSpace(r.URL.Query().Get("filter")))
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPut, path: "/messages/{uin}/admin-only", role: RoleAdmin,
+			tag: "messages", summary: "Показувати повідомлення лише адміністратору",
+			request: MessageAdminOnlyRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				uin, ok := pathInt64(w, r, "uin")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[MessageAdminOnlyRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.EventOverride.SetMessageAdminOnly(uin, request.AdminOnly))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/messages/{uin}/category", role: RoleAdmin,
+			tag: "messages", summary: "Перевизначити категорію (SC1) повідомлення",
+			request: MessageCategoryRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				uin, ok := pathInt64(w, r, "uin")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[MessageCategoryRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.EventOverride.SetMessageCategory(uin, request.SC1))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/messages/220v", role: RoleViewer,
+			tag: "messages", summary: "Повідомлення 220В за групами",
+			query: []openapi.Param{
+				{Name: "protocol", Type: "integer", Repeated: true},
+				{Name: "filter", Type: "string"},
+			},
+			response: adminv1.Message220VBuckets{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				var protocolIDs []int64
+				for _, raw := range r.URL.Query()["protocol"] {
+					value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+					if err != nil {
+						writeError(w, http.StatusBadRequest, "invalid protocol")
+						return
+					}
+					protocolIDs = append(protocolIDs, value)
+				}
+				buckets, err := p.EventOverride.List220VMessageBuckets(protocolIDs, strings.TrimSpace(r.URL.Query().Get("filter")))
+				respond(w, http.StatusOK, buckets, err)
+			},
+		},
+		{
+			method: http.MethodPut, path: "/messages/{uin}/220v", role: RoleAdmin,
+			tag: "messages", summary: "Віднести повідомлення до тривоги/відновлення 220В",
+			request: Message220VModeRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventOverride == nil {
+					unsupported(w)
+					return
+				}
+				uin, ok := pathInt64(w, r, "uin")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[Message220VModeRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.EventOverride.SetMessage220VMode(uin, request.Mode))
+			},
+		},
+	}
+}
+
+func eventEmulationRoutes() []route {
+	return []route{
+		{
+			method: http.MethodPost, path: "/event-emulation", role: RoleOperator,
+			tag: "event-emulation", summary: "Емулювати подію об'єкта",
+			request: EventEmulationRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.EventEmulation == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[EventEmulationRequest](w, r)
+				if !ok {
+					return
+				}
+				if request.ObjN <= 0 || request.MessageUIN <= 0 {
+					writeError(w, http.StatusBadRequest, "ObjN and MessageUIN are required")
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.EventEmulation.EmulateEvent(request.ObjN, request.Zone, request.MessageUIN))
+			},
+		},
+	}
+}
+
+func ppkConstructorRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/ppk-constructor", role: RoleViewer,
+			tag: "ppk-constructor", summary: "Конструктор ППК",
+			response: ItemsResponse[adminv1.PPKConstructorItem]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.PPKConstructor == nil {
+					unsupported(w)
+					return
+				}
+				values, err := p.PPKConstructor.ListPPKConstructor()
+				respond(w, http.StatusOK, items(values), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/ppk-constructor", role: RoleAdmin,
+			tag: "ppk-constructor", summary: "Додати ППК",
+			request: PPKConstructorRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.PPKConstructor == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[PPKConstructorRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.PPKConstructor.AddPPKConstructor(request.Name, request.Channel, request.ZoneCount))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/ppk-constructor/{id}", role: RoleAdmin,
+			tag: "ppk-constructor", summary: "Змінити ППК",
+			request: PPKConstructorRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.PPKConstructor == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[PPKConstructorRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.PPKConstructor.UpdatePPKConstructor(id, request.Name, request.Channel, request.ZoneCount))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/ppk-constructor/{id}", role: RoleAdmin,
+			tag: "ppk-constructor", summary: "Видалити ППК", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.PPKConstructor == nil {
+					unsupported(w)
+					return
+				}
+				id, ok := pathInt64(w, r, "id")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.PPKConstructor.DeletePPKConstructor(id))
+			},
+		},
+	}
+}
+
+func fireMonitoringRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/fire-monitoring", role: RoleViewer,
+			tag: "fire-monitoring", summary: "Налаштування пожежного моніторингу",
+			response: adminv1.FireMonitoringSettings{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.FireMonitoring == nil {
+					unsupported(w)
+					return
+				}
+				settings, err := p.FireMonitoring.GetFireMonitoringSettings()
+				respond(w, http.StatusOK, settings, err)
+			},
+		},
+		{
+			method: http.MethodPut, path: "/fire-monitoring", role: RoleAdmin,
+			tag: "fire-monitoring", summary: "Зберегти налаштування пожежного моніторингу",
+			request: adminv1.FireMonitoringSettings{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.FireMonitoring == nil {
+					unsupported(w)
+					return
+				}
+				request, ok := decodeJSON[adminv1.FireMonitoringSettings](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.FireMonitoring.SaveFireMonitoringSettings(request))
+			},
+		},
+	}
+}
+
+func systemRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/system/access-status", role: RoleViewer,
+			tag: "system", summary: "Права поточного користувача БД",
+			response: adminv1.AccessStatus{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, _ *http.Request, p Providers) {
+				if p.SystemControl == nil {
+					unsupported(w)
+					return
+				}
+				status, err := p.SystemControl.GetAdminAccessStatus()
+				respond(w, http.StatusOK, status, err)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/system/integrity-checks", role: RoleAdmin,
+			tag: "system", summary: "Перевірки цілісності даних",
+			query:    []openapi.Param{{Name: "limit", Type: "integer"}},
+			response: ItemsResponse[adminv1.DataCheckIssue]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.SystemControl == nil {
+					unsupported(w)
+					return
+				}
+				limit, err := queryInt64(r, "limit")
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				maxIssues := 500
+				if limit != nil && *limit > 0 {
+					maxIssues = int(*limit)
+				}
+				issues, err := p.SystemControl.RunDataIntegrityChecks(maxIssues)
+				respond(w, http.StatusOK, items(issues), err)
+			},
+		},
+	}
+}
+
+func objectRoutes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/objects/{objn}", role: RoleViewer,
+			tag: "objects", summary: "Картка об'єкта",
+			response: adminv1.ObjectCard{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				card, err := p.ObjectCard.GetObjectCard(objn)
+				respond(w, http.StatusOK, card, err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/objects", role: RoleAdmin,
+			tag: "objects", summary: "Створити об'єкт",
+			request: adminv1.ObjectCard{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				card, ok := decodeJSON[adminv1.ObjectCard](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.CreateObject(card))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/objects/{objn}", role: RoleAdmin,
+			tag: "objects", summary: "Оновити об'єкт",
+			request: adminv1.ObjectCard{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				card, ok := decodeJSON[adminv1.ObjectCard](w, r)
+				if !ok {
+					return
+				}
+				card.ObjN = objn
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.UpdateObject(card))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/objects/{objn}", role: RoleAdmin,
+			tag: "objects", summary: "Видалити об'єкт", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.DeleteObject(objn))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/sim-usages", role: RoleViewer,
+			tag: "objects", summary: "Об'єкти, що використовують SIM-номер",
+			query: []openapi.Param{
+				{Name: "phone", Type: "string"},
+				{Name: "exclude", Type: "integer", Description: "номер об'єкта, який не враховувати"},
+			},
+			response: ItemsResponse[adminv1.SIMPhoneUsage]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				phone := strings.TrimSpace(r.URL.Query().Get("phone"))
+				if phone == "" {
+					writeError(w, http.StatusBadRequest, "phone is required")
+					return
+				}
+				exclude, err := queryInt64(r, "exclude")
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				usages, err := p.ObjectCard.FindObjectsBySIMPhone(phone, exclude)
+				respond(w, http.StatusOK, items(usages), err)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/objects/{objn}/zones", role: RoleViewer,
+			tag: "objects", summary: "Зони об'єкта",
+			response: ItemsResponse[adminv1.ObjectZone]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				zones, err := p.ObjectCard.ListObjectZones(objn)
+				respond(w, http.StatusOK, items(zones), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/objects/{objn}/zones", role: RoleAdmin,
+			tag: "objects", summary: "Додати зону",
+			request: adminv1.ObjectZone{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				zone, ok := decodeJSON[adminv1.ObjectZone](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.AddObjectZone(objn, zone))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/objects/{objn}/zones", role: RoleAdmin,
+			tag: "objects", summary: "Очистити всі зони об'єкта", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.ClearObjectZones(objn))
+			},
+		},
+		{
+			method: http.MethodPost, path: "/objects/{objn}/zones/fill", role: RoleAdmin,
+			tag: "objects", summary: "Заповнити зони 1..Count",
+			request: FillZonesRequest{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				request, ok := decodeJSON[FillZonesRequest](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.FillObjectZones(objn, request.Count))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/objects/{objn}/zones/{zoneID}", role: RoleAdmin,
+			tag: "objects", summary: "Змінити зону",
+			request: adminv1.ObjectZone{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				zoneID, ok := pathInt64(w, r, "zoneID")
+				if !ok {
+					return
+				}
+				zone, ok := decodeJSON[adminv1.ObjectZone](w, r)
+				if !ok {
+					return
+				}
+				zone.ID = zoneID
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.UpdateObjectZone(objn, zone))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/objects/{objn}/zones/{zoneID}", role: RoleAdmin,
+			tag: "objects", summary: "Видалити зону", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				zoneID, ok := pathInt64(w, r, "zoneID")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.DeleteObjectZone(objn, zoneID))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/objects/{objn}/personals", role: RoleViewer,
+			tag: "objects", summary: "Відповідальні особи об'єкта",
+			response: ItemsResponse[adminv1.ObjectPersonal]{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				personals, err := p.ObjectCard.ListObjectPersonals(objn)
+				respond(w, http.StatusOK, items(personals), err)
+			},
+		},
+		{
+			method: http.MethodPost, path: "/objects/{objn}/personals", role: RoleAdmin,
+			tag: "objects", summary: "Додати відповідальну особу",
+			request: adminv1.ObjectPersonal{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				personal, ok := decodeJSON[adminv1.ObjectPersonal](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.AddObjectPersonal(objn, personal))
+			},
+		},
+		{
+			method: http.MethodPut, path: "/objects/{objn}/personals/{personalID}", role: RoleAdmin,
+			tag: "objects", summary: "Змінити відповідальну особу",
+			request: adminv1.ObjectPersonal{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				personalID, ok := pathInt64(w, r, "personalID")
+				if !ok {
+					return
+				}
+				personal, ok := decodeJSON[adminv1.ObjectPersonal](w, r)
+				if !ok {
+					return
+				}
+				personal.ID = personalID
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.UpdateObjectPersonal(objn, personal))
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/objects/{objn}/personals/{personalID}", role: RoleAdmin,
+			tag: "objects", summary: "Видалити відповідальну особу", status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				personalID, ok := pathInt64(w, r, "personalID")
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.DeleteObjectPersonal(objn, personalID))
+			},
+		},
+		{
+			method: http.MethodGet, path: "/objects/{objn}/coordinates", role: RoleViewer,
+			tag: "objects", summary: "Координати об'єкта",
+			response: adminv1.ObjectCoordinates{}, status: http.StatusOK,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				coords, err := p.ObjectCard.GetObjectCoordinates(objn)
+				respond(w, http.StatusOK, coords, err)
+			},
+		},
+		{
+			method: http.MethodPut, path: "/objects/{objn}/coordinates", role: RoleAdmin,
+			tag: "objects", summary: "Зберегти координати об'єкта",
+			request: adminv1.ObjectCoordinates{}, status: http.StatusNoContent,
+			handle: func(w http.ResponseWriter, r *http.Request, p Providers) {
+				if p.ObjectCard == nil {
+					unsupported(w)
+					return
+				}
+				objn, ok := pathInt64(w, r, "objn")
+				if !ok {
+					return
+				}
+				coords, ok := decodeJSON[adminv1.ObjectCoordinates](w, r)
+				if !ok {
+					return
+				}
+				respond(w, http.StatusNoContent, nil, p.ObjectCard.SaveObjectCoordinates(objn, coords))
+			},
+		},
+	}
+}
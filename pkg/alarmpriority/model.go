@@ -0,0 +1,124 @@
+// Package alarmpriority оцінює пріоритет активних тривог за налаштовуваною
+// моделлю і впорядковує стрічку тривог за оцінкою.
+package alarmpriority
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// ObjectTraits — властивості об'єкта, що впливають на пріоритет його тривог.
+type ObjectTraits struct {
+	TypeID           int64
+	ContractPriority int
+}
+
+// Model тримає налаштування оцінки і кеш властивостей об'єктів.
+// Кеш оновлюється з кожного списку об'єктів, без окремих запитів до БД.
+type Model struct {
+	cfg config.AlarmPriorityConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	objects map[int]ObjectTraits
+}
+
+// NewModel створює модель пріоритету з налаштувань.
+func NewModel(cfg config.AlarmPriorityConfig) *Model {
+	return &Model{
+		cfg:     cfg,
+		now:     time.Now,
+		objects: make(map[int]ObjectTraits),
+	}
+}
+
+// Config повертає налаштування моделі.
+func (m *Model) Config() config.AlarmPriorityConfig {
+	if m == nil {
+		return config.AlarmPriorityConfig{}
+	}
+	return m.cfg
+}
+
+// Observe запам'ятовує тип і договірний пріоритет об'єктів зі свіжого списку.
+func (m *Model) Observe(objects []models.Object) {
+	if m == nil || len(objects) == 0 {
+		return
+	}
+	traits := make(map[int]ObjectTraits, len(objects))
+	for _, object := range objects {
+		if object.ObjTypeID == 0 && object.ContractPriority == 0 {
+			continue
+		}
+		traits[object.ID] = ObjectTraits{TypeID: object.ObjTypeID, ContractPriority: object.ContractPriority}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects = traits
+}
+
+// Apply оцінює кожну тривогу і стабільно сортує alarms за спаданням оцінки,
+// тож тривоги з однаковою оцінкою зберігають попередній порядок (за часом).
+// Вимкнена модель повертає alarms без змін.
+func (m *Model) Apply(alarms []models.Alarm) []models.Alarm {
+	if m == nil || !m.cfg.Enabled || len(alarms) == 0 {
+		return alarms
+	}
+	perObject := make(map[int]int, len(alarms))
+	for _, alarm := range alarms {
+		perObject[alarm.ObjectID]++
+	}
+	m.mu.Lock()
+	objects := m.objects
+	now := m.now()
+	m.mu.Unlock()
+
+	for i := range alarms {
+		alarms[i].Priority = Score(m.cfg, alarms[i], objects[alarms[i].ObjectID], perObject[alarms[i].ObjectID], now)
+	}
+	sort.SliceStable(alarms, func(i, j int) bool {
+		return alarms[i].Priority.Score > alarms[j].Priority.Score
+	})
+	return alarms
+}
+
+// Score обчислює пріоритет тривоги. activations — кількість активних тривог
+// об'єкта; більша з неї і кількості тривожних повідомлень кейсу вважається
+// числом спрацювань. Час без реакції рахується, доки тривогу ніхто не взяв
+// у роботу і МГР не вислана.
+func Score(cfg config.AlarmPriorityConfig, alarm models.Alarm, object ObjectTraits, activations int, now time.Time) models.AlarmPriority {
+	priority := models.AlarmPriority{
+		Type:       cfg.TypeWeight(string(alarm.Type)),
+		ObjectType: cfg.ObjectTypeWeights[object.TypeID],
+	}
+	if object.ContractPriority > 0 {
+		priority.Contract = object.ContractPriority * cfg.ContractWeight
+	}
+
+	alarmMsgs := 0
+	for _, msg := range alarm.SourceMsgs {
+		if msg.IsAlarm {
+			alarmMsgs++
+		}
+	}
+	if repeats := max(activations, alarmMsgs) - 1; repeats > 0 {
+		priority.Repeats = min(repeats*cfg.RepeatWeight, cfg.RepeatMax)
+	}
+
+	dispatched := alarm.IsResponseGroupDispatched || alarm.IsResponseGroupArrived
+	if dispatched {
+		priority.Dispatched = -cfg.DispatchedPenalty
+	} else if !alarm.IsInProgress && !alarm.IsProcessed && !alarm.Time.IsZero() {
+		if minutes := int(now.Sub(alarm.Time) / time.Minute); minutes > 0 {
+			priority.Unattended = min(minutes*cfg.UnattendedPerMinute, cfg.UnattendedMax)
+		}
+	}
+
+	priority.Score = priority.Type + priority.ObjectType + priority.Contract +
+		priority.Repeats + priority.Unattended + priority.Dispatched
+	return priority
+}
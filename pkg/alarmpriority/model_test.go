@@ -0,0 +1,110 @@
+package alarmpriority
+
+import (
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func newTestModel(now time.Time) *Model {
+	cfg := config.DefaultAlarmPriorityConfig()
+	cfg.ObjectTypeWeights = map[int64]int{5: 30}
+	model := NewModel(cfg)
+	model.now = func() time.Time { return now }
+	return model
+}
+
+func TestScoreCombinesFactors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultAlarmPriorityConfig()
+	cfg.ObjectTypeWeights = map[int64]int{5: 30}
+	alarm := models.Alarm{
+		Type: models.AlarmBurglary,
+		Time: now.Add(-10 * time.Minute),
+		SourceMsgs: []models.AlarmMsg{
+			{IsAlarm: true}, {IsAlarm: true}, {IsAlarm: true}, {IsAlarm: false},
+		},
+	}
+
+	got := Score(cfg, alarm, ObjectTraits{TypeID: 5, ContractPriority: 2}, 1, now)
+	want := models.AlarmPriority{Score: 160, Type: 80, ObjectType: 30, Contract: 20, Repeats: 10, Unattended: 20}
+	if got != want {
+		t.Fatalf("Score() = %+v, want %+v", got, want)
+	}
+
+	alarm.Time = now.Add(-2 * time.Hour)
+	alarm.SourceMsgs = nil
+	if got := Score(cfg, alarm, ObjectTraits{}, 20, now); got.Repeats != cfg.RepeatMax || got.Unattended != cfg.UnattendedMax {
+		t.Fatalf("repeats and unattended must be capped, got %+v", got)
+	}
+
+	alarm.IsResponseGroupDispatched = true
+	if got := Score(cfg, alarm, ObjectTraits{}, 1, now); got.Unattended != 0 || got.Dispatched != -cfg.DispatchedPenalty || got.Score != 20 {
+		t.Fatalf("dispatched alarm = %+v, want only the penalty", got)
+	}
+
+	if got := Score(cfg, models.Alarm{Type: "custom", Time: now, IsInProgress: true}, ObjectTraits{}, 1, now); got.Score != config.DefaultAlarmTypeWeight {
+		t.Fatalf("unknown type = %+v, want default weight", got)
+	}
+}
+
+func TestModelApplySortsByScoreKeepingTimeOrder(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	model := newTestModel(now)
+	model.Observe([]models.Object{{ID: 20, ObjTypeID: 5}})
+
+	// Вхід уже впорядкований за часом, як у CombinedDataProvider.
+	alarms := []models.Alarm{
+		{ID: 1, ObjectID: 10, Type: models.AlarmBatteryLow, Time: now, IsInProgress: true},
+		{ID: 2, ObjectID: 20, Type: models.AlarmOffline, Time: now, IsInProgress: true},
+		{ID: 3, ObjectID: 30, Type: models.AlarmFire, Time: now, IsResponseGroupDispatched: true},
+		{ID: 4, ObjectID: 40, Type: models.AlarmPowerFail, Time: now, IsInProgress: true},
+		{ID: 5, ObjectID: 50, Type: models.AlarmAcTrouble, Time: now, IsInProgress: true},
+	}
+	got := model.Apply(alarms)
+
+	order := make([]int, 0, len(got))
+	for _, alarm := range got {
+		order = append(order, alarm.ID)
+	}
+	// Офлайн на об'єкті типу 5: 40+30; пожежа з висланою МГР: 100-60.
+	want := []int{2, 3, 4, 5, 1}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+	if got[0].Priority.ObjectType != 30 || got[0].Priority.Score != 70 {
+		t.Fatalf("object type weight was not applied: %+v", got[0].Priority)
+	}
+}
+
+func TestModelDisabledAndObserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultAlarmPriorityConfig()
+	cfg.Enabled = false
+	disabled := NewModel(cfg)
+	alarms := []models.Alarm{{ID: 1, Type: models.AlarmBatteryLow}, {ID: 2, Type: models.AlarmFire}}
+	if got := disabled.Apply(alarms); got[0].ID != 1 || !got[1].Priority.IsZero() {
+		t.Fatal("disabled model must leave alarms untouched")
+	}
+	var missing *Model
+	if got := missing.Apply(alarms); len(got) != 2 {
+		t.Fatal("nil model must pass alarms through")
+	}
+
+	model := newTestModel(now)
+	model.Observe([]models.Object{{ID: 7, ContractPriority: 3}})
+	model.Observe(nil)
+	if got := model.Apply([]models.Alarm{{ID: 1, ObjectID: 7, Type: models.AlarmFire, Time: now}}); got[0].Priority.Contract != 30 {
+		t.Fatalf("contract points = %d, want 30", got[0].Priority.Contract)
+	}
+}
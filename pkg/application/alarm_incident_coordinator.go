@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	fyneDialog "fyne.io/fyne/v2/dialog"
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventbus"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+	"obj_catalog_fyne_v3/pkg/usecases"
+)
+
+// initAlarmCorrelation підключає кореляцію тривог в інциденти до панелі тривог.
+func (a *Application) initAlarmCorrelation() {
+	cfg := config.LoadAlarmCorrelationConfig(a.fyneApp.Preferences())
+	if !cfg.Enabled {
+		return
+	}
+	a.alarmPanel.Correlator = usecases.NewAlarmCorrelator(cfg, a.objectGroupObjects)
+	a.alarmPanel.OnProcessIncident = a.confirmAndProcessIncident
+}
+
+func (a *Application) confirmAndProcessIncident(incident []models.Alarm, active []models.Alarm) {
+	if len(incident) == 0 {
+		return
+	}
+	title := incident[0].Incident
+	fyneDialog.ShowConfirm(
+		"Завершення інциденту",
+		fmt.Sprintf("%s\n\nЗавершити всі тривоги інциденту (%d)?", title, len(incident)),
+		func(confirmed bool) {
+			if confirmed {
+				a.processIncident(incident, active)
+			}
+		},
+		a.mainWindow,
+	)
+}
+
+func (a *Application) processIncident(incident []models.Alarm, active []models.Alarm) {
+	provider := a.getUIDataProvider()
+	if provider == nil {
+		dialogs.ShowInfoDialog(a.mainWindow, "Недоступно", "Провайдер даних недоступний.")
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		note := "Інцидент: " + incident[0].Incident
+		processed, err := usecases.ProcessAlarmIncident(ctx, provider, incident, active, contracts.DefaultOperatorName, note)
+		log.Info().Int("incidentID", incident[0].IncidentID).Int("processed", processed).Err(err).Msg("Інцидент тривог відпрацьовано")
+		fyne.Do(func() {
+			a.publishDataRefresh(eventbus.DataRefreshEvent{
+				RefreshAlarms: true,
+				RefreshEvents: true,
+			})
+			if err != nil {
+				dialogs.ShowErrorDialog(a.mainWindow, "Завершення інциденту", fmt.Errorf("завершено тривог: %d з %d: %w", processed, len(incident), err))
+				return
+			}
+			dialogs.ShowInfoDialog(a.mainWindow, "Успішно", fmt.Sprintf("Інцидент завершено, тривог: %d.", processed))
+		})
+	}()
+}
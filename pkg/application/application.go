@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -23,9 +24,11 @@ import (
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/eventbus"
+	objexport "obj_catalog_fyne_v3/pkg/export"
 	"obj_catalog_fyne_v3/pkg/ids"
 	applogger "obj_catalog_fyne_v3/pkg/logger"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	apptheme "obj_catalog_fyne_v3/pkg/theme"
 	"obj_catalog_fyne_v3/pkg/ui"
 	"obj_catalog_fyne_v3/pkg/ui/dialogs"
@@ -71,6 +74,8 @@ type Application struct {
 	objectList *ui.ObjectListPanel
 	workArea   *ui.WorkAreaPanel
 	eventLog   *ui.EventLogPanel
+	// Спільні групи об'єктів і списки спостереження; nil — файл груп недоступний.
+	objectGroups *objectgroups.Registry
 
 	// Праві вкладки (картка об'єкта / журнал / тривоги)
 	rightTabs   *container.AppTabs
@@ -333,6 +338,8 @@ func (a *Application) buildUIPanels() {
 	a.objectList = ui.NewObjectListPanel(objectProvider)
 	a.workArea = ui.NewWorkAreaPanel(workProvider, a.mainWindow)
 	a.eventLog = ui.NewEventLogPanel(eventProvider)
+	a.initObjectGroups()
+	a.initAlarmCorrelation()
 }
 
 func (a *Application) configurePanelCallbacks() {
@@ -355,6 +362,9 @@ func (a *Application) configurePanelCallbacks() {
 		log.Debug().Int("eventID", event.ID).Int("objectID", event.ObjectID).Msg("Подія вибрана")
 		a.applyObjectContextByID(int64(event.ObjectID), true)
 	}
+	a.eventLog.OnExportRequested = a.openEventExportDialog
+	a.eventLog.OnSavedViewsRequested = a.showSavedViewsMenu
+	a.objectList.OnSavedViewsRequested = a.showSavedViewsMenu
 
 	a.alarmPanel.OnProcessAlarm = func(alarm models.Alarm) {
 		a.showProcessAlarmDialog(alarm)
@@ -387,22 +397,35 @@ func (a *Application) confirmAndPickAlarm(alarm models.Alarm) {
 		if owner == "" {
 			owner = "інший оператор"
 		}
-		fyneDialog.ShowConfirm(
+		reasonEntry := widget.NewEntry()
+		reasonEntry.SetPlaceHolder("Причина перехоплення")
+		reasonEntry.Validator = func(text string) error {
+			if strings.TrimSpace(text) == "" {
+				return errors.New("вкажіть причину перехоплення")
+			}
+			return nil
+		}
+		fyneDialog.ShowForm(
 			"Перехоплення тривоги",
-			fmt.Sprintf("Тривогу вже обробляє %s. Перехопити її?", owner),
+			"Перехопити",
+			"Скасувати",
+			[]*widget.FormItem{
+				widget.NewFormItem("", widget.NewLabel(fmt.Sprintf("Тривогу вже обробляє %s.", owner))),
+				widget.NewFormItem("Причина", reasonEntry),
+			},
 			func(confirmed bool) {
 				if confirmed {
-					a.pickAlarm(alarm)
+					a.pickAlarm(alarm, strings.TrimSpace(reasonEntry.Text))
 				}
 			},
 			a.mainWindow,
 		)
 		return
 	}
-	a.pickAlarm(alarm)
+	a.pickAlarm(alarm, "")
 }
 
-func (a *Application) pickAlarm(alarm models.Alarm) {
+func (a *Application) pickAlarm(alarm models.Alarm, takeoverReason string) {
 	provider := a.getUIDataProvider()
 	if provider == nil {
 		dialogs.ShowInfoDialog(a.mainWindow, "Недоступно", "Провайдер даних недоступний.")
@@ -413,7 +436,12 @@ func (a *Application) pickAlarm(alarm models.Alarm) {
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
 
-		err := provider.PickAlarm(ctx, alarm, contracts.DefaultOperatorName)
+		var err error
+		if takeoverReason != "" {
+			err = provider.TakeOverAlarm(ctx, alarm, contracts.DefaultOperatorName, takeoverReason)
+		} else {
+			err = provider.PickAlarm(ctx, alarm, contracts.DefaultOperatorName)
+		}
 		fyne.Do(func() {
 			if err != nil {
 				dialogs.ShowErrorDialog(a.mainWindow, "Взяття тривоги в роботу", err)
@@ -882,6 +910,27 @@ func (a *Application) buildMainMenu() *fyne.MainMenu {
 				a.applyObjectContext(&object, true)
 			})
 		}),
+		fyne.NewMenuItem("Перевірка якості даних", func() {
+			a.openDataQualityReport()
+		}),
+		fyne.NewMenuItem("Архів подій Phoenix", func() {
+			a.openEventArchiveDialog()
+		}),
+		fyne.NewMenuItem("Експорт журналу подій", func() {
+			a.openEventExportDialog()
+		}),
+		fyne.NewMenuItem("Вікна обслуговування", func() {
+			a.openMaintenanceWindowsDialog()
+		}),
+		fyne.NewMenuItem("Групи об'єктів", func() {
+			a.openObjectGroupsDialog()
+		}),
+		fyne.NewMenuItem("Контроль періодичних тестів", func() {
+			a.openOverdueTestsReport()
+		}),
+		fyne.NewMenuItem("Розклад відкриття/закриття", func() {
+			a.openOpenCloseSchedulesDialog()
+		}),
 		fyne.NewMenuItem("Згенерувати звіт прийнятих об'єктів", func() {
 			a.generateAcceptedObjectsExcelReport()
 		}),
@@ -1073,6 +1122,11 @@ func (a *Application) generateAcceptedObjectsExcelReport() {
 	go func() {
 		err := excelProvider.GenerateAcceptedObjectsReport(filePath)
 		fyne.Do(func() {
+			var uploadErr *objexport.ReportUploadError
+			if errors.As(err, &uploadErr) {
+				dialogs.ShowInfoDialog(a.mainWindow, "Увага", fmt.Sprintf("Звіт сформовано в:\n%s\n\nАле не завантажено на %s: %v", filePath, uploadErr.TargetName(), uploadErr.Err))
+				return
+			}
 			if err != nil {
 				dialogs.ShowErrorDialog(a.mainWindow, "Помилка генерації звіту", err)
 				return
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+
+	"obj_catalog_fyne_v3/pkg/backend"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+// openDataQualityReport запускає перевірку якості даних по всіх джерелах.
+func (a *Application) openDataQualityReport() {
+	provider := a.getUIDataProvider()
+	if provider == nil {
+		dialogs.ShowErrorDialog(a.mainWindow, "Перевірка якості даних", fmt.Errorf("джерела даних недоступні"))
+		return
+	}
+	capabilities := []any{a.getDataProvider()}
+	if admin, ok := backend.AsAdminProvider(a.getDataProvider()); ok {
+		capabilities = append(capabilities, admin)
+	}
+	dialogs.ShowDataQualityReport(provider, dataquality.CapabilityOptions(capabilities...), a.openDataQualityFix)
+}
+
+// openDataQualityFix робить об'єкт проблеми поточним і відкриває його редактор.
+func (a *Application) openDataQualityFix(issue dataquality.Issue) {
+	if issue.ObjectID <= 0 {
+		return
+	}
+	go func() {
+		obj := a.resolveObjectByID(int64(issue.ObjectID))
+		fyne.Do(func() {
+			if obj == nil {
+				dialogs.ShowInfoDialog(a.mainWindow, "Перевірка якості даних", "Об'єкт №"+issue.ObjectNumber+" не знайдено (ID "+strconv.Itoa(issue.ObjectID)+").")
+				return
+			}
+			a.applyObjectContext(obj, true)
+			if ids.IsCASLObjectID(obj.ID) {
+				a.openCASLObjectEditor()
+				return
+			}
+			withAdminCapability(a, func(admin contracts.AdminObjectCardProvider) {
+				a.openEditCurrentObjectDialog(backend.NewAdminV1ObjectCardProvider(
+					backend.NewFrontendAdminCardBridge(a.getFrontendAPI(), admin),
+				))
+			})()
+		})
+	}()
+}
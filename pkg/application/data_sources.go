@@ -9,12 +9,17 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 
+	"obj_catalog_fyne_v3/pkg/alarmpriority"
 	"obj_catalog_fyne_v3/pkg/backend"
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/data"
 	"obj_catalog_fyne_v3/pkg/database"
+	"obj_catalog_fyne_v3/pkg/dataruntime"
 	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
 )
 
 type managedDBResource struct {
@@ -81,6 +86,7 @@ func buildDataProviderFromConfig(cfg config.DBConfig, pref fyne.Preferences, ver
 				dsn,
 				data.WithVodafoneConfigStore(config.NewPreferencesVodafoneConfigStore(pref)),
 				data.WithKyivstarConfigStore(config.NewPreferencesKyivstarConfigStore(pref)),
+				data.WithReportUploadConfigStore(config.NewPreferencesReportUploadConfigStore(pref)),
 			),
 		})
 	}
@@ -128,22 +134,44 @@ func buildDataProviderFromConfig(cfg config.DBConfig, pref fyne.Preferences, ver
 	}
 
 	if caslEnabled {
-		caslProvider := backend.NewCASLCloudProvider(
-			cfg.CASLBaseURL,
-			cfg.CASLToken,
-			cfg.CASLPultID,
-			cfg.CASLEmail,
-			cfg.CASLPass,
-		)
-		sources = append(sources, data.ProviderSource{
-			Name:         "casl",
-			Provider:     caslProvider,
-			OwnsObjectID: ids.IsCASLObjectID,
-			OwnsAlarmID:  ids.IsCASLObjectID,
+		sources = append(sources, data.CASLProviderSources(cfg)...)
+	}
+
+	sources, instanceDBs := dataruntime.AppendSourceInstances(sources, cfg, verifyConnectivity)
+	for _, instanceDB := range instanceDBs {
+		result.managedDBs = append(result.managedDBs, managedDBResource{
+			label:        instanceDB.Label,
+			db:           instanceDB.DB,
+			healthCancel: instanceDB.HealthCancel,
 		})
 	}
 
 	result.provider = backend.NewMultiSourceProvider(sources...)
+	if combined, ok := result.provider.(*data.CombinedDataProvider); ok {
+		combined.SetDispatchConfigStore(config.NewPreferencesDispatchConfigStore(pref))
+		if maintenanceCfg := config.LoadMaintenanceConfig(pref); maintenanceCfg.Enabled {
+			suppressor, err := maintenance.Open(maintenanceCfg.WindowsPath, time.Duration(maintenanceCfg.ReminderHours)*time.Hour)
+			if err != nil {
+				log.Warn().Err(err).Str("path", maintenanceCfg.WindowsPath).Msg("Вікна обслуговування вимкнено: не вдалося відкрити файл")
+			} else {
+				combined.SetMaintenanceSuppressor(suppressor)
+			}
+		}
+		if settings := testsupervision.SettingsFromConfig(config.LoadTestSupervisionConfig(pref)); settings.Enabled() {
+			combined.SetTestSupervisor(testsupervision.NewSupervisor(settings))
+		}
+		if openCloseCfg := config.LoadOpenCloseConfig(pref); openCloseCfg.Enabled {
+			supervisor, err := openclose.Open(openCloseCfg.SchedulesPath, time.Duration(openCloseCfg.EscalateMinutes)*time.Minute)
+			if err != nil {
+				log.Warn().Err(err).Str("path", openCloseCfg.SchedulesPath).Msg("Контроль розкладу вимкнено: не вдалося відкрити файл")
+			} else {
+				combined.SetOpenCloseSupervisor(supervisor)
+			}
+		}
+		if priorityCfg := config.LoadAlarmPriorityConfig(pref); priorityCfg.Enabled {
+			combined.SetAlarmPriorityModel(alarmpriority.NewModel(priorityCfg))
+		}
+	}
 	return result, nil
 }
 
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+// openEventArchiveDialog відкриває архів подій Phoenix; поточний об'єкт
+// Phoenix підставляється у фільтр.
+func (a *Application) openEventArchiveDialog() {
+	provider := a.getDataProvider()
+	archive, ok := provider.(contracts.EventArchiveProvider)
+	if !ok || !hasFrontendSource(provider, contracts.FrontendSourcePhoenix) {
+		dialogs.ShowInfoDialog(a.mainWindow, "Недоступно", "Архів подій доступний лише для підключених серверів Phoenix.")
+		return
+	}
+	var object *models.Object
+	if a.currentObject != nil && ids.IsPhoenixObjectID(a.currentObject.ID) {
+		object = a.currentObject
+	}
+	dialogs.ShowEventArchiveDialog(archive, object)
+}
+
+func hasFrontendSource(provider any, source contracts.FrontendSource) bool {
+	capabilities, ok := provider.(interface {
+		FrontendSourceCapabilities() []contracts.FrontendSourceCapability
+	})
+	if !ok {
+		return false
+	}
+	for _, capability := range capabilities.FrontendSourceCapabilities() {
+		if capability.Source == source {
+			return true
+		}
+	}
+	return false
+}
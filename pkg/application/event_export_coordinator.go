@@ -0,0 +1,23 @@
+package application
+
+import (
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/eventexport"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// openEventExportDialog відкриває експорт журналу подій з фільтрами панелі журналу.
+func (a *Application) openEventExportDialog() {
+	provider, ok := a.getDataProvider().(eventexport.Provider)
+	if !ok {
+		dialogs.ShowInfoDialog(a.mainWindow, "Недоступно", "Джерела даних не підтримують вибірку подій за період.")
+		return
+	}
+	preset := viewmodels.EventExportPreset("", "", false, nil, time.Now())
+	if a.eventLog != nil {
+		preset = a.eventLog.ExportPreset()
+	}
+	dialogs.ShowEventExportDialog(provider, preset)
+}
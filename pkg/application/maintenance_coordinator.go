@@ -0,0 +1,32 @@
+package application
+
+import (
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventbus"
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+type maintenanceProvider interface {
+	MaintenanceSuppressor() *maintenance.Suppressor
+}
+
+func (a *Application) openMaintenanceWindowsDialog() {
+	provider, _ := a.getDataProvider().(maintenanceProvider)
+	var suppressor *maintenance.Suppressor
+	if provider != nil {
+		suppressor = provider.MaintenanceSuppressor()
+	}
+	if suppressor == nil {
+		dialogs.ShowInfoDialog(
+			a.mainWindow,
+			"Недоступно",
+			"Вікна обслуговування вимкнено або файл вікон недоступний. Перевірте налаштування maintenance.* та журнал.",
+		)
+		return
+	}
+
+	dialogs.ShowMaintenanceWindowsDialog(suppressor.Store(), a.currentObject, contracts.DefaultOperatorName, func() {
+		a.publishDataRefresh(eventbus.DataRefreshEvent{RefreshAlarms: true})
+	})
+}
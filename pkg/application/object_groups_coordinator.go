@@ -0,0 +1,51 @@
+package application
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventbus"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+// initObjectGroups відкриває спільний файл груп об'єктів і підключає його до панелей.
+func (a *Application) initObjectGroups() {
+	cfg := config.LoadObjectGroupsConfig(a.fyneApp.Preferences())
+	store, err := objectgroups.OpenFileStore(cfg.Path)
+	if err != nil {
+		log.Warn().Err(err).Str("path", cfg.Path).Msg("Групи об'єктів вимкнено: не вдалося відкрити файл")
+		return
+	}
+	a.objectGroups = objectgroups.NewRegistry(store, a.objectGroupObjects)
+	a.objectList.Groups = a.objectGroups
+	a.alarmPanel.Groups = a.objectGroups
+	a.eventLog.Groups = a.objectGroups
+}
+
+func (a *Application) openObjectGroupsDialog() {
+	if a.objectGroups == nil {
+		dialogs.ShowInfoDialog(
+			a.mainWindow,
+			"Недоступно",
+			"Файл груп об'єктів недоступний. Перевірте налаштування object_groups.path та журнал.",
+		)
+		return
+	}
+
+	dialogs.ShowObjectGroupsDialog(a.objectGroups.Store(), a.objectGroupObjects, a.currentObject, contracts.DefaultOperatorName, func() {
+		a.objectGroups.Invalidate()
+		a.publishDataRefresh(eventbus.DataRefreshEvent{RefreshObjects: true, RefreshAlarms: true, RefreshEvents: true})
+	})
+}
+
+// objectGroupObjects повертає останній завантажений список об'єктів для
+// груп-виразів і номерів у формі, не перечитуючи джерела.
+func (a *Application) objectGroupObjects() []models.Object {
+	if a.objectList == nil {
+		return nil
+	}
+	return a.objectList.Objects()
+}
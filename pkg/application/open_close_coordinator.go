@@ -0,0 +1,32 @@
+package application
+
+import (
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventbus"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+type openCloseProvider interface {
+	OpenCloseSupervisor() *openclose.Supervisor
+}
+
+func (a *Application) openOpenCloseSchedulesDialog() {
+	provider, _ := a.getDataProvider().(openCloseProvider)
+	var supervisor *openclose.Supervisor
+	if provider != nil {
+		supervisor = provider.OpenCloseSupervisor()
+	}
+	if supervisor == nil {
+		dialogs.ShowInfoDialog(
+			a.mainWindow,
+			"Недоступно",
+			"Контроль розкладу вимкнено або файл розкладів недоступний. Перевірте налаштування open_close.* та журнал.",
+		)
+		return
+	}
+
+	dialogs.ShowOpenCloseSchedulesDialog(supervisor.Store(), a.currentObject, contracts.DefaultOperatorName, func() {
+		a.publishDataRefresh(eventbus.DataRefreshEvent{RefreshAlarms: true})
+	})
+}
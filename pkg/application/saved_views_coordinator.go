@@ -0,0 +1,74 @@
+package application
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// showSavedViewsMenu показує під кнопкою панелі меню збережених подань:
+// вибране подання підставляється в рядок фільтра entry, поточний вираз можна зберегти.
+func (a *Application) showSavedViewsMenu(anchor fyne.CanvasObject, entry *widget.Entry) {
+	if a.mainWindow == nil || anchor == nil || entry == nil {
+		return
+	}
+	prefs := a.fyneApp.Preferences()
+	views := config.LoadSavedViews(prefs)
+
+	items := make([]*fyne.MenuItem, 0, len(views)+3)
+	for _, view := range views {
+		items = append(items, fyne.NewMenuItem(view.Name, func() { entry.SetText(view.Query) }))
+	}
+	if len(views) == 0 {
+		empty := fyne.NewMenuItem("Немає збережених подань", nil)
+		empty.Disabled = true
+		items = append(items, empty)
+	}
+	items = append(items, fyne.NewMenuItemSeparator(), fyne.NewMenuItem("Зберегти поточний фільтр...", func() {
+		a.saveCurrentView(entry.Text)
+	}))
+	if len(views) > 0 {
+		removeItems := make([]*fyne.MenuItem, 0, len(views))
+		for _, view := range views {
+			removeItems = append(removeItems, fyne.NewMenuItem(view.Name, func() {
+				if err := config.SaveSavedViews(prefs, config.RemoveSavedView(config.LoadSavedViews(prefs), view.Name)); err != nil {
+					dialogs.ShowErrorDialog(a.mainWindow, "Збережені подання", err)
+				}
+			}))
+		}
+		remove := fyne.NewMenuItem("Видалити подання", nil)
+		remove.ChildMenu = fyne.NewMenu("", removeItems...)
+		items = append(items, remove)
+	}
+
+	position := fyne.CurrentApp().Driver().AbsolutePositionForObject(anchor).Add(fyne.NewPos(0, anchor.Size().Height))
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), a.mainWindow.Canvas(), position)
+}
+
+// saveCurrentView запитує назву і зберігає вираз фільтра як подання.
+func (a *Application) saveCurrentView(query string) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Напр. Київ без зв'язку")
+	queryLabel := widget.NewLabel(query)
+	queryLabel.Wrapping = fyne.TextWrapWord
+	dialog.ShowForm("Зберегти подання", "Зберегти", "Скасувати", []*widget.FormItem{
+		widget.NewFormItem("Назва", nameEntry),
+		widget.NewFormItem("Фільтр", queryLabel),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		prefs := a.fyneApp.Preferences()
+		views, err := viewmodels.AddSavedView(config.LoadSavedViews(prefs), nameEntry.Text, query)
+		if err == nil {
+			err = config.SaveSavedViews(prefs, views)
+		}
+		if err != nil {
+			dialogs.ShowErrorDialog(a.mainWindow, "Зберегти подання", err)
+		}
+	}, a.mainWindow)
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+
+	"obj_catalog_fyne_v3/pkg/testsupervision"
+	"obj_catalog_fyne_v3/pkg/ui/dialogs"
+)
+
+type testSupervisionProvider interface {
+	TestSupervisor() *testsupervision.Supervisor
+}
+
+// openOverdueTestsReport показує панель очікуваних і прострочених періодичних тестів.
+func (a *Application) openOverdueTestsReport() {
+	provider, _ := a.getDataProvider().(testSupervisionProvider)
+	var supervisor *testsupervision.Supervisor
+	if provider != nil {
+		supervisor = provider.TestSupervisor()
+	}
+	if supervisor == nil {
+		dialogs.ShowInfoDialog(
+			a.mainWindow,
+			"Недоступно",
+			"Контроль періодичних тестів вимкнено для всіх джерел. Перевірте налаштування test_supervision.*.",
+		)
+		return
+	}
+	source, _ := a.getDataProvider().(testsupervision.ObjectSource)
+	dialogs.ShowOverdueTestsReport(supervisor, source, a.openOverdueTestObject)
+}
+
+// openOverdueTestObject робить об'єкт з панелі тестів поточним.
+func (a *Application) openOverdueTestObject(objectID int) {
+	if objectID == 0 {
+		return
+	}
+	go func() {
+		obj := a.resolveObjectByID(int64(objectID))
+		fyne.Do(func() {
+			if obj == nil {
+				dialogs.ShowInfoDialog(a.mainWindow, "Контроль періодичних тестів", "Об'єкт ID "+strconv.Itoa(objectID)+" не знайдено.")
+				return
+			}
+			a.applyObjectContext(obj, true)
+		})
+	}()
+}
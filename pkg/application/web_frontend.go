@@ -2,8 +2,12 @@ package application
 
 import (
 	"context"
+	"io"
 
+	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 const defaultWebFrontendAddr = "127.0.0.1:17890"
@@ -135,6 +139,119 @@ func (b applicationFrontendBackend) ListResponseGroups(ctx context.Context) ([]c
 	return backend.ListResponseGroups(ctx)
 }
 
+func (b applicationFrontendBackend) ListResponseGroupsForAlarm(ctx context.Context, alarmID int) ([]contracts.FrontendResponseGroup, error) {
+	backend, err := b.current()
+	if err != nil {
+		return nil, err
+	}
+	if alarmBackend, ok := backend.(contracts.FrontendAlarmResponseGroupsBackend); ok {
+		return alarmBackend.ListResponseGroupsForAlarm(ctx, alarmID)
+	}
+	return backend.ListResponseGroups(ctx)
+}
+
+func (b applicationFrontendBackend) ExportEvents(ctx context.Context, request contracts.FrontendEventExportRequest, w io.Writer) (int, error) {
+	backend, err := b.current()
+	if err != nil {
+		return 0, err
+	}
+	if exportBackend, ok := backend.(contracts.FrontendEventExportBackend); ok {
+		return exportBackend.ExportEvents(ctx, request, w)
+	}
+	return 0, contracts.ErrUnsupportedFrontendSource
+}
+
+func (b applicationFrontendBackend) QueryObjects(ctx context.Context, query string) ([]contracts.FrontendObjectSummary, error) {
+	backend, err := b.current()
+	if err != nil {
+		return nil, err
+	}
+	if queryBackend, ok := backend.(contracts.FrontendQueryBackend); ok {
+		return queryBackend.QueryObjects(ctx, query)
+	}
+	return nil, contracts.ErrUnsupportedFrontendSource
+}
+
+func (b applicationFrontendBackend) QueryEvents(ctx context.Context, query string) ([]contracts.FrontendEventItem, error) {
+	backend, err := b.current()
+	if err != nil {
+		return nil, err
+	}
+	if queryBackend, ok := backend.(contracts.FrontendQueryBackend); ok {
+		return queryBackend.QueryEvents(ctx, query)
+	}
+	return nil, contracts.ErrUnsupportedFrontendSource
+}
+
+// ListSavedViews віддає веб-клієнту ті ж збережені подання, що й панелям Fyne.
+func (b applicationFrontendBackend) ListSavedViews(ctx context.Context) ([]contracts.FrontendSavedView, error) {
+	if b.app == nil || b.app.fyneApp == nil {
+		return nil, contracts.ErrFrontendBackendUnavailable
+	}
+	views := config.LoadSavedViews(b.app.fyneApp.Preferences())
+	result := make([]contracts.FrontendSavedView, 0, len(views))
+	for _, view := range views {
+		result = append(result, contracts.FrontendSavedView{Name: view.Name, Query: view.Query})
+	}
+	return result, nil
+}
+
+func (b applicationFrontendBackend) SaveSavedViews(ctx context.Context, views []contracts.FrontendSavedView) error {
+	if b.app == nil || b.app.fyneApp == nil {
+		return contracts.ErrFrontendBackendUnavailable
+	}
+	items := make([]config.SavedView, 0, len(views))
+	for _, view := range views {
+		items = append(items, config.SavedView{Name: view.Name, Query: view.Query})
+	}
+	items, err := usecases.NormalizeSavedViews(items)
+	if err != nil {
+		return err
+	}
+	return config.SaveSavedViews(b.app.fyneApp.Preferences(), items)
+}
+
+// ListObjectGroups віддає веб-клієнту ті ж групи об'єктів, що й панелям Fyne.
+func (b applicationFrontendBackend) ListObjectGroups(ctx context.Context) ([]contracts.FrontendObjectGroup, string, error) {
+	groups, err := b.objectGroups()
+	if err != nil {
+		return nil, "", err
+	}
+	return groups.ListObjectGroups(ctx)
+}
+
+func (b applicationFrontendBackend) SaveObjectGroups(ctx context.Context, items []contracts.FrontendObjectGroup, revision string) (string, error) {
+	groups, err := b.objectGroups()
+	if err != nil {
+		return "", err
+	}
+	return groups.SaveObjectGroups(ctx, items, revision)
+}
+
+func (b applicationFrontendBackend) ObjectGroupMembers(ctx context.Context, groupID string) (map[int]struct{}, error) {
+	groups, err := b.objectGroups()
+	if err != nil {
+		return nil, err
+	}
+	return groups.ObjectGroupMembers(ctx, groupID)
+}
+
+func (b applicationFrontendBackend) WatchedObjects(ctx context.Context) (map[int]struct{}, error) {
+	groups, err := b.objectGroups()
+	if err != nil {
+		// Без груп список спостереження порожній.
+		return nil, nil
+	}
+	return groups.WatchedObjects(ctx)
+}
+
+func (b applicationFrontendBackend) objectGroups() (*objectgroups.FrontendBackend, error) {
+	if b.app == nil || b.app.objectGroups == nil || b.app.objectGroups.Store() == nil {
+		return nil, contracts.ErrUnsupportedFrontendSource
+	}
+	return objectgroups.NewFrontendBackend(b.app.objectGroups), nil
+}
+
 func (b applicationFrontendBackend) AssignResponseGroup(ctx context.Context, alarmID int, request contracts.FrontendAlarmGroupActionRequest) error {
 	backend, err := b.current()
 	if err != nil {
@@ -3,13 +3,18 @@ package backend
 import (
 	"context"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventexport"
+	objexport "obj_catalog_fyne_v3/pkg/export"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 var legacyFrontendAlarmProcessingOptions = []contracts.FrontendAlarmProcessingOption{
@@ -42,12 +47,13 @@ type FrontendAdapterOption func(*FrontendAdapter)
 type FrontendAdapter struct {
 	dataProvider       contracts.DataProvider
 	adminMutator       FrontendAdminObjectMutator
+	phoenixMutator     FrontendAdminObjectMutator
 	caslMutator        FrontendCASLObjectMutator
 	capabilityProvider FrontendSourceCapabilityProvider
 
 	// pickedAlarmsMu guards pickedAlarmIDs for Bridge alarms picked locally (no DB state).
 	pickedAlarmsMu sync.Mutex
-	pickedAlarmIDs map[int]bool
+	pickedAlarmIDs map[pickedAlarmKey]bool
 }
 
 func WithFrontendAdminObjectMutator(mutator FrontendAdminObjectMutator) FrontendAdapterOption {
@@ -58,6 +64,14 @@ func WithFrontendAdminObjectMutator(mutator FrontendAdminObjectMutator) Frontend
 	}
 }
 
+func WithFrontendPhoenixObjectMutator(mutator FrontendAdminObjectMutator) FrontendAdapterOption {
+	return func(adapter *FrontendAdapter) {
+		if adapter != nil {
+			adapter.phoenixMutator = mutator
+		}
+	}
+}
+
 func WithFrontendCASLObjectMutator(mutator FrontendCASLObjectMutator) FrontendAdapterOption {
 	return func(adapter *FrontendAdapter) {
 		if adapter != nil {
@@ -88,6 +102,11 @@ func NewFrontendAdapter(dataProvider contracts.DataProvider, opts ...FrontendAda
 			adapter.adminMutator = admin
 		}
 	}
+	if adapter.phoenixMutator == nil {
+		if phoenix, ok := AsPhoenixObjectAdmin(dataProvider); ok {
+			adapter.phoenixMutator = phoenix
+		}
+	}
 	if adapter.caslMutator == nil {
 		if casl, ok := dataProvider.(FrontendCASLObjectMutator); ok {
 			adapter.caslMutator = casl
@@ -117,12 +136,7 @@ func (a *FrontendAdapter) ListObjects(ctx context.Context) ([]contracts.Frontend
 	if a == nil || a.dataProvider == nil {
 		return nil, contracts.ErrFrontendBackendUnavailable
 	}
-	var objects []models.Object
-	if provider, ok := a.dataProvider.(contracts.ContextObjectProvider); ok {
-		objects = provider.GetObjectsContext(ctx)
-	} else {
-		objects = a.dataProvider.GetObjects()
-	}
+	objects := a.objectsContext(ctx)
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -133,22 +147,47 @@ func (a *FrontendAdapter) ListObjects(ctx context.Context) ([]contracts.Frontend
 	return result, nil
 }
 
-func (a *FrontendAdapter) ListAlarms(ctx context.Context) ([]contracts.FrontendAlarmItem, error) {
+// QueryObjects returns the objects matching a filter language expression.
+func (a *FrontendAdapter) QueryObjects(ctx context.Context, query string) ([]contracts.FrontendObjectSummary, error) {
 	if a == nil || a.dataProvider == nil {
 		return nil, contracts.ErrFrontendBackendUnavailable
 	}
-	var alarms []models.Alarm
-	if provider, ok := a.dataProvider.(contracts.ContextAlarmProvider); ok {
-		alarms = provider.GetAlarmsContext(ctx)
-	} else {
-		alarms = a.dataProvider.GetAlarms()
+	filter, err := usecases.ParseFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	objects := a.objectsContext(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := make([]contracts.FrontendObjectSummary, 0, len(objects))
+	for _, object := range objects {
+		if filter.MatchObject(object, now) {
+			result = append(result, mapFrontendObjectSummary(object))
+		}
+	}
+	return result, nil
+}
+
+func (a *FrontendAdapter) objectsContext(ctx context.Context) []models.Object {
+	if provider, ok := a.dataProvider.(contracts.ContextObjectProvider); ok {
+		return provider.GetObjectsContext(ctx)
+	}
+	return a.dataProvider.GetObjects()
+}
+
+func (a *FrontendAdapter) ListAlarms(ctx context.Context) ([]contracts.FrontendAlarmItem, error) {
+	if a == nil || a.dataProvider == nil {
+		return nil, contracts.ErrFrontendBackendUnavailable
 	}
+	alarms := a.alarmsContext(ctx)
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 	var result []contracts.FrontendAlarmItem
 	for _, alarm := range alarms {
-		isPickedLocally := a.isBridgeAlarmPickedLocally(alarm.ID)
+		isPickedLocally := a.isBridgeAlarmPickedLocally(ctx, alarm.ID)
 
 		if len(alarm.SourceMsgs) > 0 {
 			for idx, msg := range alarm.SourceMsgs {
@@ -175,7 +214,7 @@ func (a *FrontendAdapter) ListAlarms(ctx context.Context) ([]contracts.FrontendA
 				}
 				item.VisualSeverity = frontendAlarmSeverityFromMsg(msg)
 
-				if !item.IsOwnedByMe && isPickedLocally {
+				if !item.IsInProgress && isPickedLocally {
 					item.IsOwnedByMe = true
 					item.IsInProgress = true
 					item.CanProcess = true
@@ -184,7 +223,7 @@ func (a *FrontendAdapter) ListAlarms(ctx context.Context) ([]contracts.FrontendA
 			}
 		} else {
 			item := mapFrontendAlarmItem(alarm)
-			if !item.IsOwnedByMe && isPickedLocally {
+			if !item.IsInProgress && isPickedLocally {
 				item.IsOwnedByMe = true
 				item.IsInProgress = true
 				item.CanProcess = true
@@ -195,16 +234,37 @@ func (a *FrontendAdapter) ListAlarms(ctx context.Context) ([]contracts.FrontendA
 	return result, nil
 }
 
-func (a *FrontendAdapter) isBridgeAlarmPickedLocally(alarmID int) bool {
+func (a *FrontendAdapter) alarmsContext(ctx context.Context) []models.Alarm {
+	if provider, ok := a.dataProvider.(contracts.ContextAlarmProvider); ok {
+		return provider.GetAlarmsContext(ctx)
+	}
+	return a.dataProvider.GetAlarms()
+}
+
+// pickedAlarmKey відокремлює локально взяті тривоги різних клієнтів
+// сервера оператора; для локального застосунку client порожній.
+type pickedAlarmKey struct {
+	client  string
+	alarmID int
+}
+
+func newPickedAlarmKey(ctx context.Context, alarmID int) pickedAlarmKey {
+	client, _ := contracts.FrontendClientFromContext(ctx)
+	return pickedAlarmKey{client: client.ID, alarmID: alarmID}
+}
+
+func (a *FrontendAdapter) isBridgeAlarmPickedLocally(ctx context.Context, alarmID int) bool {
 	if a == nil {
 		return false
 	}
 	a.pickedAlarmsMu.Lock()
 	defer a.pickedAlarmsMu.Unlock()
-	return a.pickedAlarmIDs[alarmID]
+	return a.pickedAlarmIDs[newPickedAlarmKey(ctx, alarmID)]
 }
 
-func (a *FrontendAdapter) setBridgeAlarmPicked(alarmID int, picked bool) {
+// setBridgeAlarmPicked позначає тривогу взятою клієнтом запиту. Завершена
+// тривога знімається з позначок усіх клієнтів.
+func (a *FrontendAdapter) setBridgeAlarmPicked(ctx context.Context, alarmID int, picked bool) {
 	if a == nil {
 		return
 	}
@@ -212,11 +272,15 @@ func (a *FrontendAdapter) setBridgeAlarmPicked(alarmID int, picked bool) {
 	defer a.pickedAlarmsMu.Unlock()
 	if picked {
 		if a.pickedAlarmIDs == nil {
-			a.pickedAlarmIDs = make(map[int]bool)
+			a.pickedAlarmIDs = make(map[pickedAlarmKey]bool)
+		}
+		a.pickedAlarmIDs[newPickedAlarmKey(ctx, alarmID)] = true
+		return
+	}
+	for key := range a.pickedAlarmIDs {
+		if key.alarmID == alarmID {
+			delete(a.pickedAlarmIDs, key)
 		}
-		a.pickedAlarmIDs[alarmID] = true
-	} else {
-		delete(a.pickedAlarmIDs, alarmID)
 	}
 }
 
@@ -224,7 +288,7 @@ func (a *FrontendAdapter) GetAlarmProcessingOptions(ctx context.Context, alarmID
 	if a == nil || a.dataProvider == nil {
 		return nil, contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +317,7 @@ func (a *FrontendAdapter) PickAlarm(ctx context.Context, alarmID int, request co
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
@@ -262,17 +326,34 @@ func (a *FrontendAdapter) PickAlarm(ctx context.Context, alarmID int, request co
 	if alarm.IsInProgress && alarm.IsOwnedByMe {
 		return nil
 	}
+	user := strings.TrimSpace(request.User)
+	reason := strings.TrimSpace(request.Reason)
+	if alarm.IsInProgress && source == contracts.FrontendSourceBridge {
+		// Тривогу МІСТ тримає інше робоче місце: перехоплення лише з причиною.
+		advanced, ok := a.dataProvider.(contracts.AlarmTakeoverReasonProvider)
+		if !ok || reason == "" {
+			return fmt.Errorf("%w: %s", contracts.ErrAlarmOwnershipConflict, strings.TrimSpace(alarm.InProgressBy))
+		}
+		if err := advanced.TakeOverAlarm(ctx, alarm, user, reason); err != nil {
+			return err
+		}
+		a.setBridgeAlarmPicked(ctx, alarmID, true)
+		return nil
+	}
 	if alarm.IsInProgress &&
 		source != contracts.FrontendSourceCASL &&
 		source != contracts.FrontendSourcePhoenix {
 		return fmt.Errorf("%w: %s", contracts.ErrAlarmOwnershipConflict, strings.TrimSpace(alarm.InProgressBy))
 	}
+	if advanced, ok := a.dataProvider.(contracts.AlarmTakeoverReasonProvider); ok && alarm.IsInProgress && reason != "" {
+		return advanced.TakeOverAlarm(ctx, alarm, user, reason)
+	}
 	if advanced, ok := a.dataProvider.(contracts.AlarmTakeoverProvider); ok {
-		if err := advanced.PickAlarm(ctx, alarm, strings.TrimSpace(request.User)); err != nil {
+		if err := advanced.PickAlarm(ctx, alarm, user); err != nil {
 			return err
 		}
 		if source == contracts.FrontendSourceBridge {
-			a.setBridgeAlarmPicked(alarmID, true)
+			a.setBridgeAlarmPicked(ctx, alarmID, true)
 		}
 		return nil
 	}
@@ -283,17 +364,14 @@ func (a *FrontendAdapter) ProcessAlarm(ctx context.Context, alarmID int, request
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
 
 	user := strings.TrimSpace(request.User)
-	source := contracts.DetectFrontendSourceByObjectID(alarm.ObjectID)
-	if source == contracts.FrontendSourceCASL || source == contracts.FrontendSourcePhoenix {
-		if err := ensureAlarmActionOwnership(alarm); err != nil {
-			return err
-		}
+	if err := ensureAlarmActionOwnership(alarm); err != nil {
+		return err
 	}
 	note := strings.TrimSpace(request.Note)
 	if advanced, ok := a.dataProvider.(contracts.AlarmProcessingProvider); ok {
@@ -303,7 +381,7 @@ func (a *FrontendAdapter) ProcessAlarm(ctx context.Context, alarmID int, request
 		}); err != nil {
 			return err
 		}
-		a.setBridgeAlarmPicked(alarmID, false)
+		a.setBridgeAlarmPicked(ctx, alarmID, false)
 		return nil
 	}
 
@@ -314,16 +392,21 @@ func (a *FrontendAdapter) GroupProcessAlarm(ctx context.Context, alarmID int, us
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
 
+	if contracts.DetectFrontendSourceByObjectID(alarm.ObjectID) == contracts.FrontendSourceBridge {
+		if err := ensureAlarmActionOwnership(alarm); err != nil {
+			return err
+		}
+	}
 	if provider, ok := a.dataProvider.(contracts.AlarmGroupProcessProvider); ok {
 		if err := provider.GroupProcessAlarm(ctx, alarm, strings.TrimSpace(user)); err != nil {
 			return err
 		}
-		a.setBridgeAlarmPicked(alarmID, false)
+		a.setBridgeAlarmPicked(ctx, alarmID, false)
 		return nil
 	}
 	return fmt.Errorf("групове завершення не підтримується для джерела %s", contracts.DetectFrontendSourceByObjectID(alarm.ObjectID))
@@ -386,33 +469,36 @@ func (a *FrontendAdapter) ListResponseGroups(ctx context.Context) ([]contracts.F
 		if err != nil {
 			return nil, err
 		}
-		result := make([]contracts.FrontendResponseGroup, 0, len(groups))
-		for _, g := range groups {
-			result = append(result, contracts.FrontendResponseGroup{
-				ID:              strings.TrimSpace(g.ID),
-				Name:            strings.TrimSpace(g.Name),
-				Callsign:        strings.TrimSpace(g.Callsign),
-				Phone:           strings.TrimSpace(g.Phone),
-				Source:          g.Source,
-				Status:          g.Status,
-				StatusText:      strings.TrimSpace(g.StatusText),
-				ObjectNumber:    strings.TrimSpace(g.ObjectNumber),
-				ObjectName:      strings.TrimSpace(g.ObjectName),
-				Latitude:        strings.TrimSpace(g.Latitude),
-				Longitude:       strings.TrimSpace(g.Longitude),
-				StatusChangedAt: g.StatusChangedAt,
-			})
-		}
-		return result, nil
+		return mapFrontendResponseGroups(groups), nil
 	}
 	return []contracts.FrontendResponseGroup{}, nil
 }
 
+// ListResponseGroupsForAlarm повертає групи джерела тривоги, впорядковані
+// радником диспетчеризації (якщо провайдер його підтримує).
+func (a *FrontendAdapter) ListResponseGroupsForAlarm(ctx context.Context, alarmID int) ([]contracts.FrontendResponseGroup, error) {
+	if a == nil || a.dataProvider == nil {
+		return nil, contracts.ErrFrontendBackendUnavailable
+	}
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
+	if err != nil {
+		return nil, err
+	}
+	if provider, ok := a.dataProvider.(contracts.AlarmResponseGroupProvider); ok {
+		groups, err := provider.ListResponseGroupsForAlarm(ctx, alarm)
+		if err != nil {
+			return nil, err
+		}
+		return mapFrontendResponseGroups(groups), nil
+	}
+	return a.ListResponseGroups(ctx)
+}
+
 func (a *FrontendAdapter) AssignResponseGroup(ctx context.Context, alarmID int, request contracts.FrontendAlarmGroupActionRequest) error {
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
@@ -429,7 +515,7 @@ func (a *FrontendAdapter) NotifyGroupArrived(ctx context.Context, alarmID int) e
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
@@ -446,7 +532,7 @@ func (a *FrontendAdapter) CancelResponseGroup(ctx context.Context, alarmID int)
 	if a == nil || a.dataProvider == nil {
 		return contracts.ErrFrontendBackendUnavailable
 	}
-	alarm, err := a.resolveAlarmByID(alarmID)
+	alarm, err := a.resolveAlarmByID(ctx, alarmID)
 	if err != nil {
 		return err
 	}
@@ -461,7 +547,14 @@ func (a *FrontendAdapter) CancelResponseGroup(ctx context.Context, alarmID int)
 
 func ensureAlarmActionOwnership(alarm models.Alarm) error {
 	source := contracts.DetectFrontendSourceByObjectID(alarm.ObjectID)
-	if source != contracts.FrontendSourceCASL && source != contracts.FrontendSourcePhoenix {
+	switch source {
+	case contracts.FrontendSourceCASL, contracts.FrontendSourcePhoenix:
+	case contracts.FrontendSourceBridge:
+		// Вільну тривогу МІСТ можна завершити без взяття в роботу, чужу — лише після перехоплення.
+		if !alarm.IsInProgress {
+			return nil
+		}
+	default:
 		return nil
 	}
 	if alarm.IsOwnedByMe {
@@ -478,12 +571,7 @@ func (a *FrontendAdapter) ListEvents(ctx context.Context) ([]contracts.FrontendE
 	if a == nil || a.dataProvider == nil {
 		return nil, contracts.ErrFrontendBackendUnavailable
 	}
-	var events []models.Event
-	if provider, ok := a.dataProvider.(contracts.ContextEventProvider); ok {
-		events = provider.GetEventsContext(ctx)
-	} else {
-		events = a.dataProvider.GetEvents()
-	}
+	events := a.eventsContext(ctx)
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -494,12 +582,41 @@ func (a *FrontendAdapter) ListEvents(ctx context.Context) ([]contracts.FrontendE
 	return result, nil
 }
 
-func (a *FrontendAdapter) resolveAlarmByID(alarmID int) (models.Alarm, error) {
+// QueryEvents returns the journal events matching a filter language expression.
+func (a *FrontendAdapter) QueryEvents(ctx context.Context, query string) ([]contracts.FrontendEventItem, error) {
+	if a == nil || a.dataProvider == nil {
+		return nil, contracts.ErrFrontendBackendUnavailable
+	}
+	filter, err := usecases.ParseFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	events := a.eventsContext(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result := make([]contracts.FrontendEventItem, 0, len(events))
+	for _, event := range events {
+		if filter.MatchEvent(event) {
+			result = append(result, mapFrontendEventItem(event))
+		}
+	}
+	return result, nil
+}
+
+func (a *FrontendAdapter) eventsContext(ctx context.Context) []models.Event {
+	if provider, ok := a.dataProvider.(contracts.ContextEventProvider); ok {
+		return provider.GetEventsContext(ctx)
+	}
+	return a.dataProvider.GetEvents()
+}
+
+func (a *FrontendAdapter) resolveAlarmByID(ctx context.Context, alarmID int) (models.Alarm, error) {
 	if alarmID <= 0 {
 		return models.Alarm{}, fmt.Errorf("invalid alarm id")
 	}
 
-	alarms := a.dataProvider.GetAlarms()
+	alarms := a.alarmsContext(ctx)
 	for _, alarm := range alarms {
 		if alarm.ID == alarmID {
 			return alarm, nil
@@ -564,6 +681,43 @@ func (a *FrontendAdapter) ListObjectEvents(ctx context.Context, objectID int, of
 	}, nil
 }
 
+// ExportEvents streams the filtered event journal of all sources into w as CSV or XLSX.
+// The filter is validated before anything is written, so request errors leave w untouched.
+func (a *FrontendAdapter) ExportEvents(ctx context.Context, request contracts.FrontendEventExportRequest, w io.Writer) (int, error) {
+	if a == nil || a.dataProvider == nil {
+		return 0, contracts.ErrFrontendBackendUnavailable
+	}
+	provider, ok := a.dataProvider.(eventexport.Provider)
+	if !ok {
+		return 0, contracts.ErrUnsupportedFrontendSource
+	}
+	filter := eventexport.Filter{
+		Sources:   request.Sources,
+		Severity:  strings.TrimSpace(request.Severity),
+		ObjectIDs: request.ObjectIDs,
+		From:      request.From,
+		To:        request.To,
+	}
+	for _, eventType := range request.Types {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			filter.Types = append(filter.Types, models.EventType(eventType))
+		}
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+	writer, err := objexport.NewEventStreamWriter(w, request.Format)
+	if err != nil {
+		return 0, err
+	}
+	written, err := eventexport.NewExporter().Export(ctx, provider, filter, writer)
+	if err != nil {
+		writer.Abort()
+		return written, err
+	}
+	return written, writer.Close()
+}
+
 func (a *FrontendAdapter) GetObjectDetails(ctx context.Context, objectID int) (contracts.FrontendObjectDetails, error) {
 	if a == nil || a.dataProvider == nil {
 		return contracts.FrontendObjectDetails{}, contracts.ErrFrontendBackendUnavailable
@@ -660,6 +814,21 @@ func (a *FrontendAdapter) CreateObject(ctx context.Context, request contracts.Fr
 			ObjectID: int(card.ObjN),
 			NativeID: strconv.FormatInt(card.ObjN, 10),
 		}, nil
+	case contracts.FrontendSourcePhoenix:
+		if a.phoenixMutator == nil {
+			return contracts.FrontendObjectMutationResult{}, fmt.Errorf("%w: %s", contracts.ErrUnsupportedFrontendSource, source)
+		}
+		card, err := buildLegacyAdminObjectCard(contracts.AdminObjectCard{}, request, false)
+		if err != nil {
+			return contracts.FrontendObjectMutationResult{}, err
+		}
+		if err := a.phoenixMutator.CreateObject(card); err != nil {
+			return contracts.FrontendObjectMutationResult{}, err
+		}
+		return contracts.FrontendObjectMutationResult{
+			Source:   source,
+			NativeID: strings.TrimSpace(card.PanelID),
+		}, nil
 	case contracts.FrontendSourceCASL:
 		if a.caslMutator == nil {
 			return contracts.FrontendObjectMutationResult{}, fmt.Errorf("%w: %s", contracts.ErrUnsupportedFrontendSource, source)
@@ -715,6 +884,31 @@ func (a *FrontendAdapter) UpdateObject(ctx context.Context, request contracts.Fr
 			ObjectID: int(card.ObjN),
 			NativeID: strconv.FormatInt(card.ObjN, 10),
 		}, nil
+	case contracts.FrontendSourcePhoenix:
+		if a.phoenixMutator == nil {
+			return contracts.FrontendObjectMutationResult{}, fmt.Errorf("%w: %s", contracts.ErrUnsupportedFrontendSource, source)
+		}
+		if request.ObjectID <= 0 {
+			return contracts.FrontendObjectMutationResult{}, fmt.Errorf("phoenix update requires object id")
+		}
+		base, err := a.phoenixMutator.GetObjectCard(int64(request.ObjectID))
+		if err != nil {
+			return contracts.FrontendObjectMutationResult{}, err
+		}
+		card, err := buildLegacyAdminObjectCard(base, request, true)
+		if err != nil {
+			return contracts.FrontendObjectMutationResult{}, err
+		}
+		card.ObjN = int64(request.ObjectID)
+		card.PanelID = base.PanelID
+		if err := a.phoenixMutator.UpdateObject(card); err != nil {
+			return contracts.FrontendObjectMutationResult{}, err
+		}
+		return contracts.FrontendObjectMutationResult{
+			Source:   source,
+			ObjectID: request.ObjectID,
+			NativeID: base.PanelID,
+		}, nil
 	case contracts.FrontendSourceCASL:
 		if a.caslMutator == nil {
 			return contracts.FrontendObjectMutationResult{}, fmt.Errorf("%w: %s", contracts.ErrUnsupportedFrontendSource, source)
@@ -757,6 +951,18 @@ func (a *FrontendAdapter) fallbackCapabilities() []contracts.FrontendSourceCapab
 			UpdateObject:      true,
 		})
 	}
+	if a.phoenixMutator != nil {
+		capabilities = append(capabilities, contracts.FrontendSourceCapability{
+			Source:            contracts.FrontendSourcePhoenix,
+			DisplayName:       contracts.FrontendSourcePhoenix.DisplayName(),
+			ReadObjects:       true,
+			ReadObjectDetails: true,
+			ReadEvents:        true,
+			ReadAlarms:        true,
+			CreateObject:      true,
+			UpdateObject:      true,
+		})
+	}
 	if a.caslMutator != nil {
 		capabilities = append(capabilities, contracts.FrontendSourceCapability{
 			Source:            contracts.FrontendSourceCASL,
@@ -816,6 +1022,9 @@ func buildLegacyAdminObjectCard(base contracts.AdminObjectCard, request contract
 	if payload.GrpN > 0 {
 		card.GrpN = payload.GrpN
 	}
+	if panelID := strings.TrimSpace(payload.PanelID); panelID != "" {
+		card.PanelID = panelID
+	}
 	if payload.ObjTypeID > 0 {
 		card.ObjTypeID = payload.ObjTypeID
 	}
@@ -1032,14 +1241,18 @@ func mapFrontendAlarmItem(alarm models.Alarm) contracts.FrontendAlarmItem {
 		IsInProgress: alarm.IsInProgress,
 		InProgressBy: strings.TrimSpace(alarm.InProgressBy),
 		IsOwnedByMe:  alarm.IsOwnedByMe,
-		CanTakeOver: (source == contracts.FrontendSourceCASL || source == contracts.FrontendSourcePhoenix) &&
+		CanTakeOver: (source == contracts.FrontendSourceCASL ||
+			source == contracts.FrontendSourcePhoenix ||
+			source == contracts.FrontendSourceBridge) &&
 			alarm.IsInProgress && !alarm.IsOwnedByMe,
 		CanProcess:                alarmCanBeProcessed(source, alarm),
 		ResponseGroupID:           strings.TrimSpace(alarm.ResponseGroupID),
 		IsResponseGroupDispatched: alarm.IsResponseGroupDispatched,
 		IsResponseGroupArrived:    alarm.IsResponseGroupArrived,
+		MaintenanceReason:         strings.TrimSpace(alarm.MaintenanceReason),
 		ObjectNativeID:            alarm.GetObjectNumberDisplay(),
 		VisualSeverity:            frontendAlarmSeverity(alarm),
+		Priority:                  contracts.FrontendAlarmPriority(alarm.Priority),
 	}
 }
 
@@ -1047,11 +1260,16 @@ func alarmCanBeProcessed(source contracts.FrontendSource, alarm models.Alarm) bo
 	if alarm.IsResponseGroupDispatched {
 		return false
 	}
+	switch alarm.Type {
+	case models.AlarmMaintenanceReminder, models.AlarmTestMissed, models.AlarmScheduleViolation:
+		// Локальні тривоги (вікна обслуговування, пропущені тести, розклад) підтверджуються без взяття в роботу.
+		return true
+	}
 	switch source {
 	case contracts.FrontendSourceCASL, contracts.FrontendSourcePhoenix:
 		return alarm.IsOwnedByMe
 	default:
-		return !alarm.IsInProgress || alarm.IsOwnedByMe || source == contracts.FrontendSourceBridge
+		return !alarm.IsInProgress || alarm.IsOwnedByMe
 	}
 }
 
@@ -1159,6 +1377,12 @@ func frontendMonitoringStatus(object models.Object) contracts.FrontendMonitoring
 
 func frontendAlarmSeverity(alarm models.Alarm) contracts.FrontendVisualSeverity {
 	switch alarm.Type {
+	case models.AlarmScheduleViolation:
+		// Необроблене порушення розкладу ескалюється до критичного.
+		if alarm.VisualSeverity == models.VisualSeverityCritical {
+			return contracts.FrontendVisualSeverityCritical
+		}
+		return contracts.FrontendVisualSeverityWarning
 	case models.AlarmFire,
 		models.AlarmBurglary,
 		models.AlarmPanic,
@@ -1174,7 +1398,9 @@ func frontendAlarmSeverity(alarm models.Alarm) contracts.FrontendVisualSeverity
 		models.AlarmBatteryLow,
 		models.AlarmOffline,
 		models.AlarmAcTrouble,
-		models.AlarmFireTrouble:
+		models.AlarmFireTrouble,
+		models.AlarmMaintenanceReminder,
+		models.AlarmTestMissed:
 		return contracts.FrontendVisualSeverityWarning
 	case models.AlarmEliminated,
 		models.AlarmNotification,
@@ -1,8 +1,10 @@
 package backend
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -312,6 +314,44 @@ func TestFrontendAdapterUpdateLegacyObjectMergesCurrentCard(t *testing.T) {
 	}
 }
 
+func TestFrontendAdapterUpdatePhoenixObjectKeepsPanelID(t *testing.T) {
+	objectID := ids.StablePhoenixID("L00042")
+	phoenix := &frontendTestAdminMutator{
+		currentCard: contracts.AdminObjectCard{
+			ObjN:      int64(objectID),
+			PanelID:   "L00042",
+			ShortName: "Магазин",
+			Address:   "Київ",
+		},
+	}
+	adapter := NewFrontendAdapter(
+		&frontendTestDataProvider{},
+		WithFrontendPhoenixObjectMutator(phoenix),
+	)
+
+	result, err := adapter.UpdateObject(context.Background(), contracts.FrontendObjectUpsertRequest{
+		ObjectID: objectID,
+		Core:     contracts.FrontendObjectCoreFields{Address: "Львів"},
+		Legacy:   &contracts.FrontendLegacyObjectPayload{PanelID: "L99999"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateObject() error = %v", err)
+	}
+	if result.Source != contracts.FrontendSourcePhoenix || result.NativeID != "L00042" {
+		t.Fatalf("UpdateObject() result = %+v", result)
+	}
+	if len(phoenix.updatedCards) != 1 {
+		t.Fatalf("UpdateObject() updated %d cards, want 1", len(phoenix.updatedCards))
+	}
+	updated := phoenix.updatedCards[0]
+	if updated.PanelID != "L00042" || updated.ObjN != int64(objectID) {
+		t.Fatalf("updated identity = %q/%d, panel id must not change", updated.PanelID, updated.ObjN)
+	}
+	if updated.Address != "Львів" || updated.ShortName != "Магазин" {
+		t.Fatalf("updated = %+v", updated)
+	}
+}
+
 func TestFrontendAdapterCreateCASLObject(t *testing.T) {
 	casl := &frontendTestCASLMutator{createID: "777"}
 	adapter := NewFrontendAdapter(
@@ -549,6 +589,53 @@ func TestFrontendAdapterListEventsAndAlarmsNormalizesVisualSeverity(t *testing.T
 	}
 }
 
+func TestFrontendAdapterListAlarmsKeepsPriority(t *testing.T) {
+	priority := models.AlarmPriority{Score: 125, Type: 100, Repeats: 5, Unattended: 20}
+	adapter := NewFrontendAdapter(&frontendTestDataProvider{
+		alarms: []models.Alarm{{ID: 3, ObjectID: 10, Type: models.AlarmFire, Priority: priority}},
+	})
+
+	alarms, err := adapter.ListAlarms(context.Background())
+	if err != nil {
+		t.Fatalf("ListAlarms() error = %v", err)
+	}
+	if len(alarms) != 1 || models.AlarmPriority(alarms[0].Priority) != priority {
+		t.Fatalf("ListAlarms() priority = %+v, want %+v", alarms, priority)
+	}
+}
+
+func TestFrontendAdapterQueryObjectsAndEvents(t *testing.T) {
+	adapter := NewFrontendAdapter(&frontendTestDataProvider{
+		objects: []models.Object{
+			{ID: ids.CASLObjectIDNamespaceStart + 1, Name: "Аптека", ConnectionStatus: models.ConnectionStatusOffline},
+			{ID: ids.CASLObjectIDNamespaceStart + 2, Name: "Склад", ConnectionStatus: models.ConnectionStatusOnline},
+			{ID: 7, Name: "Аптека МІСТ", ConnectionStatus: models.ConnectionStatusOffline},
+		},
+		events: []models.Event{
+			{ID: 1, ObjectID: 7, Type: models.EventFire},
+			{ID: 2, ObjectID: 7, Type: models.EventRestore},
+		},
+	})
+
+	objects, err := adapter.QueryObjects(context.Background(), "source:casl status:offline")
+	if err != nil {
+		t.Fatalf("QueryObjects() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].Name != "Аптека" {
+		t.Fatalf("QueryObjects() = %+v, want only the offline CASL object", objects)
+	}
+	events, err := adapter.QueryEvents(context.Background(), "severity:critical")
+	if err != nil {
+		t.Fatalf("QueryEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ID != 1 {
+		t.Fatalf("QueryEvents() = %+v, want only the fire event", events)
+	}
+	if _, err := adapter.QueryObjects(context.Background(), "status:sleeping"); err == nil {
+		t.Fatal("QueryObjects() must reject an invalid query")
+	}
+}
+
 func TestFrontendAdapterListObjectEventsReturnsSortedPage(t *testing.T) {
 	adapter := NewFrontendAdapter(&frontendTestDataProvider{
 		objectByID: map[string]models.Object{
@@ -643,3 +730,130 @@ func TestFrontendAdapterErrorsWithoutPayload(t *testing.T) {
 		t.Fatalf("CreateObject() error = %v, want missing payload or unsupported source", err)
 	}
 }
+
+type frontendBridgeTakeoverProvider struct {
+	*frontendTestDataProvider
+	takeoverReason string
+}
+
+func (p *frontendBridgeTakeoverProvider) PickAlarm(context.Context, models.Alarm, string) error {
+	return nil
+}
+
+func (p *frontendBridgeTakeoverProvider) TakeOverAlarm(_ context.Context, _ models.Alarm, _ string, reason string) error {
+	p.takeoverReason = reason
+	return nil
+}
+
+func TestFrontendAdapterPickAlarmRequiresReasonForForeignBridgeAlarm(t *testing.T) {
+	alarm := models.Alarm{
+		ID:           15,
+		ObjectID:     15,
+		IsInProgress: true,
+		InProgressBy: "Оператор 2 (pc-2)",
+	}
+	provider := &frontendBridgeTakeoverProvider{
+		frontendTestDataProvider: &frontendTestDataProvider{alarms: []models.Alarm{alarm}},
+	}
+	adapter := NewFrontendAdapter(provider)
+
+	err := adapter.PickAlarm(context.Background(), alarm.ID, contracts.FrontendAlarmPickRequest{User: "Диспетчер"})
+	if !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("PickAlarm() without reason error = %v", err)
+	}
+	err = adapter.PickAlarm(context.Background(), alarm.ID, contracts.FrontendAlarmPickRequest{
+		User:   "Диспетчер",
+		Reason: " оператор відійшов ",
+	})
+	if err != nil {
+		t.Fatalf("PickAlarm() takeover error = %v", err)
+	}
+	if provider.takeoverReason != "оператор відійшов" {
+		t.Fatalf("takeover reason = %q", provider.takeoverReason)
+	}
+}
+
+func TestFrontendAlarmCapabilitiesForForeignBridgeAlarm(t *testing.T) {
+	item := mapFrontendAlarmItem(models.Alarm{
+		ObjectID:     15,
+		IsInProgress: true,
+		InProgressBy: "Оператор 2",
+	})
+	if item.CanProcess || !item.CanTakeOver {
+		t.Fatalf("foreign bridge alarm capabilities = process %v, takeover %v", item.CanProcess, item.CanTakeOver)
+	}
+	if err := ensureAlarmActionOwnership(models.Alarm{ObjectID: 15}); err != nil {
+		t.Fatalf("free bridge alarm must be processable directly, got %v", err)
+	}
+}
+
+type frontendRangeEventsProvider struct {
+	*frontendTestDataProvider
+}
+
+func (p *frontendRangeEventsProvider) GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event {
+	var result []models.Event
+	for _, event := range p.GetObjectEvents(objectID) {
+		if !event.Time.Before(from) && !event.Time.After(to) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func TestFrontendAdapterExportEvents(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	provider := &frontendRangeEventsProvider{frontendTestDataProvider: &frontendTestDataProvider{
+		objects: []models.Object{{ID: 7}},
+		objectEventsByID: map[string][]models.Event{"7": {
+			{ID: 1, ObjectID: 7, ObjectNumber: "007", Type: models.EventFire, Time: day.Add(time.Hour)},
+			{ID: 2, ObjectID: 7, ObjectNumber: "007", Type: models.EventArm, Time: day.Add(2 * time.Hour)},
+		}},
+	}}
+	request := contracts.FrontendEventExportRequest{
+		Format: "csv",
+		Types:  []string{"fire"},
+		From:   day,
+		To:     day.Add(24 * time.Hour),
+	}
+
+	var out bytes.Buffer
+	written, err := NewFrontendAdapter(provider).ExportEvents(context.Background(), request, &out)
+	if err != nil || written != 1 {
+		t.Fatalf("ExportEvents() = %d, %v", written, err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\r\n"); len(lines) != 2 || !strings.Contains(lines[1], "007") {
+		t.Fatalf("csv = %q", out.String())
+	}
+
+	out.Reset()
+	request.Severity = "loud"
+	if _, err := NewFrontendAdapter(provider).ExportEvents(context.Background(), request, &out); err == nil || out.Len() != 0 {
+		t.Fatalf("invalid filter must fail before writing, err = %v, out = %q", err, out.String())
+	}
+	if _, err := NewFrontendAdapter(provider.frontendTestDataProvider).ExportEvents(context.Background(), request, &out); !errors.Is(err, contracts.ErrUnsupportedFrontendSource) {
+		t.Fatalf("provider without range queries error = %v", err)
+	}
+}
+
+func TestFrontendAdapterKeepsLocalBridgePicksPerClient(t *testing.T) {
+	alarm := models.Alarm{ID: 15, ObjectID: 15}
+	provider := &frontendBridgeTakeoverProvider{
+		frontendTestDataProvider: &frontendTestDataProvider{alarms: []models.Alarm{alarm}},
+	}
+	adapter := NewFrontendAdapter(provider)
+	first := contracts.WithFrontendClient(context.Background(), contracts.FrontendClient{ID: "ws-1"})
+	second := contracts.WithFrontendClient(context.Background(), contracts.FrontendClient{ID: "ws-2"})
+
+	if err := adapter.PickAlarm(first, alarm.ID, contracts.FrontendAlarmPickRequest{User: "Диспетчер"}); err != nil {
+		t.Fatalf("PickAlarm() error = %v", err)
+	}
+	own, err := adapter.ListAlarms(first)
+	if err != nil || len(own) != 1 || !own[0].IsOwnedByMe {
+		t.Fatalf("ws-1 alarms = %+v, %v; want owned", own, err)
+	}
+	foreign, err := adapter.ListAlarms(second)
+	if err != nil || len(foreign) != 1 || foreign[0].IsOwnedByMe {
+		t.Fatalf("ws-2 alarms = %+v, %v; want not owned", foreign, err)
+	}
+}
@@ -0,0 +1,108 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/data"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// actAlarmsDriver віддає один рядок ACTALARMS для об'єкта 42.
+type actAlarmsDriver struct{}
+
+func (actAlarmsDriver) Open(string) (driver.Conn, error) { return actAlarmsConn{}, nil }
+
+type actAlarmsConn struct{}
+
+func (actAlarmsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (actAlarmsConn) Close() error                        { return nil }
+func (actAlarmsConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+
+func (actAlarmsConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "FROM ACTALARMS") {
+		return nil, errors.New("unexpected query")
+	}
+	return &actAlarmsRows{}, nil
+}
+
+type actAlarmsRows struct {
+	done bool
+}
+
+func (r *actAlarmsRows) Columns() []string {
+	return []string{"EVTIME1", "OBJN", "OBJSHORTNAME1", "ADDRESS1", "ZONEN", "UKR1", "INFO1", "SC1"}
+}
+
+func (r *actAlarmsRows) Close() error { return nil }
+
+func (r *actAlarmsRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	values := []driver.Value{time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), int64(42), "Склад", "вул. Польова, 1", int64(3), "Пожежа", "", int64(1)}
+	copy(dest, values)
+	return nil
+}
+
+// processRecordingBridgeProvider завершує тривоги без запису в БД.
+type processRecordingBridgeProvider struct {
+	*data.DBDataProvider
+	processedBy []string
+}
+
+func (p *processRecordingBridgeProvider) ProcessAlarmWithRequest(ctx context.Context, _ models.Alarm, user string, _ contracts.AlarmProcessingRequest) error {
+	client, _ := contracts.FrontendClientFromContext(ctx)
+	p.processedBy = append(p.processedBy, client.ID+"/"+user)
+	return nil
+}
+
+func TestFrontendAdapterBridgeLeaseFollowsClientThroughAlarmList(t *testing.T) {
+	const driverName = "frontend-bridge-lease-test"
+	sql.Register(driverName, actAlarmsDriver{})
+	rawDB, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = rawDB.Close() })
+
+	provider := &processRecordingBridgeProvider{
+		DBDataProvider: data.NewDBDataProvider(sqlx.NewDb(rawDB, driverName), "", data.WithAlarmLeaseStore(data.NewMemoryAlarmLeaseStore())),
+	}
+	adapter := NewFrontendAdapter(provider)
+	first := contracts.WithFrontendClient(context.Background(), contracts.FrontendClient{ID: "ws-1", Workstation: "10.0.0.1"})
+	second := contracts.WithFrontendClient(context.Background(), contracts.FrontendClient{ID: "ws-2", Workstation: "10.0.0.2"})
+
+	if err := adapter.PickAlarm(first, 42, contracts.FrontendAlarmPickRequest{User: "Оператор 1"}); err != nil {
+		t.Fatalf("PickAlarm(ws-1) error = %v", err)
+	}
+
+	alarms, err := adapter.ListAlarms(first)
+	if err != nil || len(alarms) != 1 || !alarms[0].IsOwnedByMe {
+		t.Fatalf("ws-1 alarms = %+v, %v; want own alarm", alarms, err)
+	}
+	alarms, err = adapter.ListAlarms(second)
+	if err != nil || len(alarms) != 1 || alarms[0].IsOwnedByMe || alarms[0].InProgressBy != "Оператор 1 (10.0.0.1)" {
+		t.Fatalf("ws-2 alarms = %+v, %v; want foreign alarm", alarms, err)
+	}
+
+	if err := adapter.ProcessAlarm(second, 42, contracts.FrontendAlarmProcessRequest{User: "Оператор 2"}); !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("ProcessAlarm(ws-2) error = %v, want ownership conflict", err)
+	}
+	if err := adapter.ProcessAlarm(first, 42, contracts.FrontendAlarmProcessRequest{User: "Оператор 1"}); err != nil {
+		t.Fatalf("ProcessAlarm(ws-1) error = %v", err)
+	}
+	if len(provider.processedBy) != 1 || provider.processedBy[0] != "ws-1/Оператор 1" {
+		t.Fatalf("processed by = %v", provider.processedBy)
+	}
+}
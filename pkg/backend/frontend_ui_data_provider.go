@@ -361,6 +361,15 @@ func (p *FrontendUIDataProvider) ProcessAlarmWithRequest(ctx context.Context, al
 	})
 }
 
+// GroupProcessAlarm завершує всі тривоги об'єкта тривоги через
+// FrontendBackend.GroupProcessAlarm (contracts.AlarmGroupProcessProvider).
+func (p *FrontendUIDataProvider) GroupProcessAlarm(ctx context.Context, alarm models.Alarm, user string) error {
+	if p == nil || p.frontend == nil {
+		return contracts.ErrFrontendBackendUnavailable
+	}
+	return p.frontend.GroupProcessAlarm(ctx, alarm.ID, strings.TrimSpace(user))
+}
+
 func (p *FrontendUIDataProvider) PickAlarm(ctx context.Context, alarm models.Alarm, user string) error {
 	if p == nil || p.frontend == nil {
 		return contracts.ErrFrontendBackendUnavailable
@@ -368,6 +377,16 @@ func (p *FrontendUIDataProvider) PickAlarm(ctx context.Context, alarm models.Ala
 	return p.frontend.PickAlarm(ctx, alarm.ID, contracts.FrontendAlarmPickRequest{User: strings.TrimSpace(user)})
 }
 
+func (p *FrontendUIDataProvider) TakeOverAlarm(ctx context.Context, alarm models.Alarm, user string, reason string) error {
+	if p == nil || p.frontend == nil {
+		return contracts.ErrFrontendBackendUnavailable
+	}
+	return p.frontend.PickAlarm(ctx, alarm.ID, contracts.FrontendAlarmPickRequest{
+		User:   strings.TrimSpace(user),
+		Reason: strings.TrimSpace(reason),
+	})
+}
+
 func (p *FrontendUIDataProvider) ListResponseGroupsForAlarm(ctx context.Context, alarm models.Alarm) ([]contracts.FrontendResponseGroup, error) {
 	if provider, ok := p.fallback.(contracts.AlarmResponseGroupProvider); ok {
 		groups, err := provider.ListResponseGroupsForAlarm(ctx, alarm)
@@ -376,6 +395,11 @@ func (p *FrontendUIDataProvider) ListResponseGroupsForAlarm(ctx context.Context,
 		}
 		return mapFrontendResponseGroups(groups), nil
 	}
+	if p != nil && p.frontend != nil {
+		if backend, ok := p.frontend.(contracts.FrontendAlarmResponseGroupsBackend); ok {
+			return backend.ListResponseGroupsForAlarm(ctx, alarm.ID)
+		}
+	}
 
 	groups, err := p.ListResponseGroups(ctx)
 	if err != nil {
@@ -407,6 +431,7 @@ func mapFrontendResponseGroups(groups []contracts.ResponseGroup) []contracts.Fro
 			Latitude:        strings.TrimSpace(group.Latitude),
 			Longitude:       strings.TrimSpace(group.Longitude),
 			StatusChangedAt: group.StatusChangedAt,
+			Suggestion:      group.Suggestion,
 		})
 	}
 	return result
@@ -668,6 +693,7 @@ func mergeFrontendAlarm(alarm *models.Alarm, item contracts.FrontendAlarmItem) {
 	alarm.IsResponseGroupDispatched = item.IsResponseGroupDispatched
 	alarm.IsResponseGroupArrived = item.IsResponseGroupArrived
 	alarm.VisualSeverity = modelVisualSeverity(item.VisualSeverity)
+	alarm.Priority = models.AlarmPriority(item.Priority)
 	if alarm.SC1 == 0 {
 		alarm.SC1 = modelSC1FromSeverity(item.VisualSeverity)
 	}
@@ -61,10 +61,27 @@ func AsAdminProvider(provider contracts.DataProvider) (contracts.AdminProvider,
 	return admin, true
 }
 
+// AsPhoenixObjectAdmin returns Phoenix panel administration when a Phoenix source is connected.
+func AsPhoenixObjectAdmin(provider contracts.DataProvider) (contracts.PhoenixObjectAdminProvider, bool) {
+	source, ok := provider.(contracts.PhoenixObjectAdminSource)
+	if !ok {
+		return nil, false
+	}
+	admin := source.PhoenixObjectAdmin()
+	if admin == nil {
+		return nil, false
+	}
+	return admin, true
+}
+
 var _ contracts.DataProvider = (*data.DBDataProvider)(nil)
 var _ contracts.AdminProvider = (*data.DBDataProvider)(nil)
 var _ contracts.DataProvider = (*data.PhoenixDataProvider)(nil)
+var _ contracts.PhoenixObjectAdminSource = (*data.PhoenixDataProvider)(nil)
 var _ contracts.DataProvider = (*data.CASLCloudProvider)(nil)
 var _ contracts.DataProvider = (*data.CombinedDataProvider)(nil)
+var _ contracts.LoadedObjectsProvider = (*data.CombinedDataProvider)(nil)
 var _ config.VodafoneConfigStore = (*config.PreferencesVodafoneConfigStore)(nil)
 var _ config.KyivstarConfigStore = (*config.PreferencesKyivstarConfigStore)(nil)
+var _ config.ReportUploadConfigStore = (*config.PreferencesReportUploadConfigStore)(nil)
+var _ config.DispatchConfigStore = (*config.PreferencesDispatchConfigStore)(nil)
@@ -0,0 +1,301 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	frontendv1 "obj_catalog_fyne_v3/pkg/frontendapi/v1"
+	"obj_catalog_fyne_v3/pkg/frontendhttp"
+)
+
+const remoteFrontendTimeout = 30 * time.Second
+
+// RemoteFrontendBackend реалізує contracts.FrontendBackend поверх HTTP API
+// frontendapi/v1, який публікує cmd/operator-server. Оболонки Wails і Qt
+// використовують його замість власних підключень до БД і CASL.
+type RemoteFrontendBackend struct {
+	baseURL  string
+	token    string
+	clientID string
+	client   *http.Client
+}
+
+type RemoteFrontendOption func(*RemoteFrontendBackend)
+
+// WithRemoteFrontendToken додає Authorization: Bearer до кожного запиту.
+func WithRemoteFrontendToken(token string) RemoteFrontendOption {
+	return func(b *RemoteFrontendBackend) {
+		b.token = strings.TrimSpace(token)
+	}
+}
+
+// WithRemoteFrontendClientID задає ідентифікатор робочого місця для сесії на сервері.
+func WithRemoteFrontendClientID(clientID string) RemoteFrontendOption {
+	return func(b *RemoteFrontendBackend) {
+		b.clientID = strings.TrimSpace(clientID)
+	}
+}
+
+func WithRemoteFrontendHTTPClient(client *http.Client) RemoteFrontendOption {
+	return func(b *RemoteFrontendBackend) {
+		if client != nil {
+			b.client = client
+		}
+	}
+}
+
+// NewRemoteFrontendBackend приймає адресу сервера, наприклад http://10.0.0.5:8090.
+func NewRemoteFrontendBackend(serverURL string, opts ...RemoteFrontendOption) (*RemoteFrontendBackend, error) {
+	parsed, err := url.Parse(strings.TrimSpace(serverURL))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("invalid operator server url %q", serverURL)
+	}
+	b := &RemoteFrontendBackend{
+		baseURL: strings.TrimSuffix(parsed.String(), "/") + frontendhttp.APIV1BasePath,
+		client:  &http.Client{Timeout: remoteFrontendTimeout},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	return b, nil
+}
+
+// RemoteFrontendError — помилка, яку повернув сервер. Відомі помилки contracts
+// доступні через errors.Is.
+type RemoteFrontendError struct {
+	Status  int
+	Message string
+	cause   error
+}
+
+func (e *RemoteFrontendError) Error() string {
+	return fmt.Sprintf("operator server: %d %s", e.Status, e.Message)
+}
+
+func (e *RemoteFrontendError) Unwrap() error {
+	return e.cause
+}
+
+var remoteFrontendKnownErrors = []error{
+	contracts.ErrAlarmOwnershipConflict,
+	contracts.ErrMissingLegacyObjectPayload,
+	contracts.ErrMissingCASLObjectPayload,
+	contracts.ErrUnsupportedFrontendSource,
+	contracts.ErrFrontendBackendUnavailable,
+}
+
+func newRemoteFrontendError(status int, message string) *RemoteFrontendError {
+	result := &RemoteFrontendError{Status: status, Message: message}
+	for _, known := range remoteFrontendKnownErrors {
+		if strings.Contains(message, known.Error()) {
+			result.cause = known
+			return result
+		}
+	}
+	switch status {
+	case http.StatusServiceUnavailable:
+		result.cause = contracts.ErrFrontendBackendUnavailable
+	case http.StatusNotImplemented:
+		result.cause = contracts.ErrUnsupportedFrontendSource
+	}
+	return result
+}
+
+func (b *RemoteFrontendBackend) do(ctx context.Context, method string, path string, request any, response any) error {
+	if b == nil {
+		return contracts.ErrFrontendBackendUnavailable
+	}
+	var body io.Reader
+	if request != nil {
+		payload, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("encode %s %s: %w", method, path, err)
+		}
+		body = bytes.NewReader(payload)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	if b.token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	if b.clientID != "" {
+		httpRequest.Header.Set(frontendhttp.ClientIDHeader, b.clientID)
+	}
+
+	httpResponse, err := b.client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("%w: %v", contracts.ErrFrontendBackendUnavailable, err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		var apiError frontendv1.ErrorResponse
+		raw, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 64<<10))
+		message := strings.TrimSpace(string(raw))
+		if json.Unmarshal(raw, &apiError) == nil && apiError.Error != "" {
+			message = apiError.Error
+		}
+		return newRemoteFrontendError(httpResponse.StatusCode, message)
+	}
+	if response == nil {
+		_, _ = io.Copy(io.Discard, httpResponse.Body)
+		return nil
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(response); err != nil {
+		return fmt.Errorf("decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func (b *RemoteFrontendBackend) Capabilities(ctx context.Context) (contracts.FrontendCapabilities, error) {
+	var response frontendv1.Capabilities
+	if err := b.do(ctx, http.MethodGet, "/capabilities", nil, &response); err != nil {
+		return contracts.FrontendCapabilities{}, err
+	}
+	return frontendv1.FromCapabilities(response), nil
+}
+
+func (b *RemoteFrontendBackend) ListObjects(ctx context.Context) ([]contracts.FrontendObjectSummary, error) {
+	var response frontendv1.ObjectListResponse
+	if err := b.do(ctx, http.MethodGet, "/objects", nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromObjectListResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) ListAlarms(ctx context.Context) ([]contracts.FrontendAlarmItem, error) {
+	var response frontendv1.AlarmListResponse
+	if err := b.do(ctx, http.MethodGet, "/alarms", nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromAlarmListResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) GetAlarmProcessingOptions(ctx context.Context, alarmID int) ([]contracts.FrontendAlarmProcessingOption, error) {
+	var response frontendv1.AlarmProcessingOptionsResponse
+	if err := b.do(ctx, http.MethodGet, alarmPath(alarmID, "processing-options"), nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromAlarmProcessingOptionsResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) ListAlarmProcessingOptionsCached(ctx context.Context) ([]contracts.FrontendAlarmProcessingOption, error) {
+	var response frontendv1.AlarmProcessingOptionsResponse
+	if err := b.do(ctx, http.MethodGet, "/alarm-processing-options", nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromAlarmProcessingOptionsResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) PickAlarm(ctx context.Context, alarmID int, request contracts.FrontendAlarmPickRequest) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "pick"), frontendv1.ToAlarmPickRequest(request), nil)
+}
+
+func (b *RemoteFrontendBackend) ProcessAlarm(ctx context.Context, alarmID int, request contracts.FrontendAlarmProcessRequest) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "process"), frontendv1.ToAlarmProcessRequest(request), nil)
+}
+
+func (b *RemoteFrontendBackend) GroupProcessAlarm(ctx context.Context, alarmID int, user string) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "group-process"), frontendv1.AlarmGroupProcessRequest{User: user}, nil)
+}
+
+func (b *RemoteFrontendBackend) StandbyObject(ctx context.Context, objectID int, request contracts.FrontendStandbyRequest) error {
+	return b.do(ctx, http.MethodPost, "/objects/"+strconv.Itoa(objectID)+"/standby", frontendv1.ToStandbyRequest(request), nil)
+}
+
+func (b *RemoteFrontendBackend) ListResponseGroups(ctx context.Context) ([]contracts.FrontendResponseGroup, error) {
+	var response frontendv1.ResponseGroupListResponse
+	if err := b.do(ctx, http.MethodGet, "/response-groups", nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromResponseGroupListResponse(response), nil
+}
+
+// ListResponseGroupsForAlarm повертає групи з рекомендаціями сервера для тривоги.
+func (b *RemoteFrontendBackend) ListResponseGroupsForAlarm(ctx context.Context, alarmID int) ([]contracts.FrontendResponseGroup, error) {
+	var response frontendv1.ResponseGroupListResponse
+	if err := b.do(ctx, http.MethodGet, alarmPath(alarmID, "response-groups"), nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromResponseGroupListResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) AssignResponseGroup(ctx context.Context, alarmID int, request contracts.FrontendAlarmGroupActionRequest) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "assign-group"), frontendv1.ToAlarmGroupActionRequest(request), nil)
+}
+
+func (b *RemoteFrontendBackend) NotifyGroupArrived(ctx context.Context, alarmID int) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "group-arrived"), nil, nil)
+}
+
+func (b *RemoteFrontendBackend) CancelResponseGroup(ctx context.Context, alarmID int) error {
+	return b.do(ctx, http.MethodPost, alarmPath(alarmID, "cancel-group"), nil, nil)
+}
+
+func (b *RemoteFrontendBackend) ListEvents(ctx context.Context) ([]contracts.FrontendEventItem, error) {
+	var response frontendv1.EventListResponse
+	if err := b.do(ctx, http.MethodGet, "/events", nil, &response); err != nil {
+		return nil, err
+	}
+	return frontendv1.FromEventListResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) ListObjectEvents(ctx context.Context, objectID int, offset int, limit int) (contracts.FrontendEventPage, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	var response frontendv1.EventPageResponse
+	if err := b.do(ctx, http.MethodGet, "/objects/"+strconv.Itoa(objectID)+"/events?"+query.Encode(), nil, &response); err != nil {
+		return contracts.FrontendEventPage{}, err
+	}
+	return frontendv1.FromEventPageResponse(response), nil
+}
+
+func (b *RemoteFrontendBackend) GetObjectDetails(ctx context.Context, objectID int) (contracts.FrontendObjectDetails, error) {
+	var response frontendv1.ObjectDetails
+	if err := b.do(ctx, http.MethodGet, "/objects/"+strconv.Itoa(objectID), nil, &response); err != nil {
+		return contracts.FrontendObjectDetails{}, err
+	}
+	return frontendv1.FromObjectDetails(response), nil
+}
+
+func (b *RemoteFrontendBackend) CreateObject(ctx context.Context, request contracts.FrontendObjectUpsertRequest) (contracts.FrontendObjectMutationResult, error) {
+	var response frontendv1.ObjectMutationResult
+	if err := b.do(ctx, http.MethodPost, "/objects", frontendv1.ToObjectUpsertRequest(request), &response); err != nil {
+		return contracts.FrontendObjectMutationResult{}, err
+	}
+	return frontendv1.FromObjectMutationResult(response), nil
+}
+
+func (b *RemoteFrontendBackend) UpdateObject(ctx context.Context, request contracts.FrontendObjectUpsertRequest) (contracts.FrontendObjectMutationResult, error) {
+	if request.ObjectID <= 0 {
+		return contracts.FrontendObjectMutationResult{}, errors.New("object id is required")
+	}
+	var response frontendv1.ObjectMutationResult
+	if err := b.do(ctx, http.MethodPut, "/objects/"+strconv.Itoa(request.ObjectID), frontendv1.ToObjectUpsertRequest(request), &response); err != nil {
+		return contracts.FrontendObjectMutationResult{}, err
+	}
+	return frontendv1.FromObjectMutationResult(response), nil
+}
+
+func alarmPath(alarmID int, action string) string {
+	return "/alarms/" + strconv.Itoa(alarmID) + "/" + action
+}
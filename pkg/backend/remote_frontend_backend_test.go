@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/frontendhttp"
+)
+
+type remoteFrontendStub struct {
+	contracts.FrontendBackend
+
+	alarms        []contracts.FrontendAlarmItem
+	processed     contracts.FrontendAlarmProcessRequest
+	processedID   int
+	pickErr       error
+	eventsOffset  int
+	eventsLimit   int
+	authorization string
+	clientID      string
+}
+
+func (s *remoteFrontendStub) ListAlarms(context.Context) ([]contracts.FrontendAlarmItem, error) {
+	return s.alarms, nil
+}
+
+func (s *remoteFrontendStub) ProcessAlarm(_ context.Context, alarmID int, request contracts.FrontendAlarmProcessRequest) error {
+	s.processedID = alarmID
+	s.processed = request
+	return nil
+}
+
+func (s *remoteFrontendStub) PickAlarm(context.Context, int, contracts.FrontendAlarmPickRequest) error {
+	return s.pickErr
+}
+
+func (s *remoteFrontendStub) ListObjectEvents(_ context.Context, _ int, offset int, limit int) (contracts.FrontendEventPage, error) {
+	s.eventsOffset = offset
+	s.eventsLimit = limit
+	return contracts.FrontendEventPage{TotalCount: 3}, nil
+}
+
+func newRemoteFrontendTestServer(t *testing.T, stub *remoteFrontendStub) *httptest.Server {
+	t.Helper()
+	handler := frontendhttp.NewHandler(stub)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.authorization = r.Header.Get("Authorization")
+		stub.clientID = r.Header.Get(frontendhttp.ClientIDHeader)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteFrontendBackendRoundTrip(t *testing.T) {
+	alarmTime := time.Date(2026, 3, 1, 10, 30, 0, 0, time.Local)
+	stub := &remoteFrontendStub{alarms: []contracts.FrontendAlarmItem{{
+		ID:         11,
+		Source:     contracts.FrontendSourceBridge,
+		ObjectID:   7,
+		ObjectName: "Школа",
+		Time:       alarmTime,
+		ZoneNumber: 2,
+	}}}
+	server := newRemoteFrontendTestServer(t, stub)
+
+	remote, err := NewRemoteFrontendBackend(server.URL+"/",
+		WithRemoteFrontendToken("secret"),
+		WithRemoteFrontendClientID("ws-1"),
+	)
+	if err != nil {
+		t.Fatalf("NewRemoteFrontendBackend() error = %v", err)
+	}
+
+	alarms, err := remote.ListAlarms(context.Background())
+	if err != nil {
+		t.Fatalf("ListAlarms() error = %v", err)
+	}
+	if len(alarms) != 1 || alarms[0].ID != 11 || alarms[0].ObjectName != "Школа" || alarms[0].ZoneNumber != 2 {
+		t.Fatalf("unexpected alarms: %+v", alarms)
+	}
+	if !alarms[0].Time.Equal(alarmTime) {
+		t.Fatalf("alarm time = %v, want %v", alarms[0].Time, alarmTime)
+	}
+	if stub.authorization != "Bearer secret" || stub.clientID != "ws-1" {
+		t.Fatalf("headers = %q/%q", stub.authorization, stub.clientID)
+	}
+
+	if err := remote.ProcessAlarm(context.Background(), 11, contracts.FrontendAlarmProcessRequest{User: "operator", Note: "хибна"}); err != nil {
+		t.Fatalf("ProcessAlarm() error = %v", err)
+	}
+	if stub.processedID != 11 || stub.processed.User != "operator" || stub.processed.Note != "хибна" {
+		t.Fatalf("unexpected process request: %d %+v", stub.processedID, stub.processed)
+	}
+
+	page, err := remote.ListObjectEvents(context.Background(), 7, 20, 50)
+	if err != nil {
+		t.Fatalf("ListObjectEvents() error = %v", err)
+	}
+	if page.TotalCount != 3 || stub.eventsOffset != 20 || stub.eventsLimit != 50 {
+		t.Fatalf("unexpected page %+v offset=%d limit=%d", page, stub.eventsOffset, stub.eventsLimit)
+	}
+}
+
+func TestRemoteFrontendBackendMapsServerErrors(t *testing.T) {
+	stub := &remoteFrontendStub{pickErr: contracts.ErrAlarmOwnershipConflict}
+	server := newRemoteFrontendTestServer(t, stub)
+	remote, err := NewRemoteFrontendBackend(server.URL)
+	if err != nil {
+		t.Fatalf("NewRemoteFrontendBackend() error = %v", err)
+	}
+
+	err = remote.PickAlarm(context.Background(), 1, contracts.FrontendAlarmPickRequest{User: "operator"})
+	if !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("PickAlarm() error = %v, want ownership conflict", err)
+	}
+
+	server.Close()
+	if _, err := remote.ListAlarms(context.Background()); !errors.Is(err, contracts.ErrFrontendBackendUnavailable) {
+		t.Fatalf("ListAlarms() on closed server error = %v, want unavailable", err)
+	}
+}
+
+func TestNewRemoteFrontendBackendRejectsInvalidURL(t *testing.T) {
+	for _, raw := range []string{"", "10.0.0.5:8090", "ftp://host"} {
+		if _, err := NewRemoteFrontendBackend(raw); err == nil {
+			t.Errorf("NewRemoteFrontendBackend(%q) error = nil", raw)
+		}
+	}
+}
@@ -80,6 +80,19 @@ func (c *Client) Publish(topic string, payload any) error {
 	return nil
 }
 
+// PublishRaw sends an already encoded payload as is. A nil body sends a
+// single topic frame, matching messages recorded with one frame.
+func (c *Client) PublishRaw(topic string, body []byte) error {
+	msg := zmq4.NewMsgFrom([]byte(topic))
+	if body != nil {
+		msg = zmq4.NewMsgFrom([]byte(topic), body)
+	}
+	if err := c.pub.Send(msg); err != nil {
+		return fmt.Errorf("broker: send to %q: %w", topic, err)
+	}
+	return nil
+}
+
 // Recv blocks until a message arrives on the SUB socket.
 // Returns (topic, rawJSON, error).
 func (c *Client) Recv() (string, []byte, error) {
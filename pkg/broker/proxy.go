@@ -0,0 +1,99 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/go-zeromq/zmq4"
+)
+
+// Proxy is a local stand-in for the CASL broker. It listens on the same two
+// ports as the real broker and forwards everything published on subPort to
+// the subscribers of pubPort, so casl-bridge, CASLCloudProvider and the
+// sniffer replay can run against it without changes.
+//
+// The inbound side is a SUB socket subscribed to all topics rather than
+// XSUB: zmq4 only resends subscriptions to newly connected publishers for
+// SUB sockets, and PUB peers filter by those subscriptions.
+type Proxy struct {
+	in     zmq4.Socket
+	out    zmq4.Socket
+	cancel context.CancelFunc
+}
+
+// NewProxy starts listening on host:pubPort (subscribers) and host:subPort
+// (publishers). Port 0 picks a free port; see PubAddr and SubAddr.
+func NewProxy(ctx context.Context, host string, pubPort, subPort int) (*Proxy, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	out := zmq4.NewPub(ctx)
+	outEndpoint := "tcp://" + net.JoinHostPort(host, strconv.Itoa(pubPort))
+	if err := out.Listen(outEndpoint); err != nil {
+		cancel()
+		return nil, fmt.Errorf("broker: listen pub endpoint %s: %w", outEndpoint, err)
+	}
+
+	in := zmq4.NewSub(ctx)
+	if err := in.SetOption(zmq4.OptionSubscribe, ""); err != nil {
+		_ = out.Close()
+		cancel()
+		return nil, fmt.Errorf("broker: subscribe proxy input: %w", err)
+	}
+	inEndpoint := "tcp://" + net.JoinHostPort(host, strconv.Itoa(subPort))
+	if err := in.Listen(inEndpoint); err != nil {
+		_ = out.Close()
+		cancel()
+		return nil, fmt.Errorf("broker: listen sub endpoint %s: %w", inEndpoint, err)
+	}
+
+	return &Proxy{in: in, out: out, cancel: cancel}, nil
+}
+
+// PubAddr returns the address subscribers connect to (broker XPUB port).
+func (p *Proxy) PubAddr() net.Addr {
+	return p.out.Addr()
+}
+
+// SubAddr returns the address publishers connect to (broker XSUB port).
+func (p *Proxy) SubAddr() net.Addr {
+	return p.in.Addr()
+}
+
+// Run forwards messages until ctx is cancelled or Close is called.
+// The optional observe callback sees every forwarded message.
+func (p *Proxy) Run(observe func(topic string, payload []byte)) error {
+	for {
+		msg, err := p.in.Recv()
+		if err != nil {
+			if errors.Is(err, zmq4.ErrClosedConn) || errors.Is(err, context.Canceled) {
+				return context.Canceled
+			}
+			// zmq4 reports a disconnected publisher (io.EOF, reset) through
+			// Recv; the broker itself keeps serving the remaining peers.
+			continue
+		}
+		if len(msg.Frames) == 0 {
+			continue
+		}
+		if err := p.out.Send(msg); err != nil {
+			return fmt.Errorf("broker: proxy send: %w", err)
+		}
+		if observe != nil {
+			var payload []byte
+			if len(msg.Frames) > 1 {
+				payload = msg.Frames[1]
+			}
+			observe(string(msg.Frames[0]), payload)
+		}
+	}
+}
+
+// Close stops the proxy and releases both ports.
+func (p *Proxy) Close() {
+	p.cancel()
+	_ = p.in.Close()
+	_ = p.out.Close()
+}
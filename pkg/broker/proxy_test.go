@@ -0,0 +1,68 @@
+package broker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestProxyForwardsPublishedMessagesToSubscribers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	proxy, err := NewProxy(ctx, "127.0.0.1", 0, 0)
+	if err != nil {
+		t.Fatalf("NewProxy() error = %v", err)
+	}
+	defer proxy.Close()
+	go func() { _ = proxy.Run(nil) }()
+
+	pubPort := proxy.PubAddr().(*net.TCPAddr).Port
+	subPort := proxy.SubAddr().(*net.TCPAddr).Port
+
+	subscriber, err := New(ctx, "127.0.0.1", pubPort, subPort, "test-sub")
+	if err != nil {
+		t.Fatalf("New(subscriber) error = %v", err)
+	}
+	defer subscriber.Close()
+	if err := subscriber.Subscribe("ppk_"); err != nil {
+		t.Fatal(err)
+	}
+
+	publisher, err := New(ctx, "127.0.0.1", pubPort, subPort, "test-pub")
+	if err != nil {
+		t.Fatalf("New(publisher) error = %v", err)
+	}
+	defer publisher.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		topic, body, err := subscriber.Recv()
+		if err == nil {
+			received <- topic + " " + string(body)
+		}
+	}()
+
+	// Subscriptions propagate asynchronously; keep publishing until one arrives.
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if err := publisher.PublishRaw("api_out", []byte(`{"skip":true}`)); err != nil {
+			t.Fatal(err)
+		}
+		if err := publisher.PublishRaw("ppk_in", []byte(`{"ok":true}`)); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got := <-received:
+			if got != `ppk_in {"ok":true}` {
+				t.Fatalf("received %q", got)
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("no message forwarded through proxy")
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package caslcompat
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// EmitPath приймає події ППК, які шлюз розсилає WebSocket-підписникам так,
+// ніби вони прийшли від CASL Cloud. Маршрут є лише у fixture-шлюзі й
+// використовується сценаріями емуляції подій (pkg/eventscenario).
+const EmitPath = "/fixture/emit"
+
+// EmitRequest описує одну подію для EmitPath.
+type EmitRequest struct {
+	Tag        string `json:"tag,omitempty"` // ppk_in за замовчуванням
+	PultID     string `json:"pult_id,omitempty"`
+	ObjID      string `json:"obj_id"`
+	PPKNum     int    `json:"ppk_num,omitempty"`
+	LineNumber int    `json:"line_number,omitempty"`
+	Action     string `json:"action,omitempty"`     // код CASL: GROUP_ON, FIRE, ...
+	ContactID  string `json:"contact_id,omitempty"` // код Contact ID, якщо подія прийшла з приймача
+	Time       int64  `json:"time,omitempty"`       // Unix ms; 0 — поточний час шлюзу
+}
+
+func (h *Handler) handleFixtureEmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeCASLMethodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req EmitRequest
+	if !decodeCASLBody(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.ObjID) == "" && req.PPKNum <= 0 {
+		writeCASLError(w, http.StatusBadRequest, "obj_id or ppk_num is required")
+		return
+	}
+	if strings.TrimSpace(req.Action) == "" && strings.TrimSpace(req.ContactID) == "" {
+		writeCASLError(w, http.StatusBadRequest, "action or contact_id is required")
+		return
+	}
+
+	tag := strings.TrimSpace(req.Tag)
+	if tag == "" {
+		tag = "ppk_in"
+	}
+	pultID := strings.TrimSpace(req.PultID)
+	if pultID == "" {
+		h.fixtureMu.Lock()
+		if h.fixture.User.PultID > 0 {
+			pultID = strconv.Itoa(h.fixture.User.PultID)
+		}
+		h.fixtureMu.Unlock()
+	}
+	eventTime := req.Time
+	if eventTime <= 0 {
+		eventTime = h.now().UnixMilli()
+	}
+
+	row := map[string]any{
+		"obj_id":      strings.TrimSpace(req.ObjID),
+		"line_number": req.LineNumber,
+		"time":        eventTime,
+	}
+	if req.PPKNum > 0 {
+		row["ppk_num"] = req.PPKNum
+	}
+	if action := strings.TrimSpace(req.Action); action != "" {
+		row["action"] = action
+	}
+	if contactID := strings.TrimSpace(req.ContactID); contactID != "" {
+		row["contact_id"] = contactID
+		row["code"] = contactID
+	}
+
+	h.broadcastByTag(tag, pultID, map[string]any{
+		"tag":  tag,
+		"data": []any{row},
+	})
+	writeCASLJSON(w, http.StatusOK, map[string]any{"status": "ok", "time": eventTime})
+}
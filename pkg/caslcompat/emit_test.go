@@ -0,0 +1,70 @@
+package caslcompat
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestFixtureHandler_EmitBroadcastsPPKEventToSubscribers(t *testing.T) {
+	handler := NewFixtureHandler()
+	handler.now = func() time.Time { return time.UnixMilli(1774788999196) }
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	connID := readWSConnID(t, conn)
+	subscribe := httptest.NewRequest(http.MethodPost, "/subscribe", bytes.NewBufferString(`{"token":"fixture-token","conn_id":"`+connID+`","tag":"ppk_in","pult_id":1}`))
+	handler.ServeHTTP(httptest.NewRecorder(), subscribe)
+
+	emit := httptest.NewRequest(http.MethodPost, EmitPath, bytes.NewBufferString(`{"obj_id":"25","ppk_num":1003,"line_number":5,"action":"FIRE","pult_id":"1"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, emit)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("emit status = %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]any
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("read emitted event: %v", err)
+	}
+	if msg["type"] != "ppk_in" {
+		t.Fatalf("unexpected message type: %#v", msg)
+	}
+	rows, _ := msg["data"].([]any)
+	if len(rows) != 1 {
+		t.Fatalf("unexpected rows: %#v", msg)
+	}
+	row, _ := rows[0].(map[string]any)
+	if row["obj_id"] != "25" || row["action"] != "FIRE" || row["line_number"] != float64(5) || row["time"] != float64(1774788999196) {
+		t.Fatalf("unexpected row: %#v", row)
+	}
+}
+
+func TestFixtureHandler_EmitValidatesRequest(t *testing.T) {
+	handler := NewFixtureHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, EmitPath, bytes.NewBufferString(`{"obj_id":"25"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status without action = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, EmitPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
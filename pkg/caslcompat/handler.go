@@ -146,6 +146,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleAPIDevicesState(w, r)
 	case "/api/report":
 		h.handleAPIReport(w, r)
+	case EmitPath:
+		h.handleFixtureEmit(w, r)
 	default:
 		writeCASLError(w, http.StatusNotFound, "route not found")
 	}
@@ -0,0 +1,407 @@
+package caslcompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// RecordingVersion — версія формату набору записаних сесій CASL.
+	RecordingVersion = 1
+
+	redactedValue       = "***"
+	maxRecordedBodySize = 32 << 20
+)
+
+// Recording — набір HTTP-обмінів і WebSocket-кадрів, записаних з реального
+// сервера CASL через RecordingProxy. Секрети (токени, паролі) замасковані,
+// ws_url очищено: ReplayHandler підставляє власну адресу.
+type Recording struct {
+	Version    int                `json:"version"`
+	RecordedAt time.Time          `json:"recorded_at"`
+	Upstream   string             `json:"upstream,omitempty"`
+	Exchanges  []RecordedExchange `json:"exchanges"`
+	Frames     []RecordedFrame    `json:"frames"`
+}
+
+// RecordedExchange — один HTTP-запит до CASL і відповідь на нього.
+type RecordedExchange struct {
+	OffsetMS int64           `json:"offset_ms"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// RecordedFrame — кадр realtime WebSocket від сервера CASL до клієнта.
+type RecordedFrame struct {
+	OffsetMS int64           `json:"offset_ms"`
+	Message  json.RawMessage `json:"message"`
+}
+
+// ReadRecordingFile читає набір, записаний RecordingProxy.
+func ReadRecordingFile(path string) (Recording, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return Recording{}, fmt.Errorf("read casl recording %q: %w", path, err)
+	}
+	var recording Recording
+	if err := json.Unmarshal(body, &recording); err != nil {
+		return Recording{}, fmt.Errorf("decode casl recording %q: %w", path, err)
+	}
+	if recording.Version != RecordingVersion {
+		return Recording{}, fmt.Errorf("casl recording %q: unsupported version %d", path, recording.Version)
+	}
+	return recording, nil
+}
+
+// WriteFile зберігає набір у JSON-файл.
+func (r Recording) WriteFile(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("casl recording path is empty")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create casl recording directory %q: %w", dir, err)
+		}
+	}
+	body, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode casl recording: %w", err)
+	}
+	body = append(body, '\n')
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return fmt.Errorf("write casl recording %q: %w", path, err)
+	}
+	return nil
+}
+
+// RecordingProxy пересилає запити й WebSocket реальному серверу CASL і
+// записує обміни в Recording. Відповідь /login переписується так, щоб
+// клієнт підключався до realtime через проксі.
+type RecordingProxy struct {
+	upstream *url.URL
+	wsURL    string
+	client   *http.Client
+	dialer   *websocket.Dialer
+	upgrader websocket.Upgrader
+	now      func() time.Time
+	started  time.Time
+
+	mu         sync.Mutex
+	upstreamWS string
+	recording  Recording
+}
+
+// NewRecordingProxy створює проксі до upstreamURL. wsURL — адреса, яку
+// клієнти отримають у ws_url; порожня — ws:// на хості запиту.
+func NewRecordingProxy(upstreamURL string, wsURL string) (*RecordingProxy, error) {
+	upstream, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(upstreamURL), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse casl upstream url: %w", err)
+	}
+	if upstream.Scheme != "http" && upstream.Scheme != "https" {
+		return nil, fmt.Errorf("casl upstream url %q must be http or https", upstreamURL)
+	}
+	now := time.Now
+	started := now()
+	return &RecordingProxy{
+		upstream: upstream,
+		wsURL:    strings.TrimSpace(wsURL),
+		client:   &http.Client{Timeout: 60 * time.Second},
+		dialer:   websocket.DefaultDialer,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		now:     now,
+		started: started,
+		recording: Recording{
+			Version:    RecordingVersion,
+			RecordedAt: started.UTC(),
+			Upstream:   upstream.Host,
+		},
+	}, nil
+}
+
+// Recording повертає копію записаного на цей момент.
+func (p *RecordingProxy) Recording() Recording {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	recording := p.recording
+	recording.Exchanges = append([]RecordedExchange(nil), p.recording.Exchanges...)
+	recording.Frames = append([]RecordedFrame(nil), p.recording.Frames...)
+	return recording
+}
+
+func (p *RecordingProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimSpace(r.URL.Path), "/")
+	if path == "" {
+		path = "/"
+	}
+	if path == "/" && websocket.IsWebSocketUpgrade(r) {
+		p.proxyWebSocket(w, r)
+		return
+	}
+	p.proxyHTTP(w, r, path)
+}
+
+func (p *RecordingProxy) proxyHTTP(w http.ResponseWriter, r *http.Request, path string) {
+	var requestBody []byte
+	if r.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRecordedBodySize))
+		if err != nil {
+			writeCASLError(w, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+		requestBody = body
+	}
+
+	target := *p.upstream
+	target.Path = strings.TrimSuffix(p.upstream.Path, "/") + r.URL.Path
+	target.RawQuery = r.URL.RawQuery
+	upstreamRequest, err := http.NewRequestWithContext(r.Context(), r.Method, target.String(), bytes.NewReader(requestBody))
+	if err != nil {
+		writeCASLError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	for _, header := range []string{"Content-Type", "Accept", "Authorization"} {
+		if value := r.Header.Get(header); value != "" {
+			upstreamRequest.Header.Set(header, value)
+		}
+	}
+
+	response, err := p.client.Do(upstreamRequest)
+	if err != nil {
+		writeCASLError(w, http.StatusBadGateway, "casl upstream: "+err.Error())
+		return
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxRecordedBodySize))
+	if err != nil {
+		writeCASLError(w, http.StatusBadGateway, "casl upstream: "+err.Error())
+		return
+	}
+
+	clientBody := responseBody
+	if isCASLLoginPath(path) {
+		clientBody = p.rewriteLoginResponse(r, responseBody)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(clientBody)
+
+	if !json.Valid(responseBody) {
+		return
+	}
+	exchange := RecordedExchange{
+		OffsetMS: p.offsetMS(),
+		Method:   r.Method,
+		Path:     path,
+		Status:   response.StatusCode,
+		Response: redactCASLJSON(responseBody),
+	}
+	if len(bytes.TrimSpace(requestBody)) > 0 && json.Valid(requestBody) {
+		exchange.Request = redactCASLJSON(requestBody)
+	}
+	p.mu.Lock()
+	p.recording.Exchanges = append(p.recording.Exchanges, exchange)
+	p.mu.Unlock()
+}
+
+// rewriteLoginResponse запам'ятовує справжній ws_url і віддає клієнту адресу проксі.
+func (p *RecordingProxy) rewriteLoginResponse(r *http.Request, body []byte) []byte {
+	var payload map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return body
+	}
+	if upstreamWS := strings.TrimSpace(asWSString(payload["ws_url"])); upstreamWS != "" {
+		p.mu.Lock()
+		p.upstreamWS = upstreamWS
+		p.mu.Unlock()
+	}
+	replaceCASLWSURL(payload, localWSURL(p.wsURL, r))
+	rewritten, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
+
+func (p *RecordingProxy) proxyWebSocket(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	upstreamWS := p.upstreamWS
+	p.mu.Unlock()
+	if upstreamWS == "" {
+		wsUpstream := *p.upstream
+		wsUpstream.Scheme = "ws"
+		if p.upstream.Scheme == "https" {
+			wsUpstream.Scheme = "wss"
+		}
+		wsUpstream.Path = "/"
+		upstreamWS = wsUpstream.String()
+	}
+
+	upstreamConn, _, err := p.dialer.DialContext(r.Context(), upstreamWS, nil)
+	if err != nil {
+		writeCASLError(w, http.StatusBadGateway, "casl upstream websocket: "+err.Error())
+		return
+	}
+	defer upstreamConn.Close()
+	clientConn, err := p.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer clientConn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	context.AfterFunc(ctx, func() {
+		_ = upstreamConn.Close()
+		_ = clientConn.Close()
+	})
+
+	go func() {
+		defer cancel()
+		for {
+			messageType, body, err := clientConn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := upstreamConn.WriteMessage(messageType, body); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		messageType, body, err := upstreamConn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType == websocket.TextMessage {
+			p.recordFrame(body)
+		}
+		if err := clientConn.WriteMessage(messageType, body); err != nil {
+			return
+		}
+	}
+}
+
+// recordFrame пропускає службові conn_id і ping: ReplayHandler надсилає власні.
+func (p *RecordingProxy) recordFrame(body []byte) {
+	var message map[string]any
+	if err := json.Unmarshal(body, &message); err != nil {
+		return
+	}
+	switch strings.TrimSpace(asWSString(message["type"])) {
+	case "conn_id", "ping":
+		return
+	}
+	frame := RecordedFrame{OffsetMS: p.offsetMS(), Message: redactCASLJSON(body)}
+	p.mu.Lock()
+	p.recording.Frames = append(p.recording.Frames, frame)
+	p.mu.Unlock()
+}
+
+func (p *RecordingProxy) offsetMS() int64 {
+	return p.now().Sub(p.started).Milliseconds()
+}
+
+func isCASLLoginPath(path string) bool {
+	return path == "/login" || path == "/login_technician"
+}
+
+func localWSURL(configured string, r *http.Request) string {
+	if configured = strings.TrimSpace(configured); configured != "" {
+		return configured
+	}
+	return "ws://" + r.Host
+}
+
+// redactCASLJSON маскує секрети й очищає ws_url у записаному JSON.
+func redactCASLJSON(body []byte) json.RawMessage {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return json.RawMessage(body)
+	}
+	redactCASLValue(value)
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage(body)
+	}
+	return redacted
+}
+
+func redactCASLValue(value any) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			switch {
+			case isCASLSecretKey(key):
+				if nested != nil && nested != "" {
+					typed[key] = redactedValue
+				}
+			case strings.EqualFold(key, "ws_url"):
+				typed[key] = ""
+			default:
+				redactCASLValue(nested)
+			}
+		}
+	case []any:
+		for _, nested := range typed {
+			redactCASLValue(nested)
+		}
+	}
+}
+
+// caslSecretKeyParts — фрагменти назв полів із секретами. Порівняння за
+// підрядком покриває passw, passw_remote, device_password, licence_key,
+// access_token тощо, а не лише точні назви.
+var caslSecretKeyParts = []string{"pass", "pwd", "token", "secret", "key", "auth"}
+
+func isCASLSecretKey(key string) bool {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, part := range caslSecretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func replaceCASLWSURL(value any, wsURL string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if strings.EqualFold(key, "ws_url") {
+				typed[key] = wsURL
+				continue
+			}
+			replaceCASLWSURL(nested, wsURL)
+		}
+	case []any:
+		for _, nested := range typed {
+			replaceCASLWSURL(nested, wsURL)
+		}
+	}
+}
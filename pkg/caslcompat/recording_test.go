@@ -0,0 +1,182 @@
+package caslcompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func postCASLJSON(t *testing.T, baseURL, path, body string) map[string]any {
+	t.Helper()
+	response, err := http.Post(baseURL+path, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer response.Body.Close()
+	raw, _ := io.ReadAll(response.Body)
+	var payload map[string]any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatalf("POST %s: decode %s: %v", path, raw, err)
+	}
+	return payload
+}
+
+func dialCASLWS(t *testing.T, wsURL string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial websocket %s: %v", wsURL, err)
+	}
+	return conn
+}
+
+func readWSType(t *testing.T, conn *websocket.Conn, messageType string) map[string]any {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for {
+		var msg map[string]any
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("read %s frame: %v", messageType, err)
+		}
+		if msg["type"] == messageType {
+			return msg
+		}
+	}
+}
+
+func recordFixtureSession(t *testing.T) Recording {
+	t.Helper()
+	upstream := httptest.NewServer(NewFixtureHandler())
+	defer upstream.Close()
+
+	proxy, err := NewRecordingProxy(upstream.URL, "")
+	if err != nil {
+		t.Fatalf("NewRecordingProxy() error = %v", err)
+	}
+	proxyServer := httptest.NewServer(proxy)
+	defer proxyServer.Close()
+
+	login := postCASLJSON(t, proxyServer.URL, "/login", `{"email":"op@example.com","pwd":"secret","pult_id":1}`)
+	wsURL, _ := login["ws_url"].(string)
+	if wsURL != "ws://"+strings.TrimPrefix(proxyServer.URL, "http://") {
+		t.Fatalf("login ws_url = %q, want proxy address", wsURL)
+	}
+	postCASLJSON(t, proxyServer.URL, "/command", `{"type":"read_pult","token":"fixture-token"}`)
+
+	conn := dialCASLWS(t, wsURL+"/")
+	defer conn.Close()
+	connID := readWSConnID(t, conn)
+	postCASLJSON(t, proxyServer.URL, "/subscribe", `{"token":"fixture-token","conn_id":"`+connID+`","tag":"ppk_in","pult_id":1}`)
+	postCASLJSON(t, upstream.URL, EmitPath, `{"obj_id":"25","line_number":3,"action":"FIRE","pult_id":"1"}`)
+	readWSType(t, conn, "ppk_in")
+
+	// Кадр записується до пересилання клієнту, тож він уже в наборі.
+	return proxy.Recording()
+}
+
+func TestRecordingProxy_RecordsCommandsAndFramesWithSecretsRedacted(t *testing.T) {
+	recording := recordFixtureSession(t)
+
+	if len(recording.Exchanges) != 3 {
+		t.Fatalf("exchanges = %d, want login, command and subscribe: %#v", len(recording.Exchanges), recording.Exchanges)
+	}
+	login := recording.Exchanges[0]
+	if login.Path != "/login" || strings.Contains(string(login.Request), "secret") || !strings.Contains(string(login.Request), `"pwd":"***"`) {
+		t.Fatalf("login request not redacted: %s", login.Request)
+	}
+	if strings.Contains(string(login.Response), fixtureToken) || strings.Contains(string(login.Response), "ws://") {
+		t.Fatalf("login response leaks token or ws_url: %s", login.Response)
+	}
+	if len(recording.Frames) != 1 || !strings.Contains(string(recording.Frames[0].Message), `"action":"FIRE"`) {
+		t.Fatalf("frames = %#v", recording.Frames)
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recording.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	loaded, err := ReadRecordingFile(path)
+	if err != nil {
+		t.Fatalf("ReadRecordingFile() error = %v", err)
+	}
+	if len(loaded.Exchanges) != len(recording.Exchanges) || len(loaded.Frames) != 1 {
+		t.Fatalf("loaded recording differs: %#v", loaded)
+	}
+}
+
+func TestReplayHandler_ServesRecordedSessionDeterministically(t *testing.T) {
+	recording := recordFixtureSession(t)
+	server := httptest.NewServer(NewReplayHandler(recording, ""))
+	defer server.Close()
+
+	login := postCASLJSON(t, server.URL, "/login", `{"email":"op@example.com","pwd":"other","pult_id":1}`)
+	wsURL, _ := login["ws_url"].(string)
+	if wsURL != "ws://"+strings.TrimPrefix(server.URL, "http://") {
+		t.Fatalf("replayed ws_url = %q", wsURL)
+	}
+
+	// Інший токен не заважає збігу: секрети не входять у ключ.
+	for range 2 {
+		pults := postCASLJSON(t, server.URL, "/command", `{"type":"read_pult","token":"***"}`)
+		if pults["status"] != "ok" || pults["data"] == nil {
+			t.Fatalf("replayed read_pult = %#v", pults)
+		}
+	}
+	if missing := postCASLJSON(t, server.URL, "/command", `{"type":"read_device"}`); missing["status"] != "error" {
+		t.Fatalf("unrecorded command = %#v, want error", missing)
+	}
+
+	conn := dialCASLWS(t, wsURL+"/")
+	defer conn.Close()
+	connID := readWSConnID(t, conn)
+	if !strings.HasPrefix(connID, "replay-") {
+		t.Fatalf("conn_id = %q", connID)
+	}
+	postCASLJSON(t, server.URL, "/subscribe", `{"token":"***","conn_id":"`+connID+`","tag":"ppk_in","pult_id":1}`)
+	frame := readWSType(t, conn, "ppk_in")
+	rows, _ := frame["data"].([]any)
+	row, _ := rows[0].(map[string]any)
+	if row["obj_id"] != "25" || row["action"] != "FIRE" {
+		t.Fatalf("replayed frame = %#v", frame)
+	}
+}
+
+func TestRedactCASLValue_MasksSecretFieldsBySubstring(t *testing.T) {
+	payload := map[string]any{
+		"passw_remote":   "1111",
+		"device":         map[string]any{"passw": "2222", "device_password": "3333", "name": "ППК"},
+		"licence_key":    "ABC-DEF",
+		"access_token":   "tok",
+		"Authorization":  "Bearer tok",
+		"client_secret":  "s3",
+		"empty_password": "",
+		"obj_id":         "25",
+		"users":          []any{map[string]any{"pwd": "4444", "surname": "Петренко"}},
+	}
+	redactCASLValue(payload)
+
+	for _, key := range []string{"passw_remote", "licence_key", "access_token", "Authorization", "client_secret"} {
+		if payload[key] != redactedValue {
+			t.Errorf("%s = %v, want redacted", key, payload[key])
+		}
+	}
+	device := payload["device"].(map[string]any)
+	if device["passw"] != redactedValue || device["device_password"] != redactedValue || device["name"] != "ППК" {
+		t.Errorf("device = %v", device)
+	}
+	user := payload["users"].([]any)[0].(map[string]any)
+	if user["pwd"] != redactedValue || user["surname"] != "Петренко" {
+		t.Errorf("user = %v", user)
+	}
+	if payload["empty_password"] != "" || payload["obj_id"] != "25" {
+		t.Errorf("non-secret or empty values changed: %v", payload)
+	}
+}
@@ -0,0 +1,295 @@
+package caslcompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ReplayHandler відтворює Recording детерміновано: на запит віддається
+// записана відповідь з тим самим методом, шляхом і тілом (без секретів та
+// conn_id), а якщо такого тіла не було — наступна записана відповідь на
+// ту саму команду (поле "type"). Повторні запити проходять записи по черзі,
+// останній повторюється. Кадри realtime надсилаються клієнту WebSocket
+// після його /subscribe у записаному порядку.
+type ReplayHandler struct {
+	recording Recording
+	wsURL     string
+	speed     float64
+	upgrader  websocket.Upgrader
+	nextID    atomic.Uint64
+
+	mu     sync.Mutex
+	exact  map[string]*replayQueue
+	byType map[string]*replayQueue
+
+	clientsMu sync.Mutex
+	clients   map[string]*replayClient
+}
+
+type replayQueue struct {
+	exchanges []RecordedExchange
+	next      int
+}
+
+func (q *replayQueue) take() RecordedExchange {
+	exchange := q.exchanges[q.next]
+	if q.next < len(q.exchanges)-1 {
+		q.next++
+	}
+	return exchange
+}
+
+type replayClient struct {
+	id      string
+	conn    *websocket.Conn
+	mu      sync.Mutex
+	started sync.Once
+	done    chan struct{}
+}
+
+func (c *replayClient) write(body []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, body)
+}
+
+// NewReplayHandler створює обробник для recording. wsURL підставляється
+// у ws_url відповіді /login; порожній — ws:// на хості запиту.
+func NewReplayHandler(recording Recording, wsURL string) *ReplayHandler {
+	h := &ReplayHandler{
+		recording: recording,
+		wsURL:     strings.TrimSpace(wsURL),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		exact:   make(map[string]*replayQueue),
+		byType:  make(map[string]*replayQueue),
+		clients: make(map[string]*replayClient),
+	}
+	for _, exchange := range recording.Exchanges {
+		exactKey, typeKey := replayKeys(exchange.Method, exchange.Path, exchange.Request)
+		appendReplayQueue(h.exact, exactKey, exchange)
+		appendReplayQueue(h.byType, typeKey, exchange)
+	}
+	return h
+}
+
+// SetFrameSpeed задає темп кадрів realtime: 0 — без пауз (за замовчуванням),
+// 1 — записані інтервали, 2 — удвічі швидше.
+func (h *ReplayHandler) SetFrameSpeed(speed float64) {
+	if h == nil {
+		return
+	}
+	h.speed = speed
+}
+
+func appendReplayQueue(queues map[string]*replayQueue, key string, exchange RecordedExchange) {
+	queue := queues[key]
+	if queue == nil {
+		queue = &replayQueue{}
+		queues[key] = queue
+	}
+	queue.exchanges = append(queue.exchanges, exchange)
+}
+
+func (h *ReplayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimSpace(r.URL.Path), "/")
+	if path == "" {
+		path = "/"
+	}
+	if path == "/" && websocket.IsWebSocketUpgrade(r) {
+		h.handleWebSocket(w, r)
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(http.MaxBytesReader(w, r.Body, maxRecordedBodySize)); err != nil {
+			writeCASLError(w, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+		body = buf.Bytes()
+	}
+
+	exchange, ok := h.lookup(r.Method, path, body)
+	if !ok {
+		if path == "/subscribe" || path == "/subscribe_techn" {
+			h.startFrames(body)
+			writeCASLJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+			return
+		}
+		writeCASLError(w, http.StatusNotFound, fmt.Sprintf("no recorded response for %s %s", r.Method, path))
+		return
+	}
+
+	response := []byte(exchange.Response)
+	if isCASLLoginPath(path) {
+		response = rewriteReplayLogin(response, localWSURL(h.wsURL, r))
+	}
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(exchange.Status)
+	_, _ = w.Write(response)
+
+	if path == "/subscribe" || path == "/subscribe_techn" {
+		h.startFrames(body)
+	}
+}
+
+func (h *ReplayHandler) lookup(method, path string, body []byte) (RecordedExchange, bool) {
+	exactKey, typeKey := replayKeys(method, path, body)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if queue := h.exact[exactKey]; queue != nil {
+		return queue.take(), true
+	}
+	if queue := h.byType[typeKey]; queue != nil {
+		return queue.take(), true
+	}
+	return RecordedExchange{}, false
+}
+
+// replayKeys будує ключі пошуку: точний (канонічне тіло без секретів і
+// conn_id) та за командою.
+func replayKeys(method, path string, body []byte) (exact string, byType string) {
+	prefix := strings.ToUpper(strings.TrimSpace(method)) + " " + path
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if len(bytes.TrimSpace(body)) == 0 || decoder.Decode(&value) != nil {
+		return prefix + " " + string(bytes.TrimSpace(body)), prefix
+	}
+	commandType := ""
+	if object, ok := value.(map[string]any); ok {
+		commandType = strings.TrimSpace(asWSString(object["type"]))
+		delete(object, "conn_id")
+	}
+	redactCASLValue(value)
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return prefix + " " + string(body), prefix + " " + commandType
+	}
+	return prefix + " " + string(canonical), prefix + " " + commandType
+}
+
+func rewriteReplayLogin(body []byte, wsURL string) []byte {
+	var payload map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return body
+	}
+	if _, ok := payload["ws_url"]; !ok {
+		payload["ws_url"] = wsURL
+	}
+	replaceCASLWSURL(payload, wsURL)
+	rewritten, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
+
+func (h *ReplayHandler) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	client := &replayClient{
+		id:   fmt.Sprintf("replay-%d", h.nextID.Add(1)),
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	h.clientsMu.Lock()
+	h.clients[client.id] = client
+	h.clientsMu.Unlock()
+	defer func() {
+		h.clientsMu.Lock()
+		delete(h.clients, client.id)
+		h.clientsMu.Unlock()
+		close(client.done)
+		_ = conn.Close()
+	}()
+
+	connIDFrame, _ := json.Marshal(map[string]any{"type": "conn_id", "id": client.id})
+	if err := client.write(connIDFrame); err != nil {
+		return
+	}
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for {
+			_, body, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var message map[string]any
+			if json.Unmarshal(body, &message) == nil && asWSString(message["type"]) == "get_id" {
+				_ = client.write(connIDFrame)
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-readDone:
+			return
+		case <-ticker.C:
+			ping, _ := json.Marshal(map[string]any{"type": "ping", "time": time.Now().UTC().Format(time.RFC3339)})
+			if err := client.write(ping); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// startFrames запускає відтворення кадрів для з'єднання з conn_id запиту
+// підписки; кожне з'єднання отримує кадри один раз.
+func (h *ReplayHandler) startFrames(body []byte) {
+	var request subscribeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return
+	}
+	h.clientsMu.Lock()
+	client := h.clients[strings.TrimSpace(request.ConnID)]
+	h.clientsMu.Unlock()
+	if client == nil {
+		return
+	}
+	client.started.Do(func() {
+		go h.playFrames(client)
+	})
+}
+
+func (h *ReplayHandler) playFrames(client *replayClient) {
+	var previous int64
+	for i, frame := range h.recording.Frames {
+		if i > 0 && h.speed > 0 && frame.OffsetMS > previous {
+			delay := time.Duration(float64(time.Duration(frame.OffsetMS-previous)*time.Millisecond) / h.speed)
+			timer := time.NewTimer(delay)
+			select {
+			case <-client.done:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		previous = frame.OffsetMS
+		if err := client.write(frame.Message); err != nil {
+			return
+		}
+	}
+}
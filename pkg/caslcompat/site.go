@@ -24,7 +24,9 @@ func NewFixtureSiteHandler(caslRootDir, wsURL string) http.Handler {
 	})
 }
 
-func NewStaticSiteHandler(apiHandler *Handler, options StaticSiteOptions) http.Handler {
+// NewStaticSiteHandler обслуговує статичний веб-інтерфейс CASL, а маршрути
+// API і WebSocket передає apiHandler (Handler, RecordingProxy або ReplayHandler).
+func NewStaticSiteHandler(apiHandler http.Handler, options StaticSiteOptions) http.Handler {
 	if apiHandler == nil {
 		apiHandler = NewFixtureHandler()
 	}
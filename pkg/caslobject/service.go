@@ -43,6 +43,7 @@ func CreateDraft(
 		return objID, objectID, fmt.Errorf("номер приладу %d вже зайнятий", device.Number)
 	}
 	deviceID, err := provider.CreateCASLDevice(ctx, contracts.CASLDeviceCreate{
+		PultID: object.ReactingPultID,
 		Number: device.Number, Name: device.Name, DeviceType: device.Type, Timeout: device.Timeout,
 		SIM1: device.SIM1, SIM2: device.SIM2, TechnicianID: device.TechnicianID,
 		Units: device.Units, Requisites: device.Requisites, ChangeDate: device.ChangeDate,
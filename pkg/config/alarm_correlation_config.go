@@ -0,0 +1,80 @@
+package config
+
+const (
+	PrefAlarmCorrelationEnabled             = "alarm_correlation.enabled"
+	PrefAlarmCorrelationWindowSeconds       = "alarm_correlation.window_seconds"
+	PrefAlarmCorrelationSubServerMinObjects = "alarm_correlation.subserver_min_objects"
+	PrefAlarmCorrelationChannelMinObjects   = "alarm_correlation.channel_min_objects"
+	PrefAlarmCorrelationStormThreshold      = "alarm_correlation.storm_threshold"
+	PrefAlarmCorrelationStormWindowSeconds  = "alarm_correlation.storm_window_seconds"
+)
+
+const (
+	defaultAlarmCorrelationWindowSeconds       = 60
+	defaultAlarmCorrelationSubServerMinObjects = 3
+	defaultAlarmCorrelationChannelMinObjects   = 10
+	defaultAlarmCorrelationStormThreshold      = 50
+	defaultAlarmCorrelationStormWindowSeconds  = 120
+)
+
+// AlarmCorrelationConfig описує групування пов'язаних тривог в інциденти
+// і виявлення шторму тривог.
+//
+// WindowSeconds — найбільший проміжок між сусідніми тривогами одного інциденту.
+// SubServerMinObjects і ChannelMinObjects — скільки різних об'єктів одного
+// підсервера або каналу мають дати технічні тривоги, щоб їх згрупувати.
+// Шторм — StormThreshold і більше тривог за останні StormWindowSeconds.
+type AlarmCorrelationConfig struct {
+	Enabled             bool
+	WindowSeconds       int
+	SubServerMinObjects int
+	ChannelMinObjects   int
+	StormThreshold      int
+	StormWindowSeconds  int
+}
+
+func LoadAlarmCorrelationConfig(p Preferences) AlarmCorrelationConfig {
+	defaults := defaultAlarmCorrelationConfig()
+	if p == nil {
+		return defaults
+	}
+	return AlarmCorrelationConfig{
+		Enabled:             p.BoolWithFallback(PrefAlarmCorrelationEnabled, defaults.Enabled),
+		WindowSeconds:       positiveIntWithFallback(p, PrefAlarmCorrelationWindowSeconds, defaults.WindowSeconds),
+		SubServerMinObjects: positiveIntWithFallback(p, PrefAlarmCorrelationSubServerMinObjects, defaults.SubServerMinObjects),
+		ChannelMinObjects:   positiveIntWithFallback(p, PrefAlarmCorrelationChannelMinObjects, defaults.ChannelMinObjects),
+		StormThreshold:      positiveIntWithFallback(p, PrefAlarmCorrelationStormThreshold, defaults.StormThreshold),
+		StormWindowSeconds:  positiveIntWithFallback(p, PrefAlarmCorrelationStormWindowSeconds, defaults.StormWindowSeconds),
+	}
+}
+
+func SaveAlarmCorrelationConfig(p Preferences, cfg AlarmCorrelationConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefAlarmCorrelationEnabled, cfg.Enabled)
+	p.SetInt(PrefAlarmCorrelationWindowSeconds, cfg.WindowSeconds)
+	p.SetInt(PrefAlarmCorrelationSubServerMinObjects, cfg.SubServerMinObjects)
+	p.SetInt(PrefAlarmCorrelationChannelMinObjects, cfg.ChannelMinObjects)
+	p.SetInt(PrefAlarmCorrelationStormThreshold, cfg.StormThreshold)
+	p.SetInt(PrefAlarmCorrelationStormWindowSeconds, cfg.StormWindowSeconds)
+}
+
+func defaultAlarmCorrelationConfig() AlarmCorrelationConfig {
+	return AlarmCorrelationConfig{
+		Enabled:             true,
+		WindowSeconds:       defaultAlarmCorrelationWindowSeconds,
+		SubServerMinObjects: defaultAlarmCorrelationSubServerMinObjects,
+		ChannelMinObjects:   defaultAlarmCorrelationChannelMinObjects,
+		StormThreshold:      defaultAlarmCorrelationStormThreshold,
+		StormWindowSeconds:  defaultAlarmCorrelationStormWindowSeconds,
+	}
+}
+
+func positiveIntWithFallback(p Preferences, key string, fallback int) int {
+	value := p.IntWithFallback(key, fallback)
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
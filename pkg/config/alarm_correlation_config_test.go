@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestAlarmCorrelationConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	defaults := LoadAlarmCorrelationConfig(prefs)
+	if !defaults.Enabled || defaults.WindowSeconds != 60 || defaults.ChannelMinObjects != 10 || defaults.StormThreshold != 50 {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+
+	SaveAlarmCorrelationConfig(prefs, AlarmCorrelationConfig{
+		WindowSeconds:       30,
+		SubServerMinObjects: 5,
+		ChannelMinObjects:   0,
+		StormThreshold:      -1,
+		StormWindowSeconds:  60,
+	})
+	got := LoadAlarmCorrelationConfig(prefs)
+	if got.Enabled || got.WindowSeconds != 30 || got.SubServerMinObjects != 5 || got.StormWindowSeconds != 60 {
+		t.Fatalf("LoadAlarmCorrelationConfig() = %+v", got)
+	}
+	if got.ChannelMinObjects != 10 || got.StormThreshold != 50 {
+		t.Fatalf("non-positive values must fall back to defaults, got %+v", got)
+	}
+}
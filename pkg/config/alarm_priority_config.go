@@ -0,0 +1,220 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+const (
+	PrefAlarmPriorityEnabled             = "alarm_priority.enabled"
+	PrefAlarmPriorityTypeWeights         = "alarm_priority.type_weights"
+	PrefAlarmPriorityObjectTypeWeights   = "alarm_priority.object_type_weights"
+	PrefAlarmPriorityContractWeight      = "alarm_priority.contract_weight"
+	PrefAlarmPriorityRepeatWeight        = "alarm_priority.repeat_weight"
+	PrefAlarmPriorityRepeatMax           = "alarm_priority.repeat_max"
+	PrefAlarmPriorityUnattendedPerMinute = "alarm_priority.unattended_per_minute"
+	PrefAlarmPriorityUnattendedMax       = "alarm_priority.unattended_max"
+	PrefAlarmPriorityDispatchedPenalty   = "alarm_priority.dispatched_penalty"
+)
+
+// DefaultAlarmTypeWeight — бали типу тривоги, відсутнього в TypeWeights.
+const DefaultAlarmTypeWeight = 20
+
+const (
+	defaultAlarmPriorityContractWeight      = 10
+	defaultAlarmPriorityRepeatWeight        = 5
+	defaultAlarmPriorityRepeatMax           = 25
+	defaultAlarmPriorityUnattendedPerMinute = 2
+	defaultAlarmPriorityUnattendedMax       = 40
+	defaultAlarmPriorityDispatchedPenalty   = 60
+)
+
+// AlarmPriorityConfig описує модель оцінки пріоритету тривог. Оцінка — сума
+// балів за тип тривоги (TypeWeights, ключ — код типу), тип об'єкта
+// (ObjectTypeWeights, ключ — OBJTYPEID), договірний пріоритет об'єкта
+// (ContractWeight за кожен рівень), повторні спрацювання (RepeatWeight за
+// кожне, не більше RepeatMax) і час без реакції (UnattendedPerMinute за
+// хвилину, не більше UnattendedMax). Тривога з уже висланою МГР втрачає
+// DispatchedPenalty балів. Нульова вага вимикає відповідний чинник.
+type AlarmPriorityConfig struct {
+	Enabled             bool
+	TypeWeights         map[string]int
+	ObjectTypeWeights   map[int64]int
+	ContractWeight      int
+	RepeatWeight        int
+	RepeatMax           int
+	UnattendedPerMinute int
+	UnattendedMax       int
+	DispatchedPenalty   int
+}
+
+// TypeWeight повертає бали типу тривоги; невідомий тип отримує DefaultAlarmTypeWeight.
+func (cfg AlarmPriorityConfig) TypeWeight(alarmType string) int {
+	if weight, ok := cfg.TypeWeights[alarmType]; ok {
+		return weight
+	}
+	return DefaultAlarmTypeWeight
+}
+
+// DefaultAlarmPriorityConfig повертає типову модель пріоритету: життя й
+// пожежа — найвище, охоронні тривоги — далі, технічні й локальні — нижче.
+func DefaultAlarmPriorityConfig() AlarmPriorityConfig {
+	return AlarmPriorityConfig{
+		Enabled: true,
+		TypeWeights: map[string]int{
+			"fire":                 100,
+			"panic":                95,
+			"medical":              90,
+			"gas":                  90,
+			"BURGLARY_ALARM":       80,
+			"ALARM_TYPE_OPERATOR":  75,
+			"ALARM_TYPE_MOBILE":    75,
+			"ALARM_TYPE_DEVICE":    70,
+			"tamper":               60,
+			"EXIT_ALARM":           50,
+			"offline":              40,
+			"fault":                35,
+			"FIRE_TROUBLE":         35,
+			"power_fail":           30,
+			"AC_TROUBLE":           30,
+			"schedule_violation":   25,
+			"test_missed":          20,
+			"battery_low":          15,
+			"notification":         10,
+			"maintenance_reminder": 10,
+			"system_event":         5,
+			"ALARM_ELIMINATED":     0,
+		},
+		ObjectTypeWeights:   map[int64]int{},
+		ContractWeight:      defaultAlarmPriorityContractWeight,
+		RepeatWeight:        defaultAlarmPriorityRepeatWeight,
+		RepeatMax:           defaultAlarmPriorityRepeatMax,
+		UnattendedPerMinute: defaultAlarmPriorityUnattendedPerMinute,
+		UnattendedMax:       defaultAlarmPriorityUnattendedMax,
+		DispatchedPenalty:   defaultAlarmPriorityDispatchedPenalty,
+	}
+}
+
+// LoadAlarmPriorityConfig читає модель пріоритету. Ваги типів з налаштувань
+// доповнюють і перекривають типові; пошкоджений JSON ваг ігнорується.
+func LoadAlarmPriorityConfig(p Preferences) AlarmPriorityConfig {
+	cfg := DefaultAlarmPriorityConfig()
+	if p == nil {
+		return cfg
+	}
+	cfg.Enabled = p.BoolWithFallback(PrefAlarmPriorityEnabled, cfg.Enabled)
+	if weights, err := ParseAlarmTypeWeights(p.String(PrefAlarmPriorityTypeWeights)); err == nil {
+		maps.Copy(cfg.TypeWeights, weights)
+	}
+	if weights, err := ParseObjectTypeWeights(p.String(PrefAlarmPriorityObjectTypeWeights)); err == nil && weights != nil {
+		cfg.ObjectTypeWeights = weights
+	}
+	cfg.ContractWeight = nonNegativeIntWithFallback(p, PrefAlarmPriorityContractWeight, cfg.ContractWeight)
+	cfg.RepeatWeight = nonNegativeIntWithFallback(p, PrefAlarmPriorityRepeatWeight, cfg.RepeatWeight)
+	cfg.RepeatMax = nonNegativeIntWithFallback(p, PrefAlarmPriorityRepeatMax, cfg.RepeatMax)
+	cfg.UnattendedPerMinute = nonNegativeIntWithFallback(p, PrefAlarmPriorityUnattendedPerMinute, cfg.UnattendedPerMinute)
+	cfg.UnattendedMax = nonNegativeIntWithFallback(p, PrefAlarmPriorityUnattendedMax, cfg.UnattendedMax)
+	cfg.DispatchedPenalty = nonNegativeIntWithFallback(p, PrefAlarmPriorityDispatchedPenalty, cfg.DispatchedPenalty)
+	return cfg
+}
+
+// SaveAlarmPriorityConfig записує модель пріоритету; ваги типів зберігаються повністю.
+func SaveAlarmPriorityConfig(p Preferences, cfg AlarmPriorityConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefAlarmPriorityEnabled, cfg.Enabled)
+	p.SetString(PrefAlarmPriorityTypeWeights, FormatAlarmTypeWeights(cfg.TypeWeights))
+	p.SetString(PrefAlarmPriorityObjectTypeWeights, FormatObjectTypeWeights(cfg.ObjectTypeWeights))
+	p.SetInt(PrefAlarmPriorityContractWeight, cfg.ContractWeight)
+	p.SetInt(PrefAlarmPriorityRepeatWeight, cfg.RepeatWeight)
+	p.SetInt(PrefAlarmPriorityRepeatMax, cfg.RepeatMax)
+	p.SetInt(PrefAlarmPriorityUnattendedPerMinute, cfg.UnattendedPerMinute)
+	p.SetInt(PrefAlarmPriorityUnattendedMax, cfg.UnattendedMax)
+	p.SetInt(PrefAlarmPriorityDispatchedPenalty, cfg.DispatchedPenalty)
+}
+
+// ParseAlarmTypeWeights розбирає JSON-об'єкт «код типу тривоги → бали».
+// Порожній рядок — ваги не задані.
+func ParseAlarmTypeWeights(raw string) (map[string]int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var weights map[string]int
+	if err := json.Unmarshal([]byte(raw), &weights); err != nil {
+		return nil, fmt.Errorf("некоректний JSON ваг типів тривог: %w", err)
+	}
+	result := make(map[string]int, len(weights))
+	for code, weight := range weights {
+		if code = strings.TrimSpace(code); code != "" {
+			result[code] = weight
+		}
+	}
+	return result, nil
+}
+
+// FormatAlarmTypeWeights записує ваги типів тривог у JSON.
+func FormatAlarmTypeWeights(weights map[string]int) string {
+	if len(weights) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ParseObjectTypeWeights розбирає JSON-об'єкт «OBJTYPEID → бали», напр. {"5": 30}.
+func ParseObjectTypeWeights(raw string) (map[int64]int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var weights map[string]int
+	if err := json.Unmarshal([]byte(raw), &weights); err != nil {
+		return nil, fmt.Errorf("некоректний JSON ваг типів об'єктів: %w", err)
+	}
+	result := make(map[int64]int, len(weights))
+	for key, weight := range weights {
+		id, err := strconv.ParseInt(strings.TrimSpace(key), 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("тип об'єкта %q: очікується додатний OBJTYPEID", key)
+		}
+		result[id] = weight
+	}
+	return result, nil
+}
+
+// FormatObjectTypeWeights записує ваги типів об'єктів у JSON.
+func FormatObjectTypeWeights(weights map[int64]int) string {
+	if len(weights) == 0 {
+		return ""
+	}
+	raw := make(map[string]int, len(weights))
+	for id, weight := range weights {
+		raw[strconv.FormatInt(id, 10)] = weight
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func nonNegativeIntWithFallback(p Preferences, key string, fallback int) int {
+	value := p.IntWithFallback(key, fallback)
+	if value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// AlarmPriorityConfigStore абстрагує збереження моделі пріоритету тривог.
+type AlarmPriorityConfigStore interface {
+	LoadAlarmPriorityConfig() AlarmPriorityConfig
+}
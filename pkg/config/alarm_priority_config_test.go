@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestAlarmPriorityConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	defaults := LoadAlarmPriorityConfig(prefs)
+	if !defaults.Enabled || defaults.TypeWeight("fire") != 100 || defaults.TypeWeight("unknown") != DefaultAlarmTypeWeight {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+
+	prefs.strings[PrefAlarmPriorityTypeWeights] = `{"fire": 120, "test_missed": 0}`
+	prefs.strings[PrefAlarmPriorityObjectTypeWeights] = `{"5": 30}`
+	prefs.ints[PrefAlarmPriorityContractWeight] = 0
+	prefs.ints[PrefAlarmPriorityDispatchedPenalty] = -5
+	got := LoadAlarmPriorityConfig(prefs)
+	if got.TypeWeight("fire") != 120 || got.TypeWeight("test_missed") != 0 || got.TypeWeight("panic") != 95 {
+		t.Fatalf("configured type weights must override defaults, got %v", got.TypeWeights)
+	}
+	if got.ObjectTypeWeights[5] != 30 || got.ContractWeight != 0 {
+		t.Fatalf("LoadAlarmPriorityConfig() = %+v", got)
+	}
+	if got.DispatchedPenalty != 60 {
+		t.Fatalf("negative penalty must fall back to default, got %d", got.DispatchedPenalty)
+	}
+
+	SaveAlarmPriorityConfig(prefs, got)
+	if again := LoadAlarmPriorityConfig(prefs); again.TypeWeight("fire") != 120 || again.ObjectTypeWeights[5] != 30 {
+		t.Fatalf("round trip = %+v", again)
+	}
+}
+
+func TestParseObjectTypeWeightsRejectsInvalidIDs(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{`{"shop": 10}`, `{"0": 10}`, `[1]`} {
+		if _, err := ParseObjectTypeWeights(raw); err == nil {
+			t.Errorf("%s: expected an error", raw)
+		}
+	}
+	if weights, err := ParseObjectTypeWeights("  "); err != nil || weights != nil {
+		t.Fatalf("blank = %v, %v", weights, err)
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -40,6 +42,11 @@ const (
 	PrefCASLPass    = "casl.password"
 	PrefCASLPultID  = "casl.pult_id"
 	PrefLogLevel    = "log.level"
+
+	PrefCASLExtraPultIDs = "casl.extra_pult_ids"
+
+	PrefOperatorServerURL   = "operator_server.url"
+	PrefOperatorServerToken = "operator_server.token"
 )
 
 const (
@@ -101,6 +108,24 @@ type DBConfig struct {
 	CASLPass    string
 	CASLPultID  int64
 	LogLevel    string
+
+	// CASLExtraPultIDs — додаткові пульти CASL, які обслуговує робоче місце
+	// поряд з CASLPultID. Кожен пульт має власну сесію і realtime-підписку.
+	CASLExtraPultIDs []int64
+
+	// SourceInstances — додаткові джерела поряд з основними Firebird, Phoenix
+	// і CASL, наприклад друга БД МІСТ філії. Кожне отримує власний слот ID.
+	SourceInstances []SourceInstance
+
+	// OperatorServerURL перемикає оболонку на віддалений cmd/operator-server
+	// замість прямих підключень до БД і CASL.
+	OperatorServerURL   string
+	OperatorServerToken string
+}
+
+// UsesOperatorServer повідомляє, чи дані беруться з сервера оператора.
+func (c DBConfig) UsesOperatorServer() bool {
+	return strings.TrimSpace(c.OperatorServerURL) != ""
 }
 
 func LoadDBConfig(p Preferences) DBConfig {
@@ -140,6 +165,12 @@ func LoadDBConfig(p Preferences) DBConfig {
 		CASLPass:    p.StringWithFallback(PrefCASLPass, ""),
 		CASLPultID:  int64(p.IntWithFallback(PrefCASLPultID, 0)),
 		LogLevel:    applogger.NormalizeLogLevel(p.StringWithFallback(PrefLogLevel, "info")),
+
+		CASLExtraPultIDs: ParseCASLPultIDs(p.StringWithFallback(PrefCASLExtraPultIDs, "")),
+		SourceInstances:  loadSourceInstances(p),
+
+		OperatorServerURL:   strings.TrimSpace(p.StringWithFallback(PrefOperatorServerURL, "")),
+		OperatorServerToken: p.StringWithFallback(PrefOperatorServerToken, ""),
 	}
 
 	log.Debug().
@@ -196,10 +227,23 @@ func SaveDBConfig(p Preferences, cfg DBConfig) {
 	p.SetString(PrefCASLEmail, cfg.CASLEmail)
 	p.SetString(PrefCASLPass, cfg.CASLPass)
 	p.SetInt(PrefCASLPultID, int(cfg.CASLPultID))
+	p.SetString(PrefCASLExtraPultIDs, FormatCASLPultIDs(cfg.CASLExtraPultIDs))
+	p.SetString(PrefSourceInstances, FormatSourceInstances(cfg.SourceInstances))
 	p.SetString(PrefLogLevel, applogger.NormalizeLogLevel(cfg.LogLevel))
+	p.SetString(PrefOperatorServerURL, strings.TrimSpace(cfg.OperatorServerURL))
+	p.SetString(PrefOperatorServerToken, cfg.OperatorServerToken)
 	log.Debug().Str("host", cfg.Host).Str("port", cfg.Port).Msg("Налаштування БД збережено")
 }
 
+func loadSourceInstances(p Preferences) []SourceInstance {
+	instances, err := ParseSourceInstances(p.StringWithFallback(PrefSourceInstances, ""))
+	if err != nil {
+		log.Warn().Err(err).Msg("Додаткові джерела проігноровано")
+		return nil
+	}
+	return instances
+}
+
 func (c DBConfig) FirebirdDSN() string {
 	// Format: user:password@host:port/path?params
 	host := strings.TrimSpace(c.Host)
@@ -283,6 +327,45 @@ func (c DBConfig) PhoenixDSN() string {
 	return dsn
 }
 
+// CASLPultIDs повертає пульти CASL робочого місця: основний і додаткові без повторів.
+// Основний пульт 0 означає автовибір при логіні і лишається першим у списку.
+func (c DBConfig) CASLPultIDs() []int64 {
+	pults := []int64{c.CASLPultID}
+	for _, id := range c.CASLExtraPultIDs {
+		if id > 0 && !slices.Contains(pults, id) {
+			pults = append(pults, id)
+		}
+	}
+	return pults
+}
+
+// ParseCASLPultIDs розбирає список пультів на кшталт "2, 3;5".
+// Нечислові та непозитивні значення пропускаються.
+func ParseCASLPultIDs(raw string) []int64 {
+	var pults []int64
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	}) {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 || slices.Contains(pults, id) {
+			continue
+		}
+		pults = append(pults, id)
+	}
+	return pults
+}
+
+// FormatCASLPultIDs записує список пультів у вигляді "2,3,5".
+func FormatCASLPultIDs(pults []int64) string {
+	parts := make([]string, 0, len(pults))
+	for _, id := range pults {
+		if id > 0 {
+			parts = append(parts, strconv.FormatInt(id, 10))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
 func (c DBConfig) NormalizedMode() string {
 	return normalizeBackendMode(c.Mode)
 }
@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestNormalizeBackendMode(t *testing.T) {
 	t.Parallel()
@@ -271,3 +274,65 @@ func (p memoryPreferences) SetString(key string, value string) {
 		p.strings[key] = value
 	}
 }
+
+func TestSaveAndLoadDBConfigPreservesOperatorServer(t *testing.T) {
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+
+	SaveDBConfig(prefs, DBConfig{OperatorServerURL: " http://10.0.0.5:8090 ", OperatorServerToken: "secret"})
+	got := LoadDBConfig(prefs)
+
+	if got.OperatorServerURL != "http://10.0.0.5:8090" || got.OperatorServerToken != "secret" {
+		t.Fatalf("operator server settings = %q/%q", got.OperatorServerURL, got.OperatorServerToken)
+	}
+	if !got.UsesOperatorServer() || (DBConfig{}).UsesOperatorServer() {
+		t.Fatal("UsesOperatorServer() must follow OperatorServerURL")
+	}
+}
+
+func TestSaveAndLoadDBConfigPreservesCASLExtraPults(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+
+	SaveDBConfig(prefs, DBConfig{CASLPultID: 2, CASLExtraPultIDs: []int64{3, 5}})
+	got := LoadDBConfig(prefs)
+
+	if got.CASLPultID != 2 || !slices.Equal(got.CASLExtraPultIDs, []int64{3, 5}) {
+		t.Fatalf("CASL pults = %d/%v", got.CASLPultID, got.CASLExtraPultIDs)
+	}
+}
+
+func TestDBConfigCASLPultIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  DBConfig
+		want []int64
+	}{
+		{name: "single auto pult", cfg: DBConfig{}, want: []int64{0}},
+		{name: "primary first", cfg: DBConfig{CASLPultID: 2, CASLExtraPultIDs: []int64{3, 2, 4}}, want: []int64{2, 3, 4}},
+		{name: "auto primary with extras", cfg: DBConfig{CASLExtraPultIDs: []int64{7}}, want: []int64{0, 7}},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.CASLPultIDs(); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s: CASLPultIDs() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := ParseCASLPultIDs(" 3, x;3 0 -1 5"); !slices.Equal(got, []int64{3, 5}) {
+		t.Fatalf("ParseCASLPultIDs = %v", got)
+	}
+	if got := FormatCASLPultIDs([]int64{3, 0, 5}); got != "3,5" {
+		t.Fatalf("FormatCASLPultIDs = %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import "strings"
+
+const (
+	PrefDispatchRoadDistance = "dispatch.road_distance"
+	PrefDispatchRoutingURL   = "dispatch.routing_url"
+)
+
+// DefaultDispatchRoutingURL — публічний OSRM-сервіс для дорожніх відстаней.
+const DefaultDispatchRoutingURL = "https://router.project-osrm.org"
+
+// DispatchConfig описує, як радник диспетчеризації рахує відстань від МГР до об'єкта.
+// Без RoadDistance використовується пряма відстань (по прямій).
+type DispatchConfig struct {
+	RoadDistance bool
+	RoutingURL   string
+}
+
+func LoadDispatchConfig(p Preferences) DispatchConfig {
+	if p == nil {
+		return defaultDispatchConfig()
+	}
+	defaults := defaultDispatchConfig()
+	return DispatchConfig{
+		RoadDistance: p.BoolWithFallback(PrefDispatchRoadDistance, defaults.RoadDistance),
+		RoutingURL:   stringWithTrimmedFallback(p, PrefDispatchRoutingURL, defaults.RoutingURL),
+	}
+}
+
+func SaveDispatchConfig(p Preferences, cfg DispatchConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefDispatchRoadDistance, cfg.RoadDistance)
+	p.SetString(PrefDispatchRoutingURL, strings.TrimSpace(cfg.RoutingURL))
+}
+
+func defaultDispatchConfig() DispatchConfig {
+	return DispatchConfig{RoutingURL: DefaultDispatchRoutingURL}
+}
+
+// DispatchConfigStore абстрагує збереження налаштувань радника диспетчеризації.
+type DispatchConfigStore interface {
+	LoadDispatchConfig() DispatchConfig
+}
+
+// PreferencesDispatchConfigStore читає налаштування диспетчеризації з преференсів.
+type PreferencesDispatchConfigStore struct {
+	pref Preferences
+}
+
+func NewPreferencesDispatchConfigStore(pref Preferences) *PreferencesDispatchConfigStore {
+	if pref == nil {
+		return nil
+	}
+	return &PreferencesDispatchConfigStore{pref: pref}
+}
+
+func (s *PreferencesDispatchConfigStore) LoadDispatchConfig() DispatchConfig {
+	if s == nil || s.pref == nil {
+		return defaultDispatchConfig()
+	}
+	return LoadDispatchConfig(s.pref)
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestDispatchConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	if got := LoadDispatchConfig(prefs); got.RoadDistance || got.RoutingURL != DefaultDispatchRoutingURL {
+		t.Fatalf("defaults = %+v", got)
+	}
+
+	SaveDispatchConfig(prefs, DispatchConfig{RoadDistance: true, RoutingURL: " http://osrm.local:5000 "})
+	got := LoadDispatchConfig(prefs)
+	if !got.RoadDistance || got.RoutingURL != "http://osrm.local:5000" {
+		t.Fatalf("LoadDispatchConfig() = %+v", got)
+	}
+
+	SaveDispatchConfig(prefs, DispatchConfig{})
+	if got := LoadDispatchConfig(prefs); got.RoutingURL != DefaultDispatchRoutingURL {
+		t.Fatalf("empty routing URL must fall back to default, got %q", got.RoutingURL)
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import "strings"
+
+const (
+	PrefMaintenanceEnabled       = "maintenance.enabled"
+	PrefMaintenanceWindowsPath   = "maintenance.windows_path"
+	PrefMaintenanceReminderHours = "maintenance.reminder_hours"
+)
+
+// DefaultMaintenanceWindowsPath — файл вікон обслуговування поруч із програмою.
+// Щоб кілька робочих місць бачили ті самі вікна, шлях вказують на спільну теку.
+const DefaultMaintenanceWindowsPath = "maintenance-windows.json"
+
+const defaultMaintenanceReminderHours = 12
+
+// MaintenanceConfig описує вікна обслуговування з автоматичним приглушенням тривог.
+type MaintenanceConfig struct {
+	Enabled       bool
+	WindowsPath   string
+	ReminderHours int
+}
+
+func LoadMaintenanceConfig(p Preferences) MaintenanceConfig {
+	defaults := defaultMaintenanceConfig()
+	if p == nil {
+		return defaults
+	}
+	reminderHours := p.IntWithFallback(PrefMaintenanceReminderHours, defaults.ReminderHours)
+	if reminderHours <= 0 {
+		reminderHours = defaults.ReminderHours
+	}
+	return MaintenanceConfig{
+		Enabled:       p.BoolWithFallback(PrefMaintenanceEnabled, defaults.Enabled),
+		WindowsPath:   stringWithTrimmedFallback(p, PrefMaintenanceWindowsPath, defaults.WindowsPath),
+		ReminderHours: reminderHours,
+	}
+}
+
+func SaveMaintenanceConfig(p Preferences, cfg MaintenanceConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefMaintenanceEnabled, cfg.Enabled)
+	p.SetString(PrefMaintenanceWindowsPath, strings.TrimSpace(cfg.WindowsPath))
+	p.SetInt(PrefMaintenanceReminderHours, cfg.ReminderHours)
+}
+
+func defaultMaintenanceConfig() MaintenanceConfig {
+	return MaintenanceConfig{
+		Enabled:       true,
+		WindowsPath:   DefaultMaintenanceWindowsPath,
+		ReminderHours: defaultMaintenanceReminderHours,
+	}
+}
+
+// MaintenanceConfigStore абстрагує збереження налаштувань вікон обслуговування.
+type MaintenanceConfigStore interface {
+	LoadMaintenanceConfig() MaintenanceConfig
+}
+
+// PreferencesMaintenanceConfigStore читає налаштування вікон обслуговування з преференсів.
+type PreferencesMaintenanceConfigStore struct {
+	pref Preferences
+}
+
+func NewPreferencesMaintenanceConfigStore(pref Preferences) *PreferencesMaintenanceConfigStore {
+	if pref == nil {
+		return nil
+	}
+	return &PreferencesMaintenanceConfigStore{pref: pref}
+}
+
+func (s *PreferencesMaintenanceConfigStore) LoadMaintenanceConfig() MaintenanceConfig {
+	if s == nil || s.pref == nil {
+		return defaultMaintenanceConfig()
+	}
+	return LoadMaintenanceConfig(s.pref)
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestMaintenanceConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	if got := LoadMaintenanceConfig(prefs); !got.Enabled || got.WindowsPath != DefaultMaintenanceWindowsPath || got.ReminderHours != 12 {
+		t.Fatalf("defaults = %+v", got)
+	}
+
+	SaveMaintenanceConfig(prefs, MaintenanceConfig{WindowsPath: ` \\share\ops\maintenance.json `, ReminderHours: 4})
+	got := LoadMaintenanceConfig(prefs)
+	if got.Enabled || got.WindowsPath != `\\share\ops\maintenance.json` || got.ReminderHours != 4 {
+		t.Fatalf("LoadMaintenanceConfig() = %+v", got)
+	}
+
+	SaveMaintenanceConfig(prefs, MaintenanceConfig{Enabled: true})
+	if got := LoadMaintenanceConfig(prefs); got.WindowsPath != DefaultMaintenanceWindowsPath || got.ReminderHours != 12 {
+		t.Fatalf("empty values must fall back to defaults, got %+v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+const PrefObjectGroupsPath = "object_groups.path"
+
+// DefaultObjectGroupsPath — файл груп об'єктів поруч із програмою. Щоб групи
+// й списки спостереження були спільними для кількох робочих місць і
+// operator-server, шлях вказують на спільну теку.
+const DefaultObjectGroupsPath = "object-groups.json"
+
+// ObjectGroupsConfig описує, де зберігаються групи об'єктів операторів.
+type ObjectGroupsConfig struct {
+	Path string
+}
+
+func LoadObjectGroupsConfig(p Preferences) ObjectGroupsConfig {
+	if p == nil {
+		return ObjectGroupsConfig{Path: DefaultObjectGroupsPath}
+	}
+	return ObjectGroupsConfig{Path: stringWithTrimmedFallback(p, PrefObjectGroupsPath, DefaultObjectGroupsPath)}
+}
+
+func SaveObjectGroupsConfig(p Preferences, cfg ObjectGroupsConfig) {
+	if p == nil {
+		return
+	}
+	p.SetString(PrefObjectGroupsPath, strings.TrimSpace(cfg.Path))
+}
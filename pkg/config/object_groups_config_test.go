@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestObjectGroupsConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	if got := LoadObjectGroupsConfig(prefs); got.Path != DefaultObjectGroupsPath {
+		t.Fatalf("defaults = %+v", got)
+	}
+	SaveObjectGroupsConfig(prefs, ObjectGroupsConfig{Path: ` \\share\ops\groups.json `})
+	if got := LoadObjectGroupsConfig(prefs); got.Path != `\\share\ops\groups.json` {
+		t.Fatalf("LoadObjectGroupsConfig() = %+v", got)
+	}
+	SaveObjectGroupsConfig(prefs, ObjectGroupsConfig{})
+	if got := LoadObjectGroupsConfig(prefs); got.Path != DefaultObjectGroupsPath {
+		t.Fatalf("blank path must fall back to the default, got %+v", got)
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import "strings"
+
+const (
+	PrefOpenCloseEnabled         = "open_close.enabled"
+	PrefOpenCloseSchedulesPath   = "open_close.schedules_path"
+	PrefOpenCloseEscalateMinutes = "open_close.escalate_minutes"
+)
+
+// DefaultOpenCloseSchedulesPath — файл розкладів відкриття/закриття поруч із програмою.
+// Як і вікна обслуговування, для кількох робочих місць його кладуть у спільну теку.
+const DefaultOpenCloseSchedulesPath = "open-close-schedules.json"
+
+const defaultOpenCloseEscalateMinutes = 15
+
+// OpenCloseConfig описує контроль розкладу відкриття/закриття об'єктів.
+// EscalateMinutes = 0 вимикає ескалацію необроблених порушень.
+type OpenCloseConfig struct {
+	Enabled         bool
+	SchedulesPath   string
+	EscalateMinutes int
+}
+
+func LoadOpenCloseConfig(p Preferences) OpenCloseConfig {
+	defaults := defaultOpenCloseConfig()
+	if p == nil {
+		return defaults
+	}
+	escalateMinutes := p.IntWithFallback(PrefOpenCloseEscalateMinutes, defaults.EscalateMinutes)
+	if escalateMinutes < 0 {
+		escalateMinutes = defaults.EscalateMinutes
+	}
+	return OpenCloseConfig{
+		Enabled:         p.BoolWithFallback(PrefOpenCloseEnabled, defaults.Enabled),
+		SchedulesPath:   stringWithTrimmedFallback(p, PrefOpenCloseSchedulesPath, defaults.SchedulesPath),
+		EscalateMinutes: escalateMinutes,
+	}
+}
+
+func SaveOpenCloseConfig(p Preferences, cfg OpenCloseConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefOpenCloseEnabled, cfg.Enabled)
+	p.SetString(PrefOpenCloseSchedulesPath, strings.TrimSpace(cfg.SchedulesPath))
+	p.SetInt(PrefOpenCloseEscalateMinutes, cfg.EscalateMinutes)
+}
+
+func defaultOpenCloseConfig() OpenCloseConfig {
+	return OpenCloseConfig{
+		Enabled:         true,
+		SchedulesPath:   DefaultOpenCloseSchedulesPath,
+		EscalateMinutes: defaultOpenCloseEscalateMinutes,
+	}
+}
+
+// OpenCloseConfigStore абстрагує збереження налаштувань контролю розкладу.
+type OpenCloseConfigStore interface {
+	LoadOpenCloseConfig() OpenCloseConfig
+}
+
+// PreferencesOpenCloseConfigStore читає налаштування контролю розкладу з преференсів.
+type PreferencesOpenCloseConfigStore struct {
+	pref Preferences
+}
+
+func NewPreferencesOpenCloseConfigStore(pref Preferences) *PreferencesOpenCloseConfigStore {
+	if pref == nil {
+		return nil
+	}
+	return &PreferencesOpenCloseConfigStore{pref: pref}
+}
+
+func (s *PreferencesOpenCloseConfigStore) LoadOpenCloseConfig() OpenCloseConfig {
+	if s == nil || s.pref == nil {
+		return defaultOpenCloseConfig()
+	}
+	return LoadOpenCloseConfig(s.pref)
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestOpenCloseConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	if got := LoadOpenCloseConfig(prefs); !got.Enabled || got.SchedulesPath != DefaultOpenCloseSchedulesPath || got.EscalateMinutes != 15 {
+		t.Fatalf("defaults = %+v", got)
+	}
+
+	SaveOpenCloseConfig(prefs, OpenCloseConfig{SchedulesPath: ` \\share\ops\schedules.json `, EscalateMinutes: 0})
+	got := LoadOpenCloseConfig(prefs)
+	if got.Enabled || got.SchedulesPath != `\\share\ops\schedules.json` || got.EscalateMinutes != 0 {
+		t.Fatalf("LoadOpenCloseConfig() = %+v", got)
+	}
+
+	SaveOpenCloseConfig(prefs, OpenCloseConfig{Enabled: true, EscalateMinutes: -5})
+	if got := LoadOpenCloseConfig(prefs); got.SchedulesPath != DefaultOpenCloseSchedulesPath || got.EscalateMinutes != 15 {
+		t.Fatalf("invalid values must fall back to defaults, got %+v", got)
+	}
+}
@@ -0,0 +1,186 @@
+package config
+
+import "strings"
+
+const (
+	PrefReportUploadTarget            = "report_upload.target"
+	PrefReportUploadGDriveCredentials = "report_upload.gdrive.credentials_path"
+	PrefReportUploadGDriveToken       = "report_upload.gdrive.token_path"
+	PrefReportUploadLocalDir          = "report_upload.local.dir"
+	PrefReportUploadWebDAVURL         = "report_upload.webdav.url"
+	PrefReportUploadWebDAVUser        = "report_upload.webdav.user"
+	PrefReportUploadWebDAVPassword    = "report_upload.webdav.password"
+	PrefReportUploadS3Endpoint        = "report_upload.s3.endpoint"
+	PrefReportUploadS3Region          = "report_upload.s3.region"
+	PrefReportUploadS3Bucket          = "report_upload.s3.bucket"
+	PrefReportUploadS3AccessKey       = "report_upload.s3.access_key"
+	PrefReportUploadS3SecretKey       = "report_upload.s3.secret_key"
+	PrefReportUploadS3PathStyle       = "report_upload.s3.path_style"
+	PrefReportUploadPublicBaseURL     = "report_upload.public_base_url"
+)
+
+const (
+	ReportUploadTargetNone        = "none"
+	ReportUploadTargetGoogleDrive = "gdrive"
+	ReportUploadTargetLocal       = "local"
+	ReportUploadTargetWebDAV      = "webdav"
+	ReportUploadTargetS3          = "s3"
+)
+
+const (
+	DefaultReportUploadGDriveCredentials = "credentials.json"
+	DefaultReportUploadGDriveToken       = "token.json"
+	DefaultReportUploadS3Region          = "us-east-1"
+)
+
+// ReportUploadConfig описує, куди публікуються згенеровані звіти.
+type ReportUploadConfig struct {
+	// Target порожній, доки ціль не обрано в налаштуваннях: тоді звіт прийнятих
+	// об'єктів не публікується, а звіт видалених, як і раніше, іде в Google Drive.
+	// Явно обране "none" вимикає публікацію обох звітів.
+	Target string
+
+	GDriveCredentialsPath string
+	GDriveTokenPath       string
+
+	LocalDir string
+
+	WebDAVURL      string
+	WebDAVUser     string
+	WebDAVPassword string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool
+
+	// PublicBaseURL замінює службову адресу в посиланні, яке потрапляє у звіт.
+	PublicBaseURL string
+}
+
+func LoadReportUploadConfig(p Preferences) ReportUploadConfig {
+	if p == nil {
+		return defaultReportUploadConfig()
+	}
+	defaults := defaultReportUploadConfig()
+	return ReportUploadConfig{
+		Target:                loadReportUploadTarget(p),
+		GDriveCredentialsPath: stringWithTrimmedFallback(p, PrefReportUploadGDriveCredentials, defaults.GDriveCredentialsPath),
+		GDriveTokenPath:       stringWithTrimmedFallback(p, PrefReportUploadGDriveToken, defaults.GDriveTokenPath),
+		LocalDir:              strings.TrimSpace(p.StringWithFallback(PrefReportUploadLocalDir, "")),
+		WebDAVURL:             strings.TrimSpace(p.StringWithFallback(PrefReportUploadWebDAVURL, "")),
+		WebDAVUser:            strings.TrimSpace(p.StringWithFallback(PrefReportUploadWebDAVUser, "")),
+		WebDAVPassword:        p.StringWithFallback(PrefReportUploadWebDAVPassword, ""),
+		S3Endpoint:            strings.TrimSpace(p.StringWithFallback(PrefReportUploadS3Endpoint, "")),
+		S3Region:              stringWithTrimmedFallback(p, PrefReportUploadS3Region, defaults.S3Region),
+		S3Bucket:              strings.TrimSpace(p.StringWithFallback(PrefReportUploadS3Bucket, "")),
+		S3AccessKey:           strings.TrimSpace(p.StringWithFallback(PrefReportUploadS3AccessKey, "")),
+		S3SecretKey:           p.StringWithFallback(PrefReportUploadS3SecretKey, ""),
+		S3PathStyle:           p.BoolWithFallback(PrefReportUploadS3PathStyle, true),
+		PublicBaseURL:         strings.TrimSpace(p.StringWithFallback(PrefReportUploadPublicBaseURL, "")),
+	}
+}
+
+func SaveReportUploadConfig(p Preferences, cfg ReportUploadConfig) {
+	if p == nil {
+		return
+	}
+	// Порожня ціль означає, що її не обирали: преференс лишається незаписаним,
+	// щоб "ніколи не налаштовано" і явне "none" не змішувались.
+	if cfg.TargetSelected() {
+		p.SetString(PrefReportUploadTarget, NormalizeReportUploadTarget(cfg.Target))
+	}
+	p.SetString(PrefReportUploadGDriveCredentials, strings.TrimSpace(cfg.GDriveCredentialsPath))
+	p.SetString(PrefReportUploadGDriveToken, strings.TrimSpace(cfg.GDriveTokenPath))
+	p.SetString(PrefReportUploadLocalDir, strings.TrimSpace(cfg.LocalDir))
+	p.SetString(PrefReportUploadWebDAVURL, strings.TrimSpace(cfg.WebDAVURL))
+	p.SetString(PrefReportUploadWebDAVUser, strings.TrimSpace(cfg.WebDAVUser))
+	p.SetString(PrefReportUploadWebDAVPassword, cfg.WebDAVPassword)
+	p.SetString(PrefReportUploadS3Endpoint, strings.TrimSpace(cfg.S3Endpoint))
+	p.SetString(PrefReportUploadS3Region, strings.TrimSpace(cfg.S3Region))
+	p.SetString(PrefReportUploadS3Bucket, strings.TrimSpace(cfg.S3Bucket))
+	p.SetString(PrefReportUploadS3AccessKey, strings.TrimSpace(cfg.S3AccessKey))
+	p.SetString(PrefReportUploadS3SecretKey, cfg.S3SecretKey)
+	p.SetBool(PrefReportUploadS3PathStyle, cfg.S3PathStyle)
+	p.SetString(PrefReportUploadPublicBaseURL, strings.TrimSpace(cfg.PublicBaseURL))
+}
+
+// TargetSelected повідомляє, чи обрано ціль завантаження явно.
+func (c ReportUploadConfig) TargetSelected() bool {
+	return strings.TrimSpace(c.Target) != ""
+}
+
+// NormalizedTarget повертає підтримуваний тип цілі завантаження.
+func (c ReportUploadConfig) NormalizedTarget() string {
+	return NormalizeReportUploadTarget(c.Target)
+}
+
+// ReportUploadTargets повертає всі підтримувані типи цілей у порядку показу в налаштуваннях.
+func ReportUploadTargets() []string {
+	return []string{
+		ReportUploadTargetGoogleDrive,
+		ReportUploadTargetLocal,
+		ReportUploadTargetWebDAV,
+		ReportUploadTargetS3,
+		ReportUploadTargetNone,
+	}
+}
+
+// NormalizeReportUploadTarget приводить значення з преференсів до відомого типу цілі.
+func NormalizeReportUploadTarget(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", ReportUploadTargetNone, "off", "disabled":
+		return ReportUploadTargetNone
+	case ReportUploadTargetLocal, "smb", "folder":
+		return ReportUploadTargetLocal
+	case ReportUploadTargetWebDAV:
+		return ReportUploadTargetWebDAV
+	case ReportUploadTargetS3:
+		return ReportUploadTargetS3
+	default:
+		return ReportUploadTargetGoogleDrive
+	}
+}
+
+func loadReportUploadTarget(p Preferences) string {
+	target := strings.TrimSpace(p.StringWithFallback(PrefReportUploadTarget, ""))
+	if target == "" {
+		return ""
+	}
+	return NormalizeReportUploadTarget(target)
+}
+
+func defaultReportUploadConfig() ReportUploadConfig {
+	return ReportUploadConfig{
+		GDriveCredentialsPath: DefaultReportUploadGDriveCredentials,
+		GDriveTokenPath:       DefaultReportUploadGDriveToken,
+		S3Region:              DefaultReportUploadS3Region,
+		S3PathStyle:           true,
+	}
+}
+
+// ReportUploadConfigStore абстрагує збереження налаштувань публікації звітів.
+type ReportUploadConfigStore interface {
+	LoadReportUploadConfig() ReportUploadConfig
+}
+
+// PreferencesReportUploadConfigStore читає налаштування публікації звітів з преференсів.
+type PreferencesReportUploadConfigStore struct {
+	pref Preferences
+}
+
+func NewPreferencesReportUploadConfigStore(pref Preferences) *PreferencesReportUploadConfigStore {
+	if pref == nil {
+		return nil
+	}
+	return &PreferencesReportUploadConfigStore{pref: pref}
+}
+
+func (s *PreferencesReportUploadConfigStore) LoadReportUploadConfig() ReportUploadConfig {
+	if s == nil || s.pref == nil {
+		return defaultReportUploadConfig()
+	}
+	return LoadReportUploadConfig(s.pref)
+}
@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestNormalizeReportUploadTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":         ReportUploadTargetNone,
+		"GDRIVE":   ReportUploadTargetGoogleDrive,
+		" smb ":    ReportUploadTargetLocal,
+		"local":    ReportUploadTargetLocal,
+		"WebDAV":   ReportUploadTargetWebDAV,
+		"s3":       ReportUploadTargetS3,
+		"disabled": ReportUploadTargetNone,
+	}
+	for input, want := range tests {
+		if got := NormalizeReportUploadTarget(input); got != want {
+			t.Fatalf("NormalizeReportUploadTarget(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestReportUploadTargetIsOptIn(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	cfg := LoadReportUploadConfig(prefs)
+	if cfg.TargetSelected() || cfg.NormalizedTarget() != ReportUploadTargetNone {
+		t.Fatalf("default target = %q, want no selected target", cfg.Target)
+	}
+
+	SaveReportUploadConfig(prefs, cfg)
+	if _, ok := prefs.strings[PrefReportUploadTarget]; ok {
+		t.Fatal("saving an unselected target must leave it unselected")
+	}
+
+	cfg.Target = ReportUploadTargetNone
+	SaveReportUploadConfig(prefs, cfg)
+	if got := LoadReportUploadConfig(prefs); !got.TargetSelected() || got.NormalizedTarget() != ReportUploadTargetNone {
+		t.Fatalf("target = %q, want explicit none to be kept", got.Target)
+	}
+
+	cfg.Target = ReportUploadTargetWebDAV
+	SaveReportUploadConfig(prefs, cfg)
+	if got := LoadReportUploadConfig(prefs); got.NormalizedTarget() != ReportUploadTargetWebDAV {
+		t.Fatalf("target = %q, want webdav", got.Target)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PrefSavedViews зберігає іменовані подання (вирази мови фільтрів) у вигляді JSON-списку.
+const PrefSavedViews = "filters.saved_views"
+
+// MaxSavedViews обмежує список, щоб випадаючі меню панелей лишалися оглядовими.
+const MaxSavedViews = 50
+
+// SavedView — іменований вираз фільтра, спільний для списку об'єктів і журналу подій.
+type SavedView struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// LoadSavedViews читає збережені подання; пошкоджений JSON дає порожній список.
+func LoadSavedViews(p Preferences) []SavedView {
+	if p == nil {
+		return nil
+	}
+	views, err := ParseSavedViews(p.String(PrefSavedViews))
+	if err != nil {
+		return nil
+	}
+	return views
+}
+
+// SaveSavedViews перевіряє і записує список подань.
+func SaveSavedViews(p Preferences, views []SavedView) error {
+	views, err := NormalizeSavedViews(views)
+	if err != nil {
+		return err
+	}
+	if p != nil {
+		p.SetString(PrefSavedViews, FormatSavedViews(views))
+	}
+	return nil
+}
+
+// ParseSavedViews розбирає JSON-список подань. Порожній рядок — подань немає.
+func ParseSavedViews(raw string) ([]SavedView, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var views []SavedView
+	if err := json.Unmarshal([]byte(raw), &views); err != nil {
+		return nil, fmt.Errorf("некоректний JSON списку подань: %w", err)
+	}
+	return NormalizeSavedViews(views)
+}
+
+// FormatSavedViews записує список подань у JSON.
+func FormatSavedViews(views []SavedView) string {
+	if len(views) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(views)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// NormalizeSavedViews обрізає пробіли й перевіряє, що назви задані й не повторюються.
+// Синтаксис виразів додатково перевіряє usecases.NormalizeSavedViews.
+func NormalizeSavedViews(views []SavedView) ([]SavedView, error) {
+	if len(views) == 0 {
+		return nil, nil
+	}
+	if len(views) > MaxSavedViews {
+		return nil, fmt.Errorf("не більше %d збережених подань", MaxSavedViews)
+	}
+	result := make([]SavedView, 0, len(views))
+	names := make(map[string]struct{}, len(views))
+	for i, view := range views {
+		view.Name = strings.TrimSpace(view.Name)
+		view.Query = strings.TrimSpace(view.Query)
+		if view.Name == "" {
+			return nil, fmt.Errorf("подання #%d: не вказано назву", i+1)
+		}
+		if view.Query == "" {
+			return nil, fmt.Errorf("подання %q: порожній фільтр", view.Name)
+		}
+		key := strings.ToLower(view.Name)
+		if _, exists := names[key]; exists {
+			return nil, fmt.Errorf("подання %q: назва повторюється", view.Name)
+		}
+		names[key] = struct{}{}
+		result = append(result, view)
+	}
+	return result, nil
+}
+
+// UpsertSavedView замінює подання з тією ж назвою або додає нове в кінець.
+func UpsertSavedView(views []SavedView, view SavedView) []SavedView {
+	result := make([]SavedView, 0, len(views)+1)
+	replaced := false
+	for _, existing := range views {
+		if strings.EqualFold(strings.TrimSpace(existing.Name), strings.TrimSpace(view.Name)) {
+			existing = view
+			replaced = true
+		}
+		result = append(result, existing)
+	}
+	if !replaced {
+		result = append(result, view)
+	}
+	return result
+}
+
+// RemoveSavedView прибирає подання з назвою name.
+func RemoveSavedView(views []SavedView, name string) []SavedView {
+	result := make([]SavedView, 0, len(views))
+	for _, view := range views {
+		if !strings.EqualFold(strings.TrimSpace(view.Name), strings.TrimSpace(name)) {
+			result = append(result, view)
+		}
+	}
+	return result
+}
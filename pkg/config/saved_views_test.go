@@ -0,0 +1,52 @@
+package config
+
+import "testing"
+
+func TestSavedViewsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	if views := LoadSavedViews(prefs); views != nil {
+		t.Fatalf("defaults = %+v", views)
+	}
+
+	views := UpsertSavedView(nil, SavedView{Name: " Київ офлайн ", Query: ` region:"Київ" status:offline `})
+	views = UpsertSavedView(views, SavedView{Name: "Тести", Query: "lasttest>48h"})
+	views = UpsertSavedView(views, SavedView{Name: "київ офлайн", Query: "status:offline"})
+	if err := SaveSavedViews(prefs, views); err != nil {
+		t.Fatalf("SaveSavedViews() error = %v", err)
+	}
+	got := LoadSavedViews(prefs)
+	if len(got) != 2 || got[0].Name != "київ офлайн" || got[0].Query != "status:offline" || got[1].Query != "lasttest>48h" {
+		t.Fatalf("LoadSavedViews() = %+v", got)
+	}
+
+	if err := SaveSavedViews(prefs, RemoveSavedView(got, "ТЕСТИ")); err != nil {
+		t.Fatalf("SaveSavedViews() error = %v", err)
+	}
+	if got := LoadSavedViews(prefs); len(got) != 1 {
+		t.Fatalf("after remove = %+v", got)
+	}
+}
+
+func TestNormalizeSavedViewsRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, views := range map[string][]SavedView{
+		"no name":   {{Query: "status:offline"}},
+		"no query":  {{Name: "X"}},
+		"duplicate": {{Name: "X", Query: "a"}, {Name: "x", Query: "b"}},
+	} {
+		if _, err := NormalizeSavedViews(views); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+	if _, err := ParseSavedViews(`[{"name": `); err == nil {
+		t.Fatal("broken JSON must fail")
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PrefSourceInstances зберігає додаткові джерела даних у вигляді JSON-списку.
+const PrefSourceInstances = "sources.instances"
+
+const (
+	SourceInstanceFirebird = "firebird"
+	SourceInstancePhoenix  = "phoenix"
+	SourceInstanceCASL     = "casl"
+)
+
+// MaxSourceInstancesPerType — скільки додаткових джерел одного типу вміщує
+// простір ID (слот 0 займає основне джерело з налаштувань вище).
+const MaxSourceInstancesPerType = 9
+
+// SourceInstance описує додаткове джерело даних поряд з основними.
+// Порожні поля підключення беруться з основного джерела того ж типу,
+// тож для другої БД МІСТ на тому ж сервері досить вказати Path.
+type SourceInstance struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	Host     string `json:"host,omitempty"`
+	Port     string `json:"port,omitempty"`
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+	Params   string `json:"params,omitempty"`
+
+	// Firebird.
+	Path string `json:"path,omitempty"`
+
+	// Phoenix.
+	Instance string `json:"instance,omitempty"`
+	Database string `json:"database,omitempty"`
+
+	// CASL Cloud.
+	BaseURL string `json:"base_url,omitempty"`
+	Token   string `json:"token,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Pass    string `json:"pass,omitempty"`
+	PultID  int64  `json:"pult_id,omitempty"`
+}
+
+// ParseSourceInstances розбирає JSON-список додаткових джерел.
+// Порожній рядок означає відсутність додаткових джерел.
+func ParseSourceInstances(raw string) ([]SourceInstance, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var instances []SourceInstance
+	if err := json.Unmarshal([]byte(raw), &instances); err != nil {
+		return nil, fmt.Errorf("некоректний JSON списку джерел: %w", err)
+	}
+	return NormalizeSourceInstances(instances)
+}
+
+// FormatSourceInstances записує список додаткових джерел у JSON.
+func FormatSourceInstances(instances []SourceInstance) string {
+	if len(instances) == 0 {
+		return ""
+	}
+	data, err := json.MarshalIndent(instances, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// NormalizeSourceInstances перевіряє назви і типи джерел.
+// Назва потрібна, щоб розрізняти джерела у фільтрах і статусі підключень.
+func NormalizeSourceInstances(instances []SourceInstance) ([]SourceInstance, error) {
+	if len(instances) == 0 {
+		return nil, nil
+	}
+	result := make([]SourceInstance, 0, len(instances))
+	names := make(map[string]struct{}, len(instances))
+	perType := make(map[string]int, 3)
+	for i, instance := range instances {
+		instance.Name = strings.TrimSpace(instance.Name)
+		instance.Type = strings.ToLower(strings.TrimSpace(instance.Type))
+		if instance.Name == "" {
+			return nil, fmt.Errorf("джерело #%d: не вказано назву", i+1)
+		}
+		key := instance.Type + "\x00" + strings.ToLower(instance.Name)
+		if _, exists := names[key]; exists {
+			return nil, fmt.Errorf("джерело %q: назва повторюється", instance.Name)
+		}
+		switch instance.Type {
+		case SourceInstanceFirebird, SourceInstancePhoenix, SourceInstanceCASL:
+		default:
+			return nil, fmt.Errorf("джерело %q: невідомий тип %q", instance.Name, instance.Type)
+		}
+		perType[instance.Type]++
+		if perType[instance.Type] > MaxSourceInstancesPerType {
+			return nil, fmt.Errorf("джерело %q: не більше %d додаткових джерел типу %s", instance.Name, MaxSourceInstancesPerType, instance.Type)
+		}
+		names[key] = struct{}{}
+		result = append(result, instance)
+	}
+	return result, nil
+}
+
+// SourceInstancesOfType повертає додаткові джерела заданого типу в порядку налаштувань.
+func (c DBConfig) SourceInstancesOfType(sourceType string) []SourceInstance {
+	var result []SourceInstance
+	for _, instance := range c.SourceInstances {
+		if instance.Type == sourceType {
+			result = append(result, instance)
+		}
+	}
+	return result
+}
+
+// ForSourceInstance накладає параметри підключення додаткового джерела на
+// конфігурацію, щоб відкрити його тими ж FirebirdDSN/PhoenixDSN/CASL-клієнтом.
+func (c DBConfig) ForSourceInstance(instance SourceInstance) DBConfig {
+	cfg := c
+	cfg.SourceInstances = nil
+	switch instance.Type {
+	case SourceInstanceFirebird:
+		cfg.Host = overlayString(cfg.Host, instance.Host)
+		cfg.Port = overlayString(cfg.Port, instance.Port)
+		cfg.User = overlayString(cfg.User, instance.User)
+		cfg.Password = overlaySecret(cfg.Password, instance.Password)
+		cfg.Path = overlayString(cfg.Path, instance.Path)
+		cfg.Params = overlayString(cfg.Params, instance.Params)
+	case SourceInstancePhoenix:
+		cfg.PhoenixHost = overlayString(cfg.PhoenixHost, instance.Host)
+		cfg.PhoenixPort = overlayString(cfg.PhoenixPort, instance.Port)
+		cfg.PhoenixUser = overlayString(cfg.PhoenixUser, instance.User)
+		cfg.PhoenixPassword = overlaySecret(cfg.PhoenixPassword, instance.Password)
+		cfg.PhoenixInstance = overlayString(cfg.PhoenixInstance, instance.Instance)
+		cfg.PhoenixDatabase = overlayString(cfg.PhoenixDatabase, instance.Database)
+		cfg.PhoenixParams = overlayString(cfg.PhoenixParams, instance.Params)
+	case SourceInstanceCASL:
+		cfg.CASLBaseURL = overlayString(cfg.CASLBaseURL, instance.BaseURL)
+		// Токен і пульти основного сервера не підходять іншому серверу CASL.
+		cfg.CASLToken = instance.Token
+		cfg.CASLEmail = overlayString(cfg.CASLEmail, instance.Email)
+		cfg.CASLPass = overlaySecret(cfg.CASLPass, instance.Pass)
+		cfg.CASLPultID = instance.PultID
+		cfg.CASLExtraPultIDs = nil
+	}
+	return cfg
+}
+
+func overlayString(base string, value string) string {
+	if value = strings.TrimSpace(value); value != "" {
+		return value
+	}
+	return base
+}
+
+func overlaySecret(base string, value string) string {
+	if value != "" {
+		return value
+	}
+	return base
+}
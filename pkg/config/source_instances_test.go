@@ -0,0 +1,101 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSourceInstancesValidatesNamesAndTypes(t *testing.T) {
+	t.Parallel()
+
+	instances, err := ParseSourceInstances(`[
+		{"name": " Філія ", "type": "FIREBIRD", "path": "D:/BASE/MOST5.FDB"},
+		{"name": "Резерв", "type": "phoenix", "host": "10.0.0.5"}
+	]`)
+	if err != nil {
+		t.Fatalf("ParseSourceInstances() error = %v", err)
+	}
+	if len(instances) != 2 || instances[0].Name != "Філія" || instances[0].Type != SourceInstanceFirebird {
+		t.Fatalf("ParseSourceInstances() = %+v", instances)
+	}
+
+	for name, raw := range map[string]string{
+		"no name":      `[{"type": "firebird"}]`,
+		"unknown type": `[{"name": "X", "type": "oracle"}]`,
+		"duplicate":    `[{"name": "X", "type": "casl"}, {"name": "x", "type": "casl"}]`,
+		"broken json":  `[{"name": `,
+	} {
+		if _, err := ParseSourceInstances(raw); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+	if instances, err := ParseSourceInstances("  "); err != nil || instances != nil {
+		t.Fatalf("empty list = %+v, %v", instances, err)
+	}
+}
+
+func TestParseSourceInstancesLimitsInstancesPerType(t *testing.T) {
+	t.Parallel()
+
+	instances := make([]SourceInstance, 0, MaxSourceInstancesPerType+1)
+	for i := 0; i <= MaxSourceInstancesPerType; i++ {
+		instances = append(instances, SourceInstance{Name: strings.Repeat("x", i+1), Type: SourceInstanceFirebird})
+	}
+	if _, err := NormalizeSourceInstances(instances); err == nil {
+		t.Fatal("expected error for too many firebird instances")
+	}
+}
+
+func TestDBConfigForSourceInstanceOverlaysConnection(t *testing.T) {
+	t.Parallel()
+
+	base := DBConfig{
+		User: "SYSDBA", Password: "masterkey", Host: "10.0.0.1", Port: "3050", Path: "C:/MOST.FDB",
+		PhoenixHost: "10.0.0.2", PhoenixDatabase: "Pult4DB",
+		CASLBaseURL: "http://casl", CASLToken: "primary", CASLPultID: 7, CASLExtraPultIDs: []int64{9},
+		SourceInstances: []SourceInstance{{Name: "Філія", Type: SourceInstanceFirebird}},
+	}
+
+	firebird := base.ForSourceInstance(SourceInstance{Name: "Філія", Type: SourceInstanceFirebird, Path: "D:/BRANCH.FDB"})
+	if firebird.Path != "D:/BRANCH.FDB" || firebird.Host != "10.0.0.1" || firebird.Password != "masterkey" {
+		t.Fatalf("firebird overlay = %+v", firebird)
+	}
+	if firebird.SourceInstances != nil {
+		t.Fatal("instance config must not carry the instance list")
+	}
+
+	phoenix := base.ForSourceInstance(SourceInstance{Name: "Резерв", Type: SourceInstancePhoenix, Host: "10.0.0.9"})
+	if phoenix.PhoenixHost != "10.0.0.9" || phoenix.PhoenixDatabase != "Pult4DB" || phoenix.Path != base.Path {
+		t.Fatalf("phoenix overlay = %+v", phoenix)
+	}
+
+	casl := base.ForSourceInstance(SourceInstance{Name: "Хмара", Type: SourceInstanceCASL, BaseURL: "http://casl2", PultID: 3})
+	if casl.CASLBaseURL != "http://casl2" || casl.CASLToken != "" || casl.CASLPultID != 3 || casl.CASLExtraPultIDs != nil {
+		t.Fatalf("casl overlay = %+v", casl)
+	}
+}
+
+func TestSaveAndLoadDBConfigPreservesSourceInstances(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	want := []SourceInstance{
+		{Name: "Філія", Type: SourceInstanceFirebird, Path: "D:/BRANCH.FDB"},
+		{Name: "Хмара", Type: SourceInstanceCASL, PultID: 3},
+	}
+	SaveDBConfig(prefs, DBConfig{SourceInstances: want})
+	got := LoadDBConfig(prefs).SourceInstances
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("SourceInstances = %+v, want %+v", got, want)
+	}
+
+	prefs.strings[PrefSourceInstances] = "not json"
+	if got := LoadDBConfig(prefs).SourceInstances; got != nil {
+		t.Fatalf("broken list must be ignored, got %+v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+const (
+	PrefTestSupervisionMISTEnabled         = "test_supervision.mist_enabled"
+	PrefTestSupervisionMISTGraceMinutes    = "test_supervision.mist_grace_minutes"
+	PrefTestSupervisionPhoenixEnabled      = "test_supervision.phoenix_enabled"
+	PrefTestSupervisionPhoenixGraceMinutes = "test_supervision.phoenix_grace_minutes"
+	PrefTestSupervisionCASLEnabled         = "test_supervision.casl_enabled"
+	PrefTestSupervisionCASLGraceMinutes    = "test_supervision.casl_grace_minutes"
+)
+
+const defaultTestSupervisionGraceMinutes = 30
+
+// TestSupervisionSourceConfig — контроль періодичних тестів для одного джерела.
+type TestSupervisionSourceConfig struct {
+	Enabled      bool
+	GraceMinutes int
+}
+
+// TestSupervisionConfig описує локальний контроль пропущених періодичних тестів.
+// МІСТ контролює тести сам, тому для нього локальний контроль типово вимкнено.
+type TestSupervisionConfig struct {
+	MIST    TestSupervisionSourceConfig
+	Phoenix TestSupervisionSourceConfig
+	CASL    TestSupervisionSourceConfig
+}
+
+// Enabled повідомляє, чи увімкнено контроль хоча б для одного джерела.
+func (c TestSupervisionConfig) Enabled() bool {
+	return c.MIST.Enabled || c.Phoenix.Enabled || c.CASL.Enabled
+}
+
+func LoadTestSupervisionConfig(p Preferences) TestSupervisionConfig {
+	defaults := defaultTestSupervisionConfig()
+	if p == nil {
+		return defaults
+	}
+	return TestSupervisionConfig{
+		MIST:    loadTestSupervisionSource(p, PrefTestSupervisionMISTEnabled, PrefTestSupervisionMISTGraceMinutes, defaults.MIST),
+		Phoenix: loadTestSupervisionSource(p, PrefTestSupervisionPhoenixEnabled, PrefTestSupervisionPhoenixGraceMinutes, defaults.Phoenix),
+		CASL:    loadTestSupervisionSource(p, PrefTestSupervisionCASLEnabled, PrefTestSupervisionCASLGraceMinutes, defaults.CASL),
+	}
+}
+
+func SaveTestSupervisionConfig(p Preferences, cfg TestSupervisionConfig) {
+	if p == nil {
+		return
+	}
+	p.SetBool(PrefTestSupervisionMISTEnabled, cfg.MIST.Enabled)
+	p.SetInt(PrefTestSupervisionMISTGraceMinutes, cfg.MIST.GraceMinutes)
+	p.SetBool(PrefTestSupervisionPhoenixEnabled, cfg.Phoenix.Enabled)
+	p.SetInt(PrefTestSupervisionPhoenixGraceMinutes, cfg.Phoenix.GraceMinutes)
+	p.SetBool(PrefTestSupervisionCASLEnabled, cfg.CASL.Enabled)
+	p.SetInt(PrefTestSupervisionCASLGraceMinutes, cfg.CASL.GraceMinutes)
+}
+
+func loadTestSupervisionSource(p Preferences, enabledKey string, graceKey string, defaults TestSupervisionSourceConfig) TestSupervisionSourceConfig {
+	grace := p.IntWithFallback(graceKey, defaults.GraceMinutes)
+	if grace < 0 {
+		grace = defaults.GraceMinutes
+	}
+	return TestSupervisionSourceConfig{
+		Enabled:      p.BoolWithFallback(enabledKey, defaults.Enabled),
+		GraceMinutes: grace,
+	}
+}
+
+func defaultTestSupervisionConfig() TestSupervisionConfig {
+	return TestSupervisionConfig{
+		MIST:    TestSupervisionSourceConfig{Enabled: false, GraceMinutes: defaultTestSupervisionGraceMinutes},
+		Phoenix: TestSupervisionSourceConfig{Enabled: true, GraceMinutes: defaultTestSupervisionGraceMinutes},
+		CASL:    TestSupervisionSourceConfig{Enabled: true, GraceMinutes: defaultTestSupervisionGraceMinutes},
+	}
+}
+
+// TestSupervisionConfigStore абстрагує збереження налаштувань контролю тестів.
+type TestSupervisionConfigStore interface {
+	LoadTestSupervisionConfig() TestSupervisionConfig
+}
+
+// PreferencesTestSupervisionConfigStore читає налаштування контролю тестів з преференсів.
+type PreferencesTestSupervisionConfigStore struct {
+	pref Preferences
+}
+
+func NewPreferencesTestSupervisionConfigStore(pref Preferences) *PreferencesTestSupervisionConfigStore {
+	if pref == nil {
+		return nil
+	}
+	return &PreferencesTestSupervisionConfigStore{pref: pref}
+}
+
+func (s *PreferencesTestSupervisionConfigStore) LoadTestSupervisionConfig() TestSupervisionConfig {
+	if s == nil || s.pref == nil {
+		return defaultTestSupervisionConfig()
+	}
+	return LoadTestSupervisionConfig(s.pref)
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestTestSupervisionConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	prefs := memoryPreferences{
+		strings: map[string]string{},
+		bools:   map[string]bool{},
+		ints:    map[string]int{},
+		floats:  map[string]float64{},
+	}
+	defaults := LoadTestSupervisionConfig(prefs)
+	if defaults.MIST.Enabled || !defaults.Phoenix.Enabled || !defaults.CASL.Enabled || defaults.CASL.GraceMinutes != 30 {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+
+	SaveTestSupervisionConfig(prefs, TestSupervisionConfig{
+		MIST:    TestSupervisionSourceConfig{Enabled: true, GraceMinutes: 5},
+		Phoenix: TestSupervisionSourceConfig{GraceMinutes: 0},
+		CASL:    TestSupervisionSourceConfig{Enabled: true, GraceMinutes: -10},
+	})
+	got := LoadTestSupervisionConfig(prefs)
+	if !got.MIST.Enabled || got.MIST.GraceMinutes != 5 || got.Phoenix.Enabled || got.Phoenix.GraceMinutes != 0 {
+		t.Fatalf("LoadTestSupervisionConfig() = %+v", got)
+	}
+	if got.CASL.GraceMinutes != 30 {
+		t.Fatalf("negative grace must fall back to default, got %+v", got.CASL)
+	}
+	if !got.Enabled() || (TestSupervisionConfig{}).Enabled() {
+		t.Fatal("Enabled() must report whether any source is supervised")
+	}
+}
@@ -155,3 +155,19 @@ type AdminObjectDialogProvider interface {
 	AdminObjectZoneService
 	AdminObjectCoordinatesService
 }
+
+// PhoenixObjectAdminProvider - адміністрування панелей Phoenix: картка
+// (Panel/Groups/Company), зони та відповідальні особи.
+type PhoenixObjectAdminProvider interface {
+	AdminObjectCardService
+	AdminObjectZoneService
+	AdminObjectPersonalService
+
+	// PanelObjectID повертає ID об'єкта застосунку для Panel_id (0 для порожнього).
+	PanelObjectID(panelID string) int64
+}
+
+// PhoenixObjectAdminSource реалізують джерела, які вміють редагувати панелі Phoenix.
+type PhoenixObjectAdminSource interface {
+	PhoenixObjectAdmin() PhoenixObjectAdminProvider
+}
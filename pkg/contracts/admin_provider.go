@@ -1,9 +1,19 @@
 package contracts
 
 import (
+	"errors"
+
 	"obj_catalog_fyne_v3/pkg/models"
 )
 
+// Види помилок адміністрування. Провайдери обгортають ними свої помилки
+// (errors.Is), а адмін-API за ними обирає HTTP-статус.
+var (
+	ErrAdminNotFound   = errors.New("admin record not found")
+	ErrAdminConflict   = errors.New("admin record conflicts with existing data")
+	ErrAdminValidation = errors.New("admin request is invalid")
+)
+
 // AdminProvider визначає доступний у UI адмінський функціонал.
 type AdminProvider interface {
 	AdminObjectDialogProvider
@@ -14,6 +14,8 @@ type AdminObjectCard struct {
 	ObjN   int64
 	GrpN   int64
 
+	PanelID string // Panel_id у Phoenix; для МІСТ порожній
+
 	ShortName string
 	FullName  string
 	ObjTypeID int64
@@ -205,6 +205,9 @@ type CASLDeviceUpdate struct {
 
 // CASLDeviceCreate описує створення нового обладнання.
 type CASLDeviceCreate struct {
+	// PultID — пульт CASL, на якому створюється прилад (як ReactingPultID
+	// об'єкта). Обов'язковий, коли робоче місце обслуговує кілька пультів.
+	PultID            string
 	Number            int64
 	Name              string
 	DeviceType        string
@@ -3,6 +3,7 @@ package contracts
 import (
 	"context"
 	"errors"
+	"io"
 	"obj_catalog_fyne_v3/pkg/ids"
 	"time"
 )
@@ -13,6 +14,7 @@ var (
 	ErrMissingLegacyObjectPayload = errors.New("legacy object payload is required")
 	ErrMissingCASLObjectPayload   = errors.New("casl object payload is required")
 	ErrAlarmOwnershipConflict     = errors.New("alarm is being processed by another operator")
+	ErrFrontendRevisionConflict   = errors.New("data was changed by another client")
 )
 
 const DefaultOperatorName = "Диспетчер"
@@ -54,6 +56,7 @@ func DetectFrontendSourceByObjectID(objectID int) FrontendSource {
 
 type FrontendSourceCapability struct {
 	Source            FrontendSource
+	Instance          string // Назва екземпляра, коли джерел одного типу кілька
 	DisplayName       string
 	ReadObjects       bool
 	ReadObjectDetails bool
@@ -200,7 +203,22 @@ type FrontendAlarmItem struct {
 	ResponseGroupID           string
 	IsResponseGroupDispatched bool
 	IsResponseGroupArrived    bool
+	MaintenanceReason         string
+	Watched                   bool
 	VisualSeverity            FrontendVisualSeverity
+	Priority                  FrontendAlarmPriority
+}
+
+// FrontendAlarmPriority — оцінка пріоритету тривоги з внеском кожного чинника.
+// Нульове значення означає, що оцінка вимкнена.
+type FrontendAlarmPriority struct {
+	Score      int
+	Type       int
+	ObjectType int
+	Contract   int
+	Repeats    int
+	Unattended int
+	Dispatched int
 }
 
 type FrontendAlarmProcessingOption struct {
@@ -221,6 +239,7 @@ type FrontendResponseGroup struct {
 	Latitude        string
 	Longitude       string
 	StatusChangedAt time.Time
+	Suggestion      ResponseGroupSuggestion
 }
 
 type FrontendAlarmProcessRequest struct {
@@ -231,6 +250,8 @@ type FrontendAlarmProcessRequest struct {
 
 type FrontendAlarmPickRequest struct {
 	User string
+	// Reason обов'язкова при перехопленні тривоги, яку обробляє інший оператор.
+	Reason string
 }
 
 type FrontendEventItem struct {
@@ -297,6 +318,7 @@ type FrontendLegacyObjectPayload struct {
 	ObjUIN             int64
 	ObjN               int64
 	GrpN               int64
+	PanelID            string
 	ObjTypeID          int64
 	ObjRegID           int64
 	ChannelCode        int64
@@ -352,6 +374,75 @@ type FrontendStandbyRequest struct {
 	Reason          string // причина переведення в стенди
 }
 
+// FrontendAlarmResponseGroupsBackend optionally returns response groups ranked
+// for a specific alarm (dispatch suggestions).
+type FrontendAlarmResponseGroupsBackend interface {
+	ListResponseGroupsForAlarm(ctx context.Context, alarmID int) ([]FrontendResponseGroup, error)
+}
+
+// FrontendEventExportRequest описує фільтр експорту журналу подій.
+// Порожні списки означають «усі»; Format — csv або xlsx.
+type FrontendEventExportRequest struct {
+	Format    string
+	Sources   []FrontendSource
+	Severity  string
+	ObjectIDs []int
+	Types     []string
+	From      time.Time
+	To        time.Time
+}
+
+// FrontendEventExportBackend optionally streams the filtered event journal
+// into w and returns the number of exported events.
+type FrontendEventExportBackend interface {
+	ExportEvents(ctx context.Context, request FrontendEventExportRequest, w io.Writer) (int, error)
+}
+
+// FrontendQueryBackend optionally filters objects and the event journal by an
+// expression of the filter language (see usecases.ParseFilterQuery).
+type FrontendQueryBackend interface {
+	QueryObjects(ctx context.Context, query string) ([]FrontendObjectSummary, error)
+	QueryEvents(ctx context.Context, query string) ([]FrontendEventItem, error)
+}
+
+// FrontendSavedView — іменований вираз мови фільтрів, спільний для всіх інтерфейсів.
+type FrontendSavedView struct {
+	Name  string
+	Query string
+}
+
+// FrontendSavedViewsBackend optionally stores the saved views list; SaveSavedViews
+// replaces the whole list and rejects invalid names or expressions.
+type FrontendSavedViewsBackend interface {
+	ListSavedViews(ctx context.Context) ([]FrontendSavedView, error)
+	SaveSavedViews(ctx context.Context, views []FrontendSavedView) error
+}
+
+// FrontendObjectGroup — спільна група об'єктів: перелік ObjectIDs або вираз Query.
+// Watch додає об'єкти групи до списку спостереження.
+type FrontendObjectGroup struct {
+	ID        string
+	Name      string
+	ObjectIDs []int
+	Query     string
+	Watch     bool
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// FrontendObjectGroupsBackend optionally stores shared object groups. ListObjectGroups
+// also returns the revision of the list. SaveObjectGroups replaces the whole list only
+// while it is still at revision (an empty revision skips the check), fails with
+// ErrFrontendRevisionConflict otherwise and returns the new revision.
+// ObjectGroupMembers fails for an unknown group and WatchedObjects returns the
+// union of all watchlist groups.
+type FrontendObjectGroupsBackend interface {
+	ListObjectGroups(ctx context.Context) ([]FrontendObjectGroup, string, error)
+	SaveObjectGroups(ctx context.Context, groups []FrontendObjectGroup, revision string) (string, error)
+	ObjectGroupMembers(ctx context.Context, groupID string) (map[int]struct{}, error)
+	WatchedObjects(ctx context.Context) (map[int]struct{}, error)
+}
+
 type FrontendBackend interface {
 	Capabilities(ctx context.Context) (FrontendCapabilities, error)
 	ListObjects(ctx context.Context) ([]FrontendObjectSummary, error)
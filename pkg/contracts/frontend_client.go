@@ -0,0 +1,34 @@
+package contracts
+
+import (
+	"context"
+	"strings"
+)
+
+// FrontendClient ідентифікує тонкий клієнт спільного сервера оператора. Один
+// процес обслуговує багато робочих місць, тому володіння тривогами МІСТ
+// визначається клієнтом запиту, а не хостом сервера.
+type FrontendClient struct {
+	ID          string
+	Workstation string
+}
+
+type frontendClientKey struct{}
+
+// WithFrontendClient додає до контексту запиту клієнта, від імені якого він виконується.
+func WithFrontendClient(ctx context.Context, client FrontendClient) context.Context {
+	client.ID = strings.TrimSpace(client.ID)
+	if client.ID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, frontendClientKey{}, client)
+}
+
+// FrontendClientFromContext повертає клієнта запиту; false — запит локального робочого місця.
+func FrontendClientFromContext(ctx context.Context) (FrontendClient, bool) {
+	if ctx == nil {
+		return FrontendClient{}, false
+	}
+	client, ok := ctx.Value(frontendClientKey{}).(FrontendClient)
+	return client, ok
+}
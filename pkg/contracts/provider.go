@@ -19,6 +19,12 @@ type ContextObjectProvider interface {
 	GetObjectsContext(ctx context.Context) []models.Object
 }
 
+// LoadedObjectsProvider returns the most recently loaded object list without
+// querying the sources, for consumers that must not trigger a full scan.
+type LoadedObjectsProvider interface {
+	LoadedObjects() []models.Object
+}
+
 type DetailProvider interface {
 	GetZones(objectID string) []models.Zone
 	GetEmployees(objectID string) []models.Contact
@@ -67,6 +73,38 @@ type ObjectEventsRangeProvider interface {
 	GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event
 }
 
+// ObjectEventsRangeQueryProvider is ObjectEventsRangeProvider that reports read
+// failures instead of returning an empty range, so exports can tell a quiet
+// object from an unreachable source.
+type ObjectEventsRangeQueryProvider interface {
+	QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error)
+}
+
+// EventArchiveQuery describes one page of a long-range archive search.
+// Zero values mean "no filter"; Cursor is the opaque NextCursor of the previous page.
+type EventArchiveQuery struct {
+	ObjectID int
+	Code     string
+	Groups   []int
+	From     time.Time
+	To       time.Time
+	Cursor   string
+	Limit    int
+}
+
+// EventArchivePage is one page of archive events, newest first.
+// An empty NextCursor means the archive has no more matching events.
+type EventArchivePage struct {
+	Events     []models.Event
+	NextCursor string
+}
+
+// EventArchiveProvider optionally pages through a source's full event archive
+// (years of history) for browsing and exports without loading it into memory.
+type EventArchiveProvider interface {
+	QueryEventArchive(ctx context.Context, query EventArchiveQuery) (EventArchivePage, error)
+}
+
 type ObjectMediaKind string
 
 const (
@@ -145,6 +183,12 @@ type AlarmTakeoverProvider interface {
 	PickAlarm(ctx context.Context, alarm models.Alarm, user string) error
 }
 
+// AlarmTakeoverReasonProvider описує перехоплення тривоги, яку вже обробляє інший оператор,
+// із зазначенням причини перехоплення.
+type AlarmTakeoverReasonProvider interface {
+	TakeOverAlarm(ctx context.Context, alarm models.Alarm, user string, reason string) error
+}
+
 type ResponseGroupStatus string
 
 const (
@@ -168,6 +212,21 @@ type ResponseGroup struct {
 	Latitude        string
 	Longitude       string
 	StatusChangedAt time.Time
+	Suggestion      ResponseGroupSuggestion
+}
+
+// ResponseGroupSuggestion описує рекомендацію диспетчеризації групи
+// для конкретної тривоги: відстань до об'єкта, належність до геозони та ранг.
+type ResponseGroupSuggestion struct {
+	Rank            int
+	Recommended     bool
+	InObjectGeoZone bool
+	Busy            bool
+	AssignedToAlarm bool
+	DistanceKnown   bool
+	RoadDistance    bool
+	DistanceKm      float64
+	Note            string
 }
 
 // AlarmGroupProcessProvider описує групове завершення тривог (МІСТ).
@@ -0,0 +1,31 @@
+package data
+
+import (
+	"fmt"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// adminError позначає помилку адміністрування видом із contracts
+// (ErrAdminNotFound, ErrAdminConflict, ErrAdminValidation), не змінюючи
+// тексту, який бачить оператор.
+type adminError struct {
+	kind    error
+	message string
+}
+
+func (e *adminError) Error() string { return e.message }
+
+func (e *adminError) Unwrap() error { return e.kind }
+
+func adminNotFoundf(format string, args ...any) error {
+	return &adminError{kind: contracts.ErrAdminNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func adminConflictf(format string, args ...any) error {
+	return &adminError{kind: contracts.ErrAdminConflict, message: fmt.Sprintf(format, args...)}
+}
+
+func adminInvalidf(format string, args ...any) error {
+	return &adminError{kind: contracts.ErrAdminValidation, message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,555 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// DefaultBridgeAlarmLeaseTTL визначає, скільки тривога МІСТ лишається за оператором без продовження.
+// Оренда продовжується при кожному опитуванні тривог на робочому місці власника.
+const DefaultBridgeAlarmLeaseTTL = 3 * time.Minute
+
+const bridgeAlarmLeaseTable = "OBJCAT_ALARM_LEASES"
+
+// alarmLeaseSchemaRetryInterval — як часто повторювати спробу підготувати
+// спільну таблицю оренд, поки робоче місце працює з локальним володінням.
+const alarmLeaseSchemaRetryInterval = 30 * time.Second
+
+// AlarmLeaseOwner ідентифікує робоче місце та оператора, що взяв тривогу в роботу.
+type AlarmLeaseOwner struct {
+	ID          string
+	Name        string
+	Workstation string
+}
+
+// AlarmLease описує поточне володіння тривогою МІСТ.
+type AlarmLease struct {
+	AlarmID        int
+	Owner          AlarmLeaseOwner
+	AcquiredAt     time.Time
+	ExpiresAt      time.Time
+	TakeoverReason string
+}
+
+// AlarmLeaseStore зберігає спільне між робочими місцями володіння тривогами МІСТ.
+// Acquire повертає contracts.ErrAlarmOwnershipConflict, якщо тривогу тримає інший власник,
+// а takeover не запитано.
+type AlarmLeaseStore interface {
+	Acquire(ctx context.Context, alarmID int, owner AlarmLeaseOwner, ttl time.Duration, takeover bool, reason string) (AlarmLease, error)
+	Release(ctx context.Context, alarmID int, ownerID string) error
+	ListActive(ctx context.Context) (map[int]AlarmLease, error)
+}
+
+// LocalAlarmLeaseOwner повертає власника оренди для поточного робочого місця.
+func LocalAlarmLeaseOwner(operator string) AlarmLeaseOwner {
+	workstation, _ := os.Hostname()
+	workstation = strings.TrimSpace(workstation)
+	if workstation == "" {
+		workstation = "localhost"
+	}
+	login := ""
+	if current, err := user.Current(); err == nil {
+		login = strings.TrimSpace(current.Username)
+	}
+	id := workstation
+	if login != "" {
+		id += "/" + login
+	}
+	return AlarmLeaseOwner{
+		ID:          id,
+		Name:        strings.TrimSpace(operator),
+		Workstation: workstation,
+	}
+}
+
+// DisplayName повертає підпис власника для колонки "У роботі".
+func (o AlarmLeaseOwner) DisplayName() string {
+	name := strings.TrimSpace(o.Name)
+	workstation := strings.TrimSpace(o.Workstation)
+	switch {
+	case name != "" && workstation != "":
+		return name + " (" + workstation + ")"
+	case name != "":
+		return name
+	case workstation != "":
+		return workstation
+	default:
+		return strings.TrimSpace(o.ID)
+	}
+}
+
+// MemoryAlarmLeaseStore — локальна заміна спільного сховища оренд у межах одного процесу.
+type MemoryAlarmLeaseStore struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	leases map[int]AlarmLease
+}
+
+// NewMemoryAlarmLeaseStore створює локальне сховище оренд.
+func NewMemoryAlarmLeaseStore() *MemoryAlarmLeaseStore {
+	return &MemoryAlarmLeaseStore{now: time.Now, leases: make(map[int]AlarmLease)}
+}
+
+func (s *MemoryAlarmLeaseStore) Acquire(_ context.Context, alarmID int, owner AlarmLeaseOwner, ttl time.Duration, takeover bool, reason string) (AlarmLease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	existing, ok := s.leases[alarmID]
+	if ok && existing.Owner.ID != owner.ID && existing.ExpiresAt.After(now) && !takeover {
+		return existing, fmt.Errorf("%w: %s", contracts.ErrAlarmOwnershipConflict, existing.Owner.DisplayName())
+	}
+	lease := AlarmLease{
+		AlarmID:    alarmID,
+		Owner:      owner,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	if ok && existing.Owner.ID == owner.ID && existing.ExpiresAt.After(now) {
+		lease.AcquiredAt = existing.AcquiredAt
+		lease.TakeoverReason = existing.TakeoverReason
+	}
+	if takeover && ok && existing.Owner.ID != owner.ID {
+		lease.TakeoverReason = strings.TrimSpace(reason)
+	}
+	s.leases[alarmID] = lease
+	return lease, nil
+}
+
+func (s *MemoryAlarmLeaseStore) Release(_ context.Context, alarmID int, ownerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.leases[alarmID]
+	if !ok {
+		return nil
+	}
+	if ownerID == "" || existing.Owner.ID == ownerID {
+		delete(s.leases, alarmID)
+	}
+	return nil
+}
+
+func (s *MemoryAlarmLeaseStore) ListActive(_ context.Context) (map[int]AlarmLease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	result := make(map[int]AlarmLease, len(s.leases))
+	for id, lease := range s.leases {
+		if lease.ExpiresAt.After(now) {
+			result[id] = lease
+		} else {
+			delete(s.leases, id)
+		}
+	}
+	return result, nil
+}
+
+// FirebirdAlarmLeaseStore зберігає оренди тривог у службовій таблиці БД МІСТ,
+// щоб усі робочі місця бачили, хто обробляє тривогу. Час оренди рахується за годинником сервера.
+type FirebirdAlarmLeaseStore struct {
+	db *sqlx.DB
+
+	ensureMu sync.Mutex
+	ensured  bool
+}
+
+// NewFirebirdAlarmLeaseStore створює сховище оренд у Firebird.
+func NewFirebirdAlarmLeaseStore(db *sqlx.DB) *FirebirdAlarmLeaseStore {
+	return &FirebirdAlarmLeaseStore{db: db}
+}
+
+// EnsureSchema створює таблицю оренд, якщо її ще немає.
+func (s *FirebirdAlarmLeaseStore) EnsureSchema(ctx context.Context) error {
+	if s == nil || s.db == nil {
+		return errors.New("firebird alarm lease store: database is unavailable")
+	}
+	s.ensureMu.Lock()
+	defer s.ensureMu.Unlock()
+	if s.ensured {
+		return nil
+	}
+	exists, err := s.tableExists(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		const ddl = `
+			CREATE TABLE ` + bridgeAlarmLeaseTable + ` (
+				ALARM_ID BIGINT NOT NULL PRIMARY KEY,
+				OWNER_ID VARCHAR(128) NOT NULL,
+				OWNER_NAME VARCHAR(128),
+				WORKSTATION VARCHAR(128),
+				ACQUIRED_AT TIMESTAMP NOT NULL,
+				EXPIRES_AT TIMESTAMP NOT NULL,
+				TAKEOVER_REASON VARCHAR(255)
+			)
+		`
+		if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+			// Робочі місця, що стартують одночасно, створюють таблицю наввипередки:
+			// програш у цих перегонах не є помилкою.
+			if !strings.Contains(strings.ToLower(err.Error()), "already exists") {
+				if created, checkErr := s.tableExists(ctx); checkErr != nil || !created {
+					return fmt.Errorf("firebird alarm lease store: create table: %w", err)
+				}
+			}
+		}
+	}
+	s.ensured = true
+	return nil
+}
+
+func (s *FirebirdAlarmLeaseStore) tableExists(ctx context.Context) (bool, error) {
+	var count int
+	if err := s.db.GetContext(ctx, &count, s.db.Rebind(`SELECT COUNT(*) FROM RDB$RELATIONS WHERE RDB$RELATION_NAME = ?`), bridgeAlarmLeaseTable); err != nil {
+		return false, fmt.Errorf("firebird alarm lease store: check schema: %w", err)
+	}
+	return count > 0, nil
+}
+
+type firebirdAlarmLeaseRow struct {
+	AlarmID        int64     `db:"ALARM_ID"`
+	OwnerID        string    `db:"OWNER_ID"`
+	OwnerName      *string   `db:"OWNER_NAME"`
+	Workstation    *string   `db:"WORKSTATION"`
+	AcquiredAt     time.Time `db:"ACQUIRED_AT"`
+	ExpiresAt      time.Time `db:"EXPIRES_AT"`
+	TakeoverReason *string   `db:"TAKEOVER_REASON"`
+	RemainingSec   int64     `db:"REMAINING_SEC"`
+}
+
+// lease переводить час закінчення оренди з годинника сервера на локальний.
+func (r firebirdAlarmLeaseRow) lease() AlarmLease {
+	return AlarmLease{
+		AlarmID: int(r.AlarmID),
+		Owner: AlarmLeaseOwner{
+			ID:          strings.TrimSpace(r.OwnerID),
+			Name:        strings.TrimSpace(ptrToString(r.OwnerName)),
+			Workstation: strings.TrimSpace(ptrToString(r.Workstation)),
+		},
+		AcquiredAt:     r.AcquiredAt,
+		ExpiresAt:      time.Now().Add(time.Duration(r.RemainingSec) * time.Second),
+		TakeoverReason: strings.TrimSpace(ptrToString(r.TakeoverReason)),
+	}
+}
+
+const firebirdAlarmLeaseColumns = `ALARM_ID, OWNER_ID, OWNER_NAME, WORKSTATION, ACQUIRED_AT, EXPIRES_AT, TAKEOVER_REASON,
+	DATEDIFF(SECOND FROM CURRENT_TIMESTAMP TO EXPIRES_AT) AS REMAINING_SEC`
+
+func (s *FirebirdAlarmLeaseStore) Acquire(ctx context.Context, alarmID int, owner AlarmLeaseOwner, ttl time.Duration, takeover bool, reason string) (AlarmLease, error) {
+	if err := s.EnsureSchema(ctx); err != nil {
+		return AlarmLease{}, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return AlarmLease{}, fmt.Errorf("firebird alarm lease acquire: begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var rows []firebirdAlarmLeaseRow
+	query := `SELECT ` + firebirdAlarmLeaseColumns + ` FROM ` + bridgeAlarmLeaseTable + ` WHERE ALARM_ID = ? FOR UPDATE WITH LOCK`
+	if err := tx.SelectContext(ctx, &rows, tx.Rebind(query), alarmID); err != nil {
+		return AlarmLease{}, fmt.Errorf("firebird alarm lease acquire: lock row: %w", err)
+	}
+	takeoverReason := ""
+	if len(rows) > 0 {
+		existing := rows[0]
+		foreign := strings.TrimSpace(existing.OwnerID) != owner.ID && existing.RemainingSec > 0
+		if foreign && !takeover {
+			return existing.lease(), fmt.Errorf("%w: %s", contracts.ErrAlarmOwnershipConflict, existing.lease().Owner.DisplayName())
+		}
+		if foreign {
+			takeoverReason = strings.TrimSpace(reason)
+		} else if existing.RemainingSec > 0 {
+			takeoverReason = strings.TrimSpace(ptrToString(existing.TakeoverReason))
+		}
+	}
+
+	seconds := max(int(ttl/time.Second), 1)
+	upsert := `UPDATE OR INSERT INTO ` + bridgeAlarmLeaseTable + `
+		(ALARM_ID, OWNER_ID, OWNER_NAME, WORKSTATION, ACQUIRED_AT, EXPIRES_AT, TAKEOVER_REASON)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP, DATEADD(? SECOND TO CURRENT_TIMESTAMP), ?)
+		MATCHING (ALARM_ID)`
+	if _, err := tx.ExecContext(ctx, tx.Rebind(upsert), alarmID, owner.ID, owner.Name, owner.Workstation, seconds, takeoverReason); err != nil {
+		if len(rows) == 0 {
+			// Паралельна вставка з іншого робочого місця порушила первинний ключ.
+			return AlarmLease{}, fmt.Errorf("%w: %v", contracts.ErrAlarmOwnershipConflict, err)
+		}
+		return AlarmLease{}, fmt.Errorf("firebird alarm lease acquire: upsert: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return AlarmLease{}, fmt.Errorf("firebird alarm lease acquire: commit: %w", err)
+	}
+	now := time.Now()
+	return AlarmLease{
+		AlarmID:        alarmID,
+		Owner:          owner,
+		AcquiredAt:     now,
+		ExpiresAt:      now.Add(ttl),
+		TakeoverReason: takeoverReason,
+	}, nil
+}
+
+func (s *FirebirdAlarmLeaseStore) Release(ctx context.Context, alarmID int, ownerID string) error {
+	if err := s.EnsureSchema(ctx); err != nil {
+		return err
+	}
+	query := `DELETE FROM ` + bridgeAlarmLeaseTable + ` WHERE ALARM_ID = ?`
+	args := []any{alarmID}
+	if ownerID != "" {
+		query += ` AND OWNER_ID = ?`
+		args = append(args, ownerID)
+	}
+	if _, err := s.db.ExecContext(ctx, s.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("firebird alarm lease release: %w", err)
+	}
+	return nil
+}
+
+func (s *FirebirdAlarmLeaseStore) ListActive(ctx context.Context) (map[int]AlarmLease, error) {
+	if err := s.EnsureSchema(ctx); err != nil {
+		return nil, err
+	}
+	var rows []firebirdAlarmLeaseRow
+	query := `SELECT ` + firebirdAlarmLeaseColumns + ` FROM ` + bridgeAlarmLeaseTable + ` WHERE EXPIRES_AT > CURRENT_TIMESTAMP`
+	if err := s.db.SelectContext(ctx, &rows, s.db.Rebind(query)); err != nil {
+		return nil, fmt.Errorf("firebird alarm lease list: %w", err)
+	}
+	result := make(map[int]AlarmLease, len(rows))
+	for _, row := range rows {
+		result[int(row.AlarmID)] = row.lease()
+	}
+	return result, nil
+}
+
+// WithAlarmLeaseStore задає сховище володіння тривогами МІСТ замість службової таблиці Firebird.
+func WithAlarmLeaseStore(store AlarmLeaseStore) DBProviderOption {
+	return func(p *DBDataProvider) {
+		if p == nil || store == nil {
+			return
+		}
+		p.alarmLeases.mu.Lock()
+		p.alarmLeases.store = store
+		p.alarmLeases.resolved = true
+		p.alarmLeases.mu.Unlock()
+	}
+}
+
+// WithAlarmLeaseTTL задає час, після якого неподовжена оренда тривоги звільняється.
+func WithAlarmLeaseTTL(ttl time.Duration) DBProviderOption {
+	return func(p *DBDataProvider) {
+		if p == nil || ttl <= 0 {
+			return
+		}
+		p.alarmLeases.ttl = ttl
+	}
+}
+
+// alarmLeaseSchema готує спільне сховище оренд перед першим використанням.
+type alarmLeaseSchema interface {
+	EnsureSchema(ctx context.Context) error
+}
+
+type alarmLeaseState struct {
+	mu       sync.Mutex
+	store    AlarmLeaseStore
+	resolved bool
+	ttl      time.Duration
+	owner    AlarmLeaseOwner
+	now      func() time.Time
+
+	// fallback тримає локальне володіння, поки спільне сховище недоступне;
+	// retryAt — коли знову спробувати підготувати спільне сховище.
+	fallback    *MemoryAlarmLeaseStore
+	retryAt     time.Time
+	degradedErr error
+}
+
+func newAlarmLeaseState(db *sqlx.DB) *alarmLeaseState {
+	state := &alarmLeaseState{
+		ttl:   DefaultBridgeAlarmLeaseTTL,
+		owner: LocalAlarmLeaseOwner(""),
+		now:   time.Now,
+	}
+	if db != nil {
+		state.store = NewFirebirdAlarmLeaseStore(db)
+	} else {
+		state.store = NewMemoryAlarmLeaseStore()
+		state.resolved = true
+	}
+	return state
+}
+
+// resolveStore повертає робоче сховище оренд. Якщо службову таблицю підготувати не вдалося
+// (наприклад, бракує прав на DDL), володіння тимчасово ведеться лише в межах цього
+// робочого місця, а спільне сховище перевіряється знову через alarmLeaseSchemaRetryInterval.
+func (s *alarmLeaseState) resolveStore(ctx context.Context) AlarmLeaseStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resolved {
+		return s.store
+	}
+	if s.fallback != nil && s.now().Before(s.retryAt) {
+		return s.fallback
+	}
+	if schema, ok := s.store.(alarmLeaseSchema); ok {
+		if err := schema.EnsureSchema(ctx); err != nil {
+			if ctx.Err() != nil {
+				return s.fallback
+			}
+			if s.fallback == nil {
+				s.fallback = NewMemoryAlarmLeaseStore()
+			}
+			log.Warn().Err(err).Msg("Спільне володіння тривогами МІСТ недоступне, тимчасово використовується локальне")
+			s.retryAt = s.now().Add(alarmLeaseSchemaRetryInterval)
+			s.degradedErr = err
+			return s.fallback
+		}
+	}
+	if s.degradedErr != nil {
+		log.Info().Msg("Спільне володіння тривогами МІСТ відновлено")
+	}
+	s.resolved = true
+	s.fallback = nil
+	s.degradedErr = nil
+	return s.store
+}
+
+// degraded повертає причину, з якої володіння тривогами зараз лише локальне.
+func (s *alarmLeaseState) degraded() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.degradedErr
+}
+
+// FrontendSourceHealth implements contracts.FrontendSourceHealthProvider: попереджає
+// оператора, що тривоги в роботі інших робочих місць зараз не видно.
+// Порожній стан означає, що справність джерела визначає перевірка з'єднання з БД.
+func (p *DBDataProvider) FrontendSourceHealth() contracts.FrontendSourceHealthInfo {
+	if p == nil || p.alarmLeases == nil {
+		return contracts.FrontendSourceHealthInfo{}
+	}
+	if p.alarmLeases.degraded() == nil {
+		return contracts.FrontendSourceHealthInfo{}
+	}
+	return contracts.FrontendSourceHealthInfo{
+		HealthStatus: contracts.FrontendSourceHealthStatusDegraded,
+		HealthText:   "МІСТ: спільне володіння тривогами недоступне, тривоги в роботі інших робочих місць не видно",
+	}
+}
+
+// leaseOwner повертає власника оренди для запиту: клієнта сервера оператора
+// з контексту або, для локального застосунку, поточне робоче місце.
+func (p *DBDataProvider) leaseOwner(ctx context.Context, user string) AlarmLeaseOwner {
+	owner := p.alarmLeases.owner
+	if client, ok := contracts.FrontendClientFromContext(ctx); ok {
+		owner = AlarmLeaseOwner{ID: client.ID, Workstation: client.Workstation}
+	}
+	owner.Name = strings.TrimSpace(user)
+	return owner
+}
+
+// PickAlarm бере тривогу МІСТ у роботу, фіксуючи оренду в спільному сховищі.
+// Повертає contracts.ErrAlarmOwnershipConflict, якщо тривогу вже обробляє інше робоче місце.
+func (p *DBDataProvider) PickAlarm(ctx context.Context, alarm models.Alarm, user string) error {
+	return p.acquireBridgeAlarmLease(ctx, alarm, user, false, "")
+}
+
+// TakeOverAlarm implements contracts.AlarmTakeoverReasonProvider для тривог МІСТ.
+func (p *DBDataProvider) TakeOverAlarm(ctx context.Context, alarm models.Alarm, user string, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("вкажіть причину перехоплення тривоги")
+	}
+	return p.acquireBridgeAlarmLease(ctx, alarm, user, true, reason)
+}
+
+func (p *DBDataProvider) acquireBridgeAlarmLease(ctx context.Context, alarm models.Alarm, user string, takeover bool, reason string) error {
+	qCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	store := p.alarmLeases.resolveStore(qCtx)
+	if store == nil {
+		return fmt.Errorf("bridge alarm lease: %w", qCtx.Err())
+	}
+	lease, err := store.Acquire(qCtx, alarm.ID, p.leaseOwner(ctx, user), p.alarmLeases.ttl, takeover, reason)
+	if err != nil {
+		return err
+	}
+	if lease.TakeoverReason != "" && takeover {
+		log.Info().
+			Int("alarmID", alarm.ID).
+			Str("previousOwner", strings.TrimSpace(alarm.InProgressBy)).
+			Str("owner", lease.Owner.DisplayName()).
+			Str("reason", lease.TakeoverReason).
+			Msg("Тривогу МІСТ перехоплено")
+	}
+	return nil
+}
+
+// overlayBridgeAlarmLeases позначає тривоги, взяті в роботу, та продовжує власні оренди.
+func (p *DBDataProvider) overlayBridgeAlarmLeases(ctx context.Context, alarms []models.Alarm) {
+	if len(alarms) == 0 {
+		return
+	}
+	store := p.alarmLeases.resolveStore(ctx)
+	if store == nil {
+		return
+	}
+	leases, err := store.ListActive(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Не вдалося завантажити володіння тривогами МІСТ")
+		return
+	}
+	ownerID := p.leaseOwner(ctx, "").ID
+	applyBridgeAlarmLeases(alarms, leases, ownerID)
+
+	renewBefore := time.Now().Add(p.alarmLeases.ttl / 2)
+	for _, alarm := range alarms {
+		lease, ok := leases[alarm.ID]
+		if !ok || lease.Owner.ID != ownerID || lease.ExpiresAt.After(renewBefore) {
+			continue
+		}
+		if _, err := store.Acquire(ctx, alarm.ID, lease.Owner, p.alarmLeases.ttl, false, ""); err != nil {
+			log.Warn().Err(err).Int("alarmID", alarm.ID).Msg("Не вдалося продовжити володіння тривогою МІСТ")
+		}
+	}
+}
+
+func (p *DBDataProvider) releaseBridgeAlarmLease(ctx context.Context, alarmID int) {
+	qCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	store := p.alarmLeases.resolveStore(qCtx)
+	if store == nil {
+		return
+	}
+	// Тривогу вже видалено з ACTALARMS, тож оренда не потрібна жодному власнику.
+	if err := store.Release(qCtx, alarmID, ""); err != nil {
+		log.Warn().Err(err).Int("alarmID", alarmID).Msg("Не вдалося звільнити тривогу МІСТ")
+	}
+}
+
+// applyBridgeAlarmLeases переносить активні оренди на тривоги МІСТ.
+func applyBridgeAlarmLeases(alarms []models.Alarm, leases map[int]AlarmLease, ownerID string) {
+	for index := range alarms {
+		lease, ok := leases[alarms[index].ID]
+		if !ok {
+			continue
+		}
+		alarm := &alarms[index]
+		alarm.IsInProgress = true
+		alarm.InProgressBy = lease.Owner.DisplayName()
+		alarm.InProgressUser = lease.Owner.ID
+		alarm.IsOwnedByMe = lease.Owner.ID == ownerID
+		alarm.CanTakeOver = !alarm.IsOwnedByMe
+	}
+}
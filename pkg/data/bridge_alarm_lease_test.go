@@ -0,0 +1,245 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestMemoryAlarmLeaseStoreRejectsForeignOwnerUntilTakeover(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryAlarmLeaseStore()
+	store.now = func() time.Time { return now }
+	first := AlarmLeaseOwner{ID: "pc-1/op", Name: "Оператор 1", Workstation: "pc-1"}
+	second := AlarmLeaseOwner{ID: "pc-2/op", Name: "Оператор 2", Workstation: "pc-2"}
+	ctx := context.Background()
+
+	if _, err := store.Acquire(ctx, 10, first, time.Minute, false, ""); err != nil {
+		t.Fatalf("Acquire(first) error = %v", err)
+	}
+	if _, err := store.Acquire(ctx, 10, second, time.Minute, false, ""); !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("Acquire(second) error = %v, want ownership conflict", err)
+	}
+	lease, err := store.Acquire(ctx, 10, second, time.Minute, true, " оператор 1 не відповідає ")
+	if err != nil {
+		t.Fatalf("takeover error = %v", err)
+	}
+	if lease.Owner.ID != second.ID || lease.TakeoverReason != "оператор 1 не відповідає" {
+		t.Fatalf("takeover lease = %+v", lease)
+	}
+	if _, err := store.Acquire(ctx, 10, first, time.Minute, false, ""); !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("previous owner must lose the alarm, got %v", err)
+	}
+}
+
+func TestMemoryAlarmLeaseStoreExpiresLeases(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryAlarmLeaseStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := store.Acquire(ctx, 10, AlarmLeaseOwner{ID: "pc-1"}, time.Minute, false, ""); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	leases, err := store.ListActive(ctx)
+	if err != nil || len(leases) != 0 {
+		t.Fatalf("ListActive() = %+v, %v; want expired lease dropped", leases, err)
+	}
+	if _, err := store.Acquire(ctx, 10, AlarmLeaseOwner{ID: "pc-2"}, time.Minute, false, ""); err != nil {
+		t.Fatalf("expired lease must be free, got %v", err)
+	}
+	if err := store.Release(ctx, 10, "pc-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if leases, _ := store.ListActive(ctx); len(leases) != 1 {
+		t.Fatal("release by a non-owner must keep the lease")
+	}
+}
+
+func TestApplyBridgeAlarmLeasesMarksOwnership(t *testing.T) {
+	t.Parallel()
+
+	alarms := []models.Alarm{{ID: 1}, {ID: 2}, {ID: 3}}
+	leases := map[int]AlarmLease{
+		1: {AlarmID: 1, Owner: AlarmLeaseOwner{ID: "me", Name: "Я", Workstation: "pc-1"}},
+		2: {AlarmID: 2, Owner: AlarmLeaseOwner{ID: "other", Name: "Оператор 2", Workstation: "pc-2"}},
+	}
+	applyBridgeAlarmLeases(alarms, leases, "me")
+
+	if !alarms[0].IsInProgress || !alarms[0].IsOwnedByMe || alarms[0].CanTakeOver {
+		t.Fatalf("own alarm = %+v", alarms[0])
+	}
+	if !alarms[1].IsInProgress || alarms[1].IsOwnedByMe || !alarms[1].CanTakeOver || alarms[1].InProgressBy != "Оператор 2 (pc-2)" {
+		t.Fatalf("foreign alarm = %+v", alarms[1])
+	}
+	if alarms[2].IsInProgress {
+		t.Fatalf("free alarm = %+v", alarms[2])
+	}
+}
+
+func TestDBDataProviderTakeOverAlarmRequiresReason(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryAlarmLeaseStore()
+	provider := NewDBDataProvider(nil, "", WithAlarmLeaseStore(store))
+	ctx := context.Background()
+	alarm := models.Alarm{ID: 42, ObjectID: 42}
+
+	if _, err := store.Acquire(ctx, alarm.ID, AlarmLeaseOwner{ID: "other-pc"}, time.Minute, false, ""); err != nil {
+		t.Fatalf("seed lease error = %v", err)
+	}
+	if err := provider.PickAlarm(ctx, alarm, "Диспетчер"); !errors.Is(err, contracts.ErrAlarmOwnershipConflict) {
+		t.Fatalf("PickAlarm() error = %v, want ownership conflict", err)
+	}
+	if err := provider.TakeOverAlarm(ctx, alarm, "Диспетчер", "  "); err == nil {
+		t.Fatal("TakeOverAlarm() without reason must fail")
+	}
+	if err := provider.TakeOverAlarm(ctx, alarm, "Диспетчер", "зміна чергування"); err != nil {
+		t.Fatalf("TakeOverAlarm() error = %v", err)
+	}
+	leases, _ := store.ListActive(ctx)
+	if lease := leases[alarm.ID]; lease.Owner.Name != "Диспетчер" || lease.TakeoverReason != "зміна чергування" {
+		t.Fatalf("lease after takeover = %+v", lease)
+	}
+}
+
+// leaseSchemaRaceDriver імітує програш у перегонах за створення таблиці оренд:
+// перша перевірка не бачить таблиці, CREATE падає, повторна перевірка її бачить.
+type leaseSchemaRaceDriver struct {
+	checks atomic.Int32
+}
+
+func (d *leaseSchemaRaceDriver) Open(string) (driver.Conn, error) {
+	return &leaseSchemaRaceConn{driver: d}, nil
+}
+
+type leaseSchemaRaceConn struct {
+	driver *leaseSchemaRaceDriver
+}
+
+func (c *leaseSchemaRaceConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *leaseSchemaRaceConn) Close() error {
+	return nil
+}
+
+func (c *leaseSchemaRaceConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *leaseSchemaRaceConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	count := int64(0)
+	if c.driver.checks.Add(1) > 1 {
+		count = 1
+	}
+	return &singleValueRows{value: count}, nil
+}
+
+func (c *leaseSchemaRaceConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return nil, errors.New("unsuccessful metadata update: lock conflict on no wait transaction")
+}
+
+type singleValueRows struct {
+	value int64
+	done  bool
+}
+
+func (r *singleValueRows) Columns() []string {
+	return []string{"COUNT"}
+}
+
+func (r *singleValueRows) Close() error {
+	return nil
+}
+
+func (r *singleValueRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func TestFirebirdAlarmLeaseStoreEnsureSchemaToleratesConcurrentCreate(t *testing.T) {
+	const driverName = "alarm-lease-schema-race-test"
+	race := &leaseSchemaRaceDriver{}
+	sql.Register(driverName, race)
+	rawDB, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = rawDB.Close()
+	})
+
+	store := NewFirebirdAlarmLeaseStore(sqlx.NewDb(rawDB, driverName))
+	if err := store.EnsureSchema(context.Background()); err != nil {
+		t.Fatalf("EnsureSchema() error = %v, want table created by another workstation accepted", err)
+	}
+	if race.checks.Load() != 2 {
+		t.Fatalf("schema checks = %d, want re-check after failed CREATE", race.checks.Load())
+	}
+}
+
+type flakySchemaLeaseStore struct {
+	*MemoryAlarmLeaseStore
+	err   error
+	calls int
+}
+
+func (s *flakySchemaLeaseStore) EnsureSchema(context.Context) error {
+	s.calls++
+	return s.err
+}
+
+func TestAlarmLeaseStateRetriesSharedStoreAfterSchemaFailure(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	shared := &flakySchemaLeaseStore{MemoryAlarmLeaseStore: NewMemoryAlarmLeaseStore(), err: errors.New("no DDL rights")}
+	provider := NewDBDataProvider(nil, "")
+	provider.alarmLeases = &alarmLeaseState{
+		store: shared,
+		ttl:   time.Minute,
+		now:   func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	if store := provider.alarmLeases.resolveStore(ctx); store == AlarmLeaseStore(shared) || store == nil {
+		t.Fatalf("resolveStore() = %T, want local fallback", store)
+	}
+	if health := provider.FrontendSourceHealth(); health.HealthStatus != contracts.FrontendSourceHealthStatusDegraded || health.HealthText == "" {
+		t.Fatalf("health = %+v, want degraded lease mode reported", health)
+	}
+	provider.alarmLeases.resolveStore(ctx)
+	if shared.calls != 1 {
+		t.Fatalf("EnsureSchema calls = %d, want no retry before the interval", shared.calls)
+	}
+
+	shared.err = nil
+	now = now.Add(alarmLeaseSchemaRetryInterval)
+	if store := provider.alarmLeases.resolveStore(ctx); store != AlarmLeaseStore(shared) {
+		t.Fatalf("resolveStore() = %T, want shared store after retry", store)
+	}
+	if health := provider.FrontendSourceHealth(); health.HealthStatus != "" {
+		t.Fatalf("health = %+v, want no lease warning after recovery", health)
+	}
+}
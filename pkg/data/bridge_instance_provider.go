@@ -0,0 +1,326 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// BridgeInstanceProvider переносить дані додаткової БД МІСТ у власний слот ID
+// (див. ids.BridgeInstanceObjectID), щоб номери об'єктів двох БД не збігались
+// у CombinedDataProvider. Назовні віддаються ID слота, всередину — номери БД.
+//
+// Адміністрування додаткових БД не підтримується: вони лише для моніторингу.
+type BridgeInstanceProvider struct {
+	inner contracts.DataProvider
+	slot  int
+}
+
+// NewBridgeInstanceProvider обгортає провайдер МІСТ для слота екземпляра.
+func NewBridgeInstanceProvider(inner contracts.DataProvider, slot int) *BridgeInstanceProvider {
+	return &BridgeInstanceProvider{inner: inner, slot: slot}
+}
+
+// Owns повертає matcher ID об'єктів і тривог цього екземпляра.
+func (p *BridgeInstanceProvider) Owns() func(id int) bool {
+	return ids.OwnsBridgeInstance(p.slot)
+}
+
+func (p *BridgeInstanceProvider) GetObjects() []models.Object {
+	return p.scopeObjects(p.inner.GetObjects())
+}
+
+func (p *BridgeInstanceProvider) GetObjectsContext(ctx context.Context) []models.Object {
+	if provider, ok := p.inner.(contracts.ContextObjectProvider); ok {
+		return p.scopeObjects(provider.GetObjectsContext(ctx))
+	}
+	return p.GetObjects()
+}
+
+func (p *BridgeInstanceProvider) GetObjectByID(idStr string) *models.Object {
+	localID, ok := p.localID(idStr)
+	if !ok {
+		return nil
+	}
+	obj := p.inner.GetObjectByID(localID)
+	if obj == nil {
+		return nil
+	}
+	scoped := p.scopeObject(*obj)
+	return &scoped
+}
+
+func (p *BridgeInstanceProvider) GetZones(idStr string) []models.Zone {
+	localID, ok := p.localID(idStr)
+	if !ok {
+		return nil
+	}
+	return p.inner.GetZones(localID)
+}
+
+func (p *BridgeInstanceProvider) GetEmployees(idStr string) []models.Contact {
+	localID, ok := p.localID(idStr)
+	if !ok {
+		return nil
+	}
+	return p.inner.GetEmployees(localID)
+}
+
+func (p *BridgeInstanceProvider) GetTestMessages(objectID string) []models.TestMessage {
+	localID, ok := p.localID(objectID)
+	if !ok {
+		return nil
+	}
+	return p.inner.GetTestMessages(localID)
+}
+
+func (p *BridgeInstanceProvider) GetExternalData(objectID string) (signal string, lastTestMsg string, lastTest time.Time, lastMsg time.Time) {
+	localID, ok := p.localID(objectID)
+	if !ok {
+		return "", "", time.Time{}, time.Time{}
+	}
+	return p.inner.GetExternalData(localID)
+}
+
+func (p *BridgeInstanceProvider) GetAllObjectContacts(ctx context.Context) (map[int][]models.Contact, error) {
+	provider, ok := p.inner.(contracts.AllObjectContactsProvider)
+	if !ok {
+		return nil, nil
+	}
+	contacts, err := provider.GetAllObjectContacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := make(map[int][]models.Contact, len(contacts))
+	for id, items := range contacts {
+		scoped[p.scopeID(id)] = items
+	}
+	return scoped, nil
+}
+
+func (p *BridgeInstanceProvider) ListObjectLocations(ctx context.Context) ([]contracts.ObjectLocation, error) {
+	provider, ok := p.inner.(contracts.ObjectLocationProvider)
+	if !ok {
+		return nil, nil
+	}
+	locations, err := provider.ListObjectLocations(ctx)
+	for i := range locations {
+		locations[i].ObjectID = p.scopeID(locations[i].ObjectID)
+	}
+	return locations, err
+}
+
+func (p *BridgeInstanceProvider) LastGPRSTestTime(ctx context.Context, objectID int) (time.Time, error) {
+	provider, ok := p.inner.(lastGPRSTestTimeProvider)
+	if !ok || !p.Owns()(objectID) {
+		return time.Time{}, nil
+	}
+	return provider.LastGPRSTestTime(ctx, ids.BridgeInstanceLocalID(objectID))
+}
+
+func (p *BridgeInstanceProvider) GetEvents() []models.Event {
+	return p.scopeEvents(p.inner.GetEvents())
+}
+
+func (p *BridgeInstanceProvider) GetEventsContext(ctx context.Context) []models.Event {
+	if provider, ok := p.inner.(contracts.ContextEventProvider); ok {
+		return p.scopeEvents(provider.GetEventsContext(ctx))
+	}
+	return p.GetEvents()
+}
+
+func (p *BridgeInstanceProvider) GetObjectEvents(objectID string) []models.Event {
+	localID, ok := p.localID(objectID)
+	if !ok {
+		return nil
+	}
+	return p.scopeEvents(p.inner.GetObjectEvents(localID))
+}
+
+func (p *BridgeInstanceProvider) GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event {
+	localID, ok := p.localID(objectID)
+	if !ok {
+		return nil
+	}
+	if provider, ok := p.inner.(contracts.ObjectEventsRangeProvider); ok {
+		return p.scopeEvents(provider.GetObjectEventsRange(localID, from, to))
+	}
+	return p.GetObjectEvents(objectID)
+}
+
+// QueryObjectEventsRange implements contracts.ObjectEventsRangeQueryProvider.
+func (p *BridgeInstanceProvider) QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
+	provider, ok := p.inner.(contracts.ObjectEventsRangeQueryProvider)
+	if !ok {
+		return p.GetObjectEventsRange(objectID, from, to), nil
+	}
+	localID, ok := p.localID(objectID)
+	if !ok {
+		return nil, nil
+	}
+	events, err := provider.QueryObjectEventsRange(ctx, localID, from, to)
+	return p.scopeEvents(events), err
+}
+
+func (p *BridgeInstanceProvider) GetLatestEventID() (int64, error) {
+	provider, ok := p.inner.(latestEventIDProvider)
+	if !ok {
+		return 0, errors.New("latest event cursor is not supported")
+	}
+	return provider.GetLatestEventID()
+}
+
+func (p *BridgeInstanceProvider) FrontendSourceHealth() contracts.FrontendSourceHealthInfo {
+	if provider, ok := p.inner.(contracts.FrontendSourceHealthProvider); ok {
+		return provider.FrontendSourceHealth()
+	}
+	return contracts.FrontendSourceHealthInfo{}
+}
+
+func (p *BridgeInstanceProvider) TriggerReconnect(reason string) {
+	if provider, ok := p.inner.(timeoutRecoverableProvider); ok {
+		provider.TriggerReconnect(reason)
+	}
+}
+
+func (p *BridgeInstanceProvider) GetAlarms() []models.Alarm {
+	alarms := p.inner.GetAlarms()
+	for i := range alarms {
+		alarms[i] = p.scopeAlarm(alarms[i])
+	}
+	return alarms
+}
+
+func (p *BridgeInstanceProvider) GetAlarmsContext(ctx context.Context) []models.Alarm {
+	provider, ok := p.inner.(contracts.ContextAlarmProvider)
+	if !ok {
+		return p.GetAlarms()
+	}
+	alarms := provider.GetAlarmsContext(ctx)
+	for i := range alarms {
+		alarms[i] = p.scopeAlarm(alarms[i])
+	}
+	return alarms
+}
+
+func (p *BridgeInstanceProvider) ProcessAlarm(id string, user string, note string) error {
+	localID, ok := p.localID(id)
+	if !ok {
+		return fmt.Errorf("alarm %s does not belong to this MIST instance", id)
+	}
+	return p.inner.ProcessAlarm(localID, user, note)
+}
+
+func (p *BridgeInstanceProvider) GetAlarmProcessingOptions(ctx context.Context, alarm models.Alarm) ([]contracts.AlarmProcessingOption, error) {
+	if provider, ok := p.inner.(alarmProcessingProvider); ok {
+		return provider.GetAlarmProcessingOptions(ctx, p.localAlarm(alarm))
+	}
+	return nil, nil
+}
+
+func (p *BridgeInstanceProvider) ProcessAlarmWithRequest(ctx context.Context, alarm models.Alarm, user string, request contracts.AlarmProcessingRequest) error {
+	if provider, ok := p.inner.(alarmProcessingProvider); ok {
+		return provider.ProcessAlarmWithRequest(ctx, p.localAlarm(alarm), user, request)
+	}
+	return p.ProcessAlarm(strconv.Itoa(alarm.ID), user, request.Note)
+}
+
+func (p *BridgeInstanceProvider) PickAlarm(ctx context.Context, alarm models.Alarm, user string) error {
+	if provider, ok := p.inner.(contracts.AlarmTakeoverProvider); ok {
+		return provider.PickAlarm(ctx, p.localAlarm(alarm), user)
+	}
+	return errors.New("alarm takeover provider is not configured")
+}
+
+func (p *BridgeInstanceProvider) TakeOverAlarm(ctx context.Context, alarm models.Alarm, user string, reason string) error {
+	if provider, ok := p.inner.(contracts.AlarmTakeoverReasonProvider); ok {
+		return provider.TakeOverAlarm(ctx, p.localAlarm(alarm), user, reason)
+	}
+	return p.PickAlarm(ctx, alarm, user)
+}
+
+func (p *BridgeInstanceProvider) GroupProcessAlarm(ctx context.Context, alarm models.Alarm, user string) error {
+	if provider, ok := p.inner.(contracts.AlarmGroupProcessProvider); ok {
+		return provider.GroupProcessAlarm(ctx, p.localAlarm(alarm), user)
+	}
+	return errors.New("group alarm processing is not supported")
+}
+
+func (p *BridgeInstanceProvider) GetAlarmSourceMessages(alarm models.Alarm) []models.AlarmMsg {
+	if provider, ok := p.inner.(contracts.AlarmHistoryProvider); ok {
+		return provider.GetAlarmSourceMessages(p.localAlarm(alarm))
+	}
+	return nil
+}
+
+func (p *BridgeInstanceProvider) GetActiveAlarmSourceMessages(alarm models.Alarm) []models.AlarmMsg {
+	if provider, ok := p.inner.(contracts.ActiveAlarmHistoryProvider); ok {
+		return provider.GetActiveAlarmSourceMessages(p.localAlarm(alarm))
+	}
+	return nil
+}
+
+func (p *BridgeInstanceProvider) scopeID(id int) int {
+	if id <= 0 {
+		return id
+	}
+	return ids.BridgeInstanceObjectID(p.slot, id)
+}
+
+// localID повертає номер у БД екземпляра; false — ID належить іншому слоту,
+// і звертатися до БД з ним не можна: там це номер зовсім іншого об'єкта.
+func (p *BridgeInstanceProvider) localID(idStr string) (string, bool) {
+	id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	if err != nil || !p.Owns()(id) {
+		return "", false
+	}
+	return strconv.Itoa(ids.BridgeInstanceLocalID(id)), true
+}
+
+func (p *BridgeInstanceProvider) scopeObjects(objects []models.Object) []models.Object {
+	for i := range objects {
+		objects[i] = p.scopeObject(objects[i])
+	}
+	return objects
+}
+
+func (p *BridgeInstanceProvider) scopeObject(obj models.Object) models.Object {
+	if strings.TrimSpace(obj.DisplayNumber) == "" {
+		obj.DisplayNumber = strconv.Itoa(obj.ID)
+	}
+	obj.ID = p.scopeID(obj.ID)
+	return obj
+}
+
+func (p *BridgeInstanceProvider) scopeEvents(events []models.Event) []models.Event {
+	for i := range events {
+		if strings.TrimSpace(events[i].ObjectNumber) == "" && events[i].ObjectID > 0 {
+			events[i].ObjectNumber = strconv.Itoa(events[i].ObjectID)
+		}
+		events[i].ID = p.scopeID(events[i].ID)
+		events[i].ObjectID = p.scopeID(events[i].ObjectID)
+	}
+	return events
+}
+
+func (p *BridgeInstanceProvider) scopeAlarm(alarm models.Alarm) models.Alarm {
+	if strings.TrimSpace(alarm.ObjectNumber) == "" && alarm.ObjectID > 0 {
+		alarm.ObjectNumber = strconv.Itoa(alarm.ObjectID)
+	}
+	alarm.ID = p.scopeID(alarm.ID)
+	alarm.ObjectID = p.scopeID(alarm.ObjectID)
+	return alarm
+}
+
+func (p *BridgeInstanceProvider) localAlarm(alarm models.Alarm) models.Alarm {
+	alarm.ID = ids.BridgeInstanceLocalID(alarm.ID)
+	alarm.ObjectID = ids.BridgeInstanceLocalID(alarm.ObjectID)
+	return alarm
+}
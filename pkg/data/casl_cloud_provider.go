@@ -14,6 +14,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
 
 	"github.com/rs/zerolog/log"
@@ -58,6 +59,9 @@ type CASLCloudProvider struct {
 	pultID  int64
 	email   string
 	pass    string
+	// pultSlot — слот ID пульта плюс один; 0 означає, що провайдер займає
+	// весь простір ID CASL (робоче місце з одним пультом).
+	pultSlot int
 
 	httpClient *http.Client
 
@@ -179,6 +183,25 @@ func NewCASLCloudProvider(baseURL string, token string, pultID int64, credential
 	return p
 }
 
+// SetPultSlot закріплює за провайдером слот ID пульта (див. ids.CASLPultObjectID).
+// Викликається до першого запиту, коли робоче місце обслуговує кілька пультів.
+func (p *CASLCloudProvider) SetPultSlot(slot int) {
+	if p == nil || slot < 0 || slot >= ids.CASLPultSlotCount {
+		return
+	}
+	p.pultSlot = slot + 1
+}
+
+// PultID повертає пульт сесії; 0, поки автовибір пульта ще не відбувся.
+func (p *CASLCloudProvider) PultID() int64 {
+	if p == nil {
+		return 0
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.pultID
+}
+
 func withCASLRequestTimeout(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		parent = context.Background()
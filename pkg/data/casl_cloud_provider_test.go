@@ -212,7 +212,7 @@ func TestCASLProvider_LoadObjects_UsesCachedSnapshotUntilInvalidated(t *testing.
 	provider.mu.Lock()
 	provider.cachedObjects = []caslGrdObject{{ObjID: "24", Name: "Cached Object"}}
 	provider.cachedObjectsAt = time.Now().Add(-24 * time.Hour)
-	provider.objectByInternalID = buildCASLObjectIndex(provider.cachedObjects)
+	provider.objectByInternalID = provider.buildCASLObjectIndex(provider.cachedObjects)
 	provider.mu.Unlock()
 
 	rows, err := provider.loadObjects(context.Background())
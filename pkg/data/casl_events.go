@@ -672,9 +672,9 @@ func (p *CASLCloudProvider) mapCASLRowsToEvents(ctx context.Context, rows []CASL
 		}
 		objectID := 0
 		if ppkNum > 0 {
-			objectID = mapCASLObjectID(strconv.FormatInt(ppkNum, 10))
+			objectID = p.caslObjectID(strconv.FormatInt(ppkNum, 10))
 		} else if rawObjID != "" {
-			objectID = mapCASLObjectID(rawObjID)
+			objectID = p.caslObjectID(rawObjID)
 		}
 		objectName := strings.TrimSpace(row.ObjName)
 		if objectName == "" {
@@ -749,7 +749,7 @@ func (p *CASLCloudProvider) mapCASLRowsToEvents(ctx context.Context, rows []CASL
 		}
 
 		events = append(events, models.Event{
-			ID:           stableCASLEventID(firstCASLValue(strconv.FormatInt(ppkNum, 10), rawObjID, sourceType), eventTS, seed, 0),
+			ID:           p.caslEventID(firstCASLValue(strconv.FormatInt(ppkNum, 10), rawObjID, sourceType), eventTS, seed, 0),
 			Time:         eventTime,
 			ObjectID:     objectID,
 			ObjectNumber: objectNum,
@@ -842,7 +842,7 @@ func (p *CASLCloudProvider) loadEventContextsByObjectNum(ctx context.Context, ob
 		}
 
 		ctxItem := caslEventContext{
-			ObjectID:  mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(ppkNum, 10)),
+			ObjectID:  p.caslObjectID(record.ObjID, record.Name, strconv.FormatInt(ppkNum, 10)),
 			ObjectNum: preferredCASLObjectNumber(record.ObjID, record.Name, ppkNum),
 		}
 		ctxItem.ObjectName = strings.TrimSpace(record.Name)
@@ -921,7 +921,7 @@ func (p *CASLCloudProvider) loadEventContextsByPPK(ctx context.Context, ppkFilte
 
 		// Якщо є об'єкт з цим DeviceNumber, заповнюємо ObjectID та ObjectNum з нього
 		if objRecord, hasObj := objectByPPK[ppkNum]; hasObj {
-			ctxItem.ObjectID = mapCASLObjectID(objRecord.ObjID, objRecord.Name, strconv.FormatInt(ppkNum, 10))
+			ctxItem.ObjectID = p.caslObjectID(objRecord.ObjID, objRecord.Name, strconv.FormatInt(ppkNum, 10))
 			ctxItem.ObjectNum = preferredCASLObjectNumber(objRecord.ObjID, objRecord.Name, ppkNum)
 			ctxItem.ObjectName = strings.TrimSpace(objRecord.Name)
 			ctxItem.GroupNames = caslEventGroupNames(objRecord.Rooms)
@@ -1462,7 +1462,7 @@ func (p *CASLCloudProvider) readGeneralTapeAsAlarms(ctx context.Context, byObjec
 			ppkNum = p.resolveCASLPPKByDeviceIDWithCache(ctx, row.DeviceID, resolvedByDeviceID, unresolvedByDeviceID)
 		}
 
-		objectID := mapCASLObjectID(row.ObjID, strconv.FormatInt(ppkNum, 10), row.DeviceID)
+		objectID := p.caslObjectID(row.ObjID, strconv.FormatInt(ppkNum, 10), row.DeviceID)
 		objectName := strings.TrimSpace(row.ObjName)
 		if objectName == "" {
 			objectName = "Об'єкт #" + strings.TrimSpace(row.ObjID)
@@ -1486,7 +1486,7 @@ func (p *CASLCloudProvider) readGeneralTapeAsAlarms(ctx context.Context, byObjec
 		seed := stableCASLAlarmSeed(firstCASLValue(row.Code, row.Action), row.ContactID, int(row.Number))
 		objectKey := canonicalCASLRealtimeObjectKey(row.ObjID, objectNum, objectID)
 		item := caslTapeItem{
-			ID:              stableCASLAlarmID(objectKey, row.Time, seed+"|"+strconv.Itoa(idx)),
+			ID:              p.caslAlarmID(objectKey, row.Time, seed+"|"+strconv.Itoa(idx)),
 			Time:            row.Time,
 			ObjectID:        objectID,
 			ObjectNum:       objectNum,
@@ -1623,10 +1623,19 @@ func (p *CASLCloudProvider) replaceRealtimeAlarmsSnapshot(alarms []models.Alarm)
 
 func (p *CASLCloudProvider) GetObjectEvents(objectID string) []models.Event {
 	now := time.Now()
-	return p.getObjectEventsRange(objectID, now.Add(-caslObjectEventsSpan), now, true)
+	events, _ := p.getObjectEventsRange(context.Background(), objectID, now.Add(-caslObjectEventsSpan), now, true)
+	return events
 }
 
 func (p *CASLCloudProvider) GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event {
+	events, _ := p.QueryObjectEventsRange(context.Background(), objectID, from, to)
+	return events
+}
+
+// QueryObjectEventsRange implements contracts.ObjectEventsRangeQueryProvider.
+// Помилка читання стрічки об'єкта повертається разом з подіями історії
+// кейсів, які вдалося отримати.
+func (p *CASLCloudProvider) QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
 	now := time.Now()
 	if to.IsZero() || to.After(now) {
 		to = now
@@ -1634,13 +1643,13 @@ func (p *CASLCloudProvider) GetObjectEventsRange(objectID string, from time.Time
 	if from.IsZero() || !from.Before(to) {
 		from = to.Add(-caslObjectEventsSpan)
 	}
-	return p.getObjectEventsRange(objectID, from, to, false)
+	return p.getObjectEventsRange(ctx, objectID, from, to, false)
 }
 
-func (p *CASLCloudProvider) getObjectEventsRange(objectID string, from time.Time, to time.Time, useDefaultCache bool) []models.Event {
+func (p *CASLCloudProvider) getObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time, useDefaultCache bool) ([]models.Event, error) {
 	internalID, ok := parseObjectID(objectID)
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("CASL: невірний ID об'єкта %q", objectID)
 	}
 
 	now := time.Now()
@@ -1651,22 +1660,26 @@ func (p *CASLCloudProvider) getObjectEventsRange(objectID string, from time.Time
 		if cached && now.Sub(ts) <= caslObjectEventsTTL {
 			events := append([]models.Event(nil), p.cachedObjectEvents[internalID]...)
 			p.mu.RUnlock()
-			return events
+			return events, nil
 		}
 		p.mu.RUnlock()
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), caslHTTPTimeout)
+	ctx, cancel := context.WithTimeout(ctx, caslHTTPTimeout)
 	defer cancel()
 
 	record, found, err := p.resolveObjectRecord(ctx, internalID)
-	if err != nil || !found {
-		return nil
+	if err != nil {
+		return nil, fmt.Errorf("CASL: об'єкт %d: %w", internalID, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("CASL: об'єкт %d не знайдено", internalID)
 	}
 
-	rawEvents, err := p.readEventsByIDRange(ctx, record, from, to)
-	if err != nil {
-		log.Debug().Err(err).Int("objectID", internalID).Msg("CASL: не вдалося отримати події об'єкта")
+	rawEvents, readErr := p.readEventsByIDRange(ctx, record, from, to)
+	if readErr != nil {
+		log.Debug().Err(readErr).Int("objectID", internalID).Msg("CASL: не вдалося отримати події об'єкта")
+		readErr = fmt.Errorf("CASL: події об'єкта %d: %w", internalID, readErr)
 	}
 
 	events := p.mapCASLObjectEvents(ctx, record, rawEvents)
@@ -1686,7 +1699,7 @@ func (p *CASLCloudProvider) getObjectEventsRange(objectID string, from time.Time
 		p.mu.Unlock()
 	}
 
-	return events
+	return events, readErr
 }
 
 func (p *CASLCloudProvider) GetAlarmSourceMessages(alarm models.Alarm) []models.AlarmMsg {
@@ -2529,7 +2542,7 @@ func (p *CASLCloudProvider) mapCASLObjectEvents(ctx context.Context, record casl
 	}
 
 	result := make([]models.Event, 0, len(raw))
-	objectID := mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
+	objectID := p.caslObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
 	objectNum := preferredCASLObjectNumber(record.ObjID, record.Name, record.DeviceNumber.Int64())
 	objectName := strings.TrimSpace(record.Name)
 	if objectName == "" {
@@ -2618,7 +2631,7 @@ func (p *CASLCloudProvider) mapCASLObjectEvents(ctx context.Context, record casl
 		}
 
 		result = append(result, models.Event{
-			ID:           stableCASLEventID(record.ObjID, ts, code, idx),
+			ID:           p.caslEventID(record.ObjID, ts, code, idx),
 			Time:         eventTime,
 			ObjectID:     objectID,
 			ObjectNumber: objectNum,
@@ -843,6 +843,30 @@ func (p *CASLCloudProvider) resolveCASLEditorObjectID(ctx context.Context, objec
 	return strings.TrimSpace(record.ObjID), nil
 }
 
+// OwnsCASLObject повідомляє, чи бачить сесія пульта об'єкт із сирим obj_id.
+func (p *CASLCloudProvider) OwnsCASLObject(ctx context.Context, objID string) bool {
+	_, found, err := p.findCASLObjectRecordByRawID(ctx, objID)
+	return err == nil && found
+}
+
+// OwnsCASLDevice повідомляє, чи бачить сесія пульта прилад із device_id.
+func (p *CASLCloudProvider) OwnsCASLDevice(ctx context.Context, deviceID string) bool {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" {
+		return false
+	}
+	devices, err := p.loadDevices(ctx)
+	if err != nil {
+		return false
+	}
+	for _, device := range devices {
+		if strings.TrimSpace(device.DeviceID.String()) == deviceID {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *CASLCloudProvider) findCASLObjectRecordByRawID(ctx context.Context, objID string) (caslGrdObject, bool, error) {
 	objID = strings.TrimSpace(objID)
 	if objID == "" {
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/geocode"
 	"obj_catalog_fyne_v3/pkg/models"
 
 	"github.com/rs/zerolog/log"
@@ -36,7 +38,7 @@ func (p *CASLCloudProvider) GetObjectsContext(parent context.Context) []models.O
 		}
 		objects := make([]models.Object, 0, len(pults))
 		for _, item := range pults {
-			objects = append(objects, mapCASLPultToObject(item))
+			objects = append(objects, p.mapCASLPult(item))
 		}
 		return objects
 	}
@@ -66,7 +68,7 @@ func (p *CASLCloudProvider) GetObjectsContext(parent context.Context) []models.O
 	objects := make([]models.Object, 0, len(records))
 	for _, record := range records {
 		device, hasDevice := p.resolveDeviceForObject(record)
-		obj := mapCASLGrdObjectToObject(record, selectCASLDevice(hasDevice, device))
+		obj := p.mapCASLObject(record, selectCASLDevice(hasDevice, device))
 		obj.Groups = mergeCASLGroupsWithStatistics(nil, caslGroupStatisticsForRecord(groupStats, record))
 		applyCASLObjectGroupGuardState(&obj, obj.Groups)
 		applyCASLResponseGroups(&obj, record.GeoZoneID.Int64(), geoZoneGroups)
@@ -144,9 +146,9 @@ func (p *CASLCloudProvider) GetObjectByID(idStr string) *models.Object {
 	devicesCancel()
 
 	device, hasDevice := p.resolveDeviceForObject(record)
-	obj := mapCASLGrdObjectToObject(record, selectCASLDevice(hasDevice, device))
+	obj := p.mapCASLObject(record, selectCASLDevice(hasDevice, device))
 	enrichCtx, enrichCancel := withCASLRequestTimeout(context.Background())
-	if record.GeoZoneID.Int64() > 0 {
+	if caslRecordsNeedResponseGroups([]caslGrdObject{record}) {
 		applyCASLResponseGroups(&obj, record.GeoZoneID.Int64(), p.loadCASLGeoZoneResponseGroups(enrichCtx))
 	}
 	p.enrichCASLObjectWithDeviceMeta(enrichCtx, &obj, hasDevice, device)
@@ -756,7 +758,7 @@ func (p *CASLCloudProvider) resolveObjectRecord(ctx context.Context, internalID
 		return caslGrdObject{}, false, err
 	}
 	for _, item := range records {
-		id := mapCASLObjectID(item.ObjID, item.Name, strconv.FormatInt(item.DeviceNumber.Int64(), 10))
+		id := p.caslObjectID(item.ObjID, item.Name, strconv.FormatInt(item.DeviceNumber.Int64(), 10))
 		if id == internalID {
 			return item, true, nil
 		}
@@ -839,7 +841,7 @@ func (p *CASLCloudProvider) applyCASLCoreSnapshot(objects []caslGrdObject, devic
 		copiedObjects := append([]caslGrdObject(nil), objects...)
 		p.cachedObjects = copiedObjects
 		p.cachedObjectsAt = now
-		p.objectByInternalID = buildCASLObjectIndex(copiedObjects)
+		p.objectByInternalID = p.buildCASLObjectIndex(copiedObjects)
 	}
 
 	if len(devices) > 0 {
@@ -858,10 +860,10 @@ func (p *CASLCloudProvider) applyCASLCoreSnapshot(objects []caslGrdObject, devic
 	}
 }
 
-func buildCASLObjectIndex(records []caslGrdObject) map[int]caslGrdObject {
+func (p *CASLCloudProvider) buildCASLObjectIndex(records []caslGrdObject) map[int]caslGrdObject {
 	index := make(map[int]caslGrdObject, len(records))
 	for _, record := range records {
-		internalID := mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
+		internalID := p.caslObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
 		index[internalID] = record
 	}
 	return index
@@ -950,6 +952,13 @@ func (p *CASLCloudProvider) readConnections(ctx context.Context) ([]caslConnecti
 	return nil, fmt.Errorf("casl read_connections: unsupported payload format")
 }
 
+// mapCASLObject мапить запис об'єкта з ID у слоті пульта провайдера.
+func (p *CASLCloudProvider) mapCASLObject(record caslGrdObject, device *caslDevice) models.Object {
+	obj := mapCASLGrdObjectToObject(record, device)
+	obj.ID = p.scopeCASLID(obj.ID)
+	return obj
+}
+
 func mapCASLGrdObjectToObject(record caslGrdObject, device *caslDevice) models.Object {
 	id := mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
 
@@ -1082,8 +1091,9 @@ func mapCASLGrdObjectToObject(record caslGrdObject, device *caslDevice) models.O
 }
 
 type caslGeoZoneResponseGroups struct {
-	IDs   []string
-	Names []string
+	IDs     []string
+	Names   []string
+	Polygon []geocode.MapPoint
 }
 
 func (p *CASLCloudProvider) loadCASLGeoZoneResponseGroups(ctx context.Context) map[string]caslGeoZoneResponseGroups {
@@ -1113,11 +1123,16 @@ func (p *CASLCloudProvider) loadCASLGeoZoneResponseGroups(ctx context.Context) m
 	return groups
 }
 
+// caslRecordsNeedResponseGroups повідомляє, чи є об'єкти з геозоною або
+// координатами, за якими геозону можна знайти по контуру.
 func caslRecordsNeedResponseGroups(records []caslGrdObject) bool {
 	for _, record := range records {
 		if record.GeoZoneID.Int64() > 0 {
 			return true
 		}
+		if _, ok := geocode.ParsePoint(record.Lat, record.Long); ok {
+			return true
+		}
 	}
 	return false
 }
@@ -1149,11 +1164,58 @@ func buildCASLGeoZoneResponseGroups(
 				names = append(names, id)
 			}
 		}
-		result[geoZoneID] = caslGeoZoneResponseGroups{IDs: ids, Names: names}
+		result[geoZoneID] = caslGeoZoneResponseGroups{IDs: ids, Names: names, Polygon: parseCASLGeoZonePolygon(geoZone)}
 	}
 	return result
 }
 
+// parseCASLGeoZonePolygon читає контур геозони: список пар [lat, lng] або
+// об'єктів {lat, lng}, як масив чи JSON-рядок.
+func parseCASLGeoZonePolygon(geoZone map[string]any) []geocode.MapPoint {
+	var raw any
+	for _, key := range []string{"polygon", "coordinates", "points"} {
+		if value, ok := geoZone[key]; ok && value != nil {
+			raw = value
+			break
+		}
+	}
+	if text, ok := raw.(string); ok {
+		if err := json.Unmarshal([]byte(text), &raw); err != nil {
+			return nil
+		}
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	polygon := make([]geocode.MapPoint, 0, len(items))
+	for _, item := range items {
+		var lat, lng any
+		switch typed := item.(type) {
+		case []any:
+			if len(typed) < 2 {
+				return nil
+			}
+			lat, lng = typed[0], typed[1]
+		case map[string]any:
+			lat = typed["lat"]
+			lng = typed["lng"]
+			if lng == nil {
+				lng = typed["long"]
+			}
+		}
+		point, ok := geocode.ParsePoint(asString(lat), asString(lng))
+		if !ok {
+			return nil
+		}
+		polygon = append(polygon, point)
+	}
+	if len(polygon) < 3 {
+		return nil
+	}
+	return polygon
+}
+
 func caslValueIDs(value any) []string {
 	values, ok := value.([]any)
 	if !ok {
@@ -1180,18 +1242,56 @@ func applyCASLResponseGroups(
 		return
 	}
 	group := groups[strconv.FormatInt(geoZoneID, 10)]
+	if len(group.IDs) == 0 {
+		group = caslResponseGroupsByPolygon(object, groups)
+	}
 	object.PreferredResponseGroupID = strings.Join(group.IDs, ", ")
 	object.PreferredResponseGroupName = strings.Join(group.Names, ", ")
 }
 
+// caslResponseGroupsByPolygon збирає ГМР геозон, у контур яких потрапляють
+// координати об'єкта; так радник бачить «свої» групи і без призначеної геозони.
+func caslResponseGroupsByPolygon(object *models.Object, groups map[string]caslGeoZoneResponseGroups) caslGeoZoneResponseGroups {
+	point, ok := geocode.ParsePoint(object.Latitude, object.Longitude)
+	if !ok {
+		return caslGeoZoneResponseGroups{}
+	}
+	zoneIDs := make([]string, 0, len(groups))
+	for zoneID := range groups {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Strings(zoneIDs)
+
+	result := caslGeoZoneResponseGroups{}
+	seen := make(map[string]struct{})
+	for _, zoneID := range zoneIDs {
+		zone := groups[zoneID]
+		if !geocode.PointInPolygon(point, zone.Polygon) {
+			continue
+		}
+		for index, id := range zone.IDs {
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			result.IDs = append(result.IDs, id)
+			if index < len(zone.Names) {
+				result.Names = append(result.Names, zone.Names[index])
+			}
+		}
+	}
+	return result
+}
+
 func cloneCASLGeoZoneResponseGroups(
 	source map[string]caslGeoZoneResponseGroups,
 ) map[string]caslGeoZoneResponseGroups {
 	result := make(map[string]caslGeoZoneResponseGroups, len(source))
 	for id, group := range source {
 		result[id] = caslGeoZoneResponseGroups{
-			IDs:   append([]string(nil), group.IDs...),
-			Names: append([]string(nil), group.Names...),
+			IDs:     append([]string(nil), group.IDs...),
+			Names:   append([]string(nil), group.Names...),
+			Polygon: append([]geocode.MapPoint(nil), group.Polygon...),
 		}
 	}
 	return result
@@ -1204,6 +1304,12 @@ func caslTimeoutMinutes(timeoutSeconds int64) int64 {
 	return (timeoutSeconds + 59) / 60
 }
 
+func (p *CASLCloudProvider) mapCASLPult(item caslPult) models.Object {
+	obj := mapCASLPultToObject(item)
+	obj.ID = p.scopeCASLID(obj.ID)
+	return obj
+}
+
 func mapCASLPultToObject(item caslPult) models.Object {
 	name := strings.TrimSpace(item.Name)
 	if name == "" {
@@ -0,0 +1,97 @@
+package data
+
+import (
+	"strconv"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/ids"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CASLProviderSources будує джерела CASL Cloud для CombinedDataProvider.
+//
+// Один пульт — одне джерело "casl" на весь простір CASL ID (як і раніше).
+// Кілька пультів — окремий провайдер на кожен пульт зі своєю сесією,
+// realtime-підпискою та слотом ID, тож команди й обробка тривог самі
+// маршрутизуються на пульт, якому належить об'єкт. Додаткові сервери CASL
+// з cfg.SourceInstances займають наступні слоти.
+func CASLProviderSources(cfg config.DBConfig) []ProviderSource {
+	type caslEntry struct {
+		name     string
+		cfg      config.DBConfig
+		pultID   int64
+		token    string
+		pult     string
+		instance string
+	}
+
+	pults := cfg.CASLPultIDs()
+	instances := cfg.SourceInstancesOfType(config.SourceInstanceCASL)
+	primaryInstance := ""
+	if len(instances) > 0 {
+		primaryInstance = PrimarySourceInstance
+	}
+	entries := make([]caslEntry, 0, len(pults)+len(instances))
+	for i, pultID := range pults {
+		entry := caslEntry{name: "casl", cfg: cfg, pultID: pultID, instance: primaryInstance}
+		if len(pults) > 1 {
+			entry.pult = caslPultLabel(pultID)
+		}
+		if i == 0 {
+			// Збережений токен видано саме основному пульту.
+			entry.token = cfg.CASLToken
+		} else {
+			entry.name = "casl:" + strconv.FormatInt(pultID, 10)
+		}
+		entries = append(entries, entry)
+	}
+	for _, instance := range instances {
+		instanceCfg := cfg.ForSourceInstance(instance)
+		entries = append(entries, caslEntry{
+			name:     "casl:" + instance.Name,
+			cfg:      instanceCfg,
+			pultID:   instanceCfg.CASLPultID,
+			token:    instanceCfg.CASLToken,
+			instance: instance.Name,
+		})
+	}
+	if len(entries) > ids.CASLPultSlotCount {
+		log.Warn().
+			Int("configured", len(entries)).
+			Int("limit", ids.CASLPultSlotCount).
+			Msg("CASL: забагато пультів, зайві проігноровано")
+		entries = entries[:ids.CASLPultSlotCount]
+	}
+	if len(entries) == 1 {
+		return []ProviderSource{{
+			Name:         "casl",
+			Provider:     NewCASLCloudProvider(cfg.CASLBaseURL, cfg.CASLToken, cfg.CASLPultID, cfg.CASLEmail, cfg.CASLPass),
+			OwnsObjectID: ids.IsCASLObjectID,
+			OwnsAlarmID:  ids.IsCASLObjectID,
+		}}
+	}
+
+	sources := make([]ProviderSource, 0, len(entries))
+	for slot, entry := range entries {
+		provider := NewCASLCloudProvider(entry.cfg.CASLBaseURL, entry.token, entry.pultID, entry.cfg.CASLEmail, entry.cfg.CASLPass)
+		provider.SetPultSlot(slot)
+		owns := ids.OwnsCASLPultSlot(slot)
+		sources = append(sources, ProviderSource{
+			Name:         entry.name,
+			Pult:         entry.pult,
+			Instance:     entry.instance,
+			Provider:     provider,
+			OwnsObjectID: owns,
+			OwnsAlarmID:  owns,
+		})
+	}
+	return sources
+}
+
+func caslPultLabel(pultID int64) string {
+	if pultID <= 0 {
+		return "Основний пульт"
+	}
+	return "Пульт " + strconv.FormatInt(pultID, 10)
+}
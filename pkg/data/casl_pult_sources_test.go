@@ -0,0 +1,238 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestCASLProviderSourcesSinglePultKeepsWholeNamespace(t *testing.T) {
+	sources := CASLProviderSources(config.DBConfig{CASLPultID: 7})
+	t.Cleanup(func() { shutdownCASLSources(sources) })
+
+	if len(sources) != 1 || sources[0].Name != "casl" || sources[0].Pult != "" {
+		t.Fatalf("CASLProviderSources() = %+v", sources)
+	}
+	if !sources[0].OwnsObjectID(ids.CASLObjectIDNamespaceEnd) {
+		t.Fatal("single pult must own the whole CASL namespace")
+	}
+}
+
+func TestCASLProviderSourcesSplitsPultsIntoSlots(t *testing.T) {
+	sources := CASLProviderSources(config.DBConfig{
+		CASLToken:        "primary-token",
+		CASLPultID:       7,
+		CASLExtraPultIDs: []int64{9, 7, 11},
+	})
+	t.Cleanup(func() { shutdownCASLSources(sources) })
+
+	if len(sources) != 3 {
+		t.Fatalf("CASLProviderSources() returned %d sources, want 3", len(sources))
+	}
+	wantNames := []string{"casl", "casl:9", "casl:11"}
+	wantPults := []int64{7, 9, 11}
+	for i, source := range sources {
+		if source.Name != wantNames[i] {
+			t.Fatalf("source[%d].Name = %q, want %q", i, source.Name, wantNames[i])
+		}
+		provider, ok := source.Provider.(*CASLCloudProvider)
+		if !ok {
+			t.Fatalf("source[%d].Provider = %T", i, source.Provider)
+		}
+		if provider.PultID() != wantPults[i] {
+			t.Fatalf("source[%d] pult = %d, want %d", i, provider.PultID(), wantPults[i])
+		}
+		if provider.pultSlot != i+1 {
+			t.Fatalf("source[%d] slot = %d, want %d", i, provider.pultSlot, i+1)
+		}
+		objectID := ids.CASLPultObjectID(i, ids.CASLObjectIDNamespaceStart+15)
+		if !source.OwnsObjectID(objectID) || !source.OwnsAlarmID(objectID) {
+			t.Fatalf("source[%d] does not own its slot ID %d", i, objectID)
+		}
+	}
+	if sources[0].Provider.(*CASLCloudProvider).token != "primary-token" {
+		t.Fatal("primary pult must reuse saved token")
+	}
+	if sources[1].Provider.(*CASLCloudProvider).token != "" {
+		t.Fatal("extra pult must log in with its own session")
+	}
+	if sources[1].OwnsObjectID(ids.CASLPultObjectID(0, ids.CASLObjectIDNamespaceStart+15)) {
+		t.Fatal("extra pult must not own objects of the primary pult")
+	}
+}
+
+func TestCASLProviderPultSlotScopesObjectIndex(t *testing.T) {
+	provider := NewCASLCloudProvider("", "", 9)
+	t.Cleanup(provider.Shutdown)
+	provider.SetPultSlot(2)
+
+	index := provider.buildCASLObjectIndex([]caslGrdObject{{ObjID: "15", Name: "Склад"}})
+	wantID := ids.CASLObjectIDNamespaceStart + 2*ids.CASLPultSlotSize + 15
+	if _, ok := index[wantID]; !ok {
+		t.Fatalf("buildCASLObjectIndex() keys = %v, want %d", index, wantID)
+	}
+	if obj := provider.mapCASLObject(caslGrdObject{ObjID: "15", Name: "Склад"}, nil); obj.ID != wantID {
+		t.Fatalf("mapCASLObject().ID = %d, want %d", obj.ID, wantID)
+	}
+}
+
+func TestCombinedDataProviderRoutesAndLabelsCASLPults(t *testing.T) {
+	firstID := ids.CASLPultObjectID(0, ids.CASLObjectIDNamespaceStart+15)
+	secondID := ids.CASLPultObjectID(1, ids.CASLObjectIDNamespaceStart+15)
+	first := &combinedStubProvider{
+		objects:    []models.Object{{ID: firstID, Name: "Перший"}},
+		alarms:     []models.Alarm{{ID: firstID, ObjectID: firstID}},
+		healthInfo: contracts.FrontendSourceHealthInfo{HealthStatus: contracts.FrontendSourceHealthStatusOnline, HealthText: "OK"},
+	}
+	second := &combinedStubProvider{
+		objects:    []models.Object{{ID: secondID, Name: "Другий"}},
+		alarms:     []models.Alarm{{ID: secondID, ObjectID: secondID}},
+		healthInfo: contracts.FrontendSourceHealthInfo{HealthStatus: contracts.FrontendSourceHealthStatusOffline, HealthText: "немає зв'язку"},
+	}
+	provider := NewMultiSourceDataProvider(
+		ProviderSource{Name: "casl", Pult: "Пульт 7", Provider: first, OwnsObjectID: ids.OwnsCASLPultSlot(0), OwnsAlarmID: ids.OwnsCASLPultSlot(0)},
+		ProviderSource{Name: "casl:9", Pult: "Пульт 9", Provider: second, OwnsObjectID: ids.OwnsCASLPultSlot(1), OwnsAlarmID: ids.OwnsCASLPultSlot(1)},
+	)
+
+	pults := make(map[int]string)
+	for _, obj := range provider.GetObjects() {
+		pults[obj.ID] = obj.Pult
+	}
+	if pults[firstID] != "Пульт 7" || pults[secondID] != "Пульт 9" {
+		t.Fatalf("object pults = %v", pults)
+	}
+	for _, alarm := range provider.GetAlarms() {
+		if alarm.ID == secondID && alarm.Pult != "Пульт 9" {
+			t.Fatalf("alarm pult = %q, want Пульт 9", alarm.Pult)
+		}
+	}
+
+	if err := provider.ProcessAlarmWithRequest(context.Background(), models.Alarm{ID: secondID, ObjectID: secondID}, "operator", contracts.AlarmProcessingRequest{}); err != nil {
+		t.Fatalf("ProcessAlarmWithRequest() error = %v", err)
+	}
+	if len(first.processCalls) != 0 || len(second.processCalls) != 1 {
+		t.Fatalf("process calls: first=%d second=%d, want 0/1", len(first.processCalls), len(second.processCalls))
+	}
+
+	capabilities := provider.FrontendSourceCapabilities()
+	if len(capabilities) != 1 || capabilities[0].Source != contracts.FrontendSourceCASL {
+		t.Fatalf("FrontendSourceCapabilities() = %+v, want single CASL entry", capabilities)
+	}
+	if capabilities[0].HealthStatus != contracts.FrontendSourceHealthStatusOffline || !strings.HasPrefix(capabilities[0].HealthText, "Пульт 9") {
+		t.Fatalf("merged CASL health = %q %q", capabilities[0].HealthStatus, capabilities[0].HealthText)
+	}
+}
+
+func TestCombinedDataProviderRoutesCASLEditorCommandsToOwningPult(t *testing.T) {
+	first := &caslPultEditorStub{combinedStubProvider: &combinedStubProvider{}, pultID: 7, objIDs: map[string]bool{"15": true}, deviceIDs: map[string]bool{"d-7": true}}
+	second := &caslPultEditorStub{combinedStubProvider: &combinedStubProvider{}, pultID: 9, objIDs: map[string]bool{"25": true}, deviceIDs: map[string]bool{"d-9": true}}
+	provider := NewMultiSourceDataProvider(
+		ProviderSource{Name: "casl", Pult: "Пульт 7", Provider: first, OwnsObjectID: ids.OwnsCASLPultSlot(0), OwnsAlarmID: ids.OwnsCASLPultSlot(0)},
+		ProviderSource{Name: "casl:9", Pult: "Пульт 9", Provider: second, OwnsObjectID: ids.OwnsCASLPultSlot(1), OwnsAlarmID: ids.OwnsCASLPultSlot(1)},
+	)
+	ctx := context.Background()
+
+	if err := provider.UpdateCASLObject(ctx, contracts.CASLGuardObjectUpdate{ObjID: "25"}); err != nil {
+		t.Fatalf("UpdateCASLObject(raw obj_id) error = %v", err)
+	}
+	if err := provider.DeleteCASLObject(ctx, int64(ids.CASLPultObjectID(1, ids.CASLObjectIDNamespaceStart+25))); err != nil {
+		t.Fatalf("DeleteCASLObject(internal id) error = %v", err)
+	}
+	if err := provider.BlockCASLDevice(ctx, contracts.CASLDeviceBlockRequest{DeviceID: "d-9"}); err != nil {
+		t.Fatalf("BlockCASLDevice() error = %v", err)
+	}
+	if err := provider.CreateCASLDeviceLine(ctx, contracts.CASLDeviceLineMutation{DeviceID: "d-7"}); err != nil {
+		t.Fatalf("CreateCASLDeviceLine() error = %v", err)
+	}
+	if _, err := provider.CreateCASLObject(ctx, contracts.CASLGuardObjectCreate{ReactingPultID: "9"}); err != nil {
+		t.Fatalf("CreateCASLObject() error = %v", err)
+	}
+	if _, err := provider.CreateCASLDevice(ctx, contracts.CASLDeviceCreate{PultID: "9"}); err != nil {
+		t.Fatalf("CreateCASLDevice() error = %v", err)
+	}
+
+	if got := strings.Join(first.calls, ","); got != "line" {
+		t.Fatalf("first pult calls = %q, want line", got)
+	}
+	if got := strings.Join(second.calls, ","); got != "update,delete,block,create-object,create-device" {
+		t.Fatalf("second pult calls = %q", got)
+	}
+
+	if err := provider.UpdateCASLObject(ctx, contracts.CASLGuardObjectUpdate{ObjID: "99"}); err == nil {
+		t.Fatal("UpdateCASLObject() for unknown obj_id must fail instead of hitting the first pult")
+	}
+	if err := provider.UnblockCASLDevice(ctx, "d-unknown"); err == nil {
+		t.Fatal("UnblockCASLDevice() for unknown device must fail")
+	}
+	if _, err := provider.CreateCASLObject(ctx, contracts.CASLGuardObjectCreate{}); err == nil {
+		t.Fatal("CreateCASLObject() without pult must fail when several pults are configured")
+	}
+}
+
+type caslPultEditorStub struct {
+	*combinedStubProvider
+	contracts.CASLObjectEditorProvider
+
+	pultID    int64
+	objIDs    map[string]bool
+	deviceIDs map[string]bool
+	calls     []string
+}
+
+func (s *caslPultEditorStub) PultID() int64 { return s.pultID }
+
+func (s *caslPultEditorStub) OwnsCASLObject(_ context.Context, objID string) bool {
+	return s.objIDs[objID]
+}
+
+func (s *caslPultEditorStub) OwnsCASLDevice(_ context.Context, deviceID string) bool {
+	return s.deviceIDs[deviceID]
+}
+
+func (s *caslPultEditorStub) UpdateCASLObject(context.Context, contracts.CASLGuardObjectUpdate) error {
+	s.calls = append(s.calls, "update")
+	return nil
+}
+
+func (s *caslPultEditorStub) DeleteCASLObject(context.Context, int64) error {
+	s.calls = append(s.calls, "delete")
+	return nil
+}
+
+func (s *caslPultEditorStub) BlockCASLDevice(context.Context, contracts.CASLDeviceBlockRequest) error {
+	s.calls = append(s.calls, "block")
+	return nil
+}
+
+func (s *caslPultEditorStub) UnblockCASLDevice(context.Context, string) error {
+	s.calls = append(s.calls, "unblock")
+	return nil
+}
+
+func (s *caslPultEditorStub) CreateCASLDeviceLine(context.Context, contracts.CASLDeviceLineMutation) error {
+	s.calls = append(s.calls, "line")
+	return nil
+}
+
+func (s *caslPultEditorStub) CreateCASLObject(context.Context, contracts.CASLGuardObjectCreate) (string, error) {
+	s.calls = append(s.calls, "create-object")
+	return "1", nil
+}
+
+func (s *caslPultEditorStub) CreateCASLDevice(context.Context, contracts.CASLDeviceCreate) (string, error) {
+	s.calls = append(s.calls, "create-device")
+	return "d-new", nil
+}
+
+func shutdownCASLSources(sources []ProviderSource) {
+	for _, source := range sources {
+		if provider, ok := source.Provider.(*CASLCloudProvider); ok {
+			provider.Shutdown()
+		}
+	}
+}
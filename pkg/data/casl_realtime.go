@@ -1124,7 +1124,7 @@ func (p *CASLCloudProvider) updateRealtimeAlarmsFromRows(ctx context.Context, ro
 			}
 		}
 
-		objectID := mapCASLObjectID(rawObjID, strconv.FormatInt(ppkNum, 10), strings.TrimSpace(row.DeviceID))
+		objectID := p.caslObjectID(rawObjID, strconv.FormatInt(ppkNum, 10), strings.TrimSpace(row.DeviceID))
 		objectName := strings.TrimSpace(row.ObjName)
 		objectNum := preferredCASLObjectNumber(rawObjID, objectName, ppkNum)
 
@@ -1241,7 +1241,7 @@ func (p *CASLCloudProvider) updateRealtimeAlarmsFromRows(ctx context.Context, ro
 		if action == "GRD_OBJ_NOTIF" {
 			seed := stableCASLAlarmSeed(action, strings.TrimSpace(row.AlarmType), zoneNumber)
 			p.realtimeAlarmByObjID[cacheKey] = models.Alarm{
-				ID:             stableCASLAlarmID(objectKey, alarmTime.UnixMilli(), seed),
+				ID:             p.caslAlarmID(objectKey, alarmTime.UnixMilli(), seed),
 				ObjectID:       objectID,
 				ObjectNumber:   objectNum,
 				ObjectName:     objectName,
@@ -1315,7 +1315,7 @@ func (p *CASLCloudProvider) updateRealtimeAlarmsFromRows(ctx context.Context, ro
 
 		seed := stableCASLAlarmSeed(row.Code, row.ContactID, zoneNumber)
 		p.realtimeAlarmByObjID[cacheKey] = models.Alarm{
-			ID:             stableCASLAlarmID(objectKey, alarmTime.UnixMilli(), seed),
+			ID:             p.caslAlarmID(objectKey, alarmTime.UnixMilli(), seed),
 			ObjectID:       objectID,
 			ObjectNumber:   objectNum,
 			ObjectName:     objectName,
@@ -1394,7 +1394,7 @@ func (p *CASLCloudProvider) applyCASLRealtimeDeviceOfflineLocked(row CASLObjectE
 		if ppkNum > 0 && record.DeviceNumber.Int64() != ppkNum && objID == "" {
 			continue
 		}
-		internalID := mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
+		internalID := p.caslObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
 		p.objectByInternalID[internalID] = *record
 	}
 }
@@ -1453,7 +1453,7 @@ func (p *CASLCloudProvider) applyCASLRealtimeDeviceBlockLocked(row CASLObjectEve
 			record.TimeUnblock = ""
 		}
 
-		internalID := mapCASLObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
+		internalID := p.caslObjectID(record.ObjID, record.Name, strconv.FormatInt(record.DeviceNumber.Int64(), 10))
 		p.objectByInternalID[internalID] = *record
 	}
 }
@@ -999,6 +999,26 @@ func mapCASLObjectID(parts ...string) int {
 	return ids.CASLObjectIDNamespaceStart + (base % ids.CASLObjectIDNamespaceSize)
 }
 
+// scopeCASLID переносить ID у слот пульта провайдера; без слота ID не змінюється.
+func (p *CASLCloudProvider) scopeCASLID(id int) int {
+	if p == nil || p.pultSlot == 0 {
+		return id
+	}
+	return ids.CASLPultObjectID(p.pultSlot-1, id)
+}
+
+func (p *CASLCloudProvider) caslObjectID(parts ...string) int {
+	return p.scopeCASLID(mapCASLObjectID(parts...))
+}
+
+func (p *CASLCloudProvider) caslEventID(objID string, ts int64, seed string, index int) int {
+	return p.scopeCASLID(stableCASLEventID(objID, ts, seed, index))
+}
+
+func (p *CASLCloudProvider) caslAlarmID(objKey string, ts int64, seed string) int {
+	return p.scopeCASLID(stableCASLAlarmID(objKey, ts, seed))
+}
+
 func preferredCASLObjectNumber(rawObjID string, name string, ppkNum int64) string {
 	if ppkNum > 0 {
 		return strconv.FormatInt(ppkNum, 10)
@@ -263,7 +263,7 @@ func (p *CASLCloudProvider) GetAllObjectContacts(ctx context.Context) (map[int][
 		if err := ctx.Err(); err != nil {
 			return result, err
 		}
-		objectID := mapCASLObjectID(
+		objectID := p.caslObjectID(
 			record.ObjID,
 			record.Name,
 			strconv.FormatInt(record.DeviceNumber.Int64(), 10),
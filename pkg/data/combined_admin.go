@@ -0,0 +1,307 @@
+package data
+
+import (
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Адміністрування маршрутизується за ID об'єкта так само, як читання:
+// команда йде лише в БД джерела, якому належить об'єкт. Інакше ID об'єкта
+// додаткового екземпляра потрапив би в основну БД як номер чужого об'єкта.
+
+func errAdminObjectNotOwned(objn int64) error {
+	return adminNotFoundf("об'єкт %d належить іншому джерелу: адміністрування тут недоступне", objn)
+}
+
+// ownsAdminObject повідомляє, чи маршрутизується objn у джерело з індексом index.
+func (p *CombinedDataProvider) ownsAdminObject(index int, objn int64) bool {
+	source := p.sourceForObjectID(int(objn))
+	return source != nil && source == &p.sources[index]
+}
+
+func (p combinedAdminProvider) guard(objn int64) error {
+	if p.owns != nil && !p.owns(objn) {
+		return errAdminObjectNotOwned(objn)
+	}
+	return nil
+}
+
+func (p combinedAdminProvider) GetObjectCard(objn int64) (contracts.AdminObjectCard, error) {
+	if err := p.guard(objn); err != nil {
+		return contracts.AdminObjectCard{}, err
+	}
+	return p.AdminProvider.GetObjectCard(objn)
+}
+
+func (p combinedAdminProvider) UpdateObject(card contracts.AdminObjectCard) error {
+	if err := p.guard(card.ObjN); err != nil {
+		return err
+	}
+	return p.AdminProvider.UpdateObject(card)
+}
+
+func (p combinedAdminProvider) DeleteObject(objn int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.DeleteObject(objn)
+}
+
+func (p combinedAdminProvider) ListObjectPersonals(objn int64) ([]contracts.AdminObjectPersonal, error) {
+	if err := p.guard(objn); err != nil {
+		return nil, err
+	}
+	return p.AdminProvider.ListObjectPersonals(objn)
+}
+
+func (p combinedAdminProvider) AddObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.AddObjectPersonal(objn, item)
+}
+
+func (p combinedAdminProvider) UpdateObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.UpdateObjectPersonal(objn, item)
+}
+
+func (p combinedAdminProvider) DeleteObjectPersonal(objn int64, personalID int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.DeleteObjectPersonal(objn, personalID)
+}
+
+func (p combinedAdminProvider) ListObjectZones(objn int64) ([]contracts.AdminObjectZone, error) {
+	if err := p.guard(objn); err != nil {
+		return nil, err
+	}
+	return p.AdminProvider.ListObjectZones(objn)
+}
+
+func (p combinedAdminProvider) AddObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.AddObjectZone(objn, zone)
+}
+
+func (p combinedAdminProvider) UpdateObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.UpdateObjectZone(objn, zone)
+}
+
+func (p combinedAdminProvider) DeleteObjectZone(objn int64, zoneID int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.DeleteObjectZone(objn, zoneID)
+}
+
+func (p combinedAdminProvider) FillObjectZones(objn int64, count int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.FillObjectZones(objn, count)
+}
+
+func (p combinedAdminProvider) ClearObjectZones(objn int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.ClearObjectZones(objn)
+}
+
+func (p combinedAdminProvider) GetObjectCoordinates(objn int64) (contracts.AdminObjectCoordinates, error) {
+	if err := p.guard(objn); err != nil {
+		return contracts.AdminObjectCoordinates{}, err
+	}
+	return p.AdminProvider.GetObjectCoordinates(objn)
+}
+
+func (p combinedAdminProvider) SaveObjectCoordinates(objn int64, coords contracts.AdminObjectCoordinates) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.SaveObjectCoordinates(objn, coords)
+}
+
+func (p combinedAdminProvider) SetDisplayBlockMode(objn int64, mode contracts.DisplayBlockMode) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.SetDisplayBlockMode(objn, mode)
+}
+
+func (p combinedAdminProvider) SetObjectSubServer(objn int64, channel int, bind string) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.SetObjectSubServer(objn, channel, bind)
+}
+
+func (p combinedAdminProvider) ClearObjectSubServer(objn int64, channel int) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.ClearObjectSubServer(objn, channel)
+}
+
+func (p combinedAdminProvider) EmulateEvent(objn int64, zone int64, messageUIN int64) error {
+	if err := p.guard(objn); err != nil {
+		return err
+	}
+	return p.AdminProvider.EmulateEvent(objn, zone, messageUIN)
+}
+
+// combinedPhoenixObjectAdmin маршрутизує адміністрування панелей на сервер
+// Phoenix, якому належить об'єкт. Нові панелі створюються на основному сервері.
+type combinedPhoenixObjectAdmin struct {
+	combined *CombinedDataProvider
+	admins   map[int]contracts.PhoenixObjectAdminProvider
+	primary  contracts.PhoenixObjectAdminProvider
+}
+
+func (a combinedPhoenixObjectAdmin) forObject(objn int64) (contracts.PhoenixObjectAdminProvider, error) {
+	for index, admin := range a.admins {
+		if a.combined.ownsAdminObject(index, objn) {
+			return admin, nil
+		}
+	}
+	return nil, errAdminObjectNotOwned(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) GetObjectCard(objn int64) (contracts.AdminObjectCard, error) {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return contracts.AdminObjectCard{}, err
+	}
+	return admin.GetObjectCard(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) CreateObject(card contracts.AdminObjectCard) error {
+	return a.primary.CreateObject(card)
+}
+
+func (a combinedPhoenixObjectAdmin) UpdateObject(card contracts.AdminObjectCard) error {
+	admin, err := a.forObject(card.ObjN)
+	if err != nil {
+		return err
+	}
+	return admin.UpdateObject(card)
+}
+
+func (a combinedPhoenixObjectAdmin) DeleteObject(objn int64) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.DeleteObject(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) FindObjectsBySIMPhone(phone string, excludeObjN *int64) ([]contracts.AdminSIMPhoneUsage, error) {
+	return a.combined.FindObjectsBySIMPhone(phone, excludeObjN)
+}
+
+func (a combinedPhoenixObjectAdmin) ListObjectZones(objn int64) ([]contracts.AdminObjectZone, error) {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return nil, err
+	}
+	return admin.ListObjectZones(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) AddObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.AddObjectZone(objn, zone)
+}
+
+func (a combinedPhoenixObjectAdmin) UpdateObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.UpdateObjectZone(objn, zone)
+}
+
+func (a combinedPhoenixObjectAdmin) DeleteObjectZone(objn int64, zoneID int64) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.DeleteObjectZone(objn, zoneID)
+}
+
+func (a combinedPhoenixObjectAdmin) FillObjectZones(objn int64, count int64) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.FillObjectZones(objn, count)
+}
+
+func (a combinedPhoenixObjectAdmin) ClearObjectZones(objn int64) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.ClearObjectZones(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) ListObjectPersonals(objn int64) ([]contracts.AdminObjectPersonal, error) {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return nil, err
+	}
+	return admin.ListObjectPersonals(objn)
+}
+
+func (a combinedPhoenixObjectAdmin) AddObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.AddObjectPersonal(objn, item)
+}
+
+func (a combinedPhoenixObjectAdmin) UpdateObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.UpdateObjectPersonal(objn, item)
+}
+
+func (a combinedPhoenixObjectAdmin) DeleteObjectPersonal(objn int64, personalID int64) error {
+	admin, err := a.forObject(objn)
+	if err != nil {
+		return err
+	}
+	return admin.DeleteObjectPersonal(objn, personalID)
+}
+
+// FindPersonalByPhone шукає відповідального на всіх серверах Phoenix по черзі.
+func (a combinedPhoenixObjectAdmin) FindPersonalByPhone(phone string) (*contracts.AdminObjectPersonal, error) {
+	for index := range a.combined.sources {
+		admin, ok := a.admins[index]
+		if !ok {
+			continue
+		}
+		personal, err := admin.FindPersonalByPhone(phone)
+		if err != nil || personal != nil {
+			return personal, err
+		}
+	}
+	return nil, nil
+}
+
+func (a combinedPhoenixObjectAdmin) PanelObjectID(panelID string) int64 {
+	return a.primary.PanelObjectID(panelID)
+}
@@ -5,10 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"obj_catalog_fyne_v3/pkg/alarmpriority"
+	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/maintenance"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
 	"obj_catalog_fyne_v3/pkg/utils"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,6 +40,14 @@ type caslObjectEditorProvider interface {
 	contracts.CASLObjectEditorProvider
 }
 
+// caslPultOwner знаходить пульт, якому належать сирі obj_id і device_id CASL:
+// на відміну від внутрішніх ID вони не несуть слота пульта.
+type caslPultOwner interface {
+	PultID() int64
+	OwnsCASLObject(ctx context.Context, objID string) bool
+	OwnsCASLDevice(ctx context.Context, deviceID string) bool
+}
+
 type caslGeoZoneAccessProvider interface {
 	ReadManagers(ctx context.Context, skip int, limit int) ([]map[string]any, error)
 }
@@ -60,6 +74,9 @@ const (
 	combinedLatestEventProbeTimeout         = 4 * time.Second
 )
 
+// PrimarySourceInstance — підпис основного джерела типу, коли поряд є додаткові.
+const PrimarySourceInstance = "Основне"
+
 // ProviderSource описує одне джерело даних у мультисистемній конфігурації.
 // OwnsObjectID/OwnsAlarmID задають, як маршрутизувати запити до цього джерела.
 // Якщо жоден matcher не спрацював, використовується перше (основне) джерело.
@@ -68,6 +85,12 @@ type ProviderSource struct {
 	Provider     contracts.DataProvider
 	OwnsObjectID func(id int) bool
 	OwnsAlarmID  func(id int) bool
+	// Pult — підпис пульта CASL для списків об'єктів і тривог;
+	// порожній, якщо робоче місце обслуговує один пульт.
+	Pult string
+	// Instance — назва екземпляра, коли джерел одного типу кілька
+	// (наприклад, дві БД МІСТ); порожня для єдиного джерела свого типу.
+	Instance string
 }
 
 // CombinedDataProvider об'єднує декілька пультових систем в один DataProvider.
@@ -79,6 +102,14 @@ type CombinedDataProvider struct {
 	latestProbeTimeout   time.Duration
 	eventsCacheMu        sync.RWMutex
 	cachedEventsBySource map[string][]models.Event
+	dispatchStore        config.DispatchConfigStore
+	maintenance          *maintenance.Suppressor
+	testSupervisor       *testsupervision.Supervisor
+	openClose            *openclose.Supervisor
+	alarmPriority        *alarmpriority.Model
+
+	loadedObjectsMu sync.RWMutex
+	loadedObjects   []models.Object
 }
 
 func (p *CombinedDataProvider) FrontendSourceCapabilities() []contracts.FrontendSourceCapability {
@@ -87,11 +118,21 @@ func (p *CombinedDataProvider) FrontendSourceCapabilities() []contracts.Frontend
 	}
 
 	capabilities := make([]contracts.FrontendSourceCapability, 0, len(p.sources))
+	type capabilityKey struct {
+		source   contracts.FrontendSource
+		instance string
+	}
+	indexBySource := make(map[capabilityKey]int, len(p.sources))
 	for _, source := range p.sources {
 		frontendSource := frontendSourceFromProviderName(source.Name)
+		displayName := frontendSource.DisplayName()
+		if source.Instance != "" {
+			displayName += " · " + source.Instance
+		}
 		capability := contracts.FrontendSourceCapability{
 			Source:            frontendSource,
-			DisplayName:       frontendSource.DisplayName(),
+			Instance:          source.Instance,
+			DisplayName:       displayName,
 			ReadObjects:       true,
 			ReadObjectDetails: true,
 			ReadEvents:        true,
@@ -106,6 +147,10 @@ func (p *CombinedDataProvider) FrontendSourceCapabilities() []contracts.Frontend
 			capability.CreateObject = true
 			capability.UpdateObject = true
 		}
+		if adminSource, ok := source.Provider.(contracts.PhoenixObjectAdminSource); ok && adminSource.PhoenixObjectAdmin() != nil {
+			capability.CreateObject = true
+			capability.UpdateObject = true
+		}
 		if healthProvider, ok := source.Provider.(contracts.FrontendSourceHealthProvider); ok {
 			health := healthProvider.FrontendSourceHealth()
 			capability.HealthStatus = health.HealthStatus
@@ -115,12 +160,38 @@ func (p *CombinedDataProvider) FrontendSourceCapabilities() []contracts.Frontend
 			capability.LastRealtimePing = health.LastRealtimePing
 		}
 
+		// Кілька пультів CASL показуються одним джерелом з найгіршим станом;
+		// екземпляри джерела показуються окремо.
+		key := capabilityKey{source: frontendSource, instance: source.Instance}
+		if i, ok := indexBySource[key]; ok {
+			if frontendHealthRank(capability.HealthStatus) > frontendHealthRank(capabilities[i].HealthStatus) {
+				capabilities[i].HealthStatus = capability.HealthStatus
+				capabilities[i].HealthText = source.Pult + ": " + capability.HealthText
+				capabilities[i].APIStatus = capability.APIStatus
+				capabilities[i].RealtimeStatus = capability.RealtimeStatus
+			}
+			continue
+		}
+		indexBySource[key] = len(capabilities)
 		capabilities = append(capabilities, capability)
 	}
 
 	return capabilities
 }
 
+func frontendHealthRank(status contracts.FrontendSourceHealthStatus) int {
+	switch status {
+	case contracts.FrontendSourceHealthStatusOffline:
+		return 3
+	case contracts.FrontendSourceHealthStatusDegraded:
+		return 2
+	case contracts.FrontendSourceHealthStatusUnknown:
+		return 1
+	default:
+		return 0
+	}
+}
+
 func NewCombinedDataProvider(primary contracts.DataProvider, secondary contracts.DataProvider) *CombinedDataProvider {
 	sources := make([]ProviderSource, 0, 2)
 	if primary != nil {
@@ -170,6 +241,86 @@ func NewMultiSourceDataProvider(sources ...ProviderSource) *CombinedDataProvider
 	}
 }
 
+// SetDispatchConfigStore задає налаштування радника диспетчеризації МГР.
+// Без сховища групи ранжуються за відстанню по прямій.
+func (p *CombinedDataProvider) SetDispatchConfigStore(store config.DispatchConfigStore) {
+	if p == nil {
+		return
+	}
+	p.dispatchStore = store
+}
+
+// SetMaintenanceSuppressor вмикає вікна обслуговування для тривог усіх джерел.
+func (p *CombinedDataProvider) SetMaintenanceSuppressor(suppressor *maintenance.Suppressor) {
+	if p == nil {
+		return
+	}
+	p.maintenance = suppressor
+}
+
+// MaintenanceSuppressor повертає налаштовані вікна обслуговування або nil.
+func (p *CombinedDataProvider) MaintenanceSuppressor() *maintenance.Suppressor {
+	if p == nil {
+		return nil
+	}
+	return p.maintenance
+}
+
+// SetTestSupervisor вмикає локальний контроль пропущених періодичних тестів.
+func (p *CombinedDataProvider) SetTestSupervisor(supervisor *testsupervision.Supervisor) {
+	if p == nil {
+		return
+	}
+	p.testSupervisor = supervisor
+}
+
+// TestSupervisor повертає налаштований контроль тестів або nil.
+func (p *CombinedDataProvider) TestSupervisor() *testsupervision.Supervisor {
+	if p == nil {
+		return nil
+	}
+	return p.testSupervisor
+}
+
+// SetOpenCloseSupervisor вмикає контроль розкладу відкриття/закриття об'єктів.
+func (p *CombinedDataProvider) SetOpenCloseSupervisor(supervisor *openclose.Supervisor) {
+	if p == nil {
+		return
+	}
+	p.openClose = supervisor
+}
+
+// OpenCloseSupervisor повертає налаштований контроль розкладу або nil.
+func (p *CombinedDataProvider) OpenCloseSupervisor() *openclose.Supervisor {
+	if p == nil {
+		return nil
+	}
+	return p.openClose
+}
+
+// SetAlarmPriorityModel вмикає оцінку пріоритету і сортування тривог за нею.
+func (p *CombinedDataProvider) SetAlarmPriorityModel(model *alarmpriority.Model) {
+	if p == nil {
+		return
+	}
+	p.alarmPriority = model
+}
+
+// AlarmPriorityModel повертає налаштовану модель пріоритету або nil.
+func (p *CombinedDataProvider) AlarmPriorityModel() *alarmpriority.Model {
+	if p == nil {
+		return nil
+	}
+	return p.alarmPriority
+}
+
+func (p *CombinedDataProvider) responseGroupAdvisor() *ResponseGroupAdvisor {
+	if p == nil || p.dispatchStore == nil {
+		return NewResponseGroupAdvisor()
+	}
+	return NewResponseGroupAdvisorFromConfig(p.dispatchStore.LoadDispatchConfig())
+}
+
 func (p *CombinedDataProvider) Shutdown() {
 	if p == nil {
 		return
@@ -187,18 +338,46 @@ func (p *CombinedDataProvider) AdminProvider() contracts.AdminProvider {
 	if p == nil {
 		return nil
 	}
-	for _, source := range p.sources {
+	for i, source := range p.sources {
 		admin, ok := source.Provider.(contracts.AdminProvider)
 		if ok {
+			index := i
 			return combinedAdminProvider{
 				AdminProvider: admin,
 				lookup:        p,
+				owns:          func(objn int64) bool { return p.ownsAdminObject(index, objn) },
 			}
 		}
 	}
 	return nil
 }
 
+// PhoenixObjectAdmin повертає адміністрування панелей Phoenix. З кількома
+// серверами Phoenix команди маршрутизуються на сервер, якому належить об'єкт.
+func (p *CombinedDataProvider) PhoenixObjectAdmin() contracts.PhoenixObjectAdminProvider {
+	if p == nil {
+		return nil
+	}
+	router := combinedPhoenixObjectAdmin{combined: p, admins: make(map[int]contracts.PhoenixObjectAdminProvider)}
+	for i, source := range p.sources {
+		if adminSource, ok := source.Provider.(contracts.PhoenixObjectAdminSource); ok {
+			if admin := adminSource.PhoenixObjectAdmin(); admin != nil {
+				router.admins[i] = admin
+				if router.primary == nil {
+					router.primary = admin
+				}
+			}
+		}
+	}
+	switch len(router.admins) {
+	case 0:
+		return nil
+	case 1:
+		return router.primary
+	}
+	return router
+}
+
 func (p *CombinedDataProvider) GetStatisticReport(ctx context.Context, name string, limit int) ([]map[string]any, error) {
 	if p == nil {
 		return nil, errors.New("combined provider is nil")
@@ -214,7 +393,14 @@ func (p *CombinedDataProvider) GetStatisticReport(ctx context.Context, name stri
 }
 
 func (p *CombinedDataProvider) GetCASLObjectEditorSnapshot(ctx context.Context, objectID int64) (contracts.CASLObjectEditorSnapshot, error) {
-	provider, err := p.resolveCASLObjectEditorProvider(objectID)
+	resolve := p.resolveAnyCASLObjectEditorProvider
+	if objectID > 0 {
+		// Без об'єкта знімок містить лише довідники, спільні для всіх пультів.
+		resolve = func() (caslObjectEditorProvider, error) {
+			return p.resolveCASLObjectEditorProvider(ctx, objectID)
+		}
+	}
+	provider, err := resolve()
 	if err != nil {
 		return contracts.CASLObjectEditorSnapshot{}, err
 	}
@@ -222,7 +408,7 @@ func (p *CombinedDataProvider) GetCASLObjectEditorSnapshot(ctx context.Context,
 }
 
 func (p *CombinedDataProvider) CreateCASLObject(ctx context.Context, create contracts.CASLGuardObjectCreate) (string, error) {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLPultEditorProvider(create.ReactingPultID)
 	if err != nil {
 		return "", err
 	}
@@ -230,7 +416,7 @@ func (p *CombinedDataProvider) CreateCASLObject(ctx context.Context, create cont
 }
 
 func (p *CombinedDataProvider) UpdateCASLObject(ctx context.Context, update contracts.CASLGuardObjectUpdate) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(update.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(update.ObjID))
 	if err != nil {
 		return err
 	}
@@ -238,7 +424,7 @@ func (p *CombinedDataProvider) UpdateCASLObject(ctx context.Context, update cont
 }
 
 func (p *CombinedDataProvider) DeleteCASLObject(ctx context.Context, objectID int64) error {
-	provider, err := p.resolveCASLObjectEditorProvider(objectID)
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, objectID)
 	if err != nil {
 		return err
 	}
@@ -246,7 +432,7 @@ func (p *CombinedDataProvider) DeleteCASLObject(ctx context.Context, objectID in
 }
 
 func (p *CombinedDataProvider) UpdateCASLRoom(ctx context.Context, update contracts.CASLRoomUpdate) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(update.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(update.ObjID))
 	if err != nil {
 		return err
 	}
@@ -254,31 +440,55 @@ func (p *CombinedDataProvider) UpdateCASLRoom(ctx context.Context, update contra
 }
 
 func (p *CombinedDataProvider) CreateCASLRoom(ctx context.Context, create contracts.CASLRoomCreate) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(create.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(create.ObjID))
 	if err != nil {
 		return err
 	}
 	return provider.CreateCASLRoom(ctx, create)
 }
 
+// ReadCASLDeviceNumbers об'єднує номери приладів усіх пультів CASL.
 func (p *CombinedDataProvider) ReadCASLDeviceNumbers(ctx context.Context) ([]int64, error) {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	providers, err := p.caslObjectEditorProviders()
 	if err != nil {
 		return nil, err
 	}
-	return provider.ReadCASLDeviceNumbers(ctx)
+	seen := make(map[int64]struct{})
+	var numbers []int64
+	for _, provider := range providers {
+		items, err := provider.ReadCASLDeviceNumbers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, number := range items {
+			if _, ok := seen[number]; ok {
+				continue
+			}
+			seen[number] = struct{}{}
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers, nil
 }
 
+// IsCASLDeviceNumberInUse перевіряє номер на всіх пультах CASL.
 func (p *CombinedDataProvider) IsCASLDeviceNumberInUse(ctx context.Context, deviceNumber int64) (bool, error) {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	providers, err := p.caslObjectEditorProviders()
 	if err != nil {
 		return false, err
 	}
-	return provider.IsCASLDeviceNumberInUse(ctx, deviceNumber)
+	for _, provider := range providers {
+		inUse, err := provider.IsCASLDeviceNumberInUse(ctx, deviceNumber)
+		if err != nil || inUse {
+			return inUse, err
+		}
+	}
+	return false, nil
 }
 
 func (p *CombinedDataProvider) CreateCASLDevice(ctx context.Context, create contracts.CASLDeviceCreate) (string, error) {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLPultEditorProvider(create.PultID)
 	if err != nil {
 		return "", err
 	}
@@ -286,7 +496,7 @@ func (p *CombinedDataProvider) CreateCASLDevice(ctx context.Context, create cont
 }
 
 func (p *CombinedDataProvider) UpdateCASLDevice(ctx context.Context, update contracts.CASLDeviceUpdate) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, update.DeviceID)
 	if err != nil {
 		return err
 	}
@@ -294,7 +504,7 @@ func (p *CombinedDataProvider) UpdateCASLDevice(ctx context.Context, update cont
 }
 
 func (p *CombinedDataProvider) BlockCASLDevice(ctx context.Context, request contracts.CASLDeviceBlockRequest) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, request.DeviceID)
 	if err != nil {
 		return err
 	}
@@ -302,7 +512,7 @@ func (p *CombinedDataProvider) BlockCASLDevice(ctx context.Context, request cont
 }
 
 func (p *CombinedDataProvider) UnblockCASLDevice(ctx context.Context, deviceID string) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, deviceID)
 	if err != nil {
 		return err
 	}
@@ -310,7 +520,7 @@ func (p *CombinedDataProvider) UnblockCASLDevice(ctx context.Context, deviceID s
 }
 
 func (p *CombinedDataProvider) UpdateCASLDeviceLine(ctx context.Context, update contracts.CASLDeviceLineMutation) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, update.DeviceID)
 	if err != nil {
 		return err
 	}
@@ -318,7 +528,7 @@ func (p *CombinedDataProvider) UpdateCASLDeviceLine(ctx context.Context, update
 }
 
 func (p *CombinedDataProvider) CreateCASLDeviceLine(ctx context.Context, create contracts.CASLDeviceLineMutation) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, create.DeviceID)
 	if err != nil {
 		return err
 	}
@@ -326,7 +536,7 @@ func (p *CombinedDataProvider) CreateCASLDeviceLine(ctx context.Context, create
 }
 
 func (p *CombinedDataProvider) DeleteCASLDeviceLine(ctx context.Context, deviceID string, lineNumber int) error {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	provider, err := p.resolveCASLDeviceEditorProvider(ctx, deviceID)
 	if err != nil {
 		return err
 	}
@@ -334,7 +544,7 @@ func (p *CombinedDataProvider) DeleteCASLDeviceLine(ctx context.Context, deviceI
 }
 
 func (p *CombinedDataProvider) AddCASLLineToRoom(ctx context.Context, binding contracts.CASLLineToRoomBinding) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(binding.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(binding.ObjID))
 	if err != nil {
 		return err
 	}
@@ -342,7 +552,7 @@ func (p *CombinedDataProvider) AddCASLLineToRoom(ctx context.Context, binding co
 }
 
 func (p *CombinedDataProvider) RemoveCASLLineFromRoom(ctx context.Context, binding contracts.CASLLineToRoomBinding) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(binding.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(binding.ObjID))
 	if err != nil {
 		return err
 	}
@@ -350,7 +560,7 @@ func (p *CombinedDataProvider) RemoveCASLLineFromRoom(ctx context.Context, bindi
 }
 
 func (p *CombinedDataProvider) AddCASLUserToRoom(ctx context.Context, request contracts.CASLAddUserToRoomRequest) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(request.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(request.ObjID))
 	if err != nil {
 		return err
 	}
@@ -358,7 +568,7 @@ func (p *CombinedDataProvider) AddCASLUserToRoom(ctx context.Context, request co
 }
 
 func (p *CombinedDataProvider) RemoveCASLUserFromRoom(ctx context.Context, request contracts.CASLRemoveUserFromRoomRequest) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(request.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(request.ObjID))
 	if err != nil {
 		return err
 	}
@@ -366,7 +576,7 @@ func (p *CombinedDataProvider) RemoveCASLUserFromRoom(ctx context.Context, reque
 }
 
 func (p *CombinedDataProvider) UpdateCASLRoomUserPriorities(ctx context.Context, objectID int64, items []contracts.CASLRoomUserPriority) error {
-	provider, err := p.resolveCASLObjectEditorProvider(objectID)
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, objectID)
 	if err != nil {
 		return err
 	}
@@ -374,7 +584,14 @@ func (p *CombinedDataProvider) UpdateCASLRoomUserPriorities(ctx context.Context,
 }
 
 func (p *CombinedDataProvider) CreateCASLUser(ctx context.Context, request contracts.CASLUserCreateRequest) (contracts.CASLUserProfile, error) {
-	provider, err := p.resolveAnyCASLObjectEditorProvider()
+	resolve := p.resolveAnyCASLObjectEditorProvider
+	if len(request.DeviceIDs) > 0 {
+		// Користувача створюємо на пульті приладів, до яких його прив'язують.
+		resolve = func() (caslObjectEditorProvider, error) {
+			return p.resolveCASLDeviceEditorProvider(ctx, request.DeviceIDs[0])
+		}
+	}
+	provider, err := resolve()
 	if err != nil {
 		return contracts.CASLUserProfile{}, err
 	}
@@ -390,7 +607,7 @@ func (p *CombinedDataProvider) ReadCASLObjectBasket(ctx context.Context) ([]cont
 }
 
 func (p *CombinedDataProvider) CreateCASLImage(ctx context.Context, request contracts.CASLImageCreateRequest) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(request.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(request.ObjID))
 	if err != nil {
 		return err
 	}
@@ -398,7 +615,7 @@ func (p *CombinedDataProvider) CreateCASLImage(ctx context.Context, request cont
 }
 
 func (p *CombinedDataProvider) DeleteCASLImage(ctx context.Context, request contracts.CASLImageDeleteRequest) error {
-	provider, err := p.resolveCASLObjectEditorProvider(parseCASLMutationObjectID(request.ObjID))
+	provider, err := p.resolveCASLObjectEditorProvider(ctx, parseCASLMutationObjectID(request.ObjID))
 	if err != nil {
 		return err
 	}
@@ -479,24 +696,54 @@ func (p *CombinedDataProvider) GetObjectsContext(ctx context.Context) []models.O
 			if err := ctx.Err(); err != nil {
 				break
 			}
+			var sourceObjects []models.Object
 			if provider, ok := source.Provider.(contracts.ContextObjectProvider); ok {
-				objects = append(objects, provider.GetObjectsContext(ctx)...)
+				sourceObjects = provider.GetObjectsContext(ctx)
 			} else {
-				objects = append(objects, source.Provider.GetObjects()...)
+				sourceObjects = source.Provider.GetObjects()
+			}
+			if source.Pult != "" || source.Instance != "" {
+				for i := range sourceObjects {
+					sourceObjects[i].Pult = source.Pult
+					sourceObjects[i].SourceInstance = source.Instance
+				}
 			}
+			objects = append(objects, sourceObjects...)
 		}
 	}
 
 	if len(objects) == 0 {
 		return nil
 	}
+	if p.testSupervisor != nil && ctx.Err() == nil {
+		p.testSupervisor.Observe(objects)
+	}
+	if p.alarmPriority != nil && ctx.Err() == nil {
+		p.alarmPriority.Observe(objects)
+	}
 
 	sort.SliceStable(objects, func(i, j int) bool {
 		return combinedObjectDisplayNumber(objects[i]) < combinedObjectDisplayNumber(objects[j])
 	})
+	if ctx.Err() == nil {
+		p.loadedObjectsMu.Lock()
+		p.loadedObjects = slices.Clone(objects)
+		p.loadedObjectsMu.Unlock()
+	}
 	return objects
 }
 
+// LoadedObjects повертає об'єкти останнього повного завантаження без звернення
+// до джерел; до першого завантаження список порожній.
+func (p *CombinedDataProvider) LoadedObjects() []models.Object {
+	if p == nil {
+		return nil
+	}
+	p.loadedObjectsMu.RLock()
+	defer p.loadedObjectsMu.RUnlock()
+	return slices.Clone(p.loadedObjects)
+}
+
 func (p *CombinedDataProvider) ListObjectLocations(ctx context.Context) ([]contracts.ObjectLocation, error) {
 	if p == nil {
 		return nil, nil
@@ -563,7 +810,7 @@ func (p *CombinedDataProvider) GetObjectByID(id string) *models.Object {
 		return nil
 	}
 	if obj := provider.GetObjectByID(id); obj != nil {
-		return obj
+		return p.withObjectPult(obj)
 	}
 	// Якщо маршрутизатор промахнувся, робимо fallback по всіх джерелах.
 	for _, source := range p.sources {
@@ -571,12 +818,24 @@ func (p *CombinedDataProvider) GetObjectByID(id string) *models.Object {
 			continue
 		}
 		if obj := source.Provider.GetObjectByID(id); obj != nil {
-			return obj
+			return p.withObjectPult(obj)
 		}
 	}
 	return nil
 }
 
+func (p *CombinedDataProvider) withObjectPult(obj *models.Object) *models.Object {
+	if source := p.sourceForObjectID(obj.ID); source != nil {
+		if source.Pult != "" {
+			obj.Pult = source.Pult
+		}
+		if source.Instance != "" {
+			obj.SourceInstance = source.Instance
+		}
+	}
+	return obj
+}
+
 func (p *CombinedDataProvider) GetZones(objectID string) []models.Zone {
 	provider := p.providerForObjectID(objectID)
 	if provider == nil {
@@ -727,6 +986,9 @@ func (p *CombinedDataProvider) GetEventsContext(ctx context.Context) []models.Ev
 	wg.Wait()
 
 	sortEvents(events)
+	if p.openClose != nil {
+		p.openClose.ObserveEvents(events)
+	}
 	return events
 }
 
@@ -774,6 +1036,93 @@ func (p *CombinedDataProvider) GetObjectEventsRange(objectID string, from time.T
 	return events
 }
 
+// QueryObjectEventsRange implements contracts.ObjectEventsRangeQueryProvider.
+// Джерела без звіту про помилки читаються через GetObjectEventsRange.
+func (p *CombinedDataProvider) QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
+	provider := p.providerForObjectID(objectID)
+	if provider == nil {
+		return nil, fmt.Errorf("джерело об'єкта %s не підключено", objectID)
+	}
+	ranged, ok := provider.(contracts.ObjectEventsRangeQueryProvider)
+	if !ok {
+		return p.GetObjectEventsRange(objectID, from, to), nil
+	}
+	events, err := ranged.QueryObjectEventsRange(ctx, objectID, from, to)
+	sortEvents(events)
+	return events, err
+}
+
+// QueryEventArchive гортає архів джерела, якому належить query.ObjectID, а без
+// об'єкта — архіви всіх джерел по черзі. Курсор зберігає номер джерела і курсор
+// усередині нього, тож сторінки не змішують події різних серверів.
+func (p *CombinedDataProvider) QueryEventArchive(ctx context.Context, query contracts.EventArchiveQuery) (contracts.EventArchivePage, error) {
+	if p == nil {
+		return contracts.EventArchivePage{}, errors.New("combined provider is nil")
+	}
+	startIndex, innerCursor, err := parseCombinedArchiveCursor(query.Cursor)
+	if err != nil {
+		return contracts.EventArchivePage{}, err
+	}
+	indexes := p.archiveSourceIndexes(query.ObjectID)
+	if len(indexes) == 0 {
+		return contracts.EventArchivePage{}, errors.New("архів подій не підтримується джерелом")
+	}
+	for position, index := range indexes {
+		if index < startIndex {
+			continue
+		}
+		sourceQuery := query
+		sourceQuery.Cursor = ""
+		if index == startIndex {
+			sourceQuery.Cursor = innerCursor
+		}
+		source := p.sources[index]
+		page, err := source.Provider.(contracts.EventArchiveProvider).QueryEventArchive(ctx, sourceQuery)
+		if err != nil {
+			return contracts.EventArchivePage{}, fmt.Errorf("%s: %w", frontendSourceFromProviderName(source.Name).DisplayName(), err)
+		}
+		switch {
+		case page.NextCursor != "":
+			page.NextCursor = formatCombinedArchiveCursor(index, page.NextCursor)
+		case position+1 < len(indexes):
+			page.NextCursor = formatCombinedArchiveCursor(indexes[position+1], "")
+		}
+		return page, nil
+	}
+	return contracts.EventArchivePage{}, nil
+}
+
+func (p *CombinedDataProvider) archiveSourceIndexes(objectID int) []int {
+	var indexes []int
+	for i, source := range p.sources {
+		if _, ok := source.Provider.(contracts.EventArchiveProvider); !ok {
+			continue
+		}
+		if objectID != 0 && p.sourceForObjectID(objectID) != &p.sources[i] {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func formatCombinedArchiveCursor(sourceIndex int, cursor string) string {
+	return strconv.Itoa(sourceIndex) + ":" + cursor
+}
+
+func parseCombinedArchiveCursor(cursor string) (int, string, error) {
+	cursor = strings.TrimSpace(cursor)
+	if cursor == "" {
+		return 0, "", nil
+	}
+	rawIndex, inner, ok := strings.Cut(cursor, ":")
+	index, err := strconv.Atoi(rawIndex)
+	if !ok || err != nil || index < 0 {
+		return 0, "", fmt.Errorf("некоректний курсор архіву %q", cursor)
+	}
+	return index, inner, nil
+}
+
 func filterEventsByTimeRange(events []models.Event, from time.Time, to time.Time) []models.Event {
 	result := make([]models.Event, 0, len(events))
 	for _, event := range events {
@@ -870,6 +1219,12 @@ func (p *CombinedDataProvider) GetAlarmsContext(ctx context.Context) []models.Al
 
 			select {
 			case sourceAlarms := <-resChan:
+				if src.Pult != "" || src.Instance != "" {
+					for i := range sourceAlarms {
+						sourceAlarms[i].Pult = src.Pult
+						sourceAlarms[i].SourceInstance = src.Instance
+					}
+				}
 				if len(sourceAlarms) > 0 {
 					mu.Lock()
 					alarms = append(alarms, sourceAlarms...)
@@ -883,6 +1238,17 @@ func (p *CombinedDataProvider) GetAlarmsContext(ctx context.Context) []models.Al
 	}
 	wg.Wait()
 
+	if p.maintenance != nil {
+		alarms = p.maintenance.ApplyMaintenance(alarms, p)
+	}
+	if p.testSupervisor != nil {
+		// Очікування наповнює GetObjectsContext; тут лише перевірка без запитів до БД.
+		alarms = p.testSupervisor.ApplyTestSupervision(alarms)
+	}
+	if p.openClose != nil {
+		alarms = p.openClose.ApplySchedules(alarms, p)
+	}
+
 	sort.SliceStable(alarms, func(i, j int) bool {
 		left := alarms[i].Time
 		right := alarms[j].Time
@@ -891,6 +1257,10 @@ func (p *CombinedDataProvider) GetAlarmsContext(ctx context.Context) []models.Al
 		}
 		return left.After(right)
 	})
+	if p.alarmPriority != nil {
+		// Кеш моделі наповнює GetObjectsContext; тут лише оцінка без запитів до БД.
+		alarms = p.alarmPriority.Apply(alarms)
+	}
 	return alarms
 }
 
@@ -899,6 +1269,10 @@ func (p *CombinedDataProvider) ProcessAlarm(id string, user string, note string)
 		return errors.New("combined provider is nil")
 	}
 
+	if alarmID, ok := parseObjectID(id); ok && p.isLocalAlarm(alarmID) {
+		return p.acknowledgeLocalAlarm(alarmID, user, note)
+	}
+
 	provider := p.providerForAlarmID(id)
 	if provider != nil {
 		return provider.ProcessAlarm(id, user, note)
@@ -915,6 +1289,9 @@ func (p *CombinedDataProvider) GetAlarmProcessingOptions(ctx context.Context, al
 	if p == nil {
 		return nil, errors.New("combined provider is nil")
 	}
+	if p.isLocalAlarm(alarm.ID) {
+		return nil, nil
+	}
 
 	provider := p.providerForAlarmID(strconv.Itoa(alarm.ID))
 	if advanced, ok := provider.(alarmProcessingProvider); ok {
@@ -927,8 +1304,31 @@ func (p *CombinedDataProvider) PickAlarm(ctx context.Context, alarm models.Alarm
 	if p == nil {
 		return errors.New("combined provider is nil")
 	}
+	if p.isLocalAlarm(alarm.ID) {
+		// Локальна тривога не має джерела, тож і брати її в роботу нікому.
+		return nil
+	}
+
+	provider := p.providerForAlarmID(strconv.Itoa(alarm.ID))
+	if advanced, ok := provider.(contracts.AlarmTakeoverProvider); ok {
+		return advanced.PickAlarm(ctx, alarm, user)
+	}
+	return errors.New("alarm takeover provider is not configured")
+}
+
+// TakeOverAlarm перехоплює тривогу з причиною; джерела без такої підтримки отримують звичайне взяття в роботу.
+func (p *CombinedDataProvider) TakeOverAlarm(ctx context.Context, alarm models.Alarm, user string, reason string) error {
+	if p == nil {
+		return errors.New("combined provider is nil")
+	}
+	if p.isLocalAlarm(alarm.ID) {
+		return nil
+	}
 
 	provider := p.providerForAlarmID(strconv.Itoa(alarm.ID))
+	if advanced, ok := provider.(contracts.AlarmTakeoverReasonProvider); ok {
+		return advanced.TakeOverAlarm(ctx, alarm, user, reason)
+	}
 	if advanced, ok := provider.(contracts.AlarmTakeoverProvider); ok {
 		return advanced.PickAlarm(ctx, alarm, user)
 	}
@@ -939,6 +1339,9 @@ func (p *CombinedDataProvider) ProcessAlarmWithRequest(ctx context.Context, alar
 	if p == nil {
 		return errors.New("combined provider is nil")
 	}
+	if p.isLocalAlarm(alarm.ID) {
+		return p.acknowledgeLocalAlarm(alarm.ID, user, request.Note)
+	}
 
 	provider := p.providerForAlarmID(strconv.Itoa(alarm.ID))
 	if advanced, ok := provider.(alarmProcessingProvider); ok {
@@ -1066,6 +1469,25 @@ func (p *CombinedDataProvider) providerForObjectID(objectID string) contracts.Da
 	return p.sources[0].Provider
 }
 
+// isLocalAlarm повідомляє, що тривогу згенерував сам клієнт (вікна обслуговування,
+// контроль тестів чи розкладу), а не джерело.
+func (p *CombinedDataProvider) isLocalAlarm(alarmID int) bool {
+	return (p.maintenance != nil && maintenance.IsReminderAlarmID(alarmID)) ||
+		(p.testSupervisor != nil && testsupervision.IsMissedTestAlarmID(alarmID)) ||
+		(p.openClose != nil && openclose.IsScheduleAlarmID(alarmID))
+}
+
+// acknowledgeLocalAlarm підтверджує локальну тривогу в тому модулі, що її створив.
+func (p *CombinedDataProvider) acknowledgeLocalAlarm(alarmID int, user string, note string) error {
+	if p.testSupervisor != nil && testsupervision.IsMissedTestAlarmID(alarmID) {
+		return p.testSupervisor.Acknowledge(alarmID, user, note)
+	}
+	if p.openClose != nil && openclose.IsScheduleAlarmID(alarmID) {
+		return p.openClose.Acknowledge(alarmID, user, note)
+	}
+	return p.maintenance.AcknowledgeReminder(alarmID, user, note)
+}
+
 func (p *CombinedDataProvider) providerForAlarmID(alarmID string) contracts.DataProvider {
 	if p == nil || len(p.sources) == 0 {
 		return nil
@@ -1140,7 +1562,8 @@ func (p *CombinedDataProvider) ListResponseGroupsForAlarm(ctx context.Context, a
 			groups[i].Source = sourceType
 		}
 	}
-	return groups, nil
+	object := src.Provider.GetObjectByID(strconv.Itoa(alarm.ObjectID))
+	return p.responseGroupAdvisor().Rank(ctx, object, alarm, groups), nil
 }
 
 // AssignResponseGroup implements contracts.ResponseGroupProvider.
@@ -1220,15 +1643,101 @@ func parseCASLMutationObjectID(raw string) int64 {
 	return parsed
 }
 
-func (p *CombinedDataProvider) resolveCASLObjectEditorProvider(objectID int64) (caslObjectEditorProvider, error) {
-	if objectID > 0 {
-		if source := p.sourceForObjectID(int(objectID)); source != nil {
-			if provider, ok := source.Provider.(caslObjectEditorProvider); ok {
-				return provider, nil
+// resolveCASLObjectEditorProvider повертає пульт CASL, якому належить об'єкт.
+// Внутрішній ID несе слот пульта; сирий obj_id шукаємо в об'єктах кожного пульта.
+func (p *CombinedDataProvider) resolveCASLObjectEditorProvider(ctx context.Context, objectID int64) (caslObjectEditorProvider, error) {
+	sources, err := p.caslObjectEditorSources()
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 1 {
+		return sources[0].Provider.(caslObjectEditorProvider), nil
+	}
+	switch {
+	case objectID <= 0:
+	case ids.IsCASLObjectID(int(objectID)):
+		for _, source := range sources {
+			if source.OwnsObjectID != nil && source.OwnsObjectID(int(objectID)) {
+				return source.Provider.(caslObjectEditorProvider), nil
+			}
+		}
+	default:
+		objID := strconv.FormatInt(objectID, 10)
+		for _, source := range sources {
+			if owner, ok := source.Provider.(caslPultOwner); ok && owner.OwnsCASLObject(ctx, objID) {
+				return source.Provider.(caslObjectEditorProvider), nil
 			}
 		}
 	}
-	return p.resolveAnyCASLObjectEditorProvider()
+	return nil, fmt.Errorf("casl object %d does not belong to any configured pult", objectID)
+}
+
+// resolveCASLDeviceEditorProvider повертає пульт CASL, якому належить прилад.
+func (p *CombinedDataProvider) resolveCASLDeviceEditorProvider(ctx context.Context, deviceID string) (caslObjectEditorProvider, error) {
+	sources, err := p.caslObjectEditorSources()
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 1 {
+		return sources[0].Provider.(caslObjectEditorProvider), nil
+	}
+	deviceID = strings.TrimSpace(deviceID)
+	for _, source := range sources {
+		if owner, ok := source.Provider.(caslPultOwner); ok && owner.OwnsCASLDevice(ctx, deviceID) {
+			return source.Provider.(caslObjectEditorProvider), nil
+		}
+	}
+	return nil, fmt.Errorf("casl device %q does not belong to any configured pult", deviceID)
+}
+
+// resolveCASLPultEditorProvider повертає пульт CASL для створення нових записів.
+// З кількома пультами його треба вказати явно: вгадувати тут не можна.
+func (p *CombinedDataProvider) resolveCASLPultEditorProvider(pultID string) (caslObjectEditorProvider, error) {
+	sources, err := p.caslObjectEditorSources()
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 1 {
+		return sources[0].Provider.(caslObjectEditorProvider), nil
+	}
+	pultID = strings.TrimSpace(pultID)
+	if pultID == "" {
+		return nil, errors.New("casl pult must be selected when several pults are configured")
+	}
+	for _, source := range sources {
+		if owner, ok := source.Provider.(caslPultOwner); ok && strconv.FormatInt(owner.PultID(), 10) == pultID {
+			return source.Provider.(caslObjectEditorProvider), nil
+		}
+	}
+	return nil, fmt.Errorf("casl pult %q is not configured", pultID)
+}
+
+func (p *CombinedDataProvider) caslObjectEditorSources() ([]ProviderSource, error) {
+	if p == nil {
+		return nil, errors.New("combined provider is nil")
+	}
+	var sources []ProviderSource
+	for _, source := range p.sources {
+		if _, ok := source.Provider.(caslObjectEditorProvider); ok {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("casl object editor provider is not configured")
+	}
+	return sources, nil
+}
+
+func (p *CombinedDataProvider) caslObjectEditorProviders() ([]caslObjectEditorProvider, error) {
+	sources, err := p.caslObjectEditorSources()
+	if err != nil {
+		return nil, err
+	}
+	providers := make([]caslObjectEditorProvider, 0, len(sources))
+	for _, source := range sources {
+		providers = append(providers, source.Provider.(caslObjectEditorProvider))
+	}
+	return providers, nil
 }
 
 func (p *CombinedDataProvider) resolveAnyCASLObjectEditorProvider() (caslObjectEditorProvider, error) {
@@ -1272,7 +1781,12 @@ func sortEvents(events []models.Event) {
 }
 
 func frontendSourceFromProviderName(name string) contracts.FrontendSource {
-	switch strings.ToLower(strings.TrimSpace(name)) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	// Пульти CASL і додаткові екземпляри джерел мають імена виду "<тип>:<назва>".
+	if kind, _, found := strings.Cut(name, ":"); found {
+		name = kind
+	}
+	switch name {
 	case "bridge", "db", "firebird", "most":
 		return contracts.FrontendSourceBridge
 	case "phoenix":
@@ -9,8 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/export"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/reportupload"
 	"obj_catalog_fyne_v3/pkg/utils"
 
 	"github.com/jmoiron/sqlx"
@@ -192,7 +194,7 @@ type objectGPSRow struct {
 
 func (p *DBDataProvider) GetObjectCard(objn int64) (AdminObjectCard, error) {
 	if objn <= 0 {
-		return AdminObjectCard{}, fmt.Errorf("invalid object number")
+		return AdminObjectCard{}, adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -230,7 +232,7 @@ func (p *DBDataProvider) GetObjectCard(objn int64) (AdminObjectCard, error) {
 	var row objectCardRow
 	if err := p.db.GetContext(ctx, &row, p.db.Rebind(q), objn); err != nil {
 		if err == sql.ErrNoRows {
-			return AdminObjectCard{}, fmt.Errorf("object #%d not found", objn)
+			return AdminObjectCard{}, adminNotFoundf("object #%d not found", objn)
 		}
 		return AdminObjectCard{}, fmt.Errorf("failed to load object card: %w", err)
 	}
@@ -295,7 +297,7 @@ func (p *DBDataProvider) CreateObject(card AdminObjectCard) error {
 		return fmt.Errorf("failed to check object number uniqueness: %w", err)
 	}
 	if exists > 0 {
-		return fmt.Errorf("object number #%d already exists", normalized.ObjN)
+		return adminConflictf("object number #%d already exists", normalized.ObjN)
 	}
 
 	testControl := int64(0)
@@ -460,7 +462,7 @@ func (p *DBDataProvider) UpdateObject(card AdminObjectCard) error {
 			normalized.ObjN,
 		); err != nil {
 			if err == sql.ErrNoRows {
-				return fmt.Errorf("object #%d not found", normalized.ObjN)
+				return adminNotFoundf("object #%d not found", normalized.ObjN)
 			}
 			return fmt.Errorf("failed to resolve object UIN: %w", err)
 		}
@@ -477,7 +479,7 @@ func (p *DBDataProvider) UpdateObject(card AdminObjectCard) error {
 		return fmt.Errorf("failed to check object number uniqueness: %w", err)
 	}
 	if duplicateCount > 0 {
-		return fmt.Errorf("object number #%d already exists", normalized.ObjN)
+		return adminConflictf("object number #%d already exists", normalized.ObjN)
 	}
 
 	testControl := int64(0)
@@ -613,7 +615,7 @@ func (p *DBDataProvider) UpdateObject(card AdminObjectCard) error {
 
 func (p *DBDataProvider) DeleteObject(objn int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -635,7 +637,7 @@ func (p *DBDataProvider) DeleteObject(objn int64) error {
 		objn,
 	); err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("object #%d not found", objn)
+			return adminNotFoundf("object #%d not found", objn)
 		}
 		return fmt.Errorf("failed to resolve object UIN for delete: %w", err)
 	}
@@ -665,7 +667,7 @@ func (p *DBDataProvider) DeleteObject(objn int64) error {
 		if sbsbVal != "" {
 			parts = append(parts, fmt.Sprintf("SBSB=%s", sbsbVal))
 		}
-		return fmt.Errorf("об'єкт #%d прив'язаний до підсервера (%s). Спочатку відв'яжіть його у вікні \"Керування об'єктами підсерверів\" (або в картці об'єкта)", objn, strings.Join(parts, ", "))
+		return adminConflictf("об'єкт #%d прив'язаний до підсервера (%s). Спочатку відв'яжіть його у вікні \"Керування об'єктами підсерверів\" (або в картці об'єкта)", objn, strings.Join(parts, ", "))
 	}
 
 	var deletedName sql.NullString
@@ -742,7 +744,7 @@ func (p *DBDataProvider) DeleteObject(objn int64) error {
 
 func (p *DBDataProvider) ListObjectPersonals(objn int64) ([]AdminObjectPersonal, error) {
 	if objn <= 0 {
-		return nil, fmt.Errorf("invalid object number")
+		return nil, adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -944,7 +946,7 @@ func (p *DBDataProvider) FindObjectsBySIMPhone(phone string, excludeObjN *int64)
 
 func (p *DBDataProvider) AddObjectPersonal(objn int64, item AdminObjectPersonal) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	item = applyPersonalObjectNumberFallback(item, objn)
@@ -1034,10 +1036,10 @@ func (p *DBDataProvider) AddObjectPersonal(objn int64, item AdminObjectPersonal)
 
 func (p *DBDataProvider) UpdateObjectPersonal(objn int64, item AdminObjectPersonal) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if item.ID <= 0 {
-		return fmt.Errorf("invalid personal id")
+		return adminInvalidf("invalid personal id")
 	}
 
 	item = applyPersonalObjectNumberFallback(item, objn)
@@ -1046,7 +1048,7 @@ func (p *DBDataProvider) UpdateObjectPersonal(objn int64, item AdminObjectPerson
 		return err
 	}
 	if normalized.Number <= 0 {
-		return fmt.Errorf("personal number must be > 0")
+		return adminInvalidf("personal number must be > 0")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1113,17 +1115,17 @@ func (p *DBDataProvider) UpdateObjectPersonal(objn int64, item AdminObjectPerson
 		return fmt.Errorf("failed to update PERSONAL row: %w", err)
 	}
 	if affected, _ := res.RowsAffected(); affected == 0 {
-		return fmt.Errorf("personal id %d not found for object #%d", normalized.ID, objn)
+		return adminNotFoundf("personal id %d not found for object #%d", normalized.ID, objn)
 	}
 	return nil
 }
 
 func (p *DBDataProvider) DeleteObjectPersonal(objn int64, personalID int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if personalID <= 0 {
-		return fmt.Errorf("invalid personal id")
+		return adminInvalidf("invalid personal id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1140,14 +1142,14 @@ func (p *DBDataProvider) DeleteObjectPersonal(objn int64, personalID int64) erro
 		return fmt.Errorf("failed to delete PERSONAL row: %w", err)
 	}
 	if affected, _ := res.RowsAffected(); affected == 0 {
-		return fmt.Errorf("personal id %d not found for object #%d", personalID, objn)
+		return adminNotFoundf("personal id %d not found for object #%d", personalID, objn)
 	}
 	return nil
 }
 
 func (p *DBDataProvider) ListObjectZones(objn int64) ([]AdminObjectZone, error) {
 	if objn <= 0 {
-		return nil, fmt.Errorf("invalid object number")
+		return nil, adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1189,7 +1191,7 @@ func (p *DBDataProvider) ListObjectZones(objn int64) ([]AdminObjectZone, error)
 
 func (p *DBDataProvider) AddObjectZone(objn int64, zone AdminObjectZone) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	normalized, err := normalizeObjectZone(zone)
@@ -1218,7 +1220,7 @@ func (p *DBDataProvider) AddObjectZone(objn int64, zone AdminObjectZone) error {
 		return err
 	}
 	if dup {
-		return fmt.Errorf("zone #%d already exists for object #%d", normalized.ZoneNumber, objn)
+		return adminConflictf("zone #%d already exists for object #%d", normalized.ZoneNumber, objn)
 	}
 
 	const qIns = `
@@ -1249,10 +1251,10 @@ func (p *DBDataProvider) AddObjectZone(objn int64, zone AdminObjectZone) error {
 
 func (p *DBDataProvider) UpdateObjectZone(objn int64, zone AdminObjectZone) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if zone.ID <= 0 {
-		return fmt.Errorf("invalid zone id")
+		return adminInvalidf("invalid zone id")
 	}
 
 	normalized, err := normalizeObjectZone(zone)
@@ -1281,7 +1283,7 @@ func (p *DBDataProvider) UpdateObjectZone(objn int64, zone AdminObjectZone) erro
 		return err
 	}
 	if dup {
-		return fmt.Errorf("zone #%d already exists for object #%d", normalized.ZoneNumber, objn)
+		return adminConflictf("zone #%d already exists for object #%d", normalized.ZoneNumber, objn)
 	}
 
 	const qUpd = `
@@ -1306,7 +1308,7 @@ func (p *DBDataProvider) UpdateObjectZone(objn int64, zone AdminObjectZone) erro
 		return fmt.Errorf("failed to update ZONES row: %w", err)
 	}
 	if affected, _ := res.RowsAffected(); affected == 0 {
-		return fmt.Errorf("zone id %d not found for object #%d", normalized.ID, objn)
+		return adminNotFoundf("zone id %d not found for object #%d", normalized.ID, objn)
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -1317,10 +1319,10 @@ func (p *DBDataProvider) UpdateObjectZone(objn int64, zone AdminObjectZone) erro
 
 func (p *DBDataProvider) DeleteObjectZone(objn int64, zoneID int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if zoneID <= 0 {
-		return fmt.Errorf("invalid zone id")
+		return adminInvalidf("invalid zone id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1337,17 +1339,17 @@ func (p *DBDataProvider) DeleteObjectZone(objn int64, zoneID int64) error {
 		return fmt.Errorf("failed to delete ZONES row: %w", err)
 	}
 	if affected, _ := res.RowsAffected(); affected == 0 {
-		return fmt.Errorf("zone id %d not found for object #%d", zoneID, objn)
+		return adminNotFoundf("zone id %d not found for object #%d", zoneID, objn)
 	}
 	return nil
 }
 
 func (p *DBDataProvider) FillObjectZones(objn int64, count int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if count <= 0 || count > 1024 {
-		return fmt.Errorf("zone count must be in range 1..1024")
+		return adminInvalidf("zone count must be in range 1..1024")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1412,7 +1414,7 @@ func (p *DBDataProvider) FillObjectZones(objn int64, count int64) error {
 
 func (p *DBDataProvider) ClearObjectZones(objn int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1432,7 +1434,7 @@ func (p *DBDataProvider) ClearObjectZones(objn int64) error {
 
 func (p *DBDataProvider) GetObjectCoordinates(objn int64) (AdminObjectCoordinates, error) {
 	if objn <= 0 {
-		return AdminObjectCoordinates{}, fmt.Errorf("invalid object number")
+		return AdminObjectCoordinates{}, adminInvalidf("invalid object number")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1465,16 +1467,16 @@ func (p *DBDataProvider) GetObjectCoordinates(objn int64) (AdminObjectCoordinate
 
 func (p *DBDataProvider) SaveObjectCoordinates(objn int64, coords AdminObjectCoordinates) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	lat := strings.TrimSpace(coords.Latitude)
 	lon := strings.TrimSpace(coords.Longitude)
 	if len(lat) > 20 {
-		return fmt.Errorf("latitude length must be <= 20")
+		return adminInvalidf("latitude length must be <= 20")
 	}
 	if len(lon) > 20 {
-		return fmt.Errorf("longitude length must be <= 20")
+		return adminInvalidf("longitude length must be <= 20")
 	}
 	lat = coordinateOrZero(lat)
 	lon = coordinateOrZero(lon)
@@ -1558,35 +1560,35 @@ func normalizeAdminObjectCard(card AdminObjectCard) (AdminObjectCard, error) {
 	n.SubServerB = strings.TrimSpace(n.SubServerB)
 
 	if n.ObjN < 100 || n.ObjN > 99999 {
-		return AdminObjectCard{}, fmt.Errorf("object number must be in range 100..99999")
+		return AdminObjectCard{}, adminInvalidf("object number must be in range 100..99999")
 	}
 	if n.ShortName == "" {
-		return AdminObjectCard{}, fmt.Errorf("object short name is empty")
+		return AdminObjectCard{}, adminInvalidf("object short name is empty")
 	}
 	if n.FullName == "" {
 		n.FullName = n.ShortName
 	}
 	if n.ObjTypeID <= 0 {
-		return AdminObjectCard{}, fmt.Errorf("object type is required")
+		return AdminObjectCard{}, adminInvalidf("object type is required")
 	}
 	if n.GrpN <= 0 {
 		n.GrpN = 1
 	}
 	if n.ChannelCode < 0 {
-		return AdminObjectCard{}, fmt.Errorf("invalid channel code")
+		return AdminObjectCard{}, adminInvalidf("invalid channel code")
 	}
 	if n.GSMHiddenN < 0 || n.GSMHiddenN > 9999 {
-		return AdminObjectCard{}, fmt.Errorf("invalid hidden GPRS number")
+		return AdminObjectCard{}, adminInvalidf("invalid hidden GPRS number")
 	}
 	if n.ChannelCode == 5 {
 		if n.GSMHiddenN <= 0 {
-			return AdminObjectCard{}, fmt.Errorf("hidden GPRS number is required for channel 5")
+			return AdminObjectCard{}, adminInvalidf("hidden GPRS number is required for channel 5")
 		}
 	} else {
 		n.GSMHiddenN = 0
 	}
 	if n.PPKID < 0 {
-		return AdminObjectCard{}, fmt.Errorf("invalid PPK id")
+		return AdminObjectCard{}, adminInvalidf("invalid PPK id")
 	}
 
 	if n.TestControlEnabled {
@@ -1621,10 +1623,10 @@ func normalizeObjectPersonal(item AdminObjectPersonal) (AdminObjectPersonal, err
 	}
 
 	if n.Number < 0 || n.Number > 999 {
-		return AdminObjectPersonal{}, fmt.Errorf("personal number must be in range 0..999")
+		return AdminObjectPersonal{}, adminInvalidf("personal number must be in range 0..999")
 	}
 	if n.Surname == "" && n.Name == "" && n.SecName == "" {
-		return AdminObjectPersonal{}, fmt.Errorf("personal full name is empty")
+		return AdminObjectPersonal{}, adminInvalidf("personal full name is empty")
 	}
 
 	return n, nil
@@ -1662,7 +1664,7 @@ func normalizeObjectZone(zone AdminObjectZone) (AdminObjectZone, error) {
 	n.Description = strings.TrimSpace(n.Description)
 
 	if n.ZoneNumber <= 0 || n.ZoneNumber > 9999 {
-		return AdminObjectZone{}, fmt.Errorf("zone number must be in range 1..9999")
+		return AdminObjectZone{}, adminInvalidf("zone number must be in range 1..9999")
 	}
 	// За поточним ТЗ тип зони фіксований: "пож.".
 	n.ZoneType = 1
@@ -1686,7 +1688,7 @@ func (p *DBDataProvider) resolveObjectRefByObjN(ctx context.Context, objn int64)
 	var objRef objectRefRow
 	if err := p.db.GetContext(ctx, &objRef, p.db.Rebind(q), objn); err != nil {
 		if err == sql.ErrNoRows {
-			return objectRefRow{}, fmt.Errorf("object #%d not found", objn)
+			return objectRefRow{}, adminNotFoundf("object #%d not found", objn)
 		}
 		return objectRefRow{}, fmt.Errorf("failed to resolve object ref: %w", err)
 	}
@@ -1695,7 +1697,7 @@ func (p *DBDataProvider) resolveObjectRefByObjN(ctx context.Context, objn int64)
 
 func cleanupOrphanObjectMirrorRowsByObjNTx(ctx context.Context, tx *sqlx.Tx, db *sqlx.DB, objn int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 
 	queries := []string{
@@ -1748,7 +1750,7 @@ func resolveObjectRefByObjNTx(ctx context.Context, tx *sqlx.Tx, db *sqlx.DB, obj
 	var objRef objectRefRow
 	if err := tx.GetContext(ctx, &objRef, db.Rebind(q), objn); err != nil {
 		if err == sql.ErrNoRows {
-			return objectRefRow{}, fmt.Errorf("object #%d not found", objn)
+			return objectRefRow{}, adminNotFoundf("object #%d not found", objn)
 		}
 		return objectRefRow{}, fmt.Errorf("failed to resolve object ref: %w", err)
 	}
@@ -1820,7 +1822,7 @@ func resolveDefaultRegionIDTx(ctx context.Context, tx *sqlx.Tx, db *sqlx.DB, req
 		return 0, fmt.Errorf("failed to resolve default region id: %w", err)
 	}
 	if defaultRegionID <= 0 {
-		return 0, fmt.Errorf("invalid default region id")
+		return 0, adminInvalidf("invalid default region id")
 	}
 	return defaultRegionID, nil
 }
@@ -1968,11 +1970,46 @@ func formatDateTimePtr(t *time.Time) string {
 }
 
 // GenerateAcceptedObjectsReport generates the report for accepted objects
+// and publishes the workbook to the configured upload target.
 func (p *DBDataProvider) GenerateAcceptedObjectsReport(filePath string) error {
-	return export.GenerateAcceptedObjectsReport(p.db, filePath)
+	if err := export.GenerateAcceptedObjectsReport(p.db, filePath); err != nil {
+		return err
+	}
+	target, err := p.reportUploadTarget()
+	if err != nil {
+		return &export.ReportUploadError{Err: err}
+	}
+	_, err = export.PublishReport(context.Background(), target, filePath, acceptedObjectsReportFolder)
+	return err
 }
 
 // AppendObjectToDeletedReport appends an object to the deleted report
 func (p *DBDataProvider) AppendObjectToDeletedReport(obj *models.Object, contacts []models.Contact, pdfFilePath string, filePath string) error {
-	return export.AppendObjectToDeletedXLSX(obj, contacts, pdfFilePath, filePath)
+	cfg := p.reportUploadConfig()
+	if !cfg.TargetSelected() {
+		// Звіт видалених завжди публікувався в Google Drive; без обраної цілі так і лишається.
+		cfg.Target = config.ReportUploadTargetGoogleDrive
+	}
+	target, err := reportupload.New(cfg)
+	if err != nil {
+		if appendErr := export.AppendObjectToDeletedXLSX(obj, contacts, pdfFilePath, filePath, nil); appendErr != nil {
+			return appendErr
+		}
+		return &export.ReportUploadError{Err: err}
+	}
+	return export.AppendObjectToDeletedXLSX(obj, contacts, pdfFilePath, filePath, target)
+}
+
+const acceptedObjectsReportFolder = "Звіти"
+
+// reportUploadTarget builds the upload target from current settings; nil means uploads are disabled.
+func (p *DBDataProvider) reportUploadTarget() (reportupload.Target, error) {
+	return reportupload.New(p.reportUploadConfig())
+}
+
+func (p *DBDataProvider) reportUploadConfig() config.ReportUploadConfig {
+	if p.reportUpload != nil {
+		return p.reportUpload.LoadReportUploadConfig()
+	}
+	return config.LoadReportUploadConfig(nil)
 }
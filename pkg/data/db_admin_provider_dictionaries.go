@@ -34,7 +34,7 @@ func (p *DBDataProvider) ListObjectTypes() ([]DictionaryItem, error) {
 func (p *DBDataProvider) AddObjectType(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("name is empty")
+		return adminInvalidf("name is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -50,10 +50,10 @@ func (p *DBDataProvider) AddObjectType(name string) error {
 func (p *DBDataProvider) UpdateObjectType(id int64, name string) error {
 	name = strings.TrimSpace(name)
 	if id <= 0 {
-		return fmt.Errorf("invalid object type id")
+		return adminInvalidf("invalid object type id")
 	}
 	if name == "" {
-		return fmt.Errorf("name is empty")
+		return adminInvalidf("name is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -68,7 +68,7 @@ func (p *DBDataProvider) UpdateObjectType(id int64, name string) error {
 
 func (p *DBDataProvider) DeleteObjectType(id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("invalid object type id")
+		return adminInvalidf("invalid object type id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -110,7 +110,7 @@ func (p *DBDataProvider) ListRegions() ([]DictionaryItem, error) {
 func (p *DBDataProvider) AddRegion(name string, regionCode *int64) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("region name is empty")
+		return adminInvalidf("region name is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -134,10 +134,10 @@ func (p *DBDataProvider) AddRegion(name string, regionCode *int64) error {
 func (p *DBDataProvider) UpdateRegion(id int64, name string, regionCode *int64) error {
 	name = strings.TrimSpace(name)
 	if id <= 0 {
-		return fmt.Errorf("invalid region id")
+		return adminInvalidf("invalid region id")
 	}
 	if name == "" {
-		return fmt.Errorf("region name is empty")
+		return adminInvalidf("region name is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -160,7 +160,7 @@ func (p *DBDataProvider) UpdateRegion(id int64, name string, regionCode *int64)
 
 func (p *DBDataProvider) DeleteRegion(id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("invalid region id")
+		return adminInvalidf("invalid region id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -226,7 +226,7 @@ func (p *DBDataProvider) ListAlarmReasons() ([]DictionaryItem, error) {
 func (p *DBDataProvider) AddAlarmReason(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("reason is empty")
+		return adminInvalidf("reason is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -242,10 +242,10 @@ func (p *DBDataProvider) AddAlarmReason(name string) error {
 func (p *DBDataProvider) UpdateAlarmReason(id int64, name string) error {
 	name = strings.TrimSpace(name)
 	if id <= 0 {
-		return fmt.Errorf("invalid reason id")
+		return adminInvalidf("invalid reason id")
 	}
 	if name == "" {
-		return fmt.Errorf("reason is empty")
+		return adminInvalidf("reason is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -260,7 +260,7 @@ func (p *DBDataProvider) UpdateAlarmReason(id int64, name string) error {
 
 func (p *DBDataProvider) DeleteAlarmReason(id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("invalid reason id")
+		return adminInvalidf("invalid reason id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -294,7 +294,7 @@ func (p *DBDataProvider) MoveAlarmReason(id int64, direction int) error {
 		}
 	}
 	if idx == -1 {
-		return fmt.Errorf("reason id %d not found", id)
+		return adminNotFoundf("reason id %d not found", id)
 	}
 
 	targetIdx := idx + direction
@@ -381,13 +381,13 @@ func (p *DBDataProvider) ListPPKConstructor() ([]PPKConstructorItem, error) {
 func (p *DBDataProvider) AddPPKConstructor(name string, channel int64, zoneCount int64) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("PPK name is empty")
+		return adminInvalidf("PPK name is empty")
 	}
 	if channel < 0 {
-		return fmt.Errorf("invalid channel code")
+		return adminInvalidf("invalid channel code")
 	}
 	if zoneCount <= 0 {
-		return fmt.Errorf("zone count must be > 0")
+		return adminInvalidf("zone count must be > 0")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -409,16 +409,16 @@ func (p *DBDataProvider) AddPPKConstructor(name string, channel int64, zoneCount
 func (p *DBDataProvider) UpdatePPKConstructor(id int64, name string, channel int64, zoneCount int64) error {
 	name = strings.TrimSpace(name)
 	if id <= 0 {
-		return fmt.Errorf("invalid PPK id")
+		return adminInvalidf("invalid PPK id")
 	}
 	if name == "" {
-		return fmt.Errorf("PPK name is empty")
+		return adminInvalidf("PPK name is empty")
 	}
 	if channel < 0 {
-		return fmt.Errorf("invalid channel code")
+		return adminInvalidf("invalid channel code")
 	}
 	if zoneCount <= 0 {
-		return fmt.Errorf("zone count must be > 0")
+		return adminInvalidf("zone count must be > 0")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -437,7 +437,7 @@ func (p *DBDataProvider) UpdatePPKConstructor(id int64, name string, channel int
 
 func (p *DBDataProvider) DeletePPKConstructor(id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("invalid PPK id")
+		return adminInvalidf("invalid PPK id")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -102,7 +102,7 @@ func (p *DBDataProvider) ListMessages(protocolID *int64, filter string) ([]Admin
 
 func (p *DBDataProvider) SetMessageAdminOnly(uin int64, adminOnly bool) error {
 	if uin <= 0 {
-		return fmt.Errorf("invalid message uin")
+		return adminInvalidf("invalid message uin")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -122,7 +122,7 @@ func (p *DBDataProvider) SetMessageAdminOnly(uin int64, adminOnly bool) error {
 
 func (p *DBDataProvider) SetMessageCategory(uin int64, sc1 *int64) error {
 	if uin <= 0 {
-		return fmt.Errorf("invalid message uin")
+		return adminInvalidf("invalid message uin")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -223,11 +223,11 @@ func (p *DBDataProvider) List220VMessageBuckets(protocolIDs []int64, filter stri
 
 func (p *DBDataProvider) SetMessage220VMode(uin int64, mode Admin220VMode) error {
 	if uin <= 0 {
-		return fmt.Errorf("invalid message uin")
+		return adminInvalidf("invalid message uin")
 	}
 
 	if mode != Admin220VNone && mode != Admin220VAlarm && mode != Admin220VRestore {
-		return fmt.Errorf("invalid 220v mode")
+		return adminInvalidf("invalid 220v mode")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -326,13 +326,13 @@ func resolveDisplayBlockMode(guardState *int64, eng1 *int64, blockedArmed *int16
 
 func (p *DBDataProvider) SetDisplayBlockMode(objn int64, mode DisplayBlockMode) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if objn <= 1000 {
-		return fmt.Errorf("object number must be > 1000 for display blocking")
+		return adminInvalidf("object number must be > 1000 for display blocking")
 	}
 	if mode < DisplayBlockNone || mode > DisplayBlockDebug {
-		return fmt.Errorf("invalid display block mode: %d", mode)
+		return adminInvalidf("invalid display block mode: %d", mode)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -365,7 +365,7 @@ func (p *DBDataProvider) SetDisplayBlockMode(objn int64, mode DisplayBlockMode)
 	`
 	if err := tx.GetContext(ctx, &current, p.db.Rebind(qCurrent), objn); err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("object #%d not found", objn)
+			return adminNotFoundf("object #%d not found", objn)
 		}
 		return fmt.Errorf("failed to load current display-block mode: %w", err)
 	}
@@ -557,7 +557,7 @@ func resolveMessUINByProtAndMessIDTx(ctx context.Context, tx *sqlx.Tx, db *sqlx.
 	`
 	if err := tx.GetContext(ctx, &uin, db.Rebind(qFallback), messID); err != nil {
 		if err == sql.ErrNoRows {
-			return 0, fmt.Errorf("message UIN not found for MESSID=%d", messID)
+			return 0, adminNotFoundf("message UIN not found for MESSID=%d", messID)
 		}
 		return 0, fmt.Errorf("failed to resolve message UIN fallback for MESSID=%d: %w", messID, err)
 	}
@@ -1445,13 +1445,13 @@ func (p *DBDataProvider) CollectObjectStatistics(filter AdminStatisticsFilter, l
 
 func (p *DBDataProvider) EmulateEvent(objn int64, zone int64, messageUIN int64) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if zone < 0 {
-		return fmt.Errorf("invalid zone number")
+		return adminInvalidf("invalid zone number")
 	}
 	if messageUIN <= 0 {
-		return fmt.Errorf("invalid message UIN")
+		return adminInvalidf("invalid message UIN")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -1473,7 +1473,7 @@ func (p *DBDataProvider) EmulateEvent(objn int64, zone int64, messageUIN int64)
 	`
 	if err := tx.GetContext(ctx, &objectRef, p.db.Rebind(qObj), objn); err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("object #%d not found", objn)
+			return adminNotFoundf("object #%d not found", objn)
 		}
 		return fmt.Errorf("failed to load object for emulation: %w", err)
 	}
@@ -95,15 +95,15 @@ func (p *DBDataProvider) ListSubServerObjects(filter string) ([]AdminSubServerOb
 
 func (p *DBDataProvider) SetObjectSubServer(objn int64, channel int, bind string) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if channel != 1 && channel != 2 {
-		return fmt.Errorf("invalid subserver channel")
+		return adminInvalidf("invalid subserver channel")
 	}
 
 	bind = strings.TrimSpace(bind)
 	if bind == "" {
-		return fmt.Errorf("subserver bind is empty")
+		return adminInvalidf("subserver bind is empty")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
@@ -125,7 +125,7 @@ func (p *DBDataProvider) SetObjectSubServer(objn int64, channel int, bind string
 		return fmt.Errorf("failed to validate subserver bind: %w", err)
 	}
 	if exists <= 0 {
-		return fmt.Errorf("subserver bind %q not found", bind)
+		return adminNotFoundf("subserver bind %q not found", bind)
 	}
 
 	col := "SBSA"
@@ -146,10 +146,10 @@ func (p *DBDataProvider) SetObjectSubServer(objn int64, channel int, bind string
 
 func (p *DBDataProvider) ClearObjectSubServer(objn int64, channel int) error {
 	if objn <= 0 {
-		return fmt.Errorf("invalid object number")
+		return adminInvalidf("invalid object number")
 	}
 	if channel != 1 && channel != 2 {
-		return fmt.Errorf("invalid subserver channel")
+		return adminInvalidf("invalid subserver channel")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), adminQueryTimeout)
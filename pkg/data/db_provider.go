@@ -36,6 +36,10 @@ type DBDataProvider struct {
 
 	vodafone *VodafoneService
 	kyivstar *KyivstarService
+
+	reportUpload config.ReportUploadConfigStore
+
+	alarmLeases *alarmLeaseState
 }
 
 type dbEventState struct {
@@ -64,9 +68,20 @@ func WithKyivstarConfigStore(store config.KyivstarConfigStore) DBProviderOption
 	}
 }
 
+// WithReportUploadConfigStore задає джерело налаштувань цілі, куди публікуються згенеровані звіти.
+func WithReportUploadConfigStore(store config.ReportUploadConfigStore) DBProviderOption {
+	return func(p *DBDataProvider) {
+		if p == nil || store == nil {
+			return
+		}
+		p.reportUpload = store
+	}
+}
+
 func NewDBDataProvider(db *sqlx.DB, baseDSN string, opts ...DBProviderOption) *DBDataProvider {
 	provider := &DBDataProvider{db: db, baseDSN: baseDSN}
 	provider.eventState.Store(&dbEventState{})
+	provider.alarmLeases = newAlarmLeaseState(db)
 	for _, opt := range opts {
 		if opt != nil {
 			opt(provider)
@@ -494,26 +509,34 @@ func (p *DBDataProvider) GetObjectEvents(objectID string) []models.Event {
 }
 
 func (p *DBDataProvider) GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event {
-	if p.db == nil {
+	events, err := p.QueryObjectEventsRange(context.Background(), objectID, from, to)
+	if err != nil {
+		log.Error().Err(err).Str("objectID", objectID).Msg("Помилка отримання журналу об'єкта за період")
 		return nil
 	}
+	return events
+}
+
+// QueryObjectEventsRange implements contracts.ObjectEventsRangeQueryProvider.
+func (p *DBDataProvider) QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
+	if p.db == nil {
+		return nil, fmt.Errorf("database connection is not initialized")
+	}
 	id, err := strconv.ParseInt(objectID, 10, 64)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("невірний ID об'єкта %q: %w", objectID, err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	row, err := database.GetObjectDetail(ctx, p.db, id)
 	if err != nil {
-		log.Error().Err(err).Int64("objn", id).Msg("Помилка отримання деталей об'єкта для журналу за період")
-		return nil
+		return nil, fmt.Errorf("деталі об'єкта %d: %w", id, err)
 	}
 	rows, err := database.GetObjectEventsRange(ctx, p.db, row.ObjUin, from, to)
 	if err != nil {
-		log.Error().Err(err).Int64("objn", id).Msg("Помилка отримання журналу об'єкта за період")
-		return nil
+		return nil, fmt.Errorf("журнал об'єкта %d: %w", id, err)
 	}
-	return mapDBEventRows(rows, int(id))
+	return mapDBEventRows(rows, int(id)), nil
 }
 
 func (p *DBDataProvider) GetAlarmSourceMessages(alarm models.Alarm) []models.AlarmMsg {
@@ -545,13 +568,19 @@ func maxDBEventRowID(rows []database.EventRow, current int64) int64 {
 
 // GetAlarms отримує список активних тривог (оптимізовано)
 func (p *DBDataProvider) GetAlarms() []models.Alarm {
+	return p.GetAlarmsContext(context.Background())
+}
+
+// GetAlarmsContext отримує активні тривоги. Клієнт сервера оператора з parent
+// визначає, які оренди тривог позначаються як власні і продовжуються.
+func (p *DBDataProvider) GetAlarmsContext(parent context.Context) []models.Alarm {
 	if p.db == nil {
 		log.Warn().Msg("Спроба отримати тривоги без активного з'єднання БД")
 		return nil
 	}
 
 	log.Debug().Msg("Завантаження активних тривог з БД...")
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 3*time.Second)
 	defer cancel()
 
 	rows, err := database.GetAlarmsList(ctx, p.db)
@@ -639,6 +668,8 @@ func (p *DBDataProvider) GetAlarms() []models.Alarm {
 		return left.After(right)
 	})
 
+	p.overlayBridgeAlarmLeases(ctx, alarms)
+
 	log.Debug().Int("alarmsCount", len(alarms)).Msg("Тривоги завантажено")
 	return alarms
 }
@@ -924,7 +955,11 @@ func (p *DBDataProvider) ProcessAlarmWithRequest(ctx context.Context, alarm mode
 		return fmt.Errorf("bridge alarm process delete: %w", err)
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.releaseBridgeAlarmLease(ctx, alarm.ID)
+	return nil
 }
 
 // GroupProcessAlarm implements contracts.AlarmGroupProcessProvider for Bridge group finish.
@@ -968,11 +1003,10 @@ func (p *DBDataProvider) GroupProcessAlarm(ctx context.Context, alarm models.Ala
 		return fmt.Errorf("bridge group alarm process delete: %w", err)
 	}
 
-	return tx.Commit()
-}
-
-// PickAlarm implements contracts.AlarmTakeoverProvider as a no-op for Bridge (no DB state needed).
-func (p *DBDataProvider) PickAlarm(_ context.Context, _ models.Alarm, _ string) error {
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.releaseBridgeAlarmLease(ctx, alarm.ID)
 	return nil
 }
 
@@ -1222,6 +1256,9 @@ func mapObjectRowToModel(row database.ObjectInfoRow) models.Object {
 		SubServerB:    subServerB,
 		LaunchDate:    ptrToString(row.ReservText),
 
+		ObjTypeID:        ptrToInt64(row.ObjTypeId),
+		ContractPriority: int(ptrToInt64(row.ObjPriority1)),
+
 		AlarmState:        ptrToInt64(row.AlarmState1),
 		GuardState:        state.guardState,
 		TechAlarmState:    ptrToInt64(row.TechAlarmState1),
@@ -1232,6 +1269,11 @@ func mapObjectRowToModel(row database.ObjectInfoRow) models.Object {
 		MonitoringStatus:  state.monitoringStatus,
 		IsUnderGuard:      state.guardStatus == models.GuardStatusGuarded,
 		IsConnOK:          state.connectionStatus == models.ConnectionStatusOnline,
+
+		TestControl:   ptrToInt64(row.TestControl1),
+		TestTime:      ptrToInt64(row.TestTime1),
+		AutoTestHours: int(ptrToInt64(row.TestTime1)) / 60,
+		LastTestTime:  ptrToTime(row.LastTestTime1),
 	}
 }
 
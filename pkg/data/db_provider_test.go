@@ -280,6 +280,21 @@ func TestMapObjectRowToModel_UsesFullNameWithShortNameFallback(t *testing.T) {
 	}
 }
 
+func TestMapObjectRowToModel_KeepsTypeAndContractPriority(t *testing.T) {
+	t.Parallel()
+
+	objType := int64(5)
+	priority := int64(3)
+	obj := mapObjectRowToModel(database.ObjectInfoRow{
+		Objn:         1001,
+		ObjTypeId:    &objType,
+		ObjPriority1: &priority,
+	})
+	if obj.ObjTypeID != 5 || obj.ContractPriority != 3 {
+		t.Fatalf("ObjTypeID = %d, ContractPriority = %d, want 5 and 3", obj.ObjTypeID, obj.ContractPriority)
+	}
+}
+
 func TestMapObjectDetailRowToModel_NormalizesBridgeState(t *testing.T) {
 	t.Parallel()
 
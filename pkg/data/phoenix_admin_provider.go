@@ -0,0 +1,1001 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/utils"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	phoenixAdminTimeout    = 10 * time.Second
+	phoenixPanelIDMaxLen   = 15
+	phoenixZoneIDGroupBase = 100_000
+	phoenixMaxFillZones    = 999
+)
+
+// PhoenixAdminProvider записує картку панелі, зони та відповідальних у БД Phoenix.
+// Кожна операція виконується однією транзакцією MS SQL; після фіксації зміна
+// анонсується центру керування.
+type PhoenixAdminProvider struct {
+	phoenix *PhoenixDataProvider
+}
+
+// NewPhoenixAdminProvider створює адміністратор панелей поверх Phoenix-провайдера.
+func NewPhoenixAdminProvider(phoenix *PhoenixDataProvider) *PhoenixAdminProvider {
+	return &PhoenixAdminProvider{phoenix: phoenix}
+}
+
+// PhoenixObjectAdmin повертає адміністратор панелей Phoenix.
+func (p *PhoenixDataProvider) PhoenixObjectAdmin() contracts.PhoenixObjectAdminProvider {
+	if p == nil || p.db == nil {
+		return nil
+	}
+	return NewPhoenixAdminProvider(p)
+}
+
+var _ contracts.PhoenixObjectAdminProvider = (*PhoenixAdminProvider)(nil)
+
+type phoenixAdminCardRow struct {
+	PanelID     string         `db:"panel_id"`
+	GroupNo     int64          `db:"group_no"`
+	GroupName   sql.NullString `db:"group_name"`
+	CompanyID   sql.NullInt64  `db:"company_id"`
+	CompanyName sql.NullString `db:"company_name"`
+	Address     sql.NullString `db:"company_address"`
+	Telephones  sql.NullString `db:"telephones"`
+	Remarks     sql.NullString `db:"remarks"`
+	TechInfo    sql.NullString `db:"technical_information"`
+	CreateDate  sql.NullTime   `db:"create_date"`
+}
+
+type phoenixAdminZoneRow struct {
+	GroupNo  int64          `db:"group_no"`
+	ZoneNo   int64          `db:"zone_no"`
+	Name     sql.NullString `db:"zone_name"`
+	ZoneType sql.NullInt64  `db:"zone_type_id"`
+}
+
+type phoenixAdminResponsibleRow struct {
+	ResponsibleID int64          `db:"responsible_id"`
+	GroupNo       int64          `db:"group_no"`
+	Number        sql.NullInt64  `db:"responsible_number"`
+	ListID        int64          `db:"list_id"`
+	Name          sql.NullString `db:"responsible_name"`
+	Address       sql.NullString `db:"responsible_address"`
+}
+
+type phoenixAdminPhoneRow struct {
+	ResponsibleID int64          `db:"responsible_id"`
+	TelID         int64          `db:"tel_id"`
+	Phone         sql.NullString `db:"phone"`
+	Description   sql.NullString `db:"description"`
+	CallOrder     sql.NullInt64  `db:"call_order"`
+}
+
+const phoenixAdminCardQuery = `
+SELECT TOP (1)
+	P.Panel_id AS panel_id,
+	G.Group_ AS group_no,
+	G.Message AS group_name,
+	G.CompanyID AS company_id,
+	C.CompanyName AS company_name,
+	C.Address AS company_address,
+	C.Telephones AS telephones,
+	P.Remarks AS remarks,
+	P.AdditionalTechnicalInformation AS technical_information,
+	P.CreateDate AS create_date
+FROM Panel P
+INNER JOIN Groups G ON G.Panel_id = P.Panel_id
+LEFT JOIN Company C ON C.ID = G.CompanyID
+WHERE P.Panel_id = @p1 AND (@p2 = 0 OR G.Group_ = @p2)
+ORDER BY G.Group_
+`
+
+const phoenixAdminZonesQuery = `
+SELECT
+	Z.Group_ AS group_no,
+	Z.Zone AS zone_no,
+	Z.Message AS zone_name,
+	Z.RadioZoneTypeid AS zone_type_id
+FROM Zones Z
+WHERE Z.Panel_id = @p1
+ORDER BY Z.Group_, Z.Zone
+`
+
+const phoenixAdminResponsiblesQuery = `
+SELECT
+	R.Responsible_id AS responsible_id,
+	R.Group_ AS group_no,
+	R.Responsible_Number AS responsible_number,
+	R.ResponsiblesList_id AS list_id,
+	RL.Responsible_Name AS responsible_name,
+	RL.Responsible_Address AS responsible_address
+FROM Responsibles R
+INNER JOIN ResponsiblesList RL ON RL.ResponsiblesList_id = R.ResponsiblesList_id
+WHERE R.panel_id = @p1
+ORDER BY R.Group_, R.Responsible_Number, R.Responsible_id
+`
+
+const phoenixAdminPhonesQuery = `
+SELECT
+	R.Responsible_id AS responsible_id,
+	RT.ResponsibleTel_id AS tel_id,
+	RT.PhoneNo AS phone,
+	RTD.Description AS description,
+	RTD.CallOrder AS call_order
+FROM Responsibles R
+INNER JOIN ResponsibleTel RT ON RT.ResponsiblesList_id = R.ResponsiblesList_id
+LEFT JOIN ResponsibleTelDescription RTD ON
+	RTD.Responsible_id = R.Responsible_id
+	AND RTD.ResponsibleTel_id = RT.ResponsibleTel_id
+WHERE R.panel_id = @p1
+ORDER BY R.Responsible_id, ISNULL(RTD.CallOrder, 2147483647), RT.ResponsibleTel_id
+`
+
+// GetObjectCard читає картку панелі: основна (найменша) група, компанія та примітки панелі.
+func (a *PhoenixAdminProvider) GetObjectCard(objn int64) (contracts.AdminObjectCard, error) {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return contracts.AdminObjectCard{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), phoenixAdminTimeout)
+	defer cancel()
+
+	var row phoenixAdminCardRow
+	if err := a.phoenix.db.GetContext(ctx, &row, phoenixAdminCardQuery, panelID, 0); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return contracts.AdminObjectCard{}, adminNotFoundf("phoenix: панель %s не знайдена", panelID)
+		}
+		return contracts.AdminObjectCard{}, fmt.Errorf("phoenix: картка панелі %s: %w", panelID, err)
+	}
+	return phoenixAdminCardFromRow(row, int64(a.phoenix.registerPanelID(row.PanelID))), nil
+}
+
+func phoenixAdminCardFromRow(row phoenixAdminCardRow, objn int64) contracts.AdminObjectCard {
+	card := contracts.AdminObjectCard{
+		ObjN:      objn,
+		GrpN:      row.GroupNo,
+		PanelID:   strings.TrimSpace(row.PanelID),
+		ShortName: strings.TrimSpace(nullString(row.GroupName)),
+		FullName:  strings.TrimSpace(nullString(row.CompanyName)),
+		Address:   strings.TrimSpace(nullString(row.Address)),
+		Phones:    strings.TrimSpace(nullString(row.Telephones)),
+		Notes:     strings.TrimSpace(nullString(row.Remarks)),
+		Location:  strings.TrimSpace(nullString(row.TechInfo)),
+	}
+	if row.CreateDate.Valid {
+		card.StartDate = row.CreateDate.Time.Format("02.01.2006")
+	}
+	return card
+}
+
+// CreateObject створює панель з однією групою та компанією.
+func (a *PhoenixAdminProvider) CreateObject(card contracts.AdminObjectCard) error {
+	panelID, err := normalizePhoenixPanelID(card.PanelID)
+	if err != nil {
+		return err
+	}
+	if err := validatePhoenixAdminCard(card); err != nil {
+		return err
+	}
+	groupNo := max(card.GrpN, 1)
+	operator, err := a.operatorName()
+	if err != nil {
+		return err
+	}
+
+	err = a.inTx(func(ctx context.Context, tx *sqlx.Tx) error {
+		var exists int
+		if err := tx.GetContext(ctx, &exists, `SELECT COUNT(1) FROM Panel WITH (UPDLOCK, HOLDLOCK) WHERE Panel_id = @p1`, panelID); err != nil {
+			return err
+		}
+		if exists > 0 {
+			return adminConflictf("панель %s уже існує", panelID)
+		}
+		companyID, err := insertPhoenixCompany(ctx, tx, card, operator)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+INSERT INTO Panel (Panel_id, CreateDate, DateLastChange, Disabled, TestPanel, Remarks, AdditionalTechnicalInformation, UserName)
+VALUES (@p1, GETDATE(), GETDATE(), 0, 0, @p2, @p3, @p4)`,
+			panelID, strings.TrimSpace(card.Notes), strings.TrimSpace(card.Location), operator,
+		); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+INSERT INTO Groups (Panel_id, Group_, Message, IsOpen, TimeEvent, disabled, CompanyID)
+VALUES (@p1, @p2, @p3, 0, GETDATE(), 0, @p4)`,
+			panelID, groupNo, phoenixAdminGroupName(card), companyID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("phoenix: створення панелі %s: %w", panelID, err)
+	}
+	a.phoenix.registerPanelID(panelID)
+	a.announce(panelID, operator)
+	return nil
+}
+
+// UpdateObject оновлює групу, компанію та примітки панелі.
+func (a *PhoenixAdminProvider) UpdateObject(card contracts.AdminObjectCard) error {
+	panelID, err := a.cardPanelID(card)
+	if err != nil {
+		return err
+	}
+	if err := validatePhoenixAdminCard(card); err != nil {
+		return err
+	}
+	operator, err := a.operatorName()
+	if err != nil {
+		return err
+	}
+
+	err = a.inTx(func(ctx context.Context, tx *sqlx.Tx) error {
+		var row phoenixAdminCardRow
+		if err := tx.GetContext(ctx, &row, phoenixAdminCardQuery, panelID, max(card.GrpN, 0)); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return adminNotFoundf("панель або група %d не знайдена", card.GrpN)
+			}
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+UPDATE Panel
+SET Remarks = @p2, AdditionalTechnicalInformation = @p3, DateLastChange = GETDATE(), UserName = @p4
+WHERE Panel_id = @p1`,
+			panelID, strings.TrimSpace(card.Notes), strings.TrimSpace(card.Location), operator,
+		); err != nil {
+			return err
+		}
+		companyID := row.CompanyID.Int64
+		if row.CompanyID.Valid {
+			if _, err := tx.ExecContext(ctx, `
+UPDATE Company
+SET CompanyName = @p2, Address = @p3, Telephones = @p4, LastEditDate = GETDATE(), UserName = @p5
+WHERE ID = @p1`,
+				companyID, phoenixAdminCompanyName(card), strings.TrimSpace(card.Address), strings.TrimSpace(card.Phones), operator,
+			); err != nil {
+				return err
+			}
+		} else {
+			inserted, err := insertPhoenixCompany(ctx, tx, card, operator)
+			if err != nil {
+				return err
+			}
+			companyID = inserted
+		}
+		_, err := tx.ExecContext(ctx, `
+UPDATE Groups SET Message = @p3, CompanyID = @p4
+WHERE Panel_id = @p1 AND Group_ = @p2`,
+			panelID, row.GroupNo, phoenixAdminGroupName(card), companyID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("phoenix: оновлення панелі %s: %w", panelID, err)
+	}
+	a.announce(panelID, operator)
+	return nil
+}
+
+// DeleteObject не підтримується: панель зі своїм архівом видаляється лише клієнтом Phoenix.
+func (a *PhoenixAdminProvider) DeleteObject(objn int64) error {
+	return fmt.Errorf("phoenix: видалення панелей виконується в клієнті Phoenix; тут панель можна лише вимкнути")
+}
+
+// FindObjectsBySIMPhone шукає SIM-номер серед панелей Phoenix.
+func (a *PhoenixAdminProvider) FindObjectsBySIMPhone(phone string, excludeObjN *int64) ([]contracts.AdminSIMPhoneUsage, error) {
+	return a.phoenix.FindObjectsBySIMPhone(phone, excludeObjN)
+}
+
+// ListObjectZones повертає зони всіх груп панелі.
+func (a *PhoenixAdminProvider) ListObjectZones(objn int64) ([]contracts.AdminObjectZone, error) {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), phoenixAdminTimeout)
+	defer cancel()
+
+	var rows []phoenixAdminZoneRow
+	if err := a.phoenix.db.SelectContext(ctx, &rows, phoenixAdminZonesQuery, panelID); err != nil {
+		return nil, fmt.Errorf("phoenix: зони панелі %s: %w", panelID, err)
+	}
+	zones := make([]contracts.AdminObjectZone, 0, len(rows))
+	for _, row := range rows {
+		zones = append(zones, contracts.AdminObjectZone{
+			ID:          phoenixZoneID(row.GroupNo, row.ZoneNo),
+			ZoneNumber:  row.ZoneNo,
+			ZoneType:    row.ZoneType.Int64,
+			Description: strings.TrimSpace(nullString(row.Name)),
+		})
+	}
+	return zones, nil
+}
+
+// AddObjectZone додає зону до основної групи панелі.
+func (a *PhoenixAdminProvider) AddObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	if zone.ZoneNumber <= 0 {
+		return adminInvalidf("phoenix: номер зони має бути більшим за 0")
+	}
+	return a.mutate(panelID, "додавання зони", func(ctx context.Context, tx *sqlx.Tx) error {
+		groupNo := phoenixZoneGroup(zone.ID)
+		if groupNo == 0 {
+			var err error
+			if groupNo, err = phoenixMainGroup(ctx, tx, panelID); err != nil {
+				return err
+			}
+		}
+		if err := ensurePhoenixZoneFree(ctx, tx, panelID, groupNo, zone.ZoneNumber); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+INSERT INTO Zones (Panel_id, Zone, Group_, Message, Status, IsPatrol, IsAlarmButton, IsBypass, RadioZoneTypeid)
+VALUES (@p1, @p2, @p3, @p4, 0, 0, 0, 0, @p5)`,
+			panelID, zone.ZoneNumber, groupNo, strings.TrimSpace(zone.Description), phoenixNullableID(zone.ZoneType),
+		)
+		return err
+	})
+}
+
+// UpdateObjectZone змінює номер, назву або тип зони в межах її групи.
+func (a *PhoenixAdminProvider) UpdateObjectZone(objn int64, zone contracts.AdminObjectZone) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	groupNo, zoneNo := phoenixZoneGroup(zone.ID), phoenixZoneNumber(zone.ID)
+	if zoneNo <= 0 || zone.ZoneNumber <= 0 {
+		return adminInvalidf("phoenix: невалідна зона %d", zone.ID)
+	}
+	return a.mutate(panelID, "оновлення зони", func(ctx context.Context, tx *sqlx.Tx) error {
+		if zone.ZoneNumber != zoneNo {
+			if err := ensurePhoenixZoneFree(ctx, tx, panelID, groupNo, zone.ZoneNumber); err != nil {
+				return err
+			}
+		}
+		result, err := tx.ExecContext(ctx, `
+UPDATE Zones SET Zone = @p4, Message = @p5, RadioZoneTypeid = @p6
+WHERE Panel_id = @p1 AND Group_ = @p2 AND Zone = @p3`,
+			panelID, groupNo, zoneNo, zone.ZoneNumber, strings.TrimSpace(zone.Description), phoenixNullableID(zone.ZoneType),
+		)
+		if err != nil {
+			return err
+		}
+		return requirePhoenixRows(result, fmt.Sprintf("зона %d групи %d не знайдена", zoneNo, groupNo))
+	})
+}
+
+// DeleteObjectZone видаляє зону разом з прив'язками камер.
+func (a *PhoenixAdminProvider) DeleteObjectZone(objn int64, zoneID int64) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	groupNo, zoneNo := phoenixZoneGroup(zoneID), phoenixZoneNumber(zoneID)
+	return a.mutate(panelID, "видалення зони", func(ctx context.Context, tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM Zones_IPCameras WHERE Panel_Id = @p1 AND Group_ = @p2 AND Zone = @p3`, panelID, groupNo, zoneNo); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, `DELETE FROM Zones WHERE Panel_id = @p1 AND Group_ = @p2 AND Zone = @p3`, panelID, groupNo, zoneNo)
+		if err != nil {
+			return err
+		}
+		return requirePhoenixRows(result, fmt.Sprintf("зона %d групи %d не знайдена", zoneNo, groupNo))
+	})
+}
+
+// FillObjectZones додає до основної групи відсутні зони 1..count.
+func (a *PhoenixAdminProvider) FillObjectZones(objn int64, count int64) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	if count <= 0 || count > phoenixMaxFillZones {
+		return adminInvalidf("phoenix: кількість зон має бути від 1 до %d", phoenixMaxFillZones)
+	}
+	return a.mutate(panelID, "заповнення зон", func(ctx context.Context, tx *sqlx.Tx) error {
+		groupNo, err := phoenixMainGroup(ctx, tx, panelID)
+		if err != nil {
+			return err
+		}
+		var existing []int64
+		if err := tx.SelectContext(ctx, &existing, `SELECT Zone FROM Zones WHERE Panel_id = @p1 AND Group_ = @p2`, panelID, groupNo); err != nil {
+			return err
+		}
+		for _, zoneNo := range missingPhoenixZones(existing, count) {
+			if _, err := tx.ExecContext(ctx, `
+INSERT INTO Zones (Panel_id, Zone, Group_, Message, Status, IsPatrol, IsAlarmButton, IsBypass)
+VALUES (@p1, @p2, @p3, @p4, 0, 0, 0, 0)`,
+				panelID, zoneNo, groupNo, "Зона "+strconv.FormatInt(zoneNo, 10),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClearObjectZones видаляє всі зони панелі.
+func (a *PhoenixAdminProvider) ClearObjectZones(objn int64) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	return a.mutate(panelID, "очищення зон", func(ctx context.Context, tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM Zones_IPCameras WHERE Panel_Id = @p1`, panelID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM Zones WHERE Panel_id = @p1`, panelID)
+		return err
+	})
+}
+
+// ListObjectPersonals повертає відповідальних панелі з телефонами у порядку дзвінків.
+func (a *PhoenixAdminProvider) ListObjectPersonals(objn int64) ([]contracts.AdminObjectPersonal, error) {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), phoenixAdminTimeout)
+	defer cancel()
+
+	var responsibles []phoenixAdminResponsibleRow
+	if err := a.phoenix.db.SelectContext(ctx, &responsibles, phoenixAdminResponsiblesQuery, panelID); err != nil {
+		return nil, fmt.Errorf("phoenix: відповідальні панелі %s: %w", panelID, err)
+	}
+	var phones []phoenixAdminPhoneRow
+	if err := a.phoenix.db.SelectContext(ctx, &phones, phoenixAdminPhonesQuery, panelID); err != nil {
+		return nil, fmt.Errorf("phoenix: телефони відповідальних панелі %s: %w", panelID, err)
+	}
+	return buildPhoenixAdminPersonals(objn, responsibles, phones), nil
+}
+
+func buildPhoenixAdminPersonals(objn int64, responsibles []phoenixAdminResponsibleRow, phones []phoenixAdminPhoneRow) []contracts.AdminObjectPersonal {
+	phonesByResponsible := make(map[int64][]phoenixAdminPhoneRow, len(responsibles))
+	for _, phone := range phones {
+		if strings.TrimSpace(nullString(phone.Phone)) == "" {
+			continue
+		}
+		phonesByResponsible[phone.ResponsibleID] = append(phonesByResponsible[phone.ResponsibleID], phone)
+	}
+
+	items := make([]contracts.AdminObjectPersonal, 0, len(responsibles))
+	for _, row := range responsibles {
+		surname, name, secName := splitPhoenixResponsibleName(nullString(row.Name))
+		item := contracts.AdminObjectPersonal{
+			ID:         row.ResponsibleID,
+			SourceObjN: objn,
+			Number:     row.Number.Int64,
+			Surname:    surname,
+			Name:       name,
+			SecName:    secName,
+			Address:    strings.TrimSpace(nullString(row.Address)),
+		}
+		numbers := make([]string, 0, len(phonesByResponsible[row.ResponsibleID]))
+		for _, phone := range phonesByResponsible[row.ResponsibleID] {
+			numbers = append(numbers, strings.TrimSpace(nullString(phone.Phone)))
+			if item.Position == "" {
+				item.Position = strings.TrimSpace(nullString(phone.Description))
+			}
+		}
+		item.Phones = strings.Join(numbers, ", ")
+		items = append(items, item)
+	}
+	return items
+}
+
+// AddObjectPersonal додає відповідального до основної групи панелі.
+func (a *PhoenixAdminProvider) AddObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	fullName := joinPhoenixResponsibleName(item)
+	if fullName == "" {
+		return adminInvalidf("phoenix: вкажіть ПІБ відповідального")
+	}
+	phones := parsePhoenixPhones(item.Phones)
+	return a.mutate(panelID, "додавання відповідального", func(ctx context.Context, tx *sqlx.Tx) error {
+		groupNo, err := phoenixMainGroup(ctx, tx, panelID)
+		if err != nil {
+			return err
+		}
+		number := item.Number
+		if number <= 0 {
+			if err := tx.GetContext(ctx, &number, `SELECT ISNULL(MAX(Responsible_Number), 0) + 1 FROM Responsibles WHERE panel_id = @p1 AND Group_ = @p2`, panelID, groupNo); err != nil {
+				return err
+			}
+		}
+		var listID int64
+		if err := tx.GetContext(ctx, &listID, `
+INSERT INTO ResponsiblesList (Responsible_Name, Responsible_Address)
+OUTPUT INSERTED.ResponsiblesList_id
+VALUES (@p1, @p2)`, fullName, strings.TrimSpace(item.Address)); err != nil {
+			return err
+		}
+		var responsibleID int64
+		if err := tx.GetContext(ctx, &responsibleID, `
+INSERT INTO Responsibles (panel_id, Group_, Responsible_Number, ResponsiblesList_id)
+OUTPUT INSERTED.Responsible_id
+VALUES (@p1, @p2, @p3, @p4)`, panelID, groupNo, number, listID); err != nil {
+			return err
+		}
+		for i, phone := range phones {
+			if err := insertPhoenixResponsiblePhone(ctx, tx, listID, responsibleID, phone, item.Position, i+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateObjectPersonal оновлює ПІБ, адресу, номер і телефони відповідального.
+// ПІБ і телефони належать запису ResponsiblesList, тому зміна видна на всіх
+// панелях, де ця особа відповідальна.
+func (a *PhoenixAdminProvider) UpdateObjectPersonal(objn int64, item contracts.AdminObjectPersonal) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	fullName := joinPhoenixResponsibleName(item)
+	if fullName == "" {
+		return adminInvalidf("phoenix: вкажіть ПІБ відповідального")
+	}
+	phones := parsePhoenixPhones(item.Phones)
+	return a.mutate(panelID, "оновлення відповідального", func(ctx context.Context, tx *sqlx.Tx) error {
+		listID, err := phoenixResponsibleList(ctx, tx, panelID, item.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE ResponsiblesList SET Responsible_Name = @p2, Responsible_Address = @p3 WHERE ResponsiblesList_id = @p1`,
+			listID, fullName, strings.TrimSpace(item.Address)); err != nil {
+			return err
+		}
+		if item.Number > 0 {
+			if _, err := tx.ExecContext(ctx, `UPDATE Responsibles SET Responsible_Number = @p2 WHERE Responsible_id = @p1`, item.ID, item.Number); err != nil {
+				return err
+			}
+		}
+
+		var current []phoenixAdminPhoneRow
+		if err := tx.SelectContext(ctx, &current, `
+SELECT @p2 AS responsible_id, RT.ResponsibleTel_id AS tel_id, RT.PhoneNo AS phone,
+	CAST(NULL AS nvarchar(255)) AS description, CAST(NULL AS int) AS call_order
+FROM ResponsibleTel RT WHERE RT.ResponsiblesList_id = @p1`, listID, item.ID); err != nil {
+			return err
+		}
+		keep, remove := diffPhoenixPhones(current, phones)
+		for _, telID := range remove {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM ResponsibleTelDescription WHERE ResponsibleTel_id = @p1`, telID); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM ResponsibleTel WHERE ResponsibleTel_id = @p1`, telID); err != nil {
+				return err
+			}
+		}
+		for i, phone := range phones {
+			telID, ok := keep[phoenixPhoneKey(phone)]
+			if !ok {
+				if err := insertPhoenixResponsiblePhone(ctx, tx, listID, item.ID, phone, item.Position, i+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := upsertPhoenixPhoneDescription(ctx, tx, item.ID, telID, item.Position, i+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteObjectPersonal прибирає відповідального з панелі; запис особи з телефонами
+// видаляється, лише якщо вона більше ніде не відповідальна.
+func (a *PhoenixAdminProvider) DeleteObjectPersonal(objn int64, personalID int64) error {
+	panelID, err := a.panelID(objn)
+	if err != nil {
+		return err
+	}
+	return a.mutate(panelID, "видалення відповідального", func(ctx context.Context, tx *sqlx.Tx) error {
+		listID, err := phoenixResponsibleList(ctx, tx, panelID, personalID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ResponsibleTelDescription WHERE Responsible_id = @p1`, personalID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM Responsibles WHERE Responsible_id = @p1`, personalID); err != nil {
+			return err
+		}
+		var references int
+		if err := tx.GetContext(ctx, &references, `SELECT COUNT(1) FROM Responsibles WHERE ResponsiblesList_id = @p1`, listID); err != nil {
+			return err
+		}
+		if references > 0 {
+			return nil
+		}
+		for _, statement := range []string{
+			`DELETE FROM ResponsibleTel WHERE ResponsiblesList_id = @p1`,
+			`DELETE FROM ResponsibleEmail WHERE ResponsiblesList_id = @p1`,
+			`DELETE FROM ResponsiblesList WHERE ResponsiblesList_id = @p1`,
+		} {
+			if _, err := tx.ExecContext(ctx, statement, listID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPersonalByPhone шукає особу з таким телефоном серед відповідальних Phoenix.
+func (a *PhoenixAdminProvider) FindPersonalByPhone(phone string) (*contracts.AdminObjectPersonal, error) {
+	suffix := phoenixPhoneKey(phone)
+	if len(suffix) < 7 {
+		return nil, nil
+	}
+	if a == nil || a.phoenix == nil || a.phoenix.db == nil {
+		return nil, fmt.Errorf("phoenix: база не ініціалізована")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), phoenixAdminTimeout)
+	defer cancel()
+
+	var row phoenixAdminResponsibleRow
+	err := a.phoenix.db.GetContext(ctx, &row, `
+SELECT TOP (1)
+	CAST(0 AS int) AS responsible_id,
+	CAST(0 AS int) AS group_no,
+	CAST(NULL AS int) AS responsible_number,
+	RL.ResponsiblesList_id AS list_id,
+	RL.Responsible_Name AS responsible_name,
+	RL.Responsible_Address AS responsible_address
+FROM ResponsibleTel RT
+INNER JOIN ResponsiblesList RL ON RL.ResponsiblesList_id = RT.ResponsiblesList_id
+WHERE REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(RT.PhoneNo, ' ', ''), '-', ''), '(', ''), ')', ''), '+', '') LIKE '%' + @p1
+ORDER BY RL.ResponsiblesList_id DESC`, suffix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("phoenix: пошук відповідального за телефоном: %w", err)
+	}
+	var phones []string
+	if err := a.phoenix.db.SelectContext(ctx, &phones, `SELECT PhoneNo FROM ResponsibleTel WHERE ResponsiblesList_id = @p1 ORDER BY ResponsibleTel_id`, row.ListID); err != nil {
+		return nil, fmt.Errorf("phoenix: телефони відповідального: %w", err)
+	}
+	surname, name, secName := splitPhoenixResponsibleName(nullString(row.Name))
+	return &contracts.AdminObjectPersonal{
+		Surname: surname,
+		Name:    name,
+		SecName: secName,
+		Address: strings.TrimSpace(nullString(row.Address)),
+		Phones:  strings.Join(parsePhoenixPhones(strings.Join(phones, ",")), ", "),
+	}, nil
+}
+
+// PanelObjectID повертає ID об'єкта застосунку для Panel_id.
+func (a *PhoenixAdminProvider) PanelObjectID(panelID string) int64 {
+	if a == nil || a.phoenix == nil {
+		return 0
+	}
+	return int64(a.phoenix.registerPanelID(strings.ToUpper(strings.TrimSpace(panelID))))
+}
+
+func (a *PhoenixAdminProvider) panelID(objn int64) (string, error) {
+	if a == nil || a.phoenix == nil || a.phoenix.db == nil {
+		return "", fmt.Errorf("phoenix: база не ініціалізована")
+	}
+	panelID, ok := a.phoenix.resolvePanelID(strconv.FormatInt(objn, 10))
+	if !ok || strings.TrimSpace(panelID) == "" {
+		return "", fmt.Errorf("phoenix: не вдалося визначити панель для об'єкта %d", objn)
+	}
+	return strings.TrimSpace(panelID), nil
+}
+
+func (a *PhoenixAdminProvider) cardPanelID(card contracts.AdminObjectCard) (string, error) {
+	if card.ObjN > 0 {
+		return a.panelID(card.ObjN)
+	}
+	if a == nil || a.phoenix == nil || a.phoenix.db == nil {
+		return "", fmt.Errorf("phoenix: база не ініціалізована")
+	}
+	return normalizePhoenixPanelID(card.PanelID)
+}
+
+func (a *PhoenixAdminProvider) operatorName() (string, error) {
+	_, operator, err := a.phoenix.alarmOperatorIdentity()
+	if err != nil {
+		return "", fmt.Errorf("phoenix: %w", err)
+	}
+	return operator, nil
+}
+
+func (a *PhoenixAdminProvider) inTx(fn func(ctx context.Context, tx *sqlx.Tx) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), phoenixAdminTimeout)
+	defer cancel()
+	tx, err := a.phoenix.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// mutate виконує зміну панелі в транзакції, позначає дату зміни панелі та анонсує її.
+func (a *PhoenixAdminProvider) mutate(panelID string, action string, fn func(ctx context.Context, tx *sqlx.Tx) error) error {
+	operator, err := a.operatorName()
+	if err != nil {
+		return err
+	}
+	err = a.inTx(func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := fn(ctx, tx); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, `UPDATE Panel SET DateLastChange = GETDATE(), UserName = @p2 WHERE Panel_id = @p1`, panelID, operator)
+		if err != nil {
+			return err
+		}
+		return requirePhoenixRows(result, "панель не знайдена")
+	})
+	if err != nil {
+		return fmt.Errorf("phoenix: %s (панель %s): %w", action, panelID, err)
+	}
+	a.announce(panelID, operator)
+	return nil
+}
+
+// announce повідомляє центр керування про зміну панелі та скидає локальні кеші.
+// Окремої дії для зміни картки протокол не має, тому надсилається STATUS_OBJECT:
+// після нього центр керування та інші клієнти перечитують стан об'єктів.
+// Дані вже зафіксовані, тому відсутність сеансу лише логується.
+func (a *PhoenixAdminProvider) announce(panelID string, operator string) {
+	p := a.phoenix
+	if err := p.writeControlPacket(p.phoenixStatusObjectPayload(0, operator, time.Now())); err != nil {
+		log.Warn().Err(err).Str("panelID", panelID).Msg("Phoenix: зміну панелі не анонсовано центру керування")
+	}
+	p.controlRevision.Add(1)
+	p.invalidatePhoenixCaches()
+}
+
+func insertPhoenixCompany(ctx context.Context, tx *sqlx.Tx, card contracts.AdminObjectCard, operator string) (int64, error) {
+	var companyID int64
+	err := tx.GetContext(ctx, &companyID, `
+INSERT INTO Company (CompanyName, Address, Telephones, LastEditDate, UserName)
+OUTPUT INSERTED.ID
+VALUES (@p1, @p2, @p3, GETDATE(), @p4)`,
+		phoenixAdminCompanyName(card), strings.TrimSpace(card.Address), strings.TrimSpace(card.Phones), operator,
+	)
+	return companyID, err
+}
+
+func insertPhoenixResponsiblePhone(ctx context.Context, tx *sqlx.Tx, listID int64, responsibleID int64, phone string, description string, callOrder int) error {
+	var telID int64
+	if err := tx.GetContext(ctx, &telID, `
+INSERT INTO ResponsibleTel (PhoneNo, TypeTel_id, ResponsiblesList_id)
+OUTPUT INSERTED.ResponsibleTel_id
+VALUES (@p1, (SELECT MIN(TypeTel_id) FROM ResponsibleTypeTel), @p2)`, phone, listID); err != nil {
+		return err
+	}
+	return upsertPhoenixPhoneDescription(ctx, tx, responsibleID, telID, description, callOrder)
+}
+
+func upsertPhoenixPhoneDescription(ctx context.Context, tx *sqlx.Tx, responsibleID int64, telID int64, description string, callOrder int) error {
+	result, err := tx.ExecContext(ctx, `
+UPDATE ResponsibleTelDescription SET Description = @p3, CallOrder = @p4
+WHERE Responsible_id = @p1 AND ResponsibleTel_id = @p2`,
+		responsibleID, telID, strings.TrimSpace(description), callOrder)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO ResponsibleTelDescription (Responsible_id, ResponsibleTel_id, Description, FireAlarm, ClientInfoSms, ControlLun, AsteriskAutodial, CallOrder)
+VALUES (@p1, @p2, @p3, 0, 0, 0, 0, @p4)`,
+		responsibleID, telID, strings.TrimSpace(description), callOrder)
+	return err
+}
+
+func phoenixMainGroup(ctx context.Context, tx *sqlx.Tx, panelID string) (int64, error) {
+	var groupNo sql.NullInt64
+	if err := tx.GetContext(ctx, &groupNo, `SELECT MIN(Group_) FROM Groups WHERE Panel_id = @p1`, panelID); err != nil {
+		return 0, err
+	}
+	if !groupNo.Valid {
+		return 0, fmt.Errorf("панель не має жодної групи")
+	}
+	return groupNo.Int64, nil
+}
+
+func phoenixResponsibleList(ctx context.Context, tx *sqlx.Tx, panelID string, responsibleID int64) (int64, error) {
+	var listID int64
+	err := tx.GetContext(ctx, &listID, `SELECT ResponsiblesList_id FROM Responsibles WHERE Responsible_id = @p1 AND panel_id = @p2`, responsibleID, panelID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, adminNotFoundf("відповідальний %d не знайдений", responsibleID)
+	}
+	return listID, err
+}
+
+func ensurePhoenixZoneFree(ctx context.Context, tx *sqlx.Tx, panelID string, groupNo int64, zoneNo int64) error {
+	var count int
+	if err := tx.GetContext(ctx, &count, `SELECT COUNT(1) FROM Zones WHERE Panel_id = @p1 AND Group_ = @p2 AND Zone = @p3`, panelID, groupNo, zoneNo); err != nil {
+		return err
+	}
+	if count > 0 {
+		return adminConflictf("зона %d у групі %d уже існує", zoneNo, groupNo)
+	}
+	return nil
+}
+
+func requirePhoenixRows(result sql.Result, message string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(message)
+	}
+	return nil
+}
+
+func normalizePhoenixPanelID(raw string) (string, error) {
+	panelID := strings.ToUpper(strings.TrimSpace(raw))
+	switch {
+	case panelID == "":
+		return "", adminInvalidf("phoenix: вкажіть номер панелі (Panel_id)")
+	case len(panelID) > phoenixPanelIDMaxLen:
+		return "", adminInvalidf("phoenix: номер панелі довший за %d символів", phoenixPanelIDMaxLen)
+	case strings.Contains(panelID, phoenixPacketSeparator) || strings.ContainsAny(panelID, " \t\r\n'"):
+		return "", adminInvalidf("phoenix: номер панелі містить недопустимі символи")
+	}
+	return panelID, nil
+}
+
+func validatePhoenixAdminCard(card contracts.AdminObjectCard) error {
+	if strings.TrimSpace(card.ShortName) == "" && strings.TrimSpace(card.FullName) == "" {
+		return adminInvalidf("phoenix: вкажіть назву об'єкта")
+	}
+	return nil
+}
+
+func phoenixAdminGroupName(card contracts.AdminObjectCard) string {
+	return phoenixFirstNonEmpty(card.ShortName, card.FullName)
+}
+
+func phoenixAdminCompanyName(card contracts.AdminObjectCard) string {
+	return phoenixFirstNonEmpty(card.FullName, card.ShortName)
+}
+
+func phoenixNullableID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id > 0}
+}
+
+// phoenixZoneID кодує групу й номер зони в один ідентифікатор AdminObjectZone.ID,
+// бо первинний ключ Zones — (Panel_id, Group_, Zone).
+func phoenixZoneID(groupNo int64, zoneNo int64) int64 {
+	return groupNo*phoenixZoneIDGroupBase + zoneNo
+}
+
+func phoenixZoneGroup(id int64) int64 {
+	if id <= 0 {
+		return 0
+	}
+	return id / phoenixZoneIDGroupBase
+}
+
+func phoenixZoneNumber(id int64) int64 {
+	if id <= 0 {
+		return 0
+	}
+	return id % phoenixZoneIDGroupBase
+}
+
+func missingPhoenixZones(existing []int64, count int64) []int64 {
+	present := make(map[int64]struct{}, len(existing))
+	for _, zoneNo := range existing {
+		present[zoneNo] = struct{}{}
+	}
+	missing := make([]int64, 0, count)
+	for zoneNo := int64(1); zoneNo <= count; zoneNo++ {
+		if _, ok := present[zoneNo]; !ok {
+			missing = append(missing, zoneNo)
+		}
+	}
+	return missing
+}
+
+// splitPhoenixResponsibleName розбиває Responsible_Name ("Прізвище Ім'я По батькові")
+// на поля картки; все після третього слова лишається в по батькові.
+func splitPhoenixResponsibleName(fullName string) (surname, name, secName string) {
+	parts := strings.Fields(fullName)
+	switch len(parts) {
+	case 0:
+		return "", "", ""
+	case 1:
+		return parts[0], "", ""
+	case 2:
+		return parts[0], parts[1], ""
+	default:
+		return parts[0], parts[1], strings.Join(parts[2:], " ")
+	}
+}
+
+func joinPhoenixResponsibleName(item contracts.AdminObjectPersonal) string {
+	return strings.Join(strings.Fields(strings.Join([]string{item.Surname, item.Name, item.SecName}, " ")), " ")
+}
+
+// parsePhoenixPhones розбирає список телефонів з картки, зберігаючи порядок і
+// відкидаючи дублікати.
+func parsePhoenixPhones(raw string) []string {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n' || r == '\r'
+	})
+	phones := make([]string, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, part := range parts {
+		phone := strings.TrimSpace(part)
+		key := phoenixPhoneKey(phone)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		phones = append(phones, phone)
+	}
+	return phones
+}
+
+// phoenixPhoneKey порівнює номери за останніми дев'ятьма цифрами, щоб 0671234567
+// і +380671234567 вважалися одним телефоном.
+func phoenixPhoneKey(phone string) string {
+	digits := utils.DigitsOnly(phone)
+	if len(digits) > 9 {
+		digits = digits[len(digits)-9:]
+	}
+	return digits
+}
+
+// diffPhoenixPhones повертає наявні телефони, що лишаються (ключ → ResponsibleTel_id),
+// та ідентифікатори телефонів, яких більше немає в картці.
+func diffPhoenixPhones(current []phoenixAdminPhoneRow, phones []string) (map[string]int64, []int64) {
+	wanted := make(map[string]struct{}, len(phones))
+	for _, phone := range phones {
+		wanted[phoenixPhoneKey(phone)] = struct{}{}
+	}
+	keep := make(map[string]int64, len(current))
+	var remove []int64
+	for _, row := range current {
+		key := phoenixPhoneKey(nullString(row.Phone))
+		if _, ok := wanted[key]; ok && key != "" {
+			if _, duplicate := keep[key]; !duplicate {
+				keep[key] = row.TelID
+				continue
+			}
+		}
+		remove = append(remove, row.TelID)
+	}
+	sort.Slice(remove, func(i, j int) bool { return remove[i] < remove[j] })
+	return keep, remove
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"database/sql"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+func TestPhoenixZoneIDRoundTrip(t *testing.T) {
+	id := phoenixZoneID(3, 17)
+	if phoenixZoneGroup(id) != 3 || phoenixZoneNumber(id) != 17 {
+		t.Fatalf("zone id %d decodes to group %d zone %d", id, phoenixZoneGroup(id), phoenixZoneNumber(id))
+	}
+	if phoenixZoneGroup(0) != 0 || phoenixZoneNumber(-5) != 0 {
+		t.Fatal("new zone id must decode to zero group and zone")
+	}
+	if got := missingPhoenixZones([]int64{1, 3, 9}, 4); len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Fatalf("missingPhoenixZones() = %v", got)
+	}
+}
+
+func TestPhoenixResponsiblePhonesAndNames(t *testing.T) {
+	phones := parsePhoenixPhones("067 123-45-67; +380671234567\n050 000 11 22,, ")
+	if len(phones) != 2 || phones[0] != "067 123-45-67" || phones[1] != "050 000 11 22" {
+		t.Fatalf("parsePhoenixPhones() = %q", phones)
+	}
+
+	current := []phoenixAdminPhoneRow{
+		{TelID: 5, Phone: sql.NullString{String: "0671234567", Valid: true}},
+		{TelID: 4, Phone: sql.NullString{String: "0931112233", Valid: true}},
+		{TelID: 7, Phone: sql.NullString{String: "+38 (067) 123 45 67", Valid: true}},
+	}
+	keep, remove := diffPhoenixPhones(current, phones)
+	if keep[phoenixPhoneKey("0671234567")] != 5 || len(keep) != 1 {
+		t.Fatalf("keep = %v", keep)
+	}
+	if len(remove) != 2 || remove[0] != 4 || remove[1] != 7 {
+		t.Fatalf("remove = %v", remove)
+	}
+
+	surname, name, secName := splitPhoenixResponsibleName("  Шевченко  Тарас Григорович ")
+	if surname != "Шевченко" || name != "Тарас" || secName != "Григорович" {
+		t.Fatalf("split = %q %q %q", surname, name, secName)
+	}
+	item := contracts.AdminObjectPersonal{Surname: surname, Name: name}
+	if got := joinPhoenixResponsibleName(item); got != "Шевченко Тарас" {
+		t.Fatalf("join = %q", got)
+	}
+}
+
+func TestBuildPhoenixAdminPersonalsOrdersPhones(t *testing.T) {
+	responsibles := []phoenixAdminResponsibleRow{
+		{ResponsibleID: 11, Number: sql.NullInt64{Int64: 1, Valid: true}, Name: sql.NullString{String: "Іваненко Петро", Valid: true}},
+		{ResponsibleID: 12, Number: sql.NullInt64{Int64: 2, Valid: true}, Name: sql.NullString{String: "Охорона", Valid: true}},
+	}
+	phones := []phoenixAdminPhoneRow{
+		{ResponsibleID: 11, TelID: 1, Phone: sql.NullString{String: "0501112233", Valid: true}, Description: sql.NullString{String: "Директор", Valid: true}},
+		{ResponsibleID: 11, TelID: 2, Phone: sql.NullString{String: "0672223344", Valid: true}},
+		{ResponsibleID: 12, TelID: 3, Phone: sql.NullString{String: " ", Valid: true}},
+	}
+	items := buildPhoenixAdminPersonals(1_000_000_001, responsibles, phones)
+	if len(items) != 2 {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[0].Phones != "0501112233, 0672223344" || items[0].Position != "Директор" || items[0].SourceObjN != 1_000_000_001 {
+		t.Fatalf("first = %+v", items[0])
+	}
+	if items[1].Phones != "" || items[1].Surname != "Охорона" {
+		t.Fatalf("second = %+v", items[1])
+	}
+}
+
+func TestNormalizePhoenixPanelIDAndCard(t *testing.T) {
+	if got, err := normalizePhoenixPanelID(" l00042 "); err != nil || got != "L00042" {
+		t.Fatalf("normalizePhoenixPanelID() = %q, %v", got, err)
+	}
+	for _, raw := range []string{"", "L0[*]1", "L 1", strings.Repeat("9", 16)} {
+		if _, err := normalizePhoenixPanelID(raw); err == nil {
+			t.Fatalf("panel id %q must be rejected", raw)
+		}
+	}
+	if err := validatePhoenixAdminCard(contracts.AdminObjectCard{}); err == nil {
+		t.Fatal("card without name must be rejected")
+	}
+
+	card := phoenixAdminCardFromRow(phoenixAdminCardRow{
+		PanelID:     "L00042 ",
+		GroupNo:     1,
+		GroupName:   sql.NullString{String: "Магазин", Valid: true},
+		CompanyName: sql.NullString{String: "ТОВ Ромашка", Valid: true},
+		CreateDate:  sql.NullTime{Time: time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local), Valid: true},
+	}, 7)
+	if card.PanelID != "L00042" || card.ShortName != "Магазин" || card.FullName != "ТОВ Ромашка" || card.StartDate != "01.04.2025" {
+		t.Fatalf("card = %+v", card)
+	}
+	if phoenixAdminGroupName(contracts.AdminObjectCard{FullName: "ТОВ"}) != "ТОВ" {
+		t.Fatal("group name must fall back to the company name")
+	}
+}
+
+func TestPhoenixPanelChangeAnnouncedAsStatusObject(t *testing.T) {
+	provider := NewPhoenixDataProvider(nil, "")
+	provider.controlSource = "W11WS2V3"
+	provider.controlLocalIP = "10.32.1.48"
+	provider.controlRemote = &net.UDPAddr{IP: net.ParseIP("10.32.1.200"), Port: 5057}
+
+	payload := provider.phoenixStatusObjectPayload(0, "Оператор", time.Date(2026, 5, 19, 14, 54, 52, 0, time.Local))
+	packet := parsePhoenixControlPacket(payload)
+	if packet.action != "STATUS_OBJECT" || len(packet.fields) != 20 {
+		t.Fatalf("packet = %+v", packet)
+	}
+	if !phoenixPacketChangesData(packet.action) {
+		t.Fatal("panel change must invalidate caches on receiving clients")
+	}
+	if provider.PhoenixObjectAdmin() != nil {
+		t.Fatal("provider without database must not expose administration")
+	}
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+const (
+	phoenixArchiveDefaultLimit = 500
+	phoenixArchiveMaxLimit     = 5000
+)
+
+// QueryEventArchive гортає архів подій Phoenix (vwArchives) від новіших до
+// старіших. Курсор — Event_id останньої події сторінки, тож сторінка завжди
+// читається індексом і не залежить від того, скільки подій уже пропущено.
+func (p *PhoenixDataProvider) QueryEventArchive(ctx context.Context, query contracts.EventArchiveQuery) (contracts.EventArchivePage, error) {
+	if p == nil || p.db == nil {
+		return contracts.EventArchivePage{}, fmt.Errorf("phoenix database is not initialized")
+	}
+	panelID := ""
+	if query.ObjectID != 0 {
+		resolved, ok := p.resolvePanelID(strconv.Itoa(query.ObjectID))
+		if !ok {
+			return contracts.EventArchivePage{}, fmt.Errorf("об'єкт Phoenix %d не знайдено", query.ObjectID)
+		}
+		panelID = resolved
+	}
+
+	limit := phoenixArchiveLimit(query.Limit)
+	sqlQuery, args, err := buildPhoenixArchiveQuery(query, panelID, limit+1)
+	if err != nil {
+		return contracts.EventArchivePage{}, err
+	}
+	var rows []phoenixEventRow
+	if err := p.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return contracts.EventArchivePage{}, fmt.Errorf("phoenix archive query: %w", err)
+	}
+
+	page := contracts.EventArchivePage{}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		page.NextCursor = strconv.FormatInt(rows[len(rows)-1].EventID, 10)
+	}
+	page.Events = make([]models.Event, 0, len(rows))
+	for _, row := range rows {
+		page.Events = append(page.Events, p.mapEventRow(row))
+	}
+	return page, nil
+}
+
+func phoenixArchiveLimit(limit int) int {
+	if limit <= 0 {
+		return phoenixArchiveDefaultLimit
+	}
+	return min(limit, phoenixArchiveMaxLimit)
+}
+
+func buildPhoenixArchiveQuery(query contracts.EventArchiveQuery, panelID string, top int) (string, []any, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(condition string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+
+	if cursor := strings.TrimSpace(query.Cursor); cursor != "" {
+		eventID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || eventID <= 0 {
+			return "", nil, fmt.Errorf("некоректний курсор архіву %q", query.Cursor)
+		}
+		add("A.Event_id < @p%d", eventID)
+	}
+	if panelID != "" {
+		add("A.Panel_id = @p%d", panelID)
+	}
+	if code := strings.TrimSpace(query.Code); code != "" {
+		add("A.Code = @p%d", code)
+	}
+	if len(query.Groups) > 0 {
+		placeholders := make([]string, 0, len(query.Groups))
+		for _, group := range query.Groups {
+			args = append(args, group)
+			placeholders = append(placeholders, "@p"+strconv.Itoa(len(args)))
+		}
+		where = append(where, "A.Group_ IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if !query.From.IsZero() {
+		add("A.TimeEvent >= @p%d", query.From)
+	}
+	if !query.To.IsZero() {
+		add("A.TimeEvent <= @p%d", query.To)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, phoenixArchiveEventsQueryBase, top)
+	if len(where) > 0 {
+		b.WriteString("WHERE ")
+		b.WriteString(strings.Join(where, "\n  AND "))
+		b.WriteString("\n")
+	}
+	b.WriteString("ORDER BY A.Event_id DESC\n")
+	return b.String(), args, nil
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestBuildPhoenixArchiveQueryAppliesFiltersAndCursor(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	query, args, err := buildPhoenixArchiveQuery(contracts.EventArchiveQuery{
+		Code:   "E130",
+		Groups: []int{1, 2},
+		From:   from,
+		Cursor: "9001",
+	}, "L00028", 501)
+	if err != nil {
+		t.Fatalf("buildPhoenixArchiveQuery() error = %v", err)
+	}
+	for _, want := range []string{
+		"SELECT TOP (501)",
+		"A.Event_id < @p1",
+		"A.Panel_id = @p2",
+		"A.Code = @p3",
+		"A.Group_ IN (@p4, @p5)",
+		"A.TimeEvent >= @p6",
+		"ORDER BY A.Event_id DESC",
+	} {
+		if !strings.Contains(query, want) {
+			t.Fatalf("query does not contain %q:\n%s", want, query)
+		}
+	}
+	if strings.Contains(query, "A.TimeEvent <=") {
+		t.Fatal("empty To must not add an upper bound")
+	}
+	if len(args) != 6 || args[0] != int64(9001) || args[1] != "L00028" || args[5] != from {
+		t.Fatalf("args = %v", args)
+	}
+}
+
+func TestBuildPhoenixArchiveQueryRejectsBadCursor(t *testing.T) {
+	if _, _, err := buildPhoenixArchiveQuery(contracts.EventArchiveQuery{Cursor: "abc"}, "", 10); err == nil {
+		t.Fatal("buildPhoenixArchiveQuery() error = nil, want cursor error")
+	}
+	if got := phoenixArchiveLimit(0); got != phoenixArchiveDefaultLimit {
+		t.Fatalf("phoenixArchiveLimit(0) = %d", got)
+	}
+	if got := phoenixArchiveLimit(1_000_000); got != phoenixArchiveMaxLimit {
+		t.Fatalf("phoenixArchiveLimit(1e6) = %d", got)
+	}
+}
+
+type archiveStubProvider struct {
+	*combinedStubProvider
+	pages   map[string]contracts.EventArchivePage
+	queries []contracts.EventArchiveQuery
+}
+
+func (s *archiveStubProvider) QueryEventArchive(_ context.Context, query contracts.EventArchiveQuery) (contracts.EventArchivePage, error) {
+	s.queries = append(s.queries, query)
+	return s.pages[query.Cursor], nil
+}
+
+func TestCombinedDataProviderPagesArchivesSourceBySource(t *testing.T) {
+	primary := &archiveStubProvider{
+		combinedStubProvider: &combinedStubProvider{},
+		pages: map[string]contracts.EventArchivePage{
+			"":    {Events: []models.Event{{ID: 1}}, NextCursor: "100"},
+			"100": {Events: []models.Event{{ID: 2}}},
+		},
+	}
+	branch := &archiveStubProvider{
+		combinedStubProvider: &combinedStubProvider{},
+		pages:                map[string]contracts.EventArchivePage{"": {Events: []models.Event{{ID: 3}}}},
+	}
+	provider := NewMultiSourceDataProvider(
+		ProviderSource{Name: "bridge", Provider: &combinedStubProvider{}},
+		ProviderSource{Name: "phoenix", Provider: primary, OwnsObjectID: ids.OwnsPhoenixInstance(0)},
+		ProviderSource{Name: "phoenix:Філія", Provider: branch, OwnsObjectID: ids.OwnsPhoenixInstance(1)},
+	)
+
+	var got []int
+	query := contracts.EventArchiveQuery{}
+	for range 5 {
+		page, err := provider.QueryEventArchive(context.Background(), query)
+		if err != nil {
+			t.Fatalf("QueryEventArchive() error = %v", err)
+		}
+		for _, event := range page.Events {
+			got = append(got, event.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("archive events = %v, want 1,2,3", got)
+	}
+
+	branch.queries = nil
+	objectID := ids.PhoenixInstanceObjectID(1, ids.PhoenixObjectIDNamespaceStart+5)
+	if _, err := provider.QueryEventArchive(context.Background(), contracts.EventArchiveQuery{ObjectID: objectID}); err != nil {
+		t.Fatalf("QueryEventArchive(object) error = %v", err)
+	}
+	if len(branch.queries) != 1 || branch.queries[0].ObjectID != objectID {
+		t.Fatalf("object query must go to the owning server, got %+v", branch.queries)
+	}
+}
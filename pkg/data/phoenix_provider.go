@@ -58,6 +58,9 @@ type PhoenixDataProvider struct {
 	idMu      sync.RWMutex
 	panelByID map[int]string
 	idByPanel map[string]int
+	// instanceSlot — слот ID екземпляра (див. ids.PhoenixInstanceObjectID) плюс один;
+	// 0 означає, що провайдер використовує весь простір Phoenix.
+	instanceSlot int
 
 	objectMu         sync.RWMutex
 	cachedObjects    []models.Object
@@ -430,22 +433,31 @@ func (p *PhoenixDataProvider) GetObjectEvents(objectID string) []models.Event {
 }
 
 func (p *PhoenixDataProvider) GetObjectEventsRange(objectID string, from time.Time, to time.Time) []models.Event {
+	events, err := p.QueryObjectEventsRange(context.Background(), objectID, from, to)
+	if err != nil {
+		log.Error().Err(err).Str("objectID", objectID).Msg("Phoenix: помилка отримання подій об'єкта за період")
+		return nil
+	}
+	return events
+}
+
+// QueryObjectEventsRange implements contracts.ObjectEventsRangeQueryProvider.
+func (p *PhoenixDataProvider) QueryObjectEventsRange(ctx context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
 	panelID, ok := p.resolvePanelID(objectID)
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("phoenix: панель об'єкта %s не знайдена", objectID)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
 	defer cancel()
 	var rows []phoenixEventRow
 	if err := p.db.SelectContext(ctx, &rows, phoenixObjectEventsRangeQuery, panelID, from, to); err != nil {
-		log.Error().Err(err).Str("panelID", panelID).Msg("Phoenix: помилка отримання подій об'єкта за період")
-		return nil
+		return nil, fmt.Errorf("phoenix: події панелі %s: %w", panelID, err)
 	}
 	events := make([]models.Event, 0, len(rows))
 	for _, row := range rows {
 		events = append(events, p.mapEventRow(row))
 	}
-	return events
+	return events, nil
 }
 
 func (p *PhoenixDataProvider) GetAlarmSourceMessages(alarm models.Alarm) []models.AlarmMsg {
@@ -719,7 +731,7 @@ func (p *PhoenixDataProvider) buildPhoenixAlarms(rows []phoenixObjectGroupRow) [
 		}
 
 		alarms = append(alarms, models.Alarm{
-			ID:           ids.StablePhoenixID(panelID, strconv.Itoa(row.GroupNo), "alarm"),
+			ID:           p.scopePhoenixID(ids.StablePhoenixID(panelID, strconv.Itoa(row.GroupNo), "alarm")),
 			ObjectID:     objectID,
 			ObjectNumber: panelID,
 			ObjectName:   objectName,
@@ -811,11 +823,11 @@ func (p *PhoenixDataProvider) buildPhoenixActiveAlarms(rows []phoenixActiveAlarm
 			details = "Тривога Phoenix"
 		}
 
-		alarmID := ids.StablePhoenixID(
+		alarmID := p.scopePhoenixID(ids.StablePhoenixID(
 			panelID,
 			groupKey,
 			"alarm_case",
-		)
+		))
 		rowSC1 := resolvePhoenixGroupedAlarmSC1(messages, phoenixActiveAlarmMessageSC1(selected))
 		stateEvent := nullInt64(selectedRow.StateEvent)
 		operator := strings.TrimSpace(nullString(selectedRow.Computer))
@@ -1233,7 +1245,7 @@ func (p *PhoenixDataProvider) mapEventRow(row phoenixEventRow) models.Event {
 	)
 
 	return models.Event{
-		ID:           stablePhoenixEventID(panelID, row.EventID),
+		ID:           p.scopePhoenixID(stablePhoenixEventID(panelID, row.EventID)),
 		Time:         normalizePhoenixEventTime(row.TimeEvent),
 		ObjectID:     objectID,
 		ObjectNumber: panelID,
@@ -1379,7 +1391,8 @@ func (p *PhoenixDataProvider) registerPanelID(panelID string) int {
 		return id
 	}
 
-	candidate := ids.StablePhoenixID(panelID)
+	first, last := p.phoenixIDRange()
+	candidate := p.scopePhoenixID(ids.StablePhoenixID(panelID))
 	for {
 		existing, occupied := p.panelByID[candidate]
 		if !occupied || existing == panelID {
@@ -1388,12 +1401,36 @@ func (p *PhoenixDataProvider) registerPanelID(panelID string) int {
 			return candidate
 		}
 		candidate++
-		if candidate > ids.PhoenixObjectIDNamespaceEnd {
-			candidate = ids.PhoenixObjectIDNamespaceStart
+		if candidate > last {
+			candidate = first
 		}
 	}
 }
 
+// SetInstanceSlot закріплює за провайдером слот ID екземпляра Phoenix, коли
+// робоче місце підключене до кількох серверів Phoenix. Викликається до першого запиту.
+func (p *PhoenixDataProvider) SetInstanceSlot(slot int) {
+	if p == nil || slot < 0 || slot >= ids.SourceInstanceSlotCount {
+		return
+	}
+	p.instanceSlot = slot + 1
+}
+
+func (p *PhoenixDataProvider) scopePhoenixID(id int) int {
+	if p == nil || p.instanceSlot == 0 {
+		return id
+	}
+	return ids.PhoenixInstanceObjectID(p.instanceSlot-1, id)
+}
+
+func (p *PhoenixDataProvider) phoenixIDRange() (int, int) {
+	if p.instanceSlot == 0 {
+		return ids.PhoenixObjectIDNamespaceStart, ids.PhoenixObjectIDNamespaceEnd
+	}
+	first := ids.PhoenixInstanceObjectID(p.instanceSlot-1, 0)
+	return first, first + ids.PhoenixInstanceSlotSize - 1
+}
+
 func buildPhoenixGroupID(panelID string, groupNo int) string {
 	return fmt.Sprintf("phoenix:panel=%s:group=%d", strings.TrimSpace(panelID), groupNo)
 }
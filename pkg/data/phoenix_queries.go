@@ -721,6 +721,37 @@ WHERE A.Panel_id = @p1
 ORDER BY A.Event_id DESC
 `
 
+// phoenixArchiveEventsQueryBase — пошук в архіві подій; умови WHERE і TOP
+// додає buildPhoenixArchiveQuery, сторінки беруться за Event_id (keyset).
+const phoenixArchiveEventsQueryBase = `
+SELECT TOP (%d)
+	A.Event_id AS event_id,
+	A.Panel_id AS panel_id,
+	A.Group_ AS group_no,
+	A.Zone AS zone_no,
+	A.TimeEvent AS time_event,
+	A.Code AS event_code,
+	C.Message AS code_message,
+	TC.idTCode AS type_code_id,
+	TC.Message AS type_code_message,
+	CASE WHEN COALESCE(C.AutoReset, 0) = 1 THEN 1 ELSE 0 END AS auto_reset,
+	CASE WHEN COALESCE(C.groupsent, 0) = 1 THEN 1 ELSE 0 END AS group_sent,
+	ISNULL(C.AccessCode, '0') AS access_code,
+	C.ContactID_Code AS contact_id_code,
+	CASE WHEN COALESCE(C.System, 0) = 1 THEN 1 ELSE 0 END AS system_flag,
+	C.zoneno AS code_zone_no,
+	G.Message AS group_name,
+	Z.Message AS zone_name,
+	Co.CompanyName AS company_name,
+	Co.Address AS company_address
+FROM vwArchives A WITH (NOLOCK)
+LEFT JOIN Groups G WITH (NOLOCK) ON G.Panel_id = A.Panel_id AND G.Group_ = A.Group_
+LEFT JOIN Code C WITH (NOLOCK) ON C.Code = A.Code AND C.CodeGroup = A.CodeGroup
+LEFT JOIN TypeCode TC WITH (NOLOCK) ON TC.idTCode = C.idTCode
+LEFT JOIN Zones Z WITH (NOLOCK) ON Z.Panel_id = A.Panel_id AND Z.Group_ = A.Group_ AND Z.Zone = A.Zone
+LEFT JOIN Company Co WITH (NOLOCK) ON Co.ID = G.CompanyID
+`
+
 const phoenixObjectEventsRangeQuery = `
 SELECT TOP (500)
 	A.Event_id AS event_id,
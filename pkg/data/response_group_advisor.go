@@ -0,0 +1,243 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/geocode"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+const (
+	defaultRoadDistanceCandidates = 5
+	defaultRoadDistanceTimeout    = 5 * time.Second
+)
+
+// RoadDistanceFunc рахує дорожню відстань між двома точками в кілометрах.
+type RoadDistanceFunc func(ctx context.Context, from, to geocode.MapPoint) (float64, error)
+
+// ResponseGroupAdvisor впорядковує групи реагування для конкретної тривоги:
+// група, вже направлена на тривогу, йде першою, далі вільні групи геозони
+// об'єкта, далі інші вільні групи за відстанню, зайняті групи — в кінці.
+type ResponseGroupAdvisor struct {
+	roadDistance   RoadDistanceFunc
+	roadCandidates int
+	roadTimeout    time.Duration
+}
+
+// ResponseGroupAdvisorOption налаштовує ResponseGroupAdvisor.
+type ResponseGroupAdvisorOption func(*ResponseGroupAdvisor)
+
+// WithRoadDistance вмикає уточнення відстані по дорогах для найближчих вільних груп.
+func WithRoadDistance(fn RoadDistanceFunc) ResponseGroupAdvisorOption {
+	return func(a *ResponseGroupAdvisor) {
+		a.roadDistance = fn
+	}
+}
+
+// WithRoadDistanceCandidates обмежує кількість груп, для яких запитується маршрут.
+func WithRoadDistanceCandidates(limit int) ResponseGroupAdvisorOption {
+	return func(a *ResponseGroupAdvisor) {
+		if limit > 0 {
+			a.roadCandidates = limit
+		}
+	}
+}
+
+// NewResponseGroupAdvisor створює радника; без опцій використовується відстань по прямій.
+func NewResponseGroupAdvisor(opts ...ResponseGroupAdvisorOption) *ResponseGroupAdvisor {
+	advisor := &ResponseGroupAdvisor{
+		roadCandidates: defaultRoadDistanceCandidates,
+		roadTimeout:    defaultRoadDistanceTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(advisor)
+		}
+	}
+	return advisor
+}
+
+// NewResponseGroupAdvisorFromConfig створює радника за налаштуваннями диспетчеризації.
+func NewResponseGroupAdvisorFromConfig(cfg config.DispatchConfig) *ResponseGroupAdvisor {
+	if !cfg.RoadDistance {
+		return NewResponseGroupAdvisor()
+	}
+	routingURL := strings.TrimSpace(cfg.RoutingURL)
+	return NewResponseGroupAdvisor(WithRoadDistance(func(ctx context.Context, from, to geocode.MapPoint) (float64, error) {
+		return geocode.RoadDistanceKm(ctx, routingURL, from, to)
+	}))
+}
+
+// Rank повертає копію groups з заповненими рекомендаціями, впорядковану за пріоритетом.
+// object може бути nil — тоді геозона та відстань невідомі.
+func (a *ResponseGroupAdvisor) Rank(ctx context.Context, object *models.Object, alarm models.Alarm, groups []contracts.ResponseGroup) []contracts.ResponseGroup {
+	if a == nil {
+		a = NewResponseGroupAdvisor()
+	}
+	ranked := make([]contracts.ResponseGroup, len(groups))
+	copy(ranked, groups)
+
+	zoneGroups := objectGeoZoneGroups(object)
+	objectPoint, objectPointOK := geocode.MapPoint{}, false
+	if object != nil {
+		objectPoint, objectPointOK = geocode.ParsePoint(object.Latitude, object.Longitude)
+	}
+	currentGroupID := strings.TrimSpace(alarm.ResponseGroupID)
+
+	for i := range ranked {
+		group := &ranked[i]
+		suggestion := contracts.ResponseGroupSuggestion{}
+		groupID := strings.TrimSpace(group.ID)
+		suggestion.AssignedToAlarm = currentGroupID != "" && groupID == currentGroupID && alarm.IsResponseGroupDispatched
+		suggestion.Busy = !suggestion.AssignedToAlarm && responseGroupBusy(group.Status)
+		_, suggestion.InObjectGeoZone = zoneGroups[groupID]
+		if objectPointOK {
+			if groupPoint, ok := geocode.ParsePoint(group.Latitude, group.Longitude); ok {
+				suggestion.DistanceKnown = true
+				suggestion.DistanceKm = geocode.DistanceKm(groupPoint, objectPoint)
+			}
+		}
+		group.Suggestion = suggestion
+	}
+
+	sortResponseGroupsBySuggestion(ranked)
+	if a.roadDistance != nil && objectPointOK && a.applyRoadDistances(ctx, objectPoint, ranked) {
+		sortResponseGroupsBySuggestion(ranked)
+	}
+
+	recommended := false
+	for i := range ranked {
+		suggestion := &ranked[i].Suggestion
+		suggestion.Rank = i + 1
+		if !recommended && !alarm.IsResponseGroupDispatched && !suggestion.Busy {
+			suggestion.Recommended = true
+			recommended = true
+		}
+		suggestion.Note = responseGroupSuggestionNote(ranked[i])
+	}
+	return ranked
+}
+
+// applyRoadDistances уточнює відстань для найближчих вільних груп; при помилці
+// маршрутизації залишається відстань по прямій.
+func (a *ResponseGroupAdvisor) applyRoadDistances(ctx context.Context, objectPoint geocode.MapPoint, ranked []contracts.ResponseGroup) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.roadTimeout)
+	defer cancel()
+
+	changed := false
+	requested := 0
+	for i := range ranked {
+		if requested >= a.roadCandidates {
+			break
+		}
+		group := &ranked[i]
+		if group.Suggestion.Busy || !group.Suggestion.DistanceKnown {
+			continue
+		}
+		groupPoint, ok := geocode.ParsePoint(group.Latitude, group.Longitude)
+		if !ok {
+			continue
+		}
+		requested++
+		distance, err := a.roadDistance(ctx, groupPoint, objectPoint)
+		if err != nil {
+			log.Debug().Err(err).Str("groupID", group.ID).Msg("Диспетчеризація: дорожня відстань недоступна")
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		group.Suggestion.DistanceKm = distance
+		group.Suggestion.RoadDistance = true
+		changed = true
+	}
+	return changed
+}
+
+func sortResponseGroupsBySuggestion(groups []contracts.ResponseGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		left, right := groups[i].Suggestion, groups[j].Suggestion
+		if tier := suggestionTier(left) - suggestionTier(right); tier != 0 {
+			return tier < 0
+		}
+		if left.DistanceKnown != right.DistanceKnown {
+			return left.DistanceKnown
+		}
+		if left.DistanceKnown && left.DistanceKm != right.DistanceKm {
+			return left.DistanceKm < right.DistanceKm
+		}
+		return strings.ToLower(strings.TrimSpace(groups[i].Name)) < strings.ToLower(strings.TrimSpace(groups[j].Name))
+	})
+}
+
+func suggestionTier(suggestion contracts.ResponseGroupSuggestion) int {
+	switch {
+	case suggestion.AssignedToAlarm:
+		return 0
+	case suggestion.Busy:
+		return 3
+	case suggestion.InObjectGeoZone:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func responseGroupBusy(status contracts.ResponseGroupStatus) bool {
+	return status == contracts.ResponseGroupStatusDispatched || status == contracts.ResponseGroupStatusArrived
+}
+
+// objectGeoZoneGroups повертає ID груп, закріплених за геозоною об'єкта.
+// CASL зберігає кілька груп через кому (з призначеної геозони або з тих, у
+// контур яких потрапляє об'єкт), Phoenix — одну основну ГМР.
+func objectGeoZoneGroups(object *models.Object) map[string]struct{} {
+	result := make(map[string]struct{})
+	if object == nil {
+		return result
+	}
+	for _, id := range strings.Split(object.PreferredResponseGroupID, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+func responseGroupSuggestionNote(group contracts.ResponseGroup) string {
+	suggestion := group.Suggestion
+	parts := make([]string, 0, 3)
+	switch {
+	case suggestion.AssignedToAlarm:
+		parts = append(parts, "направлена на цю тривогу")
+	case suggestion.Busy:
+		if number := strings.TrimSpace(group.ObjectNumber); number != "" {
+			parts = append(parts, "зайнята на №"+number)
+		} else {
+			parts = append(parts, "зайнята")
+		}
+	case suggestion.Recommended:
+		parts = append(parts, "рекомендовано")
+	}
+	if suggestion.InObjectGeoZone {
+		parts = append(parts, "геозона об'єкта")
+	}
+	if suggestion.DistanceKnown {
+		kind := "по прямій"
+		if suggestion.RoadDistance {
+			kind = "дорогою"
+		}
+		parts = append(parts, fmt.Sprintf("%.1f км %s", suggestion.DistanceKm, kind))
+	}
+	return strings.Join(parts, ", ")
+}
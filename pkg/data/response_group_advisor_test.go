@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/geocode"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestResponseGroupAdvisorRank(t *testing.T) {
+	t.Parallel()
+
+	object := &models.Object{
+		Latitude:                 "50.4501",
+		Longitude:                "30.5234",
+		PreferredResponseGroupID: "20, 30",
+	}
+	groups := []contracts.ResponseGroup{
+		{ID: "10", Name: "Близька", Status: contracts.ResponseGroupStatusFree, Latitude: "50.4510", Longitude: "30.5240"},
+		{ID: "20", Name: "Зонова далека", Status: contracts.ResponseGroupStatusFree, Latitude: "50.60", Longitude: "30.70"},
+		{ID: "30", Name: "Зонова без координат", Status: contracts.ResponseGroupStatusFree},
+		{ID: "40", Name: "Зайнята", Status: contracts.ResponseGroupStatusDispatched, ObjectNumber: "777", Latitude: "50.4502", Longitude: "30.5235"},
+		{ID: "50", Name: "Далека", Status: contracts.ResponseGroupStatusUnknown, Latitude: "50.90", Longitude: "31.00"},
+	}
+
+	ranked := NewResponseGroupAdvisor().Rank(context.Background(), object, models.Alarm{}, groups)
+
+	wantOrder := []string{"20", "30", "10", "50", "40"}
+	for i, id := range wantOrder {
+		if ranked[i].ID != id {
+			t.Fatalf("rank %d = %s, want %s (order %v)", i+1, ranked[i].ID, id, rankedIDs(ranked))
+		}
+		if ranked[i].Suggestion.Rank != i+1 {
+			t.Fatalf("group %s rank = %d, want %d", id, ranked[i].Suggestion.Rank, i+1)
+		}
+	}
+	if !ranked[0].Suggestion.Recommended || ranked[1].Suggestion.Recommended {
+		t.Fatalf("only the first free geo-zone group must be recommended: %+v", ranked[:2])
+	}
+	if !ranked[0].Suggestion.InObjectGeoZone || ranked[2].Suggestion.InObjectGeoZone {
+		t.Fatalf("geo-zone flags are wrong: %+v", ranked)
+	}
+	if ranked[1].Suggestion.DistanceKnown {
+		t.Fatalf("group without coordinates must not have a distance")
+	}
+	if !ranked[4].Suggestion.Busy || ranked[4].Suggestion.Note != "зайнята на №777, 0.0 км по прямій" {
+		t.Fatalf("busy group suggestion = %+v", ranked[4].Suggestion)
+	}
+	if groups[0].Suggestion.Rank != 0 {
+		t.Fatalf("Rank must not modify the input slice")
+	}
+}
+
+func TestResponseGroupAdvisorRankAssignedGroupFirst(t *testing.T) {
+	t.Parallel()
+
+	groups := []contracts.ResponseGroup{
+		{ID: "1", Name: "А", Status: contracts.ResponseGroupStatusFree},
+		{ID: "2", Name: "Б", Status: contracts.ResponseGroupStatusDispatched},
+	}
+	alarm := models.Alarm{ResponseGroupID: "2", IsResponseGroupDispatched: true}
+
+	ranked := NewResponseGroupAdvisor().Rank(context.Background(), nil, alarm, groups)
+	if ranked[0].ID != "2" || !ranked[0].Suggestion.AssignedToAlarm || ranked[0].Suggestion.Busy {
+		t.Fatalf("assigned group must be first and not busy: %+v", ranked)
+	}
+	for _, group := range ranked {
+		if group.Suggestion.Recommended {
+			t.Fatalf("no recommendation expected when a group is already dispatched: %+v", group)
+		}
+	}
+}
+
+func TestResponseGroupAdvisorRoadDistance(t *testing.T) {
+	t.Parallel()
+
+	object := &models.Object{Latitude: "50.45", Longitude: "30.52"}
+	groups := []contracts.ResponseGroup{
+		{ID: "near", Status: contracts.ResponseGroupStatusFree, Latitude: "50.46", Longitude: "30.52"},
+		{ID: "far", Status: contracts.ResponseGroupStatusFree, Latitude: "50.50", Longitude: "30.52"},
+		{ID: "fail", Status: contracts.ResponseGroupStatusFree, Latitude: "50.60", Longitude: "30.52"},
+	}
+	road := map[string]float64{"50.46": 12, "50.50": 3}
+	advisor := NewResponseGroupAdvisor(WithRoadDistance(func(_ context.Context, from, _ geocode.MapPoint) (float64, error) {
+		for lat, distance := range road {
+			if point, _ := geocode.ParsePoint(lat, "30.52"); point == from {
+				return distance, nil
+			}
+		}
+		return 0, errors.New("no route")
+	}))
+
+	ranked := advisor.Rank(context.Background(), object, models.Alarm{}, groups)
+	if got := rankedIDs(ranked); got[0] != "far" || got[1] != "near" || got[2] != "fail" {
+		t.Fatalf("order = %v, want road distance ordering", got)
+	}
+	if !ranked[0].Suggestion.RoadDistance || ranked[2].Suggestion.RoadDistance || !ranked[2].Suggestion.DistanceKnown {
+		t.Fatalf("road distance fallback is wrong: %+v", ranked)
+	}
+}
+
+func rankedIDs(groups []contracts.ResponseGroup) []string {
+	result := make([]string, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group.ID)
+	}
+	return result
+}
+
+func TestResponseGroupAdvisorUsesCASLGeoZonePolygon(t *testing.T) {
+	t.Parallel()
+
+	zones := buildCASLGeoZoneResponseGroups(
+		[]map[string]any{
+			{
+				"geo_zone_id": "7",
+				"mgrs":        []any{"21"},
+				"polygon":     `[[50.40,30.40],[50.40,30.65],[50.50,30.65],[50.50,30.40]]`,
+			},
+			{
+				"geo_zone_id": float64(8),
+				"mgrs":        []any{"22"},
+				"coordinates": []any{
+					map[string]any{"lat": 49.80, "lng": 23.95},
+					map[string]any{"lat": 49.80, "lng": 24.10},
+					map[string]any{"lat": 49.90, "lng": 24.10},
+					map[string]any{"lat": 49.90, "lng": 23.95},
+				},
+			},
+			{"geo_zone_id": "99", "mgrs": []any{"23"}},
+		},
+		[]map[string]any{{"mgr_id": "21", "name": "ГМР Центр"}, {"mgr_id": "22", "name": "ГМР Львів"}},
+	)
+
+	object := models.Object{Latitude: "50.4501", Longitude: "30.5234"}
+	applyCASLResponseGroups(&object, 0, zones)
+	if object.PreferredResponseGroupID != "21" || object.PreferredResponseGroupName != "ГМР Центр" {
+		t.Fatalf("groups by polygon = %q / %q, want 21 / ГМР Центр", object.PreferredResponseGroupID, object.PreferredResponseGroupName)
+	}
+
+	assigned := models.Object{Latitude: "50.4501", Longitude: "30.5234"}
+	applyCASLResponseGroups(&assigned, 99, zones)
+	if assigned.PreferredResponseGroupID != "23" {
+		t.Fatalf("assigned geo zone must win over the polygon, got %q", assigned.PreferredResponseGroupID)
+	}
+
+	outside := models.Object{Latitude: "48.4647", Longitude: "35.0462"}
+	applyCASLResponseGroups(&outside, 0, zones)
+	if outside.PreferredResponseGroupID != "" {
+		t.Fatalf("object outside every polygon got groups %q", outside.PreferredResponseGroupID)
+	}
+
+	groups := []contracts.ResponseGroup{
+		{ID: "22", Name: "Близька чужа", Status: contracts.ResponseGroupStatusFree, Latitude: "50.4510", Longitude: "30.5240"},
+		{ID: "21", Name: "Зонова", Status: contracts.ResponseGroupStatusFree, Latitude: "50.48", Longitude: "30.60"},
+	}
+	ranked := NewResponseGroupAdvisor().Rank(context.Background(), &object, models.Alarm{}, groups)
+	if ranked[0].ID != "21" || !ranked[0].Suggestion.InObjectGeoZone || ranked[1].Suggestion.InObjectGeoZone {
+		t.Fatalf("ranked = %v, want polygon zone group 21 first", rankedIDs(ranked))
+	}
+}
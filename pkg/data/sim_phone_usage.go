@@ -17,6 +17,8 @@ const simPhoneLookupTimeout = 20 * time.Second
 type combinedAdminProvider struct {
 	contracts.AdminProvider
 	lookup contracts.AdminObjectSIMLookupService
+	// owns відсікає ID об'єктів, що належать іншим джерелам (див. combined_admin.go).
+	owns func(objn int64) bool
 }
 
 func (p combinedAdminProvider) FindObjectsBySIMPhone(phone string, excludeObjN *int64) ([]contracts.AdminSIMPhoneUsage, error) {
@@ -159,10 +161,10 @@ func (p *CASLCloudProvider) FindObjectsBySIMPhone(phone string, excludeObjN *int
 	for _, record := range records {
 		device, ok := p.resolveDeviceForObject(record)
 		if !ok {
-			objects = append(objects, mapCASLGrdObjectToObject(record, nil))
+			objects = append(objects, p.mapCASLObject(record, nil))
 			continue
 		}
-		objects = append(objects, mapCASLGrdObjectToObject(record, &device))
+		objects = append(objects, p.mapCASLObject(record, &device))
 	}
 	return findSIMPhoneUsagesInObjects(objects, normalized, excludeObjN), nil
 }
@@ -0,0 +1,196 @@
+package data
+
+import (
+	"strconv"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestBridgeInstanceProviderTranslatesIDs(t *testing.T) {
+	inner := &combinedStubProvider{
+		objects: []models.Object{{ID: 1001, Name: "Філія"}},
+		zones:   map[string][]models.Zone{"1001": {{Number: 1, Name: "Вхід"}}},
+		events:  []models.Event{{ID: 55, ObjectID: 1001}},
+		alarms:  []models.Alarm{{ID: 77, ObjectID: 1001}},
+	}
+	provider := NewBridgeInstanceProvider(inner, 2)
+	scopedID := ids.BridgeInstanceObjectID(2, 1001)
+
+	objects := provider.GetObjects()
+	if len(objects) != 1 || objects[0].ID != scopedID || objects[0].DisplayNumber != "1001" {
+		t.Fatalf("GetObjects() = %+v, want ID %d with display number 1001", objects, scopedID)
+	}
+	if obj := provider.GetObjectByID(strconv.Itoa(scopedID)); obj == nil || obj.ID != scopedID {
+		t.Fatalf("GetObjectByID() = %+v", obj)
+	}
+	if zones := provider.GetZones(strconv.Itoa(scopedID)); len(zones) != 1 {
+		t.Fatalf("GetZones() = %+v, want zones of local object", zones)
+	}
+	events := provider.GetEvents()
+	if len(events) != 1 || events[0].ObjectID != scopedID || events[0].ObjectNumber != "1001" {
+		t.Fatalf("GetEvents() = %+v", events)
+	}
+	alarms := provider.GetAlarms()
+	if len(alarms) != 1 || alarms[0].ObjectID != scopedID || !provider.Owns()(alarms[0].ID) {
+		t.Fatalf("GetAlarms() = %+v", alarms)
+	}
+	if ids.OwnsBridgeInstance(0)(scopedID) {
+		t.Fatal("primary bridge must not own instance IDs")
+	}
+
+	// Номер 1001 основної БД не має потрапляти в БД філії як її об'єкт 1001.
+	if obj := provider.GetObjectByID("1001"); obj != nil {
+		t.Fatalf("GetObjectByID(foreign) = %+v, want nil", obj)
+	}
+	if zones := provider.GetZones("1001"); zones != nil {
+		t.Fatalf("GetZones(foreign) = %+v, want nil", zones)
+	}
+	if err := provider.ProcessAlarm("77", "operator", ""); err == nil {
+		t.Fatal("ProcessAlarm(foreign) must fail")
+	}
+}
+
+func TestCombinedDataProviderRoutesAdminToOwningInstance(t *testing.T) {
+	primaryID := int64(ids.PhoenixInstanceObjectID(0, ids.StablePhoenixID("panel", "L00001")))
+	branchID := int64(ids.PhoenixInstanceObjectID(1, ids.StablePhoenixID("panel", "L00001")))
+	primaryAdmin := &phoenixAdminStub{panel: "primary"}
+	branchAdmin := &phoenixAdminStub{panel: "branch"}
+	mist := &mistAdminStub{combinedStubProvider: &combinedStubProvider{}}
+	provider := NewMultiSourceDataProvider(
+		ProviderSource{Name: "bridge", Instance: PrimarySourceInstance, Provider: mist, OwnsObjectID: ids.OwnsBridgeInstance(0)},
+		ProviderSource{Name: "bridge:Філія", Instance: "Філія", Provider: &combinedStubProvider{}, OwnsObjectID: ids.OwnsBridgeInstance(1)},
+		ProviderSource{Name: "phoenix", Instance: PrimarySourceInstance, Provider: &phoenixAdminSourceStub{combinedStubProvider: &combinedStubProvider{}, admin: primaryAdmin}, OwnsObjectID: ids.OwnsPhoenixInstance(0)},
+		ProviderSource{Name: "phoenix:Філія", Instance: "Філія", Provider: &phoenixAdminSourceStub{combinedStubProvider: &combinedStubProvider{}, admin: branchAdmin}, OwnsObjectID: ids.OwnsPhoenixInstance(1)},
+	)
+
+	phoenix := provider.PhoenixObjectAdmin()
+	for objn, want := range map[int64]string{primaryID: "primary", branchID: "branch"} {
+		card, err := phoenix.GetObjectCard(objn)
+		if err != nil || card.PanelID != want {
+			t.Fatalf("PhoenixObjectAdmin().GetObjectCard(%d) = %+v, %v; want %s", objn, card, err, want)
+		}
+	}
+
+	admin := provider.AdminProvider()
+	if _, err := admin.GetObjectCard(15); err != nil {
+		t.Fatalf("AdminProvider().GetObjectCard(primary) error = %v", err)
+	}
+	if _, err := admin.GetObjectCard(int64(ids.BridgeInstanceObjectID(1, 15))); err == nil {
+		t.Fatal("AdminProvider() must refuse objects of another MIST instance")
+	}
+	if mist.cardCalls != 1 {
+		t.Fatalf("primary MIST admin calls = %d, want 1", mist.cardCalls)
+	}
+}
+
+type phoenixAdminStub struct {
+	contracts.PhoenixObjectAdminProvider
+	panel string
+}
+
+func (s *phoenixAdminStub) GetObjectCard(objn int64) (contracts.AdminObjectCard, error) {
+	return contracts.AdminObjectCard{ObjN: objn, PanelID: s.panel}, nil
+}
+
+type phoenixAdminSourceStub struct {
+	*combinedStubProvider
+	admin contracts.PhoenixObjectAdminProvider
+}
+
+func (s *phoenixAdminSourceStub) PhoenixObjectAdmin() contracts.PhoenixObjectAdminProvider {
+	return s.admin
+}
+
+type mistAdminStub struct {
+	*combinedStubProvider
+	contracts.AdminProvider
+	cardCalls int
+}
+
+func (s *mistAdminStub) GetObjectCard(objn int64) (contracts.AdminObjectCard, error) {
+	s.cardCalls++
+	return contracts.AdminObjectCard{ObjN: objn}, nil
+}
+
+func TestPhoenixInstanceSlotScopesPanelIDs(t *testing.T) {
+	primary := NewPhoenixDataProvider(nil, "")
+	primary.SetInstanceSlot(0)
+	branch := NewPhoenixDataProvider(nil, "")
+	branch.SetInstanceSlot(3)
+
+	primaryID := primary.registerPanelID("L00028")
+	branchID := branch.registerPanelID("L00028")
+	if primaryID == branchID {
+		t.Fatalf("same panel on two servers got the same ID %d", primaryID)
+	}
+	if !ids.OwnsPhoenixInstance(0)(primaryID) {
+		t.Fatalf("primary ID %d is outside slot 0", primaryID)
+	}
+	if !ids.OwnsPhoenixInstance(3)(branchID) {
+		t.Fatalf("branch ID %d is outside slot 3", branchID)
+	}
+}
+
+func TestCombinedDataProviderLabelsSourceInstances(t *testing.T) {
+	primaryID := ids.BridgeInstanceObjectID(0, 15)
+	branchID := ids.BridgeInstanceObjectID(1, 15)
+	primary := &combinedStubProvider{
+		objects: []models.Object{{ID: primaryID}},
+		alarms:  []models.Alarm{{ID: primaryID, ObjectID: primaryID}},
+	}
+	branch := &combinedStubProvider{
+		objects:    []models.Object{{ID: branchID}},
+		alarms:     []models.Alarm{{ID: branchID, ObjectID: branchID}},
+		healthInfo: contracts.FrontendSourceHealthInfo{HealthStatus: contracts.FrontendSourceHealthStatusOffline},
+	}
+	provider := NewMultiSourceDataProvider(
+		ProviderSource{Name: "bridge", Instance: PrimarySourceInstance, Provider: primary, OwnsObjectID: ids.OwnsBridgeInstance(0), OwnsAlarmID: ids.OwnsBridgeInstance(0)},
+		ProviderSource{Name: "bridge:Філія", Instance: "Філія", Provider: branch, OwnsObjectID: ids.OwnsBridgeInstance(1), OwnsAlarmID: ids.OwnsBridgeInstance(1)},
+	)
+
+	instances := make(map[int]string)
+	for _, obj := range provider.GetObjects() {
+		instances[obj.ID] = obj.SourceInstance
+	}
+	if instances[primaryID] != PrimarySourceInstance || instances[branchID] != "Філія" {
+		t.Fatalf("object instances = %v", instances)
+	}
+	for _, alarm := range provider.GetAlarms() {
+		if alarm.ID == branchID && alarm.SourceInstance != "Філія" {
+			t.Fatalf("alarm instance = %q, want Філія", alarm.SourceInstance)
+		}
+	}
+
+	capabilities := provider.FrontendSourceCapabilities()
+	if len(capabilities) != 2 {
+		t.Fatalf("FrontendSourceCapabilities() = %+v, want one entry per instance", capabilities)
+	}
+	if capabilities[1].Instance != "Філія" || capabilities[1].Source != contracts.FrontendSourceBridge {
+		t.Fatalf("branch capability = %+v", capabilities[1])
+	}
+}
+
+func TestCASLProviderSourcesAddsInstancesAfterPults(t *testing.T) {
+	sources := CASLProviderSources(config.DBConfig{
+		CASLPultID: 7,
+		SourceInstances: []config.SourceInstance{
+			{Name: "Захід", Type: config.SourceInstanceCASL, BaseURL: "http://casl-west", PultID: 3},
+		},
+	})
+	t.Cleanup(func() { shutdownCASLSources(sources) })
+
+	if len(sources) != 2 {
+		t.Fatalf("CASLProviderSources() returned %d sources, want 2", len(sources))
+	}
+	if sources[0].Instance != PrimarySourceInstance || sources[1].Name != "casl:Захід" || sources[1].Instance != "Захід" {
+		t.Fatalf("CASLProviderSources() = %+v", sources)
+	}
+	objectID := ids.CASLPultObjectID(1, ids.CASLObjectIDNamespaceStart+15)
+	if !sources[1].OwnsObjectID(objectID) || sources[0].OwnsObjectID(objectID) {
+		t.Fatal("CASL instance must own its own pult slot")
+	}
+}
@@ -14,12 +14,16 @@ func GetObjectsList(ctx context.Context, db *sqlx.DB) ([]ObjectInfoRow, error) {
 		SELECT
 			oi.OBJUIN, oi.OBJN, oi.OBJFULLNAME1, oi.OBJSHORTNAME1, oi.ADDRESS1, oi.CONTRACT1, 
 			oi.ENG1, oi.GSMPHONE, oi.GSMPHONE2, oi.OBJCHAN, oi.RESERVLONG2, oi.RESERVTEXT, oi.SBSA, oi.SBSB,
+			oi.OBJTYPEID, oi.OBJPRIORITY1,
 			os.ALARMSTATE1, os.GUARDSTATE1, os.TECHALARMSTATE1,
 			os.BLOCKEDARMED_ON_OFF,
-			ol.ISCONNSTATE1
+			os.TESTCONTROL1, os.TESTTIME1,
+			ol.ISCONNSTATE1,
+			tt.LASTTESTTIME1
 		FROM OBJECTS_INFO oi
 		JOIN OBJECTS_LA ol ON ol.OBJUIN = oi.OBJUIN
 		JOIN OBJECTS_STATE os ON os.OBJUIN = oi.OBJUIN
+		LEFT JOIN TBL_TESTCONTROL tt ON tt.OBJN = oi.OBJN
 		WHERE oi.OBJTYPEID <> 1
 		ORDER BY oi.OBJN
 	`
@@ -18,15 +18,22 @@ type ObjectInfoRow struct {
 	ReservText    *string `db:"RESERVTEXT"`
 	SBSA          *string `db:"SBSA"`
 	SBSB          *string `db:"SBSB"`
+	ObjTypeId     *int64  `db:"OBJTYPEID"`
+	ObjPriority1  *int64  `db:"OBJPRIORITY1"`
 
 	// Поля з OBJECTS_STATE
 	AlarmState1       *int64 `db:"ALARMSTATE1"`
 	GuardState1       *int64 `db:"GUARDSTATE1"`
 	TechAlarmState1   *int64 `db:"TECHALARMSTATE1"`
 	BlockedArmedOnOff *int16 `db:"BLOCKEDARMED_ON_OFF"`
+	TestControl1      *int64 `db:"TESTCONTROL1"`
+	TestTime1         *int64 `db:"TESTTIME1"`
 
 	// Поля з OBJECTS_LA
 	IsConnState1 *int64 `db:"ISCONNSTATE1"`
+
+	// Поле з TBL_TESTCONTROL
+	LastTestTime1 *time.Time `db:"LASTTESTTIME1"`
 }
 
 type ObjectDetailRow struct {
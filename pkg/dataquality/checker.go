@@ -0,0 +1,646 @@
+// Package dataquality validates object cards from every configured data source.
+package dataquality
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/phonenumbers"
+
+	"obj_catalog_fyne_v3/pkg/caslobject"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/geocode"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+const (
+	SeverityError = "error"
+	SeverityWarn  = "warn"
+	SeverityInfo  = "info"
+)
+
+const (
+	CodeMissingCoordinates = "MISSING_COORDINATES"
+	CodeInvalidPhone       = "INVALID_PHONE"
+	CodeInvalidSIM         = "INVALID_SIM"
+	CodeDuplicateSIM       = "DUPLICATE_SIM"
+	CodeUnnamedZone        = "UNNAMED_ZONE"
+	CodeNoContacts         = "NO_CONTACTS"
+	CodeUnknownPPK         = "UNKNOWN_PPK"
+	CodeStaleTest          = "STALE_TEST"
+)
+
+const (
+	defaultStaleTestAfter = 72 * time.Hour
+	defaultWorkers        = 4
+	defaultSIMLookupLimit = 200
+)
+
+// Issue is a single data-quality finding for an object.
+type Issue struct {
+	Severity     string
+	Code         string
+	Source       contracts.FrontendSource
+	ObjectID     int
+	ObjectNumber string
+	ObjectName   string
+	Field        string
+	Details      string
+}
+
+// ObjectDetailsProvider loads the full card of one object with zones and contacts in a single call.
+type ObjectDetailsProvider interface {
+	GetObjectBaseDetails(objectID string) (*models.Object, []models.Zone, []models.Contact)
+}
+
+type zoneProvider interface {
+	GetZones(objectID string) []models.Zone
+}
+
+type contactProvider interface {
+	GetEmployees(objectID string) []models.Contact
+}
+
+// Checker runs data-quality rules over objects of all sources.
+type Checker struct {
+	now            func() time.Time
+	staleTestAfter time.Duration
+	knownPPK       map[string]struct{}
+	simLookup      contracts.AdminObjectSIMLookupService
+	simLookupLimit int
+	workers        int
+	progress       func(done int, total int)
+}
+
+// Option configures Checker.
+type Option func(*Checker)
+
+// WithNow overrides the clock used for stale test detection.
+func WithNow(now func() time.Time) Option {
+	return func(c *Checker) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
+// WithStaleTestAfter sets the default age after which the last test is stale.
+// Objects with a configured auto-test period use twice that period instead.
+func WithStaleTestAfter(d time.Duration) Option {
+	return func(c *Checker) {
+		if d > 0 {
+			c.staleTestAfter = d
+		}
+	}
+}
+
+// WithKnownPanelTypes sets the PPK catalog used to validate bridge object device types.
+func WithKnownPanelTypes(names ...string) Option {
+	return func(c *Checker) {
+		for _, name := range names {
+			if key := panelTypeKey(name); key != "" {
+				c.knownPPK[key] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithSIMLookup enables duplicate SIM lookups outside of the loaded object list.
+// At most limit distinct numbers are looked up; limit <= 0 keeps the default.
+func WithSIMLookup(lookup contracts.AdminObjectSIMLookupService, limit int) Option {
+	return func(c *Checker) {
+		c.simLookup = lookup
+		if limit > 0 {
+			c.simLookupLimit = limit
+		}
+	}
+}
+
+// WithWorkers sets how many objects are loaded in parallel.
+func WithWorkers(n int) Option {
+	return func(c *Checker) {
+		if n > 0 {
+			c.workers = n
+		}
+	}
+}
+
+// WithProgress registers a callback invoked after every checked object.
+func WithProgress(fn func(done int, total int)) Option {
+	return func(c *Checker) {
+		c.progress = fn
+	}
+}
+
+// CapabilityOptions derives checker options from optional provider capabilities:
+// the PPK catalog for device type validation and the cross-source SIM lookup.
+func CapabilityOptions(providers ...any) []Option {
+	var opts []Option
+	haveCatalog, haveLookup := false, false
+	for _, provider := range providers {
+		if catalog, ok := provider.(contracts.PPKConstructorReferenceService); ok && !haveCatalog {
+			if items, err := catalog.ListPPKConstructor(); err == nil && len(items) > 0 {
+				names := make([]string, 0, len(items))
+				for _, item := range items {
+					names = append(names, item.Name)
+				}
+				opts = append(opts, WithKnownPanelTypes(names...))
+				haveCatalog = true
+			}
+		}
+		if lookup, ok := provider.(contracts.AdminObjectSIMLookupService); ok && !haveLookup {
+			opts = append(opts, WithSIMLookup(lookup, 0))
+			haveLookup = true
+		}
+	}
+	return opts
+}
+
+// Filter returns issues of the given severity (empty means all) whose text contains query.
+func Filter(issues []Issue, severity string, query string) []Issue {
+	query = strings.ToLower(strings.TrimSpace(query))
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if severity != "" && issue.Severity != severity {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(issueSearchText(issue)), query) {
+			continue
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+// Summary returns a short per-severity count line for the issue list status bar.
+func Summary(issues []Issue) string {
+	counts := make(map[string]int, 3)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+	return fmt.Sprintf("Проблем: %d (помилок: %d, попереджень: %d, інфо: %d)",
+		len(issues), counts[SeverityError], counts[SeverityWarn], counts[SeverityInfo])
+}
+
+func issueSearchText(issue Issue) string {
+	return strings.Join([]string{
+		issue.Source.DisplayName(),
+		issue.ObjectNumber,
+		issue.ObjectName,
+		issue.Field,
+		CodeLabel(issue.Code),
+		issue.Details,
+	}, " ")
+}
+
+// NewChecker creates a data-quality checker.
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{
+		now:            time.Now,
+		staleTestAfter: defaultStaleTestAfter,
+		knownPPK:       make(map[string]struct{}),
+		simLookupLimit: defaultSIMLookupLimit,
+		workers:        defaultWorkers,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+type objectCard struct {
+	object   models.Object
+	zones    []models.Zone
+	contacts []models.Contact
+}
+
+// Run checks every object returned by provider and returns severity-sorted issues.
+// Full object cards are loaded through ObjectDetailsProvider when the provider supports it.
+func (c *Checker) Run(ctx context.Context, provider contracts.ObjectProvider) ([]Issue, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("джерело об'єктів не налаштовано")
+	}
+	var objects []models.Object
+	if ctxProvider, ok := provider.(contracts.ContextObjectProvider); ok {
+		objects = ctxProvider.GetObjectsContext(ctx)
+	} else {
+		objects = provider.GetObjects()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cards := c.loadCards(ctx, provider, objects)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(cards))
+	for _, card := range cards {
+		issues = append(issues, c.CheckObject(card.object, card.zones, card.contacts)...)
+	}
+	issues = append(issues, c.duplicateSIMIssues(ctx, cards)...)
+	SortIssues(issues)
+	return issues, nil
+}
+
+func (c *Checker) loadCards(ctx context.Context, provider contracts.ObjectProvider, objects []models.Object) []objectCard {
+	cards := make([]objectCard, len(objects))
+	jobs := make(chan int)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	for range c.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				cards[index] = loadCard(provider, objects[index])
+				if c.progress != nil {
+					mu.Lock()
+					done++
+					c.progress(done, len(objects))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for index := range objects {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+	return cards
+}
+
+func loadCard(provider contracts.ObjectProvider, object models.Object) objectCard {
+	objectID := strconv.Itoa(object.ID)
+	card := objectCard{object: object}
+	if details, ok := provider.(ObjectDetailsProvider); ok {
+		full, zones, contacts := details.GetObjectBaseDetails(objectID)
+		if full != nil {
+			card.object = *full
+		}
+		card.zones = zones
+		card.contacts = contacts
+		return card
+	}
+	if full := provider.GetObjectByID(objectID); full != nil {
+		card.object = *full
+	}
+	if zones, ok := provider.(zoneProvider); ok {
+		card.zones = zones.GetZones(objectID)
+	}
+	if contacts, ok := provider.(contactProvider); ok {
+		card.contacts = contacts.GetEmployees(objectID)
+	}
+	return card
+}
+
+// CheckObject applies all single-object rules to an already loaded card.
+func (c *Checker) CheckObject(object models.Object, zones []models.Zone, contacts []models.Contact) []Issue {
+	newIssue := func(severity, code, field, details string) Issue {
+		return Issue{
+			Severity:     severity,
+			Code:         code,
+			Source:       contracts.DetectFrontendSourceByObjectID(object.ID),
+			ObjectID:     object.ID,
+			ObjectNumber: ids.ObjectDisplayNumber(object),
+			ObjectName:   strings.TrimSpace(object.Name),
+			Field:        field,
+			Details:      details,
+		}
+	}
+	var issues []Issue
+
+	if _, ok := geocode.ParsePoint(object.Latitude, object.Longitude); !ok {
+		issues = append(issues, newIssue(SeverityWarn, CodeMissingCoordinates, "Координати", "не вказані або некоректні координати об'єкта"))
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{name: "Телефон об'єкта", value: object.Phone},
+		{name: "Телефон на об'єкті", value: object.Phones1},
+	} {
+		if field.name != "Телефон об'єкта" && strings.TrimSpace(field.value) == strings.TrimSpace(object.Phone) {
+			continue
+		}
+		for _, phone := range SplitPhones(field.value) {
+			if _, err := NormalizePhone(phone); err != nil {
+				issues = append(issues, newIssue(SeverityError, CodeInvalidPhone, field.name, fmt.Sprintf("%s: %v", phone, err)))
+			}
+		}
+	}
+
+	for _, sim := range []struct {
+		name  string
+		value string
+	}{
+		{name: "SIM1", value: object.SIM1},
+		{name: "SIM2", value: object.SIM2},
+	} {
+		value := strings.TrimSpace(sim.value)
+		if value == "" {
+			continue
+		}
+		if _, err := NormalizePhone(value); err != nil {
+			issues = append(issues, newIssue(SeverityError, CodeInvalidSIM, sim.name, fmt.Sprintf("%s: %v", value, err)))
+		}
+	}
+
+	unnamed := make([]string, 0)
+	for _, zone := range zones {
+		if strings.TrimSpace(zone.Name) == "" {
+			unnamed = append(unnamed, strconv.Itoa(zone.Number))
+		}
+	}
+	if len(unnamed) > 0 {
+		issues = append(issues, newIssue(SeverityInfo, CodeUnnamedZone, "Зони", "зони без назви: "+strings.Join(unnamed, ", ")))
+	}
+
+	if len(contacts) == 0 {
+		issues = append(issues, newIssue(SeverityWarn, CodeNoContacts, "Відповідальні", "у об'єкта немає жодного контакту"))
+	}
+	for _, contact := range contacts {
+		for _, phone := range SplitPhones(contact.Phone) {
+			if _, err := NormalizePhone(phone); err != nil {
+				details := fmt.Sprintf("%s (%s): %v", phone, blankFallback(strings.TrimSpace(contact.Name), "без імені"), err)
+				issues = append(issues, newIssue(SeverityError, CodeInvalidPhone, "Телефон контакту", details))
+			}
+		}
+	}
+
+	if details, unknown := c.unknownPanelType(object); unknown {
+		issues = append(issues, newIssue(SeverityWarn, CodeUnknownPPK, "Тип ППК", details))
+	}
+
+	if !object.LastTestTime.IsZero() {
+		limit := c.staleTestAfter
+		if object.AutoTestHours > 0 {
+			limit = 2 * time.Duration(object.AutoTestHours) * time.Hour
+		}
+		if age := c.now().Sub(object.LastTestTime); age > limit {
+			details := fmt.Sprintf("останній тест %s (понад %s)", object.LastTestTime.Format("02.01.2006 15:04"), formatHours(limit))
+			issues = append(issues, newIssue(SeverityWarn, CodeStaleTest, "Останній тест", details))
+		}
+	}
+	return issues
+}
+
+func (c *Checker) unknownPanelType(object models.Object) (string, bool) {
+	deviceType := strings.TrimSpace(object.DeviceType)
+	if deviceType == "" {
+		deviceType = strings.TrimSpace(object.PanelMark)
+	}
+	key := panelTypeKey(deviceType)
+	if key == "" {
+		return "тип ППК не вказано", true
+	}
+	if len(c.knownPPK) == 0 || contracts.DetectFrontendSourceByObjectID(object.ID) != contracts.FrontendSourceBridge {
+		return "", false
+	}
+	if _, ok := c.knownPPK[key]; ok {
+		return "", false
+	}
+	return fmt.Sprintf("тип ППК «%s» відсутній у довіднику", deviceType), true
+}
+
+func (c *Checker) duplicateSIMIssues(ctx context.Context, cards []objectCard) []Issue {
+	type simUsage struct {
+		card objectCard
+		slot string
+	}
+	bySIM := make(map[string][]simUsage)
+	order := make([]string, 0)
+	for _, card := range cards {
+		for _, slot := range []struct {
+			name  string
+			value string
+		}{
+			{name: "SIM1", value: card.object.SIM1},
+			{name: "SIM2", value: card.object.SIM2},
+		} {
+			key := simKey(slot.value)
+			if key == "" {
+				continue
+			}
+			if _, seen := bySIM[key]; !seen {
+				order = append(order, key)
+			}
+			bySIM[key] = append(bySIM[key], simUsage{card: card, slot: slot.name})
+		}
+	}
+
+	var issues []Issue
+	lookups := 0
+	for _, key := range order {
+		usages := bySIM[key]
+		labels := make([]string, 0, len(usages))
+		objectIDs := make(map[int]struct{}, len(usages))
+		for _, usage := range usages {
+			objectIDs[usage.card.object.ID] = struct{}{}
+			labels = append(labels, fmt.Sprintf("%s №%s (%s)",
+				contracts.DetectFrontendSourceByObjectID(usage.card.object.ID).DisplayName(),
+				ids.ObjectDisplayNumber(usage.card.object),
+				usage.slot,
+			))
+		}
+		if len(objectIDs) < 2 && c.simLookup != nil && lookups < c.simLookupLimit && ctx.Err() == nil {
+			lookups++
+			first := usages[0].card.object
+			exclude := int64(first.ID)
+			found, err := c.simLookup.FindObjectsBySIMPhone(key, &exclude)
+			if err == nil {
+				for _, item := range found {
+					if item.ObjN == exclude {
+						continue
+					}
+					objectIDs[int(item.ObjN)] = struct{}{}
+					labels = append(labels, fmt.Sprintf("%s №%s (%s)",
+						blankFallback(strings.TrimSpace(item.Source), "інше джерело"),
+						blankFallback(strings.TrimSpace(item.DisplayNumber), strconv.FormatInt(item.ObjN, 10)),
+						strings.TrimSpace(item.Slot),
+					))
+				}
+			}
+		}
+		if len(objectIDs) < 2 {
+			continue
+		}
+		details := fmt.Sprintf("SIM %s використовується на: %s", key, strings.Join(labels, "; "))
+		reported := make(map[int]struct{}, len(usages))
+		for _, usage := range usages {
+			object := usage.card.object
+			if _, done := reported[object.ID]; done {
+				continue
+			}
+			reported[object.ID] = struct{}{}
+			issues = append(issues, Issue{
+				Severity:     SeverityError,
+				Code:         CodeDuplicateSIM,
+				Source:       contracts.DetectFrontendSourceByObjectID(object.ID),
+				ObjectID:     object.ID,
+				ObjectNumber: ids.ObjectDisplayNumber(object),
+				ObjectName:   strings.TrimSpace(object.Name),
+				Field:        usage.slot,
+				Details:      details,
+			})
+		}
+	}
+	return issues
+}
+
+// SortIssues orders issues by severity, source, object number and code.
+func SortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		left, right := issues[i], issues[j]
+		if a, b := severityRank(left.Severity), severityRank(right.Severity); a != b {
+			return a < b
+		}
+		if left.Source != right.Source {
+			return left.Source < right.Source
+		}
+		if left.ObjectID != right.ObjectID {
+			return left.ObjectID < right.ObjectID
+		}
+		return left.Code < right.Code
+	})
+}
+
+// SeverityLabel returns the operator-facing name of a severity.
+func SeverityLabel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "Помилка"
+	case SeverityWarn:
+		return "Попередження"
+	case SeverityInfo:
+		return "Інфо"
+	default:
+		return severity
+	}
+}
+
+// CodeLabel returns the operator-facing name of an issue code.
+func CodeLabel(code string) string {
+	switch code {
+	case CodeMissingCoordinates:
+		return "Немає координат"
+	case CodeInvalidPhone:
+		return "Некоректний телефон"
+	case CodeInvalidSIM:
+		return "Некоректна SIM"
+	case CodeDuplicateSIM:
+		return "Дублікат SIM"
+	case CodeUnnamedZone:
+		return "Зона без назви"
+	case CodeNoContacts:
+		return "Немає контактів"
+	case CodeUnknownPPK:
+		return "Невідомий ППК"
+	case CodeStaleTest:
+		return "Застарілий тест"
+	default:
+		return code
+	}
+}
+
+// SplitPhones splits a free-form phone field into separate numbers.
+func SplitPhones(raw string) []string {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		switch r {
+		case ',', ';', '/', '\n', '\r', '\t':
+			return true
+		default:
+			return false
+		}
+	})
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if value := strings.TrimSpace(part); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// NormalizePhone validates a Ukrainian phone number and returns it in E.164 format.
+func NormalizePhone(raw string) (string, error) {
+	formatted, err := caslobject.NormalizeUAPhone(raw)
+	if err != nil {
+		return "", err
+	}
+	if formatted == "" {
+		return "", nil
+	}
+	parsed, err := phonenumbers.Parse("+"+digitsOnly(formatted), "UA")
+	if err != nil || !phonenumbers.IsValidNumberForRegion(parsed, "UA") {
+		return "", fmt.Errorf("номер не відповідає нумерації України")
+	}
+	return phonenumbers.Format(parsed, phonenumbers.E164), nil
+}
+
+func simKey(raw string) string {
+	normalized, err := NormalizePhone(raw)
+	if err != nil || normalized == "" {
+		return ""
+	}
+	// +380XXXXXXXXX -> 0XXXXXXXXX, як у пошуку SIM по джерелах.
+	return "0" + strings.TrimPrefix(normalized, "+380")
+}
+
+func panelTypeKey(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "—" || value == "-" {
+		return ""
+	}
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 0
+	case SeverityWarn:
+		return 1
+	case SeverityInfo:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func formatHours(d time.Duration) string {
+	return strconv.Itoa(int(d.Hours())) + " год"
+}
+
+func digitsOnly(value string) string {
+	var builder strings.Builder
+	for _, char := range value {
+		if char >= '0' && char <= '9' {
+			builder.WriteRune(char)
+		}
+	}
+	return builder.String()
+}
+
+func blankFallback(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,237 @@
+package dataquality
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type stubObjectProvider struct {
+	objects  []models.Object
+	zones    map[int][]models.Zone
+	contacts map[int][]models.Contact
+}
+
+func (p stubObjectProvider) GetObjects() []models.Object {
+	return p.objects
+}
+
+func (p stubObjectProvider) GetObjectByID(id string) *models.Object {
+	for index := range p.objects {
+		if strconv.Itoa(p.objects[index].ID) == id {
+			object := p.objects[index]
+			return &object
+		}
+	}
+	return nil
+}
+
+func (p stubObjectProvider) GetZones(objectID string) []models.Zone {
+	id, _ := strconv.Atoi(objectID)
+	return p.zones[id]
+}
+
+func (p stubObjectProvider) GetEmployees(objectID string) []models.Contact {
+	id, _ := strconv.Atoi(objectID)
+	return p.contacts[id]
+}
+
+type stubSIMLookup struct {
+	usages map[string][]contracts.AdminSIMPhoneUsage
+	calls  int
+}
+
+func (s *stubSIMLookup) FindObjectsBySIMPhone(phone string, _ *int64) ([]contracts.AdminSIMPhoneUsage, error) {
+	s.calls++
+	return s.usages[phone], nil
+}
+
+func issueCodes(issues []Issue, objectID int) map[string]Issue {
+	result := make(map[string]Issue)
+	for _, issue := range issues {
+		if issue.ObjectID == objectID {
+			result[issue.Code] = issue
+		}
+	}
+	return result
+}
+
+func TestCheckObjectFlagsCardProblems(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	checker := NewChecker(
+		WithNow(func() time.Time { return now }),
+		WithKnownPanelTypes("Тірас-16П", "Орион"),
+	)
+	object := models.Object{
+		ID:           1001,
+		Name:         "Магазин",
+		Phone:        "050 123 45 67, 12345",
+		SIM1:         "0991234567",
+		SIM2:         "abc",
+		DeviceType:   "Невідомий прилад",
+		LastTestTime: now.Add(-100 * time.Hour),
+	}
+	zones := []models.Zone{{Number: 1, Name: "Вхід"}, {Number: 2}}
+
+	got := issueCodes(checker.CheckObject(object, zones, nil), object.ID)
+	for _, code := range []string{
+		CodeMissingCoordinates,
+		CodeInvalidPhone,
+		CodeInvalidSIM,
+		CodeUnnamedZone,
+		CodeNoContacts,
+		CodeUnknownPPK,
+		CodeStaleTest,
+	} {
+		if _, ok := got[code]; !ok {
+			t.Fatalf("expected %s issue, got %+v", code, got)
+		}
+	}
+	if got[CodeInvalidSIM].Field != "SIM2" {
+		t.Fatalf("invalid SIM field = %q, want SIM2", got[CodeInvalidSIM].Field)
+	}
+	if got[CodeUnnamedZone].Details != "зони без назви: 2" {
+		t.Fatalf("unnamed zone details = %q", got[CodeUnnamedZone].Details)
+	}
+}
+
+func TestCheckObjectAcceptsCleanCard(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	checker := NewChecker(
+		WithNow(func() time.Time { return now }),
+		WithKnownPanelTypes("Тірас-16П"),
+	)
+	object := models.Object{
+		ID:            1002,
+		Latitude:      "50,4501",
+		Longitude:     "30.5234",
+		Phone:         "+38 (050) 123-45-67",
+		SIM1:          "380991234567",
+		DeviceType:    " тірас-16п ",
+		AutoTestHours: 72,
+		LastTestTime:  now.Add(-100 * time.Hour),
+	}
+	contacts := []models.Contact{{Name: "Іван", Phone: "067 765 43 21"}}
+
+	if issues := checker.CheckObject(object, []models.Zone{{Number: 1, Name: "Вхід"}}, contacts); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+}
+
+func TestCheckObjectValidatesPPKCatalogOnlyForBridge(t *testing.T) {
+	t.Parallel()
+
+	checker := NewChecker(WithKnownPanelTypes("Тірас-16П"))
+	casl := models.Object{ID: ids.CASLObjectIDNamespaceStart + 1, DeviceType: "Lun-11"}
+	if _, ok := issueCodes(checker.CheckObject(casl, nil, nil), casl.ID)[CodeUnknownPPK]; ok {
+		t.Fatal("CASL device type must not be checked against the bridge PPK catalog")
+	}
+	empty := models.Object{ID: ids.CASLObjectIDNamespaceStart + 2, DeviceType: "—"}
+	if _, ok := issueCodes(checker.CheckObject(empty, nil, nil), empty.ID)[CodeUnknownPPK]; !ok {
+		t.Fatal("empty device type must be reported for every source")
+	}
+}
+
+func TestRunDetectsDuplicateSIMsAcrossSources(t *testing.T) {
+	t.Parallel()
+
+	caslID := ids.CASLObjectIDNamespaceStart + 5
+	provider := stubObjectProvider{
+		objects: []models.Object{
+			{ID: 10, Name: "Міст", SIM1: "+380501112233"},
+			{ID: caslID, Name: "CASL", SIM2: "050 111 22 33"},
+			{ID: 11, Name: "Окремий", SIM1: "0674445566"},
+		},
+	}
+	lookup := &stubSIMLookup{usages: map[string][]contracts.AdminSIMPhoneUsage{
+		"0674445566": {{ObjN: 900, DisplayNumber: "900", Slot: "SIM1", Source: "МІСТ"}},
+	}}
+
+	issues, err := NewChecker(WithSIMLookup(lookup, 0)).Run(context.Background(), provider)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, ok := issueCodes(issues, 10)[CodeDuplicateSIM]; !ok {
+		t.Fatal("bridge object must be reported as duplicate SIM")
+	}
+	if issue, ok := issueCodes(issues, caslID)[CodeDuplicateSIM]; !ok || issue.Field != "SIM2" {
+		t.Fatalf("CASL object duplicate = %+v, %v", issue, ok)
+	}
+	if _, ok := issueCodes(issues, 11)[CodeDuplicateSIM]; !ok {
+		t.Fatal("SIM found by lookup in another object must be reported")
+	}
+	if lookup.calls != 1 {
+		t.Fatalf("lookup calls = %d, want only numbers without local duplicates", lookup.calls)
+	}
+	if issues[0].Severity != SeverityError {
+		t.Fatalf("issues must start with errors, got %+v", issues[0])
+	}
+}
+
+func TestSortIssuesBySeverityThenSource(t *testing.T) {
+	t.Parallel()
+
+	issues := []Issue{
+		{Severity: SeverityInfo, Source: contracts.FrontendSourceBridge, ObjectID: 1},
+		{Severity: SeverityWarn, Source: contracts.FrontendSourcePhoenix, ObjectID: 2},
+		{Severity: SeverityWarn, Source: contracts.FrontendSourceBridge, ObjectID: 3},
+		{Severity: SeverityError, Source: contracts.FrontendSourceCASL, ObjectID: 4},
+	}
+	SortIssues(issues)
+	want := []int{4, 3, 2, 1}
+	for index, id := range want {
+		if issues[index].ObjectID != id {
+			t.Fatalf("issues[%d].ObjectID = %d, want %d", index, issues[index].ObjectID, id)
+		}
+	}
+}
+
+type stubPPKCatalog struct {
+	items []contracts.PPKConstructorItem
+}
+
+func (s stubPPKCatalog) ListPPKConstructor() ([]contracts.PPKConstructorItem, error) {
+	return s.items, nil
+}
+
+func TestCapabilityOptionsUsePPKCatalogAndSIMLookup(t *testing.T) {
+	t.Parallel()
+
+	lookup := &stubSIMLookup{}
+	opts := CapabilityOptions(nil, stubPPKCatalog{items: []contracts.PPKConstructorItem{{Name: "Тірас-16П"}}}, lookup)
+	checker := NewChecker(opts...)
+	if checker.simLookup != lookup {
+		t.Fatal("SIM lookup must be taken from provider capabilities")
+	}
+	if _, ok := checker.knownPPK[panelTypeKey("ТІРАС-16П")]; !ok {
+		t.Fatalf("known PPK = %+v", checker.knownPPK)
+	}
+}
+
+func TestFilterAndSummary(t *testing.T) {
+	t.Parallel()
+
+	issues := []Issue{
+		{Severity: SeverityError, Code: CodeInvalidSIM, ObjectNumber: "10", ObjectName: "Аптека"},
+		{Severity: SeverityWarn, Code: CodeNoContacts, ObjectNumber: "11", ObjectName: "Склад"},
+		{Severity: SeverityWarn, Code: CodeStaleTest, ObjectNumber: "12", ObjectName: "Аптека 2"},
+	}
+	if got := Filter(issues, SeverityWarn, "аптека"); len(got) != 1 || got[0].ObjectNumber != "12" {
+		t.Fatalf("Filter() = %+v", got)
+	}
+	if got := Filter(issues, "", "некоректна sim"); len(got) != 1 || got[0].ObjectNumber != "10" {
+		t.Fatalf("Filter() by code label = %+v", got)
+	}
+	if got, want := Summary(issues), "Проблем: 3 (помилок: 1, попереджень: 2, інфо: 0)"; got != want {
+		t.Fatalf("Summary() = %q, want %q", got, want)
+	}
+}
@@ -3,23 +3,29 @@ package dataruntime
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 
+	"obj_catalog_fyne_v3/pkg/alarmpriority"
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/data"
 	"obj_catalog_fyne_v3/pkg/database"
 	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
 )
 
 type managedDBResource struct {
 	db           *sqlx.DB
 	healthCancel context.CancelFunc
 	source       contracts.FrontendSource
+	instance     string
 	health       *database.ConnectionHealth
 }
 
@@ -40,8 +46,10 @@ type Runtime struct {
 }
 
 // SourceHealth reports the latest known connectivity state of an enabled source.
+// Instance is set when several sources of the same type are configured.
 type SourceHealth struct {
 	Source     contracts.FrontendSource
+	Instance   string
 	Status     contracts.FrontendSourceHealthStatus
 	HealthText string
 }
@@ -83,14 +91,16 @@ func New(cfg config.DBConfig, store ConfigStore, verifyConnectivity bool) (*Runt
 			source:       contracts.FrontendSourceBridge,
 			health:       health,
 		})
+		dbOptions := []data.DBProviderOption{
+			data.WithVodafoneConfigStore(store),
+			data.WithKyivstarConfigStore(store),
+		}
+		if uploadStore, ok := store.(config.ReportUploadConfigStore); ok {
+			dbOptions = append(dbOptions, data.WithReportUploadConfigStore(uploadStore))
+		}
 		sources = append(sources, data.ProviderSource{
-			Name: "bridge",
-			Provider: data.NewDBDataProvider(
-				db,
-				dsn,
-				data.WithVodafoneConfigStore(store),
-				data.WithKyivstarConfigStore(store),
-			),
+			Name:     "bridge",
+			Provider: data.NewDBDataProvider(db, dsn, dbOptions...),
 		})
 	}
 
@@ -143,21 +153,60 @@ func New(cfg config.DBConfig, store ConfigStore, verifyConnectivity bool) (*Runt
 	}
 
 	if caslEnabled {
-		sources = append(sources, data.ProviderSource{
-			Name: "casl",
-			Provider: data.NewCASLCloudProvider(
-				cfg.CASLBaseURL,
-				cfg.CASLToken,
-				cfg.CASLPultID,
-				cfg.CASLEmail,
-				cfg.CASLPass,
-			),
-			OwnsObjectID: ids.IsCASLObjectID,
-			OwnsAlarmID:  ids.IsCASLObjectID,
+		sources = append(sources, data.CASLProviderSources(cfg)...)
+	}
+
+	sources, instanceDBs := AppendSourceInstances(sources, cfg, verifyConnectivity)
+	for i := range runtime.managedDBs {
+		if primaryHasInstances(sources, runtime.managedDBs[i].source) {
+			runtime.managedDBs[i].instance = data.PrimarySourceInstance
+		}
+	}
+	for _, instanceDB := range instanceDBs {
+		runtime.managedDBs = append(runtime.managedDBs, managedDBResource{
+			db:           instanceDB.DB,
+			healthCancel: instanceDB.HealthCancel,
+			source:       instanceDB.Source,
+			instance:     instanceDB.Instance,
+			health:       instanceDB.Health,
 		})
 	}
 
-	runtime.Provider = data.NewMultiSourceDataProvider(sources...)
+	provider := data.NewMultiSourceDataProvider(sources...)
+	if dispatchStore, ok := store.(config.DispatchConfigStore); ok {
+		provider.SetDispatchConfigStore(dispatchStore)
+	}
+	if maintenanceStore, ok := store.(config.MaintenanceConfigStore); ok {
+		if maintenanceCfg := maintenanceStore.LoadMaintenanceConfig(); maintenanceCfg.Enabled {
+			suppressor, err := maintenance.Open(maintenanceCfg.WindowsPath, time.Duration(maintenanceCfg.ReminderHours)*time.Hour)
+			if err != nil {
+				log.Warn().Err(err).Str("path", maintenanceCfg.WindowsPath).Msg("Вікна обслуговування вимкнено: не вдалося відкрити файл")
+			} else {
+				provider.SetMaintenanceSuppressor(suppressor)
+			}
+		}
+	}
+	if testStore, ok := store.(config.TestSupervisionConfigStore); ok {
+		if settings := testsupervision.SettingsFromConfig(testStore.LoadTestSupervisionConfig()); settings.Enabled() {
+			provider.SetTestSupervisor(testsupervision.NewSupervisor(settings))
+		}
+	}
+	if openCloseStore, ok := store.(config.OpenCloseConfigStore); ok {
+		if openCloseCfg := openCloseStore.LoadOpenCloseConfig(); openCloseCfg.Enabled {
+			supervisor, err := openclose.Open(openCloseCfg.SchedulesPath, time.Duration(openCloseCfg.EscalateMinutes)*time.Minute)
+			if err != nil {
+				log.Warn().Err(err).Str("path", openCloseCfg.SchedulesPath).Msg("Контроль розкладу вимкнено: не вдалося відкрити файл")
+			} else {
+				provider.SetOpenCloseSupervisor(supervisor)
+			}
+		}
+	}
+	if priorityStore, ok := store.(config.AlarmPriorityConfigStore); ok {
+		if priorityCfg := priorityStore.LoadAlarmPriorityConfig(); priorityCfg.Enabled {
+			provider.SetAlarmPriorityModel(alarmpriority.NewModel(priorityCfg))
+		}
+	}
+	runtime.Provider = provider
 	return runtime, nil
 }
 
@@ -167,8 +216,18 @@ func (r *Runtime) SourceHealth() []SourceHealth {
 		return nil
 	}
 
-	healthBySource := make(map[contracts.FrontendSource]contracts.FrontendSourceHealthStatus, 3)
-	healthTextBySource := make(map[contracts.FrontendSource]string, 3)
+	type healthKey struct {
+		source   contracts.FrontendSource
+		instance string
+	}
+	healthBySource := make(map[healthKey]contracts.FrontendSourceHealthStatus, 3)
+	healthTextBySource := make(map[healthKey]string, 3)
+	instancesBySource := make(map[contracts.FrontendSource][]string, 3)
+	addInstance := func(key healthKey) {
+		if !slices.Contains(instancesBySource[key.source], key.instance) {
+			instancesBySource[key.source] = append(instancesBySource[key.source], key.instance)
+		}
+	}
 	for _, resource := range r.managedDBs {
 		checked, online := resource.health.Status()
 		status := contracts.FrontendSourceHealthStatusUnknown
@@ -178,19 +237,23 @@ func (r *Runtime) SourceHealth() []SourceHealth {
 				status = contracts.FrontendSourceHealthStatusOnline
 			}
 		}
-		healthBySource[resource.source] = status
+		key := healthKey{source: resource.source, instance: resource.instance}
+		healthBySource[key] = status
+		addInstance(key)
 	}
 
 	if capabilityProvider, ok := r.Provider.(interface {
 		FrontendSourceCapabilities() []contracts.FrontendSourceCapability
 	}); ok {
 		for _, capability := range capabilityProvider.FrontendSourceCapabilities() {
+			key := healthKey{source: capability.Source, instance: capability.Instance}
 			if capability.HealthStatus != "" {
-				healthBySource[capability.Source] = capability.HealthStatus
+				healthBySource[key] = capability.HealthStatus
 			}
 			if capability.HealthText != "" {
-				healthTextBySource[capability.Source] = capability.HealthText
+				healthTextBySource[key] = capability.HealthText
 			}
+			addInstance(key)
 		}
 	}
 
@@ -199,15 +262,23 @@ func (r *Runtime) SourceHealth() []SourceHealth {
 		if !enabled {
 			return
 		}
-		status, ok := healthBySource[source]
-		if !ok || status == "" {
-			status = contracts.FrontendSourceHealthStatusUnknown
+		instances := instancesBySource[source]
+		if len(instances) == 0 {
+			instances = []string{""}
+		}
+		for _, instance := range instances {
+			key := healthKey{source: source, instance: instance}
+			status, ok := healthBySource[key]
+			if !ok || status == "" {
+				status = contracts.FrontendSourceHealthStatusUnknown
+			}
+			result = append(result, SourceHealth{
+				Source:     source,
+				Instance:   instance,
+				Status:     status,
+				HealthText: strings.TrimSpace(healthTextBySource[key]),
+			})
 		}
-		result = append(result, SourceHealth{
-			Source:     source,
-			Status:     status,
-			HealthText: strings.TrimSpace(healthTextBySource[source]),
-		})
 	}
 	appendSource(r.FirebirdEnabled, contracts.FrontendSourceBridge)
 	appendSource(r.PhoenixEnabled, contracts.FrontendSourcePhoenix)
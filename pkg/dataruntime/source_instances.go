@@ -0,0 +1,150 @@
+package dataruntime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/data"
+	"obj_catalog_fyne_v3/pkg/database"
+	"obj_catalog_fyne_v3/pkg/ids"
+)
+
+// InstanceDB is a database opened for an extra Firebird or Phoenix source instance.
+type InstanceDB struct {
+	Source       contracts.FrontendSource
+	Instance     string
+	Label        string
+	DB           *sqlx.DB
+	HealthCancel context.CancelFunc
+	Health       *database.ConnectionHealth
+}
+
+// Close stops the health check and closes the database.
+func (d InstanceDB) Close() {
+	if d.HealthCancel != nil {
+		d.HealthCancel()
+	}
+	if d.DB != nil {
+		_ = d.DB.Close()
+	}
+}
+
+// AppendSourceInstances adds extra Firebird and Phoenix instances from
+// cfg.SourceInstances next to the primary "bridge" and "phoenix" sources.
+//
+// Instances of a type are attached only when its primary source is present;
+// the primary is then confined to ID slot 0 and each instance gets its own
+// slot. Extra instances are monitoring-only: they skip Phoenix operator login
+// and UDP control, and never become the admin provider. An unreachable
+// instance is logged and skipped so one branch cannot block the workstation.
+// CASL instances are built by data.CASLProviderSources.
+func AppendSourceInstances(sources []data.ProviderSource, cfg config.DBConfig, verifyConnectivity bool) ([]data.ProviderSource, []InstanceDB) {
+	var opened []InstanceDB
+	for _, sourceType := range []string{config.SourceInstanceFirebird, config.SourceInstancePhoenix} {
+		instances := cfg.SourceInstancesOfType(sourceType)
+		primary := primarySourceIndex(sources, sourceType)
+		if len(instances) == 0 || primary < 0 {
+			continue
+		}
+		scopePrimarySource(&sources[primary], sourceType)
+
+		for i, instance := range instances {
+			slot := i + 1
+			source, db, err := openSourceInstance(cfg.ForSourceInstance(instance), instance, slot, verifyConnectivity)
+			if err != nil {
+				log.Warn().Err(err).Str("type", sourceType).Str("instance", instance.Name).Msg("Додаткове джерело недоступне, пропущено")
+				continue
+			}
+			sources = append(sources, source)
+			opened = append(opened, db)
+		}
+	}
+	return sources, opened
+}
+
+func primarySourceIndex(sources []data.ProviderSource, sourceType string) int {
+	name := "bridge"
+	if sourceType == config.SourceInstancePhoenix {
+		name = "phoenix"
+	}
+	for i, source := range sources {
+		if source.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func primaryHasInstances(sources []data.ProviderSource, source contracts.FrontendSource) bool {
+	sourceType := config.SourceInstanceFirebird
+	if source == contracts.FrontendSourcePhoenix {
+		sourceType = config.SourceInstancePhoenix
+	}
+	primary := primarySourceIndex(sources, sourceType)
+	return primary >= 0 && sources[primary].Instance != ""
+}
+
+func scopePrimarySource(source *data.ProviderSource, sourceType string) {
+	source.Instance = data.PrimarySourceInstance
+	switch sourceType {
+	case config.SourceInstanceFirebird:
+		source.OwnsObjectID = ids.OwnsBridgeInstance(0)
+		source.OwnsAlarmID = ids.OwnsBridgeInstance(0)
+	case config.SourceInstancePhoenix:
+		if scoped, ok := source.Provider.(interface{ SetInstanceSlot(slot int) }); ok {
+			scoped.SetInstanceSlot(0)
+		}
+		source.OwnsObjectID = ids.OwnsPhoenixInstance(0)
+		source.OwnsAlarmID = ids.OwnsPhoenixInstance(0)
+	}
+}
+
+func openSourceInstance(cfg config.DBConfig, instance config.SourceInstance, slot int, verifyConnectivity bool) (data.ProviderSource, InstanceDB, error) {
+	driver, dsn, frontendSource := "firebirdsql", cfg.FirebirdDSN(), contracts.FrontendSourceBridge
+	if instance.Type == config.SourceInstancePhoenix {
+		driver, dsn, frontendSource = "sqlserver", cfg.PhoenixDSN(), contracts.FrontendSourcePhoenix
+	}
+	label := frontendSource.DisplayName() + " · " + instance.Name
+
+	db, err := database.InitNamedDB(driver, dsn, label)
+	if err != nil {
+		return data.ProviderSource{}, InstanceDB{}, err
+	}
+	if verifyConnectivity {
+		if err := database.PingWithTimeout(context.Background(), db, 5*time.Second); err != nil {
+			_ = db.Close()
+			return data.ProviderSource{}, InstanceDB{}, fmt.Errorf("%s ping failed: %w", label, err)
+		}
+	}
+	healthCancel, health := database.StartNamedHealthCheckWithStatus(db, label)
+	opened := InstanceDB{
+		Source:       frontendSource,
+		Instance:     instance.Name,
+		Label:        label,
+		DB:           db,
+		HealthCancel: healthCancel,
+		Health:       health,
+	}
+
+	source := data.ProviderSource{Instance: instance.Name}
+	if instance.Type == config.SourceInstancePhoenix {
+		provider := data.NewPhoenixDataProvider(db, dsn)
+		provider.SetInstanceSlot(slot)
+		source.Name = "phoenix:" + instance.Name
+		source.Provider = provider
+		source.OwnsObjectID = ids.OwnsPhoenixInstance(slot)
+	} else {
+		provider := data.NewBridgeInstanceProvider(data.NewDBDataProvider(db, dsn), slot)
+		source.Name = "bridge:" + instance.Name
+		source.Provider = provider
+		source.OwnsObjectID = provider.Owns()
+	}
+	source.OwnsAlarmID = source.OwnsObjectID
+	return source, opened, nil
+}
@@ -0,0 +1,283 @@
+// Package eventexport streams the global event journal of every data source
+// into CSV or XLSX without loading the whole range into memory.
+package eventexport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+const (
+	SeverityAll      = ""
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// archivePageSize is how many archive events are held in memory at once.
+const archivePageSize = 2000
+
+// Filter selects the journal events to export. Empty slices mean "any".
+// From and To are required: range queries are the only way to reach history.
+type Filter struct {
+	Sources   []contracts.FrontendSource
+	Severity  string
+	ObjectIDs []int
+	Types     []models.EventType
+	From      time.Time
+	To        time.Time
+}
+
+// Validate checks the date range and severity.
+func (f Filter) Validate() error {
+	if f.From.IsZero() || f.To.IsZero() {
+		return fmt.Errorf("вкажіть період експорту")
+	}
+	if f.To.Before(f.From) {
+		return fmt.Errorf("кінець періоду раніший за початок")
+	}
+	switch f.Severity {
+	case SeverityAll, SeverityCritical, SeverityWarning, SeverityInfo:
+	default:
+		return fmt.Errorf("невідомий рівень важливості %q", f.Severity)
+	}
+	for _, source := range f.Sources {
+		switch source {
+		case contracts.FrontendSourceBridge, contracts.FrontendSourcePhoenix, contracts.FrontendSourceCASL:
+		default:
+			return fmt.Errorf("невідоме джерело %q", source)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether event passes every part of the filter.
+func (f Filter) Matches(event models.Event) bool {
+	if event.Time.Before(f.From) || event.Time.After(f.To) {
+		return false
+	}
+	if !f.wantsSource(contracts.DetectFrontendSourceByObjectID(event.ObjectID)) {
+		return false
+	}
+	if len(f.ObjectIDs) > 0 && !slices.Contains(f.ObjectIDs, event.ObjectID) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	switch f.Severity {
+	case SeverityCritical:
+		return event.IsCritical()
+	case SeverityWarning:
+		return event.IsWarning()
+	case SeverityInfo:
+		return !event.IsCritical() && !event.IsWarning()
+	}
+	return true
+}
+
+func (f Filter) wantsSource(source contracts.FrontendSource) bool {
+	return len(f.Sources) == 0 || slices.Contains(f.Sources, source)
+}
+
+// Provider is the data source the journal is read from. Providers that also
+// implement contracts.EventArchiveProvider serve Phoenix history from the
+// archive, which has no per-object row cap; those implementing
+// contracts.ObjectEventsRangeQueryProvider report unreadable objects, which
+// fails the export.
+type Provider interface {
+	contracts.ObjectProvider
+	contracts.ObjectEventsRangeProvider
+}
+
+// Exporter streams filtered journal events into an export writer.
+type Exporter struct {
+	progress func(written int)
+}
+
+// Option configures Exporter.
+type Option func(*Exporter)
+
+// WithProgress reports the running number of written events after each object or archive page.
+func WithProgress(progress func(written int)) Option {
+	return func(e *Exporter) {
+		e.progress = progress
+	}
+}
+
+func NewExporter(opts ...Option) *Exporter {
+	e := &Exporter{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	return e
+}
+
+// Export writes every event matching filter into writer and returns the number
+// of written events. Events come object by object (archive page by page for
+// Phoenix), newest first within each. writer is neither closed nor aborted:
+// the caller decides what to do with partial output.
+func (e *Exporter) Export(ctx context.Context, provider Provider, filter Filter, writer objexport.EventWriter) (int, error) {
+	if provider == nil {
+		return 0, fmt.Errorf("джерело подій не налаштовано")
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	run := &exportRun{ctx: ctx, filter: filter, writer: writer, progress: e.progress}
+	objectIDs := exportObjectIDs(provider, filter)
+	archive, hasArchive := provider.(contracts.EventArchiveProvider)
+	useArchive := hasArchive && filter.wantsSource(contracts.FrontendSourcePhoenix) &&
+		slices.ContainsFunc(objectIDs, func(id int) bool {
+			return contracts.DetectFrontendSourceByObjectID(id) == contracts.FrontendSourcePhoenix
+		})
+	if useArchive && len(filter.ObjectIDs) == 0 {
+		if err := run.archive(archive, 0); err != nil {
+			return run.written, err
+		}
+	}
+
+	for _, objectID := range objectIDs {
+		source := contracts.DetectFrontendSourceByObjectID(objectID)
+		if !filter.wantsSource(source) {
+			continue
+		}
+		var err error
+		switch {
+		case source != contracts.FrontendSourcePhoenix || !useArchive:
+			err = run.objectRange(provider, objectID)
+		case len(filter.ObjectIDs) > 0:
+			err = run.archive(archive, objectID)
+		}
+		if err != nil {
+			return run.written, err
+		}
+	}
+	return run.written, ctx.Err()
+}
+
+// ExportFile streams the filtered journal into filePath (CSV or XLSX by extension).
+// On error or cancellation the partial file is removed.
+func (e *Exporter) ExportFile(ctx context.Context, provider Provider, filter Filter, filePath string) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+	writer, err := objexport.NewEventFileWriter(filePath)
+	if err != nil {
+		return 0, err
+	}
+	written, err := e.Export(ctx, provider, filter, writer)
+	if err != nil {
+		writer.Abort()
+		return written, err
+	}
+	return written, writer.Close()
+}
+
+func exportObjectIDs(provider Provider, filter Filter) []int {
+	if len(filter.ObjectIDs) > 0 {
+		return filter.ObjectIDs
+	}
+	objects := provider.GetObjects()
+	result := make([]int, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, object.ID)
+	}
+	return result
+}
+
+type exportRun struct {
+	ctx      context.Context
+	filter   Filter
+	writer   objexport.EventWriter
+	progress func(written int)
+	written  int
+}
+
+func (r *exportRun) write(events []models.Event) error {
+	for _, event := range events {
+		if !r.filter.Matches(event) {
+			continue
+		}
+		if err := r.writer.Write(event); err != nil {
+			return err
+		}
+		r.written++
+	}
+	if r.progress != nil {
+		r.progress(r.written)
+	}
+	return nil
+}
+
+func (r *exportRun) objectRange(provider Provider, objectID int) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+	events, err := r.objectEvents(provider, objectID)
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		// Not every source stamps object events with their object.
+		if events[i].ObjectID == 0 {
+			events[i].ObjectID = objectID
+		}
+	}
+	slices.SortStableFunc(events, func(left, right models.Event) int {
+		return right.Time.Compare(left.Time)
+	})
+	return r.write(events)
+}
+
+// objectEvents reads one object's range. A read failure fails the export:
+// an incomplete journal must not look like a quiet period.
+func (r *exportRun) objectEvents(provider Provider, objectID int) ([]models.Event, error) {
+	id := strconv.Itoa(objectID)
+	ranged, ok := provider.(contracts.ObjectEventsRangeQueryProvider)
+	if !ok {
+		return provider.GetObjectEventsRange(id, r.filter.From, r.filter.To), nil
+	}
+	events, err := ranged.QueryObjectEventsRange(r.ctx, id, r.filter.From, r.filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("журнал об'єкта #%d: %w", objectID, err)
+	}
+	return events, nil
+}
+
+// archive pages through the archive of objectID, or of every archive source when objectID is 0.
+func (r *exportRun) archive(archive contracts.EventArchiveProvider, objectID int) error {
+	query := contracts.EventArchiveQuery{
+		ObjectID: objectID,
+		From:     r.filter.From,
+		To:       r.filter.To,
+		Limit:    archivePageSize,
+	}
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		page, err := archive.QueryEventArchive(r.ctx, query)
+		if err != nil {
+			return fmt.Errorf("архів подій: %w", err)
+		}
+		if err := r.write(page.Events); err != nil {
+			return err
+		}
+		if strings.TrimSpace(page.NextCursor) == "" {
+			return nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
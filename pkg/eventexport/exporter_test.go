@@ -0,0 +1,276 @@
+package eventexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type stubProvider struct {
+	objects     []models.Object
+	events      map[int][]models.Event
+	rangeCalls  []int
+	archiveHits []contracts.EventArchiveQuery
+	pages       map[string]contracts.EventArchivePage
+}
+
+func (p *stubProvider) GetObjects() []models.Object {
+	return p.objects
+}
+
+func (p *stubProvider) GetObjectByID(string) *models.Object {
+	return nil
+}
+
+func (p *stubProvider) GetObjectEventsRange(objectID string, _ time.Time, _ time.Time) []models.Event {
+	id, _ := strconv.Atoi(objectID)
+	p.rangeCalls = append(p.rangeCalls, id)
+	return append([]models.Event(nil), p.events[id]...)
+}
+
+// queryStubProvider reports read failures for objects listed in failing.
+type queryStubProvider struct {
+	*stubProvider
+	failing map[int]error
+}
+
+func (p queryStubProvider) QueryObjectEventsRange(_ context.Context, objectID string, from time.Time, to time.Time) ([]models.Event, error) {
+	id, _ := strconv.Atoi(objectID)
+	if err := p.failing[id]; err != nil {
+		p.rangeCalls = append(p.rangeCalls, id)
+		return nil, err
+	}
+	return p.GetObjectEventsRange(objectID, from, to), nil
+}
+
+type stubArchiveProvider struct {
+	*stubProvider
+}
+
+func (p stubArchiveProvider) QueryEventArchive(_ context.Context, query contracts.EventArchiveQuery) (contracts.EventArchivePage, error) {
+	p.archiveHits = append(p.archiveHits, query)
+	return p.pages[query.Cursor], nil
+}
+
+type recordingWriter struct {
+	events []models.Event
+}
+
+func (w *recordingWriter) Write(event models.Event) error {
+	w.events = append(w.events, event)
+	return nil
+}
+
+func (w *recordingWriter) Close() error { return nil }
+func (w *recordingWriter) Abort()       {}
+
+var (
+	day       = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	phoenixID = ids.PhoenixObjectIDNamespaceStart + 10
+	caslID    = ids.CASLObjectIDNamespaceStart + 5
+)
+
+func dayFilter() Filter {
+	return Filter{From: day, To: day.Add(24*time.Hour - time.Second)}
+}
+
+func TestFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Filter{
+		"no range":       {},
+		"reversed range": {From: day, To: day.Add(-time.Hour)},
+		"bad severity":   {From: day, To: day, Severity: "loud"},
+		"bad source":     {From: day, To: day, Sources: []contracts.FrontendSource{"ftp"}},
+	}
+	for name, filter := range tests {
+		if err := filter.Validate(); err == nil {
+			t.Errorf("%s: Validate() error = nil", name)
+		}
+	}
+	if err := dayFilter().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	filter := dayFilter()
+	filter.Sources = []contracts.FrontendSource{contracts.FrontendSourceBridge}
+	filter.Severity = SeverityCritical
+	filter.Types = []models.EventType{models.EventFire, models.EventArm}
+
+	fire := models.Event{ObjectID: 7, Type: models.EventFire, Time: day.Add(time.Hour)}
+	if !filter.Matches(fire) {
+		t.Fatal("bridge fire within the day must match")
+	}
+	for name, event := range map[string]models.Event{
+		"outside range":  {ObjectID: 7, Type: models.EventFire, Time: day.Add(-time.Hour)},
+		"other source":   {ObjectID: caslID, Type: models.EventFire, Time: day.Add(time.Hour)},
+		"not critical":   {ObjectID: 7, Type: models.EventArm, Time: day.Add(time.Hour)},
+		"type not asked": {ObjectID: 7, Type: models.EventPanic, Time: day.Add(time.Hour)},
+	} {
+		if filter.Matches(event) {
+			t.Errorf("%s: Matches() = true", name)
+		}
+	}
+
+	filter.ObjectIDs = []int{8}
+	if filter.Matches(fire) {
+		t.Fatal("event of an object outside the set must not match")
+	}
+}
+
+func TestExportReadsRangesAndPhoenixArchive(t *testing.T) {
+	t.Parallel()
+
+	base := &stubProvider{
+		objects: []models.Object{{ID: 7}, {ID: phoenixID}, {ID: caslID}},
+		events: map[int][]models.Event{
+			7: {
+				{ID: 1, Type: models.EventArm, Time: day.Add(time.Hour)},
+				{ID: 2, Type: models.EventFire, Time: day.Add(2 * time.Hour)},
+				{ID: 3, Type: models.EventFire, Time: day.Add(-time.Hour)},
+			},
+			phoenixID: {{ID: 99, ObjectID: phoenixID, Time: day.Add(time.Hour)}},
+			caslID:    {{ID: 4, ObjectID: caslID, Type: models.EventTest, Time: day.Add(time.Hour)}},
+		},
+		pages: map[string]contracts.EventArchivePage{
+			"":     {Events: []models.Event{{ID: 10, ObjectID: phoenixID, Time: day.Add(3 * time.Hour)}}, NextCursor: "0:10"},
+			"0:10": {Events: []models.Event{{ID: 11, ObjectID: phoenixID, Time: day.Add(time.Hour)}}},
+		},
+	}
+	provider := stubArchiveProvider{stubProvider: base}
+
+	var progress []int
+	writer := &recordingWriter{}
+	written, err := NewExporter(WithProgress(func(n int) { progress = append(progress, n) })).
+		Export(context.Background(), provider, dayFilter(), writer)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var got []int
+	for _, event := range writer.events {
+		got = append(got, event.ID)
+	}
+	want := []int{10, 11, 2, 1, 4}
+	if written != len(want) || len(got) != len(want) {
+		t.Fatalf("exported %v (%d), want %v", got, written, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("exported %v, want %v", got, want)
+		}
+	}
+	if writer.events[2].ObjectID != 7 {
+		t.Fatalf("range events must be stamped with their object, got %d", writer.events[2].ObjectID)
+	}
+	for _, id := range base.rangeCalls {
+		if id == phoenixID {
+			t.Fatal("Phoenix objects must be read from the archive, not the capped range query")
+		}
+	}
+	if len(progress) == 0 || progress[len(progress)-1] != len(want) {
+		t.Fatalf("progress = %v", progress)
+	}
+}
+
+func TestExportObjectSetUsesOwningArchive(t *testing.T) {
+	t.Parallel()
+
+	base := &stubProvider{
+		pages: map[string]contracts.EventArchivePage{
+			"": {Events: []models.Event{{ID: 10, ObjectID: phoenixID, Time: day.Add(time.Hour)}}},
+		},
+	}
+	provider := stubArchiveProvider{stubProvider: base}
+	filter := dayFilter()
+	filter.ObjectIDs = []int{phoenixID}
+
+	written, err := NewExporter().Export(context.Background(), provider, filter, &recordingWriter{})
+	if err != nil || written != 1 {
+		t.Fatalf("Export() = %d, %v", written, err)
+	}
+	if len(base.archiveHits) != 1 || base.archiveHits[0].ObjectID != phoenixID {
+		t.Fatalf("archive queries = %+v", base.archiveHits)
+	}
+}
+
+func TestExportStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{objects: []models.Object{{ID: 7}, {ID: 8}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewExporter().Export(ctx, provider, dayFilter(), &recordingWriter{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Export() error = %v, want context.Canceled", err)
+	}
+	if len(provider.rangeCalls) != 0 {
+		t.Fatalf("cancelled export must not query objects, got %v", provider.rangeCalls)
+	}
+}
+
+func TestExportFailsOnUnreadableObject(t *testing.T) {
+	t.Parallel()
+
+	readErr := errors.New("connection reset")
+	provider := queryStubProvider{
+		stubProvider: &stubProvider{
+			objects: []models.Object{{ID: 7}, {ID: 8}, {ID: 9}},
+			events: map[int][]models.Event{
+				7: {{ID: 1, Type: models.EventFire, Time: day.Add(time.Hour)}},
+				9: {{ID: 3, Type: models.EventFire, Time: day.Add(time.Hour)}},
+			},
+		},
+		failing: map[int]error{8: readErr},
+	}
+	writer := &recordingWriter{}
+	written, err := NewExporter().Export(context.Background(), provider, dayFilter(), writer)
+	if !errors.Is(err, readErr) || !strings.Contains(err.Error(), "#8") {
+		t.Fatalf("Export() error = %v, want read failure of object #8", err)
+	}
+	if written != 1 || len(provider.rangeCalls) != 2 {
+		t.Fatalf("written = %d, range calls = %v; export must stop at object 8", written, provider.rangeCalls)
+	}
+}
+
+func TestExportStreamsCSV(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{
+		objects: []models.Object{{ID: 7}},
+		events: map[int][]models.Event{
+			7: {{ID: 1, ObjectNumber: "007", Type: models.EventFire, Time: day.Add(time.Hour)}},
+		},
+	}
+	var out bytes.Buffer
+	writer, err := objexport.NewEventStreamWriter(&out, objexport.EventFormatCSV)
+	if err != nil {
+		t.Fatalf("NewEventStreamWriter() error = %v", err)
+	}
+	if _, err := NewExporter().Export(context.Background(), provider, dayFilter(), writer); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	if len(rows) != 2 || rows[1][1] != "007" {
+		t.Fatalf("rows = %v", rows)
+	}
+}
@@ -0,0 +1,163 @@
+package eventscenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/caslcompat"
+)
+
+// Injector подає один крок сценарію на джерело.
+type Injector interface {
+	Inject(ctx context.Context, step Step) error
+}
+
+// InjectorFunc дозволяє використати функцію як Injector.
+type InjectorFunc func(ctx context.Context, step Step) error
+
+func (f InjectorFunc) Inject(ctx context.Context, step Step) error {
+	return f(ctx, step)
+}
+
+// EventEmulator — емуляція події МІСТ (contracts.AdminProvider.EmulateEvent).
+type EventEmulator interface {
+	EmulateEvent(objn int64, zone int64, messageUIN int64) error
+}
+
+// BridgeInjector подає події через емуляцію БД МІСТ.
+type BridgeInjector struct {
+	Emulator EventEmulator
+}
+
+func (i BridgeInjector) Inject(_ context.Context, step Step) error {
+	if i.Emulator == nil {
+		return errors.New("bridge emulation is unavailable")
+	}
+	return i.Emulator.EmulateEvent(step.Object, step.Zone, step.MessageUIN)
+}
+
+var contactIDCodePattern = regexp.MustCompile(`^[ER][0-9]{3}$`)
+
+// CASLFixtureInjector подає події через caslcompat.EmitPath fixture-шлюзу
+// (cmd/casl-fixture-gateway), до якого підключено CASL-провайдер.
+type CASLFixtureInjector struct {
+	BaseURL string
+	PultID  string
+	Client  *http.Client
+}
+
+func (i CASLFixtureInjector) Inject(ctx context.Context, step Step) error {
+	baseURL := strings.TrimSuffix(strings.TrimSpace(i.BaseURL), "/")
+	if baseURL == "" {
+		return errors.New("casl fixture gateway url is empty")
+	}
+	request := caslcompat.EmitRequest{
+		PultID:     strings.TrimSpace(i.PultID),
+		ObjID:      strings.TrimSpace(step.ObjectID),
+		PPKNum:     step.PPKNum,
+		LineNumber: int(step.Zone),
+	}
+	code := strings.ToUpper(strings.TrimSpace(step.Code))
+	if contactIDCodePattern.MatchString(code) {
+		request.ContactID = code
+	} else {
+		request.Action = code
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+caslcompat.EmitPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	client := i.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("casl fixture emit: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(response.Body, 4<<10))
+		return fmt.Errorf("casl fixture emit: %d %s", response.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return nil
+}
+
+// PhoenixControlInjector надсилає пакет на UDP-порт центру керування Phoenix.
+// Формат пакета емуляції залежить від стенда, тому він задається шаблоном
+// з полями, розділеними "[*]", і підстановками {panel}, {zone}, {code},
+// {time} (02.01.2006 15:04:05) та {host}. Якщо центр відповідає пакетом
+// з першим полем "1", інжектор чекає підтвердження AckTimeout.
+type PhoenixControlInjector struct {
+	Addr       string
+	Template   string
+	AckTimeout time.Duration
+	now        func() time.Time
+}
+
+func (i PhoenixControlInjector) Inject(ctx context.Context, step Step) error {
+	if strings.TrimSpace(i.Addr) == "" {
+		return errors.New("phoenix control center address is empty")
+	}
+	if strings.TrimSpace(i.Template) == "" {
+		return errors.New("phoenix control packet template is empty")
+	}
+	now := time.Now
+	if i.now != nil {
+		now = i.now
+	}
+	host, _ := os.Hostname()
+	packet := strings.NewReplacer(
+		"{panel}", strings.TrimSpace(step.Panel),
+		"{zone}", strconv.FormatInt(step.Zone, 10),
+		"{code}", strings.ToUpper(strings.TrimSpace(step.Code)),
+		"{time}", now().Format("02.01.2006 15:04:05"),
+		"{host}", host,
+	).Replace(i.Template)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", strings.TrimSpace(i.Addr))
+	if err != nil {
+		return fmt.Errorf("phoenix control: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(packet)); err != nil {
+		return fmt.Errorf("phoenix control: send: %w", err)
+	}
+	if i.AckTimeout <= 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(i.AckTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetReadDeadline(deadline)
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return fmt.Errorf("phoenix control: no acknowledgement: %w", err)
+		}
+		if first, _, _ := strings.Cut(string(buf[:n]), "[*]"); strings.TrimSpace(first) == "1" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package eventscenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+const defaultPollInterval = 500 * time.Millisecond
+
+// Observer — джерело результату сценарію (data.CombinedDataProvider).
+type Observer interface {
+	GetEvents() []models.Event
+	GetAlarms() []models.Alarm
+}
+
+// Runner відтворює сценарії та перевіряє очікування.
+type Runner struct {
+	Observer     Observer
+	Injectors    map[Target]Injector
+	PollInterval time.Duration
+}
+
+// StepResult — результат подачі одного кроку.
+type StepResult struct {
+	Step  Step
+	At    time.Time
+	Error error
+}
+
+// ExpectationResult — результат перевірки одного очікування.
+type ExpectationResult struct {
+	Expectation Expectation
+	Matched     int
+	Passed      bool
+}
+
+// Report — підсумок прогону сценарію.
+type Report struct {
+	Scenario     string
+	Started      time.Time
+	Finished     time.Time
+	Steps        []StepResult
+	Expectations []ExpectationResult
+}
+
+// Passed повертає true, якщо всі кроки подано і всі очікування виконано.
+func (r Report) Passed() bool {
+	for _, step := range r.Steps {
+		if step.Error != nil {
+			return false
+		}
+	}
+	for _, expectation := range r.Expectations {
+		if !expectation.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Run подає кроки сценарію і чекає, доки очікування виконаються або мине
+// таймаут сценарію. Очікування Absent перевіряються до кінця таймауту.
+// Помилка повертається лише для некоректного сценарію чи скасування ctx;
+// провалені кроки та очікування відображаються у Report.
+func (r *Runner) Run(ctx context.Context, scenario Scenario) (Report, error) {
+	report := Report{Scenario: scenario.Name}
+	if r == nil || r.Observer == nil {
+		return report, errors.New("scenario observer is not configured")
+	}
+	if err := scenario.Validate(); err != nil {
+		return report, err
+	}
+	for _, step := range scenario.Steps {
+		if r.Injectors[step.Target] == nil {
+			return report, fmt.Errorf("no injector for target %q", step.Target)
+		}
+	}
+
+	baseline := r.snapshot(ctx)
+	report.Started = time.Now()
+	deadline := report.Started.Add(scenario.timeout())
+	runCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	for _, step := range scenario.Steps {
+		if err := sleepContext(runCtx, time.Duration(step.Delay)); err != nil {
+			return r.finish(report), err
+		}
+		err := r.Injectors[step.Target].Inject(runCtx, step)
+		report.Steps = append(report.Steps, StepResult{Step: step, At: time.Now(), Error: err})
+	}
+
+	report.Expectations = make([]ExpectationResult, len(scenario.Expect))
+	for i, expectation := range scenario.Expect {
+		report.Expectations[i] = ExpectationResult{Expectation: expectation, Passed: expectation.Absent}
+	}
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		observed := r.snapshot(runCtx).since(baseline, report.Started)
+		pending := false
+		for i := range report.Expectations {
+			result := &report.Expectations[i]
+			result.Matched = observed.count(result.Expectation)
+			if result.Expectation.Absent {
+				result.Passed = result.Matched == 0
+				pending = pending || result.Passed
+			} else {
+				result.Passed = result.Matched >= result.Expectation.minCount()
+				pending = pending || !result.Passed
+			}
+		}
+		if !pending {
+			return r.finish(report), nil
+		}
+		if err := sleepContext(runCtx, interval); err != nil {
+			if ctx.Err() != nil {
+				return r.finish(report), ctx.Err()
+			}
+			return r.finish(report), nil
+		}
+	}
+}
+
+func (r *Runner) finish(report Report) Report {
+	report.Finished = time.Now()
+	return report
+}
+
+type observation struct {
+	events []models.Event
+	alarms []models.Alarm
+}
+
+func (r *Runner) snapshot(ctx context.Context) observation {
+	var snapshot observation
+	if provider, ok := r.Observer.(contracts.ContextEventProvider); ok {
+		snapshot.events = provider.GetEventsContext(ctx)
+	} else {
+		snapshot.events = r.Observer.GetEvents()
+	}
+	if provider, ok := r.Observer.(contracts.ContextAlarmProvider); ok {
+		snapshot.alarms = provider.GetAlarmsContext(ctx)
+	} else {
+		snapshot.alarms = r.Observer.GetAlarms()
+	}
+	return snapshot
+}
+
+// since залишає події, яких не було в baseline, і тривоги, що з'явилися
+// або оновилися після старту сценарію.
+func (o observation) since(baseline observation, started time.Time) observation {
+	knownEvents := make(map[eventKey]struct{}, len(baseline.events))
+	for _, event := range baseline.events {
+		knownEvents[keyOfEvent(event)] = struct{}{}
+	}
+	knownAlarms := make(map[int]struct{}, len(baseline.alarms))
+	for _, alarm := range baseline.alarms {
+		knownAlarms[alarm.ID] = struct{}{}
+	}
+
+	var fresh observation
+	for _, event := range o.events {
+		if _, ok := knownEvents[keyOfEvent(event)]; !ok {
+			fresh.events = append(fresh.events, event)
+		}
+	}
+	for _, alarm := range o.alarms {
+		if _, ok := knownAlarms[alarm.ID]; !ok || !alarm.Time.Before(started) {
+			fresh.alarms = append(fresh.alarms, alarm)
+		}
+	}
+	return fresh
+}
+
+type eventKey struct {
+	source models.EventSource
+	id     int
+}
+
+func keyOfEvent(event models.Event) eventKey {
+	return eventKey{source: event.Source, id: event.ID}
+}
+
+func (o observation) count(e Expectation) int {
+	matched := 0
+	switch e.Kind {
+	case KindEvent:
+		for _, event := range o.events {
+			if e.matches(Target(event.Source), event.ObjectID, event.ObjectNumber, event.ZoneNumber, string(event.Type), event.TypeLabel+" "+event.Details) {
+				matched++
+			}
+		}
+	case KindAlarm:
+		for _, alarm := range o.alarms {
+			if e.matches(alarmSource(alarm), alarm.ObjectID, alarm.ObjectNumber, alarm.ZoneNumber, string(alarm.Type), alarm.Details) {
+				matched++
+			}
+		}
+	}
+	return matched
+}
+
+func (e Expectation) matches(source Target, objectID int, objectNumber string, zone int, kind string, text string) bool {
+	if e.Source != "" && e.Source != source {
+		return false
+	}
+	if e.ObjectID != 0 && e.ObjectID != objectID {
+		return false
+	}
+	if e.ObjectNumber != "" && !strings.EqualFold(strings.TrimSpace(e.ObjectNumber), strings.TrimSpace(objectNumber)) {
+		return false
+	}
+	if e.Zone != nil && *e.Zone != zone {
+		return false
+	}
+	if e.Type != "" && !strings.EqualFold(e.Type, kind) {
+		return false
+	}
+	if e.TextContains != "" && !strings.Contains(strings.ToLower(text), strings.ToLower(e.TextContains)) {
+		return false
+	}
+	return true
+}
+
+func alarmSource(alarm models.Alarm) Target {
+	switch {
+	case ids.IsCASLObjectID(alarm.ObjectID):
+		return TargetCASL
+	case ids.IsPhoenixObjectID(alarm.ObjectID):
+		return TargetPhoenix
+	default:
+		return TargetBridge
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
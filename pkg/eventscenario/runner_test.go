@@ -0,0 +1,197 @@
+package eventscenario
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/caslcompat"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type fakeObserver struct {
+	mu     sync.Mutex
+	events []models.Event
+	alarms []models.Alarm
+}
+
+func (o *fakeObserver) GetEvents() []models.Event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]models.Event(nil), o.events...)
+}
+
+func (o *fakeObserver) GetAlarms() []models.Alarm {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]models.Alarm(nil), o.alarms...)
+}
+
+func (o *fakeObserver) add(event models.Event, alarm *models.Alarm) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+	if alarm != nil {
+		o.alarms = append(o.alarms, *alarm)
+	}
+}
+
+func TestParse_RejectsIncompleteSteps(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse(strings.NewReader(`{"steps":[{"target":"casl","object_id":"25"}]}`))
+	if err == nil || !strings.Contains(err.Error(), "requires code") {
+		t.Fatalf("Parse() error = %v, want missing code", err)
+	}
+
+	_, err = Parse(strings.NewReader(`{"steps":[{"target":"bridge","object":1,"message_uin":2,"delay":"bogus"}]}`))
+	if err == nil {
+		t.Fatal("Parse() accepted invalid delay")
+	}
+
+	scenario, err := Parse(strings.NewReader(`{"name":"fire","timeout":"2s","steps":[{"target":"bridge","object":1001,"zone":3,"message_uin":77,"delay":"100ms"}],"expect":[{"kind":"alarm","zone":3,"type":"fire"}]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if scenario.timeout() != 2*time.Second || time.Duration(scenario.Steps[0].Delay) != 100*time.Millisecond {
+		t.Fatalf("unexpected durations: %+v", scenario)
+	}
+}
+
+func TestRunner_PassesWhenInjectedEventsAppear(t *testing.T) {
+	t.Parallel()
+
+	observer := &fakeObserver{
+		events: []models.Event{{ID: 1, ObjectID: 1001, Type: models.EventFire, ZoneNumber: 3, Source: models.EventSourceBridge}},
+	}
+	nextID := 100
+	bridge := InjectorFunc(func(_ context.Context, step Step) error {
+		nextID++
+		observer.add(
+			models.Event{ID: nextID, ObjectID: int(step.Object), ZoneNumber: int(step.Zone), Type: models.EventFire, Details: "Пожежа у шлейфі", Source: models.EventSourceBridge},
+			&models.Alarm{ID: nextID, ObjectID: int(step.Object), ZoneNumber: int(step.Zone), Type: models.AlarmFire, Time: time.Now()},
+		)
+		return nil
+	})
+	zone := 3
+	scenario := Scenario{
+		Name:    "fire",
+		Timeout: Duration(time.Second),
+		Steps:   []Step{{Target: TargetBridge, Object: 1001, Zone: 3, MessageUIN: 77}},
+		Expect: []Expectation{
+			{Kind: KindAlarm, Source: TargetBridge, ObjectID: 1001, Zone: &zone, Type: string(models.AlarmFire)},
+			{Kind: KindEvent, TextContains: "пожежа"},
+			{Kind: KindAlarm, Source: TargetCASL, Absent: true},
+		},
+	}
+
+	runner := &Runner{
+		Observer:     observer,
+		Injectors:    map[Target]Injector{TargetBridge: bridge},
+		PollInterval: 10 * time.Millisecond,
+	}
+	report, err := runner.Run(context.Background(), scenario)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !report.Passed() {
+		t.Fatalf("report failed: %+v", report)
+	}
+	if report.Expectations[1].Matched != 1 {
+		t.Fatalf("baseline event was counted: %+v", report.Expectations[1])
+	}
+}
+
+func TestRunner_FailsAfterTimeoutWhenNothingArrives(t *testing.T) {
+	t.Parallel()
+
+	runner := &Runner{
+		Observer:     &fakeObserver{},
+		Injectors:    map[Target]Injector{TargetPhoenix: InjectorFunc(func(context.Context, Step) error { return nil })},
+		PollInterval: 10 * time.Millisecond,
+	}
+	scenario := Scenario{
+		Timeout: Duration(50 * time.Millisecond),
+		Steps:   []Step{{Target: TargetPhoenix, Panel: "L00123", Code: "E130"}},
+		Expect:  []Expectation{{Kind: KindAlarm, Source: TargetPhoenix}},
+	}
+	report, err := runner.Run(context.Background(), scenario)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Passed() || report.Expectations[0].Matched != 0 {
+		t.Fatalf("expected failed report, got %+v", report)
+	}
+}
+
+func TestRunner_RequiresInjectorForEveryTarget(t *testing.T) {
+	t.Parallel()
+
+	runner := &Runner{Observer: &fakeObserver{}}
+	_, err := runner.Run(context.Background(), Scenario{Steps: []Step{{Target: TargetCASL, ObjectID: "25", Code: "FIRE"}}})
+	if err == nil || !strings.Contains(err.Error(), "no injector") {
+		t.Fatalf("Run() error = %v, want missing injector", err)
+	}
+}
+
+func TestCASLFixtureInjector_SendsContactIDAndActionCodes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []caslcompat.EmitRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != caslcompat.EmitPath {
+			http.NotFound(w, r)
+			return
+		}
+		var request caslcompat.EmitRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	injector := CASLFixtureInjector{BaseURL: server.URL + "/", PultID: "1"}
+	for _, code := range []string{"e130", "GROUP_ON"} {
+		if err := injector.Inject(context.Background(), Step{Target: TargetCASL, ObjectID: "25", Zone: 2, Code: code}); err != nil {
+			t.Fatalf("Inject(%s) error = %v", code, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+	if requests[0].ContactID != "E130" || requests[0].Action != "" || requests[0].LineNumber != 2 || requests[0].PultID != "1" {
+		t.Fatalf("unexpected contact id request: %+v", requests[0])
+	}
+	if requests[1].Action != "GROUP_ON" || requests[1].ContactID != "" {
+		t.Fatalf("unexpected action request: %+v", requests[1])
+	}
+}
+
+func TestAlarmSource_UsesObjectIDNamespaces(t *testing.T) {
+	t.Parallel()
+
+	cases := map[int]Target{
+		1001:                              TargetBridge,
+		ids.PhoenixObjectIDNamespaceStart: TargetPhoenix,
+		ids.CASLObjectIDNamespaceStart:    TargetCASL,
+	}
+	for objectID, want := range cases {
+		if got := alarmSource(models.Alarm{ObjectID: objectID}); got != want {
+			t.Fatalf("alarmSource(%d) = %q, want %q", objectID, got, want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package eventscenario відтворює скриптовані послідовності подій на джерелах
+// (емуляція МІСТ, fixture-шлюз CASL, канал керування Phoenix) і перевіряє,
+// які тривоги та події побачив об'єднаний провайдер даних. Сценарії
+// використовуються для регресійної перевірки класифікації подій перед оновленнями.
+package eventscenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// Target — джерело, на яке подається крок сценарію.
+type Target string
+
+const (
+	TargetBridge  Target = Target(models.EventSourceBridge)
+	TargetPhoenix Target = Target(models.EventSourcePhoenix)
+	TargetCASL    Target = Target(models.EventSourceCASL)
+)
+
+const (
+	KindAlarm = "alarm"
+	KindEvent = "event"
+
+	defaultScenarioTimeout = 30 * time.Second
+)
+
+// Duration приймає в JSON рядок у форматі time.ParseDuration ("1.5s", "2m").
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	if strings.TrimSpace(raw) == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Scenario — послідовність кроків і очікувань до результату.
+type Scenario struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Timeout     Duration      `json:"timeout,omitempty"`
+	Steps       []Step        `json:"steps"`
+	Expect      []Expectation `json:"expect"`
+}
+
+// Step подає одну подію на Target після затримки Delay від попереднього кроку.
+// Для bridge використовуються Object, Zone і MessageUIN (MESSLIST.UIN);
+// для casl — ObjectID або PPKNum, Zone і Code (код CASL або Contact ID);
+// для phoenix — Panel, Zone і Code (Contact ID).
+type Step struct {
+	Target     Target   `json:"target"`
+	Delay      Duration `json:"delay,omitempty"`
+	Object     int64    `json:"object,omitempty"`
+	ObjectID   string   `json:"object_id,omitempty"`
+	PPKNum     int      `json:"ppk_num,omitempty"`
+	Panel      string   `json:"panel,omitempty"`
+	Zone       int64    `json:"zone,omitempty"`
+	MessageUIN int64    `json:"message_uin,omitempty"`
+	Code       string   `json:"code,omitempty"`
+}
+
+// Expectation описує тривоги або події, які мають з'явитися після кроків.
+// Порожні поля не перевіряються. Absent інвертує перевірку: таких записів
+// не повинно бути до кінця таймауту сценарію.
+type Expectation struct {
+	Kind         string `json:"kind"`
+	Source       Target `json:"source,omitempty"`
+	ObjectID     int    `json:"object_id,omitempty"`
+	ObjectNumber string `json:"object_number,omitempty"`
+	Zone         *int   `json:"zone,omitempty"`
+	Type         string `json:"type,omitempty"`
+	TextContains string `json:"text_contains,omitempty"`
+	MinCount     int    `json:"min_count,omitempty"`
+	Absent       bool   `json:"absent,omitempty"`
+}
+
+// Load читає сценарій з JSON-файлу.
+func Load(path string) (Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("open scenario %q: %w", path, err)
+	}
+	defer file.Close()
+	scenario, err := Parse(file)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("scenario %q: %w", path, err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+	return scenario, nil
+}
+
+// Parse декодує і перевіряє сценарій.
+func Parse(r io.Reader) (Scenario, error) {
+	var scenario Scenario
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&scenario); err != nil {
+		return Scenario{}, fmt.Errorf("decode: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return Scenario{}, err
+	}
+	return scenario, nil
+}
+
+// Validate перевіряє, що кожен крок має дані для свого джерела.
+func (s Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("scenario has no steps")
+	}
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	for i, expectation := range s.Expect {
+		if err := expectation.validate(); err != nil {
+			return fmt.Errorf("expectation %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (s Scenario) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return time.Duration(s.Timeout)
+	}
+	return defaultScenarioTimeout
+}
+
+func (step Step) validate() error {
+	if step.Zone < 0 {
+		return errors.New("zone must not be negative")
+	}
+	switch step.Target {
+	case TargetBridge:
+		if step.Object <= 0 || step.MessageUIN <= 0 {
+			return errors.New("bridge step requires object and message_uin")
+		}
+	case TargetCASL:
+		if strings.TrimSpace(step.ObjectID) == "" && step.PPKNum <= 0 {
+			return errors.New("casl step requires object_id or ppk_num")
+		}
+		if strings.TrimSpace(step.Code) == "" {
+			return errors.New("casl step requires code")
+		}
+	case TargetPhoenix:
+		if strings.TrimSpace(step.Panel) == "" || strings.TrimSpace(step.Code) == "" {
+			return errors.New("phoenix step requires panel and code")
+		}
+	default:
+		return fmt.Errorf("unknown target %q", step.Target)
+	}
+	return nil
+}
+
+func (e Expectation) validate() error {
+	switch e.Kind {
+	case KindAlarm, KindEvent:
+	default:
+		return fmt.Errorf("unknown kind %q", e.Kind)
+	}
+	switch e.Source {
+	case "", TargetBridge, TargetCASL, TargetPhoenix:
+	default:
+		return fmt.Errorf("unknown source %q", e.Source)
+	}
+	if e.MinCount < 0 {
+		return errors.New("min_count must not be negative")
+	}
+	return nil
+}
+
+func (e Expectation) minCount() int {
+	if e.MinCount > 0 {
+		return e.MinCount
+	}
+	return 1
+}
+
+// String коротко описує очікування для звіту.
+func (e Expectation) String() string {
+	parts := []string{e.Kind}
+	if e.Absent {
+		parts = append(parts, "absent")
+	}
+	if e.Source != "" {
+		parts = append(parts, "source="+string(e.Source))
+	}
+	if e.ObjectID != 0 {
+		parts = append(parts, fmt.Sprintf("object_id=%d", e.ObjectID))
+	}
+	if e.ObjectNumber != "" {
+		parts = append(parts, "object="+e.ObjectNumber)
+	}
+	if e.Zone != nil {
+		parts = append(parts, fmt.Sprintf("zone=%d", *e.Zone))
+	}
+	if e.Type != "" {
+		parts = append(parts, "type="+e.Type)
+	}
+	if e.TextContains != "" {
+		parts = append(parts, fmt.Sprintf("text~%q", e.TextContains))
+	}
+	if !e.Absent && e.minCount() > 1 {
+		parts = append(parts, fmt.Sprintf("min_count=%d", e.minCount()))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"obj_catalog_fyne_v3/pkg/dataquality"
+)
+
+// WriteDataQualityXLSX writes data-quality findings to an XLSX file, one issue per row.
+func WriteDataQualityXLSX(filePath string, issues []dataquality.Issue) error {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return fmt.Errorf("шлях до файлу не вказано")
+	}
+	if dir := filepath.Dir(filePath); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("не вдалося створити каталог: %w", err)
+		}
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := "Якість даних"
+	if err := f.SetSheetName(f.GetSheetName(f.GetActiveSheetIndex()), sheet); err != nil {
+		return err
+	}
+
+	border := []excelize.Border{{Type: "left", Color: "D9D9D9", Style: 1}, {Type: "right", Color: "D9D9D9", Style: 1}, {Type: "top", Color: "D9D9D9", Style: 1}, {Type: "bottom", Color: "D9D9D9", Style: 1}}
+	tableHeaderStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#F2F2F2"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    border,
+	})
+	cellStyle, _ := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"},
+		Border:    border,
+	})
+	severityStyles := map[string]int{}
+	for severity, fill := range map[string]string{
+		dataquality.SeverityError: "#F8CBAD",
+		dataquality.SeverityWarn:  "#FFE699",
+		dataquality.SeverityInfo:  "#DDEBF7",
+	} {
+		styleID, _ := f.NewStyle(&excelize.Style{
+			Fill:      excelize.Fill{Type: "pattern", Color: []string{fill}, Pattern: 1},
+			Alignment: &excelize.Alignment{Vertical: "top"},
+			Border:    border,
+		})
+		severityStyles[severity] = styleID
+	}
+
+	setTableHeaders(f, sheet, 1, []string{"Рівень", "Джерело", "№", "Назва", "Поле", "Проблема", "Деталі"}, tableHeaderStyle)
+	for index, issue := range issues {
+		row := index + 2
+		setTableRow(f, sheet, row, []string{
+			dataquality.SeverityLabel(issue.Severity),
+			issue.Source.DisplayName(),
+			normalizeValue(issue.ObjectNumber),
+			normalizeValue(issue.ObjectName),
+			normalizeValue(issue.Field),
+			dataquality.CodeLabel(issue.Code),
+			normalizeValue(issue.Details),
+		}, cellStyle)
+		if styleID, ok := severityStyles[issue.Severity]; ok {
+			cell := fmt.Sprintf("A%d", row)
+			_ = f.SetCellStyle(sheet, cell, cell, styleID)
+		}
+	}
+
+	_ = f.SetColWidth(sheet, "A", "A", 14)
+	_ = f.SetColWidth(sheet, "B", "B", 12)
+	_ = f.SetColWidth(sheet, "C", "C", 10)
+	_ = f.SetColWidth(sheet, "D", "D", 36)
+	_ = f.SetColWidth(sheet, "E", "E", 20)
+	_ = f.SetColWidth(sheet, "F", "F", 22)
+	_ = f.SetColWidth(sheet, "G", "G", 70)
+	_ = f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	if len(issues) > 0 {
+		_ = f.AutoFilter(sheet, fmt.Sprintf("A1:G%d", len(issues)+1), nil)
+	}
+
+	if err := f.SaveAs(filePath); err != nil {
+		return fmt.Errorf("не вдалося зберегти XLSX: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
+)
+
+func TestWriteDataQualityXLSXWritesIssueRows(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "quality", "issues.xlsx")
+	issues := []dataquality.Issue{
+		{
+			Severity:     dataquality.SeverityError,
+			Code:         dataquality.CodeDuplicateSIM,
+			Source:       contracts.FrontendSourceBridge,
+			ObjectID:     1001,
+			ObjectNumber: "1001",
+			ObjectName:   "Магазин",
+			Field:        "SIM1",
+			Details:      "SIM 0501112233 використовується на: МІСТ №1001 (SIM1); CASL №77 (SIM2)",
+		},
+	}
+
+	if err := WriteDataQualityXLSX(filePath, issues); err != nil {
+		t.Fatalf("WriteDataQualityXLSX() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Якість даних")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want header + 1 issue", len(rows))
+	}
+	want := []string{"Помилка", issues[0].Source.DisplayName(), "1001", "Магазин", "SIM1", "Дублікат SIM", issues[0].Details}
+	for index, value := range want {
+		if rows[1][index] != value {
+			t.Fatalf("cell %d = %q, want %q", index, rows[1][index], value)
+		}
+	}
+}
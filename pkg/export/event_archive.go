@@ -0,0 +1,65 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// eventArchiveExportPageSize is how many archive events are held in memory at once.
+const eventArchiveExportPageSize = 2000
+
+// ExportEventArchive pages through the event archive matching query and streams
+// every event into filePath (CSV or XLSX by extension). query.Cursor and
+// query.Limit are ignored: the export always starts from the newest event.
+// progress, when set, receives the running row count after each page.
+// On error or cancellation the partial file is removed.
+func ExportEventArchive(
+	ctx context.Context,
+	archive contracts.EventArchiveProvider,
+	query contracts.EventArchiveQuery,
+	filePath string,
+	progress func(written int),
+) (int, error) {
+	if archive == nil {
+		return 0, fmt.Errorf("архів подій недоступний")
+	}
+	writer, err := NewEventFileWriter(filePath)
+	if err != nil {
+		return 0, err
+	}
+
+	query.Cursor = ""
+	query.Limit = eventArchiveExportPageSize
+	written := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			writer.Abort()
+			return written, err
+		}
+		page, err := archive.QueryEventArchive(ctx, query)
+		if err != nil {
+			writer.Abort()
+			return written, err
+		}
+		for _, event := range page.Events {
+			if err := writer.Write(event); err != nil {
+				writer.Abort()
+				return written, err
+			}
+			written++
+		}
+		if progress != nil {
+			progress(written)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if err := writer.Close(); err != nil {
+		return written, err
+	}
+	return written, nil
+}
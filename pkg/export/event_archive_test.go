@@ -0,0 +1,110 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type pagedArchiveStub struct {
+	pages   [][]models.Event
+	queries []contracts.EventArchiveQuery
+	err     error
+}
+
+func (s *pagedArchiveStub) QueryEventArchive(_ context.Context, query contracts.EventArchiveQuery) (contracts.EventArchivePage, error) {
+	s.queries = append(s.queries, query)
+	if s.err != nil {
+		return contracts.EventArchivePage{}, s.err
+	}
+	index := 0
+	if query.Cursor != "" {
+		index, _ = strconv.Atoi(query.Cursor)
+	}
+	page := contracts.EventArchivePage{Events: s.pages[index]}
+	if index+1 < len(s.pages) {
+		page.NextCursor = strconv.Itoa(index + 1)
+	}
+	return page, nil
+}
+
+func TestExportEventArchiveStreamsAllPagesToCSV(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 15, 0, 0, time.Local)
+	archive := &pagedArchiveStub{pages: [][]models.Event{
+		{{Time: at, ObjectNumber: "L00028", ObjectName: "Аптека", TypeLabel: "Тривога", ZoneNumber: 3, ZoneName: "Вхід"}},
+		{{Time: at.Add(-time.Hour), ObjectNumber: "L00028", ObjectName: "Аптека", TypeLabel: "Взяття"}},
+	}}
+	filePath := filepath.Join(t.TempDir(), "archive.csv")
+	var progress []int
+
+	written, err := ExportEventArchive(context.Background(), archive, contracts.EventArchiveQuery{Code: "E130", Cursor: "stale"}, filePath, func(n int) {
+		progress = append(progress, n)
+	})
+	if err != nil {
+		t.Fatalf("ExportEventArchive() error = %v", err)
+	}
+	if written != 2 || len(progress) != 2 || progress[1] != 2 {
+		t.Fatalf("written = %d, progress = %v", written, progress)
+	}
+	if archive.queries[0].Cursor != "" || archive.queries[0].Code != "E130" || archive.queries[1].Cursor != "1" {
+		t.Fatalf("queries = %+v", archive.queries)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		t.Fatalf("open csv: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 3 || records[1][0] != "01.03.2026 10:15:00" || records[1][4] != "3 Вхід" {
+		t.Fatalf("csv records = %v", records)
+	}
+}
+
+func TestExportEventArchiveWritesXLSX(t *testing.T) {
+	archive := &pagedArchiveStub{pages: [][]models.Event{
+		{{Time: time.Now(), ObjectNumber: "7", TypeLabel: "Тест"}},
+	}}
+	filePath := filepath.Join(t.TempDir(), "archive.xlsx")
+
+	if _, err := ExportEventArchive(context.Background(), archive, contracts.EventArchiveQuery{}, filePath, nil); err != nil {
+		t.Fatalf("ExportEventArchive() error = %v", err)
+	}
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Події")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != EventExportHeaders[0] || rows[1][3] != "Тест" {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestExportEventArchiveRemovesPartialFileOnError(t *testing.T) {
+	archive := &pagedArchiveStub{err: errors.New("timeout")}
+	filePath := filepath.Join(t.TempDir(), "archive.csv")
+
+	if _, err := ExportEventArchive(context.Background(), archive, contracts.EventArchiveQuery{}, filePath, nil); err == nil {
+		t.Fatal("ExportEventArchive() error = nil, want archive error")
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Fatalf("partial file must be removed, stat err = %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// EventExportHeaders are the columns of streamed event exports.
+var EventExportHeaders = []string{
+	"Дата і час",
+	"№",
+	"Об'єкт",
+	"Подія",
+	"Зона",
+	"Група",
+	"Деталі",
+	"Користувач",
+}
+
+// xlsxMaxDataRows keeps each sheet under the Excel row limit (1 048 576 with the header).
+const xlsxMaxDataRows = excelize.TotalRows - 1
+
+// EventExportValues returns one export row for event.
+func EventExportValues(event models.Event) []string {
+	zone := strings.TrimSpace(event.ZoneName)
+	if event.ZoneNumber > 0 {
+		zone = strings.TrimSpace(strconv.Itoa(event.ZoneNumber) + " " + zone)
+	}
+	return []string{
+		event.Time.Format("02.01.2006 15:04:05"),
+		strings.TrimSpace(event.ObjectNumber),
+		strings.TrimSpace(event.ObjectName),
+		event.GetTypeDisplay(),
+		zone,
+		strings.TrimSpace(event.GroupName),
+		strings.TrimSpace(event.Details),
+		strings.TrimSpace(event.UserName),
+	}
+}
+
+// Формати потокового експорту подій.
+const (
+	EventFormatCSV  = "csv"
+	EventFormatXLSX = "xlsx"
+)
+
+// EventWriter writes events one by one, so exports of any size never hold
+// the whole journal in memory.
+type EventWriter interface {
+	Write(event models.Event) error
+	// Close finishes the output. Abort discards the partial output instead.
+	Close() error
+	Abort()
+}
+
+// EventFormatFromPath returns the export format chosen by the file extension.
+func EventFormatFromPath(filePath string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(strings.TrimSpace(filePath)))
+	switch ext {
+	case ".csv":
+		return EventFormatCSV, nil
+	case ".xlsx":
+		return EventFormatXLSX, nil
+	default:
+		return "", fmt.Errorf("непідтримуваний формат файлу %q: оберіть .csv або .xlsx", ext)
+	}
+}
+
+// NewEventStreamWriter creates a CSV or XLSX event writer over out.
+// CSV rows reach out as they are written; XLSX rows are buffered by the excelize
+// stream writer in a temporary file and the workbook is written to out on Close.
+func NewEventStreamWriter(out io.Writer, format string) (EventWriter, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case EventFormatCSV:
+		return newEventCSVWriter(out)
+	case EventFormatXLSX:
+		return newEventXLSXWriter(out)
+	default:
+		return nil, fmt.Errorf("непідтримуваний формат експорту %q: оберіть csv або xlsx", format)
+	}
+}
+
+// NewEventFileWriter creates a CSV or XLSX event writer chosen by the file extension.
+func NewEventFileWriter(filePath string) (EventWriter, error) {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return nil, fmt.Errorf("шлях до файлу не вказано")
+	}
+	format, err := EventFormatFromPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if dir := filepath.Dir(filePath); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("не вдалося створити каталог: %w", err)
+		}
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("створити файл експорту: %w", err)
+	}
+	writer, err := NewEventStreamWriter(file, format)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(filePath)
+		return nil, err
+	}
+	return &eventFileWriter{path: filePath, file: file, writer: writer}, nil
+}
+
+// eventFileWriter owns the file behind a stream writer and removes it on Abort.
+type eventFileWriter struct {
+	path   string
+	file   *os.File
+	writer EventWriter
+}
+
+func (w *eventFileWriter) Write(event models.Event) error {
+	return w.writer.Write(event)
+}
+
+func (w *eventFileWriter) Close() error {
+	if err := w.writer.Close(); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("закрити файл експорту: %w", err)
+	}
+	return nil
+}
+
+func (w *eventFileWriter) Abort() {
+	w.writer.Abort()
+	_ = w.file.Close()
+	_ = os.Remove(w.path)
+}
+
+type eventCSVWriter struct {
+	writer *csv.Writer
+}
+
+func newEventCSVWriter(out io.Writer) (*eventCSVWriter, error) {
+	writer := csv.NewWriter(out)
+	writer.UseCRLF = true
+	if err := writer.Write(EventExportHeaders); err != nil {
+		return nil, fmt.Errorf("записати заголовок CSV: %w", err)
+	}
+	return &eventCSVWriter{writer: writer}, nil
+}
+
+func (w *eventCSVWriter) Write(event models.Event) error {
+	if err := w.writer.Write(EventExportValues(event)); err != nil {
+		return fmt.Errorf("записати рядок CSV: %w", err)
+	}
+	return nil
+}
+
+func (w *eventCSVWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("завершити запис CSV: %w", err)
+	}
+	return nil
+}
+
+func (w *eventCSVWriter) Abort() {}
+
+// eventXLSXWriter uses the excelize stream writer: rows go to a temporary
+// file instead of the in-memory sheet, and a new sheet starts at the row limit.
+type eventXLSXWriter struct {
+	out         io.Writer
+	file        *excelize.File
+	stream      *excelize.StreamWriter
+	headerStyle int
+	sheets      int
+	row         int
+}
+
+func newEventXLSXWriter(out io.Writer) (*eventXLSXWriter, error) {
+	f := excelize.NewFile()
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#F2F2F2"}, Pattern: 1},
+	})
+	w := &eventXLSXWriter{out: out, file: f, headerStyle: headerStyle}
+	if err := w.startSheet(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *eventXLSXWriter) startSheet() error {
+	w.sheets++
+	sheet := "Події"
+	if w.sheets == 1 {
+		if err := w.file.SetSheetName(w.file.GetSheetName(0), sheet); err != nil {
+			return err
+		}
+	} else {
+		if err := w.stream.Flush(); err != nil {
+			return fmt.Errorf("записати аркуш XLSX: %w", err)
+		}
+		sheet = fmt.Sprintf("Події %d", w.sheets)
+		if _, err := w.file.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+	stream, err := w.file.NewStreamWriter(sheet)
+	if err != nil {
+		return fmt.Errorf("створити аркуш XLSX: %w", err)
+	}
+	for col, width := range []float64{20, 10, 36, 28, 18, 18, 60, 20} {
+		_ = stream.SetColWidth(col+1, col+1, width)
+	}
+	_ = stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	if err := stream.SetRow("A1", xlsxRowValues(EventExportHeaders), excelize.RowOpts{StyleID: w.headerStyle}); err != nil {
+		return fmt.Errorf("записати заголовок XLSX: %w", err)
+	}
+	w.stream = stream
+	w.row = 1
+	return nil
+}
+
+func (w *eventXLSXWriter) Write(event models.Event) error {
+	if w.row > xlsxMaxDataRows {
+		if err := w.startSheet(); err != nil {
+			return err
+		}
+	}
+	w.row++
+	cell, _ := excelize.CoordinatesToCellName(1, w.row)
+	if err := w.stream.SetRow(cell, xlsxRowValues(EventExportValues(event))); err != nil {
+		return fmt.Errorf("записати рядок XLSX: %w", err)
+	}
+	return nil
+}
+
+func (w *eventXLSXWriter) Close() error {
+	defer w.file.Close()
+	if err := w.stream.Flush(); err != nil {
+		return fmt.Errorf("записати аркуш XLSX: %w", err)
+	}
+	if _, err := w.file.WriteTo(w.out); err != nil {
+		return fmt.Errorf("не вдалося зберегти XLSX: %w", err)
+	}
+	return nil
+}
+
+func (w *eventXLSXWriter) Abort() {
+	_ = w.file.Close()
+}
+
+func xlsxRowValues(values []string) []any {
+	row := make([]any, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+	return row
+}
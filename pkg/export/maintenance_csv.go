@@ -0,0 +1,72 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/maintenance"
+)
+
+var suppressedAlarmsCSVHeader = []string{
+	"Час тривоги",
+	"Об'єкт",
+	"Назва",
+	"Зона",
+	"Тип",
+	"Деталі",
+	"Причина обслуговування",
+	"Приглушено",
+}
+
+// WriteSuppressedAlarmsCSV пише звіт тривог, приглушених вікнами обслуговування.
+func WriteSuppressedAlarmsCSV(filePath string, entries []maintenance.SuppressedAlarm) error {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return fmt.Errorf("шлях до CSV-файлу порожній")
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("створити CSV-файл: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.UseCRLF = true
+	if err := writer.Write(suppressedAlarmsCSVHeader); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("записати заголовок CSV: %w", err)
+	}
+	for _, entry := range entries {
+		zone := strings.TrimSpace(entry.ZoneName)
+		if entry.ZoneNumber > 0 {
+			zone = strings.TrimSpace(strconv.Itoa(entry.ZoneNumber) + " " + zone)
+		}
+		record := []string{
+			entry.Time.Format("02.01.2006 15:04:05"),
+			entry.ObjectNumber,
+			entry.ObjectName,
+			zone,
+			entry.Type,
+			entry.Details,
+			entry.Reason,
+			entry.SuppressedAt.Format("02.01.2006 15:04:05"),
+		}
+		if err := writer.Write(record); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("записати тривогу у CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("завершити запис CSV: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("закрити CSV-файл: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/openclose"
+)
+
+// WriteOpenCloseComplianceCSV пише звіт дотримання розкладу відкриття/закриття по об'єктах.
+func WriteOpenCloseComplianceCSV(filePath string, rows []openclose.ComplianceRow) error {
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, row.Values())
+	}
+	return writeOpenCloseCSV(filePath, openclose.ComplianceHeaders, records)
+}
+
+// WriteOpenCloseViolationsCSV пише журнал порушень розкладу відкриття/закриття.
+func WriteOpenCloseViolationsCSV(filePath string, violations []openclose.Violation) error {
+	records := make([][]string, 0, len(violations))
+	for _, violation := range violations {
+		records = append(records, violation.Values())
+	}
+	return writeOpenCloseCSV(filePath, openclose.ViolationHeaders, records)
+}
+
+func writeOpenCloseCSV(filePath string, header []string, records [][]string) error {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return fmt.Errorf("шлях до CSV-файлу порожній")
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("створити CSV-файл: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.UseCRLF = true
+	if err := writer.Write(header); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("записати заголовок CSV: %w", err)
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("записати рядок CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("завершити запис CSV: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("закрити CSV-файл: %w", err)
+	}
+	return nil
+}
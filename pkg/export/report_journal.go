@@ -2,6 +2,7 @@ package export
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,18 +13,68 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 	"github.com/xuri/excelize/v2"
-	"obj_catalog_fyne_v3/pkg/gdrive"
+	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/reportupload"
 )
 
-// GoogleDriveUploadError indicates that the Excel row was appended successfully but the Google Drive upload failed.
-type GoogleDriveUploadError struct {
-	Err error
+// ReportUploadError indicates that the report file was written successfully but publishing it to the upload target failed.
+type ReportUploadError struct {
+	Target string
+	Err    error
 }
 
-func (e *GoogleDriveUploadError) Error() string {
-	return fmt.Sprintf("помилка завантаження на Google Drive: %v", e.Err)
+func (e *ReportUploadError) Error() string {
+	return fmt.Sprintf("помилка завантаження на %s: %v", e.TargetName(), e.Err)
+}
+
+func (e *ReportUploadError) Unwrap() error {
+	return e.Err
+}
+
+// TargetName returns the upload target label for operator messages.
+func (e *ReportUploadError) TargetName() string {
+	if e == nil || strings.TrimSpace(e.Target) == "" {
+		return "сховище звітів"
+	}
+	return e.Target
+}
+
+// PublishReport uploads a generated report file to target and returns the link to the stored copy.
+// A nil target means uploads are disabled and yields an empty link without error.
+func PublishReport(ctx context.Context, target reportupload.Target, localFilePath string, folderName string) (string, error) {
+	if target == nil {
+		return "", nil
+	}
+	if strings.TrimSpace(localFilePath) == "" {
+		return "", &ReportUploadError{Target: target.Name(), Err: fmt.Errorf("report file path is empty")}
+	}
+	if _, err := os.Stat(localFilePath); err != nil {
+		return "", &ReportUploadError{Target: target.Name(), Err: fmt.Errorf("report file does not exist locally: %s", localFilePath)}
+	}
+	fileName := filepath.Base(localFilePath)
+	log.Info().Str("target", target.Name()).Str("file", fileName).Str("folder", folderName).Msg("Uploading report file...")
+	link, err := target.Upload(ctx, localFilePath, fileName, folderName)
+	if err != nil {
+		log.Error().Err(err).Str("target", target.Name()).Str("file", fileName).Msg("Failed to upload report file")
+		return "", &ReportUploadError{Target: target.Name(), Err: err}
+	}
+	log.Info().Str("target", target.Name()).Str("link", link).Msg("Report file uploaded")
+	return link, nil
+}
+
+// ReportsFolder is the upload folder for reports generated on demand.
+const ReportsFolder = "Звіти"
+
+// PublishConfiguredReport uploads a report exported from the UI to the target chosen in settings.
+// An unselected or "none" target skips the upload and yields an empty link without error.
+func PublishConfiguredReport(ctx context.Context, cfg config.ReportUploadConfig, localFilePath string) (string, error) {
+	target, err := reportupload.New(cfg)
+	if err != nil {
+		return "", &ReportUploadError{Err: err}
+	}
+	return PublishReport(ctx, target, localFilePath, ReportsFolder)
 }
 
 // ContactInfo stores basic contact information
@@ -316,9 +367,9 @@ func GenerateAcceptedObjectsReport(db *sqlx.DB, filePath string) error {
 	return nil
 }
 
-// AppendObjectToDeletedXLSX queries the details of the object and appends it to the 'Зняття' sheet in the Excel report file
 // AppendObjectToDeletedXLSX appends the object details to the 'Зняття' sheet in the Excel report file
-func AppendObjectToDeletedXLSX(obj *models.Object, contacts []models.Contact, pdfFilePath string, filePath string) error {
+// and publishes the object PDF to target. A nil target keeps the PDF local only.
+func AppendObjectToDeletedXLSX(obj *models.Object, contacts []models.Contact, pdfFilePath string, filePath string, target reportupload.Target) error {
 	if obj == nil {
 		return fmt.Errorf("object is nil")
 	}
@@ -336,49 +387,19 @@ func AppendObjectToDeletedXLSX(obj *models.Object, contacts []models.Contact, pd
 		displayNumber = fmt.Sprintf("%d", obj.ID)
 	}
 
-	// 1. Determine Google Drive folder name
-	folderName := "Об'єкти МОСТП"
-	if ids.IsCASLObjectID(obj.ID) {
-		folderName = "Об'єкти casl"
-	} else if ids.IsPhoenixObjectID(obj.ID) {
-		folderName = "Об'єкти фенікс"
-	}
-	log.Info().Int("object_id", obj.ID).Str("folder_name", folderName).Msg("Determined Google Drive folder for object deletion")
-
-	// 2. Google Drive upload
-	var driveLink string
-	var uploadErr error
-	credentialsPath := "credentials.json"
-	tokenPath := "token.json"
-
-	if pdfFilePath == "" {
-		uploadErr = fmt.Errorf("pdf file path is empty")
-		log.Error().Msg("Cannot upload to Google Drive: PDF file path is empty")
-	} else if _, err := os.Stat(pdfFilePath); os.IsNotExist(err) {
-		uploadErr = fmt.Errorf("pdf file does not exist locally: %s", pdfFilePath)
-		log.Error().Str("pdf_path", pdfFilePath).Msg("Cannot upload to Google Drive: PDF file does not exist locally")
-	} else if _, err := os.Stat(credentialsPath); os.IsNotExist(err) {
-		uploadErr = fmt.Errorf("credentials.json not found in root")
-		log.Error().Msg("Cannot upload to Google Drive: credentials.json not found in root")
-	} else {
-		log.Info().Str("credentials_path", credentialsPath).Msg("Initializing Google Drive OAuth2 client...")
-		srv, err := gdrive.NewService(credentialsPath, tokenPath)
-		if err != nil {
-			uploadErr = fmt.Errorf("failed to initialize google drive: %w", err)
-			log.Error().Err(err).Msg("Google Drive initialization failed")
-		} else {
-			fileName := filepath.Base(pdfFilePath)
-			if fileName == "." || fileName == "/" || fileName == "\\" || fileName == "" {
-				fileName = fmt.Sprintf("Object_%d.pdf", obj.ID)
-			}
-			log.Info().Str("fileName", fileName).Str("pdf_path", pdfFilePath).Str("folder", folderName).Msg("Uploading PDF to Google Drive...")
-			driveLink, uploadErr = srv.UploadAndShareFile(context.Background(), pdfFilePath, fileName, folderName)
-			if uploadErr != nil {
-				log.Error().Err(uploadErr).Msg("Failed to upload PDF to Google Drive")
-			} else {
-				log.Info().Str("link", driveLink).Msg("Successfully uploaded PDF to Google Drive and shared publicly")
-			}
+	// 1. Determine upload folder name
+	folderName := DeletedObjectsFolderName(obj.ID)
+	log.Info().Int("object_id", obj.ID).Str("folder_name", folderName).Msg("Determined upload folder for object deletion")
+
+	// 2. Upload PDF
+	var reportLink string
+	var uploadErr *ReportUploadError
+	if target != nil {
+		link, err := PublishReport(context.Background(), target, pdfFilePath, folderName)
+		if err != nil && !errors.As(err, &uploadErr) {
+			uploadErr = &ReportUploadError{Target: target.Name(), Err: err}
 		}
+		reportLink = link
 	}
 
 	notesVal := obj.Notes1
@@ -386,9 +407,9 @@ func AppendObjectToDeletedXLSX(obj *models.Object, contacts []models.Contact, pd
 		if notesVal != "" {
 			notesVal += " | "
 		}
-		notesVal += fmt.Sprintf("[Локальний файл: %s] (Не завантажено на Google Drive: %v)", filepath.Base(pdfFilePath), uploadErr)
-	} else if driveLink != "" {
-		notesVal = driveLink
+		notesVal += fmt.Sprintf("[Локальний файл: %s] (Не завантажено на %s: %v)", filepath.Base(pdfFilePath), uploadErr.TargetName(), uploadErr.Err)
+	} else if reportLink != "" {
+		notesVal = reportLink
 	}
 
 	// 3. Open Excel file
@@ -511,12 +532,24 @@ func AppendObjectToDeletedXLSX(obj *models.Object, contacts []models.Contact, pd
 	log.Info().Str("excel_path", filePath).Msg("Successfully saved Excel deleted report")
 
 	if uploadErr != nil {
-		return &GoogleDriveUploadError{Err: uploadErr}
+		return uploadErr
 	}
 
 	return nil
 }
 
+// DeletedObjectsFolderName returns the upload folder for PDFs of deleted objects of the given source.
+func DeletedObjectsFolderName(objectID int) string {
+	switch {
+	case ids.IsCASLObjectID(objectID):
+		return "Об'єкти casl"
+	case ids.IsPhoenixObjectID(objectID):
+		return "Об'єкти фенікс"
+	default:
+		return "Об'єкти МОСТП"
+	}
+}
+
 // formatSIMTo9Digits normalizes a Ukrainian mobile phone number to operator code + 7 digits (e.g. 501234567)
 func formatSIMTo9Digits(raw string) string {
 	raw = strings.TrimSpace(raw)
@@ -1,8 +1,14 @@
 package export
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
 )
 
 func TestParseLaunchDate(t *testing.T) {
@@ -47,3 +53,82 @@ func TestParseLaunchDate(t *testing.T) {
 		}
 	}
 }
+
+type fakeUploadTarget struct {
+	folder string
+	name   string
+	err    error
+}
+
+func (f *fakeUploadTarget) Name() string { return "fake" }
+
+func (f *fakeUploadTarget) Upload(_ context.Context, _ string, fileName string, folderName string) (string, error) {
+	f.folder = folderName
+	f.name = fileName
+	if f.err != nil {
+		return "", f.err
+	}
+	return "https://reports.example/" + fileName, nil
+}
+
+func TestPublishReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accepted.xlsx")
+	if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+
+	link, err := PublishReport(context.Background(), nil, path, "Звіти")
+	if err != nil || link != "" {
+		t.Fatalf("PublishReport(nil target) = %q, %v; want empty link and nil error", link, err)
+	}
+
+	target := &fakeUploadTarget{}
+	link, err = PublishReport(context.Background(), target, path, "Звіти")
+	if err != nil {
+		t.Fatalf("PublishReport() error = %v", err)
+	}
+	if link != "https://reports.example/accepted.xlsx" || target.folder != "Звіти" || target.name != "accepted.xlsx" {
+		t.Fatalf("PublishReport() link = %q, folder = %q, name = %q", link, target.folder, target.name)
+	}
+
+	_, err = PublishReport(context.Background(), &fakeUploadTarget{err: errors.New("offline")}, path, "Звіти")
+	var uploadErr *ReportUploadError
+	if !errors.As(err, &uploadErr) || uploadErr.TargetName() != "fake" {
+		t.Fatalf("PublishReport() error = %v, want ReportUploadError from fake", err)
+	}
+}
+
+func TestPublishConfiguredReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data_quality.xlsx")
+	if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+
+	for _, cfg := range []config.ReportUploadConfig{{}, {Target: config.ReportUploadTargetNone}} {
+		link, err := PublishConfiguredReport(context.Background(), cfg, path)
+		if err != nil || link != "" {
+			t.Fatalf("PublishConfiguredReport(%q) = %q, %v; want skipped upload", cfg.Target, link, err)
+		}
+	}
+
+	shared := t.TempDir()
+	cfg := config.ReportUploadConfig{Target: config.ReportUploadTargetLocal, LocalDir: shared}
+	if _, err := PublishConfiguredReport(context.Background(), cfg, path); err != nil {
+		t.Fatalf("PublishConfiguredReport(local) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(shared, ReportsFolder, "data_quality.xlsx")); err != nil {
+		t.Fatalf("report was not copied to the shared folder: %v", err)
+	}
+
+	_, err := PublishConfiguredReport(context.Background(), config.ReportUploadConfig{Target: config.ReportUploadTargetLocal}, path)
+	var uploadErr *ReportUploadError
+	if !errors.As(err, &uploadErr) {
+		t.Fatalf("PublishConfiguredReport(local without folder) error = %v, want ReportUploadError", err)
+	}
+}
+
+func TestDeletedObjectsFolderName(t *testing.T) {
+	if got := DeletedObjectsFolderName(1001); got != "Об'єкти МОСТП" {
+		t.Fatalf("DeletedObjectsFolderName(bridge) = %q", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 type alarmGroupDraft struct {
 	groupID    string
 	alertLevel int
+	score      int
 	latestAt   time.Time
 	latestTime string
 	primary    contracts.FrontendAlarmItem
@@ -59,6 +60,7 @@ func BuildAlarmGroups(items []contracts.FrontendAlarmItem) []AlarmGroup {
 				current = &alarmGroupDraft{
 					groupID:    buildAlarmGroupID(objectID, groupIndex, item.ID),
 					alertLevel: level,
+					score:      item.Priority.Score,
 					latestAt:   item.Time,
 					latestTime: formatTimestamp(item.Time),
 					primary:    item,
@@ -68,6 +70,7 @@ func BuildAlarmGroups(items []contracts.FrontendAlarmItem) []AlarmGroup {
 			}
 
 			current.items = append(current.items, item)
+			current.score = max(current.score, item.Priority.Score)
 			if item.Time.After(current.latestAt) {
 				current.latestAt = item.Time
 				current.latestTime = formatTimestamp(item.Time)
@@ -79,8 +82,13 @@ func BuildAlarmGroups(items []contracts.FrontendAlarmItem) []AlarmGroup {
 		}
 	}
 
+	// Highest priority score first, then the most recent group.
 	slices.SortFunc(groups, func(left, right alarmGroupDraft) int {
 		switch {
+		case left.score > right.score:
+			return -1
+		case left.score < right.score:
+			return 1
 		case left.latestAt.After(right.latestAt):
 			return -1
 		case left.latestAt.Before(right.latestAt):
@@ -0,0 +1,353 @@
+package v1
+
+import (
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Зворотні перетворення для клієнтів HTTP API: відповіді v1 -> contracts,
+// запити contracts -> v1. Використовуються віддаленим FrontendBackend.
+
+func FromCapabilities(capabilities Capabilities) contracts.FrontendCapabilities {
+	items := make([]contracts.FrontendSourceCapability, 0, len(capabilities.Sources))
+	for _, item := range capabilities.Sources {
+		items = append(items, contracts.FrontendSourceCapability{
+			Source:            toContractSource(item.Source),
+			Instance:          item.Instance,
+			DisplayName:       item.DisplayName,
+			ReadObjects:       item.ReadObjects,
+			ReadObjectDetails: item.ReadObjectDetails,
+			ReadEvents:        item.ReadEvents,
+			ReadAlarms:        item.ReadAlarms,
+			CreateObject:      item.CreateObject,
+			UpdateObject:      item.UpdateObject,
+			HealthStatus:      contracts.FrontendSourceHealthStatus(item.HealthStatus),
+			HealthText:        item.HealthText,
+			APIStatus:         contracts.FrontendConnectionStatus(item.APIStatus),
+			RealtimeStatus:    contracts.FrontendConnectionStatus(item.RealtimeStatus),
+			LastRealtimePing:  parseTimestamp(item.LastRealtimePing),
+		})
+	}
+	return contracts.FrontendCapabilities{Sources: items}
+}
+
+func FromObjectSummary(item ObjectSummary) contracts.FrontendObjectSummary {
+	return contracts.FrontendObjectSummary{
+		ID:               item.ID,
+		Source:           toContractSource(item.Source),
+		NativeID:         item.NativeID,
+		DisplayNumber:    item.DisplayNumber,
+		Name:             item.Name,
+		Address:          item.Address,
+		ContractNumber:   item.ContractNumber,
+		Phone:            item.Phone,
+		StatusCode:       item.StatusCode,
+		StatusText:       item.StatusText,
+		DeviceType:       item.DeviceType,
+		PanelMark:        item.PanelMark,
+		SignalStrength:   item.SignalStrength,
+		SIM1:             item.SIM1,
+		SIM2:             item.SIM2,
+		LastTestTime:     parseTimestamp(item.LastTestTime),
+		LastMessageTime:  parseTimestamp(item.LastMessageTime),
+		GuardStatus:      contracts.FrontendGuardStatus(item.GuardStatus),
+		ConnectionStatus: contracts.FrontendConnectionStatus(item.ConnectionStatus),
+		MonitoringStatus: contracts.FrontendMonitoringStatus(item.MonitoringStatus),
+		HasAssignment:    item.HasAssignment,
+	}
+}
+
+func FromObjectListResponse(response ObjectListResponse) []contracts.FrontendObjectSummary {
+	items := make([]contracts.FrontendObjectSummary, 0, len(response.Items))
+	for _, item := range response.Items {
+		items = append(items, FromObjectSummary(item))
+	}
+	return items
+}
+
+func FromAlarmItem(item AlarmItem) contracts.FrontendAlarmItem {
+	result := contracts.FrontendAlarmItem{
+		ID:                        item.ID,
+		Source:                    toContractSource(item.Source),
+		ObjectID:                  item.ObjectID,
+		ObjectNativeID:            item.ObjectNativeID,
+		ObjectNumber:              item.ObjectNumber,
+		ObjectName:                item.ObjectName,
+		Address:                   item.Address,
+		Time:                      parseTimestamp(item.Time),
+		Details:                   item.Details,
+		TypeCode:                  item.TypeCode,
+		TypeText:                  item.TypeText,
+		ZoneNumber:                item.ZoneNumber,
+		ZoneName:                  item.ZoneName,
+		IsProcessed:               item.IsProcessed,
+		ProcessedBy:               item.ProcessedBy,
+		ProcessNote:               item.ProcessNote,
+		IsInProgress:              item.IsInProgress,
+		InProgressBy:              item.InProgressBy,
+		IsOwnedByMe:               item.IsOwnedByMe,
+		CanTakeOver:               item.CanTakeOver,
+		CanProcess:                item.CanProcess,
+		ResponseGroupID:           item.ResponseGroupID,
+		IsResponseGroupDispatched: item.IsResponseGroupDispatched,
+		IsResponseGroupArrived:    item.IsResponseGroupArrived,
+		MaintenanceReason:         item.MaintenanceReason,
+		Watched:                   item.Watched,
+		VisualSeverity:            contracts.FrontendVisualSeverity(item.VisualSeverity),
+	}
+	if item.Priority != nil {
+		result.Priority = contracts.FrontendAlarmPriority{
+			Score:      item.Priority.Score,
+			Type:       item.Priority.Type,
+			ObjectType: item.Priority.ObjectType,
+			Contract:   item.Priority.Contract,
+			Repeats:    item.Priority.Repeats,
+			Unattended: item.Priority.Unattended,
+			Dispatched: item.Priority.Dispatched,
+		}
+	}
+	return result
+}
+
+func FromAlarmListResponse(response AlarmListResponse) []contracts.FrontendAlarmItem {
+	items := make([]contracts.FrontendAlarmItem, 0, len(response.Items))
+	for _, item := range response.Items {
+		items = append(items, FromAlarmItem(item))
+	}
+	return items
+}
+
+func FromAlarmProcessingOptionsResponse(response AlarmProcessingOptionsResponse) []contracts.FrontendAlarmProcessingOption {
+	items := make([]contracts.FrontendAlarmProcessingOption, 0, len(response.Items))
+	for _, item := range response.Items {
+		items = append(items, contracts.FrontendAlarmProcessingOption{Code: item.Code, Label: item.Label})
+	}
+	return items
+}
+
+func FromEventItem(item EventItem) contracts.FrontendEventItem {
+	return contracts.FrontendEventItem{
+		ID:             item.ID,
+		Source:         toContractSource(item.Source),
+		ObjectID:       item.ObjectID,
+		ObjectNativeID: item.ObjectNativeID,
+		ObjectNumber:   item.ObjectNumber,
+		ObjectName:     item.ObjectName,
+		Time:           parseTimestamp(item.Time),
+		TypeCode:       item.TypeCode,
+		TypeText:       item.TypeText,
+		ZoneNumber:     item.ZoneNumber,
+		Details:        item.Details,
+		UserName:       item.UserName,
+		VisualSeverity: contracts.FrontendVisualSeverity(item.VisualSeverity),
+	}
+}
+
+func FromEventListResponse(response EventListResponse) []contracts.FrontendEventItem {
+	return fromEvents(response.Items)
+}
+
+func FromEventPageResponse(response EventPageResponse) contracts.FrontendEventPage {
+	return contracts.FrontendEventPage{
+		Items:      fromEvents(response.Items),
+		TotalCount: response.TotalCount,
+		HasMore:    response.HasMore,
+	}
+}
+
+func FromObjectDetails(item ObjectDetails) contracts.FrontendObjectDetails {
+	zones := make([]contracts.FrontendZone, 0, len(item.Zones))
+	for _, zone := range item.Zones {
+		zones = append(zones, contracts.FrontendZone{
+			Number:         zone.Number,
+			Name:           zone.Name,
+			SensorType:     zone.SensorType,
+			Status:         zone.Status,
+			GroupID:        zone.GroupID,
+			GroupNumber:    zone.GroupNumber,
+			GroupName:      zone.GroupName,
+			GroupStateText: zone.GroupStateText,
+		})
+	}
+	contacts := make([]contracts.FrontendContact, 0, len(item.Contacts))
+	for _, contact := range item.Contacts {
+		contacts = append(contacts, contracts.FrontendContact{
+			Name:           contact.Name,
+			Position:       contact.Position,
+			Phone:          contact.Phone,
+			Priority:       contact.Priority,
+			CodeWord:       contact.CodeWord,
+			GroupID:        contact.GroupID,
+			GroupNumber:    contact.GroupNumber,
+			GroupName:      contact.GroupName,
+			GroupStateText: contact.GroupStateText,
+		})
+	}
+	return contracts.FrontendObjectDetails{
+		Summary:                    FromObjectSummary(item.Summary),
+		GSMLevel:                   item.GSMLevel,
+		PowerSource:                item.PowerSource,
+		AutoTestHours:              item.AutoTestHours,
+		SubServerA:                 item.SubServerA,
+		SubServerB:                 item.SubServerB,
+		ChannelCode:                item.ChannelCode,
+		AKBState:                   item.AKBState,
+		PowerFault:                 item.PowerFault,
+		TestControl:                item.TestControl,
+		TestIntervalMin:            item.TestIntervalMin,
+		Phones:                     item.Phones,
+		Description:                item.Description,
+		Notes:                      item.Notes,
+		Location:                   item.Location,
+		LaunchDate:                 item.LaunchDate,
+		PreferredResponseGroupID:   item.PreferredResponseGroupID,
+		PreferredResponseGroupName: item.PreferredResponseGroupName,
+		ExternalSignal:             item.ExternalSignal,
+		ExternalTestMessage:        item.ExternalTestMessage,
+		ExternalLastTest:           parseTimestamp(item.ExternalLastTest),
+		ExternalLastMessage:        parseTimestamp(item.ExternalLastMessage),
+		Zones:                      zones,
+		Contacts:                   contacts,
+		Events:                     fromEvents(item.Events),
+	}
+}
+
+func FromObjectMutationResult(item ObjectMutationResult) contracts.FrontendObjectMutationResult {
+	return contracts.FrontendObjectMutationResult{
+		Source:   toContractSource(item.Source),
+		ObjectID: item.ObjectID,
+		NativeID: item.NativeID,
+	}
+}
+
+func FromResponseGroup(item ResponseGroup) contracts.FrontendResponseGroup {
+	result := contracts.FrontendResponseGroup{
+		ID:              item.ID,
+		Name:            item.Name,
+		Callsign:        item.Callsign,
+		Phone:           item.Phone,
+		Source:          contracts.FrontendSource(item.Source),
+		Status:          contracts.ResponseGroupStatus(item.Status),
+		StatusText:      item.StatusText,
+		ObjectNumber:    item.ObjectNumber,
+		ObjectName:      item.ObjectName,
+		Latitude:        item.Latitude,
+		Longitude:       item.Longitude,
+		StatusChangedAt: parseTimestamp(item.StatusChangedAt),
+	}
+	if item.Suggestion != nil {
+		result.Suggestion = contracts.ResponseGroupSuggestion{
+			Rank:            item.Suggestion.Rank,
+			Recommended:     item.Suggestion.Recommended,
+			InObjectGeoZone: item.Suggestion.InObjectGeoZone,
+			Busy:            item.Suggestion.Busy,
+			AssignedToAlarm: item.Suggestion.AssignedToAlarm,
+			DistanceKnown:   item.Suggestion.DistanceKnown,
+			RoadDistance:    item.Suggestion.RoadDistance,
+			DistanceKm:      item.Suggestion.DistanceKm,
+			Note:            item.Suggestion.Note,
+		}
+	}
+	return result
+}
+
+func FromResponseGroupListResponse(response ResponseGroupListResponse) []contracts.FrontendResponseGroup {
+	items := make([]contracts.FrontendResponseGroup, 0, len(response.Items))
+	for _, item := range response.Items {
+		items = append(items, FromResponseGroup(item))
+	}
+	return items
+}
+
+func ToObjectUpsertRequest(request contracts.FrontendObjectUpsertRequest) ObjectUpsertRequest {
+	result := ObjectUpsertRequest{
+		Source:   toSource(request.Source),
+		ObjectID: request.ObjectID,
+		Core: ObjectCoreFields{
+			Name:        request.Core.Name,
+			Address:     request.Core.Address,
+			Contract:    request.Core.Contract,
+			Description: request.Core.Description,
+			Notes:       request.Core.Notes,
+			Latitude:    request.Core.Latitude,
+			Longitude:   request.Core.Longitude,
+		},
+	}
+	if legacy := request.Legacy; legacy != nil {
+		result.Legacy = &LegacyObjectPayload{
+			ObjUIN:             legacy.ObjUIN,
+			ObjN:               legacy.ObjN,
+			GrpN:               legacy.GrpN,
+			PanelID:            legacy.PanelID,
+			ObjTypeID:          legacy.ObjTypeID,
+			ObjRegID:           legacy.ObjRegID,
+			ChannelCode:        legacy.ChannelCode,
+			PPKID:              legacy.PPKID,
+			GSMHiddenN:         legacy.GSMHiddenN,
+			TestIntervalMin:    legacy.TestIntervalMin,
+			ShortName:          legacy.ShortName,
+			FullName:           legacy.FullName,
+			Phones:             legacy.Phones,
+			StartDate:          legacy.StartDate,
+			Location:           legacy.Location,
+			GSMPhone1:          legacy.GSMPhone1,
+			GSMPhone2:          legacy.GSMPhone2,
+			SubServerA:         legacy.SubServerA,
+			SubServerB:         legacy.SubServerB,
+			TestControlEnabled: legacy.TestControlEnabled,
+		}
+	}
+	if casl := request.CASL; casl != nil {
+		result.CASL = &CASLObjectPayload{
+			ObjID:          casl.ObjID,
+			ManagerID:      casl.ManagerID,
+			Status:         casl.Status,
+			ObjectType:     casl.ObjectType,
+			IDRequest:      casl.IDRequest,
+			ReactingPultID: casl.ReactingPultID,
+			StartDate:      casl.StartDate,
+			GeoZoneID:      casl.GeoZoneID,
+			BusinessCoeff:  casl.BusinessCoeff,
+		}
+	}
+	return result
+}
+
+func ToAlarmPickRequest(request contracts.FrontendAlarmPickRequest) AlarmPickRequest {
+	return AlarmPickRequest{User: request.User, Reason: request.Reason}
+}
+
+func ToAlarmProcessRequest(request contracts.FrontendAlarmProcessRequest) AlarmProcessRequest {
+	return AlarmProcessRequest{User: request.User, CauseCode: request.CauseCode, Note: request.Note}
+}
+
+func ToAlarmGroupActionRequest(request contracts.FrontendAlarmGroupActionRequest) AlarmGroupActionRequest {
+	return AlarmGroupActionRequest{GroupID: request.GroupID}
+}
+
+func ToStandbyRequest(request contracts.FrontendStandbyRequest) StandbyRequest {
+	return StandbyRequest{DurationMinutes: request.DurationMinutes, Reason: request.Reason}
+}
+
+func fromEvents(items []EventItem) []contracts.FrontendEventItem {
+	result := make([]contracts.FrontendEventItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, FromEventItem(item))
+	}
+	return result
+}
+
+// parseTimestamp розбирає RFC3339 з formatTimestamp і повертає локальний час.
+func parseTimestamp(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed.Local()
+}
@@ -29,6 +29,7 @@ func ToCapabilities(capabilities contracts.FrontendCapabilities) Capabilities {
 	for _, item := range capabilities.Sources {
 		items = append(items, SourceCapability{
 			Source:            toSource(item.Source),
+			Instance:          item.Instance,
 			DisplayName:       item.DisplayName,
 			ReadObjects:       item.ReadObjects,
 			ReadObjectDetails: item.ReadObjectDetails,
@@ -83,7 +84,8 @@ func FromAlarmProcessRequest(request AlarmProcessRequest) contracts.FrontendAlar
 
 func FromAlarmPickRequest(request AlarmPickRequest) contracts.FrontendAlarmPickRequest {
 	return contracts.FrontendAlarmPickRequest{
-		User: request.User,
+		User:   request.User,
+		Reason: request.Reason,
 	}
 }
 
@@ -138,6 +140,18 @@ func ToObjectSummary(item contracts.FrontendObjectSummary) ObjectSummary {
 }
 
 func ToAlarmItem(item contracts.FrontendAlarmItem) AlarmItem {
+	var priority *AlarmPriority
+	if item.Priority != (contracts.FrontendAlarmPriority{}) {
+		priority = &AlarmPriority{
+			Score:      item.Priority.Score,
+			Type:       item.Priority.Type,
+			ObjectType: item.Priority.ObjectType,
+			Contract:   item.Priority.Contract,
+			Repeats:    item.Priority.Repeats,
+			Unattended: item.Priority.Unattended,
+			Dispatched: item.Priority.Dispatched,
+		}
+	}
 	return AlarmItem{
 		ID:                        item.ID,
 		Source:                    toSource(item.Source),
@@ -163,7 +177,10 @@ func ToAlarmItem(item contracts.FrontendAlarmItem) AlarmItem {
 		ResponseGroupID:           item.ResponseGroupID,
 		IsResponseGroupDispatched: item.IsResponseGroupDispatched,
 		IsResponseGroupArrived:    item.IsResponseGroupArrived,
+		MaintenanceReason:         item.MaintenanceReason,
+		Watched:                   item.Watched,
 		VisualSeverity:            toVisualSeverity(item.VisualSeverity),
+		Priority:                  priority,
 	}
 }
 
@@ -274,6 +291,7 @@ func fromLegacyPayload(payload *LegacyObjectPayload) *contracts.FrontendLegacyOb
 		ObjUIN:             payload.ObjUIN,
 		ObjN:               payload.ObjN,
 		GrpN:               payload.GrpN,
+		PanelID:            payload.PanelID,
 		ObjTypeID:          payload.ObjTypeID,
 		ObjRegID:           payload.ObjRegID,
 		ChannelCode:        payload.ChannelCode,
@@ -394,6 +412,20 @@ func formatTimestamp(value time.Time) string {
 }
 
 func ToResponseGroup(item contracts.FrontendResponseGroup) ResponseGroup {
+	var suggestion *DispatchSuggestion
+	if item.Suggestion.Rank > 0 {
+		suggestion = &DispatchSuggestion{
+			Rank:            item.Suggestion.Rank,
+			Recommended:     item.Suggestion.Recommended,
+			InObjectGeoZone: item.Suggestion.InObjectGeoZone,
+			Busy:            item.Suggestion.Busy,
+			AssignedToAlarm: item.Suggestion.AssignedToAlarm,
+			DistanceKnown:   item.Suggestion.DistanceKnown,
+			RoadDistance:    item.Suggestion.RoadDistance,
+			DistanceKm:      item.Suggestion.DistanceKm,
+			Note:            item.Suggestion.Note,
+		}
+	}
 	return ResponseGroup{
 		ID:              item.ID,
 		Name:            item.Name,
@@ -407,6 +439,7 @@ func ToResponseGroup(item contracts.FrontendResponseGroup) ResponseGroup {
 		Latitude:        item.Latitude,
 		Longitude:       item.Longitude,
 		StatusChangedAt: formatTimestamp(item.StatusChangedAt),
+		Suggestion:      suggestion,
 	}
 }
 
@@ -421,3 +454,51 @@ func ToResponseGroupListResponse(items []contracts.FrontendResponseGroup) Respon
 func FromAlarmGroupActionRequest(request AlarmGroupActionRequest) contracts.FrontendAlarmGroupActionRequest {
 	return contracts.FrontendAlarmGroupActionRequest{GroupID: request.GroupID}
 }
+
+func ToSavedViewList(items []contracts.FrontendSavedView) SavedViewList {
+	result := make([]SavedView, 0, len(items))
+	for _, item := range items {
+		result = append(result, SavedView{Name: item.Name, Query: item.Query})
+	}
+	return SavedViewList{Items: result}
+}
+
+func FromSavedViewList(request SavedViewList) []contracts.FrontendSavedView {
+	result := make([]contracts.FrontendSavedView, 0, len(request.Items))
+	for _, item := range request.Items {
+		result = append(result, contracts.FrontendSavedView{Name: item.Name, Query: item.Query})
+	}
+	return result
+}
+
+func ToObjectGroupList(items []contracts.FrontendObjectGroup) ObjectGroupList {
+	result := make([]ObjectGroup, 0, len(items))
+	for _, item := range items {
+		result = append(result, ObjectGroup{
+			ID:        item.ID,
+			Name:      item.Name,
+			ObjectIDs: item.ObjectIDs,
+			Query:     item.Query,
+			Watch:     item.Watch,
+			UpdatedBy: item.UpdatedBy,
+			UpdatedAt: formatTimestamp(item.UpdatedAt),
+		})
+	}
+	return ObjectGroupList{Items: result}
+}
+
+func FromObjectGroupList(request ObjectGroupList) []contracts.FrontendObjectGroup {
+	result := make([]contracts.FrontendObjectGroup, 0, len(request.Items))
+	for _, item := range request.Items {
+		result = append(result, contracts.FrontendObjectGroup{
+			ID:        item.ID,
+			Name:      item.Name,
+			ObjectIDs: item.ObjectIDs,
+			Query:     item.Query,
+			Watch:     item.Watch,
+			UpdatedBy: item.UpdatedBy,
+			UpdatedAt: parseTimestamp(item.UpdatedAt),
+		})
+	}
+	return result
+}
@@ -122,3 +122,31 @@ func TestToResponseGroupIncludesOperationalState(t *testing.T) {
 		t.Fatalf("StatusChangedAt = %q", got.StatusChangedAt)
 	}
 }
+
+func TestAlarmItemPriorityRoundTrip(t *testing.T) {
+	item := contracts.FrontendAlarmItem{
+		ID:       3,
+		Priority: contracts.FrontendAlarmPriority{Score: 140, Type: 100, ObjectType: 30, Contract: 10},
+	}
+	dto := ToAlarmItem(item)
+	if dto.Priority == nil || dto.Priority.Score != 140 || dto.Priority.ObjectType != 30 {
+		t.Fatalf("ToAlarmItem().Priority = %+v", dto.Priority)
+	}
+	if got := FromAlarmItem(dto); got.Priority != item.Priority {
+		t.Fatalf("FromAlarmItem().Priority = %+v, want %+v", got.Priority, item.Priority)
+	}
+	if dto := ToAlarmItem(contracts.FrontendAlarmItem{ID: 4}); dto.Priority != nil {
+		t.Fatalf("alarm without score must omit Priority, got %+v", dto.Priority)
+	}
+}
+
+func TestBuildAlarmGroupsPutsHighestScoreFirst(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	groups := BuildAlarmGroups([]contracts.FrontendAlarmItem{
+		{ID: 1, ObjectID: 10, Time: now, Priority: contracts.FrontendAlarmPriority{Score: 15}},
+		{ID: 2, ObjectID: 20, Time: now.Add(-time.Hour), Priority: contracts.FrontendAlarmPriority{Score: 100}},
+	})
+	if len(groups) != 2 || groups[0].ObjectID != 20 || groups[1].ObjectID != 10 {
+		t.Fatalf("groups = %+v, want object 20 with the higher score first", groups)
+	}
+}
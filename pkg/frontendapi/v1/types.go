@@ -55,6 +55,7 @@ const (
 
 type SourceCapability struct {
 	Source            Source `json:"Source"`
+	Instance          string `json:"Instance,omitempty"`
 	DisplayName       string `json:"DisplayName"`
 	ReadObjects       bool   `json:"ReadObjects"`
 	ReadObjectDetails bool   `json:"ReadObjectDetails"`
@@ -145,7 +146,22 @@ type AlarmItem struct {
 	ResponseGroupID           string         `json:"ResponseGroupID"`
 	IsResponseGroupDispatched bool           `json:"IsResponseGroupDispatched"`
 	IsResponseGroupArrived    bool           `json:"IsResponseGroupArrived"`
+	MaintenanceReason         string         `json:"MaintenanceReason,omitempty"`
+	Watched                   bool           `json:"Watched,omitempty"`
 	VisualSeverity            VisualSeverity `json:"VisualSeverity"`
+	Priority                  *AlarmPriority `json:"Priority,omitempty"`
+}
+
+// AlarmPriority is the alarm score with the points of every factor; the list
+// is sorted by Score. Dispatched is negative when a response group is on its way.
+type AlarmPriority struct {
+	Score      int `json:"Score"`
+	Type       int `json:"Type"`
+	ObjectType int `json:"ObjectType"`
+	Contract   int `json:"Contract"`
+	Repeats    int `json:"Repeats"`
+	Unattended int `json:"Unattended"`
+	Dispatched int `json:"Dispatched"`
 }
 
 type AlarmProcessingOption struct {
@@ -160,7 +176,8 @@ type AlarmProcessRequest struct {
 }
 
 type AlarmPickRequest struct {
-	User string `json:"User"`
+	User   string `json:"User"`
+	Reason string `json:"Reason,omitempty"`
 }
 
 type AlarmGroup struct {
@@ -235,6 +252,7 @@ type LegacyObjectPayload struct {
 	ObjUIN             int64  `json:"ObjUIN"`
 	ObjN               int64  `json:"ObjN"`
 	GrpN               int64  `json:"GrpN"`
+	PanelID            string `json:"PanelID,omitempty"`
 	ObjTypeID          int64  `json:"ObjTypeID"`
 	ObjRegID           int64  `json:"ObjRegID"`
 	ChannelCode        int64  `json:"ChannelCode"`
@@ -299,6 +317,29 @@ type EventListResponse struct {
 	Items []EventItem `json:"items"`
 }
 
+type SavedView struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type SavedViewList struct {
+	Items []SavedView `json:"items"`
+}
+
+type ObjectGroup struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	ObjectIDs []int  `json:"objectIds,omitempty"`
+	Query     string `json:"query,omitempty"`
+	Watch     bool   `json:"watch"`
+	UpdatedBy string `json:"updatedBy,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+type ObjectGroupList struct {
+	Items []ObjectGroup `json:"items"`
+}
+
 type EventPageResponse struct {
 	Items      []EventItem `json:"items"`
 	TotalCount int         `json:"totalCount"`
@@ -322,6 +363,20 @@ type ResponseGroup struct {
 	Latitude        string              `json:"Latitude"`
 	Longitude       string              `json:"Longitude"`
 	StatusChangedAt string              `json:"StatusChangedAt"`
+	Suggestion      *DispatchSuggestion `json:"Suggestion,omitempty"`
+}
+
+// DispatchSuggestion is filled only for alarm-scoped group lists.
+type DispatchSuggestion struct {
+	Rank            int     `json:"Rank"`
+	Recommended     bool    `json:"Recommended"`
+	InObjectGeoZone bool    `json:"InObjectGeoZone"`
+	Busy            bool    `json:"Busy"`
+	AssignedToAlarm bool    `json:"AssignedToAlarm"`
+	DistanceKnown   bool    `json:"DistanceKnown"`
+	RoadDistance    bool    `json:"RoadDistance"`
+	DistanceKm      float64 `json:"DistanceKm"`
+	Note            string  `json:"Note"`
 }
 
 type ResponseGroupListResponse struct {
@@ -331,3 +386,25 @@ type ResponseGroupListResponse struct {
 type AlarmGroupActionRequest struct {
 	GroupID string `json:"GroupID"`
 }
+
+type AlarmGroupProcessRequest struct {
+	User string `json:"User"`
+}
+
+type StandbyRequest struct {
+	DurationMinutes int    `json:"durationMinutes"`
+	Reason          string `json:"reason"`
+}
+
+type DialRequest struct {
+	Phone string `json:"phone"`
+}
+
+type DialResponse struct {
+	CallID string `json:"callID"`
+}
+
+type AMIStatus struct {
+	Connected bool `json:"connected"`
+	Enabled   bool `json:"enabled"`
+}
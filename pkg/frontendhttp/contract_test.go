@@ -0,0 +1,346 @@
+package frontendhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Contract-тести звіряють OpenAPI-документ з реальною поведінкою Handler:
+// кожна описана операція має відповідати заявленим статусом і схемою,
+// а неописані методи на описаних шляхах — 405. Якщо тест падає після зміни
+// обробника чи DTO, оновіть apiRoutes і перевірте сумісність за API_VERSIONING.md.
+
+type contractDialerStub struct{}
+
+func (contractDialerStub) DialPhone(string) (string, error) { return "call-1", nil }
+func (contractDialerStub) HangupCall(string)                {}
+func (contractDialerStub) IsDialerConnected() bool          { return true }
+
+type contractAMISettingsStub struct{}
+
+func (contractAMISettingsStub) GetAMISettings() contracts.AMISettings {
+	return contracts.AMISettings{Enabled: true, Host: "127.0.0.1", Port: 5038}
+}
+
+func (contractAMISettingsStub) SaveAMISettings(contracts.AMISettings) error { return nil }
+
+var contractRequestBodies = map[string]string{
+	"POST /dial": `{"phone":"101"}`,
+}
+
+func newContractHandler() http.Handler {
+	summary := contracts.FrontendObjectSummary{ID: 7, Source: contracts.FrontendSourceBridge, Name: "Школа"}
+	event := contracts.FrontendEventItem{ID: 1, ObjectID: 7, Source: contracts.FrontendSourceBridge}
+	alarm := contracts.FrontendAlarmItem{
+		ID:       7,
+		ObjectID: 7,
+		Source:   contracts.FrontendSourceBridge,
+		Priority: contracts.FrontendAlarmPriority{Score: 90, Type: 80, Repeats: 10},
+	}
+	stub := &frontendBackendStub{
+		capabilitiesResult: contracts.FrontendCapabilities{
+			Sources: []contracts.FrontendSourceCapability{{Source: contracts.FrontendSourceBridge}},
+		},
+		objectsResult:                []contracts.FrontendObjectSummary{summary},
+		alarmsResult:                 []contracts.FrontendAlarmItem{alarm},
+		alarmProcessingOptionsResult: []contracts.FrontendAlarmProcessingOption{{Code: "1", Label: "Хибна"}},
+		eventsResult:                 []contracts.FrontendEventItem{event},
+		objectEventsResult:           contracts.FrontendEventPage{Items: []contracts.FrontendEventItem{event}, TotalCount: 1},
+		detailsResult: contracts.FrontendObjectDetails{
+			Summary:  summary,
+			Zones:    []contracts.FrontendZone{{Number: 1}},
+			Contacts: []contracts.FrontendContact{{Name: "Відповідальний"}},
+			Events:   []contracts.FrontendEventItem{event},
+		},
+		createResult: contracts.FrontendObjectMutationResult{ObjectID: 7, Source: contracts.FrontendSourceBridge},
+		updateResult: contracts.FrontendObjectMutationResult{ObjectID: 7, Source: contracts.FrontendSourceBridge},
+	}
+	backend := &objectGroupsBackendStub{queryBackendStub: &queryBackendStub{eventExportBackendStub: &eventExportBackendStub{frontendBackendStub: stub}}}
+	return NewHandlerFull(backend, contractDialerStub{}, contractAMISettingsStub{}, nil)
+}
+
+func servedOpenAPIDocument(t *testing.T, path string) map[string]any {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	NewHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s status = %d, want %d", path, rec.Code, http.StatusOK)
+	}
+	var document map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &document); err != nil {
+		t.Fatalf("decode OpenAPI document: %v", err)
+	}
+	return document
+}
+
+func contractPaths(document map[string]any) map[string]map[string]any {
+	result := map[string]map[string]any{}
+	paths, _ := document["paths"].(map[string]any)
+	for path, raw := range paths {
+		operations, _ := raw.(map[string]any)
+		result[path] = operations
+	}
+	return result
+}
+
+func TestOpenAPIDocumentServedOnBothPaths(t *testing.T) {
+	document := servedOpenAPIDocument(t, OpenAPIPath)
+	alias := servedOpenAPIDocument(t, OpenAPIAliasPath)
+
+	if document["openapi"] != "3.0.3" {
+		t.Fatalf("openapi = %v", document["openapi"])
+	}
+	if len(contractPaths(alias)) != len(contractPaths(document)) {
+		t.Fatal("alias path must serve the same document")
+	}
+	paths := contractPaths(document)
+	for _, item := range apiRoutes {
+		if _, ok := paths[APIV1BasePath+item.Path][strings.ToLower(item.Method)]; !ok {
+			t.Errorf("route %s %s is missing in OpenAPI", item.Method, item.Path)
+		}
+	}
+}
+
+func TestContractOperationsMatchHandler(t *testing.T) {
+	document := servedOpenAPIDocument(t, OpenAPIPath)
+	handler := newContractHandler()
+
+	for path, operations := range contractPaths(document) {
+		if path == OpenAPIPath {
+			continue
+		}
+		for method, raw := range operations {
+			operation, _ := raw.(map[string]any)
+			method = strings.ToUpper(method)
+			t.Run(method+" "+path, func(t *testing.T) {
+				status, schema := successResponse(t, operation)
+
+				var body *strings.Reader
+				if _, hasBody := operation["requestBody"]; hasBody {
+					payload, ok := contractRequestBodies[method+" "+strings.TrimPrefix(path, APIV1BasePath)]
+					if !ok {
+						payload = "{}"
+					}
+					body = strings.NewReader(payload)
+				} else {
+					body = strings.NewReader("")
+				}
+
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(method, concretePath(path), body))
+				if rec.Code != status {
+					t.Fatalf("status = %d, want %d; body = %s", rec.Code, status, rec.Body.String())
+				}
+				if schema == nil {
+					if media := successFileMediaTypes(operation); len(media) > 0 {
+						contentType := rec.Header().Get("Content-Type")
+						if !slices.ContainsFunc(media, func(item string) bool { return strings.HasPrefix(contentType, item) }) {
+							t.Fatalf("Content-Type = %q, spec declares %v", contentType, media)
+						}
+						return
+					}
+					if rec.Body.Len() != 0 {
+						t.Fatalf("operation declares no body, got %s", rec.Body.String())
+					}
+					return
+				}
+				var value any
+				if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+					t.Fatalf("decode response: %v", err)
+				}
+				if err := matchSchema(document, schema, value, "$"); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestContractUndeclaredMethodsAreRejected(t *testing.T) {
+	document := servedOpenAPIDocument(t, OpenAPIPath)
+	handler := newContractHandler()
+
+	for path, operations := range contractPaths(document) {
+		var declared []string
+		for method := range operations {
+			declared = append(declared, strings.ToUpper(method))
+		}
+		sort.Strings(declared)
+
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch} {
+			if slices.Contains(declared, method) {
+				continue
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, concretePath(path), strings.NewReader("{}")))
+			if rec.Code != http.StatusMethodNotAllowed {
+				t.Errorf("%s %s status = %d, want %d", method, path, rec.Code, http.StatusMethodNotAllowed)
+				continue
+			}
+			allowed := strings.Split(rec.Header().Get("Allow"), ", ")
+			sort.Strings(allowed)
+			if !slices.Equal(allowed, declared) {
+				t.Errorf("%s Allow = %v, spec declares %v", path, allowed, declared)
+			}
+		}
+	}
+}
+
+func TestContractErrorResponsesMatchSchema(t *testing.T) {
+	document := servedOpenAPIDocument(t, OpenAPIPath)
+	rec := httptest.NewRecorder()
+	newContractHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects/abc", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	var value any
+	if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if err := matchSchema(document, map[string]any{"$ref": "#/components/schemas/Error"}, value, "$"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func concretePath(path string) string {
+	replacer := strings.NewReplacer("{objectID}", "7", "{alarmID}", "7", "{callID}", "call-1")
+	return replacer.Replace(path)
+}
+
+func successResponse(t *testing.T, operation map[string]any) (int, map[string]any) {
+	t.Helper()
+	responses, _ := operation["responses"].(map[string]any)
+	for key, raw := range responses {
+		var status int
+		if _, err := fmt.Sscanf(key, "%d", &status); err != nil || status < 200 || status >= 300 {
+			continue
+		}
+		response, _ := raw.(map[string]any)
+		content, _ := response["content"].(map[string]any)
+		media, _ := content["application/json"].(map[string]any)
+		schema, _ := media["schema"].(map[string]any)
+		return status, schema
+	}
+	t.Fatal("operation declares no success response")
+	return 0, nil
+}
+
+// successFileMediaTypes повертає медіатипи файлового тіла успішної відповіді.
+func successFileMediaTypes(operation map[string]any) []string {
+	var result []string
+	responses, _ := operation["responses"].(map[string]any)
+	for key, raw := range responses {
+		if !strings.HasPrefix(key, "2") {
+			continue
+		}
+		response, _ := raw.(map[string]any)
+		content, _ := response["content"].(map[string]any)
+		for mediaType := range content {
+			if mediaType != "application/json" {
+				result = append(result, mediaType)
+			}
+		}
+	}
+	return result
+}
+
+// matchSchema перевіряє JSON-значення на відповідність підмножині OpenAPI-схеми,
+// яку генерує пакет openapi: $ref, allOf, nullable, type, properties, items.
+// Набір полів об'єкта має збігатися зі схемою в обидва боки.
+func matchSchema(document map[string]any, schema map[string]any, value any, at string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		components, _ := document["components"].(map[string]any)
+		schemas, _ := components["schemas"].(map[string]any)
+		resolved, ok := schemas[name].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: unresolved schema %s", at, ref)
+		}
+		return matchSchema(document, resolved, value, at)
+	}
+	if value == nil {
+		if schema["nullable"] == true {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", at)
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, raw := range allOf {
+			part, _ := raw.(map[string]any)
+			if err := matchSchema(document, part, value, at); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", at, value)
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+			for key, item := range object {
+				if err := matchSchema(document, additional, item, at+"."+key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key := range object {
+			if _, ok := properties[key]; !ok {
+				return fmt.Errorf("%s: field %q is not described in the spec", at, key)
+			}
+		}
+		for key, raw := range properties {
+			item, present := object[key]
+			if !present {
+				// omitempty-поля можуть бути відсутні; решта Go завжди кодує.
+				continue
+			}
+			property, _ := raw.(map[string]any)
+			if err := matchSchema(document, property, item, at+"."+key); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", at, value)
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		for i, item := range items {
+			if err := matchSchema(document, itemSchema, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", at, value)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s: expected integer, got %v", at, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", at, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", at, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package frontendhttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// EventsExportAliasPath — коротка адреса експорту журналу подій для інтеграторів.
+const EventsExportAliasPath = "/api/v1/events/export"
+
+const (
+	csvContentType  = "text/csv; charset=utf-8"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	eventExportDateLayout     = "2006-01-02"
+	eventExportDefaultPeriod  = 24 * time.Hour
+	eventExportDefaultFormat  = "csv"
+	eventExportFileNamePrefix = "events_"
+)
+
+// handleEventsExport віддає відфільтрований журнал подій файлом CSV чи XLSX.
+// Файл пишеться у відповідь потоково; заголовки й статус 200 надсилаються лише
+// з першими байтами, тож помилки фільтра чи бекенду ще повертаються як JSON.
+func (h *Handler) handleEventsExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, http.MethodGet)
+		return
+	}
+	backend, ok := h.backend.(contracts.FrontendEventExportBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "event export is not supported")
+		return
+	}
+	request, err := parseEventExportRequest(r, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	out := &fileResponseWriter{
+		w:           w,
+		contentType: csvContentType,
+		fileName:    eventExportFileNamePrefix + request.To.Format(eventExportDateLayout) + "." + request.Format,
+	}
+	if request.Format == "xlsx" {
+		out.contentType = xlsxContentType
+	}
+	if _, err := backend.ExportEvents(r.Context(), request, out); err != nil {
+		if !out.started {
+			writeBackendError(w, err)
+			return
+		}
+		// Відповідь уже почалася: розриваємо з'єднання, щоб клієнт не прийняв
+		// обрізаний файл за повний журнал.
+		panic(http.ErrAbortHandler)
+	}
+	out.start()
+}
+
+func parseEventExportRequest(r *http.Request, now time.Time) (contracts.FrontendEventExportRequest, error) {
+	query := r.URL.Query()
+	request := contracts.FrontendEventExportRequest{
+		Format:   strings.ToLower(strings.TrimSpace(query.Get("format"))),
+		Severity: strings.TrimSpace(query.Get("severity")),
+		Types:    queryList(query["type"]),
+	}
+	switch request.Format {
+	case "":
+		request.Format = eventExportDefaultFormat
+	case "csv", "xlsx":
+	default:
+		return request, errors.New("invalid format: use csv or xlsx")
+	}
+	for _, source := range queryList(query["source"]) {
+		request.Sources = append(request.Sources, contracts.FrontendSource(strings.ToLower(source)))
+	}
+	for _, raw := range queryList(query["object"]) {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id == 0 {
+			return request, errors.New("invalid object")
+		}
+		request.ObjectIDs = append(request.ObjectIDs, id)
+	}
+
+	var err error
+	request.To = now
+	if raw := strings.TrimSpace(query.Get("to")); raw != "" {
+		if request.To, err = parseEventExportTime(raw, true); err != nil {
+			return request, errors.New("invalid to")
+		}
+	}
+	request.From = request.To.Add(-eventExportDefaultPeriod)
+	if raw := strings.TrimSpace(query.Get("from")); raw != "" {
+		if request.From, err = parseEventExportTime(raw, false); err != nil {
+			return request, errors.New("invalid from")
+		}
+	}
+	if request.To.Before(request.From) {
+		return request, errors.New("to is before from")
+	}
+	return request, nil
+}
+
+// parseEventExportTime приймає RFC 3339 або дату РРРР-ММ-ДД; дата як кінець
+// періоду включає весь день.
+func parseEventExportTime(raw string, endOfDay bool) (time.Time, error) {
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return value, nil
+	}
+	value, err := time.ParseInLocation(eventExportDateLayout, raw, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		value = value.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return value, nil
+}
+
+// queryList розгортає повторювані параметри й значення через кому.
+func queryList(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// fileResponseWriter відкладає заголовки файлової відповіді до першого запису.
+type fileResponseWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (f *fileResponseWriter) start() {
+	if f.started {
+		return
+	}
+	f.started = true
+	f.w.Header().Set("Content-Type", f.contentType)
+	f.w.Header().Set("Content-Disposition", `attachment; filename="`+f.fileName+`"`)
+	f.w.WriteHeader(http.StatusOK)
+}
+
+func (f *fileResponseWriter) Write(p []byte) (int, error) {
+	f.start()
+	return f.w.Write(p)
+}
@@ -0,0 +1,81 @@
+package frontendhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	frontendv1 "obj_catalog_fyne_v3/pkg/frontendapi/v1"
+)
+
+// handleObjectsQuery фільтрує об'єкти виразом мови фільтрів (параметр q).
+// Помилка синтаксису повертається як 400 з текстом для оператора.
+func (h *Handler) handleObjectsQuery(w http.ResponseWriter, r *http.Request, query string, members map[int]struct{}) {
+	backend, ok := h.backend.(contracts.FrontendQueryBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "filter queries are not supported")
+		return
+	}
+	items, err := backend.QueryObjects(r.Context(), query)
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, frontendv1.ToObjectListResponse(filterByGroup(items, members, objectSummaryID)))
+}
+
+// handleEventsQuery фільтрує журнал подій виразом мови фільтрів (параметр q).
+func (h *Handler) handleEventsQuery(w http.ResponseWriter, r *http.Request, query string, members map[int]struct{}) {
+	backend, ok := h.backend.(contracts.FrontendQueryBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "filter queries are not supported")
+		return
+	}
+	items, err := backend.QueryEvents(r.Context(), query)
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, frontendv1.ToEventListResponse(filterByGroup(items, members, eventObjectID)))
+}
+
+// handleSavedViews віддає (GET) або повністю замінює (PUT) список збережених подань.
+func (h *Handler) handleSavedViews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		writeMethodNotAllowed(w, http.MethodGet, http.MethodPut)
+		return
+	}
+	backend, ok := h.backend.(contracts.FrontendSavedViewsBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "saved views are not supported")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		items, err := backend.ListSavedViews(r.Context())
+		if err != nil {
+			writeBackendError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, frontendv1.ToSavedViewList(items))
+	case http.MethodPut:
+		if r.Body == nil {
+			writeError(w, http.StatusBadRequest, "request body is required")
+			return
+		}
+		defer r.Body.Close()
+		var request frontendv1.SavedViewList
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json body")
+			return
+		}
+		if err := backend.SaveSavedViews(r.Context(), frontendv1.FromSavedViewList(request)); err != nil {
+			writeBackendError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -14,7 +14,9 @@ import (
 
 const (
 	APIV1BasePath = "/api/frontend/v1"
-	contentType   = "application/json; charset=utf-8"
+	// ClientIDHeader ідентифікує робоче місце тонкого клієнта на cmd/operator-server.
+	ClientIDHeader = "X-Client-ID"
+	contentType    = "application/json; charset=utf-8"
 )
 
 type Handler struct {
@@ -60,6 +62,10 @@ func (h *Handler) setDialer(d contracts.PhoneDialer) {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path := strings.TrimSuffix(strings.TrimSpace(r.URL.Path), "/"); path == OpenAPIPath || path == OpenAPIAliasPath {
+		handleOpenAPI(w, r)
+		return
+	}
 	if h == nil || h.backend == nil {
 		writeError(w, http.StatusServiceUnavailable, "frontend backend is unavailable")
 		return
@@ -85,6 +91,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleResponseGroups(w, r)
 	case path == APIV1BasePath+"/events":
 		h.handleEvents(w, r)
+	case path == APIV1BasePath+"/events/export" || path == EventsExportAliasPath:
+		h.handleEventsExport(w, r)
+	case path == APIV1BasePath+"/saved-views":
+		h.handleSavedViews(w, r)
+	case path == APIV1BasePath+"/object-groups":
+		h.handleObjectGroups(w, r)
 	case path == APIV1BasePath+"/dial":
 		h.handleDial(w, r)
 	case strings.HasPrefix(path, APIV1BasePath+"/dial/"):
@@ -115,12 +127,20 @@ func (h *Handler) handleCapabilities(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleObjectsCollection(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
+		members, ok := h.objectGroupMembers(w, r)
+		if !ok {
+			return
+		}
+		if query := strings.TrimSpace(r.URL.Query().Get("q")); query != "" {
+			h.handleObjectsQuery(w, r, query, members)
+			return
+		}
 		items, err := h.backend.ListObjects(r.Context())
 		if err != nil {
 			writeBackendError(w, err)
 			return
 		}
-		writeJSON(w, http.StatusOK, frontendv1.ToObjectListResponse(items))
+		writeJSON(w, http.StatusOK, frontendv1.ToObjectListResponse(filterByGroup(items, members, objectSummaryID)))
 	case http.MethodPost:
 		request, ok := decodeUpsertRequest(w, r)
 		if !ok {
@@ -228,10 +248,7 @@ func (h *Handler) handleObjectStandby(w http.ResponseWriter, r *http.Request, ob
 		return
 	}
 
-	var req struct {
-		DurationMinutes int    `json:"durationMinutes"`
-		Reason          string `json:"reason"`
-	}
+	var req frontendv1.StandbyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
@@ -253,7 +270,14 @@ func (h *Handler) handleAlarms(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	members, ok := h.objectGroupMembers(w, r)
+	if !ok {
+		return
+	}
 	items, err := h.backend.ListAlarms(r.Context())
+	if err == nil {
+		items, err = h.watchAlarms(r, items, members)
+	}
 	if err != nil {
 		writeBackendError(w, err)
 		return
@@ -295,6 +319,8 @@ func (h *Handler) handleAlarmItem(w http.ResponseWriter, r *http.Request, rawID
 		h.handleAlarmCancelGroup(w, r, alarmID)
 	case "group-process":
 		h.handleAlarmGroupProcess(w, r, alarmID)
+	case "response-groups":
+		h.handleAlarmResponseGroups(w, r, alarmID)
 	default:
 		writeError(w, http.StatusNotFound, "route not found")
 	}
@@ -328,6 +354,28 @@ func (h *Handler) handleResponseGroups(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, frontendv1.ToResponseGroupListResponse(items))
 }
 
+func (h *Handler) handleAlarmResponseGroups(w http.ResponseWriter, r *http.Request, alarmID int) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	var (
+		items []contracts.FrontendResponseGroup
+		err   error
+	)
+	if backend, ok := h.backend.(contracts.FrontendAlarmResponseGroupsBackend); ok {
+		items, err = backend.ListResponseGroupsForAlarm(r.Context(), alarmID)
+	} else {
+		items, err = h.backend.ListResponseGroups(r.Context())
+	}
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, frontendv1.ToResponseGroupListResponse(items))
+}
+
 func (h *Handler) handleAlarmProcessingOptions(w http.ResponseWriter, r *http.Request, alarmID int) {
 	if r.Method != http.MethodGet {
 		writeMethodNotAllowed(w, http.MethodGet)
@@ -348,10 +396,7 @@ func (h *Handler) handleAlarmGroupProcess(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	type groupProcessRequest struct {
-		User string `json:"User"`
-	}
-	var req groupProcessRequest
+	var req frontendv1.AlarmGroupProcessRequest
 	if r.Body != nil {
 		defer r.Body.Close()
 		_ = json.NewDecoder(r.Body).Decode(&req)
@@ -506,12 +551,20 @@ func (h *Handler) handleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	members, ok := h.objectGroupMembers(w, r)
+	if !ok {
+		return
+	}
+	if query := strings.TrimSpace(r.URL.Query().Get("q")); query != "" {
+		h.handleEventsQuery(w, r, query, members)
+		return
+	}
 	items, err := h.backend.ListEvents(r.Context())
 	if err != nil {
 		writeBackendError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, frontendv1.ToEventListResponse(items))
+	writeJSON(w, http.StatusOK, frontendv1.ToEventListResponse(filterByGroup(items, members, eventObjectID)))
 }
 
 func decodeUpsertRequest(w http.ResponseWriter, r *http.Request) (frontendv1.ObjectUpsertRequest, bool) {
@@ -593,6 +646,8 @@ func writeBackendError(w http.ResponseWriter, err error) {
 		writeError(w, http.StatusServiceUnavailable, err.Error())
 	case errors.Is(err, contracts.ErrUnsupportedFrontendSource):
 		writeError(w, http.StatusNotImplemented, err.Error())
+	case errors.Is(err, contracts.ErrFrontendRevisionConflict):
+		writeError(w, http.StatusConflict, err.Error())
 	case errors.Is(err, contracts.ErrMissingLegacyObjectPayload),
 		errors.Is(err, contracts.ErrMissingCASLObjectPayload):
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -627,9 +682,7 @@ func (h *Handler) handleDial(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		Phone string `json:"phone"`
-	}
+	var req frontendv1.DialRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Phone) == "" {
 		writeError(w, http.StatusBadRequest, "phone is required")
 		return
@@ -641,7 +694,7 @@ func (h *Handler) handleDial(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"callID": callID})
+	writeJSON(w, http.StatusOK, frontendv1.DialResponse{CallID: callID})
 }
 
 func (h *Handler) handleDialItem(w http.ResponseWriter, r *http.Request, callID string) {
@@ -671,7 +724,7 @@ func (h *Handler) handleAMIStatus(w http.ResponseWriter, r *http.Request) {
 	if d := h.getDialer(); d != nil {
 		connected = d.IsDialerConnected()
 	}
-	writeJSON(w, http.StatusOK, map[string]bool{"connected": connected, "enabled": enabled})
+	writeJSON(w, http.StatusOK, frontendv1.AMIStatus{Connected: connected, Enabled: enabled})
 }
 
 func (h *Handler) handleAMISettings(w http.ResponseWriter, r *http.Request) {
@@ -5,8 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -396,6 +400,7 @@ func TestHandlerListEventsAndAlarms(t *testing.T) {
 				ObjectID:   1500000001,
 				ObjectName: "CASL",
 				Time:       time.Now(),
+				Priority:   contracts.FrontendAlarmPriority{Score: 50, Type: 80, Unattended: 30, Dispatched: -60},
 			},
 		},
 	}
@@ -413,6 +418,11 @@ func TestHandlerListEventsAndAlarms(t *testing.T) {
 	if recAlarms.Code != http.StatusOK {
 		t.Fatalf("alarms status = %d, want %d", recAlarms.Code, http.StatusOK)
 	}
+	var alarms frontendv1.AlarmListResponse
+	decodeJSON(t, recAlarms, &alarms)
+	if len(alarms.Items) != 1 || alarms.Items[0].Priority == nil || alarms.Items[0].Priority.Score != 50 || alarms.Items[0].Priority.Dispatched != -60 {
+		t.Fatalf("alarm priority = %+v, want the score breakdown", alarms.Items)
+	}
 }
 
 func TestHandlerListObjectEventsPage(t *testing.T) {
@@ -476,6 +486,353 @@ func TestHandlerAlarmProcessingOptions(t *testing.T) {
 	}
 }
 
+type alarmResponseGroupsBackendStub struct {
+	*frontendBackendStub
+	alarmID int
+	groups  []contracts.FrontendResponseGroup
+}
+
+func (s *alarmResponseGroupsBackendStub) ListResponseGroupsForAlarm(_ context.Context, alarmID int) ([]contracts.FrontendResponseGroup, error) {
+	s.alarmID = alarmID
+	return s.groups, nil
+}
+
+func TestHandlerAlarmResponseGroupSuggestions(t *testing.T) {
+	stub := &alarmResponseGroupsBackendStub{
+		frontendBackendStub: &frontendBackendStub{},
+		groups: []contracts.FrontendResponseGroup{
+			{
+				ID:     "20",
+				Name:   "МГР-2",
+				Status: contracts.ResponseGroupStatusFree,
+				Suggestion: contracts.ResponseGroupSuggestion{
+					Rank:            1,
+					Recommended:     true,
+					InObjectGeoZone: true,
+					DistanceKnown:   true,
+					DistanceKm:      2.5,
+					Note:            "рекомендовано",
+				},
+			},
+			{ID: "30", Name: "МГР-3", Status: contracts.ResponseGroupStatusDispatched},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, APIV1BasePath+"/alarms/91/response-groups", nil)
+	rec := httptest.NewRecorder()
+
+	NewHandler(stub).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if stub.alarmID != 91 {
+		t.Fatalf("alarm id = %d, want 91", stub.alarmID)
+	}
+
+	var payload frontendv1.ResponseGroupListResponse
+	decodeJSON(t, rec, &payload)
+	if len(payload.Items) != 2 {
+		t.Fatalf("payload = %+v", payload)
+	}
+	first := payload.Items[0].Suggestion
+	if first == nil || first.Rank != 1 || !first.Recommended || !first.InObjectGeoZone || first.DistanceKm != 2.5 {
+		t.Fatalf("suggestion = %+v", first)
+	}
+	if payload.Items[1].Suggestion != nil {
+		t.Fatalf("unranked group must not carry a suggestion: %+v", payload.Items[1].Suggestion)
+	}
+}
+
+type eventExportBackendStub struct {
+	*frontendBackendStub
+	request contracts.FrontendEventExportRequest
+	err     error
+	// failMidStream повертає err після запису першого рядка.
+	failMidStream bool
+}
+
+func (s *eventExportBackendStub) ExportEvents(_ context.Context, request contracts.FrontendEventExportRequest, w io.Writer) (int, error) {
+	s.request = request
+	if s.err != nil && !s.failMidStream {
+		return 0, s.err
+	}
+	if _, err := io.WriteString(w, "Дата і час,№\r\n"); err != nil {
+		return 0, err
+	}
+	return 1, s.err
+}
+
+func TestHandlerEventsExport(t *testing.T) {
+	stub := &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{}}
+	req := httptest.NewRequest(http.MethodGet,
+		EventsExportAliasPath+"?format=xlsx&from=2024-03-01&to=2024-03-02&source=bridge,casl&severity=critical&object=7&object=8&type=fire", nil)
+	rec := httptest.NewRecorder()
+
+	NewHandler(stub).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != xlsxContentType {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, `filename="events_2024-03-02.xlsx"`) {
+		t.Fatalf("Content-Disposition = %q", got)
+	}
+	request := stub.request
+	wantTo := time.Date(2024, 3, 2, 23, 59, 59, 0, time.Local)
+	if request.Format != "xlsx" || request.Severity != "critical" || !request.To.Equal(wantTo) ||
+		len(request.Sources) != 2 || request.Sources[1] != contracts.FrontendSourceCASL ||
+		len(request.ObjectIDs) != 2 || request.ObjectIDs[1] != 8 || len(request.Types) != 1 {
+		t.Fatalf("request = %+v", request)
+	}
+}
+
+type queryBackendStub struct {
+	*eventExportBackendStub
+	query      string
+	queryErr   error
+	savedViews []contracts.FrontendSavedView
+}
+
+func (s *queryBackendStub) QueryObjects(_ context.Context, query string) ([]contracts.FrontendObjectSummary, error) {
+	s.query = query
+	return s.objectsResult, s.queryErr
+}
+
+func (s *queryBackendStub) QueryEvents(_ context.Context, query string) ([]contracts.FrontendEventItem, error) {
+	s.query = query
+	return s.eventsResult, s.queryErr
+}
+
+func (s *queryBackendStub) ListSavedViews(context.Context) ([]contracts.FrontendSavedView, error) {
+	return s.savedViews, nil
+}
+
+func (s *queryBackendStub) SaveSavedViews(_ context.Context, views []contracts.FrontendSavedView) error {
+	s.savedViews = views
+	return nil
+}
+
+type objectGroupsBackendStub struct {
+	*queryBackendStub
+	groups   []contracts.FrontendObjectGroup
+	revision int
+}
+
+func (s *objectGroupsBackendStub) ListObjectGroups(context.Context) ([]contracts.FrontendObjectGroup, string, error) {
+	return s.groups, strconv.Itoa(s.revision), nil
+}
+
+func (s *objectGroupsBackendStub) SaveObjectGroups(_ context.Context, groups []contracts.FrontendObjectGroup, revision string) (string, error) {
+	if revision != "" && revision != strconv.Itoa(s.revision) {
+		return "", contracts.ErrFrontendRevisionConflict
+	}
+	s.groups = groups
+	s.revision++
+	return strconv.Itoa(s.revision), nil
+}
+
+func (s *objectGroupsBackendStub) ObjectGroupMembers(_ context.Context, groupID string) (map[int]struct{}, error) {
+	for _, group := range s.groups {
+		if group.ID == groupID {
+			members := make(map[int]struct{}, len(group.ObjectIDs))
+			for _, id := range group.ObjectIDs {
+				members[id] = struct{}{}
+			}
+			return members, nil
+		}
+	}
+	return nil, fmt.Errorf("group %q not found", groupID)
+}
+
+func (s *objectGroupsBackendStub) WatchedObjects(context.Context) (map[int]struct{}, error) {
+	watched := make(map[int]struct{})
+	for _, group := range s.groups {
+		if !group.Watch {
+			continue
+		}
+		for _, id := range group.ObjectIDs {
+			watched[id] = struct{}{}
+		}
+	}
+	return watched, nil
+}
+
+func TestHandlerObjectsAndEventsFilterQuery(t *testing.T) {
+	stub := &queryBackendStub{eventExportBackendStub: &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{
+		objectsResult: []contracts.FrontendObjectSummary{{ID: 7, Name: "Школа"}},
+		eventsResult:  []contracts.FrontendEventItem{{ID: 1, ObjectID: 7}},
+	}}}
+	handler := NewHandler(stub)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects?q=source%3Acasl+status%3Aoffline", nil))
+	if rec.Code != http.StatusOK || stub.query != "source:casl status:offline" {
+		t.Fatalf("objects status = %d, query = %q, body = %s", rec.Code, stub.query, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events?q=severity%3Acritical", nil))
+	if rec.Code != http.StatusOK || stub.query != "severity:critical" {
+		t.Fatalf("events status = %d, query = %q", rec.Code, stub.query)
+	}
+
+	stub.queryErr = errors.New("невідомий стан \"sleeping\"")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects?q=status%3Asleeping", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid query status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = httptest.NewRecorder()
+	NewHandler(&frontendBackendStub{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects?q=status%3Aoffline", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("unsupported backend status = %d, want %d", rec.Code, http.StatusNotImplemented)
+	}
+}
+
+func TestHandlerSavedViews(t *testing.T) {
+	stub := &queryBackendStub{eventExportBackendStub: &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{}}}
+	handler := NewHandler(stub)
+
+	rec := httptest.NewRecorder()
+	body := `{"items":[{"name":"Офлайн CASL","query":"source:casl status:offline"}]}`
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, APIV1BasePath+"/saved-views", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/saved-views", nil))
+	var response frontendv1.SavedViewList
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode saved views: %v", err)
+	}
+	if len(response.Items) != 1 || response.Items[0].Query != "source:casl status:offline" {
+		t.Fatalf("saved views = %+v", response.Items)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, APIV1BasePath+"/saved-views", strings.NewReader(`{"views":[]}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown field status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandlerObjectGroupsFilterAndWatchlist(t *testing.T) {
+	stub := &objectGroupsBackendStub{queryBackendStub: &queryBackendStub{eventExportBackendStub: &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{
+		objectsResult: []contracts.FrontendObjectSummary{{ID: 7}, {ID: 8}},
+		eventsResult:  []contracts.FrontendEventItem{{ID: 1, ObjectID: 7}, {ID: 2, ObjectID: 8}},
+		alarmsResult:  []contracts.FrontendAlarmItem{{ID: 1, ObjectID: 7}, {ID: 2, ObjectID: 8}, {ID: 3, ObjectID: 9}},
+	}}}}
+	handler := NewHandler(stub)
+
+	rec := httptest.NewRecorder()
+	body := `{"items":[{"id":"banks","name":"Банки","objectIds":[8,9],"watch":true}]}`
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, APIV1BasePath+"/object-groups", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects?group=banks", nil))
+	var objects frontendv1.ObjectListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &objects); err != nil {
+		t.Fatalf("decode objects: %v; body = %s", err, rec.Body.String())
+	}
+	if len(objects.Items) != 1 || objects.Items[0].ID != 8 {
+		t.Fatalf("objects = %+v, want only object 8", objects.Items)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/alarms", nil))
+	var response frontendv1.AlarmListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode alarms: %v; body = %s", err, rec.Body.String())
+	}
+	alarms := response.Items
+	if len(alarms) != 3 || alarms[0].ObjectID != 8 || !alarms[0].Watched || alarms[1].ObjectID != 9 || alarms[2].Watched {
+		t.Fatalf("alarms = %+v, want watched objects 8 and 9 first", alarms)
+	}
+	if stub.alarmsResult[0].Watched || stub.alarmsResult[0].ObjectID != 7 {
+		t.Fatal("backend alarms must not be modified")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events?group=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown group status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	NewHandler(&frontendBackendStub{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/objects?group=banks", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("unsupported backend status = %d, want %d", rec.Code, http.StatusNotImplemented)
+	}
+}
+
+func TestHandlerObjectGroupsRejectStaleRevision(t *testing.T) {
+	stub := &objectGroupsBackendStub{queryBackendStub: &queryBackendStub{eventExportBackendStub: &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{}}}}
+	handler := NewHandler(stub)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/object-groups", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag != `"0"` {
+		t.Fatalf("GET status = %d, ETag = %q", rec.Code, etag)
+	}
+
+	put := func(ifMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPut, APIV1BasePath+"/object-groups", strings.NewReader(`{"items":[{"name":"Банки","objectIds":[8]}]}`))
+		request.Header.Set("If-Match", ifMatch)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request)
+		return rec
+	}
+	if rec := put(etag); rec.Code != http.StatusNoContent || rec.Header().Get("ETag") != `"1"` {
+		t.Fatalf("PUT status = %d, ETag = %q, body = %s", rec.Code, rec.Header().Get("ETag"), rec.Body.String())
+	}
+	if rec := put(etag); rec.Code != http.StatusConflict {
+		t.Fatalf("stale PUT status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if rec := put("*"); rec.Code != http.StatusNoContent {
+		t.Fatalf("unconditional PUT status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+func TestHandlerEventsExportErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(&frontendBackendStub{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events/export", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("unsupported backend status = %d, want %d", rec.Code, http.StatusNotImplemented)
+	}
+
+	stub := &eventExportBackendStub{frontendBackendStub: &frontendBackendStub{}}
+	for _, query := range []string{"format=pdf", "from=yesterday", "object=x", "from=2024-03-02&to=2024-03-01"} {
+		rec := httptest.NewRecorder()
+		NewHandler(stub).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events/export?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+
+	stub.err = errors.New("невідомий рівень важливості")
+	rec = httptest.NewRecorder()
+	NewHandler(stub).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events/export", nil))
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("backend error before streaming must be JSON, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	stub.err = errors.New("журнал об'єкта #7: timeout")
+	stub.failMidStream = true
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Fatalf("error after streaming started must abort the response, got %v", recovered)
+		}
+	}()
+	NewHandler(stub).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, APIV1BasePath+"/events/export", nil))
+}
+
 func TestHandlerProcessAlarm(t *testing.T) {
 	stub := &frontendBackendStub{}
 
@@ -0,0 +1,139 @@
+package frontendhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	frontendv1 "obj_catalog_fyne_v3/pkg/frontendapi/v1"
+)
+
+// handleObjectGroups віддає (GET) або повністю замінює (PUT) спільні групи об'єктів.
+func (h *Handler) handleObjectGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		writeMethodNotAllowed(w, http.MethodGet, http.MethodPut)
+		return
+	}
+	backend, ok := h.backend.(contracts.FrontendObjectGroupsBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "object groups are not supported")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		items, revision, err := backend.ListObjectGroups(r.Context())
+		if err != nil {
+			writeBackendError(w, err)
+			return
+		}
+		setRevisionETag(w, revision)
+		writeJSON(w, http.StatusOK, frontendv1.ToObjectGroupList(items))
+	case http.MethodPut:
+		if r.Body == nil {
+			writeError(w, http.StatusBadRequest, "request body is required")
+			return
+		}
+		defer r.Body.Close()
+		var request frontendv1.ObjectGroupList
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json body")
+			return
+		}
+		revision, err := backend.SaveObjectGroups(r.Context(), frontendv1.FromObjectGroupList(request), ifMatchRevision(r))
+		if err != nil {
+			writeBackendError(w, err)
+			return
+		}
+		setRevisionETag(w, revision)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// setRevisionETag віддає ревізію списку як ETag для наступного If-Match.
+func setRevisionETag(w http.ResponseWriter, revision string) {
+	if revision != "" {
+		w.Header().Set("ETag", `"`+revision+`"`)
+	}
+}
+
+// ifMatchRevision повертає ревізію з If-Match; порожній рядок (заголовка
+// немає або він "*") означає заміну без перевірки.
+func ifMatchRevision(r *http.Request) string {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "*" {
+		return ""
+	}
+	return strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+}
+
+// objectGroupMembers повертає склад групи з параметра group; nil — параметр
+// не задано. false означає, що відповідь з помилкою вже записано.
+func (h *Handler) objectGroupMembers(w http.ResponseWriter, r *http.Request) (map[int]struct{}, bool) {
+	groupID := strings.TrimSpace(r.URL.Query().Get("group"))
+	if groupID == "" {
+		return nil, true
+	}
+	backend, ok := h.backend.(contracts.FrontendObjectGroupsBackend)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "object groups are not supported")
+		return nil, false
+	}
+	members, err := backend.ObjectGroupMembers(r.Context(), groupID)
+	if err != nil {
+		writeBackendError(w, err)
+		return nil, false
+	}
+	return members, true
+}
+
+// filterByGroup залишає елементи об'єктів групи; nil-склад пропускає всі.
+// Результат — новий зріз, бо бекенд може віддавати кешовані дані.
+func filterByGroup[T any](items []T, members map[int]struct{}, objectID func(T) int) []T {
+	if members == nil {
+		return items
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if _, ok := members[objectID(item)]; ok {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func objectSummaryID(item contracts.FrontendObjectSummary) int { return item.ID }
+
+func eventObjectID(item contracts.FrontendEventItem) int { return item.ObjectID }
+
+// watchAlarms фільтрує тривоги за групою, позначає тривоги об'єктів зі
+// списку спостереження і ставить їх першими, не змінюючи решти порядку.
+func (h *Handler) watchAlarms(r *http.Request, items []contracts.FrontendAlarmItem, members map[int]struct{}) ([]contracts.FrontendAlarmItem, error) {
+	items = filterByGroup(items, members, func(item contracts.FrontendAlarmItem) int { return item.ObjectID })
+	backend, ok := h.backend.(contracts.FrontendObjectGroupsBackend)
+	if !ok {
+		return items, nil
+	}
+	watched, err := backend.WatchedObjects(r.Context())
+	if err != nil || len(watched) == 0 {
+		return items, err
+	}
+	items = slices.Clone(items)
+	for i := range items {
+		_, items[i].Watched = watched[items[i].ObjectID]
+	}
+	slices.SortStableFunc(items, func(a, b contracts.FrontendAlarmItem) int {
+		switch {
+		case a.Watched && !b.Watched:
+			return -1
+		case b.Watched && !a.Watched:
+			return 1
+		}
+		return 0
+	})
+	return items, nil
+}
@@ -0,0 +1,265 @@
+package frontendhttp
+
+import (
+	"maps"
+	"net/http"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	frontendv1 "obj_catalog_fyne_v3/pkg/frontendapi/v1"
+	"obj_catalog_fyne_v3/pkg/openapi"
+)
+
+const (
+	OpenAPIPath = APIV1BasePath + "/openapi.json"
+	// OpenAPIAliasPath — коротка адреса специфікації для інтеграторів.
+	OpenAPIAliasPath = "/api/v1/openapi.json"
+)
+
+var commonErrorResponses = map[int]string{
+	http.StatusBadRequest:         "Некоректний запит або відмова бекенду",
+	http.StatusNotFound:           "Ресурс не знайдено",
+	http.StatusNotImplemented:     "Операція не підтримується джерелом",
+	http.StatusServiceUnavailable: "Бекенд недоступний",
+}
+
+var (
+	objectIDParam    = openapi.Param{Name: "objectID", Type: "integer", Format: "int32"}
+	alarmIDParam     = openapi.Param{Name: "alarmID", Type: "integer", Format: "int32"}
+	filterQueryParam = openapi.Param{Name: "q", Type: "string", Description: "вираз мови фільтрів, напр. source:casl status:offline lasttest>48h"}
+	objectGroupParam = openapi.Param{Name: "group", Type: "string", Description: "ID групи об'єктів; лише об'єкти групи"}
+)
+
+// apiRoutes — таблиця маршрутів frontendapi/v1 відносно APIV1BasePath.
+// Кожен маршрут ServeHTTP має бути описаний тут: contract-тести звіряють
+// таблицю з реальними відповідями обробника (див. API_VERSIONING.md).
+var apiRoutes = []openapi.Operation{
+	{
+		Method: http.MethodGet, Path: "/capabilities", Tag: "meta",
+		Summary:  "Можливості підключених джерел",
+		Response: frontendv1.Capabilities{},
+	},
+	{
+		Method: http.MethodGet, Path: "/objects", Tag: "objects",
+		Summary:  "Список об'єктів",
+		Query:    []openapi.Param{filterQueryParam, objectGroupParam},
+		Response: frontendv1.ObjectListResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/objects", Tag: "objects",
+		Summary:  "Створити об'єкт",
+		Request:  frontendv1.ObjectUpsertRequest{},
+		Response: frontendv1.ObjectMutationResult{}, Status: http.StatusCreated,
+	},
+	{
+		Method: http.MethodGet, Path: "/objects/{objectID}", Tag: "objects",
+		Summary:    "Картка об'єкта",
+		PathParams: []openapi.Param{objectIDParam},
+		Response:   frontendv1.ObjectDetails{},
+	},
+	{
+		Method: http.MethodPut, Path: "/objects/{objectID}", Tag: "objects",
+		Summary:    "Оновити об'єкт",
+		PathParams: []openapi.Param{objectIDParam},
+		Request:    frontendv1.ObjectUpsertRequest{},
+		Response:   frontendv1.ObjectMutationResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/objects/{objectID}/events", Tag: "events",
+		Summary:    "Сторінка подій об'єкта",
+		PathParams: []openapi.Param{objectIDParam},
+		Query: []openapi.Param{
+			{Name: "offset", Type: "integer", Format: "int32", Description: "за замовчуванням 0"},
+			{Name: "limit", Type: "integer", Format: "int32", Description: "за замовчуванням 100"},
+		},
+		Response: frontendv1.EventPageResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/objects/{objectID}/standby", Tag: "objects",
+		Summary:    "Тимчасово зняти об'єкт зі спостереження",
+		PathParams: []openapi.Param{objectIDParam},
+		Request:    frontendv1.StandbyRequest{},
+		Response:   struct{}{},
+	},
+	{
+		Method: http.MethodGet, Path: "/alarms", Tag: "alarms",
+		Summary:  "Активні тривоги; тривоги списку спостереження йдуть першими (Watched)",
+		Query:    []openapi.Param{objectGroupParam},
+		Response: frontendv1.AlarmListResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/alarm-groups", Tag: "alarms",
+		Summary:  "Тривоги, згруповані за об'єктами",
+		Response: frontendv1.AlarmGroupListResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/alarm-processing-options", Tag: "alarms",
+		Summary:  "Кешований довідник причин відпрацювання",
+		Response: frontendv1.AlarmProcessingOptionsResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/alarms/{alarmID}/processing-options", Tag: "alarms",
+		Summary:    "Причини відпрацювання для тривоги",
+		PathParams: []openapi.Param{alarmIDParam},
+		Response:   frontendv1.AlarmProcessingOptionsResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/pick", Tag: "alarms",
+		Summary:    "Взяти тривогу в роботу або перехопити з причиною",
+		PathParams: []openapi.Param{alarmIDParam},
+		Request:    frontendv1.AlarmPickRequest{}, Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/process", Tag: "alarms",
+		Summary:    "Відпрацювати тривогу",
+		PathParams: []openapi.Param{alarmIDParam},
+		Request:    frontendv1.AlarmProcessRequest{}, Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/group-process", Tag: "alarms",
+		Summary:    "Відпрацювати всі тривоги об'єкта",
+		PathParams: []openapi.Param{alarmIDParam},
+		Request:    frontendv1.AlarmGroupProcessRequest{}, RequestOptional: true,
+		Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/alarms/{alarmID}/response-groups", Tag: "response-groups",
+		Summary:    "Групи реагування з рекомендаціями для тривоги",
+		PathParams: []openapi.Param{alarmIDParam},
+		Response:   frontendv1.ResponseGroupListResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/assign-group", Tag: "response-groups",
+		Summary:    "Направити групу реагування",
+		PathParams: []openapi.Param{alarmIDParam},
+		Request:    frontendv1.AlarmGroupActionRequest{}, Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/group-arrived", Tag: "response-groups",
+		Summary:    "Позначити прибуття групи",
+		PathParams: []openapi.Param{alarmIDParam},
+		Status:     http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/alarms/{alarmID}/cancel-group", Tag: "response-groups",
+		Summary:    "Скасувати виїзд групи",
+		PathParams: []openapi.Param{alarmIDParam},
+		Status:     http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/response-groups", Tag: "response-groups",
+		Summary:  "Групи реагування",
+		Response: frontendv1.ResponseGroupListResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/events", Tag: "events",
+		Summary:  "Стрічка подій",
+		Query:    []openapi.Param{filterQueryParam, objectGroupParam},
+		Response: frontendv1.EventListResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/events/export", Tag: "events",
+		Summary: "Експорт журналу подій у CSV або XLSX (також " + EventsExportAliasPath + "); якщо джерело не віддало журнал об'єкта, з'єднання розривається",
+		Query: []openapi.Param{
+			{Name: "format", Type: "string", Description: "csv (за замовчуванням) або xlsx"},
+			{Name: "from", Type: "string", Description: "RFC 3339 або РРРР-ММ-ДД; за замовчуванням добу до to"},
+			{Name: "to", Type: "string", Description: "RFC 3339 або РРРР-ММ-ДД (весь день); за замовчуванням зараз"},
+			{Name: "source", Type: "string", Repeated: true, Description: "bridge, phoenix, casl"},
+			{Name: "severity", Type: "string", Description: "critical, warning або info"},
+			{Name: "object", Type: "integer", Format: "int32", Repeated: true, Description: "ID об'єктів"},
+			{Name: "type", Type: "string", Repeated: true, Description: "типи подій, напр. fire, arm"},
+		},
+		Produces: []string{"text/csv", xlsxContentType},
+	},
+	{
+		Method: http.MethodGet, Path: "/saved-views", Tag: "filters",
+		Summary:  "Збережені подання фільтрів",
+		Response: frontendv1.SavedViewList{},
+	},
+	{
+		Method: http.MethodPut, Path: "/saved-views", Tag: "filters",
+		Summary: "Замінити список збережених подань",
+		Request: frontendv1.SavedViewList{}, Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/object-groups", Tag: "filters",
+		Summary:  "Спільні групи об'єктів і списки спостереження",
+		Response: frontendv1.ObjectGroupList{},
+	},
+	{
+		Method: http.MethodPut, Path: "/object-groups", Tag: "filters",
+		Summary: "Замінити список груп об'єктів; If-Match з ETag відхиляє застарілий список",
+		Request: frontendv1.ObjectGroupList{}, Status: http.StatusNoContent,
+		Errors: map[int]string{http.StatusConflict: "Список змінено іншим клієнтом після читання"},
+	},
+	{
+		Method: http.MethodPost, Path: "/dial", Tag: "telephony",
+		Summary:  "Набрати номер через AMI",
+		Request:  frontendv1.DialRequest{},
+		Response: frontendv1.DialResponse{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/dial/{callID}", Tag: "telephony",
+		Summary:    "Завершити виклик",
+		PathParams: []openapi.Param{{Name: "callID", Type: "string"}},
+		Status:     http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/ami-settings", Tag: "telephony",
+		Summary:  "Налаштування AMI",
+		Response: contracts.AMISettings{},
+	},
+	{
+		Method: http.MethodPut, Path: "/ami-settings", Tag: "telephony",
+		Summary: "Зберегти налаштування AMI",
+		Request: contracts.AMISettings{}, Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/ami-status", Tag: "telephony",
+		Summary:  "Стан підключення AMI",
+		Response: frontendv1.AMIStatus{},
+	},
+}
+
+// withCommonErrors доповнює помилки маршруту спільними відповідями.
+func withCommonErrors(extra map[int]string) map[int]string {
+	if len(extra) == 0 {
+		return commonErrorResponses
+	}
+	merged := maps.Clone(commonErrorResponses)
+	maps.Copy(merged, extra)
+	return merged
+}
+
+// OpenAPIDocument повертає OpenAPI 3.0 опис frontendapi/v1, згенерований
+// з таблиці маршрутів і DTO пакета frontendapi/v1.
+func OpenAPIDocument() map[string]any {
+	operations := make([]openapi.Operation, 0, len(apiRoutes)+1)
+	for _, item := range apiRoutes {
+		item.Path = APIV1BasePath + item.Path
+		item.Public = true
+		item.Errors = withCommonErrors(item.Errors)
+		operations = append(operations, item)
+	}
+	operations = append(operations, openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        OpenAPIPath,
+		Tag:         "meta",
+		Summary:     "OpenAPI-опис frontend API",
+		OperationID: "getOpenAPI",
+		Public:      true,
+	})
+
+	return openapi.Document(openapi.Info{
+		Title:       "obj_catalog frontend API",
+		Version:     "v1",
+		Description: "Об'єкти, тривоги, події та групи реагування для UI і інтеграторів. Правила сумісності — API_VERSIONING.md.",
+	}, operations)
+}
+
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, http.MethodGet)
+		return
+	}
+	writeJSON(w, http.StatusOK, OpenAPIDocument())
+}
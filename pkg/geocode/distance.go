@@ -0,0 +1,118 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultRoutingURL is the public OSRM endpoint used for road distances.
+const DefaultRoutingURL = "https://router.project-osrm.org"
+
+const earthRadiusKm = 6371.0088
+
+// ParsePoint parses textual coordinates stored by data sources.
+// Decimal comma is accepted; zero or out-of-range coordinates are rejected.
+func ParsePoint(latitude, longitude string) (MapPoint, bool) {
+	lat, latErr := parseCoordinate(latitude)
+	lon, lonErr := parseCoordinate(longitude)
+	if latErr != nil || lonErr != nil {
+		return MapPoint{}, false
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 || (lat == 0 && lon == 0) {
+		return MapPoint{}, false
+	}
+	return MapPoint{Latitude: lat, Longitude: lon}, true
+}
+
+func parseCoordinate(raw string) (float64, error) {
+	clean := strings.TrimSpace(strings.ReplaceAll(raw, ",", "."))
+	if clean == "" {
+		return 0, fmt.Errorf("empty coordinate")
+	}
+	value, err := strconv.ParseFloat(clean, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid coordinate %q", raw)
+	}
+	return value, nil
+}
+
+// DistanceKm returns the great-circle (straight-line) distance between two points.
+func DistanceKm(from, to MapPoint) float64 {
+	lat1 := from.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// PointInPolygon reports whether point lies inside polygon (ray casting on
+// plain coordinates, good enough for city-sized zones). Polygons with fewer
+// than three vertices contain nothing.
+func PointInPolygon(point MapPoint, polygon []MapPoint) bool {
+	if len(polygon) < 3 {
+		return false
+	}
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > point.Latitude) == (b.Latitude > point.Latitude) {
+			continue
+		}
+		crossLon := a.Longitude + (point.Latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if point.Longitude < crossLon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// RoadDistanceKm asks an OSRM-compatible router for the driving distance.
+// Empty baseURL uses DefaultRoutingURL.
+func RoadDistanceKm(ctx context.Context, baseURL string, from, to MapPoint) (float64, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultRoutingURL
+	}
+	endpoint := fmt.Sprintf(
+		"%s/route/v1/driving/%s,%s;%s,%s?overview=false",
+		baseURL,
+		strconv.FormatFloat(from.Longitude, 'f', 6, 64),
+		strconv.FormatFloat(from.Latitude, 'f', 6, 64),
+		strconv.FormatFloat(to.Longitude, 'f', 6, 64),
+		strconv.FormatFloat(to.Latitude, 'f', 6, 64),
+	)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("User-Agent", "obj_catalog_fyne_v3/1.0")
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("маршрутизація: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return 0, fmt.Errorf("сервіс маршрутів повернув %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var payload struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Distance float64 `json:"distance"`
+		} `json:"routes"`
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(&payload); err != nil {
+		return 0, fmt.Errorf("відповідь сервісу маршрутів: %w", err)
+	}
+	if !strings.EqualFold(payload.Code, "Ok") || len(payload.Routes) == 0 {
+		return 0, fmt.Errorf("маршрут не знайдено")
+	}
+	return payload.Routes[0].Distance / 1000, nil
+}
@@ -0,0 +1,107 @@
+package geocode
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParsePoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lat, lon string
+		ok       bool
+	}{
+		{name: "dot", lat: "50.4501", lon: "30.5234", ok: true},
+		{name: "comma", lat: "50,4501", lon: " 30,5234 ", ok: true},
+		{name: "empty", lat: "", lon: "30.5", ok: false},
+		{name: "zero", lat: "0", lon: "0", ok: false},
+		{name: "out of range", lat: "91", lon: "30", ok: false},
+		{name: "garbage", lat: "abc", lon: "30", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, ok := ParsePoint(tt.lat, tt.lon)
+			if ok != tt.ok {
+				t.Fatalf("ParsePoint(%q, %q) ok = %v, want %v", tt.lat, tt.lon, ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	t.Parallel()
+
+	kyiv := MapPoint{Latitude: 50.4501, Longitude: 30.5234}
+	lviv := MapPoint{Latitude: 49.8397, Longitude: 24.0297}
+	got := DistanceKm(kyiv, lviv)
+	if math.Abs(got-469) > 5 {
+		t.Fatalf("DistanceKm(Kyiv, Lviv) = %.1f, want ~469", got)
+	}
+	if DistanceKm(kyiv, kyiv) != 0 {
+		t.Fatalf("distance to itself must be zero")
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	t.Parallel()
+
+	// Увігнутий контур: «виріз» з північного боку лишає Поділ поза зоною.
+	zone := []MapPoint{
+		{Latitude: 50.40, Longitude: 30.40},
+		{Latitude: 50.40, Longitude: 30.65},
+		{Latitude: 50.50, Longitude: 30.65},
+		{Latitude: 50.50, Longitude: 30.55},
+		{Latitude: 50.45, Longitude: 30.55},
+		{Latitude: 50.45, Longitude: 30.50},
+		{Latitude: 50.50, Longitude: 30.50},
+		{Latitude: 50.50, Longitude: 30.40},
+	}
+	tests := []struct {
+		name  string
+		point MapPoint
+		want  bool
+	}{
+		{name: "centre", point: MapPoint{Latitude: 50.42, Longitude: 30.52}, want: true},
+		{name: "west lobe", point: MapPoint{Latitude: 50.48, Longitude: 30.45}, want: true},
+		{name: "notch", point: MapPoint{Latitude: 50.48, Longitude: 30.52}, want: false},
+		{name: "outside", point: MapPoint{Latitude: 49.84, Longitude: 24.03}, want: false},
+	}
+	for _, tt := range tests {
+		if got := PointInPolygon(tt.point, zone); got != tt.want {
+			t.Errorf("%s: PointInPolygon() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if PointInPolygon(MapPoint{Latitude: 50.42, Longitude: 30.52}, zone[:2]) {
+		t.Fatal("a degenerate polygon must not contain points")
+	}
+}
+
+func TestRoadDistanceKm(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/route/v1/driving/30.523400,50.450100;24.029700,49.839700") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"code":"Ok","routes":[{"distance":540500}]}`))
+	}))
+	defer server.Close()
+
+	got, err := RoadDistanceKm(context.Background(), server.URL+"/",
+		MapPoint{Latitude: 50.4501, Longitude: 30.5234},
+		MapPoint{Latitude: 49.8397, Longitude: 24.0297},
+	)
+	if err != nil {
+		t.Fatalf("RoadDistanceKm() error = %v", err)
+	}
+	if math.Abs(got-540.5) > 0.001 {
+		t.Fatalf("RoadDistanceKm() = %v, want 540.5", got)
+	}
+}
@@ -48,6 +48,13 @@ type MapMarker struct {
 	Color color.RGBA
 }
 
+// MapLine describes a straight colored segment rendered under markers.
+type MapLine struct {
+	From  MapPoint
+	To    MapPoint
+	Color color.RGBA
+}
+
 // LoadMapSnapshotForPoints fits all valid points into one OpenStreetMap view.
 func LoadMapSnapshotForPoints(ctx context.Context, points []MapPoint, width, height int) (*MapSnapshot, error) {
 	if len(points) == 0 {
@@ -235,8 +242,18 @@ func (snapshot *MapSnapshot) PNGWithMarker(x, y int) []byte {
 
 // PNGWithMarkers returns the snapshot with multiple colored markers.
 func (snapshot *MapSnapshot) PNGWithMarkers(markers []MapMarker) []byte {
+	return snapshot.PNGWithOverlay(markers, nil)
+}
+
+// PNGWithOverlay returns the snapshot with lines drawn first and markers on top.
+func (snapshot *MapSnapshot) PNGWithOverlay(markers []MapMarker, lines []MapLine) []byte {
 	canvas := image.NewRGBA(image.Rect(0, 0, snapshot.width, snapshot.height))
 	draw.Draw(canvas, canvas.Bounds(), snapshot.base, image.Point{}, draw.Src)
+	for _, line := range lines {
+		fromX, fromY := snapshot.PixelAt(line.From.Latitude, line.From.Longitude)
+		toX, toY := snapshot.PixelAt(line.To.Latitude, line.To.Longitude)
+		drawLine(canvas, fromX, fromY, toX, toY, line.Color)
+	}
 	for _, marker := range markers {
 		x, y := snapshot.PixelAt(marker.Latitude, marker.Longitude)
 		drawColoredMarker(canvas, x, y, marker.Color)
@@ -282,6 +299,51 @@ func drawColoredMarker(target *image.RGBA, centerX, centerY int, markerColor col
 	}
 }
 
+// drawLine draws a 3px wide segment using Bresenham's algorithm.
+func drawLine(target *image.RGBA, x0, y0, x1, y1 int, lineColor color.RGBA) {
+	if lineColor.A == 0 {
+		lineColor.A = 255
+	}
+	dx := absInt(x1 - x0)
+	dy := -absInt(y1 - y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+	errorTerm := dx + dy
+	for {
+		for offsetY := -1; offsetY <= 1; offsetY++ {
+			for offsetX := -1; offsetX <= 1; offsetX++ {
+				if image.Pt(x0+offsetX, y0+offsetY).In(target.Bounds()) {
+					target.SetRGBA(x0+offsetX, y0+offsetY, lineColor)
+				}
+			}
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		doubled := 2 * errorTerm
+		if doubled >= dy {
+			errorTerm += dy
+			x0 += stepX
+		}
+		if doubled <= dx {
+			errorTerm += dx
+			y0 += stepY
+		}
+	}
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
 func mapViewport(points []MapPoint, width, height int) (float64, float64, int) {
 	minX, maxX := math.MaxFloat64, -math.MaxFloat64
 	minY, maxY := math.MaxFloat64, -math.MaxFloat64
@@ -136,3 +136,30 @@ func TestLoadMapSnapshotDownloadsTilesWithBoundedConcurrency(t *testing.T) {
 		t.Fatalf("maximum concurrent downloads = %d, want 2..%d", got, mapTileDownloadLimit)
 	}
 }
+
+func TestMapSnapshotPNGWithOverlayDrawsLine(t *testing.T) {
+	snapshot := &MapSnapshot{
+		base:  image.NewRGBA(image.Rect(0, 0, 200, 200)),
+		width: 200, height: 200, zoom: 12,
+	}
+	centerX, centerY := mapWorldPoint(49.8397, 24.0297, snapshot.zoom)
+	snapshot.leftWorldX = centerX - 100
+	snapshot.topWorldY = centerY - 100
+	fromLat, fromLon := snapshot.CoordinateAt(20, 100)
+	toLat, toLon := snapshot.CoordinateAt(180, 100)
+	lineColor := color.RGBA{R: 230, G: 140, A: 255}
+
+	body := snapshot.PNGWithOverlay(nil, []MapLine{{
+		From:  MapPoint{Latitude: fromLat, Longitude: fromLon},
+		To:    MapPoint{Latitude: toLat, Longitude: toLon},
+		Color: lineColor,
+	}})
+	decoded, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("PNGWithOverlay() invalid PNG: %v", err)
+	}
+	r, g, b, _ := decoded.At(100, 100).RGBA()
+	if uint8(r>>8) != lineColor.R || uint8(g>>8) != lineColor.G || uint8(b>>8) != lineColor.B {
+		t.Fatalf("pixel on the line = %d,%d,%d, want line color", r>>8, g>>8, b>>8)
+	}
+}
@@ -0,0 +1,42 @@
+package ids
+
+// Коли робоче місце обслуговує кілька пультів CASL, простір ID CASL ділиться
+// на рівні слоти — по одному на пульт. За ID об'єкта, події чи тривоги
+// агрегатор знаходить пульт-власника і маршрутизує до нього команди.
+// З одним пультом слоти не використовуються і ID лишаються як були.
+const (
+	CASLPultSlotCount = 10
+	CASLPultSlotSize  = CASLObjectIDNamespaceSize / CASLPultSlotCount
+)
+
+// CASLPultObjectID переносить ID з простору CASL у слот пульта.
+// Номер obj_id зберігається, якщо він менший за розмір слота.
+func CASLPultObjectID(slot int, id int) int {
+	if slot < 0 || slot >= CASLPultSlotCount {
+		return id
+	}
+	local := id
+	if IsCASLObjectID(id) {
+		local = id - CASLObjectIDNamespaceStart
+	}
+	if local < 0 {
+		local = -local
+	}
+	return CASLObjectIDNamespaceStart + slot*CASLPultSlotSize + local%CASLPultSlotSize
+}
+
+// CASLPultSlot повертає слот пульта, якому належить ID.
+func CASLPultSlot(id int) (int, bool) {
+	if !IsCASLObjectID(id) {
+		return 0, false
+	}
+	return (id - CASLObjectIDNamespaceStart) / CASLPultSlotSize, true
+}
+
+// OwnsCASLPultSlot повертає matcher ID для слота пульта.
+func OwnsCASLPultSlot(slot int) func(id int) bool {
+	return func(id int) bool {
+		owner, ok := CASLPultSlot(id)
+		return ok && owner == slot
+	}
+}
@@ -0,0 +1,41 @@
+package ids
+
+import (
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/utils"
+)
+
+// ObjectDisplayNumber returns the pult number shown to operators.
+// МІСТ objects use their own ID; Phoenix and CASL objects derive it from the display number, panel mark or name.
+func ObjectDisplayNumber(object models.Object) string {
+	if strings.TrimSpace(object.DisplayNumber) != "" {
+		return object.DisplayNumber
+	}
+	if !IsCASLObjectID(object.ID) && !IsPhoenixObjectID(object.ID) {
+		return strconv.Itoa(object.ID)
+	}
+	if number := numberFromPanelMark(object.PanelMark); number != "" {
+		return number
+	}
+	if number := utils.LeadingDigits(strings.TrimSpace(object.Name)); number != "" {
+		return number
+	}
+	return strconv.Itoa(object.ID)
+}
+
+func numberFromPanelMark(value string) string {
+	text := strings.TrimSpace(value)
+	if text == "" {
+		return ""
+	}
+
+	if idx := strings.LastIndex(text, "#"); idx >= 0 && idx < len(text)-1 {
+		if number := utils.LeadingDigits(strings.TrimSpace(text[idx+1:])); number != "" {
+			return number
+		}
+	}
+	return utils.LeadingDigits(text)
+}
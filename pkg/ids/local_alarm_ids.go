@@ -0,0 +1,58 @@
+package ids
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// Локальні (синтетичні) тривоги, які формує сам клієнт, мають від'ємні ID,
+// щоб не перетинатися з тривогами джерел. Кожен генератор має свій діапазон.
+const (
+	MaintenanceAlarmIDNamespaceStart = -999_999_999
+	MaintenanceAlarmIDNamespaceEnd   = -1
+
+	MissedTestAlarmIDNamespaceStart = -1_999_999_999
+	MissedTestAlarmIDNamespaceEnd   = -1_000_000_000
+
+	// Діапазон розкладів менший: ID мають вміщатися в int32 для збірки windows/386.
+	ScheduleAlarmIDNamespaceStart = -2_147_483_647
+	ScheduleAlarmIDNamespaceEnd   = -2_000_000_000
+
+	localAlarmIDNamespaceSize    = 999_999_999
+	scheduleAlarmIDNamespaceSize = ScheduleAlarmIDNamespaceEnd - ScheduleAlarmIDNamespaceStart + 1
+)
+
+func IsMaintenanceAlarmID(id int) bool {
+	return id >= MaintenanceAlarmIDNamespaceStart && id <= MaintenanceAlarmIDNamespaceEnd
+}
+
+func IsMissedTestAlarmID(id int) bool {
+	return id >= MissedTestAlarmIDNamespaceStart && id <= MissedTestAlarmIDNamespaceEnd
+}
+
+func IsScheduleAlarmID(id int) bool {
+	return id >= ScheduleAlarmIDNamespaceStart && id <= ScheduleAlarmIDNamespaceEnd
+}
+
+// IsLocalAlarmID повідомляє, чи належить ID синтетичній тривозі.
+func IsLocalAlarmID(id int) bool {
+	return IsMaintenanceAlarmID(id) || IsMissedTestAlarmID(id) || IsScheduleAlarmID(id)
+}
+
+func StableMaintenanceAlarmID(key string) int {
+	return MaintenanceAlarmIDNamespaceEnd - stableLocalAlarmOffset(key)
+}
+
+func StableMissedTestAlarmID(key string) int {
+	return MissedTestAlarmIDNamespaceEnd - stableLocalAlarmOffset(key)
+}
+
+func StableScheduleAlarmID(key string) int {
+	return ScheduleAlarmIDNamespaceEnd - stableLocalAlarmOffset(key)%scheduleAlarmIDNamespaceSize
+}
+
+func stableLocalAlarmOffset(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.TrimSpace(key)))
+	return int(h.Sum32()&0x7fffffff) % localAlarmIDNamespaceSize
+}
@@ -15,3 +15,80 @@ func TestStablePhoenixIDIsDeterministicAndNamespaced(t *testing.T) {
 		t.Fatalf("phoenix id must not overlap with CASL namespace: %d", first)
 	}
 }
+
+func TestStableLocalAlarmIDsStayInOwnNamespace(t *testing.T) {
+	for _, key := range []string{"", "mw-1@2026-03-02T10:00:00Z", "1500000001@2026-03-02T10:00:00Z"} {
+		maintenanceID := StableMaintenanceAlarmID(key)
+		if !IsMaintenanceAlarmID(maintenanceID) || IsMissedTestAlarmID(maintenanceID) {
+			t.Fatalf("maintenance id %d for %q is out of namespace", maintenanceID, key)
+		}
+		missedTestID := StableMissedTestAlarmID(key)
+		if !IsMissedTestAlarmID(missedTestID) || IsMaintenanceAlarmID(missedTestID) {
+			t.Fatalf("missed test id %d for %q is out of namespace", missedTestID, key)
+		}
+		scheduleID := StableScheduleAlarmID(key)
+		if !IsScheduleAlarmID(scheduleID) || IsMissedTestAlarmID(scheduleID) {
+			t.Fatalf("schedule id %d for %q is out of namespace", scheduleID, key)
+		}
+		if !IsLocalAlarmID(maintenanceID) || !IsLocalAlarmID(missedTestID) || !IsLocalAlarmID(scheduleID) {
+			t.Fatalf("local ids must be recognised: %d, %d, %d", maintenanceID, missedTestID, scheduleID)
+		}
+	}
+	if IsLocalAlarmID(0) || IsLocalAlarmID(CASLObjectIDNamespaceStart) {
+		t.Fatal("source ids must not be local")
+	}
+}
+
+func TestCASLPultObjectIDKeepsObjIDInsideSlot(t *testing.T) {
+	base := CASLObjectIDNamespaceStart + 1234
+	for slot := range CASLPultSlotCount {
+		id := CASLPultObjectID(slot, base)
+		if !IsCASLObjectID(id) {
+			t.Fatalf("slot %d: id %d left CASL namespace", slot, id)
+		}
+		if got, ok := CASLPultSlot(id); !ok || got != slot {
+			t.Fatalf("slot %d: CASLPultSlot(%d) = %d, %v", slot, id, got, ok)
+		}
+		if local := (id - CASLObjectIDNamespaceStart) % CASLPultSlotSize; local != 1234 {
+			t.Fatalf("slot %d: obj_id %d was not preserved", slot, local)
+		}
+		if !OwnsCASLPultSlot(slot)(id) || OwnsCASLPultSlot((slot+1)%CASLPultSlotCount)(id) {
+			t.Fatalf("slot %d: matcher ownership is wrong for %d", slot, id)
+		}
+	}
+	if got := CASLPultObjectID(-1, base); got != base {
+		t.Fatalf("unslotted id changed: %d", got)
+	}
+	if _, ok := CASLPultSlot(PhoenixObjectIDNamespaceStart); ok {
+		t.Fatal("phoenix id must not belong to a CASL pult slot")
+	}
+	if last := CASLPultObjectID(CASLPultSlotCount-1, CASLObjectIDNamespaceEnd); !IsCASLObjectID(last) {
+		t.Fatalf("last slot id %d left CASL namespace", last)
+	}
+}
+
+func TestSourceInstanceIDsStayInsideTypeNamespace(t *testing.T) {
+	if got := BridgeInstanceObjectID(0, 1042); got != 1042 {
+		t.Fatalf("primary bridge id changed: %d", got)
+	}
+	for slot := range SourceInstanceSlotCount {
+		bridgeID := BridgeInstanceObjectID(slot, 1042)
+		if bridgeID < 0 || IsPhoenixObjectID(bridgeID) || BridgeInstanceLocalID(bridgeID) != 1042 {
+			t.Fatalf("slot %d: bridge id %d is out of its slot", slot, bridgeID)
+		}
+		if !OwnsBridgeInstance(slot)(bridgeID) || OwnsBridgeInstance((slot+1)%SourceInstanceSlotCount)(bridgeID) {
+			t.Fatalf("slot %d: bridge matcher ownership is wrong for %d", slot, bridgeID)
+		}
+
+		phoenixID := PhoenixInstanceObjectID(slot, StablePhoenixID("panel", "L00001"))
+		if !IsPhoenixObjectID(phoenixID) {
+			t.Fatalf("slot %d: phoenix id %d left namespace", slot, phoenixID)
+		}
+		if !OwnsPhoenixInstance(slot)(phoenixID) || OwnsPhoenixInstance((slot+1)%SourceInstanceSlotCount)(phoenixID) {
+			t.Fatalf("slot %d: phoenix matcher ownership is wrong for %d", slot, phoenixID)
+		}
+	}
+	if OwnsBridgeInstance(0)(PhoenixObjectIDNamespaceStart) || OwnsBridgeInstance(0)(-5) {
+		t.Fatal("bridge slot must not own phoenix or local alarm ids")
+	}
+}
@@ -0,0 +1,60 @@
+package ids
+
+// Кілька екземплярів джерела одного типу (наприклад, дві БД МІСТ філій)
+// ділять простір ID свого типу на слоти. Основний екземпляр займає слот 0,
+// тож доки додаткових немає, ID лишаються як були. Екземпляри CASL
+// займають слоти пультів (див. CASLPultObjectID).
+const (
+	SourceInstanceSlotCount = 10
+
+	// BridgeObjectIDNamespaceEnd — межа ID МІСТ: усе нижче простору Phoenix.
+	BridgeObjectIDNamespaceEnd = PhoenixObjectIDNamespaceStart - 1
+	BridgeInstanceSlotSize     = PhoenixObjectIDNamespaceStart / SourceInstanceSlotCount
+	PhoenixInstanceSlotSize    = PhoenixObjectIDNamespaceSize / SourceInstanceSlotCount
+)
+
+// BridgeInstanceObjectID переносить номер об'єкта МІСТ у слот екземпляра.
+func BridgeInstanceObjectID(slot int, id int) int {
+	return namespaceSlotID(0, BridgeInstanceSlotSize, slot, id)
+}
+
+// BridgeInstanceLocalID повертає номер об'єкта МІСТ у БД екземпляра.
+func BridgeInstanceLocalID(id int) int {
+	if id < 0 || id > BridgeObjectIDNamespaceEnd {
+		return id
+	}
+	return id % BridgeInstanceSlotSize
+}
+
+// OwnsBridgeInstance повертає matcher ID для слота екземпляра МІСТ.
+func OwnsBridgeInstance(slot int) func(id int) bool {
+	return func(id int) bool {
+		return id >= 0 && id <= BridgeObjectIDNamespaceEnd && id/BridgeInstanceSlotSize == slot
+	}
+}
+
+// PhoenixInstanceObjectID переносить ID з простору Phoenix у слот екземпляра.
+func PhoenixInstanceObjectID(slot int, id int) int {
+	local := id
+	if IsPhoenixObjectID(id) {
+		local = id - PhoenixObjectIDNamespaceStart
+	}
+	return namespaceSlotID(PhoenixObjectIDNamespaceStart, PhoenixInstanceSlotSize, slot, local)
+}
+
+// OwnsPhoenixInstance повертає matcher ID для слота екземпляра Phoenix.
+func OwnsPhoenixInstance(slot int) func(id int) bool {
+	return func(id int) bool {
+		return IsPhoenixObjectID(id) && (id-PhoenixObjectIDNamespaceStart)/PhoenixInstanceSlotSize == slot
+	}
+}
+
+func namespaceSlotID(start int, size int, slot int, local int) int {
+	if slot < 0 || slot >= SourceInstanceSlotCount {
+		return start + local
+	}
+	if local < 0 {
+		local = -local
+	}
+	return start + slot*size + local%size
+}
@@ -0,0 +1,68 @@
+package maintenance
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Формати дат у формах вікон обслуговування.
+const (
+	FormTimeLayout = "02.01.2006 15:04"
+	FormDateLayout = "02.01.2006"
+)
+
+// Form — сирі значення форми створення вікна з UI.
+type Form struct {
+	ObjectID     int
+	ObjectNumber string
+	ObjectName   string
+	Zones        string
+	Start        string
+	End          string
+	Until        string
+	Recurrence   Recurrence
+	Mode         Mode
+	Reason       string
+	CreatedBy    string
+}
+
+// Window розбирає форму у вікно; час трактується як локальний.
+// Until задається датою і діє до кінця цього дня.
+func (f Form) Window() (Window, error) {
+	start, err := time.ParseInLocation(FormTimeLayout, strings.TrimSpace(f.Start), time.Local)
+	if err != nil {
+		return Window{}, errors.New("некоректний початок, формат: ДД.ММ.РРРР ГГ:ХХ")
+	}
+	end, err := time.ParseInLocation(FormTimeLayout, strings.TrimSpace(f.End), time.Local)
+	if err != nil {
+		return Window{}, errors.New("некоректний кінець, формат: ДД.ММ.РРРР ГГ:ХХ")
+	}
+	zones, err := ParseZones(f.Zones)
+	if err != nil {
+		return Window{}, err
+	}
+	window := Window{
+		ObjectID:     f.ObjectID,
+		ObjectNumber: strings.TrimSpace(f.ObjectNumber),
+		ObjectName:   strings.TrimSpace(f.ObjectName),
+		Zones:        zones,
+		Start:        start,
+		End:          end,
+		Recurrence:   f.Recurrence,
+		Mode:         f.Mode,
+		Reason:       strings.TrimSpace(f.Reason),
+		CreatedBy:    strings.TrimSpace(f.CreatedBy),
+	}
+	if text := strings.TrimSpace(f.Until); text != "" {
+		until, err := time.ParseInLocation(FormDateLayout, text, time.Local)
+		if err != nil {
+			return Window{}, errors.New("некоректна дата «повторювати до», формат: ДД.ММ.РРРР")
+		}
+		window.Until = until.AddDate(0, 0, 1).Add(-time.Minute)
+	}
+	if err := window.Validate(); err != nil {
+		return Window{}, err
+	}
+	return window, nil
+}
@@ -0,0 +1,75 @@
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrences і Modes перелічують значення в порядку показу у формах.
+var (
+	Recurrences = []Recurrence{RecurrenceNone, RecurrenceDaily, RecurrenceWeekly}
+	Modes       = []Mode{ModeSuppress, ModeTag}
+)
+
+// Label повертає підпис повторення для UI.
+func (r Recurrence) Label() string {
+	switch r {
+	case RecurrenceDaily:
+		return "Щодня"
+	case RecurrenceWeekly:
+		return "Щотижня"
+	default:
+		return "Одноразово"
+	}
+}
+
+// Label повертає підпис режиму для UI.
+func (m Mode) Label() string {
+	if m == ModeTag {
+		return "Лише позначати тривоги"
+	}
+	return "Приглушувати тривоги"
+}
+
+// ZonesLabel повертає перелік зон вікна для UI.
+func (w Window) ZonesLabel() string {
+	if len(w.Zones) == 0 {
+		return "увесь об'єкт"
+	}
+	parts := make([]string, 0, len(w.Zones))
+	for _, zone := range w.Zones {
+		parts = append(parts, strconv.Itoa(zone))
+	}
+	return "зони " + strings.Join(parts, ", ")
+}
+
+// StateLabel повертає стан вікна на момент now.
+func (w Window) StateLabel(now time.Time) string {
+	if _, active := w.ActiveAt(now); active {
+		return "ДІЄ"
+	}
+	if w.Recurrence == RecurrenceNone && !w.End.After(now) {
+		return "завершено"
+	}
+	if w.Recurrence != RecurrenceNone && !w.Until.IsZero() && w.Until.Before(now) {
+		if _, ok := w.LastEndedBy(now); ok {
+			return "завершено"
+		}
+	}
+	return "заплановано"
+}
+
+// ParseZones розбирає перелік зон «1, 3 5»; порожній рядок — увесь об'єкт.
+func ParseZones(text string) ([]int, error) {
+	var zones []int
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == ';' }) {
+		zone, err := strconv.Atoi(part)
+		if err != nil || zone <= 0 {
+			return nil, fmt.Errorf("некоректний номер зони %q", part)
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
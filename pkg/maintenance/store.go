@@ -0,0 +1,331 @@
+package maintenance
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSuppressedEntries обмежує журнал приглушених тривог у файлі.
+const maxSuppressedEntries = 5000
+
+// SuppressedAlarm — запис журналу приглушених тривог для звіту.
+type SuppressedAlarm struct {
+	WindowID     string    `json:"window_id"`
+	Reason       string    `json:"reason"`
+	AlarmID      int       `json:"alarm_id"`
+	ObjectID     int       `json:"object_id"`
+	ObjectNumber string    `json:"object_number,omitempty"`
+	ObjectName   string    `json:"object_name,omitempty"`
+	ZoneNumber   int       `json:"zone_number,omitempty"`
+	ZoneName     string    `json:"zone_name,omitempty"`
+	Type         string    `json:"type"`
+	Details      string    `json:"details,omitempty"`
+	Time         time.Time `json:"time"`
+	SuppressedAt time.Time `json:"suppressed_at"`
+}
+
+func (e SuppressedAlarm) key() string {
+	return fmt.Sprintf("%s|%d|%s", e.WindowID, e.AlarmID, e.Time.UTC().Format(time.RFC3339Nano))
+}
+
+// ReminderAck фіксує, хто підтвердив нагадування про завершене вікно.
+type ReminderAck struct {
+	User string    `json:"user,omitempty"`
+	Note string    `json:"note,omitempty"`
+	At   time.Time `json:"at"`
+}
+
+type storeFile struct {
+	Windows    []Window               `json:"windows"`
+	Suppressed []SuppressedAlarm      `json:"suppressed,omitempty"`
+	Reminders  map[string]ReminderAck `json:"acknowledged_reminders,omitempty"`
+}
+
+// FileStore зберігає вікна, журнал приглушених тривог і підтвердження
+// нагадувань в одному JSON-файлі. Файл перечитується, коли його змінив
+// інший процес, тож кілька робочих місць можуть ділити його через мережеву
+// теку. Порожній шлях — сховище лише в пам'яті.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	state   storeFile
+	modTime time.Time
+	size    int64
+	seen    map[string]struct{}
+}
+
+// OpenFileStore відкриває (або створює при першому записі) файл вікон.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: strings.TrimSpace(path)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path повертає шлях до файлу сховища.
+func (s *FileStore) Path() string {
+	if s == nil {
+		return ""
+	}
+	return s.path
+}
+
+// Windows повертає всі вікна, відсортовані за початком.
+func (s *FileStore) Windows() ([]Window, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	windows := slices.Clone(s.state.Windows)
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows, nil
+}
+
+// SaveWindow додає нове вікно (порожній ID) або замінює існуюче.
+func (s *FileStore) SaveWindow(window Window) (Window, error) {
+	if s == nil {
+		return Window{}, errors.New("maintenance: store is nil")
+	}
+	window.Reason = strings.TrimSpace(window.Reason)
+	if err := window.Validate(); err != nil {
+		return Window{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return Window{}, err
+	}
+	if window.ID == "" {
+		window.ID = newWindowID()
+		if window.CreatedAt.IsZero() {
+			window.CreatedAt = time.Now()
+		}
+		s.state.Windows = append(s.state.Windows, window)
+	} else {
+		index := slices.IndexFunc(s.state.Windows, func(w Window) bool { return w.ID == window.ID })
+		if index < 0 {
+			return Window{}, fmt.Errorf("вікно %s не знайдено", window.ID)
+		}
+		s.state.Windows[index] = window
+	}
+	return window, s.saveLocked()
+}
+
+// DeleteWindow видаляє вікно; журнал приглушених за ним тривог зберігається.
+func (s *FileStore) DeleteWindow(id string) error {
+	if s == nil {
+		return errors.New("maintenance: store is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	before := len(s.state.Windows)
+	s.state.Windows = slices.DeleteFunc(s.state.Windows, func(w Window) bool { return w.ID == id })
+	if len(s.state.Windows) == before {
+		return fmt.Errorf("вікно %s не знайдено", id)
+	}
+	return s.saveLocked()
+}
+
+// RecordSuppressed дописує нові записи в журнал; повтори тієї самої тривоги
+// в тому самому вікні ігноруються.
+func (s *FileStore) RecordSuppressed(entries []SuppressedAlarm) error {
+	if s == nil || len(entries) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	added := false
+	for _, entry := range entries {
+		key := entry.key()
+		if _, ok := s.seen[key]; ok {
+			continue
+		}
+		s.seen[key] = struct{}{}
+		s.state.Suppressed = append(s.state.Suppressed, entry)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	if overflow := len(s.state.Suppressed) - maxSuppressedEntries; overflow > 0 {
+		for _, entry := range s.state.Suppressed[:overflow] {
+			delete(s.seen, entry.key())
+		}
+		s.state.Suppressed = slices.Clone(s.state.Suppressed[overflow:])
+	}
+	return s.saveLocked()
+}
+
+// Suppressed повертає записи журналу з часом тривоги в [from, to), новіші першими.
+// Нульова межа не обмежує діапазон.
+func (s *FileStore) Suppressed(from, to time.Time) ([]SuppressedAlarm, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	result := make([]SuppressedAlarm, 0, len(s.state.Suppressed))
+	for _, entry := range s.state.Suppressed {
+		if !from.IsZero() && entry.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !entry.Time.Before(to) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.After(result[j].Time)
+	})
+	return result, nil
+}
+
+// ReminderAcknowledged повідомляє, чи підтверджено нагадування з ключем key.
+func (s *FileStore) ReminderAcknowledged(key string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.state.Reminders[key]
+	return ok
+}
+
+// AcknowledgeReminder зберігає підтвердження нагадування.
+func (s *FileStore) AcknowledgeReminder(key string, ack ReminderAck) error {
+	if s == nil {
+		return errors.New("maintenance: store is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	if s.state.Reminders == nil {
+		s.state.Reminders = make(map[string]ReminderAck)
+	}
+	s.state.Reminders[key] = ack
+	s.pruneRemindersLocked()
+	return s.saveLocked()
+}
+
+// pruneRemindersLocked прибирає підтвердження для видалених вікон.
+func (s *FileStore) pruneRemindersLocked() {
+	for key := range s.state.Reminders {
+		windowID, _, _ := strings.Cut(key, "@")
+		if !slices.ContainsFunc(s.state.Windows, func(w Window) bool { return w.ID == windowID }) {
+			delete(s.state.Reminders, key)
+		}
+	}
+}
+
+func (s *FileStore) reloadLocked() error {
+	if s.seen == nil {
+		s.seen = make(map[string]struct{})
+	}
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("maintenance: stat %s: %w", s.path, err)
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("maintenance: read %s: %w", s.path, err)
+	}
+	var state storeFile
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return fmt.Errorf("maintenance: decode %s: %w", s.path, err)
+		}
+	}
+	// Повторення рахуються календарними днями, тож час переводиться в
+	// локальну зону, інакше AddDate не врахує перехід на літній час.
+	for i := range state.Windows {
+		window := &state.Windows[i]
+		window.Start = window.Start.Local()
+		window.End = window.End.Local()
+		if !window.Until.IsZero() {
+			window.Until = window.Until.Local()
+		}
+	}
+	s.state = state
+	s.seen = make(map[string]struct{}, len(state.Suppressed))
+	for _, entry := range state.Suppressed {
+		s.seen[entry.key()] = struct{}{}
+	}
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("maintenance: encode windows: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("maintenance: create %s: %w", dir, err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o600); err != nil {
+		return fmt.Errorf("maintenance: write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("maintenance: replace %s: %w", s.path, err)
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+	return nil
+}
+
+func newWindowID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("mw-%d", time.Now().UnixNano())
+	}
+	return "mw-" + hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,223 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReminderPeriod — скільки після завершення вікна показується
+// нагадування про не взятий під охорону об'єкт.
+const DefaultReminderPeriod = 12 * time.Hour
+
+// ObjectLookup дає поточний стан об'єкта для перевірки охорони.
+type ObjectLookup interface {
+	GetObjectByID(id string) *models.Object
+}
+
+// Suppressor застосовує вікна обслуговування до зведеної стрічки тривог.
+// Нагадування мають ID з ids.MaintenanceAlarmIDNamespace*.
+type Suppressor struct {
+	store          *FileStore
+	reminderPeriod time.Duration
+	now            func() time.Time
+}
+
+// NewSuppressor створює Suppressor над store; reminderPeriod <= 0 — DefaultReminderPeriod.
+func NewSuppressor(store *FileStore, reminderPeriod time.Duration) *Suppressor {
+	if reminderPeriod <= 0 {
+		reminderPeriod = DefaultReminderPeriod
+	}
+	return &Suppressor{
+		store:          store,
+		reminderPeriod: reminderPeriod,
+		now:            time.Now,
+	}
+}
+
+// Open відкриває файл вікон path і створює для нього Suppressor.
+func Open(path string, reminderPeriod time.Duration) (*Suppressor, error) {
+	store, err := OpenFileStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSuppressor(store, reminderPeriod), nil
+}
+
+// Store повертає сховище вікон.
+func (s *Suppressor) Store() *FileStore {
+	if s == nil {
+		return nil
+	}
+	return s.store
+}
+
+// ApplyMaintenance прибирає або позначає тривоги, що виникли в діючому вікні,
+// і додає нагадування для завершених вікон. Тривога, що була до початку
+// вікна, не чіпається: оператор її вже бачив.
+func (s *Suppressor) ApplyMaintenance(alarms []models.Alarm, objects ObjectLookup) []models.Alarm {
+	if s == nil || s.store == nil {
+		return alarms
+	}
+	windows, err := s.store.Windows()
+	if err != nil {
+		log.Warn().Err(err).Msg("maintenance: не вдалося прочитати вікна обслуговування")
+		return alarms
+	}
+	if len(windows) == 0 {
+		return alarms
+	}
+	now := s.now()
+
+	type activeWindow struct {
+		window     Window
+		occurrence Occurrence
+	}
+	active := make([]activeWindow, 0, len(windows))
+	for _, window := range windows {
+		if occurrence, ok := window.ActiveAt(now); ok {
+			active = append(active, activeWindow{window: window, occurrence: occurrence})
+		}
+	}
+
+	result := alarms
+	if len(active) > 0 {
+		result = make([]models.Alarm, 0, len(alarms))
+		var suppressed []SuppressedAlarm
+		for _, alarm := range alarms {
+			index := -1
+			for i, candidate := range active {
+				if candidate.window.Matches(alarm) && !alarm.Time.Before(candidate.occurrence.Start) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				result = append(result, alarm)
+				continue
+			}
+			window := active[index].window
+			if window.Mode == ModeTag {
+				alarm.MaintenanceReason = window.Reason
+				result = append(result, alarm)
+				continue
+			}
+			suppressed = append(suppressed, suppressedEntry(window, alarm, now))
+		}
+		if err := s.store.RecordSuppressed(suppressed); err != nil {
+			log.Warn().Err(err).Msg("maintenance: не вдалося записати журнал приглушених тривог")
+		}
+	}
+
+	return append(result, s.reminders(windows, now, objects)...)
+}
+
+func suppressedEntry(window Window, alarm models.Alarm, now time.Time) SuppressedAlarm {
+	return SuppressedAlarm{
+		WindowID:     window.ID,
+		Reason:       window.Reason,
+		AlarmID:      alarm.ID,
+		ObjectID:     alarm.ObjectID,
+		ObjectNumber: alarm.GetObjectNumberDisplay(),
+		ObjectName:   alarm.ObjectName,
+		ZoneNumber:   alarm.ZoneNumber,
+		ZoneName:     alarm.ZoneName,
+		Type:         alarm.GetTypeDisplay(),
+		Details:      alarm.Details,
+		Time:         alarm.Time,
+		SuppressedAt: now,
+	}
+}
+
+// reminders будує нагадування для вікон, що завершились протягом
+// reminderPeriod, не підтверджені, а об'єкт досі знятий з охорони.
+// Невідомий стан охорони нагадування не викликає.
+func (s *Suppressor) reminders(windows []Window, now time.Time, objects ObjectLookup) []models.Alarm {
+	if objects == nil {
+		return nil
+	}
+	var reminders []models.Alarm
+	for _, window := range windows {
+		if _, active := window.ActiveAt(now); active {
+			continue
+		}
+		occurrence, ok := window.LastEndedBy(now)
+		if !ok || now.Sub(occurrence.End) > s.reminderPeriod {
+			continue
+		}
+		key := OccurrenceKey(window.ID, occurrence)
+		if s.store.ReminderAcknowledged(key) {
+			continue
+		}
+		object := objects.GetObjectByID(strconv.Itoa(window.ObjectID))
+		if object == nil || object.GuardStatusValue() != models.GuardStatusDisarmed {
+			continue
+		}
+		reminders = append(reminders, reminderAlarm(window, occurrence, *object))
+	}
+	return reminders
+}
+
+func reminderAlarm(window Window, occurrence Occurrence, object models.Object) models.Alarm {
+	number := strings.TrimSpace(window.ObjectNumber)
+	if number == "" {
+		number = strconv.Itoa(object.ID)
+	}
+	return models.Alarm{
+		ID:             ReminderAlarmID(OccurrenceKey(window.ID, occurrence)),
+		ObjectID:       object.ID,
+		ObjectNumber:   number,
+		ObjectName:     object.Name,
+		Address:        object.Address,
+		Time:           occurrence.End,
+		Details:        fmt.Sprintf("Обслуговування завершено о %s, об'єкт не під охороною. Причина: %s", occurrence.End.Format("02.01.2006 15:04"), window.Reason),
+		Type:           models.AlarmMaintenanceReminder,
+		VisualSeverity: models.VisualSeverityWarning,
+		CanProcess:     true,
+	}
+}
+
+// ReminderAlarmID повертає стабільний від'ємний ID нагадування.
+func ReminderAlarmID(key string) int {
+	return ids.StableMaintenanceAlarmID(key)
+}
+
+// IsReminderAlarmID повідомляє, чи належить ID нагадуванню про вікно.
+func IsReminderAlarmID(id int) bool {
+	return ids.IsMaintenanceAlarmID(id)
+}
+
+// AcknowledgeReminder підтверджує нагадування з ID alarmID; після цього
+// воно більше не з'являється у стрічці.
+func (s *Suppressor) AcknowledgeReminder(alarmID int, user string, note string) error {
+	if s == nil || s.store == nil {
+		return errors.New("maintenance: suppressor is not configured")
+	}
+	windows, err := s.store.Windows()
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, window := range windows {
+		occurrence, ok := window.LastEndedBy(now)
+		if !ok {
+			continue
+		}
+		key := OccurrenceKey(window.ID, occurrence)
+		if ReminderAlarmID(key) == alarmID {
+			return s.store.AcknowledgeReminder(key, ReminderAck{
+				User: strings.TrimSpace(user),
+				Note: strings.TrimSpace(note),
+				At:   now,
+			})
+		}
+	}
+	return fmt.Errorf("нагадування %d не знайдено", alarmID)
+}
@@ -0,0 +1,159 @@
+package maintenance
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type stubObjects map[int]models.Object
+
+func (s stubObjects) GetObjectByID(id string) *models.Object {
+	key, _ := strconv.Atoi(id)
+	object, ok := s[key]
+	if !ok {
+		return nil
+	}
+	return &object
+}
+
+func newTestSuppressor(t *testing.T, now time.Time, windows ...Window) *Suppressor {
+	t.Helper()
+	store, err := OpenFileStore("")
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	for _, window := range windows {
+		if _, err := store.SaveWindow(window); err != nil {
+			t.Fatalf("SaveWindow() error = %v", err)
+		}
+	}
+	suppressor := NewSuppressor(store, 0)
+	suppressor.now = func() time.Time { return now }
+	return suppressor
+}
+
+func TestApplyMaintenanceSuppressesAndTags(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	now := start.Add(30 * time.Minute)
+	suppressor := newTestSuppressor(t, now,
+		Window{ObjectID: 1, Start: start, End: start.Add(2 * time.Hour), Reason: "заміна АКБ", Mode: ModeSuppress},
+		Window{ObjectID: 2, Zones: []int{3}, Start: start, End: start.Add(2 * time.Hour), Reason: "ремонт", Mode: ModeTag},
+	)
+
+	alarms := []models.Alarm{
+		{ID: 10, ObjectID: 1, Time: start.Add(10 * time.Minute), Type: models.AlarmFire},
+		{ID: 11, ObjectID: 1, Time: start.Add(-10 * time.Minute), Type: models.AlarmBurglary},
+		{ID: 20, ObjectID: 2, ZoneNumber: 3, Time: start.Add(5 * time.Minute), Type: models.AlarmBurglary},
+		{ID: 21, ObjectID: 2, ZoneNumber: 4, Time: start.Add(5 * time.Minute), Type: models.AlarmBurglary},
+	}
+	result := suppressor.ApplyMaintenance(alarms, stubObjects{})
+
+	byID := make(map[int]models.Alarm, len(result))
+	for _, alarm := range result {
+		byID[alarm.ID] = alarm
+	}
+	if _, ok := byID[10]; ok {
+		t.Fatal("alarm raised inside suppress window must be removed")
+	}
+	if _, ok := byID[11]; !ok {
+		t.Fatal("alarm raised before the window must stay")
+	}
+	if got := byID[20].MaintenanceReason; got != "ремонт" {
+		t.Fatalf("tagged alarm tag = %q", got)
+	}
+	if got := byID[21].MaintenanceReason; got != "" {
+		t.Fatalf("alarm from another zone must not be tagged, got %q", got)
+	}
+
+	suppressed, err := suppressor.Store().Suppressed(start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Suppressed() error = %v", err)
+	}
+	if len(suppressed) != 1 || suppressed[0].AlarmID != 10 || suppressed[0].Reason != "заміна АКБ" {
+		t.Fatalf("suppressed = %+v", suppressed)
+	}
+
+	// Повторне опитування не дублює запис журналу.
+	suppressor.ApplyMaintenance(alarms, stubObjects{})
+	if suppressed, _ := suppressor.Store().Suppressed(start, start.Add(time.Hour)); len(suppressed) != 1 {
+		t.Fatalf("suppressed after second poll = %d, want 1", len(suppressed))
+	}
+}
+
+func TestApplyMaintenanceRemindsAboutDisarmedObject(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	now := start.Add(3 * time.Hour)
+	suppressor := newTestSuppressor(t, now,
+		Window{ObjectID: 1, Start: start, End: start.Add(2 * time.Hour), Reason: "заміна АКБ", Mode: ModeSuppress},
+		Window{ObjectID: 2, Start: start, End: start.Add(2 * time.Hour), Reason: "ремонт", Mode: ModeSuppress},
+	)
+	objects := stubObjects{
+		1: {ID: 1, Name: "Магазин", GuardStatus: models.GuardStatusDisarmed},
+		2: {ID: 2, Name: "Склад", GuardStatus: models.GuardStatusGuarded},
+	}
+
+	result := suppressor.ApplyMaintenance(nil, objects)
+	if len(result) != 1 {
+		t.Fatalf("reminders = %d, want 1", len(result))
+	}
+	reminder := result[0]
+	if reminder.Type != models.AlarmMaintenanceReminder || reminder.ObjectID != 1 || !IsReminderAlarmID(reminder.ID) {
+		t.Fatalf("unexpected reminder %+v", reminder)
+	}
+
+	if err := suppressor.AcknowledgeReminder(reminder.ID, "оператор", "подзвонили"); err != nil {
+		t.Fatalf("AcknowledgeReminder() error = %v", err)
+	}
+	if result := suppressor.ApplyMaintenance(nil, objects); len(result) != 0 {
+		t.Fatalf("acknowledged reminder is still shown: %+v", result)
+	}
+
+	late := newTestSuppressor(t, start.Add(2*time.Hour+DefaultReminderPeriod+time.Minute),
+		Window{ObjectID: 1, Start: start, End: start.Add(2 * time.Hour), Reason: "заміна АКБ", Mode: ModeSuppress},
+	)
+	if result := late.ApplyMaintenance(nil, objects); len(result) != 0 {
+		t.Fatalf("reminder after reminder period: %+v", result)
+	}
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "maintenance.json")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	saved, err := store.SaveWindow(Window{ObjectID: 4, Start: start, End: start.Add(time.Hour), Reason: "ремонт", Mode: ModeTag})
+	if err != nil {
+		t.Fatalf("SaveWindow() error = %v", err)
+	}
+	if saved.ID == "" {
+		t.Fatal("SaveWindow() must assign ID")
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	windows, err := reopened.Windows()
+	if err != nil || len(windows) != 1 || windows[0].ID != saved.ID || !windows[0].Start.Equal(start) {
+		t.Fatalf("Windows() = %+v, %v", windows, err)
+	}
+
+	if err := reopened.DeleteWindow(saved.ID); err != nil {
+		t.Fatalf("DeleteWindow() error = %v", err)
+	}
+	if windows, _ := store.Windows(); len(windows) != 0 {
+		t.Fatalf("first store must see deletion, got %+v", windows)
+	}
+}
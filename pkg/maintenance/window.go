@@ -0,0 +1,180 @@
+// Package maintenance описує планові вікна обслуговування об'єктів: у межах
+// вікна тривоги об'єкта (або окремих зон) приглушуються чи позначаються, а
+// після завершення вікна оператор отримує нагадування, якщо об'єкт так і не
+// поставили під охорону.
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// Recurrence задає повторення вікна.
+type Recurrence string
+
+const (
+	RecurrenceNone   Recurrence = ""
+	RecurrenceDaily  Recurrence = "daily"
+	RecurrenceWeekly Recurrence = "weekly"
+)
+
+// Mode визначає, що відбувається з тривогою у вікні.
+type Mode string
+
+const (
+	// ModeSuppress прибирає тривогу зі стрічки та пише її у журнал приглушених.
+	ModeSuppress Mode = "suppress"
+	// ModeTag залишає тривогу у стрічці з позначкою обслуговування.
+	ModeTag Mode = "tag"
+)
+
+// Window — одне вікно обслуговування. Для повторюваних вікон Start/End
+// задають перше входження, Until (якщо задано) — останній можливий початок.
+type Window struct {
+	ID           string     `json:"id"`
+	ObjectID     int        `json:"object_id"`
+	ObjectNumber string     `json:"object_number,omitempty"`
+	ObjectName   string     `json:"object_name,omitempty"`
+	Zones        []int      `json:"zones,omitempty"`
+	Start        time.Time  `json:"start"`
+	End          time.Time  `json:"end"`
+	Recurrence   Recurrence `json:"recurrence,omitempty"`
+	Until        time.Time  `json:"until,omitzero"`
+	Reason       string     `json:"reason"`
+	Mode         Mode       `json:"mode"`
+	CreatedBy    string     `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at,omitzero"`
+}
+
+// Occurrence — конкретний інтервал дії вікна.
+type Occurrence struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Validate перевіряє, що вікно можна зберегти.
+func (w Window) Validate() error {
+	if w.ObjectID == 0 {
+		return errors.New("не вказано об'єкт")
+	}
+	if strings.TrimSpace(w.Reason) == "" {
+		return errors.New("не вказано причину обслуговування")
+	}
+	if w.Start.IsZero() || !w.End.After(w.Start) {
+		return errors.New("кінець вікна має бути пізніше за початок")
+	}
+	switch w.Mode {
+	case ModeSuppress, ModeTag:
+	default:
+		return fmt.Errorf("невідомий режим вікна %q", w.Mode)
+	}
+	switch w.Recurrence {
+	case RecurrenceNone:
+	case RecurrenceDaily, RecurrenceWeekly:
+		if period := time.Duration(w.periodDays()) * 24 * time.Hour; w.End.Sub(w.Start) >= period {
+			return errors.New("вікно довше за період повторення")
+		}
+		if !w.Until.IsZero() && w.Until.Before(w.Start) {
+			return errors.New("дата завершення повторень раніше за початок")
+		}
+	default:
+		return fmt.Errorf("невідоме повторення %q", w.Recurrence)
+	}
+	for _, zone := range w.Zones {
+		if zone <= 0 {
+			return fmt.Errorf("некоректний номер зони %d", zone)
+		}
+	}
+	return nil
+}
+
+// Matches повідомляє, чи стосується вікно тривоги (об'єкт і, якщо задано, зона).
+func (w Window) Matches(alarm models.Alarm) bool {
+	if alarm.ObjectID != w.ObjectID {
+		return false
+	}
+	return len(w.Zones) == 0 || slices.Contains(w.Zones, alarm.ZoneNumber)
+}
+
+// ActiveAt повертає входження, що діє в момент t.
+func (w Window) ActiveAt(t time.Time) (Occurrence, bool) {
+	if w.Recurrence == RecurrenceNone {
+		if !t.Before(w.Start) && t.Before(w.End) {
+			return Occurrence{Start: w.Start, End: w.End}, true
+		}
+		return Occurrence{}, false
+	}
+	// Вікно коротше за період, тож у момент t може діяти лише поточне
+	// або попереднє (якщо перетинає північ) входження; сусіднє додано на
+	// випадок доби у 23/25 годин.
+	index := w.indexAt(t)
+	for _, k := range []int{index + 1, index, index - 1} {
+		occurrence, ok := w.occurrence(k)
+		if ok && !t.Before(occurrence.Start) && t.Before(occurrence.End) {
+			return occurrence, true
+		}
+	}
+	return Occurrence{}, false
+}
+
+// LastEndedBy повертає останнє входження, що завершилось не пізніше t.
+func (w Window) LastEndedBy(t time.Time) (Occurrence, bool) {
+	if w.Recurrence == RecurrenceNone {
+		if !w.End.After(t) {
+			return Occurrence{Start: w.Start, End: w.End}, true
+		}
+		return Occurrence{}, false
+	}
+	index := w.indexAt(t)
+	for k := index + 1; k >= index-2; k-- {
+		occurrence, ok := w.occurrence(k)
+		if ok && !occurrence.End.After(t) {
+			return occurrence, true
+		}
+	}
+	return Occurrence{}, false
+}
+
+func (w Window) periodDays() int {
+	if w.Recurrence == RecurrenceWeekly {
+		return 7
+	}
+	return 1
+}
+
+// indexAt повертає номер входження, що почалось у день t або раніше.
+func (w Window) indexAt(t time.Time) int {
+	days := int(t.Sub(w.Start).Hours() / 24)
+	if t.Before(w.Start) {
+		days--
+	}
+	step := w.periodDays()
+	if days < 0 {
+		return (days - step + 1) / step
+	}
+	return days / step
+}
+
+// occurrence рахує k-те входження календарними днями, щоб перехід на
+// літній час не зсував вікно.
+func (w Window) occurrence(k int) (Occurrence, bool) {
+	if k < 0 {
+		return Occurrence{}, false
+	}
+	days := k * w.periodDays()
+	start := w.Start.AddDate(0, 0, days)
+	if !w.Until.IsZero() && start.After(w.Until) {
+		return Occurrence{}, false
+	}
+	return Occurrence{Start: start, End: w.End.AddDate(0, 0, days)}, true
+}
+
+// OccurrenceKey ідентифікує входження вікна, напр. для підтвердження нагадувань.
+func OccurrenceKey(windowID string, occurrence Occurrence) string {
+	return windowID + "@" + occurrence.End.UTC().Format(time.RFC3339)
+}
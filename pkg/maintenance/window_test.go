@@ -0,0 +1,121 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestWindowDailyOccurrences(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)
+	window := Window{
+		ID:         "mw-1",
+		ObjectID:   101,
+		Start:      start,
+		End:        start.Add(3 * time.Hour),
+		Recurrence: RecurrenceDaily,
+		Until:      time.Date(2026, 3, 5, 23, 59, 0, 0, time.UTC),
+		Reason:     "нічні роботи",
+		Mode:       ModeSuppress,
+	}
+	if err := window.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	occurrence, ok := window.ActiveAt(time.Date(2026, 3, 4, 0, 30, 0, 0, time.UTC))
+	if !ok || !occurrence.Start.Equal(time.Date(2026, 3, 3, 22, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ActiveAt after midnight = %v, %v", occurrence, ok)
+	}
+	if _, ok := window.ActiveAt(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)); ok {
+		t.Fatal("window must not be active at noon")
+	}
+	if _, ok := window.ActiveAt(time.Date(2026, 3, 6, 22, 30, 0, 0, time.UTC)); ok {
+		t.Fatal("window must not be active after Until")
+	}
+	if _, ok := window.ActiveAt(start.Add(-time.Minute)); ok {
+		t.Fatal("window must not be active before the first occurrence")
+	}
+
+	last, ok := window.LastEndedBy(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC))
+	if !ok || !last.End.Equal(time.Date(2026, 3, 4, 1, 0, 0, 0, time.UTC)) {
+		t.Fatalf("LastEndedBy = %v, %v", last, ok)
+	}
+	if _, ok := window.LastEndedBy(start.Add(time.Hour)); ok {
+		t.Fatal("no occurrence has ended during the first one")
+	}
+}
+
+func TestWindowValidateRejectsInvalidWindows(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	valid := Window{ObjectID: 1, Start: start, End: start.Add(time.Hour), Reason: "ремонт", Mode: ModeTag}
+	cases := map[string]func(*Window){
+		"no object":      func(w *Window) { w.ObjectID = 0 },
+		"no reason":      func(w *Window) { w.Reason = " " },
+		"end before":     func(w *Window) { w.End = w.Start },
+		"unknown mode":   func(w *Window) { w.Mode = "mute" },
+		"too long daily": func(w *Window) { w.Recurrence = RecurrenceDaily; w.End = w.Start.Add(25 * time.Hour) },
+		"bad zone":       func(w *Window) { w.Zones = []int{0} },
+	}
+	for name, mutate := range cases {
+		window := valid
+		mutate(&window)
+		if err := window.Validate(); err == nil {
+			t.Errorf("%s: Validate() expected error", name)
+		}
+	}
+}
+
+func TestWindowMatchesZones(t *testing.T) {
+	t.Parallel()
+
+	window := Window{ObjectID: 7, Zones: []int{2, 4}}
+	if !window.Matches(models.Alarm{ObjectID: 7, ZoneNumber: 4}) {
+		t.Fatal("zone 4 must match")
+	}
+	if window.Matches(models.Alarm{ObjectID: 7, ZoneNumber: 3}) {
+		t.Fatal("zone 3 must not match")
+	}
+	if window.Matches(models.Alarm{ObjectID: 8, ZoneNumber: 2}) {
+		t.Fatal("other object must not match")
+	}
+	window.Zones = nil
+	if !window.Matches(models.Alarm{ObjectID: 7, ZoneNumber: 3}) {
+		t.Fatal("window without zones must cover the whole object")
+	}
+}
+
+func TestFormWindowParsesLocalTimes(t *testing.T) {
+	t.Parallel()
+
+	window, err := Form{
+		ObjectID:   5,
+		Zones:      "1, 3;5",
+		Start:      "02.03.2026 08:00",
+		End:        "02.03.2026 10:30",
+		Until:      "06.03.2026",
+		Recurrence: RecurrenceDaily,
+		Mode:       ModeSuppress,
+		Reason:     "заміна АКБ",
+	}.Window()
+	if err != nil {
+		t.Fatalf("Window() error = %v", err)
+	}
+	if got := window.ZonesLabel(); got != "зони 1, 3, 5" {
+		t.Fatalf("zones = %q", got)
+	}
+	if want := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local); !window.Start.Equal(want) {
+		t.Fatalf("start = %v, want %v", window.Start, want)
+	}
+	if _, ok := window.occurrence(4); !ok {
+		t.Fatal("occurrence on the Until day must be included")
+	}
+
+	if _, err := (Form{ObjectID: 5, Start: "02.03.2026", End: "02.03.2026 10:30", Mode: ModeTag, Reason: "x"}).Window(); err == nil {
+		t.Fatal("expected error for start without time")
+	}
+}
@@ -32,7 +32,12 @@ const (
 	// AlarmFire        AlarmType = "FIRE_ALARM"        // Пожежна тривога
 	AlarmFireTrouble  AlarmType = "FIRE_TROUBLE" // Проблеми з пожежною сигналізацією
 	AlarmNotification AlarmType = "notification" // Повідомлення
-
+	// AlarmMaintenanceReminder нагадує, що вікно обслуговування завершилось, а об'єкт не під охороною.
+	AlarmMaintenanceReminder AlarmType = "maintenance_reminder"
+	// AlarmTestMissed — локально виявлений пропуск періодичного тесту.
+	AlarmTestMissed AlarmType = "test_missed"
+	// AlarmScheduleViolation — порушення розкладу відкриття/закриття об'єкта.
+	AlarmScheduleViolation AlarmType = "schedule_violation"
 )
 
 // Alarm представляє активну тривогу, що потребує обробки
@@ -61,7 +66,60 @@ type Alarm struct {
 	ResponseGroupID           string // Ідентифікатор призначеної МГР
 	IsResponseGroupDispatched bool   // Чи вислана МГР
 	IsResponseGroupArrived    bool   // Чи МГР відмічена як така, що прибула
+	MaintenanceReason         string // Причина вікна обслуговування, під яке потрапила тривога
+	Pult                      string // Пульт CASL тривоги (лише коли пультів кілька)
+	SourceInstance            string // Екземпляр джерела (лише коли джерел одного типу кілька)
+	IncidentID                int    // ID головної тривоги інциденту кореляції (0 — тривога поодинока)
+	Incident                  string // Опис інциденту кореляції
 	SourceMsgs                []AlarmMsg
+	Priority                  AlarmPriority // Оцінка пріоритету з розкладом за чинниками
+}
+
+// AlarmPriority — оцінка пріоритету тривоги і внесок кожного чинника.
+// Score — сума решти полів; Dispatched від'ємний, коли МГР уже вислана.
+type AlarmPriority struct {
+	Score      int
+	Type       int
+	ObjectType int
+	Contract   int
+	Repeats    int
+	Unattended int
+	Dispatched int
+}
+
+// IsZero повертає true, якщо пріоритет не обчислювався.
+func (p AlarmPriority) IsZero() bool {
+	return p == AlarmPriority{}
+}
+
+// Breakdown повертає розклад оцінки, напр. "185 = тип 100 + об'єкт 20 + повтори 10".
+// Нульові чинники пропускаються.
+func (p AlarmPriority) Breakdown() string {
+	parts := make([]string, 0, 6)
+	add := func(label string, value int) {
+		if value == 0 {
+			return
+		}
+		if len(parts) > 0 {
+			if value < 0 {
+				parts = append(parts, "- "+label+" "+strconv.Itoa(-value))
+				return
+			}
+			parts = append(parts, "+ "+label+" "+strconv.Itoa(value))
+			return
+		}
+		parts = append(parts, label+" "+strconv.Itoa(value))
+	}
+	add("тип", p.Type)
+	add("об'єкт", p.ObjectType)
+	add("договір", p.Contract)
+	add("повтори", p.Repeats)
+	add("без реакції", p.Unattended)
+	add("МГР", p.Dispatched)
+	if len(parts) == 0 {
+		return strconv.Itoa(p.Score)
+	}
+	return strconv.Itoa(p.Score) + " = " + strings.Join(parts, " ")
 }
 
 // AlarmMsg представляє джерельне повідомлення тривожного кейсу (ppk_msgs-ланцюжок).
@@ -116,6 +174,12 @@ func (a *Alarm) GetTypeDisplay() string {
 		return "Проблеми з пожежною сигналізацією"
 	case AlarmNotification:
 		return "Попадання тривоги в стрічку"
+	case AlarmMaintenanceReminder:
+		return "Завершено обслуговування"
+	case AlarmTestMissed:
+		return "ПРОПУЩЕНО ТЕСТ"
+	case AlarmScheduleViolation:
+		return "ПОРУШЕННЯ РОЗКЛАДУ"
 	default:
 		return "ПОДІЯ"
 	}
@@ -142,6 +206,17 @@ func (a *Alarm) GetObjectNumberDisplay() string {
 	return strconv.Itoa(a.ObjectID)
 }
 
+// MaintenanceTag повертає позначку вікна обслуговування для стрічки тривог або порожній рядок.
+func (a *Alarm) MaintenanceTag() string {
+	if a == nil {
+		return ""
+	}
+	if reason := strings.TrimSpace(a.MaintenanceReason); reason != "" {
+		return "ТО: " + reason
+	}
+	return ""
+}
+
 // IsCritical повертає true якщо тривога критична і має підсвічуватись як пріоритетна.
 func (a *Alarm) IsCritical() bool {
 	switch a.Type {
@@ -30,3 +30,15 @@ func TestAlarmGetObjectNumberDisplay(t *testing.T) {
 		}
 	})
 }
+
+func TestAlarmPriorityBreakdown(t *testing.T) {
+	priority := AlarmPriority{Score: 75, Type: 100, Repeats: 10, Unattended: 25, Dispatched: -60}
+
+	want := "75 = тип 100 + повтори 10 + без реакції 25 - МГР 60"
+	if got := priority.Breakdown(); got != want {
+		t.Fatalf("Breakdown() = %q, want %q", got, want)
+	}
+	if got := (AlarmPriority{}).Breakdown(); got != "0" {
+		t.Fatalf("zero Breakdown() = %q, want %q", got, "0")
+	}
+}
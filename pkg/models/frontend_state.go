@@ -141,7 +141,7 @@ func (a Alarm) VisualSeverityValue() VisualSeverity {
 		return VisualSeverityCritical
 	}
 	switch a.Type {
-	case AlarmFault, AlarmPowerFail, AlarmBatteryLow, AlarmOffline, AlarmAcTrouble, AlarmFireTrouble:
+	case AlarmFault, AlarmPowerFail, AlarmBatteryLow, AlarmOffline, AlarmAcTrouble, AlarmFireTrouble, AlarmMaintenanceReminder, AlarmTestMissed, AlarmScheduleViolation:
 		return VisualSeverityWarning
 	case AlarmEliminated, AlarmNotification, AlarmSystemEvent:
 		return VisualSeverityInfo
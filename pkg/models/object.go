@@ -74,6 +74,10 @@ type Object struct {
 	LaunchDate                 string // Дата запуску (OBJECTS_INFO.RESERVTEXT)
 	PreferredResponseGroupID   string // Основна/прив'язана ГМР з картки об'єкта
 	PreferredResponseGroupName string // Назва основної/прив'язаної ГМР
+	Pult                       string // Пульт CASL об'єкта (лише коли пультів кілька)
+	SourceInstance             string // Екземпляр джерела (лише коли джерел одного типу кілька)
+	ObjTypeID                  int64  // Тип об'єкта (OBJTYPEID)
+	ContractPriority           int    // Договірний пріоритет об'єкта (OBJPRIORITY1)
 
 	// Технічні стани
 	IsUnderGuard  bool
@@ -0,0 +1,84 @@
+package objectgroups
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// Form — сирі значення форми групи з UI. Objects — номери об'єктів через
+// кому, пробіл чи новий рядок; об'єкт, якого зараз немає у джерелах,
+// записується як #ID. Query — вираз мови фільтрів замість переліку.
+type Form struct {
+	ID        string
+	Name      string
+	Objects   string
+	Query     string
+	Watch     bool
+	UpdatedBy string
+}
+
+// NewForm заповнює форму значеннями існуючої групи.
+func NewForm(group Group, objects []models.Object) Form {
+	byID := make(map[int]models.Object, len(objects))
+	for _, object := range objects {
+		byID[object.ID] = object
+	}
+	numbers := make([]string, 0, len(group.ObjectIDs))
+	for _, id := range group.ObjectIDs {
+		if object, ok := byID[id]; ok {
+			numbers = append(numbers, ids.ObjectDisplayNumber(object))
+			continue
+		}
+		numbers = append(numbers, "#"+strconv.Itoa(id))
+	}
+	return Form{
+		ID:        group.ID,
+		Name:      group.Name,
+		Objects:   strings.Join(numbers, ", "),
+		Query:     group.Query,
+		Watch:     group.Watch,
+		UpdatedBy: group.UpdatedBy,
+	}
+}
+
+// Group розбирає форму в групу. Номер, що є в кількох джерелах, бере всі збіги.
+func (f Form) Group(objects []models.Object) (Group, error) {
+	group := Group{
+		ID:        strings.TrimSpace(f.ID),
+		Name:      strings.TrimSpace(f.Name),
+		Query:     strings.TrimSpace(f.Query),
+		Watch:     f.Watch,
+		UpdatedBy: strings.TrimSpace(f.UpdatedBy),
+	}
+	for _, number := range strings.FieldsFunc(f.Objects, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	}) {
+		if raw, ok := strings.CutPrefix(number, "#"); ok {
+			id, err := strconv.Atoi(raw)
+			if err != nil || id <= 0 {
+				return Group{}, fmt.Errorf("некоректний ID об'єкта %q", number)
+			}
+			group.ObjectIDs = append(group.ObjectIDs, id)
+			continue
+		}
+		found := false
+		for _, object := range objects {
+			if strings.EqualFold(ids.ObjectDisplayNumber(object), number) {
+				group.ObjectIDs = append(group.ObjectIDs, object.ID)
+				found = true
+			}
+		}
+		if !found {
+			return Group{}, fmt.Errorf("об'єкт №%s не знайдено", number)
+		}
+	}
+	group = group.normalize()
+	if err := group.Validate(); err != nil {
+		return Group{}, err
+	}
+	return group, nil
+}
@@ -0,0 +1,98 @@
+package objectgroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// FrontendBackend віддає групи реєстру через HTTP API
+// (contracts.FrontendObjectGroupsBackend).
+type FrontendBackend struct {
+	registry *Registry
+}
+
+// NewFrontendBackend створює адаптер API для реєстру груп.
+func NewFrontendBackend(registry *Registry) *FrontendBackend {
+	return &FrontendBackend{registry: registry}
+}
+
+func (b *FrontendBackend) ListObjectGroups(ctx context.Context) ([]contracts.FrontendObjectGroup, string, error) {
+	store := b.store()
+	if store == nil {
+		return nil, "", errors.New("objectgroups: store is nil")
+	}
+	groups, version, err := store.Snapshot()
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]contracts.FrontendObjectGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, contracts.FrontendObjectGroup{
+			ID:        group.ID,
+			Name:      group.Name,
+			ObjectIDs: group.ObjectIDs,
+			Query:     group.Query,
+			Watch:     group.Watch,
+			UpdatedBy: group.UpdatedBy,
+			UpdatedAt: group.UpdatedAt,
+		})
+	}
+	return result, version, nil
+}
+
+func (b *FrontendBackend) SaveObjectGroups(ctx context.Context, items []contracts.FrontendObjectGroup, revision string) (string, error) {
+	store := b.store()
+	if store == nil {
+		return "", errors.New("objectgroups: store is nil")
+	}
+	groups := make([]Group, 0, len(items))
+	for _, item := range items {
+		groups = append(groups, Group{
+			ID:        item.ID,
+			Name:      item.Name,
+			ObjectIDs: item.ObjectIDs,
+			Query:     item.Query,
+			Watch:     item.Watch,
+			UpdatedBy: item.UpdatedBy,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	version, err := store.ReplaceGroupsAt(revision, groups)
+	if errors.Is(err, ErrVersionConflict) {
+		return "", fmt.Errorf("%w: %v", contracts.ErrFrontendRevisionConflict, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	b.registry.Invalidate()
+	return version, nil
+}
+
+func (b *FrontendBackend) ObjectGroupMembers(ctx context.Context, groupID string) (map[int]struct{}, error) {
+	members := b.membership().Members(groupID)
+	if members == nil {
+		return nil, fmt.Errorf("objectgroups: group %q not found", groupID)
+	}
+	return members, nil
+}
+
+func (b *FrontendBackend) WatchedObjects(ctx context.Context) (map[int]struct{}, error) {
+	return b.membership().Watched(), nil
+}
+
+func (b *FrontendBackend) store() *FileStore {
+	if b == nil {
+		return nil
+	}
+	return b.registry.Store()
+}
+
+func (b *FrontendBackend) membership() *Membership {
+	if b == nil {
+		return nil
+	}
+	return b.registry.Membership()
+}
@@ -0,0 +1,91 @@
+// Package objectgroups зберігає набори об'єктів оператора — статичні списки
+// або збережені вирази мови фільтрів — і визначає, які об'єкти до них входять.
+// Групи з позначкою Watch утворюють список спостереження: тривоги їхніх
+// об'єктів закріплюються нагорі панелі тривог.
+package objectgroups
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/usecases"
+)
+
+// MaxGroups обмежує кількість груп, щоб випадаючі фільтри панелей лишалися оглядовими.
+const MaxGroups = 100
+
+// Group — іменований набір об'єктів. Задається або переліком ObjectIDs
+// (внутрішні ID з простором імен джерела), або виразом Query.
+type Group struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ObjectIDs []int     `json:"object_ids,omitempty"`
+	Query     string    `json:"query,omitempty"`
+	Watch     bool      `json:"watch,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsQuery повідомляє, що склад групи визначає вираз мови фільтрів.
+func (g Group) IsQuery() bool {
+	return strings.TrimSpace(g.Query) != ""
+}
+
+// Validate перевіряє назву і те, що група задана рівно одним способом.
+func (g Group) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return errors.New("вкажіть назву групи")
+	}
+	switch {
+	case g.IsQuery() && len(g.ObjectIDs) > 0:
+		return errors.New("вкажіть або номери об'єктів, або фільтр, а не обидва")
+	case g.IsQuery():
+		if _, err := usecases.ParseFilterQuery(g.Query); err != nil {
+			return fmt.Errorf("група %q: %w", g.Name, err)
+		}
+	case len(g.ObjectIDs) == 0:
+		return errors.New("вкажіть номери об'єктів або фільтр")
+	}
+	return nil
+}
+
+// normalize обрізає пробіли, прибирає дублікати ID і впорядковує їх.
+func (g Group) normalize() Group {
+	g.ID = strings.TrimSpace(g.ID)
+	g.Name = strings.TrimSpace(g.Name)
+	g.Query = strings.TrimSpace(g.Query)
+	g.UpdatedBy = strings.TrimSpace(g.UpdatedBy)
+	ids := slices.Clone(g.ObjectIDs)
+	slices.Sort(ids)
+	g.ObjectIDs = slices.Compact(ids)
+	return g
+}
+
+// ValidateGroups перевіряє кожну групу, а також що назви й ID не повторюються.
+func ValidateGroups(groups []Group) error {
+	if len(groups) > MaxGroups {
+		return fmt.Errorf("не більше %d груп об'єктів", MaxGroups)
+	}
+	names := make(map[string]struct{}, len(groups))
+	ids := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+		name := strings.ToLower(strings.TrimSpace(group.Name))
+		if _, exists := names[name]; exists {
+			return fmt.Errorf("група %q: назва повторюється", group.Name)
+		}
+		names[name] = struct{}{}
+		if id := strings.TrimSpace(group.ID); id != "" {
+			if _, exists := ids[id]; exists {
+				return fmt.Errorf("група %q: ID %s повторюється", group.Name, id)
+			}
+			ids[id] = struct{}{}
+		}
+	}
+	return nil
+}
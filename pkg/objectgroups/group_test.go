@@ -0,0 +1,156 @@
+package objectgroups
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func testObjects() []models.Object {
+	return []models.Object{
+		{ID: 7, Name: "Школа", ConnectionStatus: models.ConnectionStatusOnline},
+		{ID: ids.PhoenixObjectIDNamespaceStart + 7, DisplayNumber: "L0007", Name: "Аптека", ConnectionStatus: models.ConnectionStatusOffline},
+		{ID: ids.CASLObjectIDNamespaceStart + 12, Name: "Склад", Address: "м. Київ", ConnectionStatus: models.ConnectionStatusOffline},
+	}
+}
+
+func TestFormGroupResolvesNumbersAndRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	objects := testObjects()
+	group, err := Form{Name: " Ключові ", Objects: "7, L0007 7\n#999", Watch: true}.Group(objects)
+	if err != nil {
+		t.Fatalf("Group() error = %v", err)
+	}
+	want := []int{7, 999, ids.PhoenixObjectIDNamespaceStart + 7}
+	if group.Name != "Ключові" || !group.Watch || len(group.ObjectIDs) != len(want) {
+		t.Fatalf("group = %+v", group)
+	}
+	for i, id := range want {
+		if group.ObjectIDs[i] != id {
+			t.Fatalf("ObjectIDs = %v, want %v", group.ObjectIDs, want)
+		}
+	}
+	if form := NewForm(group, objects); form.Objects != "7, #999, L0007" {
+		t.Fatalf("NewForm().Objects = %q", form.Objects)
+	}
+
+	for _, form := range []Form{
+		{Name: "", Objects: "7"},
+		{Name: "Порожня"},
+		{Name: "Обидва", Objects: "7", Query: "status:offline"},
+		{Name: "Невідомий", Objects: "404"},
+		{Name: "Зламаний фільтр", Query: "status:sleeping"},
+	} {
+		if _, err := form.Group(objects); err == nil {
+			t.Errorf("%q: expected an error", form.Name)
+		}
+	}
+}
+
+func TestResolveStaticQueryAndWatchGroups(t *testing.T) {
+	t.Parallel()
+
+	membership := Resolve([]Group{
+		{ID: "static", Name: "Школи", ObjectIDs: []int{7}},
+		{ID: "kyiv", Name: "Київ офлайн", Query: `region:"Київ" status:offline`, Watch: true},
+	}, testObjects(), time.Now())
+
+	if members := membership.Members("static"); len(members) != 1 {
+		t.Fatalf("static members = %v", members)
+	}
+	kyiv := membership.Members("kyiv")
+	if _, ok := kyiv[ids.CASLObjectIDNamespaceStart+12]; !ok || len(kyiv) != 1 {
+		t.Fatalf("query members = %v", kyiv)
+	}
+	if !membership.IsWatched(ids.CASLObjectIDNamespaceStart+12) || membership.IsWatched(7) {
+		t.Fatalf("watched = %v", membership.Watched())
+	}
+	if membership.Members("") != nil || membership.Members("deleted") != nil {
+		t.Fatal("unknown group must not filter")
+	}
+	var empty *Membership
+	if empty.Members("static") != nil || empty.IsWatched(7) {
+		t.Fatal("nil membership must behave as no groups")
+	}
+}
+
+func TestFileStoreSharedBetweenRegistries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "groups", "object-groups.json")
+	first, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	saved, err := first.SaveGroup(Group{Name: "Офлайн", Query: "status:offline", Watch: true})
+	if err != nil || saved.ID == "" {
+		t.Fatalf("SaveGroup() = %+v, %v", saved, err)
+	}
+	if _, err := first.SaveGroup(Group{Name: "офлайн", ObjectIDs: []int{7}}); err == nil {
+		t.Fatal("duplicate name must be rejected")
+	}
+
+	second, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() second error = %v", err)
+	}
+	registry := NewRegistry(second, testObjects)
+	if groups := registry.Groups(); len(groups) != 1 || groups[0].ID != saved.ID {
+		t.Fatalf("shared groups = %+v", groups)
+	}
+	if watched := registry.Membership().Watched(); len(watched) != 2 {
+		t.Fatalf("watched = %v, want both offline objects", watched)
+	}
+
+	if err := second.ReplaceGroups([]Group{{Name: "Школи", ObjectIDs: []int{7, 7}}}); err != nil {
+		t.Fatalf("ReplaceGroups() error = %v", err)
+	}
+	groups, err := first.Groups()
+	if err != nil || len(groups) != 1 || groups[0].Name != "Школи" || len(groups[0].ObjectIDs) != 1 {
+		t.Fatalf("groups after replace = %+v, %v", groups, err)
+	}
+	if watched := registry.Membership().Watched(); len(watched) != 0 {
+		t.Fatalf("membership must follow the replaced groups, watched = %v", watched)
+	}
+	if err := first.DeleteGroup(groups[0].ID); err != nil {
+		t.Fatalf("DeleteGroup() error = %v", err)
+	}
+}
+
+func TestFileStoreReplaceGroupsAtRejectsStaleVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "groups.json")
+	first, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	_, version, err := first.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	version, err = first.ReplaceGroupsAt(version, []Group{{Name: "Банки", ObjectIDs: []int{7}}})
+	if err != nil {
+		t.Fatalf("ReplaceGroupsAt() error = %v", err)
+	}
+
+	second, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() second error = %v", err)
+	}
+	if _, err := second.ReplaceGroupsAt(version, []Group{{Name: "Школи та садочки", ObjectIDs: []int{8, 9}}}); err != nil {
+		t.Fatalf("ReplaceGroupsAt() on current version error = %v", err)
+	}
+	if _, err := first.ReplaceGroupsAt(version, []Group{{Name: "Аптеки", ObjectIDs: []int{10}}}); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("ReplaceGroupsAt() on stale version error = %v, want conflict", err)
+	}
+	groups, _, err := first.Snapshot()
+	if err != nil || len(groups) != 1 || groups[0].Name != "Школи та садочки" {
+		t.Fatalf("groups after conflict = %+v, %v", groups, err)
+	}
+}
@@ -0,0 +1,20 @@
+package objectgroups
+
+import "fmt"
+
+// Label повертає назву групи для випадаючих списків; групи спостереження
+// позначено оком.
+func (g Group) Label() string {
+	if g.Watch {
+		return "👁 " + g.Name
+	}
+	return g.Name
+}
+
+// SummaryLabel стисло описує склад групи.
+func (g Group) SummaryLabel() string {
+	if g.IsQuery() {
+		return "фільтр: " + g.Query
+	}
+	return fmt.Sprintf("об'єктів: %d", len(g.ObjectIDs))
+}
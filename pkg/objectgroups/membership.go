@@ -0,0 +1,73 @@
+package objectgroups
+
+import (
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
+)
+
+// Membership — склад груп на певний момент: для кожної групи множина ID
+// об'єктів і об'єднання всіх груп спостереження. Nil-значення означає «груп немає».
+type Membership struct {
+	members map[string]map[int]struct{}
+	watched map[int]struct{}
+}
+
+// Resolve обчислює склад груп. Статичні списки беруться як є, групи-вирази
+// звіряються з objects; некоректний вираз дає порожню групу.
+func Resolve(groups []Group, objects []models.Object, now time.Time) *Membership {
+	m := &Membership{
+		members: make(map[string]map[int]struct{}, len(groups)),
+		watched: make(map[int]struct{}),
+	}
+	for _, group := range groups {
+		members := make(map[int]struct{})
+		if group.IsQuery() {
+			if query, err := usecases.ParseFilterQuery(group.Query); err == nil {
+				for _, object := range objects {
+					if query.MatchObject(object, now) {
+						members[object.ID] = struct{}{}
+					}
+				}
+			}
+		} else {
+			for _, id := range group.ObjectIDs {
+				members[id] = struct{}{}
+			}
+		}
+		m.members[group.ID] = members
+		if group.Watch {
+			for id := range members {
+				m.watched[id] = struct{}{}
+			}
+		}
+	}
+	return m
+}
+
+// Members повертає ID об'єктів групи. Порожній або невідомий groupID
+// (групу вже видалено) дає nil — фільтр за групою не застосовується.
+func (m *Membership) Members(groupID string) map[int]struct{} {
+	if m == nil || groupID == "" {
+		return nil
+	}
+	return m.members[groupID]
+}
+
+// Watched повертає ID об'єктів з усіх груп спостереження.
+func (m *Membership) Watched() map[int]struct{} {
+	if m == nil {
+		return nil
+	}
+	return m.watched
+}
+
+// IsWatched повідомляє, чи входить об'єкт до якоїсь групи спостереження.
+func (m *Membership) IsWatched(objectID int) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.watched[objectID]
+	return ok
+}
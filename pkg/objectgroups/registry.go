@@ -0,0 +1,97 @@
+package objectgroups
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// membershipTTL — як довго обчислений склад груп вважається актуальним.
+// Групи-вирази залежать від стану об'єктів (status:, lasttest>), тож склад
+// перераховується періодично, а не лише після зміни файлу.
+const membershipTTL = 10 * time.Second
+
+// Registry поєднує сховище груп зі списком об'єктів джерел і кешує склад
+// груп для панелей. Nil-реєстр поводиться як реєстр без груп.
+type Registry struct {
+	store   *FileStore
+	objects func() []models.Object
+
+	mu         sync.Mutex
+	membership *Membership
+	revision   uint64
+	builtAt    time.Time
+}
+
+// NewRegistry створює реєстр; objects повертає поточні об'єкти всіх джерел.
+func NewRegistry(store *FileStore, objects func() []models.Object) *Registry {
+	return &Registry{store: store, objects: objects}
+}
+
+// Store повертає сховище груп для діалогу керування.
+func (r *Registry) Store() *FileStore {
+	if r == nil {
+		return nil
+	}
+	return r.store
+}
+
+// Groups повертає групи; помилку читання файлу записує в журнал.
+func (r *Registry) Groups() []Group {
+	if r == nil || r.store == nil {
+		return nil
+	}
+	groups, err := r.store.Groups()
+	if err != nil {
+		log.Warn().Err(err).Str("path", r.store.Path()).Msg("Групи об'єктів: не вдалося прочитати файл")
+		return nil
+	}
+	return groups
+}
+
+// Membership повертає склад груп, перераховуючи його після зміни груп
+// або коли минув membershipTTL.
+func (r *Registry) Membership() *Membership {
+	if r == nil || r.store == nil {
+		return nil
+	}
+	revision := r.store.Revision()
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.membership != nil && r.revision == revision && now.Sub(r.builtAt) < membershipTTL {
+		return r.membership
+	}
+	groups := r.Groups()
+	var objects []models.Object
+	if r.objects != nil && hasQueryGroups(groups) {
+		objects = r.objects()
+	}
+	r.membership = Resolve(groups, objects, now)
+	r.revision = revision
+	r.builtAt = now
+	return r.membership
+}
+
+// Invalidate змушує наступний Membership перерахувати склад, наприклад
+// після оновлення списку об'єктів.
+func (r *Registry) Invalidate() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.membership = nil
+}
+
+func hasQueryGroups(groups []Group) bool {
+	for _, group := range groups {
+		if group.IsQuery() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+package objectgroups
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrVersionConflict означає, що список груп змінився після того, як клієнт його прочитав.
+var ErrVersionConflict = errors.New("objectgroups: groups were changed by another client")
+
+type storeFile struct {
+	Groups []Group `json:"groups"`
+}
+
+// FileStore зберігає групи об'єктів в одному JSON-файлі. Як і файл вікон
+// обслуговування, він перечитується після змін іншим процесом, тож групи
+// можна ділити між робочими місцями і сервером через спільну теку.
+// Порожній шлях — сховище лише в пам'яті.
+type FileStore struct {
+	path string
+
+	mu       sync.Mutex
+	state    storeFile
+	modTime  time.Time
+	size     int64
+	revision uint64
+}
+
+// OpenFileStore відкриває (або створює при першому записі) файл груп.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: strings.TrimSpace(path)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path повертає шлях до файлу сховища.
+func (s *FileStore) Path() string {
+	if s == nil {
+		return ""
+	}
+	return s.path
+}
+
+// Revision зростає з кожною зміною груп — своєю чи перечитаною з файлу.
+func (s *FileStore) Revision() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.reloadLocked()
+	return s.revision
+}
+
+// Groups повертає всі групи, впорядковані за назвою.
+func (s *FileStore) Groups() ([]Group, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return sortedGroups(s.state.Groups), nil
+}
+
+// SaveGroup додає нову групу (порожній ID) або замінює існуючу.
+func (s *FileStore) SaveGroup(group Group) (Group, error) {
+	if s == nil {
+		return Group{}, errors.New("objectgroups: store is nil")
+	}
+	group = group.normalize()
+	if err := group.Validate(); err != nil {
+		return Group{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return Group{}, err
+	}
+	group.UpdatedAt = time.Now()
+	groups := slices.Clone(s.state.Groups)
+	if group.ID == "" {
+		group.ID = newGroupID()
+		groups = append(groups, group)
+	} else {
+		index := slices.IndexFunc(groups, func(existing Group) bool { return existing.ID == group.ID })
+		if index < 0 {
+			return Group{}, fmt.Errorf("групу %s не знайдено", group.ID)
+		}
+		groups[index] = group
+	}
+	if err := ValidateGroups(groups); err != nil {
+		return Group{}, err
+	}
+	s.state.Groups = groups
+	return group, s.saveLocked()
+}
+
+// DeleteGroup видаляє групу.
+func (s *FileStore) DeleteGroup(id string) error {
+	if s == nil {
+		return errors.New("objectgroups: store is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	before := len(s.state.Groups)
+	s.state.Groups = slices.DeleteFunc(s.state.Groups, func(group Group) bool { return group.ID == id })
+	if len(s.state.Groups) == before {
+		return fmt.Errorf("групу %s не знайдено", id)
+	}
+	return s.saveLocked()
+}
+
+// Snapshot повертає групи разом з версією їх вмісту для ReplaceGroupsAt.
+func (s *FileStore) Snapshot() ([]Group, string, error) {
+	if s == nil {
+		return nil, "", nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, "", err
+	}
+	groups := sortedGroups(s.state.Groups)
+	return groups, groupsVersion(groups), nil
+}
+
+// ReplaceGroups замінює весь список груп, наприклад з API сервера.
+// Групам без ID призначаються нові.
+func (s *FileStore) ReplaceGroups(groups []Group) error {
+	_, err := s.ReplaceGroupsAt("", groups)
+	return err
+}
+
+// ReplaceGroupsAt замінює список груп, лише якщо його поточна версія дорівнює
+// version (порожня версія — без перевірки), і повертає нову версію. Версія
+// залежить тільки від вмісту, тож зміна з іншого робочого місця через спільний
+// файл теж дає ErrVersionConflict.
+func (s *FileStore) ReplaceGroupsAt(version string, groups []Group) (string, error) {
+	if s == nil {
+		return "", errors.New("objectgroups: store is nil")
+	}
+	next := make([]Group, 0, len(groups))
+	now := time.Now()
+	for _, group := range groups {
+		group = group.normalize()
+		if group.ID == "" {
+			group.ID = newGroupID()
+		}
+		if group.UpdatedAt.IsZero() {
+			group.UpdatedAt = now
+		}
+		next = append(next, group)
+	}
+	if err := ValidateGroups(next); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version != "" {
+		if err := s.reloadLocked(); err != nil {
+			return "", err
+		}
+		if groupsVersion(sortedGroups(s.state.Groups)) != version {
+			return "", ErrVersionConflict
+		}
+	}
+	s.state.Groups = next
+	if err := s.saveLocked(); err != nil {
+		return "", err
+	}
+	return groupsVersion(sortedGroups(next)), nil
+}
+
+// groupsVersion — короткий хеш вмісту впорядкованого списку груп.
+func groupsVersion(groups []Group) string {
+	payload, err := json.Marshal(groups)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:8])
+}
+
+func sortedGroups(groups []Group) []Group {
+	result := slices.Clone(groups)
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result
+}
+
+func (s *FileStore) reloadLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("objectgroups: stat %s: %w", s.path, err)
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("objectgroups: read %s: %w", s.path, err)
+	}
+	var state storeFile
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return fmt.Errorf("objectgroups: decode %s: %w", s.path, err)
+		}
+	}
+	s.state = state
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	s.revision++
+	return nil
+}
+
+func (s *FileStore) saveLocked() error {
+	s.revision++
+	if s.path == "" {
+		return nil
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("objectgroups: encode groups: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("objectgroups: create %s: %w", dir, err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o600); err != nil {
+		return fmt.Errorf("objectgroups: write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("objectgroups: replace %s: %w", s.path, err)
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+	return nil
+}
+
+func newGroupID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("grp-%d", time.Now().UnixNano())
+	}
+	return "grp-" + hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,490 @@
+package objectmigrate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/caslobject"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// Endpoint reads and writes objects of one source through its admin contracts.
+type Endpoint interface {
+	Source() contracts.FrontendSource
+	// Reads lists fields Load fills in.
+	Reads() []Field
+	// Writes lists fields Create stores and Load reads back for verification.
+	Writes() []Field
+	Load(ctx context.Context, catalog models.Object) (Object, error)
+	// Create stores object under object.Number and returns its catalogue reference.
+	Create(ctx context.Context, object Object) (models.Object, error)
+	Decommission(ctx context.Context, object Object) error
+}
+
+// decommissionChecker is implemented by endpoints whose admin API cannot
+// delete objects, so a decommissioning run is rejected before it starts.
+type decommissionChecker interface {
+	CanDecommission() bool
+}
+
+func canDecommission(endpoint Endpoint) bool {
+	checker, ok := endpoint.(decommissionChecker)
+	return !ok || checker.CanDecommission()
+}
+
+// BridgeDefaults are MIST card values that other sources do not have.
+type BridgeDefaults struct {
+	ObjectTypeID int64
+	RegionID     int64
+	PPKID        int64
+	ChannelCode  int64
+}
+
+// CASLDefaults are CASL device and room values that other sources do not have.
+type CASLDefaults struct {
+	DeviceType     string
+	DeviceTimeout  int64
+	LineType       string
+	RoomName       string
+	ReactingPultID string
+	UserRole       string
+}
+
+// MIST admin services used by the bridge endpoint.
+type bridgeAdmin interface {
+	contracts.AdminObjectCardService
+	contracts.AdminObjectZoneService
+	contracts.AdminObjectPersonalService
+	contracts.AdminObjectCoordinatesService
+}
+
+type bridgeEndpoint struct {
+	admin    bridgeAdmin
+	defaults BridgeDefaults
+}
+
+// NewBridgeEndpoint creates an endpoint over the MIST admin provider.
+func NewBridgeEndpoint(admin contracts.AdminObjectDialogProvider, defaults BridgeDefaults) Endpoint {
+	return &bridgeEndpoint{admin: admin, defaults: defaults}
+}
+
+func (e *bridgeEndpoint) Source() contracts.FrontendSource { return contracts.FrontendSourceBridge }
+
+func (e *bridgeEndpoint) Reads() []Field { return AllFields }
+
+func (e *bridgeEndpoint) Writes() []Field { return AllFields }
+
+func (e *bridgeEndpoint) Load(_ context.Context, catalog models.Object) (Object, error) {
+	objn := int64(catalog.ID)
+	card, err := e.admin.GetObjectCard(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("object card: %w", err)
+	}
+	zones, err := e.admin.ListObjectZones(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("object zones: %w", err)
+	}
+	personals, err := e.admin.ListObjectPersonals(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("object personals: %w", err)
+	}
+	coords, err := e.admin.GetObjectCoordinates(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("object coordinates: %w", err)
+	}
+	object := Object{
+		Source:    contracts.FrontendSourceBridge,
+		ID:        objn,
+		Number:    strconv.FormatInt(card.ObjN, 10),
+		Name:      card.ShortName,
+		Address:   card.Address,
+		Phones:    card.Phones,
+		Contract:  card.Contract,
+		Notes:     card.Notes,
+		Location:  card.Location,
+		SIM1:      card.GSMPhone1,
+		SIM2:      card.GSMPhone2,
+		Latitude:  coords.Latitude,
+		Longitude: coords.Longitude,
+		Zones:     zonesFromAdmin(zones),
+		Contacts:  contactsFromAdmin(personals),
+	}
+	return object, nil
+}
+
+func (e *bridgeEndpoint) Create(_ context.Context, object Object) (models.Object, error) {
+	objn, err := strconv.ParseInt(strings.TrimSpace(object.Number), 10, 64)
+	if err != nil || objn <= 0 {
+		return models.Object{}, fmt.Errorf("invalid MIST object number %q", object.Number)
+	}
+	card := contracts.AdminObjectCard{
+		ObjN:        objn,
+		ShortName:   object.Name,
+		FullName:    object.Name,
+		ObjTypeID:   e.defaults.ObjectTypeID,
+		ObjRegID:    e.defaults.RegionID,
+		Address:     object.Address,
+		Phones:      object.Phones,
+		Contract:    object.Contract,
+		Location:    object.Location,
+		Notes:       object.Notes,
+		ChannelCode: e.defaults.ChannelCode,
+		PPKID:       e.defaults.PPKID,
+		GSMPhone1:   object.SIM1,
+		GSMPhone2:   object.SIM2,
+	}
+	if err := e.admin.CreateObject(card); err != nil {
+		return models.Object{}, fmt.Errorf("create object: %w", err)
+	}
+	for _, zone := range object.Zones {
+		if err := e.admin.AddObjectZone(objn, contracts.AdminObjectZone{ZoneNumber: zone.Number, Description: ZoneDescription(zone)}); err != nil {
+			return models.Object{}, fmt.Errorf("zone #%d: %w", zone.Number, err)
+		}
+	}
+	for i, contact := range object.Contacts {
+		if err := e.admin.AddObjectPersonal(objn, personalFromContact(contact, i)); err != nil {
+			return models.Object{}, fmt.Errorf("contact %q: %w", contact.FullName(), err)
+		}
+	}
+	if object.Value(FieldCoordinates) != "" {
+		if err := e.admin.SaveObjectCoordinates(objn, contracts.AdminObjectCoordinates{Latitude: object.Latitude, Longitude: object.Longitude}); err != nil {
+			return models.Object{}, fmt.Errorf("coordinates: %w", err)
+		}
+	}
+	return models.Object{ID: int(objn), DisplayNumber: strconv.FormatInt(objn, 10)}, nil
+}
+
+func (e *bridgeEndpoint) Decommission(_ context.Context, object Object) error {
+	return e.admin.DeleteObject(object.ID)
+}
+
+type phoenixEndpoint struct {
+	admin contracts.PhoenixObjectAdminProvider
+}
+
+// NewPhoenixEndpoint creates an endpoint over the Phoenix panel administration.
+// SIM numbers and coordinates are read from the catalogue but cannot be written.
+func NewPhoenixEndpoint(admin contracts.PhoenixObjectAdminProvider) Endpoint {
+	return &phoenixEndpoint{admin: admin}
+}
+
+func (e *phoenixEndpoint) Source() contracts.FrontendSource { return contracts.FrontendSourcePhoenix }
+
+func (e *phoenixEndpoint) Reads() []Field { return AllFields }
+
+func (e *phoenixEndpoint) Writes() []Field {
+	return []Field{FieldName, FieldAddress, FieldPhones, FieldNotes, FieldLocation, FieldZones, FieldContacts}
+}
+
+func (e *phoenixEndpoint) Load(_ context.Context, catalog models.Object) (Object, error) {
+	objn := int64(catalog.ID)
+	card, err := e.admin.GetObjectCard(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("panel card: %w", err)
+	}
+	zones, err := e.admin.ListObjectZones(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("panel zones: %w", err)
+	}
+	personals, err := e.admin.ListObjectPersonals(objn)
+	if err != nil {
+		return Object{}, fmt.Errorf("panel responsibles: %w", err)
+	}
+	return Object{
+		Source:    contracts.FrontendSourcePhoenix,
+		ID:        objn,
+		Number:    card.PanelID,
+		Name:      cmp.Or(strings.TrimSpace(card.ShortName), card.FullName),
+		Address:   card.Address,
+		Phones:    card.Phones,
+		Notes:     card.Notes,
+		Location:  card.Location,
+		SIM1:      catalog.SIM1,
+		SIM2:      catalog.SIM2,
+		Latitude:  catalog.Latitude,
+		Longitude: catalog.Longitude,
+		Zones:     zonesFromAdmin(zones),
+		Contacts:  contactsFromAdmin(personals),
+	}, nil
+}
+
+func (e *phoenixEndpoint) Create(_ context.Context, object Object) (models.Object, error) {
+	panelID := strings.ToUpper(strings.TrimSpace(object.Number))
+	if err := e.admin.CreateObject(contracts.AdminObjectCard{
+		PanelID:   panelID,
+		ShortName: object.Name,
+		FullName:  object.Name,
+		Address:   object.Address,
+		Phones:    object.Phones,
+		Notes:     object.Notes,
+		Location:  object.Location,
+	}); err != nil {
+		return models.Object{}, fmt.Errorf("create panel: %w", err)
+	}
+	objn := e.admin.PanelObjectID(panelID)
+	if objn <= 0 {
+		return models.Object{}, fmt.Errorf("panel %s is not registered after create", panelID)
+	}
+	for _, zone := range object.Zones {
+		if err := e.admin.AddObjectZone(objn, contracts.AdminObjectZone{ZoneNumber: zone.Number, Description: ZoneDescription(zone)}); err != nil {
+			return models.Object{}, fmt.Errorf("zone #%d: %w", zone.Number, err)
+		}
+	}
+	for i, contact := range object.Contacts {
+		if err := e.admin.AddObjectPersonal(objn, personalFromContact(contact, i)); err != nil {
+			return models.Object{}, fmt.Errorf("responsible %q: %w", contact.FullName(), err)
+		}
+	}
+	return models.Object{ID: int(objn), DisplayNumber: panelID}, nil
+}
+
+// CanDecommission is false: Phoenix panels are deleted only in the Phoenix client.
+func (e *phoenixEndpoint) CanDecommission() bool { return false }
+
+func (e *phoenixEndpoint) Decommission(_ context.Context, object Object) error {
+	return e.admin.DeleteObject(object.ID)
+}
+
+type caslEndpoint struct {
+	editor   contracts.CASLObjectEditorProvider
+	defaults CASLDefaults
+}
+
+// NewCASLEndpoint creates an endpoint over the CASL object editor. Zones become
+// device lines and contacts become users of a single room.
+func NewCASLEndpoint(editor contracts.CASLObjectEditorProvider, defaults CASLDefaults) Endpoint {
+	defaults.DeviceType = cmp.Or(strings.TrimSpace(defaults.DeviceType), "TYPE_DEVICE_CASL")
+	defaults.LineType = cmp.Or(strings.TrimSpace(defaults.LineType), "NORMAL")
+	defaults.RoomName = cmp.Or(strings.TrimSpace(defaults.RoomName), "Головна")
+	defaults.UserRole = cmp.Or(strings.TrimSpace(defaults.UserRole), "IN_CHARGE")
+	return &caslEndpoint{editor: editor, defaults: defaults}
+}
+
+func (e *caslEndpoint) Source() contracts.FrontendSource { return contracts.FrontendSourceCASL }
+
+func (e *caslEndpoint) Reads() []Field { return e.Writes() }
+
+func (e *caslEndpoint) Writes() []Field {
+	return []Field{FieldName, FieldAddress, FieldContract, FieldNotes, FieldLocation, FieldSIM1, FieldSIM2, FieldCoordinates, FieldZones, FieldContacts}
+}
+
+func (e *caslEndpoint) Load(ctx context.Context, catalog models.Object) (Object, error) {
+	snapshot, err := e.editor.GetCASLObjectEditorSnapshot(ctx, int64(catalog.ID))
+	if err != nil {
+		return Object{}, fmt.Errorf("casl object: %w", err)
+	}
+	object := snapshot.Object
+	result := Object{
+		Source:    contracts.FrontendSourceCASL,
+		ID:        int64(catalog.ID),
+		Number:    strconv.FormatInt(object.Device.Number, 10),
+		Name:      object.Name,
+		Address:   object.Address,
+		Contract:  object.Contract,
+		Notes:     object.Note,
+		Location:  object.Description,
+		SIM1:      object.Device.SIM1,
+		SIM2:      object.Device.SIM2,
+		Latitude:  object.Lat,
+		Longitude: object.Long,
+	}
+	for _, line := range object.Device.Lines {
+		result.Zones = append(result.Zones, Zone{Number: int64(line.LineNumber), Description: line.Description})
+	}
+	result.Contacts = caslRoomContacts(object.Rooms, snapshot.Users)
+	return result, nil
+}
+
+func (e *caslEndpoint) Create(ctx context.Context, object Object) (models.Object, error) {
+	deviceNumber, err := strconv.ParseInt(strings.TrimSpace(object.Number), 10, 64)
+	if err != nil || deviceNumber <= 0 {
+		return models.Object{}, fmt.Errorf("invalid CASL device number %q", object.Number)
+	}
+	users, err := e.resolveUsers(ctx, object.Contacts)
+	if err != nil {
+		return models.Object{}, err
+	}
+	room := contracts.CASLRoomDetails{Name: e.defaults.RoomName, Users: users}
+	device := contracts.CASLDeviceDetails{
+		Number:  deviceNumber,
+		Name:    object.Name,
+		Type:    e.defaults.DeviceType,
+		Timeout: e.defaults.DeviceTimeout,
+		SIM1:    object.SIM1,
+		SIM2:    object.SIM2,
+	}
+	for _, zone := range object.Zones {
+		device.Lines = append(device.Lines, contracts.CASLDeviceLineDetails{
+			LineNumber:  int(zone.Number),
+			GroupNumber: 1,
+			AdapterType: "SYS",
+			Description: ZoneDescription(zone),
+			LineType:    e.defaults.LineType,
+		})
+		room.Lines = append(room.Lines, contracts.CASLRoomLineLink{LineNumber: int(zone.Number), AdapterType: "SYS", GroupNumber: 1})
+	}
+	_, objectID, err := caslobject.CreateDraft(ctx, e.editor, contracts.CASLObjectEditorSnapshot{
+		Object: contracts.CASLGuardObjectDetails{
+			Name:           object.Name,
+			Address:        object.Address,
+			Lat:            object.Latitude,
+			Long:           object.Longitude,
+			Description:    object.Location,
+			Contract:       object.Contract,
+			Note:           object.Notes,
+			ReactingPultID: e.defaults.ReactingPultID,
+			Rooms:          []contracts.CASLRoomDetails{room},
+			Device:         device,
+		},
+	})
+	// On partial failure the ID still points the report at the object left behind.
+	return models.Object{ID: int(objectID), DisplayNumber: object.Number}, err
+}
+
+// resolveUsers links contacts to existing CASL users by phone and creates the missing ones.
+func (e *caslEndpoint) resolveUsers(ctx context.Context, contacts []Contact) ([]contracts.CASLRoomUserLink, error) {
+	if len(contacts) == 0 {
+		return nil, nil
+	}
+	references, err := e.editor.GetCASLObjectEditorSnapshot(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("casl users: %w", err)
+	}
+	links := make([]contracts.CASLRoomUserLink, 0, len(contacts))
+	for i, contact := range contacts {
+		userID := matchCASLUser(references.Users, contact)
+		if userID == "" {
+			request, err := caslUserRequest(contact, e.defaults.UserRole)
+			if err != nil {
+				return nil, fmt.Errorf("contact %q: %w", contact.FullName(), err)
+			}
+			created, err := e.editor.CreateCASLUser(ctx, request)
+			if err != nil {
+				return nil, fmt.Errorf("create user %q: %w", contact.FullName(), err)
+			}
+			references.Users = append(references.Users, created)
+			userID = created.UserID
+		}
+		links = append(links, contracts.CASLRoomUserLink{UserID: userID, Priority: i + 1})
+	}
+	return links, nil
+}
+
+func (e *caslEndpoint) Decommission(ctx context.Context, object Object) error {
+	return e.editor.DeleteCASLObject(ctx, object.ID)
+}
+
+func zonesFromAdmin(items []contracts.AdminObjectZone) []Zone {
+	zones := make([]Zone, 0, len(items))
+	for _, item := range items {
+		zones = append(zones, Zone{Number: item.ZoneNumber, Description: item.Description})
+	}
+	return zones
+}
+
+func contactsFromAdmin(items []contracts.AdminObjectPersonal) []Contact {
+	items = slices.Clone(items)
+	slices.SortStableFunc(items, func(a, b contracts.AdminObjectPersonal) int { return cmp.Compare(a.Number, b.Number) })
+	contacts := make([]Contact, 0, len(items))
+	for _, item := range items {
+		contacts = append(contacts, Contact{
+			Surname:  item.Surname,
+			Name:     item.Name,
+			SecName:  item.SecName,
+			Phones:   item.Phones,
+			Position: item.Position,
+		})
+	}
+	return contacts
+}
+
+func personalFromContact(contact Contact, index int) contracts.AdminObjectPersonal {
+	return contracts.AdminObjectPersonal{
+		Number:   int64(index + 1),
+		Surname:  strings.TrimSpace(contact.Surname),
+		Name:     strings.TrimSpace(contact.Name),
+		SecName:  strings.TrimSpace(contact.SecName),
+		Phones:   strings.Join(SplitPhones(contact.Phones), ", "),
+		Position: strings.TrimSpace(contact.Position),
+	}
+}
+
+// caslRoomContacts returns room users in priority order without duplicates.
+func caslRoomContacts(rooms []contracts.CASLRoomDetails, users []contracts.CASLUserProfile) []Contact {
+	profiles := make(map[string]contracts.CASLUserProfile, len(users))
+	for _, user := range users {
+		profiles[user.UserID] = user
+	}
+	var links []contracts.CASLRoomUserLink
+	for _, room := range rooms {
+		links = append(links, room.Users...)
+	}
+	slices.SortStableFunc(links, func(a, b contracts.CASLRoomUserLink) int { return cmp.Compare(a.Priority, b.Priority) })
+	seen := make(map[string]bool, len(links))
+	var contacts []Contact
+	for _, link := range links {
+		if seen[link.UserID] {
+			continue
+		}
+		seen[link.UserID] = true
+		profile, ok := profiles[link.UserID]
+		if !ok {
+			continue
+		}
+		phones := make([]string, 0, len(profile.PhoneNumbers))
+		for _, phone := range profile.PhoneNumbers {
+			if number := strings.TrimSpace(phone.Number); number != "" {
+				phones = append(phones, number)
+			}
+		}
+		contacts = append(contacts, Contact{
+			Surname: profile.LastName,
+			Name:    profile.FirstName,
+			SecName: profile.MiddleName,
+			Phones:  strings.Join(phones, ", "),
+		})
+	}
+	return contacts
+}
+
+// matchCASLUser finds a CASL user sharing a phone number with the contact.
+func matchCASLUser(users []contracts.CASLUserProfile, contact Contact) string {
+	keys := phoneKeys(contact.Phones)
+	if len(keys) == 0 {
+		return ""
+	}
+	for _, user := range users {
+		for _, phone := range user.PhoneNumbers {
+			if key := phoneKey(phone.Number); key != "" && slices.Contains(keys, key) {
+				return user.UserID
+			}
+		}
+	}
+	return ""
+}
+
+func caslUserRequest(contact Contact, role string) (contracts.CASLUserCreateRequest, error) {
+	request := contracts.CASLUserCreateRequest{
+		LastName:   strings.TrimSpace(contact.Surname),
+		FirstName:  strings.TrimSpace(contact.Name),
+		MiddleName: strings.TrimSpace(contact.SecName),
+		Role:       role,
+	}
+	if request.LastName == "" && request.FirstName == "" {
+		return contracts.CASLUserCreateRequest{}, errors.New("name is empty")
+	}
+	for _, phone := range SplitPhones(contact.Phones) {
+		number, err := caslobject.NormalizeUAPhone(phone)
+		if err != nil {
+			return contracts.CASLUserCreateRequest{}, fmt.Errorf("phone %q: %w", phone, err)
+		}
+		request.PhoneNumbers = append(request.PhoneNumbers, contracts.CASLPhoneNumber{Active: len(request.PhoneNumbers) == 0, Number: number})
+	}
+	return request, nil
+}
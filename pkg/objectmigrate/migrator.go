@@ -0,0 +1,272 @@
+package objectmigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// ObjectProvider is the catalogue used to resolve object numbers.
+type ObjectProvider interface {
+	GetObjects() []models.Object
+}
+
+// Options controls a migration run.
+type Options struct {
+	// DryRun only builds the plan: nothing is created or decommissioned.
+	DryRun bool
+	// Decommission removes the source object once the target passed verification.
+	Decommission bool
+	// Force decommissions the source even when the target cannot store some of
+	// its fields; those values are lost.
+	Force bool
+}
+
+// Item selects one object to migrate.
+type Item struct {
+	Number string
+	// TargetNumber overrides DefaultTargetNumber.
+	TargetNumber string
+}
+
+// Status is the outcome of one migrated object.
+type Status string
+
+const (
+	StatusPlanned        Status = "planned"
+	StatusConflict       Status = "conflict"
+	StatusUpToDate       Status = "up-to-date"
+	StatusMigrated       Status = "migrated"
+	StatusDecommissioned Status = "decommissioned"
+	// StatusSourceKept marks a migrated object whose source was not
+	// decommissioned because the target dropped some of its fields.
+	StatusSourceKept   Status = "source-kept"
+	StatusVerifyFailed Status = "verify-failed"
+	StatusFailed       Status = "failed"
+)
+
+// Result describes what happened to one object.
+type Result struct {
+	SourceNumber string     `json:"source_number"`
+	TargetNumber string     `json:"target_number,omitempty"`
+	SourceID     int64      `json:"source_id,omitempty"`
+	TargetID     int64      `json:"target_id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Status       Status     `json:"status"`
+	Conflicts    []Conflict `json:"conflicts,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// Migrator copies objects from one source to another.
+type Migrator struct {
+	catalog ObjectProvider
+	source  Endpoint
+	target  Endpoint
+	options Options
+}
+
+// New creates a migrator between two different sources.
+func New(catalog ObjectProvider, source, target Endpoint, opts Options) (*Migrator, error) {
+	if catalog == nil {
+		return nil, errors.New("object migrate: catalogue is not configured")
+	}
+	if source == nil || target == nil {
+		return nil, errors.New("object migrate: source and target must be configured")
+	}
+	if source.Source() == target.Source() {
+		return nil, fmt.Errorf("object migrate: source and target are both %s", source.Source())
+	}
+	if opts.Decommission && !canDecommission(source) {
+		return nil, fmt.Errorf("object migrate: %s objects cannot be decommissioned", source.Source())
+	}
+	return &Migrator{catalog: catalog, source: source, target: target, options: opts}, nil
+}
+
+// AllItems selects every catalogue object of the source.
+func (m *Migrator) AllItems() []Item {
+	var items []Item
+	for _, object := range m.catalog.GetObjects() {
+		if contracts.DetectFrontendSourceByObjectID(object.ID) == m.source.Source() {
+			items = append(items, Item{Number: object.DisplayNumber})
+		}
+	}
+	return items
+}
+
+// Run plans or migrates items in order; one failed object does not stop the rest.
+func (m *Migrator) Run(ctx context.Context, items []Item) []Result {
+	index := m.catalogIndex()
+	results := make([]Result, 0, len(items))
+	for _, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, m.migrate(ctx, index, item))
+	}
+	return results
+}
+
+func (m *Migrator) migrate(ctx context.Context, index map[catalogKey]models.Object, item Item) Result {
+	result := Result{SourceNumber: strings.TrimSpace(item.Number)}
+	fail := func(format string, args ...any) Result {
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	catalogObject, ok := index[newCatalogKey(m.source.Source(), item.Number)]
+	if !ok {
+		return fail("%s object %s not found", m.source.Source(), result.SourceNumber)
+	}
+	source, err := m.source.Load(ctx, catalogObject)
+	if err != nil {
+		return fail("load source: %v", err)
+	}
+	result.SourceID = source.ID
+	result.Name = source.Name
+
+	result.TargetNumber = strings.TrimSpace(item.TargetNumber)
+	if result.TargetNumber == "" {
+		result.TargetNumber = DefaultTargetNumber(m.source.Source(), m.target.Source(), catalogObject.DisplayNumber)
+	}
+	if result.TargetNumber == "" {
+		return fail("target number is required for %s -> %s", m.source.Source(), m.target.Source())
+	}
+	result.Conflicts = Unsupported(source, m.target.Writes())
+	fields := SharedFields(m.source.Reads(), m.target.Writes())
+
+	if existing, ok := index[newCatalogKey(m.target.Source(), result.TargetNumber)]; ok {
+		target, err := m.target.Load(ctx, existing)
+		if err != nil {
+			return fail("load existing target: %v", err)
+		}
+		result.TargetID = target.ID
+		if diffs := Compare(source, target, fields); len(diffs) > 0 {
+			result.Conflicts = append(result.Conflicts, diffs...)
+			result.Status = StatusConflict
+			return result
+		}
+		result.Status = StatusUpToDate
+		return m.decommission(ctx, source, result)
+	}
+	if m.options.DryRun {
+		result.Status = StatusPlanned
+		return result
+	}
+
+	draft := source
+	draft.Number = result.TargetNumber
+	created, err := m.target.Create(ctx, draft)
+	result.TargetID = int64(created.ID)
+	if err != nil {
+		return fail("create target: %v", err)
+	}
+	target, err := m.target.Load(ctx, created)
+	if err != nil {
+		return fail("reload target: %v", err)
+	}
+	if diffs := Compare(source, target, fields); len(diffs) > 0 {
+		result.Conflicts = append(result.Conflicts, diffs...)
+		result.Status = StatusVerifyFailed
+		return result
+	}
+	result.Status = StatusMigrated
+	return m.decommission(ctx, source, result)
+}
+
+// decommission removes a verified source object when requested. Values the
+// target cannot store would be lost, so the source is kept unless forced.
+func (m *Migrator) decommission(ctx context.Context, source Object, result Result) Result {
+	if !m.options.Decommission || m.options.DryRun {
+		return result
+	}
+	if lost := unsupportedFields(result.Conflicts); len(lost) > 0 && !m.options.Force {
+		result.Status = StatusSourceKept
+		result.Error = fmt.Sprintf("source kept: %s not stored by target", strings.Join(fieldNames(lost), ", "))
+		return result
+	}
+	if err := m.source.Decommission(ctx, source); err != nil {
+		result.Error = fmt.Sprintf("decommission source: %v", err)
+		return result
+	}
+	result.Status = StatusDecommissioned
+	return result
+}
+
+func unsupportedFields(conflicts []Conflict) []Field {
+	var fields []Field
+	for _, conflict := range conflicts {
+		if conflict.Reason == ReasonUnsupported {
+			fields = append(fields, conflict.Field)
+		}
+	}
+	return fields
+}
+
+func fieldNames(fields []Field) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, string(field))
+	}
+	return names
+}
+
+type catalogKey struct {
+	source contracts.FrontendSource
+	number string
+}
+
+func newCatalogKey(source contracts.FrontendSource, number string) catalogKey {
+	return catalogKey{source: source, number: strings.ToUpper(strings.TrimSpace(number))}
+}
+
+func (m *Migrator) catalogIndex() map[catalogKey]models.Object {
+	objects := m.catalog.GetObjects()
+	index := make(map[catalogKey]models.Object, len(objects))
+	for _, object := range objects {
+		key := newCatalogKey(contracts.DetectFrontendSourceByObjectID(object.ID), object.DisplayNumber)
+		if _, exists := index[key]; !exists {
+			index[key] = object
+		}
+	}
+	return index
+}
+
+// DefaultTargetNumber maps an object number between sources using the ppk_num
+// ranges shared with casl-bridge. MIST and Phoenix numbers are unrelated, so
+// migrations between them need an explicit target number.
+func DefaultTargetNumber(from, to contracts.FrontendSource, number string) string {
+	number = strings.TrimSpace(number)
+	switch {
+	case to == contracts.FrontendSourceCASL && from == contracts.FrontendSourceBridge:
+		if n, ok := ids.BridgePPKNum(number); ok {
+			return strconv.Itoa(n)
+		}
+	case to == contracts.FrontendSourceCASL && from == contracts.FrontendSourcePhoenix:
+		if n, ok := ids.PhoenixPPKNum(number); ok {
+			return strconv.Itoa(n)
+		}
+	case from == contracts.FrontendSourceCASL:
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return ""
+		}
+		if to == contracts.FrontendSourceBridge {
+			if objectNumber, ok := ids.BridgeObjectNumber(n); ok {
+				return objectNumber
+			}
+		}
+		if to == contracts.FrontendSourcePhoenix {
+			if panelID, ok := ids.PhoenixPanelID(n); ok {
+				return panelID
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,351 @@
+package objectmigrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type fakeCatalog struct {
+	objects []models.Object
+}
+
+func (c *fakeCatalog) GetObjects() []models.Object {
+	return c.objects
+}
+
+type fakeEndpoint struct {
+	source  contracts.FrontendSource
+	writes  []Field
+	catalog *fakeCatalog
+	objects map[int]Object
+	nextID  int
+	// mutate змінює об'єкт при створенні, щоб імітувати втрату даних цільовим джерелом.
+	mutate         func(*Object)
+	created        []Object
+	decommissioned []string
+}
+
+func newFakeEndpoint(source contracts.FrontendSource, catalog *fakeCatalog, firstID int, writes ...Field) *fakeEndpoint {
+	if len(writes) == 0 {
+		writes = AllFields
+	}
+	return &fakeEndpoint{source: source, writes: writes, catalog: catalog, objects: make(map[int]Object), nextID: firstID}
+}
+
+func (e *fakeEndpoint) add(object Object) {
+	object.Source = e.source
+	object.ID = int64(e.nextID)
+	e.objects[e.nextID] = object
+	e.catalog.objects = append(e.catalog.objects, models.Object{ID: e.nextID, DisplayNumber: object.Number, Name: object.Name})
+	e.nextID++
+}
+
+func (e *fakeEndpoint) Source() contracts.FrontendSource { return e.source }
+func (e *fakeEndpoint) Reads() []Field                   { return AllFields }
+func (e *fakeEndpoint) Writes() []Field                  { return e.writes }
+
+func (e *fakeEndpoint) Load(_ context.Context, catalog models.Object) (Object, error) {
+	object, ok := e.objects[catalog.ID]
+	if !ok {
+		return Object{}, errors.New("not found")
+	}
+	return object, nil
+}
+
+func (e *fakeEndpoint) Create(_ context.Context, object Object) (models.Object, error) {
+	e.created = append(e.created, object)
+	stored := Object{Number: object.Number}
+	for _, field := range e.writes {
+		copyField(&stored, object, field)
+	}
+	if e.mutate != nil {
+		e.mutate(&stored)
+	}
+	e.add(stored)
+	return e.catalog.objects[len(e.catalog.objects)-1], nil
+}
+
+func (e *fakeEndpoint) Decommission(_ context.Context, object Object) error {
+	e.decommissioned = append(e.decommissioned, object.Number)
+	return nil
+}
+
+func copyField(dst *Object, src Object, field Field) {
+	switch field {
+	case FieldName:
+		dst.Name = src.Name
+	case FieldAddress:
+		dst.Address = src.Address
+	case FieldPhones:
+		dst.Phones = src.Phones
+	case FieldContract:
+		dst.Contract = src.Contract
+	case FieldNotes:
+		dst.Notes = src.Notes
+	case FieldLocation:
+		dst.Location = src.Location
+	case FieldSIM1:
+		dst.SIM1 = src.SIM1
+	case FieldSIM2:
+		dst.SIM2 = src.SIM2
+	case FieldCoordinates:
+		dst.Latitude, dst.Longitude = src.Latitude, src.Longitude
+	case FieldZones:
+		dst.Zones = src.Zones
+	case FieldContacts:
+		dst.Contacts = src.Contacts
+	}
+}
+
+func sampleObject(number string) Object {
+	return Object{
+		Number:    number,
+		Name:      "Магазин  Ромашка",
+		Address:   "вул. Шевченка, 1",
+		Phones:    "0501234567",
+		SIM1:      "+380671112233",
+		Latitude:  "50.45",
+		Longitude: "30.52",
+		Zones:     []Zone{{Number: 2, Description: "Двері"}, {Number: 1}},
+		Contacts:  []Contact{{Surname: "Петренко", Name: "Іван", Phones: "067 111 22 33"}},
+	}
+}
+
+func TestMigratorDryRunPlansWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	target := newFakeEndpoint(contracts.FrontendSourceCASL, catalog, ids.CASLObjectIDNamespaceStart, FieldName, FieldAddress, FieldZones)
+	source.add(sampleObject("1234"))
+
+	migrator, err := New(catalog, source, target, Options{DryRun: true, Decommission: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := migrator.Run(context.Background(), migrator.AllItems())
+	if len(results) != 1 {
+		t.Fatalf("results = %d, want 1", len(results))
+	}
+	result := results[0]
+	if result.Status != StatusPlanned || result.TargetNumber != "1234" {
+		t.Fatalf("result = %+v, want planned to 1234", result)
+	}
+	if len(target.created) != 0 || len(source.decommissioned) != 0 {
+		t.Fatalf("dry run changed data: created=%d decommissioned=%d", len(target.created), len(source.decommissioned))
+	}
+	unsupported := unsupportedFields(result.Conflicts)
+	want := []Field{FieldPhones, FieldSIM1, FieldCoordinates, FieldContacts}
+	if strings.Join(fieldNames(unsupported), ",") != strings.Join(fieldNames(want), ",") {
+		t.Fatalf("unsupported = %v, want %v", unsupported, want)
+	}
+}
+
+func TestMigratorMigratesVerifiesAndDecommissions(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceCASL, catalog, ids.CASLObjectIDNamespaceStart)
+	target := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	source.add(sampleObject("1234"))
+
+	migrator, err := New(catalog, source, target, Options{Decommission: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := migrator.Run(context.Background(), []Item{{Number: "1234", TargetNumber: "777"}})
+	if results[0].Status != StatusDecommissioned {
+		t.Fatalf("result = %+v, want decommissioned", results[0])
+	}
+	if len(target.created) != 1 || target.created[0].Number != "777" {
+		t.Fatalf("created = %+v, want one object 777", target.created)
+	}
+	if len(source.decommissioned) != 1 || source.decommissioned[0] != "1234" {
+		t.Fatalf("decommissioned = %v", source.decommissioned)
+	}
+}
+
+func TestMigratorKeepsSourceWhenTargetDropsFields(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	target := newFakeEndpoint(contracts.FrontendSourceCASL, catalog, ids.CASLObjectIDNamespaceStart,
+		FieldName, FieldAddress, FieldPhones, FieldZones, FieldContacts)
+	source.add(sampleObject("1234"))
+	source.add(sampleObject("1235"))
+
+	migrator, err := New(catalog, source, target, Options{Decommission: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	result := migrator.Run(context.Background(), []Item{{Number: "1234"}})[0]
+	if result.Status != StatusSourceKept {
+		t.Fatalf("result = %+v, want source-kept", result)
+	}
+	if !strings.Contains(result.Error, "sim1, coordinates") {
+		t.Fatalf("error = %q, want lost fields listed", result.Error)
+	}
+	if len(target.created) != 1 || len(source.decommissioned) != 0 {
+		t.Fatalf("created=%d decommissioned=%v, want target created and source kept", len(target.created), source.decommissioned)
+	}
+
+	forced, err := New(catalog, source, target, Options{Decommission: true, Force: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if result := forced.Run(context.Background(), []Item{{Number: "1235"}})[0]; result.Status != StatusDecommissioned {
+		t.Fatalf("forced result = %+v, want decommissioned", result)
+	}
+	if len(source.decommissioned) != 1 || source.decommissioned[0] != "1235" {
+		t.Fatalf("decommissioned = %v, want 1235", source.decommissioned)
+	}
+}
+
+func TestNewRejectsPhoenixDecommission(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	target := newFakeEndpoint(contracts.FrontendSourceCASL, catalog, ids.CASLObjectIDNamespaceStart)
+	if _, err := New(catalog, NewPhoenixEndpoint(nil), target, Options{Decommission: true}); err == nil {
+		t.Fatal("expected an error: Phoenix panels cannot be deleted")
+	}
+	if _, err := New(catalog, NewPhoenixEndpoint(nil), target, Options{}); err != nil {
+		t.Fatalf("migration without decommission: %v", err)
+	}
+}
+
+func TestMigratorReportsVerifyFailureAndKeepsSource(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	target := newFakeEndpoint(contracts.FrontendSourcePhoenix, catalog, ids.PhoenixObjectIDNamespaceStart)
+	target.mutate = func(object *Object) { object.Zones = object.Zones[:1] }
+	source.add(sampleObject("1234"))
+
+	migrator, err := New(catalog, source, target, Options{Decommission: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := migrator.Run(context.Background(), []Item{{Number: "1234", TargetNumber: "L00012"}})
+	result := results[0]
+	if result.Status != StatusVerifyFailed {
+		t.Fatalf("status = %s, want verify-failed", result.Status)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Field != FieldZones {
+		t.Fatalf("conflicts = %v, want zones", result.Conflicts)
+	}
+	if len(source.decommissioned) != 0 {
+		t.Fatalf("source was decommissioned after failed verification")
+	}
+}
+
+func TestMigratorExistingTarget(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	target := newFakeEndpoint(contracts.FrontendSourcePhoenix, catalog, ids.PhoenixObjectIDNamespaceStart)
+	source.add(sampleObject("1234"))
+	source.add(sampleObject("1235"))
+
+	same := sampleObject("L00001")
+	same.Phones = "+38 (050) 123-45-67"
+	same.Latitude = "50,450000"
+	target.add(same)
+	changed := sampleObject("L00002")
+	changed.Address = "вул. Франка, 2"
+	target.add(changed)
+
+	migrator, err := New(catalog, source, target, Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := migrator.Run(context.Background(), []Item{
+		{Number: "1234", TargetNumber: "l00001"},
+		{Number: "1235", TargetNumber: "L00002"},
+	})
+	if results[0].Status != StatusUpToDate {
+		t.Fatalf("first = %+v, want up-to-date", results[0])
+	}
+	if results[1].Status != StatusConflict || len(results[1].Conflicts) != 1 || results[1].Conflicts[0].Field != FieldAddress {
+		t.Fatalf("second = %+v, want address conflict", results[1])
+	}
+	if len(target.created) != 0 {
+		t.Fatalf("existing targets must not be recreated")
+	}
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, results); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+	if !strings.Contains(out.String(), "total: 2; up-to-date: 1, conflict: 1") {
+		t.Fatalf("report totals missing:\n%s", out.String())
+	}
+}
+
+func TestMigratorRequiresTargetNumberBetweenMISTAndPhoenix(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fakeCatalog{}
+	source := newFakeEndpoint(contracts.FrontendSourceBridge, catalog, 1)
+	target := newFakeEndpoint(contracts.FrontendSourcePhoenix, catalog, ids.PhoenixObjectIDNamespaceStart)
+	source.add(sampleObject("1234"))
+
+	migrator, err := New(catalog, source, target, Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := migrator.Run(context.Background(), []Item{{Number: "1234"}, {Number: "9999"}})
+	for _, result := range results {
+		if result.Status != StatusFailed || result.Error == "" {
+			t.Fatalf("result = %+v, want failed", result)
+		}
+	}
+	if _, err := New(catalog, source, source, Options{}); err == nil {
+		t.Fatalf("New accepted identical sources")
+	}
+}
+
+func TestDefaultTargetNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to contracts.FrontendSource
+		number   string
+		want     string
+	}{
+		{contracts.FrontendSourceBridge, contracts.FrontendSourceCASL, "1234", "1234"},
+		{contracts.FrontendSourcePhoenix, contracts.FrontendSourceCASL, "L00022", "100022"},
+		{contracts.FrontendSourceCASL, contracts.FrontendSourcePhoenix, "100022", "L00022"},
+		{contracts.FrontendSourceCASL, contracts.FrontendSourceBridge, "1234", "1234"},
+		{contracts.FrontendSourceBridge, contracts.FrontendSourcePhoenix, "1234", ""},
+		{contracts.FrontendSourceCASL, contracts.FrontendSourceBridge, "abc", ""},
+	}
+	for _, tt := range tests {
+		if got := DefaultTargetNumber(tt.from, tt.to, tt.number); got != tt.want {
+			t.Errorf("DefaultTargetNumber(%s, %s, %q) = %q, want %q", tt.from, tt.to, tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestObjectValueNormalizesAcrossSources(t *testing.T) {
+	t.Parallel()
+
+	a := Object{Phones: "0501234567; 067-111-22-33", Latitude: "50.45", Longitude: "30.5", Zones: []Zone{{Number: 3}, {Number: 1, Description: " Вхід "}}}
+	b := Object{Phones: "+380671112233, 380501234567", Latitude: "50,450000", Longitude: "30.500000", Zones: []Zone{{Number: 1, Description: "Вхід"}, {Number: 3, Description: "Шлейф 3"}}}
+	if diffs := Compare(a, b, []Field{FieldPhones, FieldCoordinates, FieldZones}); len(diffs) != 0 {
+		t.Fatalf("Compare = %v, want no differences", diffs)
+	}
+	if got := (Object{Latitude: "0", Longitude: ""}).Value(FieldCoordinates); got != "" {
+		t.Fatalf("empty coordinates = %q", got)
+	}
+}
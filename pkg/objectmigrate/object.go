@@ -0,0 +1,253 @@
+package objectmigrate
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/utils"
+)
+
+// Field names an object attribute that can be migrated between sources.
+type Field string
+
+const (
+	FieldName        Field = "name"
+	FieldAddress     Field = "address"
+	FieldPhones      Field = "phones"
+	FieldContract    Field = "contract"
+	FieldNotes       Field = "notes"
+	FieldLocation    Field = "location"
+	FieldSIM1        Field = "sim1"
+	FieldSIM2        Field = "sim2"
+	FieldCoordinates Field = "coordinates"
+	FieldZones       Field = "zones"
+	FieldContacts    Field = "contacts"
+)
+
+// AllFields lists every migrated field in report order.
+var AllFields = []Field{
+	FieldName,
+	FieldAddress,
+	FieldPhones,
+	FieldContract,
+	FieldNotes,
+	FieldLocation,
+	FieldSIM1,
+	FieldSIM2,
+	FieldCoordinates,
+	FieldZones,
+	FieldContacts,
+}
+
+// Zone is a source-neutral zone (MIST zone, Phoenix zone, CASL line).
+type Zone struct {
+	Number      int64
+	Description string
+}
+
+// Contact is a source-neutral responsible person.
+type Contact struct {
+	Surname  string
+	Name     string
+	SecName  string
+	Phones   string
+	Position string
+}
+
+// Object is a source-neutral object snapshot used for planning and verification.
+type Object struct {
+	Source contracts.FrontendSource
+	// ID is the catalogue object ID accepted by the source admin contracts.
+	ID int64
+	// Number is the operator-facing number: MIST ObjN, Phoenix Panel_id or CASL device number.
+	Number string
+
+	Name      string
+	Address   string
+	Phones    string
+	Contract  string
+	Notes     string
+	Location  string
+	SIM1      string
+	SIM2      string
+	Latitude  string
+	Longitude string
+
+	Zones    []Zone
+	Contacts []Contact
+}
+
+// ConflictReason explains why a field is reported in a plan.
+type ConflictReason string
+
+const (
+	// ReasonDiffers marks a field whose target value does not match the source.
+	ReasonDiffers ConflictReason = "differs"
+	// ReasonUnsupported marks a source value the target source cannot store.
+	ReasonUnsupported ConflictReason = "unsupported"
+)
+
+// Conflict is a field-level difference between source and target.
+type Conflict struct {
+	Field  Field          `json:"field"`
+	Reason ConflictReason `json:"reason"`
+	Source string         `json:"source"`
+	Target string         `json:"target,omitempty"`
+}
+
+func (c Conflict) String() string {
+	if c.Reason == ReasonUnsupported {
+		return fmt.Sprintf("%s: not stored by target (%q)", c.Field, c.Source)
+	}
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Source, c.Target)
+}
+
+// Value returns a normalized field value suitable for comparison across sources.
+func (o Object) Value(field Field) string {
+	switch field {
+	case FieldName:
+		return collapseSpaces(o.Name)
+	case FieldAddress:
+		return collapseSpaces(o.Address)
+	case FieldPhones:
+		return strings.Join(phoneKeys(o.Phones), ", ")
+	case FieldContract:
+		return collapseSpaces(o.Contract)
+	case FieldNotes:
+		return collapseSpaces(o.Notes)
+	case FieldLocation:
+		return collapseSpaces(o.Location)
+	case FieldSIM1:
+		return phoneKey(o.SIM1)
+	case FieldSIM2:
+		return phoneKey(o.SIM2)
+	case FieldCoordinates:
+		lat, lon := coordinate(o.Latitude), coordinate(o.Longitude)
+		if lat == "" && lon == "" {
+			return ""
+		}
+		return lat + ", " + lon
+	case FieldZones:
+		zones := slices.Clone(o.Zones)
+		slices.SortFunc(zones, func(a, b Zone) int { return cmp.Compare(a.Number, b.Number) })
+		parts := make([]string, 0, len(zones))
+		for _, zone := range zones {
+			parts = append(parts, fmt.Sprintf("%d %s", zone.Number, ZoneDescription(zone)))
+		}
+		return strings.Join(parts, "; ")
+	case FieldContacts:
+		parts := make([]string, 0, len(o.Contacts))
+		for _, contact := range o.Contacts {
+			label := collapseSpaces(strings.Join([]string{contact.Surname, contact.Name, contact.SecName}, " "))
+			if keys := phoneKeys(contact.Phones); len(keys) > 0 {
+				label += " (" + strings.Join(keys, ", ") + ")"
+			}
+			parts = append(parts, label)
+		}
+		slices.Sort(parts)
+		return strings.Join(parts, "; ")
+	default:
+		return ""
+	}
+}
+
+// ZoneDescription returns the zone text, falling back to the MIST default name.
+func ZoneDescription(zone Zone) string {
+	if text := collapseSpaces(zone.Description); text != "" {
+		return text
+	}
+	return fmt.Sprintf("Шлейф %d", zone.Number)
+}
+
+// FullName joins contact name parts.
+func (c Contact) FullName() string {
+	return collapseSpaces(strings.Join([]string{c.Surname, c.Name, c.SecName}, " "))
+}
+
+// Compare reports fields whose values differ between source and target.
+func Compare(source, target Object, fields []Field) []Conflict {
+	var conflicts []Conflict
+	for _, field := range fields {
+		from, to := source.Value(field), target.Value(field)
+		if !strings.EqualFold(from, to) {
+			conflicts = append(conflicts, Conflict{Field: field, Reason: ReasonDiffers, Source: from, Target: to})
+		}
+	}
+	return conflicts
+}
+
+// Unsupported reports non-empty source fields that the target cannot store.
+func Unsupported(source Object, writable []Field) []Conflict {
+	var conflicts []Conflict
+	for _, field := range AllFields {
+		if slices.Contains(writable, field) {
+			continue
+		}
+		if value := source.Value(field); value != "" {
+			conflicts = append(conflicts, Conflict{Field: field, Reason: ReasonUnsupported, Source: value})
+		}
+	}
+	return conflicts
+}
+
+// SharedFields returns fields read by the source and written by the target.
+func SharedFields(reads, writes []Field) []Field {
+	shared := make([]Field, 0, len(writes))
+	for _, field := range AllFields {
+		if slices.Contains(reads, field) && slices.Contains(writes, field) {
+			shared = append(shared, field)
+		}
+	}
+	return shared
+}
+
+// SplitPhones splits a free-form phone list into trimmed numbers.
+func SplitPhones(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n' || r == '\r'
+	})
+	phones := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if phone := strings.TrimSpace(field); phone != "" {
+			phones = append(phones, phone)
+		}
+	}
+	return phones
+}
+
+func phoneKeys(raw string) []string {
+	var keys []string
+	for _, phone := range SplitPhones(raw) {
+		if key := phoneKey(phone); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// phoneKey compares numbers by the last 9 digits so 0XX, 380XX and +380 forms match.
+func phoneKey(raw string) string {
+	digits := utils.DigitsOnly(raw)
+	if len(digits) > 9 {
+		digits = digits[len(digits)-9:]
+	}
+	return digits
+}
+
+func coordinate(raw string) string {
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), ",", ".")
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', 6, 64)
+}
+
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
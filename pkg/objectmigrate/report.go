@@ -0,0 +1,43 @@
+package objectmigrate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Summary counts results by status.
+func Summary(results []Result) map[Status]int {
+	counts := make(map[Status]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	return counts
+}
+
+// WriteReport prints one line per object followed by its field-level conflicts.
+func WriteReport(w io.Writer, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "SOURCE\tTARGET\tNAME\tSTATUS\tDETAILS")
+	for _, result := range results {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+			result.SourceNumber, result.TargetNumber, result.Name, result.Status, result.Error)
+		for _, conflict := range result.Conflicts {
+			fmt.Fprintf(table, "\t\t\t\t%s\n", conflict)
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	counts := Summary(results)
+	parts := make([]string, 0, len(counts))
+	for _, status := range []Status{StatusPlanned, StatusMigrated, StatusDecommissioned, StatusSourceKept, StatusUpToDate, StatusConflict, StatusVerifyFailed, StatusFailed} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", status, counts[status]))
+		}
+	}
+	_, err := fmt.Fprintf(w, "total: %d; %s\n", len(results), strings.Join(parts, ", "))
+	return err
+}
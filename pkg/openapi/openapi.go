@@ -0,0 +1,299 @@
+// Package openapi будує OpenAPI 3.0 документи з таблиць маршрутів і Go-типів DTO.
+// Використовується frontendhttp та adminhttp, щоб специфікація не розходилася з кодом.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+var pathParamPattern = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// Param описує параметр шляху або query.
+// Type — тип OpenAPI (string, integer, boolean); для integer без Format береться int64.
+type Param struct {
+	Name        string
+	Type        string
+	Format      string
+	Description string
+	Required    bool
+	Repeated    bool
+}
+
+// Operation описує одну операцію API. Request і Response — зразкові значення Go-типів;
+// nil означає відсутність тіла. Produces — медіатипи файлового (не JSON) тіла успішної відповіді.
+type Operation struct {
+	Method          string
+	Path            string
+	Tag             string
+	Summary         string
+	OperationID     string
+	PathParams      []Param
+	Query           []Param
+	Request         any
+	RequestOptional bool
+	Response        any
+	Produces        []string
+	Status          int
+	Errors          map[int]string
+	Public          bool
+	Extensions      map[string]any
+}
+
+// Info — блок info документа.
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Document збирає повний OpenAPI-документ з операцій.
+// bearerAuth додається, якщо хоч одна операція не позначена як Public.
+func Document(info Info, operations []Operation) map[string]any {
+	schemas := NewSchemas()
+	paths := map[string]any{}
+	secured := false
+	for _, item := range operations {
+		pathItem, _ := paths[item.Path].(map[string]any)
+		if pathItem == nil {
+			pathItem = map[string]any{}
+			paths[item.Path] = pathItem
+		}
+		pathItem[strings.ToLower(item.Method)] = schemas.Operation(item)
+		if !item.Public {
+			secured = true
+		}
+	}
+
+	components := map[string]any{"schemas": schemas.Components()}
+	document := map[string]any{
+		"openapi": Version,
+		"info": map[string]any{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"servers":    []any{map[string]any{"url": "/"}},
+		"paths":      paths,
+		"components": components,
+	}
+	if secured {
+		components["securitySchemes"] = map[string]any{
+			"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+		}
+		document["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	}
+	return document
+}
+
+// Schemas накопичує іменовані схеми для components/schemas.
+type Schemas struct {
+	components map[string]any
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{components: map[string]any{
+		"Error": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"error": map[string]any{"type": "string"}},
+			"required":   []string{"error"},
+		},
+	}}
+}
+
+// Components повертає зібрані іменовані схеми.
+func (s *Schemas) Components() map[string]any {
+	return s.components
+}
+
+// Operation будує опис операції та реєструє схеми її тіл.
+func (s *Schemas) Operation(item Operation) map[string]any {
+	operation := map[string]any{
+		"summary":     item.Summary,
+		"operationId": item.OperationID,
+	}
+	if item.Tag != "" {
+		operation["tags"] = []string{item.Tag}
+	}
+	if item.OperationID == "" {
+		operation["operationId"] = OperationID(item.Method, item.Path)
+	}
+	if item.Public {
+		operation["security"] = []any{}
+	}
+	for key, value := range item.Extensions {
+		operation[key] = value
+	}
+
+	var parameters []any
+	for _, name := range pathParamPattern.FindAllStringSubmatch(item.Path, -1) {
+		param := Param{Name: name[1], Type: "integer"}
+		for _, candidate := range item.PathParams {
+			if candidate.Name == name[1] {
+				param = candidate
+				break
+			}
+		}
+		param.Required = true
+		parameters = append(parameters, parameter(param, "path"))
+	}
+	for _, param := range item.Query {
+		parameters = append(parameters, parameter(param, "query"))
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if item.Request != nil {
+		operation["requestBody"] = map[string]any{
+			"required": !item.RequestOptional,
+			"content":  jsonContent(s.For(item.Request)),
+		}
+	}
+
+	responses := map[string]any{}
+	for status, description := range item.Errors {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": description,
+			"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/Error"}),
+		}
+	}
+	status := item.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if item.Response != nil {
+		success["content"] = jsonContent(s.For(item.Response))
+	} else if len(item.Produces) > 0 {
+		content := map[string]any{}
+		for _, mediaType := range item.Produces {
+			content[mediaType] = map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}}
+		}
+		success["content"] = content
+	}
+	responses[strconv.Itoa(status)] = success
+	operation["responses"] = responses
+	return operation
+}
+
+func parameter(param Param, in string) map[string]any {
+	schema := map[string]any{"type": param.Type}
+	if param.Type == "" {
+		schema["type"] = "string"
+	}
+	if param.Format != "" {
+		schema["format"] = param.Format
+	} else if param.Type == "integer" {
+		schema["format"] = "int64"
+	}
+	if param.Repeated {
+		schema = map[string]any{"type": "array", "items": schema}
+	}
+	result := map[string]any{"name": param.Name, "in": in, "schema": schema}
+	if param.Required {
+		result["required"] = true
+	}
+	if param.Description != "" {
+		result["description"] = param.Description
+	}
+	return result
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// OperationID будує стабільний ідентифікатор операції з методу і шляху:
+// GET /objects/{id}/events -> getObjectsIdEvents.
+func OperationID(method string, path string) string {
+	parts := strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '-' || r == '{' || r == '}'
+	})
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	for _, part := range parts {
+		id.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return id.String()
+}
+
+// For повертає схему для типу значення; іменовані структури виносяться в components/schemas.
+func (s *Schemas) For(value any) map[string]any {
+	return s.schemaFor(reflect.TypeOf(value))
+}
+
+func (s *Schemas) schemaFor(t reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	if t.Kind() == reflect.Pointer {
+		schema := s.schemaFor(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Uint:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		// nil-зріз кодується як null.
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem()), "nullable": true}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" || strings.Contains(name, "[") {
+			return s.structSchema(t)
+		}
+		if _, exists := s.components[name]; !exists {
+			// Заглушка до побудови захищає від нескінченної рекурсії на самопосиланнях.
+			s.components[name] = map[string]any{"type": "object"}
+			s.components[name] = s.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (s *Schemas) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		properties[name] = s.schemaFor(field.Type)
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
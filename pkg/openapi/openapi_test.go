@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+type sampleChild struct {
+	Name string `json:"name"`
+}
+
+type sampleDTO struct {
+	ID       int64          `json:"ID"`
+	Count    int            `json:"count,omitempty"`
+	Child    *sampleChild   `json:"child"`
+	Children []sampleChild  `json:"children"`
+	Labels   map[string]int `json:"labels"`
+	Hidden   string         `json:"-"`
+	internal string
+}
+
+func TestSchemasForNamedStruct(t *testing.T) {
+	t.Parallel()
+
+	schemas := NewSchemas()
+	ref := schemas.For(sampleDTO{})
+	if ref["$ref"] != "#/components/schemas/sampleDTO" {
+		t.Fatalf("ref = %v", ref)
+	}
+
+	dto, _ := schemas.Components()["sampleDTO"].(map[string]any)
+	properties, _ := dto["properties"].(map[string]any)
+	if len(properties) != 5 {
+		t.Fatalf("properties = %v", properties)
+	}
+	if id, _ := properties["ID"].(map[string]any); id["format"] != "int64" {
+		t.Fatalf("ID schema = %v", id)
+	}
+	if child, _ := properties["child"].(map[string]any); child["nullable"] != true {
+		t.Fatalf("pointer must be nullable, got %v", child)
+	}
+	if children, _ := properties["children"].(map[string]any); children["type"] != "array" || children["nullable"] != true {
+		t.Fatalf("slice schema = %v", children)
+	}
+	if _, ok := schemas.Components()["sampleChild"]; !ok {
+		t.Fatal("nested named struct must be registered in components")
+	}
+}
+
+func TestDocumentOperations(t *testing.T) {
+	t.Parallel()
+
+	document := Document(Info{Title: "test", Version: "v1"}, []Operation{
+		{
+			Method: http.MethodGet, Path: "/items/{itemID}", Summary: "item",
+			Query:    []Param{{Name: "tag", Type: "string", Repeated: true}},
+			Response: sampleChild{},
+			Errors:   map[int]string{http.StatusNotFound: "missing"},
+		},
+		{Method: http.MethodDelete, Path: "/items/{itemID}", Status: http.StatusNoContent},
+	})
+
+	if document["openapi"] != Version {
+		t.Fatalf("openapi = %v", document["openapi"])
+	}
+	if _, secured := document["security"]; !secured {
+		t.Fatal("non-public operations must declare bearer security")
+	}
+	paths, _ := document["paths"].(map[string]any)
+	item, _ := paths["/items/{itemID}"].(map[string]any)
+	get, _ := item["get"].(map[string]any)
+	if get["operationId"] != "getItemsItemID" {
+		t.Fatalf("operationId = %v", get["operationId"])
+	}
+	parameters, _ := get["parameters"].([]any)
+	if len(parameters) != 2 {
+		t.Fatalf("parameters = %v", parameters)
+	}
+	responses, _ := get["responses"].(map[string]any)
+	if _, ok := responses["404"]; !ok {
+		t.Fatalf("responses = %v", responses)
+	}
+	deleteOperation, _ := item["delete"].(map[string]any)
+	deleteResponses, _ := deleteOperation["responses"].(map[string]any)
+	if _, ok := deleteResponses["204"]; !ok {
+		t.Fatalf("delete responses = %v", deleteResponses)
+	}
+}
+
+func TestDocumentFileResponse(t *testing.T) {
+	t.Parallel()
+
+	document := Document(Info{Title: "test", Version: "v1"}, []Operation{
+		{Method: http.MethodGet, Path: "/export", Produces: []string{"text/csv"}, Public: true},
+	})
+	paths, _ := document["paths"].(map[string]any)
+	item, _ := paths["/export"].(map[string]any)
+	get, _ := item["get"].(map[string]any)
+	responses, _ := get["responses"].(map[string]any)
+	success, _ := responses["200"].(map[string]any)
+	content, _ := success["content"].(map[string]any)
+	media, _ := content["text/csv"].(map[string]any)
+	schema, _ := media["schema"].(map[string]any)
+	if schema["format"] != "binary" {
+		t.Fatalf("file response content = %v", content)
+	}
+}
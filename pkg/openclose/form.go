@@ -0,0 +1,172 @@
+package openclose
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormDateLayout — формат дат святкових днів у формах.
+const FormDateLayout = "02.01.2006"
+
+// Form — сирі значення форми розкладу з UI. Days — по рядку на день
+// з понеділка у вигляді «08:00-20:00»; порожній рядок — вихідний.
+type Form struct {
+	ID             string
+	ObjectID       int
+	ObjectNumber   string
+	ObjectName     string
+	GroupName      string
+	Days           [7]string
+	Holidays       string
+	OpenTolerance  string
+	CloseTolerance string
+	CreatedBy      string
+}
+
+// NewForm заповнює форму значеннями існуючого розкладу.
+func NewForm(schedule Schedule) Form {
+	form := Form{
+		ID:             schedule.ID,
+		ObjectID:       schedule.ObjectID,
+		ObjectNumber:   schedule.ObjectNumber,
+		ObjectName:     schedule.ObjectName,
+		GroupName:      schedule.GroupName,
+		Holidays:       FormatHolidays(schedule.Holidays),
+		OpenTolerance:  strconv.Itoa(schedule.OpenTolerance),
+		CloseTolerance: strconv.Itoa(schedule.CloseTolerance),
+		CreatedBy:      schedule.CreatedBy,
+	}
+	for i, day := range schedule.Week {
+		if !day.Closed {
+			form.Days[i] = DayText(day)
+		}
+	}
+	return form
+}
+
+// DefaultForm — форма нового розкладу: будні 09:00-18:00, вихідні зачинено.
+func DefaultForm(objectID int, objectNumber string, objectName string) Form {
+	form := Form{
+		ObjectID:       objectID,
+		ObjectNumber:   objectNumber,
+		ObjectName:     objectName,
+		OpenTolerance:  strconv.Itoa(DefaultToleranceMinutes),
+		CloseTolerance: strconv.Itoa(DefaultToleranceMinutes),
+	}
+	for i := range 5 {
+		form.Days[i] = "09:00-18:00"
+	}
+	return form
+}
+
+// Schedule розбирає форму в розклад.
+func (f Form) Schedule() (Schedule, error) {
+	schedule := Schedule{
+		ID:           f.ID,
+		ObjectID:     f.ObjectID,
+		ObjectNumber: strings.TrimSpace(f.ObjectNumber),
+		ObjectName:   strings.TrimSpace(f.ObjectName),
+		GroupName:    strings.TrimSpace(f.GroupName),
+		CreatedBy:    strings.TrimSpace(f.CreatedBy),
+	}
+	for i, text := range f.Days {
+		day, err := ParseDay(text)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("%s: %w", WeekdayNames[i], err)
+		}
+		schedule.Week[i] = day
+	}
+	var err error
+	if schedule.OpenTolerance, err = parseTolerance(f.OpenTolerance, "відкриття"); err != nil {
+		return Schedule{}, err
+	}
+	if schedule.CloseTolerance, err = parseTolerance(f.CloseTolerance, "закриття"); err != nil {
+		return Schedule{}, err
+	}
+	if schedule.Holidays, err = ParseHolidays(f.Holidays); err != nil {
+		return Schedule{}, err
+	}
+	if err := schedule.Validate(); err != nil {
+		return Schedule{}, err
+	}
+	return schedule, nil
+}
+
+// ParseDay розбирає робочий час дня «08:00-20:00»; порожній рядок — вихідний.
+func ParseDay(text string) (Day, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Day{Closed: true}, nil
+	}
+	openText, closeText, ok := strings.Cut(strings.ReplaceAll(text, "–", "-"), "-")
+	if !ok {
+		return Day{}, errors.New("формат: ГГ:ХХ-ГГ:ХХ або порожньо для вихідного")
+	}
+	open, err := ParseClock(openText)
+	if err != nil {
+		return Day{}, err
+	}
+	closeAt, err := ParseClock(closeText)
+	if err != nil {
+		return Day{}, err
+	}
+	return Day{Open: open, Close: closeAt}, nil
+}
+
+// DayText повертає робочий час дня у форматі форми.
+func DayText(day Day) string {
+	if day.Closed {
+		return "вихідний"
+	}
+	return day.Open.String() + "-" + day.Close.String()
+}
+
+// ParseHolidays розбирає святкові дні: по рядку «ДД.ММ.РРРР назва».
+func ParseHolidays(text string) ([]Holiday, error) {
+	var holidays []Holiday
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		dateText, name, _ := strings.Cut(line, " ")
+		date, err := time.Parse(FormDateLayout, dateText)
+		if err != nil {
+			return nil, fmt.Errorf("некоректна дата святкового дня %q, формат: ДД.ММ.РРРР", dateText)
+		}
+		holidays = append(holidays, Holiday{Date: date.Format(DateLayout), Name: strings.TrimSpace(name)})
+	}
+	sortHolidays(holidays)
+	return holidays, nil
+}
+
+// FormatHolidays повертає святкові дні у форматі ParseHolidays.
+func FormatHolidays(holidays []Holiday) string {
+	lines := make([]string, 0, len(holidays))
+	for _, holiday := range holidays {
+		line := holiday.Date
+		if date, err := time.Parse(DateLayout, holiday.Date); err == nil {
+			line = date.Format(FormDateLayout)
+		}
+		if name := strings.TrimSpace(holiday.Name); name != "" {
+			line += " " + name
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseTolerance(text string, what string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	minutes, err := strconv.Atoi(text)
+	if err != nil || minutes < 0 {
+		return 0, fmt.Errorf("некоректний допуск %s: %q хв", what, text)
+	}
+	return minutes, nil
+}
@@ -0,0 +1,62 @@
+package openclose
+
+import (
+	"strings"
+	"time"
+)
+
+// WeekdayNames — короткі назви днів у порядку Week.
+var WeekdayNames = [7]string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Нд"}
+
+// Label повертає назву виду порушення для UI.
+func (k Kind) Label() string {
+	switch k {
+	case KindNotArmed:
+		return "Не поставлено під охорону"
+	case KindUnscheduledDisarm:
+		return "Зняття поза розкладом"
+	case KindEarlyOpen:
+		return "Раннє відкриття"
+	case KindLateOpen:
+		return "Пізнє відкриття"
+	default:
+		return string(k)
+	}
+}
+
+// GroupLabel повертає групу розкладу для UI.
+func (s Schedule) GroupLabel() string {
+	if group := strings.TrimSpace(s.GroupName); group != "" {
+		return "група " + group
+	}
+	return "увесь об'єкт"
+}
+
+// WeekLabel стисло описує тиждень: сусідні дні з однаковим часом об'єднуються.
+func (s Schedule) WeekLabel() string {
+	parts := make([]string, 0, len(s.Week))
+	for start := 0; start < len(s.Week); {
+		end := start
+		for end+1 < len(s.Week) && s.Week[end+1] == s.Week[start] {
+			end++
+		}
+		days := WeekdayNames[start]
+		if end > start {
+			days += "–" + WeekdayNames[end]
+		}
+		parts = append(parts, days+" "+DayText(s.Week[start]))
+		start = end + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+// StateLabel описує, чи діє зараз зміна розкладу.
+func (s Schedule) StateLabel(now time.Time, holidays []Holiday) string {
+	for _, offset := range []int{0, -1} {
+		shift, ok := s.ShiftOn(now.AddDate(0, 0, offset), holidays)
+		if ok && !now.Before(shift.Open) && now.Before(shift.Close) {
+			return "відкрито до " + shift.Close.Format("15:04")
+		}
+	}
+	return "зачинено"
+}
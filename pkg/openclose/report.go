@@ -0,0 +1,148 @@
+package openclose
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ComplianceRow — дотримання розкладу одним об'єктом за період.
+// Days — робочі дні за розкладом разом із днями, коли були порушення
+// (наприклад, зняття у святковий день); CleanDays — дні без порушень.
+type ComplianceRow struct {
+	ObjectID     int
+	ObjectNumber string
+	ObjectName   string
+	Days         int
+	CleanDays    int
+	Counts       map[Kind]int
+}
+
+// Total повертає загальну кількість порушень.
+func (r ComplianceRow) Total() int {
+	total := 0
+	for _, count := range r.Counts {
+		total += count
+	}
+	return total
+}
+
+// Percent повертає частку днів без порушень; 100 для об'єкта без робочих днів.
+func (r ComplianceRow) Percent() float64 {
+	if r.Days == 0 {
+		return 100
+	}
+	return float64(r.CleanDays) * 100 / float64(r.Days)
+}
+
+// Compliance будує звіт дотримання розкладу по об'єктах за [from, to).
+// Об'єкти з порушеннями, розклад яких уже видалено, теж потрапляють у звіт.
+func Compliance(schedules []Schedule, holidays []Holiday, violations []Violation, from, to time.Time) []ComplianceRow {
+	type accumulator struct {
+		row           ComplianceRow
+		days          map[string]struct{}
+		violationDays map[string]struct{}
+	}
+	byObject := make(map[int]*accumulator)
+	get := func(objectID int, number string, name string) *accumulator {
+		entry, ok := byObject[objectID]
+		if !ok {
+			entry = &accumulator{
+				row:           ComplianceRow{ObjectID: objectID, Counts: make(map[Kind]int)},
+				days:          make(map[string]struct{}),
+				violationDays: make(map[string]struct{}),
+			}
+			byObject[objectID] = entry
+		}
+		if entry.row.ObjectNumber == "" {
+			entry.row.ObjectNumber = number
+		}
+		if entry.row.ObjectName == "" {
+			entry.row.ObjectName = name
+		}
+		return entry
+	}
+
+	for _, schedule := range schedules {
+		entry := get(schedule.ObjectID, schedule.ObjectNumber, schedule.ObjectName)
+		for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+			if _, ok := schedule.ShiftOn(day, holidays); ok {
+				entry.days[day.Format(DateLayout)] = struct{}{}
+			}
+		}
+	}
+	for _, violation := range violations {
+		if violation.Time.Before(from) || !violation.Time.Before(to) {
+			continue
+		}
+		entry := get(violation.ObjectID, violation.ObjectNumber, violation.ObjectName)
+		entry.row.Counts[violation.Kind]++
+		entry.days[violation.Day] = struct{}{}
+		entry.violationDays[violation.Day] = struct{}{}
+	}
+
+	rows := make([]ComplianceRow, 0, len(byObject))
+	for _, entry := range byObject {
+		entry.row.Days = len(entry.days)
+		entry.row.CleanDays = len(entry.days) - len(entry.violationDays)
+		if entry.row.ObjectNumber == "" {
+			entry.row.ObjectNumber = strconv.Itoa(entry.row.ObjectID)
+		}
+		rows = append(rows, entry.row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Percent() != rows[j].Percent() {
+			return rows[i].Percent() < rows[j].Percent()
+		}
+		return rows[i].ObjectNumber < rows[j].ObjectNumber
+	})
+	return rows
+}
+
+// ComplianceHeaders — заголовки колонок для ComplianceRow.Values.
+var ComplianceHeaders = []string{"№", "Назва", "Робочих днів", "Без порушень", "Дотримання", "Не поставлено", "Поза розкладом", "Раннє відкриття", "Пізнє відкриття"}
+
+// Values повертає значення колонок звіту дотримання.
+func (r ComplianceRow) Values() []string {
+	return []string{
+		r.ObjectNumber,
+		r.ObjectName,
+		strconv.Itoa(r.Days),
+		strconv.Itoa(r.CleanDays),
+		fmt.Sprintf("%.0f%%", r.Percent()),
+		strconv.Itoa(r.Counts[KindNotArmed]),
+		strconv.Itoa(r.Counts[KindUnscheduledDisarm]),
+		strconv.Itoa(r.Counts[KindEarlyOpen]),
+		strconv.Itoa(r.Counts[KindLateOpen]),
+	}
+}
+
+// ViolationHeaders — заголовки колонок для Violation.Values.
+var ViolationHeaders = []string{"Час", "№", "Назва", "Група", "Порушення", "Деталі", "Обробив", "Коментар"}
+
+// Values повертає значення колонок журналу порушень.
+func (v Violation) Values() []string {
+	ack := v.AckUser
+	if v.Acknowledged() && ack == "" {
+		ack = "—"
+	}
+	if v.Acknowledged() {
+		ack += " " + v.AckAt.Format("02.01.2006 15:04")
+	}
+	return []string{
+		v.Time.Format("02.01.2006 15:04"),
+		v.ObjectNumber,
+		v.ObjectName,
+		v.GroupName,
+		v.Kind.Label(),
+		v.Description(),
+		ack,
+		v.AckNote,
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
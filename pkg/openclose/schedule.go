@@ -0,0 +1,208 @@
+// Package openclose контролює розклад відкриття та закриття об'єктів: за
+// тижневим розкладом і святковими днями звіряє події зняття та постановки
+// під охорону й фіксує порушення — об'єкт не поставлено після закриття,
+// зняття поза розкладом, раннє або пізнє відкриття.
+package openclose
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// DateLayout — формат дати святкових днів і днів порушень у файлі.
+const DateLayout = "2006-01-02"
+
+// DefaultToleranceMinutes — типовий допуск відхилення від розкладу.
+const DefaultToleranceMinutes = 15
+
+// Clock — час доби у хвилинах від півночі.
+type Clock int
+
+const minutesPerDay = 24 * 60
+
+// ParseClock розбирає час «8:00» або «08:00».
+func ParseClock(text string) (Clock, error) {
+	hoursText, minutesText, ok := strings.Cut(strings.TrimSpace(text), ":")
+	if !ok {
+		return 0, fmt.Errorf("некоректний час %q, формат: ГГ:ХХ", text)
+	}
+	hours, err := strconv.Atoi(hoursText)
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("некоректний час %q, формат: ГГ:ХХ", text)
+	}
+	minutes, err := strconv.Atoi(minutesText)
+	if err != nil || minutes < 0 || minutes > 59 || len(minutesText) != 2 {
+		return 0, fmt.Errorf("некоректний час %q, формат: ГГ:ХХ", text)
+	}
+	return Clock(hours*60 + minutes), nil
+}
+
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func (c Clock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Clock) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	parsed, err := ParseClock(text)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Day — робочий час одного дня тижня. Close не пізніше Open означає, що
+// об'єкт закривається наступної доби.
+type Day struct {
+	Closed bool  `json:"closed,omitempty"`
+	Open   Clock `json:"open"`
+	Close  Clock `json:"close"`
+}
+
+// Holiday — неробочий день, у який зняття з охорони не очікується.
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// Schedule — тижневий розклад об'єкта або однієї його групи. Week
+// починається з понеділка. Holidays доповнюють спільні святкові дні сховища.
+type Schedule struct {
+	ID             string    `json:"id"`
+	ObjectID       int       `json:"object_id"`
+	ObjectNumber   string    `json:"object_number,omitempty"`
+	ObjectName     string    `json:"object_name,omitempty"`
+	GroupName      string    `json:"group,omitempty"`
+	Week           [7]Day    `json:"week"`
+	Holidays       []Holiday `json:"holidays,omitempty"`
+	OpenTolerance  int       `json:"open_tolerance_minutes"`
+	CloseTolerance int       `json:"close_tolerance_minutes"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at,omitzero"`
+}
+
+// Shift — робочий інтервал конкретного дня.
+type Shift struct {
+	Day   time.Time
+	Open  time.Time
+	Close time.Time
+}
+
+// Validate перевіряє, що розклад можна зберегти.
+func (s Schedule) Validate() error {
+	if s.ObjectID == 0 {
+		return errors.New("не вказано об'єкт")
+	}
+	if s.OpenTolerance < 0 || s.CloseTolerance < 0 {
+		return errors.New("допуск не може бути від'ємним")
+	}
+	working := false
+	for i, day := range s.Week {
+		if day.Closed {
+			continue
+		}
+		working = true
+		if day.Open < 0 || day.Open >= minutesPerDay || day.Close < 0 || day.Close >= minutesPerDay {
+			return fmt.Errorf("%s: некоректний час", WeekdayNames[i])
+		}
+		if day.Open == day.Close {
+			return fmt.Errorf("%s: відкриття і закриття збігаються", WeekdayNames[i])
+		}
+	}
+	if !working {
+		return errors.New("у розкладі немає жодного робочого дня")
+	}
+	for _, holiday := range s.Holidays {
+		if _, err := time.Parse(DateLayout, holiday.Date); err != nil {
+			return fmt.Errorf("некоректна дата святкового дня %q", holiday.Date)
+		}
+	}
+	return nil
+}
+
+// Matches повідомляє, чи стосується подія розкладу (об'єкт і, якщо задано, група).
+func (s Schedule) Matches(event models.Event) bool {
+	if event.ObjectID != s.ObjectID {
+		return false
+	}
+	group := strings.TrimSpace(s.GroupName)
+	return group == "" || strings.EqualFold(strings.TrimSpace(event.GroupName), group)
+}
+
+// ShiftOn повертає робочий інтервал дня day або false для вихідного чи
+// святкового дня. common — спільні святкові дні сховища.
+func (s Schedule) ShiftOn(day time.Time, common []Holiday) (Shift, bool) {
+	year, month, date := day.Date()
+	midnight := time.Date(year, month, date, 0, 0, 0, 0, day.Location())
+	key := midnight.Format(DateLayout)
+	if isHoliday(key, s.Holidays) || isHoliday(key, common) {
+		return Shift{}, false
+	}
+	rule := s.Week[weekIndex(midnight)]
+	if rule.Closed {
+		return Shift{}, false
+	}
+	// time.Date нормалізує хвилини, тож перехід на літній час враховано.
+	open := time.Date(year, month, date, 0, int(rule.Open), 0, 0, day.Location())
+	closeDate := date
+	if rule.Close <= rule.Open {
+		closeDate++
+	}
+	return Shift{
+		Day:   midnight,
+		Open:  open,
+		Close: time.Date(year, month, closeDate, 0, int(rule.Close), 0, 0, day.Location()),
+	}, true
+}
+
+// allowedAt повертає зміну, в межах якої (з допусками) зняття з охорони
+// дозволене в момент t. Перевіряється і попередня доба — для нічних змін.
+func (s Schedule) allowedAt(t time.Time, common []Holiday) (Shift, bool) {
+	for _, offset := range []int{0, -1} {
+		shift, ok := s.ShiftOn(t.AddDate(0, 0, offset), common)
+		if !ok {
+			continue
+		}
+		from := shift.Open.Add(-s.openTolerance())
+		to := shift.Close.Add(s.closeTolerance())
+		if !t.Before(from) && !t.After(to) {
+			return shift, true
+		}
+	}
+	return Shift{}, false
+}
+
+func (s Schedule) openTolerance() time.Duration {
+	return time.Duration(s.OpenTolerance) * time.Minute
+}
+
+func (s Schedule) closeTolerance() time.Duration {
+	return time.Duration(s.CloseTolerance) * time.Minute
+}
+
+func isHoliday(date string, holidays []Holiday) bool {
+	for _, holiday := range holidays {
+		if holiday.Date == date {
+			return true
+		}
+	}
+	return false
+}
+
+// weekIndex повертає індекс дня в Week: 0 — понеділок.
+func weekIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
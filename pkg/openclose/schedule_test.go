@@ -0,0 +1,102 @@
+package openclose
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormScheduleParsesWeekAndHolidays(t *testing.T) {
+	t.Parallel()
+
+	form := DefaultForm(7, "0007", "Магазин")
+	form.Days[5] = "10:00-15:00"
+	form.Days[6] = "22:00-06:00"
+	form.Holidays = "01.01.2026 Новий рік\n\n08.03.2026"
+	schedule, err := form.Schedule()
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if schedule.Week[6].Close >= schedule.Week[6].Open || schedule.Week[0].Closed {
+		t.Fatalf("week = %+v", schedule.Week)
+	}
+	if len(schedule.Holidays) != 2 || schedule.Holidays[0].Date != "2026-01-01" || schedule.Holidays[0].Name != "Новий рік" {
+		t.Fatalf("holidays = %+v", schedule.Holidays)
+	}
+	if got := schedule.WeekLabel(); got != "Пн–Пт 09:00-18:00, Сб 10:00-15:00, Нд 22:00-06:00" {
+		t.Fatalf("WeekLabel() = %q", got)
+	}
+	if got := NewForm(schedule); got.Days != form.Days || got.Holidays != "01.01.2026 Новий рік\n08.03.2026" {
+		t.Fatalf("NewForm() = %+v", got)
+	}
+
+	form.Days = [7]string{}
+	if _, err := form.Schedule(); err == nil {
+		t.Fatal("schedule without working days must be rejected")
+	}
+	form.Days[0] = "9-18"
+	if _, err := form.Schedule(); err == nil {
+		t.Fatal("malformed day must be rejected")
+	}
+}
+
+func TestShiftOnHandlesHolidaysAndOvernight(t *testing.T) {
+	t.Parallel()
+
+	schedule := Schedule{ObjectID: 1}
+	schedule.Week[0] = Day{Open: 9 * 60, Close: 18 * 60}
+	schedule.Week[1] = Day{Open: 22 * 60, Close: 6 * 60}
+	for i := 2; i < 7; i++ {
+		schedule.Week[i] = Day{Closed: true}
+	}
+	monday := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+
+	shift, ok := schedule.ShiftOn(monday, nil)
+	if !ok || shift.Open.Hour() != 9 || shift.Close.Hour() != 18 {
+		t.Fatalf("monday shift = %+v, %v", shift, ok)
+	}
+	if _, ok := schedule.ShiftOn(monday, []Holiday{{Date: "2026-03-02"}}); ok {
+		t.Fatal("holiday must have no shift")
+	}
+	tuesday, ok := schedule.ShiftOn(monday.AddDate(0, 0, 1), nil)
+	if !ok || tuesday.Close.Day() != 4 || tuesday.Close.Hour() != 6 {
+		t.Fatalf("overnight shift = %+v, %v", tuesday, ok)
+	}
+	if _, ok := schedule.allowedAt(time.Date(2026, 3, 4, 5, 0, 0, 0, time.Local), nil); !ok {
+		t.Fatal("disarm inside overnight shift must be allowed")
+	}
+	if _, ok := schedule.ShiftOn(monday.AddDate(0, 0, 2), nil); ok {
+		t.Fatal("closed day must have no shift")
+	}
+}
+
+func TestComplianceCountsDaysAndKinds(t *testing.T) {
+	t.Parallel()
+
+	schedule := Schedule{ObjectID: 1, ObjectNumber: "0001", ObjectName: "Аптека"}
+	for i := range 5 {
+		schedule.Week[i] = Day{Open: 9 * 60, Close: 18 * 60}
+	}
+	schedule.Week[5] = Day{Closed: true}
+	schedule.Week[6] = Day{Closed: true}
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 7)
+	violations := []Violation{
+		{ObjectID: 1, Kind: KindLateOpen, Day: "2026-03-03", Time: from.AddDate(0, 0, 1).Add(9 * time.Hour)},
+		{ObjectID: 1, Kind: KindNotArmed, Day: "2026-03-03", Time: from.AddDate(0, 0, 1).Add(18 * time.Hour)},
+		{ObjectID: 1, Kind: KindUnscheduledDisarm, Day: "2026-03-07", Time: from.AddDate(0, 0, 5).Add(11 * time.Hour)},
+		{ObjectID: 2, ObjectNumber: "0002", Kind: KindEarlyOpen, Day: "2026-03-20", Time: to.AddDate(0, 0, 10)},
+	}
+
+	rows := Compliance([]Schedule{schedule}, []Holiday{{Date: "2026-03-06"}}, violations, from, to)
+	if len(rows) != 1 {
+		t.Fatalf("rows = %+v", rows)
+	}
+	row := rows[0]
+	// 4 робочі дні (п'ятниця святкова) + субота з порушенням; порушення у 2 днях.
+	if row.Days != 5 || row.CleanDays != 3 || row.Total() != 3 || row.Counts[KindNotArmed] != 1 {
+		t.Fatalf("row = %+v", row)
+	}
+	if got := row.Values()[4]; got != "60%" {
+		t.Fatalf("percent = %q", got)
+	}
+}
@@ -0,0 +1,346 @@
+package openclose
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxViolations обмежує журнал порушень у файлі.
+const maxViolations = 10000
+
+type storeFile struct {
+	Schedules  []Schedule  `json:"schedules"`
+	Holidays   []Holiday   `json:"holidays,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// FileStore зберігає розклади, спільні святкові дні та журнал порушень в
+// одному JSON-файлі. Як і файл вікон обслуговування, він перечитується після
+// змін іншим процесом, тож його можна тримати у спільній теці. Порожній
+// шлях — сховище лише в пам'яті.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	state   storeFile
+	modTime time.Time
+	size    int64
+	index   map[string]int
+}
+
+// OpenFileStore відкриває (або створює при першому записі) файл розкладів.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: strings.TrimSpace(path)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path повертає шлях до файлу сховища.
+func (s *FileStore) Path() string {
+	if s == nil {
+		return ""
+	}
+	return s.path
+}
+
+// Schedules повертає всі розклади, впорядковані за об'єктом і групою.
+func (s *FileStore) Schedules() ([]Schedule, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	schedules := slices.Clone(s.state.Schedules)
+	sort.SliceStable(schedules, func(i, j int) bool {
+		if schedules[i].ObjectID != schedules[j].ObjectID {
+			return schedules[i].ObjectID < schedules[j].ObjectID
+		}
+		return schedules[i].GroupName < schedules[j].GroupName
+	})
+	return schedules, nil
+}
+
+// SaveSchedule додає новий розклад (порожній ID) або замінює існуючий.
+// Для об'єкта допускається лише один розклад на групу.
+func (s *FileStore) SaveSchedule(schedule Schedule) (Schedule, error) {
+	if s == nil {
+		return Schedule{}, errors.New("openclose: store is nil")
+	}
+	schedule.GroupName = strings.TrimSpace(schedule.GroupName)
+	if err := schedule.Validate(); err != nil {
+		return Schedule{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return Schedule{}, err
+	}
+	for _, existing := range s.state.Schedules {
+		if existing.ID != schedule.ID && existing.ObjectID == schedule.ObjectID &&
+			strings.EqualFold(existing.GroupName, schedule.GroupName) {
+			return Schedule{}, errors.New("для цього об'єкта і групи розклад уже є")
+		}
+	}
+	schedule.UpdatedAt = time.Now()
+	if schedule.ID == "" {
+		schedule.ID = newScheduleID()
+		s.state.Schedules = append(s.state.Schedules, schedule)
+	} else {
+		index := slices.IndexFunc(s.state.Schedules, func(existing Schedule) bool { return existing.ID == schedule.ID })
+		if index < 0 {
+			return Schedule{}, fmt.Errorf("розклад %s не знайдено", schedule.ID)
+		}
+		s.state.Schedules[index] = schedule
+	}
+	return schedule, s.saveLocked()
+}
+
+// DeleteSchedule видаляє розклад; журнал порушень за ним зберігається.
+func (s *FileStore) DeleteSchedule(id string) error {
+	if s == nil {
+		return errors.New("openclose: store is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	before := len(s.state.Schedules)
+	s.state.Schedules = slices.DeleteFunc(s.state.Schedules, func(schedule Schedule) bool { return schedule.ID == id })
+	if len(s.state.Schedules) == before {
+		return fmt.Errorf("розклад %s не знайдено", id)
+	}
+	return s.saveLocked()
+}
+
+// Holidays повертає спільні святкові дні за датою.
+func (s *FileStore) Holidays() ([]Holiday, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(s.state.Holidays), nil
+}
+
+// SetHolidays замінює спільні святкові дні.
+func (s *FileStore) SetHolidays(holidays []Holiday) error {
+	if s == nil {
+		return errors.New("openclose: store is nil")
+	}
+	for _, holiday := range holidays {
+		if _, err := time.Parse(DateLayout, holiday.Date); err != nil {
+			return fmt.Errorf("некоректна дата святкового дня %q", holiday.Date)
+		}
+	}
+	holidays = slices.Clone(holidays)
+	sortHolidays(holidays)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	s.state.Holidays = holidays
+	return s.saveLocked()
+}
+
+// RecordViolations дописує нові порушення; вже відомі ключі ігноруються.
+func (s *FileStore) RecordViolations(violations []Violation) error {
+	if s == nil || len(violations) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	added := false
+	for _, violation := range violations {
+		if _, ok := s.index[violation.Key]; ok {
+			continue
+		}
+		s.index[violation.Key] = len(s.state.Violations)
+		s.state.Violations = append(s.state.Violations, violation)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	if overflow := len(s.state.Violations) - maxViolations; overflow > 0 {
+		s.state.Violations = slices.Clone(s.state.Violations[overflow:])
+		s.rebuildIndexLocked()
+	}
+	return s.saveLocked()
+}
+
+// HasViolation повідомляє, чи вже зафіксовано порушення з ключем key.
+func (s *FileStore) HasViolation(key string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.index[key]
+	return ok
+}
+
+// UpdateViolations застосовує update до кожного порушення і зберігає файл,
+// якщо хоча б одне змінилось (update повернув true).
+func (s *FileStore) UpdateViolations(update func(*Violation) bool) error {
+	if s == nil {
+		return errors.New("openclose: store is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	changed := false
+	for i := range s.state.Violations {
+		if update(&s.state.Violations[i]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveLocked()
+}
+
+// Violations повертає порушення з Time в [from, to), новіші першими.
+// Нульова межа не обмежує діапазон.
+func (s *FileStore) Violations(from, to time.Time) ([]Violation, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	result := make([]Violation, 0)
+	for _, violation := range s.state.Violations {
+		if !from.IsZero() && violation.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !violation.Time.Before(to) {
+			continue
+		}
+		result = append(result, violation)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.After(result[j].Time)
+	})
+	return result, nil
+}
+
+func (s *FileStore) rebuildIndexLocked() {
+	s.index = make(map[string]int, len(s.state.Violations))
+	for i, violation := range s.state.Violations {
+		s.index[violation.Key] = i
+	}
+}
+
+func (s *FileStore) reloadLocked() error {
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("openclose: stat %s: %w", s.path, err)
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("openclose: read %s: %w", s.path, err)
+	}
+	var state storeFile
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return fmt.Errorf("openclose: decode %s: %w", s.path, err)
+		}
+	}
+	// Розклад задано місцевим часом, тож і моменти порушень показуються в ньому.
+	for i := range state.Violations {
+		violation := &state.Violations[i]
+		violation.Time = violation.Time.Local()
+		if !violation.Expected.IsZero() {
+			violation.Expected = violation.Expected.Local()
+		}
+		if !violation.Actual.IsZero() {
+			violation.Actual = violation.Actual.Local()
+		}
+	}
+	s.state = state
+	s.rebuildIndexLocked()
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("openclose: encode schedules: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("openclose: create %s: %w", dir, err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o600); err != nil {
+		return fmt.Errorf("openclose: write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("openclose: replace %s: %w", s.path, err)
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+	return nil
+}
+
+func sortHolidays(holidays []Holiday) {
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+}
+
+func newScheduleID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("oc-%d", time.Now().UnixNano())
+	}
+	return "oc-" + hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,395 @@
+package openclose
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultEscalateAfter — через скільки необроблене порушення стає критичним.
+	DefaultEscalateAfter = 15 * time.Minute
+	// alarmPeriod — скільки порушення лишається у стрічці без обробки.
+	alarmPeriod = 24 * time.Hour
+	// observeHorizon обмежує давність подій і перевірок, щоб після запуску
+	// не піднімати тривоги за минулі дні.
+	observeHorizon = 12 * time.Hour
+	// checkInterval — як часто перевіряються межі змін.
+	checkInterval = 30 * time.Second
+)
+
+// ObjectLookup дає поточний стан охорони об'єкта, коли подій ще не було.
+type ObjectLookup interface {
+	GetObjectByID(id string) *models.Object
+}
+
+type guardState struct {
+	armed bool
+	at    time.Time
+}
+
+// Supervisor звіряє події постановки/зняття з розкладами і додає порушення
+// до зведеної стрічки тривог. Тривоги мають ID з ids.ScheduleAlarmIDNamespace*.
+type Supervisor struct {
+	store         *FileStore
+	escalateAfter time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	guard     map[string]guardState
+	checked   map[string]time.Time
+	checkedAt time.Time
+}
+
+// NewSupervisor створює Supervisor над store; escalateAfter < 0 — DefaultEscalateAfter,
+// 0 — без ескалації.
+func NewSupervisor(store *FileStore, escalateAfter time.Duration) *Supervisor {
+	if escalateAfter < 0 {
+		escalateAfter = DefaultEscalateAfter
+	}
+	return &Supervisor{
+		store:         store,
+		escalateAfter: escalateAfter,
+		now:           time.Now,
+		guard:         make(map[string]guardState),
+		checked:       make(map[string]time.Time),
+	}
+}
+
+// Open відкриває файл розкладів path і створює для нього Supervisor.
+func Open(path string, escalateAfter time.Duration) (*Supervisor, error) {
+	store, err := OpenFileStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSupervisor(store, escalateAfter), nil
+}
+
+// Store повертає сховище розкладів.
+func (s *Supervisor) Store() *FileStore {
+	if s == nil {
+		return nil
+	}
+	return s.store
+}
+
+// EscalateAfter повертає затримку ескалації (0 — вимкнено).
+func (s *Supervisor) EscalateAfter() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return s.escalateAfter
+}
+
+// ObserveEvents обробляє події постановки та зняття з охорони: запам'ятовує
+// стан охорони для розкладів, фіксує зняття поза розкладом і закриває
+// порушення, які подія виправила. Повторно передані події ігноруються.
+func (s *Supervisor) ObserveEvents(events []models.Event) {
+	if s == nil || s.store == nil || len(events) == 0 {
+		return
+	}
+	schedules, holidays, err := s.load()
+	if err != nil || len(schedules) == 0 {
+		return
+	}
+	now := s.now()
+	guardEvents := make([]models.Event, 0)
+	for _, event := range events {
+		if event.Type != models.EventArm && event.Type != models.EventDisarm {
+			continue
+		}
+		if now.Sub(event.Time) > observeHorizon {
+			continue
+		}
+		guardEvents = append(guardEvents, event)
+	}
+	if len(guardEvents) == 0 {
+		return
+	}
+	sort.SliceStable(guardEvents, func(i, j int) bool { return guardEvents[i].Time.Before(guardEvents[j].Time) })
+
+	var recorded []Violation
+	type resolution struct {
+		scheduleID string
+		kind       Kind
+		at         time.Time
+	}
+	var resolutions []resolution
+
+	s.mu.Lock()
+	for _, event := range guardEvents {
+		armed := event.Type == models.EventArm
+		for _, schedule := range schedules {
+			if !schedule.Matches(event) {
+				continue
+			}
+			if state, ok := s.guard[schedule.ID]; ok && !event.Time.After(state.at) {
+				continue
+			}
+			s.guard[schedule.ID] = guardState{armed: armed, at: event.Time}
+			if armed {
+				resolutions = append(resolutions, resolution{scheduleID: schedule.ID, kind: KindNotArmed, at: event.Time})
+				continue
+			}
+			resolutions = append(resolutions, resolution{scheduleID: schedule.ID, kind: KindLateOpen, at: event.Time})
+			if violation, ok := classifyDisarm(schedule, holidays, event, now); ok {
+				recorded = append(recorded, violation)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.store.RecordViolations(recorded); err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося записати порушення розкладу")
+	}
+	if len(resolutions) == 0 {
+		return
+	}
+	err = s.store.UpdateViolations(func(violation *Violation) bool {
+		if violation.Resolved() {
+			return false
+		}
+		for _, r := range resolutions {
+			if violation.ScheduleID == r.scheduleID && violation.Kind == r.kind && violation.Expected.Before(r.at) {
+				violation.Actual = r.at
+				violation.ResolvedAt = now
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося оновити порушення розкладу")
+	}
+}
+
+// classifyDisarm визначає, чи є зняття порушенням розкладу.
+func classifyDisarm(schedule Schedule, holidays []Holiday, event models.Event, now time.Time) (Violation, bool) {
+	if _, ok := schedule.allowedAt(event.Time, holidays); ok {
+		return Violation{}, false
+	}
+	violation := newViolation(schedule, KindUnscheduledDisarm, event.Time, now)
+	if shift, ok := schedule.ShiftOn(event.Time, holidays); ok && event.Time.Before(shift.Open) {
+		violation.Kind = KindEarlyOpen
+		violation.Expected = shift.Open
+	}
+	violation.Key = eventKey(schedule.ID, violation.Kind, event.Time)
+	violation.UserName = strings.TrimSpace(event.UserName)
+	return violation, true
+}
+
+func newViolation(schedule Schedule, kind Kind, at time.Time, now time.Time) Violation {
+	return Violation{
+		ScheduleID:   schedule.ID,
+		ObjectID:     schedule.ObjectID,
+		ObjectNumber: schedule.ObjectNumber,
+		ObjectName:   schedule.ObjectName,
+		GroupName:    schedule.GroupName,
+		Kind:         kind,
+		Day:          at.Format(DateLayout),
+		Time:         at,
+		DetectedAt:   now,
+	}
+}
+
+// ApplySchedules перевіряє межі змін і додає до alarms необроблені порушення
+// за останню добу. Порушення, не оброблене довше EscalateAfter, стає критичним.
+func (s *Supervisor) ApplySchedules(alarms []models.Alarm, objects ObjectLookup) []models.Alarm {
+	if s == nil || s.store == nil {
+		return alarms
+	}
+	now := s.now()
+	s.checkShifts(now, objects)
+
+	violations, err := s.store.Violations(now.Add(-alarmPeriod), time.Time{})
+	if err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося прочитати порушення розкладу")
+		return alarms
+	}
+	for _, violation := range violations {
+		if violation.Pending() {
+			alarms = append(alarms, s.violationAlarm(violation, now))
+		}
+	}
+	return alarms
+}
+
+// checkShifts фіксує «не поставлено» та «пізнє відкриття» для змін, межа
+// допуску яких минула. Кожна межа перевіряється один раз; невідомий стан
+// охорони перевірку відкладає.
+func (s *Supervisor) checkShifts(now time.Time, objects ObjectLookup) {
+	s.mu.Lock()
+	if now.Sub(s.checkedAt) < checkInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.checkedAt = now
+	for key, deadline := range s.checked {
+		if now.Sub(deadline) > 2*observeHorizon {
+			delete(s.checked, key)
+		}
+	}
+	s.mu.Unlock()
+
+	schedules, holidays, err := s.load()
+	if err != nil {
+		return
+	}
+	var recorded []Violation
+	for _, schedule := range schedules {
+		for _, offset := range []int{-1, 0} {
+			shift, ok := schedule.ShiftOn(now.AddDate(0, 0, offset), holidays)
+			if !ok {
+				continue
+			}
+			closeDeadline := shift.Close.Add(schedule.closeTolerance())
+			if due(now, closeDeadline) {
+				if violation, ok := s.checkShift(schedule, shift, KindNotArmed, closeDeadline, now, objects); ok {
+					violation.Expected = shift.Close
+					recorded = append(recorded, violation)
+				}
+			}
+			openDeadline := shift.Open.Add(schedule.openTolerance())
+			if due(now, openDeadline) && now.Before(shift.Close) {
+				if violation, ok := s.checkShift(schedule, shift, KindLateOpen, openDeadline, now, objects); ok {
+					violation.Expected = shift.Open
+					recorded = append(recorded, violation)
+				}
+			}
+		}
+	}
+	if err := s.store.RecordViolations(recorded); err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося записати порушення розкладу")
+	}
+}
+
+func due(now time.Time, deadline time.Time) bool {
+	return !now.Before(deadline) && now.Sub(deadline) < observeHorizon
+}
+
+// checkShift перевіряє стан охорони на межі зміни: для KindNotArmed порушення —
+// об'єкт знятий, для KindLateOpen — досі під охороною.
+func (s *Supervisor) checkShift(schedule Schedule, shift Shift, kind Kind, deadline time.Time, now time.Time, objects ObjectLookup) (Violation, bool) {
+	key := scheduledKey(schedule.ID, shift.Day, kind)
+	s.mu.Lock()
+	_, done := s.checked[key]
+	state, known := s.guard[schedule.ID]
+	s.mu.Unlock()
+	if done || s.store.HasViolation(key) {
+		return Violation{}, false
+	}
+	if !known && objects != nil {
+		if object := objects.GetObjectByID(strconv.Itoa(schedule.ObjectID)); object != nil {
+			switch object.GuardStatusValue() {
+			case models.GuardStatusGuarded:
+				state, known = guardState{armed: true}, true
+			case models.GuardStatusDisarmed:
+				state, known = guardState{armed: false}, true
+			}
+		}
+	}
+	if !known {
+		return Violation{}, false
+	}
+	s.mu.Lock()
+	s.checked[key] = deadline
+	s.mu.Unlock()
+
+	violated := !state.armed
+	if kind == KindLateOpen {
+		violated = state.armed
+	}
+	if !violated {
+		return Violation{}, false
+	}
+	violation := newViolation(schedule, kind, deadline, now)
+	violation.Key = key
+	violation.Day = shift.Day.Format(DateLayout)
+	return violation, true
+}
+
+func (s *Supervisor) load() ([]Schedule, []Holiday, error) {
+	schedules, err := s.store.Schedules()
+	if err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося прочитати розклади")
+		return nil, nil, err
+	}
+	holidays, err := s.store.Holidays()
+	if err != nil {
+		log.Warn().Err(err).Msg("openclose: не вдалося прочитати святкові дні")
+		return nil, nil, err
+	}
+	return schedules, holidays, nil
+}
+
+func (s *Supervisor) violationAlarm(violation Violation, now time.Time) models.Alarm {
+	number := strings.TrimSpace(violation.ObjectNumber)
+	if number == "" {
+		number = strconv.Itoa(violation.ObjectID)
+	}
+	details := violation.Description()
+	severity := models.VisualSeverityWarning
+	if s.escalateAfter > 0 {
+		if waiting := now.Sub(violation.DetectedAt); waiting >= s.escalateAfter {
+			severity = models.VisualSeverityCritical
+			details = fmt.Sprintf("ЕСКАЛАЦІЯ: не оброблено %d хв. %s", int(waiting/time.Minute), details)
+		}
+	}
+	return models.Alarm{
+		ID:             AlarmID(violation),
+		ObjectID:       violation.ObjectID,
+		ObjectNumber:   number,
+		ObjectName:     violation.ObjectName,
+		Time:           violation.Time,
+		Details:        details,
+		Type:           models.AlarmScheduleViolation,
+		VisualSeverity: severity,
+		CanProcess:     true,
+	}
+}
+
+// AlarmID повертає стабільний від'ємний ID тривоги порушення.
+func AlarmID(violation Violation) int {
+	return ids.StableScheduleAlarmID(violation.Key)
+}
+
+// IsScheduleAlarmID повідомляє, чи належить ID тривозі порушення розкладу.
+func IsScheduleAlarmID(id int) bool {
+	return ids.IsScheduleAlarmID(id)
+}
+
+// Acknowledge підтверджує порушення з ID тривоги alarmID; підтвердження
+// потрапляє у звіт дотримання розкладу.
+func (s *Supervisor) Acknowledge(alarmID int, user string, note string) error {
+	if s == nil || s.store == nil {
+		return errors.New("openclose: supervisor is not configured")
+	}
+	now := s.now()
+	found := false
+	err := s.store.UpdateViolations(func(violation *Violation) bool {
+		if found || violation.Acknowledged() || AlarmID(*violation) != alarmID {
+			return false
+		}
+		found = true
+		violation.AckUser = strings.TrimSpace(user)
+		violation.AckNote = strings.TrimSpace(note)
+		violation.AckAt = now
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("порушення розкладу %d не знайдено", alarmID)
+	}
+	return nil
+}
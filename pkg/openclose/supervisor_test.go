@@ -0,0 +1,153 @@
+package openclose
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type stubObjects map[int]models.Object
+
+func (s stubObjects) GetObjectByID(id string) *models.Object {
+	key, _ := strconv.Atoi(id)
+	object, ok := s[key]
+	if !ok {
+		return nil
+	}
+	return &object
+}
+
+// weekdaySchedule — будні 09:00-18:00 з допуском 15 хв.
+func weekdaySchedule(objectID int, group string) Schedule {
+	schedule := Schedule{ObjectID: objectID, ObjectNumber: strconv.Itoa(objectID), GroupName: group, OpenTolerance: 15, CloseTolerance: 15}
+	for i := range 5 {
+		schedule.Week[i] = Day{Open: 9 * 60, Close: 18 * 60}
+	}
+	schedule.Week[5] = Day{Closed: true}
+	schedule.Week[6] = Day{Closed: true}
+	return schedule
+}
+
+func newTestSupervisor(t *testing.T, now *time.Time, schedules ...Schedule) *Supervisor {
+	t.Helper()
+	store, err := OpenFileStore("")
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	for _, schedule := range schedules {
+		if _, err := store.SaveSchedule(schedule); err != nil {
+			t.Fatalf("SaveSchedule() error = %v", err)
+		}
+	}
+	supervisor := NewSupervisor(store, 30*time.Minute)
+	supervisor.now = func() time.Time { return *now }
+	return supervisor
+}
+
+func scheduleAlarms(alarms []models.Alarm) []models.Alarm {
+	var result []models.Alarm
+	for _, alarm := range alarms {
+		if alarm.Type == models.AlarmScheduleViolation {
+			result = append(result, alarm)
+		}
+	}
+	return result
+}
+
+func TestObserveEventsClassifiesDisarms(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	now := monday.Add(9 * time.Hour)
+	supervisor := newTestSupervisor(t, &now, weekdaySchedule(1, ""), weekdaySchedule(2, "Склад"))
+
+	supervisor.ObserveEvents([]models.Event{
+		{ObjectID: 1, Type: models.EventDisarm, Time: monday.Add(8*time.Hour + 50*time.Minute), UserName: "Іван"},
+		{ObjectID: 1, Type: models.EventDisarm, Time: monday.Add(6 * time.Hour), UserName: "Петро"},
+		{ObjectID: 1, Type: models.EventArm, Time: monday.Add(6*time.Hour + 30*time.Minute)},
+	})
+	now = monday.Add(23 * time.Hour)
+	supervisor.ObserveEvents([]models.Event{
+		{ObjectID: 1, Type: models.EventArm, Time: monday.Add(18 * time.Hour)},
+		{ObjectID: 1, Type: models.EventDisarm, Time: monday.Add(21 * time.Hour)},
+		{ObjectID: 2, GroupName: "Офіс", Type: models.EventDisarm, Time: monday.Add(22 * time.Hour)},
+		{ObjectID: 2, GroupName: "склад", Type: models.EventDisarm, Time: monday.Add(22 * time.Hour)},
+		{ObjectID: 1, Type: models.EventFire, Time: monday.Add(22 * time.Hour)},
+	})
+	// Повторна подача тих самих подій нічого не додає.
+	supervisor.ObserveEvents([]models.Event{{ObjectID: 1, Type: models.EventDisarm, Time: monday.Add(21 * time.Hour)}})
+
+	violations, err := supervisor.Store().Violations(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Violations() error = %v", err)
+	}
+	kinds := make(map[int][]Kind)
+	for _, violation := range violations {
+		kinds[violation.ObjectID] = append(kinds[violation.ObjectID], violation.Kind)
+	}
+	if got := kinds[1]; len(got) != 2 || got[0] != KindUnscheduledDisarm || got[1] != KindEarlyOpen {
+		t.Fatalf("object 1 violations = %v", got)
+	}
+	if got := kinds[2]; len(got) != 1 || got[0] != KindUnscheduledDisarm {
+		t.Fatalf("object 2 violations = %v (group must match case-insensitively)", got)
+	}
+	if early := violations[len(violations)-1]; early.UserName != "Петро" || early.Expected.Hour() != 9 {
+		t.Fatalf("early open = %+v", early)
+	}
+}
+
+func TestApplySchedulesDetectsNotArmedAndLateOpen(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	now := monday.Add(18*time.Hour + 20*time.Minute)
+	supervisor := newTestSupervisor(t, &now, weekdaySchedule(1, ""), weekdaySchedule(2, ""), weekdaySchedule(3, ""))
+	objects := stubObjects{
+		2: {ID: 2, GuardStatus: models.GuardStatusGuarded},
+		3: {ID: 3, GuardStatus: models.GuardStatusUnknown},
+	}
+
+	supervisor.ObserveEvents([]models.Event{{ObjectID: 1, Type: models.EventDisarm, Time: monday.Add(9 * time.Hour)}})
+	alarms := scheduleAlarms(supervisor.ApplySchedules(nil, objects))
+	if len(alarms) != 1 || alarms[0].ObjectID != 1 || alarms[0].VisualSeverity != models.VisualSeverityWarning {
+		t.Fatalf("alarms = %+v", alarms)
+	}
+	notArmedID := alarms[0].ID
+
+	// Незабаром порушення ескалюється, а постановка під охорону його закриває.
+	now = now.Add(40 * time.Minute)
+	supervisor.checkedAt = time.Time{}
+	alarms = scheduleAlarms(supervisor.ApplySchedules(nil, objects))
+	if len(alarms) != 1 || alarms[0].VisualSeverity != models.VisualSeverityCritical {
+		t.Fatalf("escalated alarms = %+v", alarms)
+	}
+	supervisor.ObserveEvents([]models.Event{{ObjectID: 1, Type: models.EventArm, Time: now}})
+	if alarms = scheduleAlarms(supervisor.ApplySchedules(nil, objects)); len(alarms) != 0 {
+		t.Fatalf("resolved violation must leave the alarm list: %+v", alarms)
+	}
+	violations, _ := supervisor.Store().Violations(time.Time{}, time.Time{})
+	if len(violations) != 1 || AlarmID(violations[0]) != notArmedID || violations[0].Actual.IsZero() {
+		t.Fatalf("violations = %+v", violations)
+	}
+
+	// Вівторок 09:20: об'єкт 1 під охороною з вечора — пізнє відкриття.
+	now = monday.Add(24*time.Hour + 9*time.Hour + 20*time.Minute)
+	supervisor.checkedAt = time.Time{}
+	alarms = scheduleAlarms(supervisor.ApplySchedules(nil, objects))
+	if len(alarms) != 2 {
+		t.Fatalf("late open alarms = %+v", alarms)
+	}
+	for _, alarm := range alarms {
+		if err := supervisor.Acknowledge(alarm.ID, "оператор", "подзвонили"); err != nil {
+			t.Fatalf("Acknowledge() error = %v", err)
+		}
+	}
+	if alarms = scheduleAlarms(supervisor.ApplySchedules(nil, objects)); len(alarms) != 0 {
+		t.Fatalf("acknowledged alarms must disappear: %+v", alarms)
+	}
+	if err := supervisor.Acknowledge(notArmedID+1, "", ""); err == nil {
+		t.Fatal("unknown alarm must not be acknowledged")
+	}
+}
@@ -0,0 +1,103 @@
+package openclose
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind — вид порушення розкладу.
+type Kind string
+
+const (
+	// KindNotArmed — після закриття з допуском об'єкт не під охороною.
+	KindNotArmed Kind = "not_armed"
+	// KindUnscheduledDisarm — зняття у вихідний, святковий день або після закриття.
+	KindUnscheduledDisarm Kind = "unscheduled_disarm"
+	// KindEarlyOpen — зняття раніше за відкриття з допуском.
+	KindEarlyOpen Kind = "early_open"
+	// KindLateOpen — після відкриття з допуском об'єкт досі під охороною.
+	KindLateOpen Kind = "late_open"
+)
+
+// Kinds перелічує види порушень у порядку показу.
+var Kinds = []Kind{KindNotArmed, KindUnscheduledDisarm, KindEarlyOpen, KindLateOpen}
+
+// Violation — зафіксоване порушення розкладу. Time — момент порушення:
+// час події для знять, межа допуску для перевірок за розкладом. Порушення
+// «не поставлено» і «пізнє відкриття» закриваються (Resolved) відповідною
+// подією; Actual тоді містить її час.
+type Violation struct {
+	Key          string    `json:"key"`
+	ScheduleID   string    `json:"schedule_id"`
+	ObjectID     int       `json:"object_id"`
+	ObjectNumber string    `json:"object_number,omitempty"`
+	ObjectName   string    `json:"object_name,omitempty"`
+	GroupName    string    `json:"group,omitempty"`
+	Kind         Kind      `json:"kind"`
+	Day          string    `json:"day"`
+	Time         time.Time `json:"time"`
+	Expected     time.Time `json:"expected,omitzero"`
+	Actual       time.Time `json:"actual,omitzero"`
+	UserName     string    `json:"user_name,omitempty"`
+	DetectedAt   time.Time `json:"detected_at"`
+	ResolvedAt   time.Time `json:"resolved_at,omitzero"`
+	AckUser      string    `json:"ack_user,omitempty"`
+	AckNote      string    `json:"ack_note,omitempty"`
+	AckAt        time.Time `json:"ack_at,omitzero"`
+}
+
+// Resolved повідомляє, що порушення закрила пізніша подія.
+func (v Violation) Resolved() bool {
+	return !v.ResolvedAt.IsZero()
+}
+
+// Acknowledged повідомляє, що оператор обробив тривогу порушення.
+func (v Violation) Acknowledged() bool {
+	return !v.AckAt.IsZero()
+}
+
+// Pending повідомляє, що порушення ще має бути у стрічці тривог.
+func (v Violation) Pending() bool {
+	return !v.Resolved() && !v.Acknowledged()
+}
+
+// Description описує порушення для стрічки тривог і звіту.
+func (v Violation) Description() string {
+	var text string
+	switch v.Kind {
+	case KindNotArmed:
+		text = "Не поставлено під охорону після закриття о " + v.Expected.Format("15:04")
+		if !v.Actual.IsZero() {
+			text += ", поставлено о " + v.Actual.Format("15:04")
+		}
+	case KindUnscheduledDisarm:
+		text = "Зняття з охорони поза розкладом о " + v.Time.Format("15:04")
+	case KindEarlyOpen:
+		text = fmt.Sprintf("Раннє відкриття: зняття о %s, за розкладом %s", v.Time.Format("15:04"), v.Expected.Format("15:04"))
+	case KindLateOpen:
+		text = "Пізнє відкриття: не знято з охорони, за розкладом " + v.Expected.Format("15:04")
+		if !v.Actual.IsZero() {
+			text = fmt.Sprintf("Пізнє відкриття: зняття о %s, за розкладом %s", v.Actual.Format("15:04"), v.Expected.Format("15:04"))
+		}
+	default:
+		text = "Порушення розкладу"
+	}
+	if user := strings.TrimSpace(v.UserName); user != "" {
+		text += ", користувач " + user
+	}
+	if group := strings.TrimSpace(v.GroupName); group != "" {
+		text += " (група " + group + ")"
+	}
+	return text
+}
+
+// scheduledKey — ключ перевірки за розкладом: одна на зміну й вид.
+func scheduledKey(scheduleID string, day time.Time, kind Kind) string {
+	return scheduleID + "|" + day.Format(DateLayout) + "|" + string(kind)
+}
+
+// eventKey — ключ порушення, спричиненого подією.
+func eventKey(scheduleID string, kind Kind, at time.Time) string {
+	return scheduleID + "|" + string(kind) + "|" + at.UTC().Format(time.RFC3339Nano)
+}
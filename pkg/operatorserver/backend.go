@@ -0,0 +1,394 @@
+package operatorserver
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Source — один зібраний бекенд сервера: фронтенд-контракт, провайдер даних
+// для адмін-API і функція звільнення ресурсів (зазвичай dataruntime.Runtime.Close).
+type Source struct {
+	Frontend contracts.FrontendBackend
+	Provider contracts.DataProvider
+	Close    func()
+}
+
+type generation struct {
+	source   Source
+	inFlight sync.WaitGroup
+}
+
+// ReloadableBackend публікує поточний Source як contracts.FrontendBackend
+// і дозволяє замінити його без перезапуску HTTP-сервера. Запити, що вже
+// виконуються, завершуються на старому Source; його ресурси закриваються
+// після того, як усі вони повернуться.
+type ReloadableBackend struct {
+	mu           sync.RWMutex
+	current      *generation
+	savedViews   contracts.FrontendSavedViewsBackend
+	objectGroups contracts.FrontendObjectGroupsBackend
+}
+
+func NewReloadableBackend(source Source) *ReloadableBackend {
+	b := &ReloadableBackend{}
+	if source.Frontend != nil {
+		b.current = &generation{source: source}
+	}
+	return b
+}
+
+// Replace встановлює новий Source і чекає завершення запитів до попереднього,
+// після чого викликає його Close.
+func (b *ReloadableBackend) Replace(source Source) {
+	var next *generation
+	if source.Frontend != nil {
+		next = &generation{source: source}
+	}
+	b.mu.Lock()
+	previous := b.current
+	b.current = next
+	b.mu.Unlock()
+	releaseGeneration(previous)
+}
+
+// Close від'єднує поточний Source і звільняє його ресурси.
+func (b *ReloadableBackend) Close() {
+	b.Replace(Source{})
+}
+
+// SetSavedViews підключає сховище збережених подань. Воно не залежить від
+// Source і переживає перезавантаження підключень до джерел.
+func (b *ReloadableBackend) SetSavedViews(store contracts.FrontendSavedViewsBackend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.savedViews = store
+}
+
+// SetObjectGroups підключає спільні групи об'єктів. Як і збережені подання,
+// вони не залежать від Source і переживають перезавантаження.
+func (b *ReloadableBackend) SetObjectGroups(groups contracts.FrontendObjectGroupsBackend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objectGroups = groups
+}
+
+// Provider повертає провайдер даних поточного Source для адмін-API.
+// Як і запити фронтенду, виклик тримає Source до release: перезавантаження
+// закриє його лише після того, як провайдер перестануть використовувати.
+func (b *ReloadableBackend) Provider() (contracts.DataProvider, func(), error) {
+	if b == nil {
+		return nil, nil, contracts.ErrFrontendBackendUnavailable
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.current == nil || b.current.source.Provider == nil {
+		return nil, nil, contracts.ErrFrontendBackendUnavailable
+	}
+	item := b.current
+	item.inFlight.Add(1)
+	return item.source.Provider, item.inFlight.Done, nil
+}
+
+func releaseGeneration(item *generation) {
+	if item == nil {
+		return
+	}
+	item.inFlight.Wait()
+	if item.source.Close != nil {
+		item.source.Close()
+	}
+}
+
+func (b *ReloadableBackend) acquire() (contracts.FrontendBackend, func(), error) {
+	if b == nil {
+		return nil, nil, contracts.ErrFrontendBackendUnavailable
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.current == nil {
+		return nil, nil, contracts.ErrFrontendBackendUnavailable
+	}
+	item := b.current
+	item.inFlight.Add(1)
+	return item.source.Frontend, item.inFlight.Done, nil
+}
+
+func (b *ReloadableBackend) Capabilities(ctx context.Context) (contracts.FrontendCapabilities, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return contracts.FrontendCapabilities{}, err
+	}
+	defer release()
+	return backend.Capabilities(ctx)
+}
+
+func (b *ReloadableBackend) ListObjects(ctx context.Context) ([]contracts.FrontendObjectSummary, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.ListObjects(ctx)
+}
+
+func (b *ReloadableBackend) ListAlarms(ctx context.Context) ([]contracts.FrontendAlarmItem, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.ListAlarms(ctx)
+}
+
+func (b *ReloadableBackend) GetAlarmProcessingOptions(ctx context.Context, alarmID int) ([]contracts.FrontendAlarmProcessingOption, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.GetAlarmProcessingOptions(ctx, alarmID)
+}
+
+func (b *ReloadableBackend) ListAlarmProcessingOptionsCached(ctx context.Context) ([]contracts.FrontendAlarmProcessingOption, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.ListAlarmProcessingOptionsCached(ctx)
+}
+
+func (b *ReloadableBackend) PickAlarm(ctx context.Context, alarmID int, request contracts.FrontendAlarmPickRequest) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.PickAlarm(ctx, alarmID, request)
+}
+
+func (b *ReloadableBackend) ProcessAlarm(ctx context.Context, alarmID int, request contracts.FrontendAlarmProcessRequest) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.ProcessAlarm(ctx, alarmID, request)
+}
+
+func (b *ReloadableBackend) GroupProcessAlarm(ctx context.Context, alarmID int, user string) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.GroupProcessAlarm(ctx, alarmID, user)
+}
+
+func (b *ReloadableBackend) StandbyObject(ctx context.Context, objectID int, request contracts.FrontendStandbyRequest) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.StandbyObject(ctx, objectID, request)
+}
+
+func (b *ReloadableBackend) ListResponseGroups(ctx context.Context) ([]contracts.FrontendResponseGroup, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.ListResponseGroups(ctx)
+}
+
+func (b *ReloadableBackend) ListResponseGroupsForAlarm(ctx context.Context, alarmID int) ([]contracts.FrontendResponseGroup, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if alarmBackend, ok := backend.(contracts.FrontendAlarmResponseGroupsBackend); ok {
+		return alarmBackend.ListResponseGroupsForAlarm(ctx, alarmID)
+	}
+	return backend.ListResponseGroups(ctx)
+}
+
+func (b *ReloadableBackend) ExportEvents(ctx context.Context, request contracts.FrontendEventExportRequest, w io.Writer) (int, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	if exportBackend, ok := backend.(contracts.FrontendEventExportBackend); ok {
+		return exportBackend.ExportEvents(ctx, request, w)
+	}
+	return 0, contracts.ErrUnsupportedFrontendSource
+}
+
+func (b *ReloadableBackend) QueryObjects(ctx context.Context, query string) ([]contracts.FrontendObjectSummary, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if queryBackend, ok := backend.(contracts.FrontendQueryBackend); ok {
+		return queryBackend.QueryObjects(ctx, query)
+	}
+	return nil, contracts.ErrUnsupportedFrontendSource
+}
+
+func (b *ReloadableBackend) QueryEvents(ctx context.Context, query string) ([]contracts.FrontendEventItem, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if queryBackend, ok := backend.(contracts.FrontendQueryBackend); ok {
+		return queryBackend.QueryEvents(ctx, query)
+	}
+	return nil, contracts.ErrUnsupportedFrontendSource
+}
+
+func (b *ReloadableBackend) ListSavedViews(ctx context.Context) ([]contracts.FrontendSavedView, error) {
+	store := b.savedViewsStore()
+	if store == nil {
+		return nil, contracts.ErrUnsupportedFrontendSource
+	}
+	return store.ListSavedViews(ctx)
+}
+
+func (b *ReloadableBackend) SaveSavedViews(ctx context.Context, views []contracts.FrontendSavedView) error {
+	store := b.savedViewsStore()
+	if store == nil {
+		return contracts.ErrUnsupportedFrontendSource
+	}
+	return store.SaveSavedViews(ctx, views)
+}
+
+func (b *ReloadableBackend) savedViewsStore() contracts.FrontendSavedViewsBackend {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.savedViews
+}
+
+func (b *ReloadableBackend) ListObjectGroups(ctx context.Context) ([]contracts.FrontendObjectGroup, string, error) {
+	groups := b.objectGroupsBackend()
+	if groups == nil {
+		return nil, "", contracts.ErrUnsupportedFrontendSource
+	}
+	return groups.ListObjectGroups(ctx)
+}
+
+func (b *ReloadableBackend) SaveObjectGroups(ctx context.Context, items []contracts.FrontendObjectGroup, revision string) (string, error) {
+	groups := b.objectGroupsBackend()
+	if groups == nil {
+		return "", contracts.ErrUnsupportedFrontendSource
+	}
+	return groups.SaveObjectGroups(ctx, items, revision)
+}
+
+func (b *ReloadableBackend) ObjectGroupMembers(ctx context.Context, groupID string) (map[int]struct{}, error) {
+	groups := b.objectGroupsBackend()
+	if groups == nil {
+		return nil, contracts.ErrUnsupportedFrontendSource
+	}
+	return groups.ObjectGroupMembers(ctx, groupID)
+}
+
+func (b *ReloadableBackend) WatchedObjects(ctx context.Context) (map[int]struct{}, error) {
+	groups := b.objectGroupsBackend()
+	if groups == nil {
+		// Без груп список спостереження просто порожній, тривоги віддаються як є.
+		return nil, nil
+	}
+	return groups.WatchedObjects(ctx)
+}
+
+func (b *ReloadableBackend) objectGroupsBackend() contracts.FrontendObjectGroupsBackend {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.objectGroups
+}
+
+func (b *ReloadableBackend) AssignResponseGroup(ctx context.Context, alarmID int, request contracts.FrontendAlarmGroupActionRequest) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.AssignResponseGroup(ctx, alarmID, request)
+}
+
+func (b *ReloadableBackend) NotifyGroupArrived(ctx context.Context, alarmID int) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.NotifyGroupArrived(ctx, alarmID)
+}
+
+func (b *ReloadableBackend) CancelResponseGroup(ctx context.Context, alarmID int) error {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return backend.CancelResponseGroup(ctx, alarmID)
+}
+
+func (b *ReloadableBackend) ListEvents(ctx context.Context) ([]contracts.FrontendEventItem, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return backend.ListEvents(ctx)
+}
+
+func (b *ReloadableBackend) ListObjectEvents(ctx context.Context, objectID int, offset int, limit int) (contracts.FrontendEventPage, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return contracts.FrontendEventPage{}, err
+	}
+	defer release()
+	return backend.ListObjectEvents(ctx, objectID, offset, limit)
+}
+
+func (b *ReloadableBackend) GetObjectDetails(ctx context.Context, objectID int) (contracts.FrontendObjectDetails, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return contracts.FrontendObjectDetails{}, err
+	}
+	defer release()
+	return backend.GetObjectDetails(ctx, objectID)
+}
+
+func (b *ReloadableBackend) CreateObject(ctx context.Context, request contracts.FrontendObjectUpsertRequest) (contracts.FrontendObjectMutationResult, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return contracts.FrontendObjectMutationResult{}, err
+	}
+	defer release()
+	return backend.CreateObject(ctx, request)
+}
+
+func (b *ReloadableBackend) UpdateObject(ctx context.Context, request contracts.FrontendObjectUpsertRequest) (contracts.FrontendObjectMutationResult, error) {
+	backend, release, err := b.acquire()
+	if err != nil {
+		return contracts.FrontendObjectMutationResult{}, err
+	}
+	defer release()
+	return backend.UpdateObject(ctx, request)
+}
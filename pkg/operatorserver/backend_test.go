@@ -0,0 +1,159 @@
+package operatorserver
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+)
+
+type blockingFrontend struct {
+	contracts.FrontendBackend
+
+	name    string
+	calls   atomic.Int32
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingFrontend) ListObjects(context.Context) ([]contracts.FrontendObjectSummary, error) {
+	if f.entered != nil && f.calls.Add(1) == 1 {
+		close(f.entered)
+		<-f.release
+	}
+	return []contracts.FrontendObjectSummary{{Name: f.name}}, nil
+}
+
+func TestReloadableBackendUnavailableWithoutSource(t *testing.T) {
+	t.Parallel()
+
+	backend := NewReloadableBackend(Source{})
+	if _, err := backend.ListObjects(context.Background()); !errors.Is(err, contracts.ErrFrontendBackendUnavailable) {
+		t.Fatalf("ListObjects() error = %v, want unavailable", err)
+	}
+}
+
+func TestReloadableBackendReplaceDrainsPreviousSource(t *testing.T) {
+	t.Parallel()
+
+	var closed atomic.Bool
+	old := &blockingFrontend{name: "old", entered: make(chan struct{}), release: make(chan struct{})}
+	backend := NewReloadableBackend(Source{Frontend: old, Close: func() { closed.Store(true) }})
+
+	result := make(chan string, 1)
+	go func() {
+		items, _ := backend.ListObjects(context.Background())
+		result <- items[0].Name
+	}()
+	<-old.entered
+
+	replaced := make(chan struct{})
+	go func() {
+		backend.Replace(Source{Frontend: &blockingFrontend{name: "new"}})
+		close(replaced)
+	}()
+
+	deadline := time.After(time.Second)
+	for {
+		items, err := backend.ListObjects(context.Background())
+		if err == nil && items[0].Name == "new" {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("new source was not published while old request is running")
+		default:
+		}
+	}
+	if closed.Load() {
+		t.Fatal("previous source closed before in-flight request finished")
+	}
+
+	close(old.release)
+	<-replaced
+	if got := <-result; got != "old" {
+		t.Fatalf("in-flight request result = %q, want old", got)
+	}
+	if !closed.Load() {
+		t.Fatal("previous source was not closed after drain")
+	}
+}
+
+type stubDataProvider struct {
+	contracts.DataProvider
+}
+
+func TestReloadableBackendProviderHoldsSourceUntilRelease(t *testing.T) {
+	t.Parallel()
+
+	var closed atomic.Bool
+	provider := &stubDataProvider{}
+	backend := NewReloadableBackend(Source{Frontend: &blockingFrontend{name: "old"}, Provider: provider, Close: func() { closed.Store(true) }})
+
+	current, release, err := backend.Provider()
+	if err != nil || current != contracts.DataProvider(provider) {
+		t.Fatalf("Provider() = %v, %v", current, err)
+	}
+	replaced := make(chan struct{})
+	go func() {
+		backend.Replace(Source{Frontend: &blockingFrontend{name: "new"}})
+		close(replaced)
+	}()
+
+	select {
+	case <-replaced:
+		t.Fatal("reload closed the source while its provider is in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if closed.Load() {
+		t.Fatal("previous source closed before provider release")
+	}
+	release()
+	<-replaced
+	if !closed.Load() {
+		t.Fatal("previous source was not closed after provider release")
+	}
+	if _, _, err := backend.Provider(); !errors.Is(err, contracts.ErrFrontendBackendUnavailable) {
+		t.Fatalf("Provider() without data provider error = %v, want unavailable", err)
+	}
+}
+
+func TestReloadableBackendObjectGroupsSurviveReplace(t *testing.T) {
+	t.Parallel()
+
+	backend := NewReloadableBackend(Source{})
+	if _, _, err := backend.ListObjectGroups(context.Background()); !errors.Is(err, contracts.ErrUnsupportedFrontendSource) {
+		t.Fatalf("ListObjectGroups() without groups error = %v, want unsupported", err)
+	}
+	if watched, err := backend.WatchedObjects(context.Background()); err != nil || len(watched) != 0 {
+		t.Fatalf("WatchedObjects() without groups = %v, %v, want empty", watched, err)
+	}
+
+	store, err := objectgroups.OpenFileStore(filepath.Join(t.TempDir(), "object-groups.json"))
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	backend.SetObjectGroups(objectgroups.NewFrontendBackend(objectgroups.NewRegistry(store, nil)))
+	revision, err := backend.SaveObjectGroups(context.Background(), []contracts.FrontendObjectGroup{{Name: "Банки", ObjectIDs: []int{7}, Watch: true}}, "")
+	if err != nil {
+		t.Fatalf("SaveObjectGroups() error = %v", err)
+	}
+	backend.Replace(Source{Frontend: &blockingFrontend{name: "next"}})
+
+	groups, listed, err := backend.ListObjectGroups(context.Background())
+	if err != nil || len(groups) != 1 || groups[0].ID == "" || listed != revision {
+		t.Fatalf("ListObjectGroups() after replace = %+v, %q, %v", groups, listed, err)
+	}
+	members, err := backend.ObjectGroupMembers(context.Background(), groups[0].ID)
+	if _, ok := members[7]; err != nil || !ok {
+		t.Fatalf("ObjectGroupMembers() = %v, %v, want object 7", members, err)
+	}
+	if watched, _ := backend.WatchedObjects(context.Background()); len(watched) != 1 {
+		t.Fatalf("WatchedObjects() = %v, want object 7", watched)
+	}
+}
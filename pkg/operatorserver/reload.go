@@ -0,0 +1,50 @@
+package operatorserver
+
+import (
+	"net/http"
+
+	"obj_catalog_fyne_v3/pkg/adminhttp"
+)
+
+// ReloadPath перечитує конфіг і перебудовує підключення до джерел так само,
+// як SIGHUP, якого немає у Windows.
+const ReloadPath = "/api/operator-server/v1/reload"
+
+// WithReload вмикає ReloadPath. Маршрут захищений адмін-токенами (роль admin),
+// а не access_token; без auth він відповідає 401.
+func WithReload(reload func() error, auth adminhttp.Authenticator) Option {
+	return func(s *Sessions) {
+		s.reload = reload
+		s.reloadAuth = auth
+	}
+}
+
+func (s *Sessions) handleReload(w http.ResponseWriter, r *http.Request) {
+	if s.reload == nil {
+		writeError(w, http.StatusNotFound, "reload is not configured")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if s.reloadAuth == nil {
+		writeError(w, http.StatusUnauthorized, "admin token required")
+		return
+	}
+	role, ok := s.reloadAuth(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "admin token required")
+		return
+	}
+	if !role.Allows(adminhttp.RoleAdmin) {
+		writeError(w, http.StatusForbidden, "admin role required")
+		return
+	}
+	if err := s.reload(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "reloaded"})
+}
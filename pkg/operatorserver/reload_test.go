@@ -0,0 +1,67 @@
+package operatorserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/adminhttp"
+)
+
+func TestReloadRequiresAdminToken(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	auth := adminhttp.TokenAuthenticator(map[string]adminhttp.Role{
+		"viewer-token": adminhttp.RoleViewer,
+		"admin-token":  adminhttp.RoleAdmin,
+	})
+	sessions := NewSessions(
+		WithAccessToken("secret"),
+		WithReload(func() error { calls++; return nil }, auth),
+	)
+	handler := sessions.Middleware(okHandler())
+
+	post := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, ReloadPath, nil)
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := post(""); code != http.StatusUnauthorized {
+		t.Fatalf("without token status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := post("viewer-token"); code != http.StatusForbidden {
+		t.Fatalf("viewer status = %d, want %d", code, http.StatusForbidden)
+	}
+	if code := post("admin-token"); code != http.StatusOK {
+		t.Fatalf("admin status = %d, want %d", code, http.StatusOK)
+	}
+	if calls != 1 {
+		t.Fatalf("reload calls = %d, want 1", calls)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReloadPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestReloadReportsFailure(t *testing.T) {
+	t.Parallel()
+
+	auth := adminhttp.TokenAuthenticator(map[string]adminhttp.Role{"admin-token": adminhttp.RoleAdmin})
+	sessions := NewSessions(WithReload(func() error { return errors.New("config is broken") }, auth))
+	req := httptest.NewRequest(http.MethodPost, ReloadPath, nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rec := httptest.NewRecorder()
+	sessions.Middleware(okHandler()).ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,75 @@
+package operatorserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/usecases"
+)
+
+// SavedViewsFile зберігає спільні для всіх веб-клієнтів збережені подання
+// фільтрів у JSON-файлі. Файл читається при кожному запиті, тож його можна
+// правити вручну без перезапуску сервера.
+type SavedViewsFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewSavedViewsFile створює сховище; файл з'являється при першому записі.
+func NewSavedViewsFile(path string) *SavedViewsFile {
+	return &SavedViewsFile{path: strings.TrimSpace(path)}
+}
+
+func (f *SavedViewsFile) ListSavedViews(ctx context.Context) ([]contracts.FrontendSavedView, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	raw, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []contracts.FrontendSavedView{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saved views: read %s: %w", f.path, err)
+	}
+	views, err := config.ParseSavedViews(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("saved views: %s: %w", f.path, err)
+	}
+	result := make([]contracts.FrontendSavedView, 0, len(views))
+	for _, view := range views {
+		result = append(result, contracts.FrontendSavedView{Name: view.Name, Query: view.Query})
+	}
+	return result, nil
+}
+
+func (f *SavedViewsFile) SaveSavedViews(ctx context.Context, views []contracts.FrontendSavedView) error {
+	items := make([]config.SavedView, 0, len(views))
+	for _, view := range views {
+		items = append(items, config.SavedView{Name: view.Name, Query: view.Query})
+	}
+	items, err := usecases.NormalizeSavedViews(items)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if dir := filepath.Dir(f.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("saved views: create %s: %w", dir, err)
+		}
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(config.FormatSavedViews(items)), 0o600); err != nil {
+		return fmt.Errorf("saved views: write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("saved views: replace %s: %w", f.path, err)
+	}
+	return nil
+}
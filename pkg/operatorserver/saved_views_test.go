@@ -0,0 +1,36 @@
+package operatorserver
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+func TestSavedViewsFileRoundTripThroughBackend(t *testing.T) {
+	t.Parallel()
+
+	backend := NewReloadableBackend(Source{})
+	if _, err := backend.ListSavedViews(context.Background()); !errors.Is(err, contracts.ErrUnsupportedFrontendSource) {
+		t.Fatalf("ListSavedViews() without store error = %v, want unsupported", err)
+	}
+
+	backend.SetSavedViews(NewSavedViewsFile(filepath.Join(t.TempDir(), "views", "saved_views.json")))
+	views, err := backend.ListSavedViews(context.Background())
+	if err != nil || len(views) != 0 {
+		t.Fatalf("ListSavedViews() on a missing file = %+v, %v", views, err)
+	}
+	want := []contracts.FrontendSavedView{{Name: "Офлайн CASL", Query: "source:casl status:offline"}}
+	if err := backend.SaveSavedViews(context.Background(), want); err != nil {
+		t.Fatalf("SaveSavedViews() error = %v", err)
+	}
+	views, err = backend.ListSavedViews(context.Background())
+	if err != nil || len(views) != 1 || views[0] != want[0] {
+		t.Fatalf("ListSavedViews() = %+v, %v, want %+v", views, err, want)
+	}
+	if err := backend.SaveSavedViews(context.Background(), []contracts.FrontendSavedView{{Name: "Зламане", Query: "lasttest:48h"}}); err == nil {
+		t.Fatal("SaveSavedViews() must reject an invalid expression")
+	}
+}
@@ -0,0 +1,329 @@
+package operatorserver
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/adminhttp"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/frontendhttp"
+)
+
+const (
+	// SessionsPath публікує список активних сесій тонких клієнтів.
+	SessionsPath = "/api/operator-server/v1/sessions"
+
+	clientCookieName = "most_client"
+	tokenCookieName  = "most_token"
+	tokenQueryParam  = "token"
+	contentType      = "application/json; charset=utf-8"
+
+	defaultMaxClients  = 32
+	defaultMaxInFlight = 8
+	defaultIdleTimeout = 30 * time.Minute
+)
+
+// SessionInfo — стан однієї сесії для SessionsPath і журналу.
+// ClientID є cookie й ідентичністю оренд тривог клієнта, тож назовні
+// віддається лише його відбиток ClientRef.
+type SessionInfo struct {
+	ClientID   string    `json:"-"`
+	ClientRef  string    `json:"client_ref"`
+	RemoteAddr string    `json:"remote_addr"`
+	UserAgent  string    `json:"user_agent"`
+	StartedAt  time.Time `json:"started_at"`
+	LastSeen   time.Time `json:"last_seen"`
+	Requests   int64     `json:"requests"`
+	InFlight   int       `json:"in_flight"`
+}
+
+type session struct {
+	info SessionInfo
+}
+
+type Option func(*Sessions)
+
+// WithMaxClients обмежує кількість одночасних сесій; понад ліміт сервер відповідає 503.
+func WithMaxClients(limit int) Option {
+	return func(s *Sessions) {
+		if limit > 0 {
+			s.maxClients = limit
+		}
+	}
+}
+
+// WithMaxInFlight обмежує кількість паралельних запитів однієї сесії; понад ліміт — 429.
+func WithMaxInFlight(limit int) Option {
+	return func(s *Sessions) {
+		if limit > 0 {
+			s.maxInFlight = limit
+		}
+	}
+}
+
+// WithIdleTimeout задає, через скільки без запитів сесія звільняє місце.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(s *Sessions) {
+		if timeout > 0 {
+			s.idleTimeout = timeout
+		}
+	}
+}
+
+// WithAccessToken вимагає токен для /api/* (крім адмін-API, що має власні токени).
+// Клієнт передає Authorization: Bearer, а браузер — ?token= на першій сторінці,
+// після чого токен зберігається в cookie.
+func WithAccessToken(token string) Option {
+	return func(s *Sessions) {
+		s.token = strings.TrimSpace(token)
+	}
+}
+
+// Sessions веде облік тонких клієнтів сервера оператора і застосовує ліміти.
+// Клієнт визначається заголовком frontendhttp.ClientIDHeader (Wails, Qt)
+// або cookie, яку сервер видає браузеру разом зі сторінкою; запити до API
+// без ідентифікатора відхиляються.
+type Sessions struct {
+	maxClients  int
+	maxInFlight int
+	idleTimeout time.Duration
+	token       string
+	reload      func() error
+	reloadAuth  adminhttp.Authenticator
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+func NewSessions(opts ...Option) *Sessions {
+	s := &Sessions{
+		maxClients:  defaultMaxClients,
+		maxInFlight: defaultMaxInFlight,
+		idleTimeout: defaultIdleTimeout,
+		now:         time.Now,
+		sessions:    make(map[string]*session),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// Middleware обгортає обробник сайту перевіркою токена, сесій і лімітів.
+// SessionsPath і ReloadPath обробляються самим middleware.
+func (s *Sessions) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorize(w, r) {
+			writeError(w, http.StatusUnauthorized, "operator server token required")
+			return
+		}
+		switch r.URL.Path {
+		case SessionsPath:
+			s.handleSessions(w, r)
+			return
+		case ReloadPath:
+			s.handleReload(w, r)
+			return
+		}
+
+		clientID, ok := s.clientID(w, r)
+		if !ok {
+			// Сторінки сайту віддаються без сесії, щоб не займати місце в maxClients.
+			// API без ідентифікатора обійшло б ліміти, а тривоги МІСТ узяло б від імені сервера.
+			if !sessionlessPath(r.URL.Path) {
+				writeError(w, http.StatusBadRequest, "client id required: send "+frontendhttp.ClientIDHeader+" or keep the "+clientCookieName+" cookie")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		status := s.begin(clientID, r)
+		if status != 0 {
+			if status == http.StatusServiceUnavailable {
+				writeError(w, status, "operator server client limit reached")
+			} else {
+				writeError(w, status, "too many concurrent requests")
+			}
+			return
+		}
+		defer s.end(clientID)
+		// Володіння тривогами МІСТ ведеться окремо для кожного клієнта.
+		ctx := contracts.WithFrontendClient(r.Context(), contracts.FrontendClient{ID: clientID, Workstation: remoteHost(r)})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Snapshot повертає активні сесії, впорядковані за часом початку.
+func (s *Sessions) Snapshot() []SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweepLocked()
+	result := make([]SessionInfo, 0, len(s.sessions))
+	for _, item := range s.sessions {
+		result = append(result, item.info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].ClientID < result[j].ClientID
+		}
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+func (s *Sessions) begin(clientID string, r *http.Request) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	item, ok := s.sessions[clientID]
+	if !ok {
+		s.sweepLocked()
+		if len(s.sessions) >= s.maxClients {
+			return http.StatusServiceUnavailable
+		}
+		item = &session{info: SessionInfo{
+			ClientID:   clientID,
+			ClientRef:  clientRef(clientID),
+			RemoteAddr: remoteHost(r),
+			UserAgent:  r.UserAgent(),
+			StartedAt:  now,
+		}}
+		s.sessions[clientID] = item
+	}
+	if item.info.InFlight >= s.maxInFlight {
+		return http.StatusTooManyRequests
+	}
+	item.info.InFlight++
+	item.info.Requests++
+	item.info.LastSeen = now
+	return 0
+}
+
+func (s *Sessions) end(clientID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.sessions[clientID]; ok {
+		item.info.InFlight--
+		item.info.LastSeen = s.now()
+	}
+}
+
+func (s *Sessions) sweepLocked() {
+	deadline := s.now().Add(-s.idleTimeout)
+	for id, item := range s.sessions {
+		if item.info.InFlight == 0 && item.info.LastSeen.Before(deadline) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// clientRef повертає відбиток ідентифікатора клієнта для списку сесій.
+func clientRef(clientID string) string {
+	sum := sha256.Sum256([]byte(clientID))
+	return hex.EncodeToString(sum[:6])
+}
+
+// clientID повертає ідентифікатор клієнта із заголовка або cookie. Клієнт без
+// ідентифікатора отримує cookie, а сесія відкривається з наступного запиту.
+func (s *Sessions) clientID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if id := strings.TrimSpace(r.Header.Get(frontendhttp.ClientIDHeader)); id != "" {
+		return id, true
+	}
+	if cookie, err := r.Cookie(clientCookieName); err == nil && strings.TrimSpace(cookie.Value) != "" {
+		return cookie.Value, true
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     clientCookieName,
+		Value:    rand.Text(),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return "", false
+}
+
+func (s *Sessions) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	if token := strings.TrimSpace(r.URL.Query().Get(tokenQueryParam)); token != "" && s.tokenMatches(token) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     tokenCookieName,
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		return true
+	}
+	if sessionlessPath(r.URL.Path) {
+		return true
+	}
+	if header := strings.TrimSpace(r.Header.Get("Authorization")); header != "" {
+		scheme, value, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && s.tokenMatches(strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	if cookie, err := r.Cookie(tokenCookieName); err == nil && s.tokenMatches(cookie.Value) {
+		return true
+	}
+	return false
+}
+
+// sessionlessPath повідомляє, що шлях не потребує токена і сесії клієнта:
+// сторінки сайту, OpenAPI та API з власною автентифікацією.
+func sessionlessPath(path string) bool {
+	return !strings.HasPrefix(path, "/api/") ||
+		strings.HasPrefix(path, adminhttp.APIV1BasePath) ||
+		path == ReloadPath ||
+		path == frontendhttp.OpenAPIPath ||
+		path == frontendhttp.OpenAPIAliasPath
+}
+
+func (s *Sessions) tokenMatches(candidate string) bool {
+	return subtle.ConstantTimeCompare([]byte(candidate), []byte(s.token)) == 1
+}
+
+func (s *Sessions) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"max_clients":   s.maxClients,
+		"max_in_flight": s.maxInFlight,
+		"items":         s.Snapshot(),
+	})
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
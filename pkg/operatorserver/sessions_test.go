@@ -0,0 +1,257 @@
+package operatorserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/frontendhttp"
+)
+
+func newSessionRequest(clientID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, frontendhttp.APIV1BasePath+"/alarms", nil)
+	if clientID != "" {
+		req.Header.Set(frontendhttp.ClientIDHeader, clientID)
+	}
+	return req
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func TestSessionsRejectClientsOverLimit(t *testing.T) {
+	t.Parallel()
+
+	sessions := NewSessions(WithMaxClients(2))
+	handler := sessions.Middleware(okHandler())
+
+	for _, id := range []string{"ws-1", "ws-2", "ws-1"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSessionRequest(id))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("client %s status = %d, want %d", id, rec.Code, http.StatusNoContent)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSessionRequest("ws-3"))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("third client status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	snapshot := sessions.Snapshot()
+	if len(snapshot) != 2 || snapshot[0].Requests+snapshot[1].Requests != 3 {
+		t.Fatalf("unexpected sessions: %+v", snapshot)
+	}
+}
+
+func TestSessionsExpireIdleClients(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	sessions := NewSessions(WithMaxClients(1), WithIdleTimeout(time.Minute))
+	sessions.now = func() time.Time { return now }
+	handler := sessions.Middleware(okHandler())
+
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("ws-1"))
+	now = now.Add(2 * time.Minute)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSessionRequest("ws-2"))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status after idle timeout = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if snapshot := sessions.Snapshot(); len(snapshot) != 1 || snapshot[0].ClientID != "ws-2" {
+		t.Fatalf("unexpected sessions: %+v", snapshot)
+	}
+}
+
+func TestSessionsLimitConcurrentRequestsPerClient(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	sessions := NewSessions(WithMaxInFlight(1))
+	handler := sessions.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("ws-1"))
+	})
+	<-started
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSessionRequest("ws-1"))
+	close(release)
+	wg.Wait()
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second concurrent request status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestSessionsAssignCookieToBrowserClients(t *testing.T) {
+	t.Parallel()
+
+	sessions := NewSessions()
+	handler := sessions.Middleware(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := rec.Result().Cookies()
+	if rec.Code != http.StatusNoContent || len(cookies) != 1 || cookies[0].Name != clientCookieName || cookies[0].Value == "" {
+		t.Fatalf("status = %d, cookies = %+v", rec.Code, cookies)
+	}
+
+	req := newSessionRequest("")
+	req.AddCookie(cookies[0])
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	snapshot := sessions.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].ClientID != cookies[0].Value || snapshot[0].Requests != 1 {
+		t.Fatalf("unexpected sessions: %+v", snapshot)
+	}
+}
+
+func TestSessionsRejectAnonymousAPIRequests(t *testing.T) {
+	t.Parallel()
+
+	var reached bool
+	sessions := NewSessions(WithMaxClients(1))
+	handler := sessions.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for range 5 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSessionRequest(""))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("anonymous API status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	}
+	if reached {
+		t.Fatal("anonymous API request reached the backend")
+	}
+
+	for _, path := range []string{"/", "/app.js", frontendhttp.OpenAPIPath} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("%s status = %d, want %d", path, rec.Code, http.StatusNoContent)
+		}
+	}
+	if snapshot := sessions.Snapshot(); len(snapshot) != 0 {
+		t.Fatalf("anonymous requests opened sessions: %+v", snapshot)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSessionRequest("ws-1"))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("identified client status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestSessionsAccessToken(t *testing.T) {
+	t.Parallel()
+
+	sessions := NewSessions(WithAccessToken("secret"))
+	handler := sessions.Middleware(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSessionRequest("ws-1"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status without token = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req := newSessionRequest("ws-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status with bearer = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?token=secret", nil))
+	cookies := rec.Result().Cookies()
+	var tokenCookie *http.Cookie
+	for _, cookie := range cookies {
+		if cookie.Name == tokenCookieName {
+			tokenCookie = cookie
+		}
+	}
+	if tokenCookie == nil {
+		t.Fatalf("token cookie is not set: %+v", cookies)
+	}
+	req = newSessionRequest("ws-1")
+	req.AddCookie(tokenCookie)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status with token cookie = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, frontendhttp.OpenAPIPath, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("OpenAPI must stay public, status = %d", rec.Code)
+	}
+}
+
+func TestSessionsStatusEndpoint(t *testing.T) {
+	t.Parallel()
+
+	sessions := NewSessions(WithMaxClients(4))
+	handler := sessions.Middleware(okHandler())
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("ws-1"))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, SessionsPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if strings.Contains(rec.Body.String(), "ws-1") {
+		t.Fatalf("sessions list exposes raw client id: %s", rec.Body.String())
+	}
+	var payload struct {
+		MaxClients int           `json:"max_clients"`
+		Items      []SessionInfo `json:"items"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if payload.MaxClients != 4 || len(payload.Items) != 1 || payload.Items[0].ClientRef != clientRef("ws-1") {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestSessionsPassClientToRequestContext(t *testing.T) {
+	t.Parallel()
+
+	var got contracts.FrontendClient
+	sessions := NewSessions()
+	handler := sessions.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = contracts.FrontendClientFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := newSessionRequest("ws-1")
+	req.RemoteAddr = "10.0.0.7:51000"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if got.ID != "ws-1" || got.Workstation != "10.0.0.7" {
+		t.Fatalf("client in context = %+v, want ws-1 from 10.0.0.7", got)
+	}
+}
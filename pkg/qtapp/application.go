@@ -18,14 +18,21 @@ import (
 	"obj_catalog_fyne_v3/pkg/backend"
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
 	"obj_catalog_fyne_v3/pkg/dataruntime"
 	"obj_catalog_fyne_v3/pkg/eventbus"
+	"obj_catalog_fyne_v3/pkg/eventexport"
 	objexport "obj_catalog_fyne_v3/pkg/export"
 	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/maintenance"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/omnicell"
+	"obj_catalog_fyne_v3/pkg/openclose"
 	"obj_catalog_fyne_v3/pkg/qtui"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 const (
@@ -39,12 +46,16 @@ const (
 type Application struct {
 	ui                      *qtui.App
 	runtime                 *dataruntime.Runtime
+	operatorServerURL       string
 	uiData                  *backend.FrontendUIDataProvider
 	workVM                  *viewmodels.WorkAreaViewModel
 	currentObject           *models.Object
 	currentObjectZones      int
 	currentObjectContacts   int
 	currentObjectsCount     int
+	objects                 []models.Object
+	objectGroups            *objectgroups.Registry
+	alarmCorrelator         *usecases.AlarmCorrelator
 	currentAlarmsCount      int
 	currentEventsCount      int
 	selectionSeq            int
@@ -68,7 +79,7 @@ type Application struct {
 	pendingRefresh          eventbus.DataRefreshEvent
 	refreshCoalescePending  bool
 	responseGroupsMu        sync.Mutex
-	responseGroupsCache     map[contracts.FrontendSource]responseGroupsCacheEntry
+	responseGroupsCache     map[int]responseGroupsCacheEntry
 	responseDialogMu        sync.Mutex
 	responseDialogAlarmID   int
 	responseDialogActive    bool
@@ -77,8 +88,10 @@ type Application struct {
 	lastBackendStatus       string
 }
 
+// responseGroupsCacheEntry зберігає ранжований для тривоги список груп.
 type responseGroupsCacheEntry struct {
 	loadedAt time.Time
+	source   contracts.FrontendSource
 	provider *backend.FrontendUIDataProvider
 	groups   []contracts.FrontendResponseGroup
 }
@@ -132,6 +145,13 @@ func NewApplication() *Application {
 	app.ui.OnResponseGroupsRequested = app.showResponseGroups
 	app.ui.OnOperationalMapRequested = app.showOperationalMap
 	app.ui.OnNewObjectsRequested = app.showNewObjectsReport
+	app.ui.OnDataQualityRequested = app.showDataQuality
+	app.ui.OnEventArchiveRequested = app.showEventArchive
+	app.ui.OnEventExportRequested = app.showEventExport
+	app.ui.OnMaintenanceRequested = app.showMaintenanceWindows
+	app.ui.OnObjectGroupsRequested = app.showObjectGroups
+	app.ui.OnOpenCloseRequested = app.showOpenCloseSchedules
+	app.ui.OnOverdueTestsRequested = app.showOverdueTests
 	app.ui.OnExportContacts = app.exportContacts
 	app.ui.OnCreateObject = app.createObject
 	app.ui.OnCreateCASLObject = app.createCASLObject
@@ -143,12 +163,15 @@ func NewApplication() *Application {
 	app.ui.OnDialPhone = app.dialPhone
 	app.ui.OnProcessAlarms = app.processAlarms
 	app.ui.OnPickAlarms = app.pickAlarms
+	app.ui.OnProcessIncident = app.processIncident
 	app.ui.OnRespondAlarm = app.respondToAlarm
 	app.ui.OnRunOnMainThread = app.runOnMainThread
 	app.ui.OnAlarmSelected = app.handleAlarmSelected
 	app.ui.OnEventSelected = app.handleEventSelected
 	app.ui.OnStarted = app.showPhoenixLoginIfNeeded
 	app.registerEventBusHandlers()
+	app.initObjectGroups(preferences)
+	app.initAlarmCorrelation(preferences)
 	app.initializeRuntime(preferences)
 	return app
 }
@@ -160,6 +183,281 @@ func (a *Application) showNewObjectsReport() {
 	a.ui.ShowNewObjectsReport(a.uiData, a.applyObjectContext)
 }
 
+func (a *Application) showDataQuality() {
+	if a == nil || a.ui == nil || a.uiData == nil {
+		return
+	}
+	provider := a.uiData
+	var capabilities []any
+	if a.runtime != nil && a.runtime.Provider != nil {
+		capabilities = append(capabilities, a.runtime.Provider)
+		if admin, ok := backend.AsAdminProvider(a.runtime.Provider); ok {
+			capabilities = append(capabilities, admin)
+		}
+	}
+	run := func(progress func(done int, total int), done func([]dataquality.Issue, error)) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+			defer cancel()
+			opts := dataquality.CapabilityOptions(capabilities...)
+			opts = append(opts, dataquality.WithProgress(func(checked int, total int) {
+				if checked%25 != 0 && checked != total {
+					return
+				}
+				a.runOnMainThread(func() { progress(checked, total) })
+			}))
+			issues, err := dataquality.NewChecker(opts...).Run(ctx, provider)
+			if err == nil {
+				log.Info().Int("issues", len(issues)).Msg("Qt data-quality check completed")
+			}
+			a.runOnMainThread(func() { done(issues, err) })
+		}()
+	}
+	initialDir := strings.TrimSpace(config.LoadUIConfig(a.ui.Preferences()).ExportDir)
+	exportXLSX := func(filePath string, issues []dataquality.Issue) error {
+		if err := objexport.WriteDataQualityXLSX(filePath, issues); err != nil {
+			return err
+		}
+		a.publishExportedReport(filePath)
+		return nil
+	}
+	issue, ok := a.ui.ShowDataQuality(run, exportXLSX, initialDir)
+	if !ok || issue.ObjectID == 0 {
+		return
+	}
+	a.reselectObject(issue.ObjectID)
+	if a.currentObject == nil {
+		a.ui.ShowInfo("Перевірка якості даних", "Об'єкт №"+issue.ObjectNumber+" не знайдено у списку.")
+		return
+	}
+	a.editCurrentObject()
+}
+
+func (a *Application) showEventArchive() {
+	if a == nil || a.ui == nil || a.runtime == nil {
+		return
+	}
+	archive, ok := a.runtime.Provider.(contracts.EventArchiveProvider)
+	if !ok || !a.hasPhoenixSource() {
+		a.ui.ShowInfo("Архів подій", "Архів подій доступний лише для підключених серверів Phoenix.")
+		return
+	}
+	load := func(query contracts.EventArchiveQuery, done func(contracts.EventArchivePage, error)) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			page, err := archive.QueryEventArchive(ctx, query)
+			a.runOnMainThread(func() { done(page, err) })
+		}()
+	}
+	export := func(query contracts.EventArchiveQuery, filePath string, progress func(int), done func(int, error)) func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			defer cancel()
+			written, err := objexport.ExportEventArchive(ctx, archive, query, filePath, func(written int) {
+				a.runOnMainThread(func() { progress(written) })
+			})
+			if err == nil {
+				log.Info().Int("events", written).Str("file", filePath).Msg("Qt event archive export completed")
+			} else if ctx.Err() != nil {
+				err = fmt.Errorf("скасовано")
+			}
+			a.runOnMainThread(func() { done(written, err) })
+		}()
+		return cancel
+	}
+	var object *models.Object
+	if a.currentObject != nil && ids.IsPhoenixObjectID(a.currentObject.ID) {
+		object = a.currentObject
+	}
+	initialDir := strings.TrimSpace(config.LoadUIConfig(a.ui.Preferences()).ExportDir)
+	a.ui.ShowEventArchive(load, export, object, initialDir)
+}
+
+// eventExportProgressInterval limits how often export progress is posted to the UI thread.
+const eventExportProgressInterval = 250 * time.Millisecond
+
+func (a *Application) showEventExport() {
+	if a == nil || a.ui == nil || a.runtime == nil {
+		return
+	}
+	provider, ok := a.runtime.Provider.(eventexport.Provider)
+	if !ok {
+		a.ui.ShowInfo("Експорт журналу подій", "Джерела даних не підтримують вибірку подій за період.")
+		return
+	}
+	export := func(input viewmodels.EventExportFilterInput, filePath string, progress func(int), done func(int, error)) func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			defer cancel()
+			var objects []models.Object
+			if strings.TrimSpace(input.Objects) != "" {
+				objects = provider.GetObjects()
+			}
+			filter, err := viewmodels.BuildEventExportFilter(input, objects)
+			written := 0
+			if err == nil {
+				var lastUpdate time.Time
+				exporter := eventexport.NewExporter(eventexport.WithProgress(func(n int) {
+					if time.Since(lastUpdate) < eventExportProgressInterval {
+						return
+					}
+					lastUpdate = time.Now()
+					a.runOnMainThread(func() { progress(n) })
+				}))
+				written, err = exporter.ExportFile(ctx, provider, filter, filePath)
+			}
+			if err == nil {
+				log.Info().Int("events", written).Str("file", filePath).Msg("Qt event journal export completed")
+			} else if ctx.Err() != nil {
+				err = fmt.Errorf("скасовано")
+			}
+			a.runOnMainThread(func() { done(written, err) })
+		}()
+		return cancel
+	}
+	initialDir := strings.TrimSpace(config.LoadUIConfig(a.ui.Preferences()).ExportDir)
+	a.ui.ShowEventExport(export, initialDir)
+}
+
+func (a *Application) hasPhoenixSource() bool {
+	capabilities, ok := a.runtime.Provider.(interface {
+		FrontendSourceCapabilities() []contracts.FrontendSourceCapability
+	})
+	if !ok {
+		return false
+	}
+	for _, capability := range capabilities.FrontendSourceCapabilities() {
+		if capability.Source == contracts.FrontendSourcePhoenix {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Application) showMaintenanceWindows() {
+	if a == nil || a.ui == nil {
+		return
+	}
+	var suppressor *maintenance.Suppressor
+	if a.runtime != nil {
+		if provider, ok := a.runtime.Provider.(interface {
+			MaintenanceSuppressor() *maintenance.Suppressor
+		}); ok {
+			suppressor = provider.MaintenanceSuppressor()
+		}
+	}
+	if suppressor == nil {
+		a.ui.ShowInfo("Вікна обслуговування", "Вікна обслуговування вимкнено або файл вікон недоступний. Перевірте налаштування maintenance.* та журнал.")
+		return
+	}
+	initialDir := strings.TrimSpace(config.LoadUIConfig(a.ui.Preferences()).ExportDir)
+	if a.ui.ShowMaintenanceWindows(suppressor.Store(), a.currentObject, contracts.DefaultOperatorName, objexport.WriteSuppressedAlarmsCSV, initialDir) {
+		a.refreshAlarms()
+	}
+}
+
+// initObjectGroups відкриває спільний файл груп об'єктів. Групи-вирази
+// обчислюються за останнім завантаженим списком об'єктів.
+func (a *Application) initObjectGroups(preferences config.Preferences) {
+	cfg := config.LoadObjectGroupsConfig(preferences)
+	store, err := objectgroups.OpenFileStore(cfg.Path)
+	if err != nil {
+		log.Warn().Err(err).Str("path", cfg.Path).Msg("Групи об'єктів вимкнено: не вдалося відкрити файл")
+		return
+	}
+	a.objectGroups = objectgroups.NewRegistry(store, func() []models.Object { return a.objects })
+	a.ui.SetObjectGroups(a.objectGroups)
+}
+
+// initAlarmCorrelation вмикає групування пов'язаних тривог в інциденти.
+// Підсервери об'єктів беруться з останнього завантаженого списку об'єктів.
+func (a *Application) initAlarmCorrelation(preferences config.Preferences) {
+	cfg := config.LoadAlarmCorrelationConfig(preferences)
+	if !cfg.Enabled {
+		return
+	}
+	a.alarmCorrelator = usecases.NewAlarmCorrelator(cfg, func() []models.Object { return a.objects })
+}
+
+func (a *Application) showObjectGroups() {
+	if a == nil || a.ui == nil {
+		return
+	}
+	if a.objectGroups == nil {
+		a.ui.ShowInfo("Групи об'єктів", "Файл груп об'єктів недоступний. Перевірте налаштування object_groups.path та журнал.")
+		return
+	}
+	objects := func() []models.Object { return a.objects }
+	if a.ui.ShowObjectGroups(a.objectGroups.Store(), objects, a.currentObject, contracts.DefaultOperatorName) {
+		a.objectGroups.Invalidate()
+		a.ui.SetObjects(a.objects)
+		a.refreshAlarms()
+		a.refreshEvents()
+	}
+}
+
+func (a *Application) showOpenCloseSchedules() {
+	if a == nil || a.ui == nil {
+		return
+	}
+	var supervisor *openclose.Supervisor
+	if a.runtime != nil {
+		if provider, ok := a.runtime.Provider.(interface {
+			OpenCloseSupervisor() *openclose.Supervisor
+		}); ok {
+			supervisor = provider.OpenCloseSupervisor()
+		}
+	}
+	if supervisor == nil {
+		a.ui.ShowInfo("Розклад відкриття/закриття", "Контроль розкладу вимкнено або файл розкладів недоступний. Перевірте налаштування open_close.* та журнал.")
+		return
+	}
+	initialDir := strings.TrimSpace(config.LoadUIConfig(a.ui.Preferences()).ExportDir)
+	export := qtui.OpenCloseExport{
+		Compliance: objexport.WriteOpenCloseComplianceCSV,
+		Violations: objexport.WriteOpenCloseViolationsCSV,
+	}
+	if a.ui.ShowOpenCloseSchedules(supervisor.Store(), a.currentObject, contracts.DefaultOperatorName, export, initialDir) {
+		a.refreshAlarms()
+	}
+}
+
+func (a *Application) showOverdueTests() {
+	if a == nil || a.ui == nil {
+		return
+	}
+	var supervisor *testsupervision.Supervisor
+	if a.runtime != nil {
+		if provider, ok := a.runtime.Provider.(interface {
+			TestSupervisor() *testsupervision.Supervisor
+		}); ok {
+			supervisor = provider.TestSupervisor()
+		}
+	}
+	if supervisor == nil {
+		a.ui.ShowInfo("Контроль періодичних тестів", "Контроль періодичних тестів вимкнено для всіх джерел. Перевірте налаштування test_supervision.*.")
+		return
+	}
+	source, _ := a.runtime.Provider.(testsupervision.ObjectSource)
+	scan := func(done func()) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			supervisor.Scan(ctx, source)
+			a.runOnMainThread(done)
+		}()
+	}
+	objectID, ok := a.ui.ShowOverdueTests(supervisor, scan)
+	if !ok || objectID == 0 {
+		return
+	}
+	a.reselectObject(objectID)
+	if a.currentObject == nil {
+		a.ui.ShowInfo("Контроль періодичних тестів", "Об'єкт ID "+strconv.Itoa(objectID)+" не знайдено у списку.")
+	}
+}
+
 func (a *Application) exportContacts() {
 	if a == nil || a.ui == nil || a.uiData == nil {
 		return
@@ -248,6 +546,30 @@ func (a *Application) exportContacts() {
 	}()
 }
 
+// publishExportedReport відправляє експортований звіт у сховище з налаштувань і показує результат у статусі.
+func (a *Application) publishExportedReport(filePath string) {
+	cfg := config.LoadReportUploadConfig(a.ui.Preferences())
+	if cfg.NormalizedTarget() == config.ReportUploadTargetNone {
+		return
+	}
+	a.ui.SetStatus("Публікація звіту у сховище: " + filepath.Base(filePath))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		link, err := objexport.PublishConfiguredReport(ctx, cfg, filePath)
+		a.runOnMainThread(func() {
+			switch {
+			case err != nil:
+				a.ui.SetStatus("Звіт не опубліковано: " + err.Error())
+			case link != "":
+				a.ui.SetStatus("Звіт опубліковано: " + link)
+			default:
+				a.ui.SetStatus("Звіт опубліковано: " + filepath.Base(filePath))
+			}
+		})
+	}()
+}
+
 func (a *Application) showPhoenixLoginIfNeeded() {
 	if a == nil || a.ui == nil {
 		return
@@ -286,12 +608,18 @@ func (a *Application) applySettings(dbCfg config.DBConfig, uiCfg config.UIConfig
 		a.runtime = nil
 	}
 	a.lastBackendStatus = ""
+	a.operatorServerURL = ""
 	a.currentObject = nil
 	a.responseGroupsMu.Lock()
 	a.responseGroupsCache = nil
 	a.responseGroupsMu.Unlock()
 	a.phoneDialer = buildAMIDialer(a.ui.Preferences())
 
+	if dbCfg.UsesOperatorServer() {
+		a.applyOperatorServer(dbCfg, uiCfg)
+		return
+	}
+
 	store := preferencesConfigStore{preferences: a.ui.Preferences()}
 
 	runtime, err := dataruntime.New(dbCfg, store, false)
@@ -320,6 +648,34 @@ func (a *Application) applySettings(dbCfg config.DBConfig, uiCfg config.UIConfig
 	a.startGettingEvents()
 }
 
+// applyOperatorServer підключає UI до cmd/operator-server. Адмін-функції
+// та редактори, яким потрібен прямий доступ до БД, у цьому режимі недоступні.
+func (a *Application) applyOperatorServer(dbCfg config.DBConfig, uiCfg config.UIConfig) {
+	clientID := "obj-catalog-qt"
+	if host, err := os.Hostname(); err == nil && strings.TrimSpace(host) != "" {
+		clientID += "@" + host
+	}
+	remote, err := backend.NewRemoteFrontendBackend(
+		dbCfg.OperatorServerURL,
+		backend.WithRemoteFrontendToken(dbCfg.OperatorServerToken),
+		backend.WithRemoteFrontendClientID(clientID),
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Qt UI: некоректна адреса сервера оператора")
+		a.ui.SetStatus("Сервер оператора: некоректна адреса")
+		return
+	}
+	a.operatorServerURL = dbCfg.OperatorServerURL
+	uiData := backend.NewFrontendUIDataProvider(remote, nil)
+	a.uiData = uiData
+	a.ui.SetDataProvider(uiData)
+	a.updateBackendStatus()
+	a.ui.ApplyFontSizes(uiCfg)
+	a.ui.SetObjectSelectedHandler(a.applyObjectContext)
+	a.refreshData()
+	a.startGettingEvents()
+}
+
 func (a *Application) refreshData() {
 	defer traceQtOperation("refreshData")()
 	if a == nil || a.ui == nil || a.uiData == nil {
@@ -368,6 +724,8 @@ func (a *Application) refreshObjects() {
 				return
 			}
 			a.currentObjectsCount = len(objects)
+			a.objects = objects
+			a.objectGroups.Invalidate()
 			a.ui.SetObjects(objects)
 		})
 	}()
@@ -410,6 +768,8 @@ func (a *Application) refreshAlarms() {
 				return
 			}
 			a.currentAlarmsCount = len(alarms)
+			alarms, storm := a.alarmCorrelator.Correlate(alarms)
+			a.ui.SetAlarmStorm(storm)
 			a.ui.SetAlarms(alarms)
 		})
 	}()
@@ -576,7 +936,20 @@ func (a *Application) showOperationalMap() {
 			if locationsErr == nil {
 				applyObjectLocations(objects, locations)
 			}
-			objectID, selected := a.ui.ShowOperationalMap(objects, alarms, groups)
+			objectID, selected := a.ui.ShowOperationalMap(objects, alarms, groups, func(done func([]models.Alarm, []contracts.FrontendResponseGroup, error)) {
+				go func() {
+					refreshCtx, refreshCancel := context.WithTimeout(context.Background(), 20*time.Second)
+					defer refreshCancel()
+					updatedGroups, refreshErr := provider.ListResponseGroups(refreshCtx)
+					updatedAlarms := provider.GetAlarms()
+					a.runOnMainThread(func() {
+						if a == nil || a.ui == nil || a.uiData != provider {
+							return
+						}
+						done(updatedAlarms, updatedGroups, refreshErr)
+					})
+				}()
+			})
 			if selected {
 				a.reselectObject(objectID)
 			}
@@ -680,6 +1053,10 @@ func (a *Application) editCurrentObject() {
 		a.ui.ShowInfo("Редагування об'єкта", "Джерела даних ще не підключені.")
 		return
 	}
+	if ids.IsPhoenixObjectID(a.currentObject.ID) {
+		a.editPhoenixPanel(int64(a.currentObject.ID))
+		return
+	}
 	admin, ok := backend.AsAdminProvider(a.runtime.Provider)
 	if !ok {
 		a.ui.ShowInfo("Редагування об'єкта", "Поточне джерело даних не підтримує редагування об'єктів.")
@@ -704,6 +1081,23 @@ func (a *Application) editCurrentObject() {
 	a.ui.SetStatus("Картку об'єкта оновлено: " + strconv.FormatInt(updated.ObjN, 10))
 }
 
+// editPhoenixPanel відкриває відповідальних і зони панелі Phoenix.
+func (a *Application) editPhoenixPanel(objn int64) {
+	admin, ok := backend.AsPhoenixObjectAdmin(a.runtime.Provider)
+	if !ok {
+		a.ui.ShowInfo("Редагування об'єкта", "Адміністрування Phoenix недоступне: немає підключення до БД Phoenix.")
+		return
+	}
+	card, err := admin.GetObjectCard(objn)
+	if err != nil {
+		a.ui.ShowError("Редагування об'єкта", "Не вдалося завантажити панель Phoenix: "+err.Error())
+		return
+	}
+	a.ui.EditPhoenixPanel(admin, card)
+	a.refreshData()
+	a.reselectObject(int(objn))
+}
+
 func (a *Application) createCASLObject() {
 	a.openCASLObjectEditor(0, true)
 }
@@ -956,6 +1350,42 @@ func (a *Application) executeProcessAlarms(
 	}()
 }
 
+// processIncident завершує всі тривоги інциденту кореляції; об'єкти МІСТ
+// завершуються груповим відпрацюванням, коли це безпечно.
+func (a *Application) processIncident(incident []models.Alarm, active []models.Alarm) {
+	if a == nil || a.ui == nil || len(incident) == 0 {
+		return
+	}
+	if a.uiData == nil {
+		a.ui.ShowInfo("Завершення інциденту", "Джерела даних ще не підключені.")
+		return
+	}
+
+	provider := a.uiData
+	a.ui.SetStatus("Завершення інциденту...")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		note := "Інцидент: " + incident[0].Incident
+		processed, err := usecases.ProcessAlarmIncident(ctx, provider, incident, active, contracts.DefaultOperatorName, note)
+
+		a.runOnMainThread(func() {
+			if a == nil || a.ui == nil || a.uiData != provider {
+				return
+			}
+			for _, alarm := range incident {
+				a.invalidateResponseGroupsCache(alarm)
+			}
+			a.refreshAlarms()
+			if err != nil {
+				a.ui.ShowError("Завершення інциденту", fmt.Sprintf("Успішно завершено: %d з %d. Помилки:\n%v", processed, len(incident), err))
+				return
+			}
+			a.ui.SetStatus(fmt.Sprintf("Інцидент завершено, тривог: %d", processed))
+		})
+	}()
+}
+
 func (a *Application) alarmProcessingOptions(
 	provider *backend.FrontendUIDataProvider,
 	ctx context.Context,
@@ -976,7 +1406,7 @@ func (a *Application) alarmProcessingOptions(
 	return intersectAlarmProcessingOptions(optionSets...)
 }
 
-func (a *Application) pickAlarms(alarms []models.Alarm) {
+func (a *Application) pickAlarms(alarms []models.Alarm, takeoverReason string) {
 	if a == nil || a.ui == nil || len(alarms) == 0 {
 		return
 	}
@@ -994,7 +1424,12 @@ func (a *Application) pickAlarms(alarms []models.Alarm) {
 		errorMsgs := make([]string, 0)
 		for _, alarm := range selected {
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-			err := provider.PickAlarm(ctx, alarm, operator)
+			var err error
+			if alarm.IsInProgress && !alarm.IsOwnedByMe && takeoverReason != "" {
+				err = provider.TakeOverAlarm(ctx, alarm, operator, takeoverReason)
+			} else {
+				err = provider.PickAlarm(ctx, alarm, operator)
+			}
 			cancel()
 			if err != nil {
 				errorMsgs = append(errorMsgs, fmt.Sprintf("№%s: %v", alarm.GetObjectNumberDisplay(), err))
@@ -1083,7 +1518,7 @@ func (a *Application) respondToAlarm(alarm models.Alarm) {
 			}
 			switch input.Action {
 			case qtui.AlarmResponseTake:
-				a.pickAlarms([]models.Alarm{alarm})
+				a.pickAlarms([]models.Alarm{alarm}, input.TakeoverReason)
 			case qtui.AlarmResponseProcess:
 				a.processAlarms([]models.Alarm{alarm})
 			default:
@@ -1122,7 +1557,7 @@ func (a *Application) responseGroupsForAlarm(
 	source := contracts.DetectFrontendSourceByObjectID(alarm.ObjectID)
 
 	a.responseGroupsMu.Lock()
-	cached, ok := a.responseGroupsCache[source]
+	cached, ok := a.responseGroupsCache[alarm.ID]
 	if ok && cached.provider == provider && time.Since(cached.loadedAt) < cacheTTL {
 		groups := append([]contracts.FrontendResponseGroup(nil), cached.groups...)
 		a.responseGroupsMu.Unlock()
@@ -1137,10 +1572,11 @@ func (a *Application) responseGroupsForAlarm(
 
 	a.responseGroupsMu.Lock()
 	if a.responseGroupsCache == nil {
-		a.responseGroupsCache = make(map[contracts.FrontendSource]responseGroupsCacheEntry)
+		a.responseGroupsCache = make(map[int]responseGroupsCacheEntry)
 	}
-	a.responseGroupsCache[source] = responseGroupsCacheEntry{
+	a.responseGroupsCache[alarm.ID] = responseGroupsCacheEntry{
 		loadedAt: time.Now(),
+		source:   source,
 		provider: provider,
 		groups:   append([]contracts.FrontendResponseGroup(nil), groups...),
 	}
@@ -1193,7 +1629,11 @@ func (a *Application) invalidateResponseGroupsCache(alarm models.Alarm) {
 	}
 	source := contracts.DetectFrontendSourceByObjectID(alarm.ObjectID)
 	a.responseGroupsMu.Lock()
-	delete(a.responseGroupsCache, source)
+	for alarmID, entry := range a.responseGroupsCache {
+		if entry.source == source {
+			delete(a.responseGroupsCache, alarmID)
+		}
+	}
 	a.responseGroupsMu.Unlock()
 }
 
@@ -1291,6 +1731,30 @@ func (s preferencesConfigStore) SaveVodafoneConfig(cfg config.VodafoneConfig) {
 	config.SaveVodafoneConfig(s.preferences, cfg)
 }
 
+func (s preferencesConfigStore) LoadReportUploadConfig() config.ReportUploadConfig {
+	return config.LoadReportUploadConfig(s.preferences)
+}
+
+func (s preferencesConfigStore) LoadDispatchConfig() config.DispatchConfig {
+	return config.LoadDispatchConfig(s.preferences)
+}
+
+func (s preferencesConfigStore) LoadMaintenanceConfig() config.MaintenanceConfig {
+	return config.LoadMaintenanceConfig(s.preferences)
+}
+
+func (s preferencesConfigStore) LoadTestSupervisionConfig() config.TestSupervisionConfig {
+	return config.LoadTestSupervisionConfig(s.preferences)
+}
+
+func (s preferencesConfigStore) LoadOpenCloseConfig() config.OpenCloseConfig {
+	return config.LoadOpenCloseConfig(s.preferences)
+}
+
+func (s preferencesConfigStore) LoadAlarmPriorityConfig() config.AlarmPriorityConfig {
+	return config.LoadAlarmPriorityConfig(s.preferences)
+}
+
 func backendStatusText(runtime *dataruntime.Runtime) string {
 	if runtime == nil {
 		return "Джерела даних: не ініціалізовано"
@@ -1306,6 +1770,9 @@ func backendStatusText(runtime *dataruntime.Runtime) string {
 		if source.Source == contracts.FrontendSourceBridge {
 			name = "БД/МІСТ"
 		}
+		if source.Instance != "" {
+			name += " · " + source.Instance
+		}
 		state := "перевірка..."
 		switch source.Status {
 		case contracts.FrontendSourceHealthStatusOnline:
@@ -1317,6 +1784,9 @@ func backendStatusText(runtime *dataruntime.Runtime) string {
 		}
 		if source.Status != contracts.FrontendSourceHealthStatusOnline {
 			if detail := strings.TrimSpace(source.HealthText); detail != "" {
+				if source.Instance != "" {
+					detail = source.Instance + ": " + detail
+				}
 				parts = append(parts, detail)
 				continue
 			}
@@ -1331,6 +1801,9 @@ func (a *Application) updateBackendStatus() {
 		return
 	}
 	status := backendStatusText(a.runtime)
+	if a.operatorServerURL != "" {
+		status = "Джерела даних: сервер оператора " + a.operatorServerURL
+	}
 	if status == a.lastBackendStatus {
 		return
 	}
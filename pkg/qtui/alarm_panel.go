@@ -7,6 +7,7 @@ import (
 	"hash/fnv"
 	"image/color"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,17 +18,22 @@ import (
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+	"obj_catalog_fyne_v3/pkg/usecases"
 	"obj_catalog_fyne_v3/pkg/utils"
 )
 
 type AlarmPanel struct {
 	*qt.QWidget
 	sourceFilter   *qt.QComboBox
+	pultFilter     *qt.QComboBox
+	groupFilter    *qt.QComboBox
 	severityFilter *qt.QComboBox
 	statusLabel    *qt.QLabel
 	criticalLabel  *qt.QLabel
 	selectionLabel *qt.QLabel
+	stormLabel     *qt.QLabel
 	processButton  *qt.QPushButton
 	pickButton     *qt.QPushButton
 	responseButton *qt.QPushButton
@@ -41,6 +47,7 @@ type AlarmPanel struct {
 	toolbarLayout  *qt.QGridLayout
 	dataProvider   contracts.DataProvider
 	prefs          config.Preferences
+	objectGroups   *objectgroups.Registry
 
 	autoSized       bool
 	filterUpdating  bool
@@ -54,9 +61,13 @@ type AlarmPanel struct {
 
 	OnAlarmSelected func(models.Alarm)
 	OnProcessAlarms func([]models.Alarm)
-	OnPickAlarms    func([]models.Alarm)
+	OnPickAlarms    func(alarms []models.Alarm, takeoverReason string)
 	OnRespondAlarm  func(models.Alarm)
 	OnCountChanged  func(count int)
+
+	// OnProcessIncident отримує всі тривоги інциденту кореляції та всі
+	// активні тривоги.
+	OnProcessIncident func(incident []models.Alarm, active []models.Alarm)
 }
 
 const prefQtAlarmSplitterSizes = "qt.splitter.alarms.sizes"
@@ -64,6 +75,8 @@ const prefQtAlarmSplitterSizes = "qt.splitter.alarms.sizes"
 type alarmGroup struct {
 	Key           string
 	Source        string
+	Instance      string
+	Pult          string
 	ObjectID      int
 	ObjectNumber  string
 	ObjectName    string
@@ -73,6 +86,9 @@ type alarmGroup struct {
 	CriticalCount int
 	LatestAt      int64
 	LatestTime    string
+	Pinned        bool
+	IncidentID    int
+	Priority      models.AlarmPriority // Пріоритет тривоги групи з найвищою оцінкою
 }
 
 func NewAlarmPanel(prefs config.Preferences) *AlarmPanel {
@@ -184,6 +200,19 @@ func NewAlarmPanel(prefs config.Preferences) *AlarmPanel {
 		}
 		panel.applyFilters()
 	})
+	panel.pultFilter = qt.NewQComboBox2()
+	panel.pultFilter.SetVisible(false)
+	panel.pultFilter.OnCurrentTextChanged(func(string) {
+		if panel.filterUpdating {
+			return
+		}
+		panel.applyFilters()
+	})
+	panel.groupFilter = qt.NewQComboBox2()
+	panel.groupFilter.SetVisible(false)
+	panel.groupFilter.OnCurrentIndexChanged(func(int) {
+		panel.applyFilters()
+	})
 	panel.severityFilter = qt.NewQComboBox2()
 	panel.severityFilter.AddItems([]string{"Всі тривоги", "Критичні", "Звичайні"})
 	panel.severityFilter.OnCurrentTextChanged(func(string) {
@@ -249,6 +278,11 @@ func NewAlarmPanel(prefs config.Preferences) *AlarmPanel {
 	topLayout.SetContentsMargins(2, 0, 2, 2)
 	topLayout.SetSpacing(2)
 	topLayout.AddWidget(header.QWidget)
+	panel.stormLabel = qt.NewQLabel3("")
+	panel.stormLabel.SetWordWrap(true)
+	panel.stormLabel.SetStyleSheet("font-weight: 700; color: #B71C1C; background: #FFEBEE; border: 1px solid #E57373; border-radius: 2px; padding: 3px 6px;")
+	panel.stormLabel.SetVisible(false)
+	topLayout.AddWidget(panel.stormLabel.QWidget)
 	topLayout.AddWidget(panel.table.QWidget)
 	topWidget.SetLayout(topLayout.QLayout)
 
@@ -325,6 +359,8 @@ func (panel *AlarmPanel) toolbarWidgets() []*qt.QWidget {
 		panel.processButton.QWidget,
 		panel.pickButton.QWidget,
 		panel.sourceFilter.QWidget,
+		panel.pultFilter.QWidget,
+		panel.groupFilter.QWidget,
 		panel.severityFilter.QWidget,
 	}
 }
@@ -337,7 +373,7 @@ func (panel *AlarmPanel) layoutToolbar() {
 	for _, widget := range panel.toolbarWidgets() {
 		panel.toolbarLayout.RemoveWidget(widget)
 	}
-	for column := 0; column < 9; column++ {
+	for column := 0; column < 11; column++ {
 		panel.toolbarLayout.SetColumnStretch(column, 0)
 	}
 
@@ -349,7 +385,9 @@ func (panel *AlarmPanel) layoutToolbar() {
 	panel.toolbarLayout.AddWidget2(panel.processButton.QWidget, 0, 5)
 	panel.toolbarLayout.AddWidget2(panel.pickButton.QWidget, 0, 6)
 	panel.toolbarLayout.AddWidget2(panel.sourceFilter.QWidget, 0, 7)
-	panel.toolbarLayout.AddWidget2(panel.severityFilter.QWidget, 0, 8)
+	panel.toolbarLayout.AddWidget2(panel.pultFilter.QWidget, 0, 8)
+	panel.toolbarLayout.AddWidget2(panel.groupFilter.QWidget, 0, 9)
+	panel.toolbarLayout.AddWidget2(panel.severityFilter.QWidget, 0, 10)
 }
 
 func alarmTreeStyleSheet(itemPadding int) string {
@@ -376,21 +414,36 @@ func alarmGroupHeaders() []string {
 	return []string{"Остання / час", "№", "Об'єкт / зона", "Кейс / тривога", "Оператор", "Пріоритет", "Джерело"}
 }
 
-func buildAlarmGroups(alarms []models.Alarm) []alarmGroup {
+// buildAlarmGroups групує тривоги за об'єктом; тривоги інциденту кореляції
+// з кількох видимих тривог утворюють одну групу інциденту.
+func buildAlarmGroups(alarms []models.Alarm, pinned map[int]struct{}) []alarmGroup {
 	if len(alarms) == 0 {
 		return nil
 	}
 
+	incidentSizes := make(map[int]int)
+	for _, alarm := range alarms {
+		if alarm.IncidentID != 0 {
+			incidentSizes[alarm.IncidentID]++
+		}
+	}
 	byKey := make(map[string]*alarmGroup)
 	order := make([]string, 0, len(alarms))
 	for _, alarm := range alarms {
 		source := viewmodels.ObjectSourceByID(alarm.ObjectID)
 		key := source + ":" + strconv.Itoa(alarm.ObjectID)
+		incidentID := 0
+		if incidentSizes[alarm.IncidentID] > 1 {
+			incidentID = alarm.IncidentID
+			key = "incident:" + strconv.Itoa(incidentID)
+		}
 		group, ok := byKey[key]
 		if !ok {
 			group = &alarmGroup{
 				Key:          key,
 				Source:       source,
+				Instance:     strings.TrimSpace(alarm.SourceInstance),
+				Pult:         strings.TrimSpace(alarm.Pult),
 				ObjectID:     alarm.ObjectID,
 				ObjectNumber: alarm.GetObjectNumberDisplay(),
 				ObjectName:   strings.TrimSpace(alarm.ObjectName),
@@ -398,11 +451,26 @@ func buildAlarmGroups(alarms []models.Alarm) []alarmGroup {
 				Primary:      alarm,
 				LatestAt:     alarm.Time.UnixNano(),
 				LatestTime:   alarm.GetTimeDisplay(),
+				IncidentID:   incidentID,
+				Priority:     alarm.Priority,
+			}
+			if incidentID != 0 {
+				group.ObjectName = "⛓ " + strings.TrimSpace(alarm.Incident)
 			}
 			byKey[key] = group
 			order = append(order, key)
 		}
 		group.Alarms = append(group.Alarms, alarm)
+		if alarm.ObjectID != group.ObjectID {
+			// Інцидент кількох об'єктів не має одного номера.
+			group.ObjectNumber = ""
+		}
+		if _, ok := pinned[alarm.ID]; ok {
+			group.Pinned = true
+		}
+		if alarm.Priority.Score > group.Priority.Score {
+			group.Priority = alarm.Priority
+		}
 		if alarm.Time.UnixNano() > group.LatestAt {
 			group.LatestAt = alarm.Time.UnixNano()
 			group.LatestTime = alarm.GetTimeDisplay()
@@ -429,6 +497,12 @@ func buildAlarmGroups(alarms []models.Alarm) []alarmGroup {
 		groups = append(groups, *group)
 	}
 	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Pinned != groups[j].Pinned {
+			return groups[i].Pinned
+		}
+		if groups[i].Priority.Score != groups[j].Priority.Score {
+			return groups[i].Priority.Score > groups[j].Priority.Score
+		}
 		leftCritical := groups[i].CriticalCount > 0
 		rightCritical := groups[j].CriticalCount > 0
 		if leftCritical != rightCritical {
@@ -454,6 +528,9 @@ func alarmGroupCaseText(group alarmGroup) string {
 	if group.Primary.Details != "" {
 		eventText += " - " + strings.TrimSpace(group.Primary.Details)
 	}
+	if tag := group.Primary.MaintenanceTag(); tag != "" {
+		eventText += " - " + tag
+	}
 	if eventText != "" {
 		parts = append(parts, eventText)
 	}
@@ -506,6 +583,26 @@ func (panel *AlarmPanel) SetAlarms(alarms []models.Alarm) {
 	panel.applyFilters()
 }
 
+// SetAlarmStorm показує банер шторму тривог; без шторму банер прихований.
+func (panel *AlarmPanel) SetAlarmStorm(storm usecases.AlarmStorm) {
+	if panel == nil || panel.stormLabel == nil {
+		return
+	}
+	text := viewmodels.AlarmStormBannerText(storm)
+	panel.stormLabel.SetText(text)
+	panel.stormLabel.SetVisible(text != "")
+}
+
+// SetObjectGroups підключає спільні групи об'єктів: фільтр за групою і
+// закріплення тривог зі списку спостереження.
+func (panel *AlarmPanel) SetObjectGroups(groups *objectgroups.Registry) {
+	if panel == nil {
+		return
+	}
+	panel.objectGroups = groups
+	panel.applyFilters()
+}
+
 func (panel *AlarmPanel) applyColumnWidths() {
 	if panel.autoSized {
 		return
@@ -523,19 +620,39 @@ func (panel *AlarmPanel) applyFilters() {
 	if panel.sourceFilter != nil {
 		selectedSource = viewmodels.NormalizeObjectSourceFilter(panel.sourceFilter.CurrentText())
 	}
+	selectedPult := viewmodels.ObjectPultAll
+	if panel.pultFilter != nil {
+		selectedPult = viewmodels.NormalizeObjectPultFilter(panel.pultFilter.CurrentText())
+	}
+	groupID := refreshGroupFilter(panel.groupFilter, panel.objectGroups)
+	membership := panel.objectGroups.Membership()
 	out := panel.vm.BuildRefreshOutput(viewmodels.AlarmRefreshInput{
 		Alarms:         panel.allAlarms,
 		LastKnownIDs:   map[int]struct{}{},
 		SelectedSource: selectedSource,
+		SelectedPult:   selectedPult,
+		GroupMembers:   membership.Members(groupID),
+		Watched:        membership.Watched(),
 	})
 	if panel.sourceFilter != nil {
 		panel.filterUpdating = true
-		updateComboItems(panel.sourceFilter, viewmodels.BuildObjectSourceOptions(out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL), selectedSource)
+		sourceOptions := viewmodels.BuildObjectSourceOptions(out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL)
+		updateComboItems(panel.sourceFilter, viewmodels.AppendObjectSourceInstanceOptions(sourceOptions, out.InstanceCounts), selectedSource)
 		panel.filterUpdating = false
 	}
+	if panel.pultFilter != nil {
+		pultOptions := viewmodels.BuildObjectPultOptions(out.CountAll, out.PultCounts)
+		panel.filterUpdating = true
+		if len(pultOptions) == 0 {
+			panel.pultFilter.Clear()
+		}
+		updateComboItems(panel.pultFilter, pultOptions, selectedPult)
+		panel.filterUpdating = false
+		panel.pultFilter.SetVisible(len(pultOptions) > 0)
+	}
 
 	filtered := filterAlarmsBySeverity(out.FilteredAlarms, panel.currentSeverityFilter())
-	groups := buildAlarmGroups(filtered)
+	groups := buildAlarmGroups(filtered, out.Pinned)
 	panel.updateRibbonStats(out.FilteredAlarms, groups)
 	if panel.OnCountChanged != nil {
 		panel.OnCountChanged(len(filtered))
@@ -584,19 +701,20 @@ func (panel *AlarmPanel) applyFilters() {
 	}
 	for _, group := range groups {
 		panel.groupsByKey[group.Key] = group
-		priority := "звичайна"
-		if group.CriticalCount > 0 {
-			priority = "критична"
-		}
+		priority := alarmPriorityText(group.Priority, group.CriticalCount > 0)
 		textColor, rowColor := eventRowColorsBySeverity(group.Primary.VisualSeverityValue(), group.Primary.SC1)
+		objectName := strings.TrimSpace(group.ObjectName)
+		if group.Pinned {
+			objectName = "📌 " + objectName
+		}
 		parentItems := newColoredReadOnlyAlarmRow([]string{
 			group.LatestTime,
 			group.ObjectNumber,
-			strings.TrimSpace(group.ObjectName),
+			objectName,
 			alarmGroupCaseText(group),
 			alarmGroupOperatorText(group),
 			priority,
-			group.Source,
+			alarmGroupSourceText(group),
 		}, group.Key, 0, textColor, rowColor)
 		panel.model.AppendRow(parentItems)
 		if !alarmGroupHasChildren(group) {
@@ -625,10 +743,34 @@ func (panel *AlarmPanel) applyFilters() {
 	panel.updateSelectionState()
 }
 
+func alarmGroupSourceText(group alarmGroup) string {
+	source := group.Source
+	if label := viewmodels.ObjectSourceInstanceLabel(group.Source, group.Instance); label != "" {
+		source = label
+	}
+	if group.Pult == "" {
+		return source
+	}
+	return source + " · " + group.Pult
+}
+
 func alarmGroupHasChildren(group alarmGroup) bool {
 	return len(group.Alarms) > 1
 }
 
+// alarmPriorityText показує оцінку пріоритету разом із рівнем критичності;
+// без оцінки (модель вимкнена) лишається тільки рівень.
+func alarmPriorityText(priority models.AlarmPriority, critical bool) string {
+	level := "звичайна"
+	if critical {
+		level = "критична"
+	}
+	if priority.IsZero() {
+		return level
+	}
+	return strconv.Itoa(priority.Score) + " · " + level
+}
+
 func alarmTreeChildValues(alarm models.Alarm) []string {
 	zone := "Подія"
 	if alarm.ZoneNumber > 0 {
@@ -638,6 +780,9 @@ func alarmTreeChildValues(alarm models.Alarm) []string {
 	if details := strings.TrimSpace(alarm.Details); details != "" {
 		eventText += " — " + details
 	}
+	if tag := alarm.MaintenanceTag(); tag != "" {
+		eventText += " — " + tag
+	}
 	operator := "Не взята"
 	if alarm.IsInProgress {
 		operator = strings.TrimSpace(alarm.InProgressBy)
@@ -645,10 +790,7 @@ func alarmTreeChildValues(alarm models.Alarm) []string {
 			operator = "У роботі"
 		}
 	}
-	priority := "звичайна"
-	if alarm.IsCritical() {
-		priority = "критична"
-	}
+	priority := alarmPriorityText(alarm.Priority, alarm.IsCritical())
 	return []string{
 		alarm.GetTimeDisplay(),
 		"",
@@ -722,6 +864,10 @@ func alarmGroupRowsHash(groups []alarmGroup) uint64 {
 		writeHashInt(h, group.CriticalCount)
 		writeHashInt(h, len(group.Alarms))
 		writeHashString(h, group.ObjectNumber)
+		writeHashString(h, group.Pult)
+		writeHashString(h, group.Instance)
+		writeHashBool(h, group.Pinned)
+		writeHashInt(h, group.Priority.Score)
 		writeHashString(h, strings.TrimSpace(group.ObjectName))
 		writeHashString(h, alarmGroupCaseText(group))
 		writeHashString(h, alarmGroupOperatorText(group))
@@ -736,6 +882,7 @@ func alarmGroupRowsHash(groups []alarmGroup) uint64 {
 			writeHashString(h, strings.TrimSpace(alarm.InProgressBy))
 			writeHashBool(h, alarm.CanTakeOver)
 			writeHashBool(h, alarm.CanProcess)
+			writeHashInt(h, alarm.Priority.Score)
 		}
 	}
 	return h.Sum64()
@@ -948,17 +1095,42 @@ func (panel *AlarmPanel) pickAlarmsWithConfirmation(alarms []models.Alarm) {
 	if panel == nil || panel.OnPickAlarms == nil || len(alarms) == 0 {
 		return
 	}
+	reason := ""
 	if alarmsRequireTakeover(alarms) {
-		answer := qt.QMessageBox_Question(
-			panel.QWidget,
-			"Перехоплення тривоги",
-			"Тривогу вже обробляє інший оператор. Перехопити її?",
-		)
-		if answer != qt.QMessageBox__Yes {
+		var ok bool
+		reason, ok = promptAlarmTakeoverReason(panel.QWidget, alarms)
+		if !ok {
 			return
 		}
 	}
-	panel.OnPickAlarms(alarms)
+	panel.OnPickAlarms(alarms, reason)
+}
+
+// promptAlarmTakeoverReason запитує причину перехоплення тривоги, яку обробляє інший оператор.
+func promptAlarmTakeoverReason(parent *qt.QWidget, alarms []models.Alarm) (string, bool) {
+	label := fmt.Sprintf("Тривогу вже обробляє %s.\nВкажіть причину перехоплення:", alarmTakeoverOwner(alarms))
+	for {
+		ok := false
+		reason := strings.TrimSpace(qt.QInputDialog_GetText4(parent, "Перехоплення тривоги", label, qt.QLineEdit__Normal, "", &ok))
+		if !ok {
+			return "", false
+		}
+		if reason != "" {
+			return reason, true
+		}
+		qt.QMessageBox_Warning(parent, "Перехоплення тривоги", "Причина перехоплення обов'язкова.")
+	}
+}
+
+func alarmTakeoverOwner(alarms []models.Alarm) string {
+	for _, alarm := range alarms {
+		if alarm.IsInProgress && !alarm.IsOwnedByMe {
+			if owner := strings.TrimSpace(alarm.InProgressBy); owner != "" {
+				return owner
+			}
+		}
+	}
+	return "інший оператор"
 }
 
 func (panel *AlarmPanel) respondToSelectedAlarm() {
@@ -1026,6 +1198,20 @@ func (panel *AlarmPanel) showContextMenu(pos *qt.QPoint) {
 		})
 	}
 
+	if group.IncidentID != 0 && panel.OnProcessIncident != nil {
+		incident := alarmIncidentMembers(panel.allAlarms, group.IncidentID)
+		incidentAction := menu.AddActionWithText(fmt.Sprintf("Завершити інцидент (%d)", len(incident)))
+		incidentAction.SetEnabled(len(incident) > 1)
+		active := slices.Clone(panel.allAlarms)
+		incidentAction.OnTriggered(func() {
+			question := fmt.Sprintf("%s\n\nЗавершити всі тривоги інциденту (%d)?", incident[0].Incident, len(incident))
+			if qt.QMessageBox_Question(panel.QWidget, "Завершення інциденту", question) != qt.QMessageBox__Yes {
+				return
+			}
+			panel.OnProcessIncident(incident, active)
+		})
+	}
+
 	menu.AddSeparator()
 	historyAction := menu.AddActionWithText("Переглянути хронологію групи")
 	historyAction.OnTriggered(func() {
@@ -1047,6 +1233,17 @@ func (panel *AlarmPanel) showContextMenu(pos *qt.QPoint) {
 	menu.ExecWithPos(panel.table.MapToGlobalWithQPoint(pos))
 }
 
+// alarmIncidentMembers повертає всі активні тривоги інциденту, незалежно від фільтрів панелі.
+func alarmIncidentMembers(alarms []models.Alarm, incidentID int) []models.Alarm {
+	var members []models.Alarm
+	for _, alarm := range alarms {
+		if alarm.IncidentID == incidentID {
+			members = append(members, alarm)
+		}
+	}
+	return members
+}
+
 func canProcessAlarms(alarms []models.Alarm) bool {
 	if len(alarms) == 0 {
 		return false
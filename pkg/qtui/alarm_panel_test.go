@@ -267,6 +267,30 @@ func TestAlarmGroupOperatorTextShowsCurrentOwner(t *testing.T) {
 	}
 }
 
+func TestResponseGroupLabelIncludesDispatchSuggestion(t *testing.T) {
+	got := responseGroupLabel(contracts.FrontendResponseGroup{
+		ID:   "7",
+		Name: "Група Захід",
+		Suggestion: contracts.ResponseGroupSuggestion{
+			Rank:        1,
+			Recommended: true,
+			Note:        "рекомендовано, геозона об'єкта",
+		},
+	})
+	want := "★ Група Захід | рекомендовано, геозона об'єкта"
+	if got != want {
+		t.Fatalf("responseGroupLabel() = %q, want %q", got, want)
+	}
+
+	busy := busyResponseGroupsSummary(models.Alarm{}, []contracts.FrontendResponseGroup{
+		{ID: "free", Name: "Вільна", Status: contracts.ResponseGroupStatusFree},
+		{ID: "busy", Name: "Зайнята", Status: contracts.ResponseGroupStatusArrived, ObjectNumber: "12"},
+	})
+	if busy != "Зайнята (№12)" {
+		t.Fatalf("busyResponseGroupsSummary() = %q", busy)
+	}
+}
+
 func TestSelectableResponseGroupsExcludesBusyGroups(t *testing.T) {
 	groups := []contracts.FrontendResponseGroup{
 		{ID: "free", Status: contracts.ResponseGroupStatusFree},
@@ -278,3 +302,61 @@ func TestSelectableResponseGroupsExcludesBusyGroups(t *testing.T) {
 		t.Fatalf("selectable groups = %+v", got)
 	}
 }
+
+func TestBuildAlarmGroupsPinsWatchedObjectsFirst(t *testing.T) {
+	now := time.Now()
+	groups := buildAlarmGroups([]models.Alarm{
+		{ID: 1, ObjectID: 10, Type: models.AlarmFire, Time: now},
+		{ID: 2, ObjectID: 20, Time: now.Add(-time.Hour)},
+	}, map[int]struct{}{2: {}})
+
+	if len(groups) != 2 || groups[0].ObjectID != 20 || !groups[0].Pinned || groups[1].Pinned {
+		t.Fatalf("groups = %+v, want watched object 20 pinned above the critical alarm", groups)
+	}
+}
+
+func TestBuildAlarmGroupsGroupsCorrelatedIncident(t *testing.T) {
+	now := time.Now()
+	groups := buildAlarmGroups([]models.Alarm{
+		{ID: 1, ObjectID: 10, IncidentID: 2, Incident: "Підсервер SBS-2 — об'єктів з технічними тривогами: 2", Time: now},
+		{ID: 2, ObjectID: 11, IncidentID: 2, Incident: "Підсервер SBS-2 — об'єктів з технічними тривогами: 2", Time: now},
+		{ID: 3, ObjectID: 12, IncidentID: 9, Incident: "№12 — пов'язаних тривог: 2", Time: now},
+	}, nil)
+
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v, want the incident and a single alarm", groups)
+	}
+	incident := groups[0]
+	if incident.Key != "incident:2" || incident.IncidentID != 2 || len(incident.Alarms) != 2 || incident.ObjectNumber != "" {
+		t.Fatalf("incident group = %+v", incident)
+	}
+	if incident.ObjectName != "⛓ Підсервер SBS-2 — об'єктів з технічними тривогами: 2" {
+		t.Fatalf("incident title = %q", incident.ObjectName)
+	}
+	if groups[1].IncidentID != 0 || groups[1].ObjectID != 12 {
+		t.Fatalf("incident with one visible alarm must stay an object group: %+v", groups[1])
+	}
+}
+
+func TestBuildAlarmGroupsSortsByPriorityScore(t *testing.T) {
+	now := time.Now()
+	groups := buildAlarmGroups([]models.Alarm{
+		{ID: 1, ObjectID: 10, Type: models.AlarmFire, Time: now, Priority: models.AlarmPriority{Score: 40, Type: 100, Dispatched: -60}},
+		{ID: 2, ObjectID: 11, Type: models.AlarmBatteryLow, Time: now.Add(-time.Minute), Priority: models.AlarmPriority{Score: 15, Type: 15}},
+		{ID: 3, ObjectID: 12, Type: models.AlarmOffline, Time: now.Add(-2 * time.Minute), Priority: models.AlarmPriority{Score: 70, Type: 40, ObjectType: 30}},
+		{ID: 4, ObjectID: 12, Type: models.AlarmFault, Time: now.Add(-3 * time.Minute), Priority: models.AlarmPriority{Score: 35, Type: 35}},
+	}, nil)
+
+	if len(groups) != 3 || groups[0].ObjectID != 12 || groups[1].ObjectID != 10 || groups[2].ObjectID != 11 {
+		t.Fatalf("groups = %+v, want objects 12, 10, 11 by score", groups)
+	}
+	if groups[0].Priority.Score != 70 {
+		t.Fatalf("group priority = %+v, want the highest alarm score", groups[0].Priority)
+	}
+	if got := alarmPriorityText(groups[0].Priority, true); got != "70 · критична" {
+		t.Fatalf("alarmPriorityText() = %q", got)
+	}
+	if got := alarmPriorityText(models.AlarmPriority{}, false); got != "звичайна" {
+		t.Fatalf("unscored alarmPriorityText() = %q", got)
+	}
+}
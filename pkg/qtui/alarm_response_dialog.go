@@ -26,6 +26,8 @@ const (
 type AlarmResponseInput struct {
 	Action  AlarmResponseAction
 	GroupID string
+	// TakeoverReason заповнюється, коли тривогу перехоплюють в іншого оператора.
+	TakeoverReason string
 }
 
 func ShowAlarmResponseDialog(
@@ -52,6 +54,9 @@ func ShowAlarmResponseDialog(
 	form.AddRow3("Зона", responseValueLabel(alarmZoneSummary(alarm)).QWidget)
 	form.AddRow3("Опис", responseValueLabel(alarm.Details).QWidget)
 	form.AddRow3("Оператор", responseValueLabel(alarmOperatorState(alarm)).QWidget)
+	if !alarm.Priority.IsZero() {
+		form.AddRow3("Пріоритет", responseValueLabel(alarm.Priority.Breakdown()).QWidget)
+	}
 
 	state := qt.NewQLabel3(alarmResponseState(alarm))
 	state.SetStyleSheet(alarmResponseStateStyle(alarm))
@@ -62,6 +67,7 @@ func ShowAlarmResponseDialog(
 	availableGroups := selectableResponseGroups(alarm, groups)
 	canRespond := alarmResponseActionsAllowed(alarm)
 	selectedIndex := -1
+	recommendedIndex := -1
 	for _, group := range availableGroups {
 		label := responseGroupLabel(group)
 		groupSelect.AddItem3(label, qt.NewQVariant14(strings.TrimSpace(group.ID)))
@@ -69,6 +75,12 @@ func ShowAlarmResponseDialog(
 		if strings.TrimSpace(group.ID) == strings.TrimSpace(alarm.ResponseGroupID) {
 			selectedIndex = index
 		}
+		if recommendedIndex < 0 && group.Suggestion.Recommended {
+			recommendedIndex = index
+		}
+	}
+	if selectedIndex < 0 {
+		selectedIndex = recommendedIndex
 	}
 	if selectedIndex >= 0 {
 		groupSelect.SetCurrentIndex(selectedIndex)
@@ -78,6 +90,11 @@ func ShowAlarmResponseDialog(
 		groupSelect.SetEnabled(false)
 	}
 	form.AddRow3("Група", groupSelect.QWidget)
+	if busy := busyResponseGroupsSummary(alarm, groups); busy != "" {
+		busyLabel := responseValueLabel(busy)
+		busyLabel.SetStyleSheet("color: #8a5a00;")
+		form.AddRow3("Зайняті МГР", busyLabel.QWidget)
+	}
 	layout.AddLayout(form.QLayout)
 
 	historyTitle := qt.NewQLabel3("Хронологія кейсу")
@@ -110,6 +127,13 @@ func ShowAlarmResponseDialog(
 
 	result := AlarmResponseInput{}
 	takeButton.OnClicked(func() {
+		if alarmsRequireTakeover([]models.Alarm{alarm}) {
+			reason, ok := promptAlarmTakeoverReason(dialog.QWidget, []models.Alarm{alarm})
+			if !ok {
+				return
+			}
+			result.TakeoverReason = reason
+		}
 		result.Action = AlarmResponseTake
 		dialog.Accept()
 	})
@@ -240,6 +264,9 @@ func responseGroupLabel(group contracts.FrontendResponseGroup) string {
 	if name == "" {
 		name = "МГР " + strings.TrimSpace(group.ID)
 	}
+	if group.Suggestion.Recommended {
+		name = "★ " + name
+	}
 	parts := []string{name}
 	if callsign := strings.TrimSpace(group.Callsign); callsign != "" {
 		parts = append(parts, "позивний "+callsign)
@@ -250,9 +277,37 @@ func responseGroupLabel(group contracts.FrontendResponseGroup) string {
 	if group.Status != "" && group.Status != contracts.ResponseGroupStatusUnknown {
 		parts = append(parts, responseGroupDisplayStatus(group))
 	}
+	if note := strings.TrimSpace(group.Suggestion.Note); note != "" {
+		parts = append(parts, note)
+	}
 	return strings.Join(parts, " | ")
 }
 
+// busyResponseGroupsSummary перелічує групи, які вже працюють на інших тривогах.
+func busyResponseGroupsSummary(alarm models.Alarm, groups []contracts.FrontendResponseGroup) string {
+	selectable := make(map[string]struct{})
+	for _, group := range selectableResponseGroups(alarm, groups) {
+		selectable[strings.TrimSpace(group.ID)] = struct{}{}
+	}
+	names := make([]string, 0)
+	for _, group := range groups {
+		if _, ok := selectable[strings.TrimSpace(group.ID)]; ok {
+			continue
+		}
+		name := strings.TrimSpace(group.Name)
+		if name == "" {
+			name = "МГР " + strings.TrimSpace(group.ID)
+		}
+		if note := strings.TrimSpace(group.Suggestion.Note); note != "" {
+			name += " (" + note + ")"
+		} else if number := strings.TrimSpace(group.ObjectNumber); number != "" {
+			name += " (№" + number + ")"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, "; ")
+}
+
 func selectableResponseGroups(alarm models.Alarm, groups []contracts.FrontendResponseGroup) []contracts.FrontendResponseGroup {
 	result := make([]contracts.FrontendResponseGroup, 0, len(groups))
 	currentID := strings.TrimSpace(alarm.ResponseGroupID)
@@ -12,8 +12,14 @@ import (
 
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
+	"obj_catalog_fyne_v3/pkg/maintenance"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/openclose"
 	"obj_catalog_fyne_v3/pkg/simcommands"
+	"obj_catalog_fyne_v3/pkg/testsupervision"
+	"obj_catalog_fyne_v3/pkg/usecases"
 	"obj_catalog_fyne_v3/pkg/version"
 )
 
@@ -32,6 +38,13 @@ type App struct {
 	OnResponseGroupsRequested func()
 	OnOperationalMapRequested func()
 	OnNewObjectsRequested     func()
+	OnDataQualityRequested    func()
+	OnEventArchiveRequested   func()
+	OnEventExportRequested    func()
+	OnMaintenanceRequested    func()
+	OnObjectGroupsRequested   func()
+	OnOpenCloseRequested      func()
+	OnOverdueTestsRequested   func()
 	OnExportContacts          func()
 	OnCreateObject            func()
 	OnCreateCASLObject        func()
@@ -42,7 +55,8 @@ type App struct {
 	OnSendSIMSMS              func(object models.Object, phone string)
 	OnDialPhone               func(phone string)
 	OnProcessAlarms           func([]models.Alarm)
-	OnPickAlarms              func([]models.Alarm)
+	OnPickAlarms              func(alarms []models.Alarm, takeoverReason string)
+	OnProcessIncident         func(incident []models.Alarm, active []models.Alarm)
 	OnRespondAlarm            func(models.Alarm)
 	OnRunOnMainThread         func(f func())
 	OnAlarmSelected           func(models.Alarm)
@@ -99,6 +113,41 @@ func NewApp() *App {
 			app.OnNewObjectsRequested()
 		}
 	}
+	app.mainWindow.OnDataQualityRequested = func() {
+		if app.OnDataQualityRequested != nil {
+			app.OnDataQualityRequested()
+		}
+	}
+	app.mainWindow.OnEventArchiveRequested = func() {
+		if app.OnEventArchiveRequested != nil {
+			app.OnEventArchiveRequested()
+		}
+	}
+	app.mainWindow.OnEventExportRequested = func() {
+		if app.OnEventExportRequested != nil {
+			app.OnEventExportRequested()
+		}
+	}
+	app.mainWindow.OnMaintenanceRequested = func() {
+		if app.OnMaintenanceRequested != nil {
+			app.OnMaintenanceRequested()
+		}
+	}
+	app.mainWindow.OnObjectGroupsRequested = func() {
+		if app.OnObjectGroupsRequested != nil {
+			app.OnObjectGroupsRequested()
+		}
+	}
+	app.mainWindow.OnOpenCloseRequested = func() {
+		if app.OnOpenCloseRequested != nil {
+			app.OnOpenCloseRequested()
+		}
+	}
+	app.mainWindow.OnOverdueTestsRequested = func() {
+		if app.OnOverdueTestsRequested != nil {
+			app.OnOverdueTestsRequested()
+		}
+	}
 	app.mainWindow.OnExportContactsRequested = func() {
 		if app.OnExportContacts != nil {
 			app.OnExportContacts()
@@ -144,9 +193,14 @@ func NewApp() *App {
 			app.OnProcessAlarms(alarms)
 		}
 	}
-	app.mainWindow.alarmPanel.OnPickAlarms = func(alarms []models.Alarm) {
+	app.mainWindow.alarmPanel.OnPickAlarms = func(alarms []models.Alarm, takeoverReason string) {
 		if app.OnPickAlarms != nil {
-			app.OnPickAlarms(alarms)
+			app.OnPickAlarms(alarms, takeoverReason)
+		}
+	}
+	app.mainWindow.alarmPanel.OnProcessIncident = func(incident []models.Alarm, active []models.Alarm) {
+		if app.OnProcessIncident != nil {
+			app.OnProcessIncident(incident, active)
 		}
 	}
 	app.mainWindow.alarmPanel.OnRespondAlarm = func(alarm models.Alarm) {
@@ -243,6 +297,62 @@ func (a *App) ShowNewObjectsReport(provider contracts.ObjectProvider, onOpen fun
 	ShowNewObjectsReport(a.mainWindow.QWidget, provider, onOpen)
 }
 
+// ShowDataQuality opens the cross-source data-quality checker and returns the issue picked for a quick fix.
+func (a *App) ShowDataQuality(run DataQualityRun, export DataQualityExport, initialDir string) (dataquality.Issue, bool) {
+	if a == nil || a.mainWindow == nil {
+		return dataquality.Issue{}, false
+	}
+	return ShowDataQualityDialog(a.mainWindow.QWidget, run, export, initialDir)
+}
+
+// ShowEventArchive opens the Phoenix event archive browser.
+func (a *App) ShowEventArchive(load EventArchiveLoad, export EventArchiveExport, object *models.Object, initialDir string) {
+	if a == nil || a.mainWindow == nil {
+		return
+	}
+	ShowEventArchiveDialog(a.mainWindow.QWidget, load, export, object, initialDir)
+}
+
+// ShowEventExport opens the event journal export prefilled with the event log filters.
+func (a *App) ShowEventExport(export EventJournalExport, initialDir string) {
+	if a == nil || a.mainWindow == nil {
+		return
+	}
+	ShowEventExportDialog(a.mainWindow.QWidget, export, a.mainWindow.eventLog.ExportPreset(), initialDir)
+}
+
+// ShowMaintenanceWindows opens maintenance windows and reports whether they were changed.
+func (a *App) ShowMaintenanceWindows(store *maintenance.FileStore, object *models.Object, user string, export SuppressedAlarmsExport, initialDir string) bool {
+	if a == nil || a.mainWindow == nil {
+		return false
+	}
+	return ShowMaintenanceWindowsDialog(a.mainWindow.QWidget, store, object, user, export, initialDir)
+}
+
+// ShowObjectGroups opens the shared object groups editor and reports whether groups were changed.
+func (a *App) ShowObjectGroups(store *objectgroups.FileStore, objects func() []models.Object, object *models.Object, user string) bool {
+	if a == nil || a.mainWindow == nil {
+		return false
+	}
+	return ShowObjectGroupsDialog(a.mainWindow.QWidget, store, objects, object, user)
+}
+
+// ShowOpenCloseSchedules opens open/close schedules and reports whether they were changed.
+func (a *App) ShowOpenCloseSchedules(store *openclose.FileStore, object *models.Object, user string, export OpenCloseExport, initialDir string) bool {
+	if a == nil || a.mainWindow == nil {
+		return false
+	}
+	return ShowOpenCloseDialog(a.mainWindow.QWidget, store, object, user, export, initialDir)
+}
+
+// ShowOverdueTests opens the periodic test dashboard and returns the object picked to open.
+func (a *App) ShowOverdueTests(supervisor *testsupervision.Supervisor, scan OverdueTestsScan) (int, bool) {
+	if a == nil || a.mainWindow == nil {
+		return 0, false
+	}
+	return ShowOverdueTestsDialog(a.mainWindow.QWidget, supervisor, scan)
+}
+
 // ChooseContactsCSVPath opens a save dialog for the contacts CSV file.
 func (a *App) ChooseContactsCSVPath(initialDir string) (string, bool) {
 	if a == nil || a.mainWindow == nil {
@@ -311,6 +421,14 @@ func (a *App) EditObjectCard(provider contracts.AdminObjectDialogProvider, card
 	return ShowObjectEditDialog(a.mainWindow.QWidget, provider, card)
 }
 
+// EditPhoenixPanel opens responsibles and zones of a Phoenix panel.
+func (a *App) EditPhoenixPanel(provider contracts.PhoenixObjectAdminProvider, card contracts.AdminObjectCard) {
+	if a == nil || a.mainWindow == nil {
+		return
+	}
+	ShowPhoenixPanelDialog(a.mainWindow.QWidget, provider, card)
+}
+
 func (a *App) CreateObjectCard(provider contracts.AdminObjectDialogProvider) (contracts.AdminObjectCard, []string, bool) {
 	if a == nil || a.mainWindow == nil {
 		return contracts.AdminObjectCard{}, nil, false
@@ -427,11 +545,12 @@ func (a *App) ShowOperationalMap(
 	objects []models.Object,
 	alarms []models.Alarm,
 	groups []contracts.FrontendResponseGroup,
+	reload OperationalMapReload,
 ) (int, bool) {
 	if a == nil || a.mainWindow == nil {
 		return 0, false
 	}
-	return ShowOperationalMapDialog(a.mainWindow.QWidget, objects, alarms, groups)
+	return ShowOperationalMapDialog(a.mainWindow.QWidget, objects, alarms, groups, reload)
 }
 
 func (a *App) ShowError(title string, message string) {
@@ -455,6 +574,16 @@ func (a *App) SetObjects(objects []models.Object) {
 	a.mainWindow.objectList.SetObjects(objects)
 }
 
+// SetObjectGroups connects the shared object groups to the object list, alarm and event panels.
+func (a *App) SetObjectGroups(groups *objectgroups.Registry) {
+	if a == nil || a.mainWindow == nil {
+		return
+	}
+	a.mainWindow.objectList.SetObjectGroups(groups)
+	a.mainWindow.alarmPanel.SetObjectGroups(groups)
+	a.mainWindow.eventLog.SetObjectGroups(groups)
+}
+
 func (a *App) SetAlarms(alarms []models.Alarm) {
 	if a == nil || a.mainWindow == nil || a.mainWindow.alarmPanel == nil {
 		return
@@ -462,6 +591,14 @@ func (a *App) SetAlarms(alarms []models.Alarm) {
 	a.mainWindow.alarmPanel.SetAlarms(alarms)
 }
 
+// SetAlarmStorm shows or hides the alarm storm banner above the alarm list.
+func (a *App) SetAlarmStorm(storm usecases.AlarmStorm) {
+	if a == nil || a.mainWindow == nil || a.mainWindow.alarmPanel == nil {
+		return
+	}
+	a.mainWindow.alarmPanel.SetAlarmStorm(storm)
+}
+
 func (a *App) SetEvents(events []models.Event) {
 	if a == nil || a.mainWindow == nil || a.mainWindow.eventLog == nil {
 		return
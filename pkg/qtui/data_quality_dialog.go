@@ -0,0 +1,224 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/dataquality"
+)
+
+// DataQualityRun starts a background data-quality check. Callbacks must be delivered on the UI thread.
+type DataQualityRun func(progress func(done int, total int), done func([]dataquality.Issue, error))
+
+// DataQualityExport writes the given issues to filePath.
+type DataQualityExport func(filePath string, issues []dataquality.Issue) error
+
+var dataQualitySeverityOptions = []struct {
+	label    string
+	severity string
+}{
+	{label: "Усі рівні", severity: ""},
+	{label: "Помилки", severity: dataquality.SeverityError},
+	{label: "Попередження", severity: dataquality.SeverityWarn},
+	{label: "Інфо", severity: dataquality.SeverityInfo},
+}
+
+// ShowDataQualityDialog shows the cross-source data-quality issue list.
+// It returns the issue chosen for a quick fix in the object editor.
+func ShowDataQualityDialog(parent *qt.QWidget, run DataQualityRun, export DataQualityExport, initialDir string) (dataquality.Issue, bool) {
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Перевірка якості даних")
+	dialog.Resize(1200, 700)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	controls := qt.NewQHBoxLayout2()
+	severity := qt.NewQComboBox2()
+	for _, option := range dataQualitySeverityOptions {
+		severity.AddItem(option.label)
+	}
+	search := lineEdit()
+	search.SetPlaceholderText("Пошук за номером, назвою, полем або описом")
+	runButton := qt.NewQPushButton3("Перевірити")
+	exportButton := qt.NewQPushButton3("Експорт XLSX")
+	controls.AddWidget(qt.NewQLabel3("Рівень").QWidget)
+	controls.AddWidget(severity.QWidget)
+	controls.AddWidget(search.QWidget)
+	controls.AddWidget(runButton.QWidget)
+	controls.AddWidget(exportButton.QWidget)
+	layout.AddLayout(controls.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	headers := []string{"Рівень", "Джерело", "№", "Назва", "Поле", "Проблема", "Деталі"}
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	table.SetSelectionBehavior(qt.QAbstractItemView__SelectRows)
+	table.SetSelectionMode(qt.QAbstractItemView__SingleSelection)
+	table.SetEditTriggers(qt.QAbstractItemView__NoEditTriggers)
+	table.SetWordWrap(false)
+	layout.AddWidget(table.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	fixButton := buttons.AddButton2("Виправити в редакторі", qt.QDialogButtonBox__ActionRole)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+
+	var (
+		allIssues     []dataquality.Issue
+		visibleIssues []dataquality.Issue
+		chosen        dataquality.Issue
+		accepted      bool
+		running       bool
+		closed        bool
+	)
+
+	render := func() {
+		selected := dataQualitySeverityOptions[max(severity.CurrentIndex(), 0)].severity
+		visibleIssues = dataquality.Filter(allIssues, selected, search.Text())
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		for row, issue := range visibleIssues {
+			addReadOnlyRow(model, dataQualityRowValues(issue))
+			if color, ok := dataQualitySeverityColor(issue.Severity); ok {
+				model.Item(row).SetData(qt.NewQColor6(color).ToQVariant(), int(qt.BackgroundRole))
+			}
+		}
+		table.ResizeColumnsToContents()
+		table.HorizontalHeader().SetStretchLastSection(true)
+		if !running {
+			status.SetText(dataquality.Summary(allIssues) + fmt.Sprintf(" | показано: %d", len(visibleIssues)))
+		}
+		exportButton.SetEnabled(!running && len(visibleIssues) > 0 && export != nil)
+	}
+
+	startRun := func() {
+		if run == nil || running {
+			return
+		}
+		running = true
+		runButton.SetEnabled(false)
+		exportButton.SetEnabled(false)
+		status.SetText("Завантаження об'єктів...")
+		startedAt := time.Now()
+		run(func(done int, total int) {
+			if closed {
+				return
+			}
+			status.SetText(fmt.Sprintf("Перевірено об'єктів: %d з %d", done, total))
+		}, func(issues []dataquality.Issue, err error) {
+			if closed {
+				return
+			}
+			running = false
+			runButton.SetEnabled(true)
+			if err != nil {
+				status.SetText("Перевірку не виконано: " + err.Error())
+				render()
+				return
+			}
+			allIssues = issues
+			render()
+			status.SetText(status.Text() + fmt.Sprintf(" | %s", time.Since(startedAt).Round(time.Second)))
+		})
+	}
+
+	openSelected := func() {
+		index := table.CurrentIndex()
+		if index == nil || !index.IsValid() || index.Row() < 0 || index.Row() >= len(visibleIssues) {
+			return
+		}
+		chosen = visibleIssues[index.Row()]
+		accepted = true
+		dialog.Accept()
+	}
+
+	exportVisible := func() {
+		if export == nil || len(visibleIssues) == 0 {
+			return
+		}
+		filePath, ok := chooseDataQualityXLSXPath(dialog.QWidget, initialDir)
+		if !ok {
+			return
+		}
+		if err := export(filePath, visibleIssues); err != nil {
+			qt.QMessageBox_Warning(dialog.QWidget, "Експорт XLSX", "Не вдалося створити файл: "+err.Error())
+			return
+		}
+		status.SetText(fmt.Sprintf("Експортовано %d проблем: %s", len(visibleIssues), filePath))
+	}
+
+	runButton.OnClicked(startRun)
+	exportButton.OnClicked(exportVisible)
+	fixButton.OnClicked(openSelected)
+	table.OnDoubleClicked(func(*qt.QModelIndex) { openSelected() })
+	search.OnTextChanged(func(string) { render() })
+	severity.OnCurrentIndexChanged(func(int) { render() })
+
+	render()
+	startRun()
+	dialog.Exec()
+	closed = true
+	return chosen, accepted
+}
+
+func chooseDataQualityXLSXPath(parent *qt.QWidget, initialDir string) (string, bool) {
+	initialDir = strings.TrimSpace(initialDir)
+	if initialDir == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			initialDir = filepath.Join(homeDir, "Downloads")
+		}
+	}
+	dialog := qt.NewQFileDialog6(parent, "Експорт перевірки якості даних", initialDir, "Excel files (*.xlsx)")
+	defer dialog.Delete()
+	dialog.SetAcceptMode(qt.QFileDialog__AcceptSave)
+	dialog.SetFileMode(qt.QFileDialog__AnyFile)
+	dialog.SetDefaultSuffix("xlsx")
+	dialog.SelectFile("data_quality_" + time.Now().Format("2006-01-02") + ".xlsx")
+	if dialog.Exec() != int(qt.QDialog__Accepted) {
+		return "", false
+	}
+	files := dialog.SelectedFiles()
+	if len(files) == 0 || strings.TrimSpace(files[0]) == "" {
+		return "", false
+	}
+	filePath := strings.TrimSpace(files[0])
+	if !strings.EqualFold(filepath.Ext(filePath), ".xlsx") {
+		filePath += ".xlsx"
+	}
+	return filePath, true
+}
+
+func dataQualityRowValues(issue dataquality.Issue) []string {
+	return []string{
+		dataquality.SeverityLabel(issue.Severity),
+		issue.Source.DisplayName(),
+		issue.ObjectNumber,
+		issue.ObjectName,
+		issue.Field,
+		dataquality.CodeLabel(issue.Code),
+		issue.Details,
+	}
+}
+
+func dataQualitySeverityColor(severity string) (string, bool) {
+	switch severity {
+	case dataquality.SeverityError:
+		return "#F8CBAD", true
+	case dataquality.SeverityWarn:
+		return "#FFE699", true
+	case dataquality.SeverityInfo:
+		return "#DDEBF7", true
+	default:
+		return "", false
+	}
+}
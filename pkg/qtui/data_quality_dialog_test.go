@@ -0,0 +1,34 @@
+//go:build qt
+
+package qtui
+
+import (
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
+)
+
+func TestDataQualityRowValues(t *testing.T) {
+	values := dataQualityRowValues(dataquality.Issue{
+		Severity:     dataquality.SeverityWarn,
+		Code:         dataquality.CodeNoContacts,
+		Source:       contracts.FrontendSourceBridge,
+		ObjectNumber: "1001",
+		ObjectName:   "Магазин",
+		Field:        "Відповідальні",
+		Details:      "у об'єкта немає жодного контакту",
+	})
+	want := []string{"Попередження", contracts.FrontendSourceBridge.DisplayName(), "1001", "Магазин", "Відповідальні", "Немає контактів", "у об'єкта немає жодного контакту"}
+	if len(values) != len(want) {
+		t.Fatalf("values = %v", values)
+	}
+	for index := range want {
+		if values[index] != want[index] {
+			t.Fatalf("values[%d] = %q, want %q", index, values[index], want[index])
+		}
+	}
+	if _, ok := dataQualitySeverityColor("unknown"); ok {
+		t.Fatal("unknown severity must not be colored")
+	}
+}
@@ -0,0 +1,271 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// EventArchiveLoad loads one archive page in the background. done must be delivered on the UI thread.
+type EventArchiveLoad func(query contracts.EventArchiveQuery, done func(contracts.EventArchivePage, error))
+
+// EventArchiveExport streams the archive matching query into filePath in the background.
+// progress and done must be delivered on the UI thread; the returned function cancels the export.
+type EventArchiveExport func(query contracts.EventArchiveQuery, filePath string, progress func(written int), done func(written int, err error)) (cancel func())
+
+const eventArchivePageSize = 500
+
+// ShowEventArchiveDialog shows the Phoenix event archive browser with paging and file export.
+// object, when set, prefills the "only this object" filter.
+func ShowEventArchiveDialog(parent *qt.QWidget, load EventArchiveLoad, export EventArchiveExport, object *models.Object, initialDir string) {
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Архів подій Phoenix")
+	dialog.Resize(1200, 720)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	objectNumber := ""
+	objectCheck := qt.NewQCheckBox3("Лише поточний об'єкт")
+	if object != nil {
+		objectNumber = viewmodels.ObjectDisplayNumber(*object)
+		objectCheck.SetText(fmt.Sprintf("Лише №%s %s", objectNumber, strings.TrimSpace(object.Name)))
+		objectCheck.SetChecked(true)
+	} else {
+		objectCheck.SetEnabled(false)
+	}
+	today := time.Now()
+	fromEntry := lineEdit()
+	fromEntry.SetText(today.AddDate(0, 0, -30).Format(viewmodels.EventArchiveDateLayout))
+	toEntry := lineEdit()
+	toEntry.SetText(today.Format(viewmodels.EventArchiveDateLayout))
+	codeEntry := lineEdit()
+	codeEntry.SetPlaceholderText("Код, напр. E130")
+	groupsEntry := lineEdit()
+	groupsEntry.SetPlaceholderText("Групи: 1, 2")
+
+	filters := qt.NewQHBoxLayout2()
+	filters.AddWidget(objectCheck.QWidget)
+	filters.AddWidget(qt.NewQLabel3("З").QWidget)
+	filters.AddWidget(fromEntry.QWidget)
+	filters.AddWidget(qt.NewQLabel3("По").QWidget)
+	filters.AddWidget(toEntry.QWidget)
+	filters.AddWidget(qt.NewQLabel3("Код").QWidget)
+	filters.AddWidget(codeEntry.QWidget)
+	filters.AddWidget(qt.NewQLabel3("Групи").QWidget)
+	filters.AddWidget(groupsEntry.QWidget)
+	layout.AddLayout(filters.QLayout)
+
+	searchButton := qt.NewQPushButton3("Шукати")
+	moreButton := qt.NewQPushButton3("Ще")
+	exportCSVButton := qt.NewQPushButton3("Експорт CSV")
+	exportXLSXButton := qt.NewQPushButton3("Експорт XLSX")
+	cancelButton := qt.NewQPushButton3("Скасувати експорт")
+	actions := qt.NewQHBoxLayout2()
+	for _, button := range []*qt.QPushButton{searchButton, moreButton, exportCSVButton, exportXLSXButton, cancelButton} {
+		actions.AddWidget(button.QWidget)
+	}
+	actions.AddStretch()
+	layout.AddLayout(actions.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	model := qt.NewQStandardItemModel2(0, len(objexport.EventExportHeaders))
+	table := newTable(model, objexport.EventExportHeaders)
+	table.SetSelectionBehavior(qt.QAbstractItemView__SelectRows)
+	table.SetEditTriggers(qt.QAbstractItemView__NoEditTriggers)
+	table.SetWordWrap(false)
+	layout.AddWidget(table.QWidget)
+
+	hint := qt.NewQLabel3("Експорт читає архів сторінками і пише у файл одразу, тож підходить для запитів за роки.")
+	hint.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(hint.QWidget)
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+
+	var (
+		query        contracts.EventArchiveQuery
+		nextCursor   string
+		shown        int
+		loading      bool
+		closed       bool
+		exportCancel func()
+	)
+	updateButtons := func() {
+		exporting := exportCancel != nil
+		searchButton.SetEnabled(load != nil && !loading && !exporting)
+		moreButton.SetEnabled(load != nil && !loading && !exporting && nextCursor != "")
+		exportCSVButton.SetEnabled(export != nil && !loading && !exporting)
+		exportXLSXButton.SetEnabled(export != nil && !loading && !exporting)
+		cancelButton.SetEnabled(exporting)
+	}
+
+	buildQuery := func() (contracts.EventArchiveQuery, bool) {
+		input := viewmodels.EventArchiveFilterInput{
+			Code:   codeEntry.Text(),
+			Groups: groupsEntry.Text(),
+			From:   fromEntry.Text(),
+			To:     toEntry.Text(),
+		}
+		if object != nil && objectCheck.IsChecked() {
+			input.ObjectID = object.ID
+		}
+		built, err := viewmodels.BuildEventArchiveQuery(input)
+		if err != nil {
+			status.SetText(err.Error())
+			return contracts.EventArchiveQuery{}, false
+		}
+		return built, true
+	}
+
+	loadPage := func(reset bool) {
+		if load == nil || loading {
+			return
+		}
+		pageQuery := query
+		if reset {
+			built, ok := buildQuery()
+			if !ok {
+				return
+			}
+			query = built
+			pageQuery = built
+		} else {
+			pageQuery.Cursor = nextCursor
+		}
+		pageQuery.Limit = eventArchivePageSize
+		loading = true
+		updateButtons()
+		status.SetText("Завантаження архіву...")
+		load(pageQuery, func(page contracts.EventArchivePage, err error) {
+			if closed {
+				return
+			}
+			loading = false
+			if err != nil {
+				status.SetText("Архів недоступний: " + err.Error())
+				updateButtons()
+				return
+			}
+			if reset {
+				model.Clear()
+				model.SetHorizontalHeaderLabels(objexport.EventExportHeaders)
+				shown = 0
+			}
+			for _, event := range page.Events {
+				addReadOnlyRow(model, objexport.EventExportValues(event))
+			}
+			shown += len(page.Events)
+			nextCursor = page.NextCursor
+			if reset {
+				table.ResizeColumnsToContents()
+				table.HorizontalHeader().SetStretchLastSection(true)
+				table.ScrollToTop()
+			}
+			more := ""
+			if nextCursor != "" {
+				more = " | є ще події — «Ще»"
+			}
+			status.SetText(fmt.Sprintf("Показано подій: %d%s", shown, more))
+			updateButtons()
+		})
+	}
+
+	startExport := func(ext string) {
+		if export == nil {
+			return
+		}
+		exportQuery, ok := buildQuery()
+		if !ok {
+			return
+		}
+		fileObject := ""
+		if exportQuery.ObjectID != 0 {
+			fileObject = objectNumber
+		}
+		filePath, ok := chooseEventFilePath(dialog.QWidget, "Експорт архіву подій", initialDir, viewmodels.EventArchiveExportFileName(fileObject, ext, time.Now()), ext)
+		if !ok {
+			return
+		}
+		status.SetText("Експорт: " + filePath)
+		exportCancel = export(exportQuery, filePath, func(written int) {
+			if !closed {
+				status.SetText(fmt.Sprintf("Експортовано подій: %d...", written))
+			}
+		}, func(written int, err error) {
+			exportCancel = nil
+			if closed {
+				return
+			}
+			updateButtons()
+			if err != nil {
+				status.SetText("Експорт не виконано: " + err.Error())
+				return
+			}
+			status.SetText(fmt.Sprintf("Експортовано подій: %d | %s", written, filePath))
+		})
+		updateButtons()
+	}
+
+	searchButton.OnClicked(func() { loadPage(true) })
+	moreButton.OnClicked(func() { loadPage(false) })
+	exportCSVButton.OnClicked(func() { startExport("csv") })
+	exportXLSXButton.OnClicked(func() { startExport("xlsx") })
+	cancelButton.OnClicked(func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+
+	updateButtons()
+	loadPage(true)
+	dialog.Exec()
+	closed = true
+	if exportCancel != nil {
+		exportCancel()
+	}
+}
+
+func chooseEventFilePath(parent *qt.QWidget, title string, initialDir string, fileName string, ext string) (string, bool) {
+	initialDir = strings.TrimSpace(initialDir)
+	if initialDir == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			initialDir = filepath.Join(homeDir, "Downloads")
+		}
+	}
+	filter := "CSV files (*.csv)"
+	if ext == "xlsx" {
+		filter = "Excel files (*.xlsx)"
+	}
+	dialog := qt.NewQFileDialog6(parent, title, initialDir, filter)
+	defer dialog.Delete()
+	dialog.SetAcceptMode(qt.QFileDialog__AcceptSave)
+	dialog.SetFileMode(qt.QFileDialog__AnyFile)
+	dialog.SetDefaultSuffix(ext)
+	dialog.SelectFile(fileName)
+	if dialog.Exec() != int(qt.QDialog__Accepted) {
+		return "", false
+	}
+	files := dialog.SelectedFiles()
+	if len(files) == 0 || strings.TrimSpace(files[0]) == "" {
+		return "", false
+	}
+	filePath := strings.TrimSpace(files[0])
+	if !strings.EqualFold(filepath.Ext(filePath), "."+ext) {
+		filePath += "." + ext
+	}
+	return filePath, true
+}
@@ -0,0 +1,172 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// EventJournalExport streams the journal events matching input into filePath in the background.
+// progress and done must be delivered on the UI thread; the returned function cancels the export.
+type EventJournalExport func(input viewmodels.EventExportFilterInput, filePath string, progress func(written int), done func(written int, err error)) (cancel func())
+
+// ShowEventExportDialog shows the event journal export form prefilled from the event log filters.
+func ShowEventExportDialog(parent *qt.QWidget, export EventJournalExport, preset viewmodels.EventExportFilterInput, initialDir string) {
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Експорт журналу подій")
+	dialog.Resize(720, 640)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	form := qt.NewQFormLayout2()
+	form.SetFieldGrowthPolicy(qt.QFormLayout__AllNonFixedFieldsGrow)
+	sources := qt.NewQHBoxLayout2()
+	sourceChecks := make([]*qt.QCheckBox, 0, len(viewmodels.EventExportSourceOptions))
+	for _, option := range viewmodels.EventExportSourceOptions {
+		check := qt.NewQCheckBox3(option)
+		check.SetChecked(slices.Contains(preset.Sources, option))
+		sources.AddWidget(check.QWidget)
+		sourceChecks = append(sourceChecks, check)
+	}
+	sources.AddStretch()
+	sourcesWidget := qt.NewQWidget2()
+	sourcesWidget.SetLayout(sources.QLayout)
+	form.AddRow3("Джерела", sourcesWidget)
+
+	severitySelect := qt.NewQComboBox2()
+	severitySelect.AddItems(viewmodels.EventExportSeverityOptions)
+	severitySelect.SetCurrentText(preset.Severity)
+	form.AddRow3("Важливість", severitySelect.QWidget)
+	objectsEntry := lineEdit()
+	objectsEntry.SetPlaceholderText("Номери об'єктів через кому; порожньо — усі")
+	objectsEntry.SetText(preset.Objects)
+	form.AddRow3("Об'єкти", objectsEntry.QWidget)
+	fromEntry := lineEdit()
+	fromEntry.SetText(preset.From)
+	form.AddRow3("З", fromEntry.QWidget)
+	toEntry := lineEdit()
+	toEntry.SetText(preset.To)
+	form.AddRow3("По", toEntry.QWidget)
+	layout.AddLayout(form.QLayout)
+
+	layout.AddWidget(qt.NewQLabel3("Типи подій").QWidget)
+	typeOptions := viewmodels.EventExportTypeOptions()
+	typeList := qt.NewQListWidget2()
+	for _, option := range typeOptions {
+		item := qt.NewQListWidgetItem2(option.Label)
+		item.SetCheckState(qt.Unchecked)
+		if slices.Contains(preset.Types, option.Type) {
+			item.SetCheckState(qt.Checked)
+		}
+		typeList.AddItemWithItem(item)
+	}
+	layout.AddWidget(typeList.QWidget)
+
+	hint := qt.NewQLabel3("Без вибраних джерел і типів експортуються всі. Файл пишеться поступово, тож підходить для великих періодів.")
+	hint.SetWordWrap(true)
+	hint.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(hint.QWidget)
+
+	exportCSVButton := qt.NewQPushButton3("Експорт CSV")
+	exportXLSXButton := qt.NewQPushButton3("Експорт XLSX")
+	cancelButton := qt.NewQPushButton3("Скасувати експорт")
+	actions := qt.NewQHBoxLayout2()
+	for _, button := range []*qt.QPushButton{exportCSVButton, exportXLSXButton, cancelButton} {
+		actions.AddWidget(button.QWidget)
+	}
+	actions.AddStretch()
+	layout.AddLayout(actions.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetWordWrap(true)
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+
+	var (
+		closed       bool
+		exportCancel func()
+	)
+	updateButtons := func() {
+		exporting := exportCancel != nil
+		exportCSVButton.SetEnabled(export != nil && !exporting)
+		exportXLSXButton.SetEnabled(export != nil && !exporting)
+		cancelButton.SetEnabled(exporting)
+	}
+
+	formInput := func() viewmodels.EventExportFilterInput {
+		input := viewmodels.EventExportFilterInput{
+			Severity: severitySelect.CurrentText(),
+			Objects:  objectsEntry.Text(),
+			From:     fromEntry.Text(),
+			To:       toEntry.Text(),
+		}
+		for i, check := range sourceChecks {
+			if check.IsChecked() {
+				input.Sources = append(input.Sources, viewmodels.EventExportSourceOptions[i])
+			}
+		}
+		for i, option := range typeOptions {
+			if typeList.Item(i).CheckState() == qt.Checked {
+				input.Types = append(input.Types, option.Type)
+			}
+		}
+		return input
+	}
+
+	startExport := func(ext string) {
+		if export == nil {
+			return
+		}
+		input := formInput()
+		if _, err := viewmodels.BuildEventExportFilter(viewmodels.EventExportFilterInput{From: input.From, To: input.To}, nil); err != nil {
+			status.SetText(err.Error())
+			return
+		}
+		filePath, ok := chooseEventFilePath(dialog.QWidget, "Експорт журналу подій", initialDir, viewmodels.EventExportFileName(ext, time.Now()), ext)
+		if !ok {
+			return
+		}
+		status.SetText("Експорт: " + filePath)
+		exportCancel = export(input, filePath, func(written int) {
+			if !closed {
+				status.SetText(fmt.Sprintf("Експортовано подій: %d...", written))
+			}
+		}, func(written int, err error) {
+			exportCancel = nil
+			if closed {
+				return
+			}
+			updateButtons()
+			if err != nil {
+				status.SetText("Експорт не виконано: " + err.Error())
+				return
+			}
+			status.SetText(fmt.Sprintf("Експортовано подій: %d | %s", written, filePath))
+		})
+		updateButtons()
+	}
+
+	exportCSVButton.OnClicked(func() { startExport("csv") })
+	exportXLSXButton.OnClicked(func() { startExport("xlsx") })
+	cancelButton.OnClicked(func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+
+	updateButtons()
+	dialog.Exec()
+	closed = true
+	if exportCancel != nil {
+		exportCancel()
+	}
+}
@@ -5,15 +5,19 @@ package qtui
 import (
 	"hash/fnv"
 	"strconv"
+	"strings"
+	"time"
 
 	qt "github.com/mappu/miqt/qt6"
 
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 )
 
 const (
+	prefQtEventLogQuery           = "qt.eventLog.query"
 	prefQtEventLogPeriod          = "qt.eventLog.period"
 	prefQtEventLogSource          = "qt.eventLog.source"
 	prefQtEventLogSeverity        = "qt.eventLog.severity"
@@ -39,15 +43,21 @@ type EventLogPanel struct {
 	filterUpdating     bool
 	autoSized          bool
 	prefs              config.Preferences
+	objectGroups       *objectgroups.Registry
 
+	queryEdit      *qt.QLineEdit
+	queryTimer     *qt.QTimer
 	pauseBtn       *qt.QPushButton
 	rangeSelect    *qt.QComboBox
 	sourceSelect   *qt.QComboBox
+	groupSelect    *qt.QComboBox
 	severitySelect *qt.QComboBox
 	contextToggle  *qt.QCheckBox
 
 	OnEventSelected func(models.Event)
 	OnCountChanged  func(count int)
+	// OnExportRequested opens the journal export with the panel filters.
+	OnExportRequested func()
 }
 
 func NewEventLogPanel(prefs config.Preferences) *EventLogPanel {
@@ -83,6 +93,12 @@ func NewEventLogPanel(prefs config.Preferences) *EventLogPanel {
 		panel.applyFilters()
 	})
 
+	panel.groupSelect = qt.NewQComboBox2()
+	panel.groupSelect.SetVisible(false)
+	panel.groupSelect.OnCurrentIndexChanged(func(int) {
+		panel.applyFilters()
+	})
+
 	panel.rangeSelect = qt.NewQComboBox2()
 	panel.rangeSelect.AddItems([]string{"Остання година", "Сьогодні", "Всі"})
 	panel.rangeSelect.OnCurrentTextChanged(func(string) {
@@ -109,11 +125,42 @@ func NewEventLogPanel(prefs config.Preferences) *EventLogPanel {
 		panel.TogglePause()
 	})
 
+	panel.queryEdit = qt.NewQLineEdit2()
+	panel.queryEdit.SetPlaceholderText("Фільтр: severity:critical source:casl ...")
+	panel.queryEdit.SetToolTip(viewmodels.FilterQueryHint)
+	panel.queryEdit.SetClearButtonEnabled(true)
+	panel.queryEdit.SetMinimumWidth(220)
+	panel.queryTimer = qt.NewQTimer()
+	panel.queryTimer.SetSingleShot(true)
+	panel.queryTimer.SetInterval(200)
+	panel.queryTimer.OnTimeout(func() {
+		panel.applyFilters()
+	})
+	panel.queryEdit.OnTextChanged(func(string) {
+		if panel.filterUpdating {
+			return
+		}
+		panel.saveFilterPrefs()
+		panel.queryTimer.Start2()
+	})
+
+	toolbar.AddWidget(panel.queryEdit.QWidget)
+	toolbar.AddWidget(newSavedViewsButton(prefs, panel.queryEdit.Text, panel.queryEdit.SetText).QWidget)
 	toolbar.AddWidget(panel.contextToggle.QWidget)
 	toolbar.AddWidget(panel.sourceSelect.QWidget)
+	toolbar.AddWidget(panel.groupSelect.QWidget)
 	toolbar.AddWidget(panel.rangeSelect.QWidget)
 	toolbar.AddWidget(panel.severitySelect.QWidget)
+	exportBtn := qt.NewQPushButton3("⤓ Експорт")
+	exportBtn.SetToolTip("Експорт журналу подій у CSV/XLSX за довільний період")
+	exportBtn.OnClicked(func() {
+		if panel.OnExportRequested != nil {
+			panel.OnExportRequested()
+		}
+	})
+
 	toolbar.AddWidget(panel.pauseBtn.QWidget)
+	toolbar.AddWidget(exportBtn.QWidget)
 	layout.AddLayout(toolbar.QLayout)
 
 	panel.model = newEventLogTableModel(eventLogHeaders())
@@ -157,6 +204,15 @@ func (panel *EventLogPanel) SetEvents(events []models.Event) {
 	panel.applyFilters()
 }
 
+// SetObjectGroups підключає спільні групи об'єктів до фільтра журналу.
+func (panel *EventLogPanel) SetObjectGroups(groups *objectgroups.Registry) {
+	if panel == nil {
+		return
+	}
+	panel.objectGroups = groups
+	panel.applyFilters()
+}
+
 func (panel *EventLogPanel) applyColumnWidths() {
 	if panel.autoSized {
 		return
@@ -187,8 +243,16 @@ func (panel *EventLogPanel) applyFilters() {
 		eventLogLimit = config.LoadUIConfig(panel.prefs).EventLogLimit
 	}
 
+	query := ""
+	if panel.queryEdit != nil {
+		query = panel.queryEdit.Text()
+	}
+	groupID := refreshGroupFilter(panel.groupSelect, panel.objectGroups)
+
 	input := viewmodels.EventLogFilterInput{
 		AllEvents:          panel.allEvents,
+		Query:              query,
+		GroupMembers:       panel.objectGroups.Membership().Members(groupID),
 		Period:             period,
 		SelectedSource:     selectedSource,
 		SeverityFilter:     severityFilter,
@@ -200,6 +264,13 @@ func (panel *EventLogPanel) applyFilters() {
 		input.HasCurrentObject = true
 	}
 	out := panel.vm.ApplyFilters(input)
+	if panel.queryEdit != nil {
+		panel.queryEdit.SetToolTip(viewmodels.FilterQueryHint)
+		if out.QueryError != "" {
+			panel.queryEdit.SetToolTip(out.QueryError)
+		}
+		panel.queryEdit.SetStyleSheet(eventLogQueryStyle(out.QueryError))
+	}
 
 	panel.filteredEvents = out.Filtered
 	if panel.OnCountChanged != nil {
@@ -264,6 +335,26 @@ func (panel *EventLogPanel) SetCurrentObject(obj *models.Object) {
 	panel.applyFilters()
 }
 
+// ExportPreset returns the panel filters as the initial export form values.
+func (panel *EventLogPanel) ExportPreset() viewmodels.EventExportFilterInput {
+	if panel == nil {
+		return viewmodels.EventExportPreset("", "", false, nil, time.Now())
+	}
+	selectedSource := viewmodels.ObjectSourceAll
+	if panel.sourceSelect != nil {
+		selectedSource = panel.sourceSelect.CurrentText()
+	}
+	severity := eventLogSeverityAll
+	if panel.severitySelect != nil {
+		severity = panel.severitySelect.CurrentText()
+	}
+	var currentObject *models.Object
+	if panel.showForCurrentOnly {
+		currentObject = panel.currentObject
+	}
+	return viewmodels.EventExportPreset(selectedSource, severity, false, currentObject, time.Now())
+}
+
 func (panel *EventLogPanel) TogglePause() {
 	if panel == nil || panel.pauseBtn == nil {
 		return
@@ -306,6 +397,9 @@ func (panel *EventLogPanel) restoreFilterPrefs() {
 		return
 	}
 	panel.filterUpdating = true
+	if panel.queryEdit != nil {
+		panel.queryEdit.SetText(panel.prefs.StringWithFallback(prefQtEventLogQuery, ""))
+	}
 	if panel.rangeSelect != nil {
 		panel.rangeSelect.SetCurrentText(panel.prefs.StringWithFallback(prefQtEventLogPeriod, "Остання година"))
 	}
@@ -327,6 +421,9 @@ func (panel *EventLogPanel) saveFilterPrefs() {
 	if panel == nil || panel.prefs == nil || panel.filterUpdating {
 		return
 	}
+	if panel.queryEdit != nil {
+		panel.prefs.SetString(prefQtEventLogQuery, strings.TrimSpace(panel.queryEdit.Text()))
+	}
 	if panel.rangeSelect != nil {
 		panel.prefs.SetString(prefQtEventLogPeriod, panel.rangeSelect.CurrentText())
 	}
@@ -341,6 +438,14 @@ func (panel *EventLogPanel) saveFilterPrefs() {
 	}
 }
 
+// eventLogQueryStyle marks the filter line red while the expression does not parse.
+func eventLogQueryStyle(queryError string) string {
+	if queryError == "" {
+		return ""
+	}
+	return "border: 1px solid #c62828;"
+}
+
 func (panel *EventLogPanel) showContextMenu(pos *qt.QPoint) {
 	if panel == nil || panel.table == nil || pos == nil {
 		return
@@ -0,0 +1,319 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// SuppressedAlarmsExport writes the suppressed-alarm report to filePath.
+type SuppressedAlarmsExport func(filePath string, entries []maintenance.SuppressedAlarm) error
+
+// ShowMaintenanceWindowsDialog shows maintenance windows and the suppressed-alarm report.
+// object prefills the new window form. It reports whether windows were changed.
+func ShowMaintenanceWindowsDialog(
+	parent *qt.QWidget,
+	store *maintenance.FileStore,
+	object *models.Object,
+	user string,
+	export SuppressedAlarmsExport,
+	initialDir string,
+) bool {
+	if store == nil {
+		return false
+	}
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Вікна обслуговування")
+	dialog.Resize(1100, 700)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	changed := false
+	tabs := qt.NewQTabWidget2()
+	tabs.AddTab(maintenanceWindowsTab(dialog.QWidget, store, object, user, func() { changed = true }), "Вікна")
+	tabs.AddTab(maintenanceSuppressedTab(dialog.QWidget, store, export, initialDir), "Приглушені тривоги")
+	layout.AddWidget(tabs.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+	dialog.Exec()
+	return changed
+}
+
+func maintenanceWindowsTab(
+	parent *qt.QWidget,
+	store *maintenance.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) *qt.QWidget {
+	tab := qt.NewQWidget2()
+	layout := qt.NewQVBoxLayout(tab)
+
+	form := qt.NewQFormLayout2()
+	form.SetFieldGrowthPolicy(qt.QFormLayout__AllNonFixedFieldsGrow)
+	objectText := "Об'єкт не вибрано"
+	if object != nil {
+		objectText = fmt.Sprintf("№%s %s (%s)", viewmodels.ObjectDisplayNumber(*object), strings.TrimSpace(object.Name), viewmodels.ObjectSourceByID(object.ID))
+	}
+	form.AddRow3("Об'єкт", qt.NewQLabel3(objectText).QWidget)
+	zones := lineEdit()
+	zones.SetPlaceholderText("Порожньо — увесь об'єкт, або 1, 3, 5")
+	form.AddRow3("Зони", zones.QWidget)
+	now := time.Now().Truncate(time.Minute)
+	start := lineEdit()
+	start.SetText(now.Format(maintenance.FormTimeLayout))
+	form.AddRow3("Початок", start.QWidget)
+	end := lineEdit()
+	end.SetText(now.Add(2 * time.Hour).Format(maintenance.FormTimeLayout))
+	form.AddRow3("Кінець", end.QWidget)
+	recurrence := qt.NewQComboBox2()
+	for _, option := range maintenance.Recurrences {
+		recurrence.AddItem(option.Label())
+	}
+	form.AddRow3("Повторення", recurrence.QWidget)
+	until := lineEdit()
+	until.SetPlaceholderText("ДД.ММ.РРРР — без обмеження")
+	form.AddRow3("Повторювати до", until.QWidget)
+	mode := qt.NewQComboBox2()
+	for _, option := range maintenance.Modes {
+		mode.AddItem(option.Label())
+	}
+	form.AddRow3("Режим", mode.QWidget)
+	reason := lineEdit()
+	reason.SetPlaceholderText("Напр.: заміна АКБ, ремонт приміщення")
+	form.AddRow3("Причина", reason.QWidget)
+	layout.AddLayout(form.QLayout)
+
+	actions := qt.NewQHBoxLayout2()
+	addButton := qt.NewQPushButton3("Додати вікно")
+	deleteButton := qt.NewQPushButton3("Видалити вибране")
+	actions.AddWidget(addButton.QWidget)
+	actions.AddWidget(deleteButton.QWidget)
+	actions.AddStretch()
+	layout.AddLayout(actions.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	headers := []string{"Стан", "№", "Назва", "Зони", "Початок", "Кінець", "Повторення", "Режим", "Причина"}
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	// Рядки відповідають windows за індексом, тому без сортування.
+	table.SetSortingEnabled(false)
+	table.SetSelectionMode(qt.QAbstractItemView__SingleSelection)
+	layout.AddWidget(table.QWidget)
+
+	var windows []maintenance.Window
+	reload := func() {
+		loaded, err := store.Windows()
+		if err != nil {
+			status.SetText("Не вдалося прочитати вікна: " + err.Error())
+			return
+		}
+		windows = loaded
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		now := time.Now()
+		for _, window := range windows {
+			addReadOnlyRow(model, []string{
+				window.StateLabel(now),
+				window.ObjectNumber,
+				window.ObjectName,
+				window.ZonesLabel(),
+				window.Start.Format(maintenance.FormTimeLayout),
+				window.End.Format(maintenance.FormTimeLayout),
+				window.Recurrence.Label(),
+				window.Mode.Label(),
+				window.Reason,
+			})
+		}
+		table.ResizeColumnsToContents()
+		status.SetText(fmt.Sprintf("Вікон: %d | файл: %s", len(windows), store.Path()))
+	}
+
+	addButton.OnClicked(func() {
+		if object == nil {
+			qt.QMessageBox_Information(parent, "Вікно обслуговування", "Виберіть об'єкт у списку перед створенням вікна.")
+			return
+		}
+		window, err := maintenance.Form{
+			ObjectID:     object.ID,
+			ObjectNumber: viewmodels.ObjectDisplayNumber(*object),
+			ObjectName:   object.Name,
+			Zones:        zones.Text(),
+			Start:        start.Text(),
+			End:          end.Text(),
+			Until:        until.Text(),
+			Recurrence:   maintenance.Recurrences[max(recurrence.CurrentIndex(), 0)],
+			Mode:         maintenance.Modes[max(mode.CurrentIndex(), 0)],
+			Reason:       reason.Text(),
+			CreatedBy:    user,
+		}.Window()
+		if err == nil {
+			_, err = store.SaveWindow(window)
+		}
+		if err != nil {
+			qt.QMessageBox_Warning(parent, "Вікно обслуговування", err.Error())
+			return
+		}
+		reason.SetText("")
+		reload()
+		onChanged()
+	})
+	deleteButton.OnClicked(func() {
+		index := table.CurrentIndex()
+		if index == nil || !index.IsValid() || index.Row() < 0 || index.Row() >= len(windows) {
+			return
+		}
+		window := windows[index.Row()]
+		if qt.QMessageBox_Question(parent, "Видалити вікно", "Видалити вікно обслуговування «"+window.Reason+"»?") != qt.QMessageBox__Yes {
+			return
+		}
+		if err := store.DeleteWindow(window.ID); err != nil {
+			qt.QMessageBox_Warning(parent, "Вікно обслуговування", err.Error())
+			return
+		}
+		reload()
+		onChanged()
+	})
+
+	reload()
+	return tab
+}
+
+func maintenanceSuppressedTab(
+	parent *qt.QWidget,
+	store *maintenance.FileStore,
+	export SuppressedAlarmsExport,
+	initialDir string,
+) *qt.QWidget {
+	tab := qt.NewQWidget2()
+	layout := qt.NewQVBoxLayout(tab)
+
+	controls := qt.NewQHBoxLayout2()
+	today := time.Now()
+	fromEntry := lineEdit()
+	fromEntry.SetText(today.AddDate(0, 0, -7).Format(maintenance.FormDateLayout))
+	toEntry := lineEdit()
+	toEntry.SetText(today.Format(maintenance.FormDateLayout))
+	showButton := qt.NewQPushButton3("Показати")
+	exportButton := qt.NewQPushButton3("Експорт CSV")
+	controls.AddWidget(qt.NewQLabel3("Від").QWidget)
+	controls.AddWidget(fromEntry.QWidget)
+	controls.AddWidget(qt.NewQLabel3("До").QWidget)
+	controls.AddWidget(toEntry.QWidget)
+	controls.AddWidget(showButton.QWidget)
+	controls.AddWidget(exportButton.QWidget)
+	controls.AddStretch()
+	layout.AddLayout(controls.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	headers := []string{"Час тривоги", "№", "Назва", "Зона", "Тип", "Деталі", "Причина обслуговування"}
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	layout.AddWidget(table.QWidget)
+
+	var entries []maintenance.SuppressedAlarm
+	load := func() {
+		from, err := time.ParseInLocation(maintenance.FormDateLayout, strings.TrimSpace(fromEntry.Text()), time.Local)
+		if err != nil {
+			status.SetText("Некоректна дата «від». Формат: ДД.ММ.РРРР")
+			return
+		}
+		to, err := time.ParseInLocation(maintenance.FormDateLayout, strings.TrimSpace(toEntry.Text()), time.Local)
+		if err != nil || to.Before(from) {
+			status.SetText("Некоректна дата «до» або діапазон дат")
+			return
+		}
+		loaded, err := store.Suppressed(from, to.AddDate(0, 0, 1))
+		if err != nil {
+			status.SetText("Не вдалося прочитати журнал: " + err.Error())
+			return
+		}
+		entries = loaded
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		for _, entry := range entries {
+			zone := ""
+			if entry.ZoneNumber > 0 {
+				zone = strings.TrimSpace(strconv.Itoa(entry.ZoneNumber) + " " + entry.ZoneName)
+			}
+			addReadOnlyRow(model, []string{
+				entry.Time.Format("02.01.2006 15:04:05"),
+				entry.ObjectNumber,
+				entry.ObjectName,
+				zone,
+				entry.Type,
+				entry.Details,
+				entry.Reason,
+			})
+		}
+		table.ResizeColumnsToContents()
+		status.SetText(fmt.Sprintf("Приглушено тривог: %d", len(entries)))
+		exportButton.SetEnabled(export != nil && len(entries) > 0)
+	}
+
+	showButton.OnClicked(load)
+	exportButton.OnClicked(func() {
+		if export == nil || len(entries) == 0 {
+			return
+		}
+		filePath, ok := chooseCSVSavePath(parent, "Експорт приглушених тривог", "suppressed_alarms", initialDir)
+		if !ok {
+			return
+		}
+		if err := export(filePath, entries); err != nil {
+			qt.QMessageBox_Warning(parent, "Експорт CSV", "Не вдалося створити файл: "+err.Error())
+			return
+		}
+		status.SetText(fmt.Sprintf("Експортовано %d тривог: %s", len(entries), filePath))
+	})
+
+	load()
+	return tab
+}
+
+// chooseCSVSavePath asks where to save a CSV report; fileName gets the current date appended.
+func chooseCSVSavePath(parent *qt.QWidget, title string, fileName string, initialDir string) (string, bool) {
+	initialDir = strings.TrimSpace(initialDir)
+	if initialDir == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			initialDir = filepath.Join(homeDir, "Downloads")
+		}
+	}
+	dialog := qt.NewQFileDialog6(parent, title, initialDir, "CSV files (*.csv)")
+	defer dialog.Delete()
+	dialog.SetAcceptMode(qt.QFileDialog__AcceptSave)
+	dialog.SetFileMode(qt.QFileDialog__AnyFile)
+	dialog.SetDefaultSuffix("csv")
+	dialog.SelectFile(fileName + "_" + time.Now().Format("2006-01-02") + ".csv")
+	if dialog.Exec() != int(qt.QDialog__Accepted) {
+		return "", false
+	}
+	files := dialog.SelectedFiles()
+	if len(files) == 0 || strings.TrimSpace(files[0]) == "" {
+		return "", false
+	}
+	filePath := strings.TrimSpace(files[0])
+	if !strings.EqualFold(filepath.Ext(filePath), ".csv") {
+		filePath += ".csv"
+	}
+	return filePath, true
+}
@@ -45,6 +45,13 @@ type MainWindow struct {
 	OnResponseGroupsRequested func()
 	OnOperationalMapRequested func()
 	OnNewObjectsRequested     func()
+	OnDataQualityRequested    func()
+	OnEventArchiveRequested   func()
+	OnEventExportRequested    func()
+	OnMaintenanceRequested    func()
+	OnObjectGroupsRequested   func()
+	OnOpenCloseRequested      func()
+	OnOverdueTestsRequested   func()
 	OnExportContactsRequested func()
 	OnCreateObjectRequested   func()
 	OnCreateCASLRequested     func()
@@ -140,6 +147,48 @@ func (mw *MainWindow) buildMenuBar() {
 			mw.OnNewObjectsRequested()
 		}
 	})
+	dataQualityAction := viewMenu.AddActionWithText("Перевірка якості даних")
+	dataQualityAction.OnTriggered(func() {
+		if mw.OnDataQualityRequested != nil {
+			mw.OnDataQualityRequested()
+		}
+	})
+	eventArchiveAction := viewMenu.AddActionWithText("Архів подій Phoenix")
+	eventArchiveAction.OnTriggered(func() {
+		if mw.OnEventArchiveRequested != nil {
+			mw.OnEventArchiveRequested()
+		}
+	})
+	eventExportAction := viewMenu.AddActionWithText("Експорт журналу подій")
+	eventExportAction.OnTriggered(func() {
+		if mw.OnEventExportRequested != nil {
+			mw.OnEventExportRequested()
+		}
+	})
+	maintenanceAction := viewMenu.AddActionWithText("Вікна обслуговування")
+	maintenanceAction.OnTriggered(func() {
+		if mw.OnMaintenanceRequested != nil {
+			mw.OnMaintenanceRequested()
+		}
+	})
+	objectGroupsAction := viewMenu.AddActionWithText("Групи об'єктів")
+	objectGroupsAction.OnTriggered(func() {
+		if mw.OnObjectGroupsRequested != nil {
+			mw.OnObjectGroupsRequested()
+		}
+	})
+	overdueTestsAction := viewMenu.AddActionWithText("Контроль періодичних тестів")
+	overdueTestsAction.OnTriggered(func() {
+		if mw.OnOverdueTestsRequested != nil {
+			mw.OnOverdueTestsRequested()
+		}
+	})
+	openCloseAction := viewMenu.AddActionWithText("Розклад відкриття/закриття")
+	openCloseAction.OnTriggered(func() {
+		if mw.OnOpenCloseRequested != nil {
+			mw.OnOpenCloseRequested()
+		}
+	})
 	viewMenu.AddSeparator()
 	if mw.alarmDock != nil {
 		toggleAlarmsAction := mw.alarmDock.ToggleViewAction()
@@ -206,6 +255,11 @@ func (mw *MainWindow) buildLayout() {
 	mw.eventLog.OnCountChanged = func(count int) {
 		mw.setDockCount(mw.eventDock, "Журнал подій", count)
 	}
+	mw.eventLog.OnExportRequested = func() {
+		if mw.OnEventExportRequested != nil {
+			mw.OnEventExportRequested()
+		}
+	}
 
 	mw.topSplitter = qt.NewQSplitter3(qt.Horizontal)
 	mw.topSplitter.AddWidget(mw.objectList.QWidget)
@@ -0,0 +1,190 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"strings"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// ShowObjectGroupsDialog shows the shared object groups and watchlists editor.
+// objects returns the current objects of all sources; object is offered for a quick add.
+// It reports whether groups were changed.
+func ShowObjectGroupsDialog(
+	parent *qt.QWidget,
+	store *objectgroups.FileStore,
+	objects func() []models.Object,
+	object *models.Object,
+	user string,
+) bool {
+	if store == nil {
+		return false
+	}
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Групи об'єктів")
+	dialog.Resize(1000, 680)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	form := qt.NewQFormLayout2()
+	form.SetFieldGrowthPolicy(qt.QFormLayout__AllNonFixedFieldsGrow)
+	name := lineEdit()
+	name.SetPlaceholderText("Напр.: Банки Оболоні")
+	form.AddRow3("Назва", name.QWidget)
+	members := qt.NewQPlainTextEdit2()
+	members.SetPlaceholderText("Номери об'єктів через кому або з нового рядка")
+	members.SetMaximumHeight(96)
+	form.AddRow3("Об'єкти", members.QWidget)
+	query := lineEdit()
+	query.SetPlaceholderText("Або фільтр: source:casl region:Оболонь")
+	query.SetToolTip(viewmodels.FilterQueryHint)
+	form.AddRow3("Фільтр", query.QWidget)
+	watch := qt.NewQCheckBox3("Список спостереження: тривоги цих об'єктів закріплюються нагорі")
+	form.AddRow3("", watch.QWidget)
+	layout.AddLayout(form.QLayout)
+
+	actions := qt.NewQHBoxLayout2()
+	newButton := qt.NewQPushButton3("Нова група")
+	addObjectButton := qt.NewQPushButton3("Додати вибраний об'єкт")
+	saveButton := qt.NewQPushButton3("Зберегти")
+	deleteButton := qt.NewQPushButton3("Видалити вибрану")
+	for _, button := range []*qt.QPushButton{newButton, addObjectButton, saveButton, deleteButton} {
+		actions.AddWidget(button.QWidget)
+	}
+	actions.AddStretch()
+	layout.AddLayout(actions.QLayout)
+
+	hint := qt.NewQLabel3("Група задається або переліком об'єктів, або виразом фільтра. Групи спільні для всіх операторів, що працюють з цим файлом.")
+	hint.SetWordWrap(true)
+	hint.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(hint.QWidget)
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	headers := []string{"Назва", "Склад", "Змінив", "Змінено"}
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	// Рядки відповідають groups за індексом, тому без сортування.
+	table.SetSortingEnabled(false)
+	table.SetSelectionMode(qt.QAbstractItemView__SingleSelection)
+	layout.AddWidget(table.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+
+	var (
+		groups    []objectgroups.Group
+		snapshot  []models.Object
+		editingID string
+		changed   bool
+	)
+	loadObjects := func() []models.Object {
+		if snapshot == nil && objects != nil {
+			snapshot = objects()
+		}
+		return snapshot
+	}
+	fillForm := func(values objectgroups.Form) {
+		editingID = values.ID
+		name.SetText(values.Name)
+		members.SetPlainText(values.Objects)
+		query.SetText(values.Query)
+		watch.SetChecked(values.Watch)
+	}
+	reload := func() {
+		loaded, err := store.Groups()
+		if err != nil {
+			status.SetText("Не вдалося прочитати групи: " + err.Error())
+			return
+		}
+		groups = loaded
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		for _, group := range groups {
+			addReadOnlyRow(model, []string{
+				group.Label(),
+				group.SummaryLabel(),
+				group.UpdatedBy,
+				group.UpdatedAt.Format("02.01.2006 15:04"),
+			})
+		}
+		table.ResizeColumnsToContents()
+		status.SetText(fmt.Sprintf("Груп: %d | файл: %s", len(groups), store.Path()))
+	}
+	selectedGroup := func() (objectgroups.Group, bool) {
+		index := table.CurrentIndex()
+		if index == nil || !index.IsValid() || index.Row() < 0 || index.Row() >= len(groups) {
+			return objectgroups.Group{}, false
+		}
+		return groups[index.Row()], true
+	}
+
+	table.SelectionModel().OnCurrentRowChanged(func(current *qt.QModelIndex, previous *qt.QModelIndex) {
+		if group, ok := selectedGroup(); ok {
+			fillForm(objectgroups.NewForm(group, loadObjects()))
+		}
+	})
+	newButton.OnClicked(func() {
+		table.ClearSelection()
+		fillForm(objectgroups.Form{})
+	})
+	addObjectButton.OnClicked(func() {
+		if object == nil {
+			qt.QMessageBox_Information(dialog.QWidget, "Групи об'єктів", "Виберіть об'єкт у списку, щоб додати його до групи.")
+			return
+		}
+		text := strings.TrimSpace(members.ToPlainText())
+		if text != "" {
+			text += ", "
+		}
+		members.SetPlainText(text + viewmodels.ObjectDisplayNumber(*object))
+	})
+	saveButton.OnClicked(func() {
+		group, err := objectgroups.Form{
+			ID:        editingID,
+			Name:      name.Text(),
+			Objects:   members.ToPlainText(),
+			Query:     query.Text(),
+			Watch:     watch.IsChecked(),
+			UpdatedBy: user,
+		}.Group(loadObjects())
+		if err == nil {
+			group, err = store.SaveGroup(group)
+		}
+		if err != nil {
+			qt.QMessageBox_Warning(dialog.QWidget, "Група об'єктів", err.Error())
+			return
+		}
+		changed = true
+		reload()
+		fillForm(objectgroups.NewForm(group, loadObjects()))
+	})
+	deleteButton.OnClicked(func() {
+		group, ok := selectedGroup()
+		if !ok {
+			return
+		}
+		if qt.QMessageBox_Question(dialog.QWidget, "Видалити групу", "Видалити групу «"+group.Name+"» для всіх операторів?") != qt.QMessageBox__Yes {
+			return
+		}
+		if err := store.DeleteGroup(group.ID); err != nil {
+			qt.QMessageBox_Warning(dialog.QWidget, "Група об'єктів", err.Error())
+			return
+		}
+		changed = true
+		fillForm(objectgroups.Form{})
+		reload()
+	})
+
+	reload()
+	dialog.Exec()
+	return changed
+}
@@ -13,6 +13,7 @@ import (
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 )
 
@@ -25,14 +26,18 @@ const (
 type ObjectListPanel struct {
 	*qt.QWidget
 	search            *qt.QLineEdit
+	queryError        *qt.QLabel
 	statusFilter      *qt.QComboBox
 	sourceFilter      *qt.QComboBox
+	pultFilter        *qt.QComboBox
+	groupFilter       *qt.QComboBox
 	searchTimer       *qt.QTimer
 	table             *qt.QTableView
 	model             *objectListTableModel
 	vm                *viewmodels.ObjectListViewModel
 	prefs             config.Preferences
 	allObjects        []models.Object
+	groups            *objectgroups.Registry
 	rowsHash          uint64
 	rowsReady         bool
 	autoSized         bool
@@ -56,16 +61,30 @@ func NewObjectListPanel(prefs config.Preferences) *ObjectListPanel {
 	title.SetStyleSheet("font-weight: 600; font-size: 11pt; padding: 4px 0;")
 
 	panel.search = qt.NewQLineEdit2()
-	panel.search.SetPlaceholderText("Номер, назва, адреса, SIM або договір")
+	panel.search.SetPlaceholderText("Номер, назва, адреса, SIM або умови: status:offline lasttest>48h")
+	panel.search.SetToolTip(viewmodels.FilterQueryHint)
 	panel.search.SetClearButtonEnabled(true)
+	panel.queryError = qt.NewQLabel3("")
+	panel.queryError.SetWordWrap(true)
+	panel.queryError.SetStyleSheet("color: #c62828;")
+	panel.queryError.SetVisible(false)
+	searchLayout := qt.NewQHBoxLayout2()
+	searchLayout.AddWidget(panel.search.QWidget)
+	searchLayout.AddWidget(newSavedViewsButton(prefs, panel.search.Text, panel.search.SetText).QWidget)
 
 	filtersLayout := qt.NewQHBoxLayout2()
 	panel.statusFilter = qt.NewQComboBox2()
 	panel.statusFilter.AddItems(panel.vm.BuildFilterOptions(0, 0, 0, 0, 0))
 	panel.sourceFilter = qt.NewQComboBox2()
 	panel.sourceFilter.AddItems(viewmodels.BuildObjectSourceOptions(0, 0, 0, 0))
+	panel.pultFilter = qt.NewQComboBox2()
+	panel.pultFilter.SetVisible(false)
+	panel.groupFilter = qt.NewQComboBox2()
+	panel.groupFilter.SetVisible(false)
 	filtersLayout.AddWidget(panel.statusFilter.QWidget)
 	filtersLayout.AddWidget(panel.sourceFilter.QWidget)
+	filtersLayout.AddWidget(panel.pultFilter.QWidget)
+	filtersLayout.AddWidget(panel.groupFilter.QWidget)
 
 	panel.model = newObjectListTableModel(panel.vm)
 
@@ -81,6 +100,7 @@ func NewObjectListPanel(prefs config.Preferences) *ObjectListPanel {
 	for i, w := range []int{50, 250, 230} {
 		panel.table.SetColumnWidth(i, w)
 	}
+	panel.table.SetColumnHidden(objectListPultColumn, true)
 	panel.table.SelectionModel().OnCurrentRowChanged(func(current *qt.QModelIndex, previous *qt.QModelIndex) {
 		panel.notifyObjectSelection(current)
 	})
@@ -110,9 +130,16 @@ func NewObjectListPanel(prefs config.Preferences) *ObjectListPanel {
 		panel.saveFilterPrefs()
 		panel.applyFilters()
 	})
+	panel.pultFilter.OnCurrentTextChanged(func(string) {
+		panel.applyFilters()
+	})
+	panel.groupFilter.OnCurrentIndexChanged(func(int) {
+		panel.applyFilters()
+	})
 
 	layout.AddWidget(title.QWidget)
-	layout.AddWidget(panel.search.QWidget)
+	layout.AddLayout(searchLayout.QLayout)
+	layout.AddWidget(panel.queryError.QWidget)
 	layout.AddLayout(filtersLayout.QLayout)
 	layout.AddWidget(panel.table.QWidget)
 	panel.SetLayout(layout.QLayout)
@@ -130,6 +157,15 @@ func (panel *ObjectListPanel) SetObjects(objects []models.Object) {
 	panel.applyFilters()
 }
 
+// SetObjectGroups підключає спільні групи об'єктів до фільтра панелі.
+func (panel *ObjectListPanel) SetObjectGroups(groups *objectgroups.Registry) {
+	if panel == nil {
+		return
+	}
+	panel.groups = groups
+	panel.applyFilters()
+}
+
 func (panel *ObjectListPanel) FocusSearch() {
 	if panel == nil || panel.search == nil {
 		return
@@ -222,19 +258,31 @@ func (panel *ObjectListPanel) applyFilters() {
 	if panel.sourceFilter != nil {
 		currentSource = panel.sourceFilter.CurrentText()
 	}
+	currentPult := ""
+	if panel.pultFilter != nil {
+		currentPult = panel.pultFilter.CurrentText()
+	}
 	query := ""
 	if panel.search != nil {
 		query = panel.search.Text()
 	}
+	groupID := refreshGroupFilter(panel.groupFilter, panel.groups)
 
 	out := panel.vm.ApplyFilters(viewmodels.ObjectListFilterInput{
 		AllObjects:    panel.allObjects,
 		Query:         query,
+		GroupMembers:  panel.groups.Membership().Members(groupID),
 		CurrentFilter: currentFilter,
 		CurrentSource: currentSource,
+		CurrentPult:   currentPult,
 	})
 
+	if panel.queryError != nil {
+		panel.queryError.SetText(out.QueryError)
+		panel.queryError.SetVisible(out.QueryError != "")
+	}
 	panel.refreshFilterOptions(out, currentFilter, currentSource)
+	panel.refreshPultFilter(out, currentPult)
 	panel.setFilteredObjects(out.Filtered)
 }
 
@@ -251,12 +299,31 @@ func (panel *ObjectListPanel) refreshFilterOptions(out viewmodels.ObjectListFilt
 		normalized := viewmodels.NormalizeObjectSourceFilter(currentSource)
 		wasBlocked := panel.sourceFilter.BlockSignals(true)
 		panel.sourceFilter.Clear()
-		panel.sourceFilter.AddItems(viewmodels.BuildObjectSourceOptions(out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL))
+		options := viewmodels.BuildObjectSourceOptions(out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL)
+		panel.sourceFilter.AddItems(viewmodels.AppendObjectSourceInstanceOptions(options, out.InstanceCounts))
 		panel.sourceFilter.SetCurrentIndex(indexForNormalizedSourceFilter(panel.sourceFilter, normalized))
 		panel.sourceFilter.BlockSignals(wasBlocked)
 	}
 }
 
+// refreshPultFilter показує фільтр і колонку пульта лише коли пультів CASL кілька.
+func (panel *ObjectListPanel) refreshPultFilter(out viewmodels.ObjectListFilterOutput, currentPult string) {
+	if panel.pultFilter == nil {
+		return
+	}
+	options := viewmodels.BuildObjectPultOptions(out.CountAll, out.PultCounts)
+	normalized := viewmodels.NormalizeObjectPultFilter(currentPult)
+	wasBlocked := panel.pultFilter.BlockSignals(true)
+	panel.pultFilter.Clear()
+	panel.pultFilter.AddItems(options)
+	panel.pultFilter.SetCurrentIndex(indexForNormalizedPultFilter(panel.pultFilter, normalized))
+	panel.pultFilter.BlockSignals(wasBlocked)
+	panel.pultFilter.SetVisible(len(options) > 0)
+	if panel.table != nil {
+		panel.table.SetColumnHidden(objectListPultColumn, len(options) == 0)
+	}
+}
+
 func (panel *ObjectListPanel) setFilteredObjects(objects []models.Object) {
 	hash := objectRowsHash(objects)
 	if panel.rowsReady && panel.rowsHash == hash {
@@ -294,6 +361,8 @@ func objectRowsHash(objects []models.Object) uint64 {
 		writeHashString(h, strings.TrimSpace(object.StatusText))
 		writeHashString(h, string(object.MonitoringStatusValue()))
 		writeHashString(h, viewmodels.ObjectSourceByID(object.ID))
+		writeHashString(h, object.Pult)
+		writeHashString(h, object.SourceInstance)
 	}
 	return h.Sum64()
 }
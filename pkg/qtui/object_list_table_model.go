@@ -12,6 +12,9 @@ import (
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 )
 
+// objectListPultColumn показується лише коли робоче місце обслуговує кілька пультів CASL.
+const objectListPultColumn = 3
+
 type objectListTableModel struct {
 	*qt.QAbstractTableModel
 	headers []string
@@ -22,7 +25,7 @@ type objectListTableModel struct {
 func newObjectListTableModel(vm *viewmodels.ObjectListViewModel) *objectListTableModel {
 	model := &objectListTableModel{
 		QAbstractTableModel: qt.NewQAbstractTableModel(),
-		headers:             []string{"№", "Назва", "Адреса"},
+		headers:             []string{"№", "Назва", "Адреса", "Пульт"},
 		vm:                  vm,
 	}
 	model.OnRowCount(func(parent *qt.QModelIndex) int {
@@ -125,9 +128,14 @@ func objectListCellText(object models.Object, column int) string {
 	case 0:
 		return viewmodels.ObjectDisplayNumber(object)
 	case 1:
+		if instance := strings.TrimSpace(object.SourceInstance); instance != "" {
+			return strings.TrimSpace(object.Name) + " [" + instance + "]"
+		}
 		return strings.TrimSpace(object.Name)
 	case 2:
 		return strings.TrimSpace(object.Address)
+	case objectListPultColumn:
+		return strings.TrimSpace(object.Pult)
 	default:
 		return ""
 	}
@@ -0,0 +1,407 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// OpenCloseExport writes the open/close compliance report and violation journal to CSV.
+type OpenCloseExport struct {
+	Compliance func(filePath string, rows []openclose.ComplianceRow) error
+	Violations func(filePath string, violations []openclose.Violation) error
+}
+
+// ShowOpenCloseDialog shows open/close schedules, shared holidays and the compliance report.
+// object prefills the new schedule form. It reports whether schedules or holidays were changed.
+func ShowOpenCloseDialog(
+	parent *qt.QWidget,
+	store *openclose.FileStore,
+	object *models.Object,
+	user string,
+	export OpenCloseExport,
+	initialDir string,
+) bool {
+	if store == nil {
+		return false
+	}
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Розклад відкриття/закриття")
+	dialog.Resize(1200, 760)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	changed := false
+	onChanged := func() { changed = true }
+	tabs := qt.NewQTabWidget2()
+	tabs.AddTab(openCloseSchedulesTab(dialog.QWidget, store, object, user, onChanged), "Розклади")
+	tabs.AddTab(openCloseHolidaysTab(dialog.QWidget, store, onChanged), "Святкові дні")
+	tabs.AddTab(openCloseComplianceTab(dialog.QWidget, store, export, initialDir), "Дотримання")
+	layout.AddWidget(tabs.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+	dialog.Exec()
+	return changed
+}
+
+func openCloseSchedulesTab(
+	parent *qt.QWidget,
+	store *openclose.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) *qt.QWidget {
+	tab := qt.NewQWidget2()
+	layout := qt.NewQHBoxLayout(tab)
+
+	formWidget := qt.NewQWidget2()
+	formLayout := qt.NewQVBoxLayout(formWidget)
+	form := qt.NewQFormLayout2()
+	form.SetFieldGrowthPolicy(qt.QFormLayout__AllNonFixedFieldsGrow)
+	objectLabel := qt.NewQLabel3("")
+	form.AddRow3("Об'єкт", objectLabel.QWidget)
+	group := lineEdit()
+	group.SetPlaceholderText("Порожньо — увесь об'єкт")
+	form.AddRow3("Група", group.QWidget)
+	var days [7]*qt.QLineEdit
+	for i := range days {
+		days[i] = lineEdit()
+		days[i].SetPlaceholderText("ГГ:ХХ-ГГ:ХХ, порожньо — вихідний")
+		form.AddRow3(openclose.WeekdayNames[i], days[i].QWidget)
+	}
+	openTolerance := lineEdit()
+	form.AddRow3("Допуск відкриття, хв", openTolerance.QWidget)
+	closeTolerance := lineEdit()
+	form.AddRow3("Допуск закриття, хв", closeTolerance.QWidget)
+	ownHolidays := qt.NewQTextEdit2()
+	ownHolidays.SetAcceptRichText(false)
+	ownHolidays.SetPlaceholderText("ДД.ММ.РРРР назва — по рядку")
+	ownHolidays.SetMaximumHeight(90)
+	form.AddRow3("Свята об'єкта", ownHolidays.QWidget)
+	formLayout.AddLayout(form.QLayout)
+
+	actions := qt.NewQHBoxLayout2()
+	saveButton := qt.NewQPushButton3("Зберегти розклад")
+	newButton := qt.NewQPushButton3("Новий для об'єкта")
+	deleteButton := qt.NewQPushButton3("Видалити вибраний")
+	actions.AddWidget(saveButton.QWidget)
+	actions.AddWidget(newButton.QWidget)
+	actions.AddWidget(deleteButton.QWidget)
+	actions.AddStretch()
+	formLayout.AddLayout(actions.QLayout)
+	hint := qt.NewQLabel3("Закриття раніше за відкриття означає нічну зміну. Спільні свята — на вкладці «Святкові дні».")
+	hint.SetWordWrap(true)
+	hint.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	formLayout.AddWidget(hint.QWidget)
+	formLayout.AddStretch()
+	layout.AddWidget(formWidget)
+
+	listWidget := qt.NewQWidget2()
+	listLayout := qt.NewQVBoxLayout(listWidget)
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	listLayout.AddWidget(status.QWidget)
+	headers := []string{"Стан", "№", "Назва", "Група", "Тиждень", "Допуск, хв"}
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	// Рядки відповідають schedules за індексом, тому без сортування.
+	table.SetSortingEnabled(false)
+	table.SetSelectionMode(qt.QAbstractItemView__SingleSelection)
+	listLayout.AddWidget(table.QWidget)
+	layout.AddWidget2(listWidget, 1)
+
+	var (
+		schedules []openclose.Schedule
+		editing   openclose.Form
+	)
+	fill := func(form openclose.Form) {
+		editing = form
+		title := "новий розклад"
+		if form.ID != "" {
+			title = "редагування"
+		}
+		objectLabel.SetText(fmt.Sprintf("№%s %s (%s)", form.ObjectNumber, form.ObjectName, title))
+		group.SetText(form.GroupName)
+		for i := range days {
+			days[i].SetText(form.Days[i])
+		}
+		openTolerance.SetText(form.OpenTolerance)
+		closeTolerance.SetText(form.CloseTolerance)
+		ownHolidays.SetPlainText(form.Holidays)
+	}
+	newForm := func() {
+		if object == nil {
+			editing = openclose.Form{}
+			objectLabel.SetText("Об'єкт не вибрано")
+			return
+		}
+		fill(openclose.DefaultForm(object.ID, viewmodels.ObjectDisplayNumber(*object), strings.TrimSpace(object.Name)))
+	}
+	reload := func() {
+		loaded, err := store.Schedules()
+		if err != nil {
+			status.SetText("Не вдалося прочитати розклади: " + err.Error())
+			return
+		}
+		holidays, _ := store.Holidays()
+		schedules = loaded
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		now := time.Now()
+		for _, schedule := range schedules {
+			addReadOnlyRow(model, []string{
+				schedule.StateLabel(now, holidays),
+				schedule.ObjectNumber,
+				schedule.ObjectName,
+				schedule.GroupLabel(),
+				schedule.WeekLabel(),
+				fmt.Sprintf("%d/%d", schedule.OpenTolerance, schedule.CloseTolerance),
+			})
+		}
+		table.ResizeColumnsToContents()
+		status.SetText(fmt.Sprintf("Розкладів: %d | файл: %s", len(schedules), store.Path()))
+	}
+	selectedSchedule := func() (openclose.Schedule, bool) {
+		index := table.CurrentIndex()
+		if index == nil || !index.IsValid() || index.Row() < 0 || index.Row() >= len(schedules) {
+			return openclose.Schedule{}, false
+		}
+		return schedules[index.Row()], true
+	}
+
+	table.OnClicked(func(*qt.QModelIndex) {
+		if schedule, ok := selectedSchedule(); ok {
+			fill(openclose.NewForm(schedule))
+		}
+	})
+	saveButton.OnClicked(func() {
+		if editing.ObjectID == 0 {
+			qt.QMessageBox_Information(parent, "Розклад", "Виберіть об'єкт у списку або розклад для редагування.")
+			return
+		}
+		form := editing
+		form.GroupName = group.Text()
+		for i := range days {
+			form.Days[i] = days[i].Text()
+		}
+		form.OpenTolerance = openTolerance.Text()
+		form.CloseTolerance = closeTolerance.Text()
+		form.Holidays = ownHolidays.ToPlainText()
+		if form.CreatedBy == "" {
+			form.CreatedBy = user
+		}
+		schedule, err := form.Schedule()
+		if err == nil {
+			schedule, err = store.SaveSchedule(schedule)
+		}
+		if err != nil {
+			qt.QMessageBox_Warning(parent, "Розклад", err.Error())
+			return
+		}
+		reload()
+		fill(openclose.NewForm(schedule))
+		onChanged()
+	})
+	newButton.OnClicked(func() {
+		table.ClearSelection()
+		newForm()
+	})
+	deleteButton.OnClicked(func() {
+		schedule, ok := selectedSchedule()
+		if !ok {
+			return
+		}
+		message := fmt.Sprintf("Видалити розклад №%s %s (%s)?", schedule.ObjectNumber, schedule.ObjectName, schedule.GroupLabel())
+		if qt.QMessageBox_Question(parent, "Видалити розклад", message) != qt.QMessageBox__Yes {
+			return
+		}
+		if err := store.DeleteSchedule(schedule.ID); err != nil {
+			qt.QMessageBox_Warning(parent, "Розклад", err.Error())
+			return
+		}
+		reload()
+		newForm()
+		onChanged()
+	})
+
+	reload()
+	newForm()
+	return tab
+}
+
+func openCloseHolidaysTab(parent *qt.QWidget, store *openclose.FileStore, onChanged func()) *qt.QWidget {
+	tab := qt.NewQWidget2()
+	layout := qt.NewQVBoxLayout(tab)
+
+	hint := qt.NewQLabel3("Спільні святкові дні діють для всіх розкладів: у ці дні об'єкти вважаються зачиненими.")
+	hint.SetWordWrap(true)
+	layout.AddWidget(hint.QWidget)
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+	entry := qt.NewQTextEdit2()
+	entry.SetAcceptRichText(false)
+	entry.SetPlaceholderText("ДД.ММ.РРРР назва — по рядку")
+	layout.AddWidget(entry.QWidget)
+	actions := qt.NewQHBoxLayout2()
+	saveButton := qt.NewQPushButton3("Зберегти")
+	actions.AddWidget(saveButton.QWidget)
+	actions.AddStretch()
+	layout.AddLayout(actions.QLayout)
+
+	load := func() {
+		holidays, err := store.Holidays()
+		if err != nil {
+			status.SetText("Не вдалося прочитати святкові дні: " + err.Error())
+			return
+		}
+		entry.SetPlainText(openclose.FormatHolidays(holidays))
+		status.SetText(fmt.Sprintf("Святкових днів: %d", len(holidays)))
+	}
+	saveButton.OnClicked(func() {
+		holidays, err := openclose.ParseHolidays(entry.ToPlainText())
+		if err == nil {
+			err = store.SetHolidays(holidays)
+		}
+		if err != nil {
+			qt.QMessageBox_Warning(parent, "Святкові дні", err.Error())
+			return
+		}
+		load()
+		onChanged()
+	})
+
+	load()
+	return tab
+}
+
+func openCloseComplianceTab(
+	parent *qt.QWidget,
+	store *openclose.FileStore,
+	export OpenCloseExport,
+	initialDir string,
+) *qt.QWidget {
+	tab := qt.NewQWidget2()
+	layout := qt.NewQVBoxLayout(tab)
+
+	controls := qt.NewQHBoxLayout2()
+	today := time.Now()
+	fromEntry := lineEdit()
+	fromEntry.SetText(today.AddDate(0, 0, -30).Format(openclose.FormDateLayout))
+	toEntry := lineEdit()
+	toEntry.SetText(today.Format(openclose.FormDateLayout))
+	showButton := qt.NewQPushButton3("Показати")
+	exportRowsButton := qt.NewQPushButton3("Звіт CSV")
+	exportViolationsButton := qt.NewQPushButton3("Порушення CSV")
+	controls.AddWidget(qt.NewQLabel3("Від").QWidget)
+	controls.AddWidget(fromEntry.QWidget)
+	controls.AddWidget(qt.NewQLabel3("До").QWidget)
+	controls.AddWidget(toEntry.QWidget)
+	controls.AddWidget(showButton.QWidget)
+	controls.AddWidget(exportRowsButton.QWidget)
+	controls.AddWidget(exportViolationsButton.QWidget)
+	controls.AddStretch()
+	layout.AddLayout(controls.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	splitter := qt.NewQSplitter3(qt.Vertical)
+	rowModel := qt.NewQStandardItemModel2(0, len(openclose.ComplianceHeaders))
+	rowTable := newTable(rowModel, openclose.ComplianceHeaders)
+	splitter.AddWidget(rowTable.QWidget)
+	violationModel := qt.NewQStandardItemModel2(0, len(openclose.ViolationHeaders))
+	violationTable := newTable(violationModel, openclose.ViolationHeaders)
+	splitter.AddWidget(violationTable.QWidget)
+	layout.AddWidget(splitter.QWidget)
+
+	var (
+		rows       []openclose.ComplianceRow
+		violations []openclose.Violation
+	)
+	load := func() {
+		from, err := time.ParseInLocation(openclose.FormDateLayout, strings.TrimSpace(fromEntry.Text()), time.Local)
+		if err != nil {
+			status.SetText("Некоректна дата «від». Формат: ДД.ММ.РРРР")
+			return
+		}
+		to, err := time.ParseInLocation(openclose.FormDateLayout, strings.TrimSpace(toEntry.Text()), time.Local)
+		if err != nil || to.Before(from) {
+			status.SetText("Некоректна дата «до» або діапазон дат")
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		schedules, err := store.Schedules()
+		if err != nil {
+			status.SetText("Не вдалося прочитати розклади: " + err.Error())
+			return
+		}
+		holidays, _ := store.Holidays()
+		loaded, err := store.Violations(from, to)
+		if err != nil {
+			status.SetText("Не вдалося прочитати порушення: " + err.Error())
+			return
+		}
+		violations = loaded
+		rows = openclose.Compliance(schedules, holidays, violations, from, to)
+		rowModel.Clear()
+		rowModel.SetHorizontalHeaderLabels(openclose.ComplianceHeaders)
+		for _, row := range rows {
+			addReadOnlyRow(rowModel, row.Values())
+		}
+		rowTable.ResizeColumnsToContents()
+		violationModel.Clear()
+		violationModel.SetHorizontalHeaderLabels(openclose.ViolationHeaders)
+		for _, violation := range violations {
+			addReadOnlyRow(violationModel, violation.Values())
+		}
+		violationTable.ResizeColumnsToContents()
+		status.SetText(fmt.Sprintf("Об'єктів: %d | порушень: %d", len(rows), len(violations)))
+		exportRowsButton.SetEnabled(export.Compliance != nil && len(rows) > 0)
+		exportViolationsButton.SetEnabled(export.Violations != nil && len(violations) > 0)
+	}
+
+	showButton.OnClicked(load)
+	exportRowsButton.OnClicked(func() {
+		if export.Compliance == nil || len(rows) == 0 {
+			return
+		}
+		filePath, ok := chooseCSVSavePath(parent, "Експорт дотримання розкладу", "open_close_compliance", initialDir)
+		if !ok {
+			return
+		}
+		if err := export.Compliance(filePath, rows); err != nil {
+			qt.QMessageBox_Warning(parent, "Експорт CSV", "Не вдалося створити файл: "+err.Error())
+			return
+		}
+		status.SetText("Експортовано: " + filePath)
+	})
+	exportViolationsButton.OnClicked(func() {
+		if export.Violations == nil || len(violations) == 0 {
+			return
+		}
+		filePath, ok := chooseCSVSavePath(parent, "Експорт порушень розкладу", "open_close_violations", initialDir)
+		if !ok {
+			return
+		}
+		if err := export.Violations(filePath, violations); err != nil {
+			qt.QMessageBox_Warning(parent, "Експорт CSV", "Не вдалося створити файл: "+err.Error())
+			return
+		}
+		status.SetText("Експортовано: " + filePath)
+	})
+
+	load()
+	return tab
+}
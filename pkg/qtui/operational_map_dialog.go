@@ -36,6 +36,27 @@ type operationalMapItem struct {
 	MarkerColor color.RGBA
 }
 
+// operationalMapRoute з'єднує направлену МГР з об'єктом тривоги.
+type operationalMapRoute struct {
+	FromLatitude  float64
+	FromLongitude float64
+	ToLatitude    float64
+	ToLongitude   float64
+	LineColor     color.RGBA
+}
+
+// OperationalMapReload оновлює тривоги та групи реагування для відкритої карти.
+type OperationalMapReload func(done func([]models.Alarm, []contracts.FrontendResponseGroup, error))
+
+const operationalMapRefreshInterval = 15 * time.Second
+
+var (
+	operationalMapGroupFreeColor       = color.RGBA{R: 61, G: 156, B: 59, A: 255}
+	operationalMapGroupDispatchedColor = color.RGBA{R: 230, G: 140, B: 0, A: 255}
+	operationalMapGroupArrivedColor    = color.RGBA{R: 123, G: 31, B: 162, A: 255}
+	operationalMapGroupUnknownColor    = color.RGBA{R: 120, G: 120, B: 120, A: 255}
+)
+
 type operationalMapRenderResult struct {
 	seq           int64
 	pointCount    int
@@ -49,6 +70,7 @@ func ShowOperationalMapDialog(
 	objects []models.Object,
 	alarms []models.Alarm,
 	groups []contracts.FrontendResponseGroup,
+	reload OperationalMapReload,
 ) (int, bool) {
 	dialog := qt.NewQDialog(parent)
 	dialog.SetWindowTitle("Оперативна карта")
@@ -59,8 +81,12 @@ func ShowOperationalMapDialog(
 	showObjects := qt.NewQCheckBox3("Об'єкти")
 	showAlarms := qt.NewQCheckBox3("Тривоги")
 	showGroups := qt.NewQCheckBox3("МГР")
+	autoRefresh := qt.NewQCheckBox3("Оновлювати МГР")
+	autoRefresh.SetToolTip(fmt.Sprintf("Оновлювати позиції груп реагування кожні %d с", int(operationalMapRefreshInterval/time.Second)))
 	showAlarms.SetChecked(true)
 	showGroups.SetChecked(true)
+	autoRefresh.SetChecked(reload != nil)
+	autoRefresh.SetEnabled(reload != nil)
 	status := qt.NewQLabel3("")
 	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
 	retryButton := qt.NewQPushButton3("Повторити")
@@ -69,6 +95,7 @@ func ShowOperationalMapDialog(
 	toolbar.AddWidget(showObjects.QWidget)
 	toolbar.AddWidget(showAlarms.QWidget)
 	toolbar.AddWidget(showGroups.QWidget)
+	toolbar.AddWidget(autoRefresh.QWidget)
 	toolbar.AddStretch()
 	toolbar.AddWidget(status.QWidget)
 	toolbar.AddWidget(retryButton.QWidget)
@@ -99,6 +126,8 @@ func ShowOperationalMapDialog(
 		items        []operationalMapItem
 		selected     int
 		renderCancel context.CancelFunc
+		reloading    bool
+		refreshedAt  = time.Now()
 	)
 	renderResults := make(chan operationalMapRenderResult, 4)
 	resultTimer := qt.NewQTimer()
@@ -128,13 +157,19 @@ func ShowOperationalMapDialog(
 					retryButton.QWidget.SetVisible(true)
 					continue
 				}
-				status.SetText(fmt.Sprintf("Точок: %d | червоні: тривоги | зелені: МГР | сині: об'єкти", result.pointCount))
+				status.SetText(fmt.Sprintf(
+					"Точок: %d | червоні: тривоги | сині: об'єкти | МГР: зелені — вільні, помаранчеві — направлені, фіолетові — прибули | оновлено %s",
+					result.pointCount,
+					refreshedAt.Format("15:04:05"),
+				))
 			default:
 				return
 			}
 		}
 	})
 	resultTimer.Start2()
+	refreshTimer := qt.NewQTimer()
+	refreshTimer.SetInterval(int(operationalMapRefreshInterval / time.Millisecond))
 	dialog.OnFinished(func(int) {
 		closed.Store(true)
 		renderSeq.Add(1)
@@ -142,6 +177,7 @@ func ShowOperationalMapDialog(
 			renderCancel()
 		}
 		resultTimer.Stop()
+		refreshTimer.Stop()
 	})
 	var render func()
 	render = func() {
@@ -150,6 +186,10 @@ func ShowOperationalMapDialog(
 		}
 		seq := renderSeq.Add(1)
 		items = buildOperationalMapItems(objects, alarms, groups, showObjects.IsChecked(), showAlarms.IsChecked(), showGroups.IsChecked())
+		var routes []operationalMapRoute
+		if showGroups.IsChecked() {
+			routes = buildOperationalMapRoutes(objects, alarms, groups)
+		}
 		list.Clear()
 		retryButton.QWidget.SetVisible(false)
 		mapLabel.SetToolTip("")
@@ -175,6 +215,14 @@ func ShowOperationalMapDialog(
 			points = append(points, point)
 			markers = append(markers, geocode.MapMarker{MapPoint: point, Color: item.MarkerColor})
 		}
+		lines := make([]geocode.MapLine, 0, len(routes))
+		for _, route := range routes {
+			lines = append(lines, geocode.MapLine{
+				From:  geocode.MapPoint{Latitude: route.FromLatitude, Longitude: route.FromLongitude},
+				To:    geocode.MapPoint{Latitude: route.ToLatitude, Longitude: route.ToLongitude},
+				Color: route.LineColor,
+			})
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		renderCancel = cancel
 		go func(ctx context.Context, cancel context.CancelFunc) {
@@ -192,7 +240,7 @@ func ShowOperationalMapDialog(
 			}
 			var body []byte
 			if snapshot != nil {
-				body = snapshot.PNGWithMarkers(markers)
+				body = snapshot.PNGWithOverlay(markers, lines)
 			}
 			result := operationalMapRenderResult{
 				seq:           seq,
@@ -208,6 +256,30 @@ func ShowOperationalMapDialog(
 		}(ctx, cancel)
 	}
 
+	refreshTimer.OnTimeout(func() {
+		if reload == nil || reloading || closed.Load() || !autoRefresh.IsChecked() {
+			return
+		}
+		reloading = true
+		reload(func(updatedAlarms []models.Alarm, updatedGroups []contracts.FrontendResponseGroup, err error) {
+			reloading = false
+			if closed.Load() {
+				return
+			}
+			if err != nil {
+				status.SetText("Оновлення МГР: " + strings.TrimSpace(err.Error()))
+				return
+			}
+			alarms = updatedAlarms
+			groups = updatedGroups
+			refreshedAt = time.Now()
+			render()
+		})
+	})
+	if reload != nil {
+		refreshTimer.Start2()
+	}
+
 	retryButton.OnClicked(func() { render() })
 	showObjects.OnToggled(func(bool) { render() })
 	showAlarms.OnToggled(func(bool) { render() })
@@ -223,6 +295,7 @@ func ShowOperationalMapDialog(
 
 	render()
 	accepted := dialog.Exec() == int(qt.QDialog__Accepted) && selected > 0
+	refreshTimer.Delete()
 	resultTimer.Delete()
 	return selected, accepted
 }
@@ -304,28 +377,138 @@ func buildOperationalMapItems(
 		}
 	}
 	if includeGroups {
+		now := time.Now()
 		for _, group := range groups {
 			lat, lon, ok := parseOperationalCoordinates(group.Latitude, group.Longitude)
 			if !ok {
 				continue
 			}
 			objectID := 0
-			if object, found := objectsByNumber[strings.TrimSpace(group.ObjectNumber)]; found {
+			if object, found := operationalMapGroupTarget(group, alarms, objectsByID, objectsByNumber); found {
 				objectID = object.ID
 			}
 			result = append(result, operationalMapItem{
 				Kind:     operationalMapGroup,
 				ObjectID: objectID,
 				Title:    "МГР " + responseGroupDisplayName(group),
-				Details:  responseGroupDisplayStatus(group),
+				Details:  operationalMapGroupDetails(group, now),
 				Latitude: lat, Longitude: lon,
-				MarkerColor: color.RGBA{R: 61, G: 156, B: 59, A: 255},
+				MarkerColor: operationalMapGroupColor(group.Status),
 			})
 		}
 	}
 	return result
 }
 
+// buildOperationalMapRoutes будує лінії від направлених МГР до об'єктів їхніх тривог.
+func buildOperationalMapRoutes(
+	objects []models.Object,
+	alarms []models.Alarm,
+	groups []contracts.FrontendResponseGroup,
+) []operationalMapRoute {
+	objectsByID := make(map[int]models.Object, len(objects))
+	objectsByNumber := make(map[string]models.Object, len(objects))
+	for _, object := range objects {
+		objectsByID[object.ID] = object
+		objectsByNumber[objectDisplayNumberForMap(object)] = object
+	}
+	routes := make([]operationalMapRoute, 0)
+	for _, group := range groups {
+		if group.Status != contracts.ResponseGroupStatusDispatched {
+			continue
+		}
+		fromLat, fromLon, ok := parseOperationalCoordinates(group.Latitude, group.Longitude)
+		if !ok {
+			continue
+		}
+		object, found := operationalMapGroupTarget(group, alarms, objectsByID, objectsByNumber)
+		if !found {
+			continue
+		}
+		toLat, toLon, ok := parseOperationalCoordinates(object.Latitude, object.Longitude)
+		if !ok {
+			continue
+		}
+		routes = append(routes, operationalMapRoute{
+			FromLatitude: fromLat, FromLongitude: fromLon,
+			ToLatitude: toLat, ToLongitude: toLon,
+			LineColor: operationalMapGroupDispatchedColor,
+		})
+	}
+	return routes
+}
+
+// operationalMapGroupTarget знаходить об'єкт, на який працює група: спершу за
+// тривогою з цією МГР, потім за номером об'єкта зі статусу групи.
+func operationalMapGroupTarget(
+	group contracts.FrontendResponseGroup,
+	alarms []models.Alarm,
+	objectsByID map[int]models.Object,
+	objectsByNumber map[string]models.Object,
+) (models.Object, bool) {
+	groupID := strings.TrimSpace(group.ID)
+	if groupID != "" {
+		for _, alarm := range alarms {
+			if !alarm.IsResponseGroupDispatched || strings.TrimSpace(alarm.ResponseGroupID) != groupID {
+				continue
+			}
+			if object, ok := objectsByID[alarm.ObjectID]; ok {
+				return object, true
+			}
+		}
+	}
+	if number := strings.TrimSpace(group.ObjectNumber); number != "" {
+		object, ok := objectsByNumber[number]
+		return object, ok
+	}
+	return models.Object{}, false
+}
+
+func operationalMapGroupDetails(group contracts.FrontendResponseGroup, now time.Time) string {
+	details := responseGroupDisplayStatus(group)
+	if elapsed := operationalMapElapsedText(group, now); elapsed != "" {
+		details += " · " + elapsed
+	}
+	if number := strings.TrimSpace(group.ObjectNumber); number != "" && group.Status != contracts.ResponseGroupStatusFree {
+		details += " · №" + number
+	}
+	return details
+}
+
+func operationalMapElapsedText(group contracts.FrontendResponseGroup, now time.Time) string {
+	if group.StatusChangedAt.IsZero() || now.Before(group.StatusChangedAt) {
+		return ""
+	}
+	elapsed := now.Sub(group.StatusChangedAt).Round(time.Minute)
+	var value string
+	if hours := int(elapsed.Hours()); hours > 0 {
+		value = fmt.Sprintf("%d год %02d хв", hours, int(elapsed.Minutes())%60)
+	} else {
+		value = fmt.Sprintf("%d хв", int(elapsed.Minutes()))
+	}
+	switch group.Status {
+	case contracts.ResponseGroupStatusDispatched:
+		return "в дорозі " + value
+	case contracts.ResponseGroupStatusArrived:
+		return "на об'єкті " + value
+	default:
+		return ""
+	}
+}
+
+func operationalMapGroupColor(status contracts.ResponseGroupStatus) color.RGBA {
+	switch status {
+	case contracts.ResponseGroupStatusFree:
+		return operationalMapGroupFreeColor
+	case contracts.ResponseGroupStatusDispatched:
+		return operationalMapGroupDispatchedColor
+	case contracts.ResponseGroupStatusArrived:
+		return operationalMapGroupArrivedColor
+	default:
+		return operationalMapGroupUnknownColor
+	}
+}
+
 func preferOperationalMapAlarm(candidate models.Alarm, current models.Alarm) bool {
 	candidatePriority := operationalMapAlarmPriority(candidate.VisualSeverityValue())
 	currentPriority := operationalMapAlarmPriority(current.VisualSeverityValue())
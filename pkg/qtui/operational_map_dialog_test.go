@@ -47,3 +47,60 @@ func TestBuildOperationalMapItemsGroupsAlarmsByObject(t *testing.T) {
 		t.Fatalf("grouped alarm details = %q", got[0].Details)
 	}
 }
+
+func TestBuildOperationalMapRoutesLinksDispatchedGroupToAlarmObject(t *testing.T) {
+	objects := []models.Object{
+		{ID: 7, DisplayNumber: "1007", Latitude: "49.8", Longitude: "24.0"},
+		{ID: 8, DisplayNumber: "1008", Latitude: "49.7", Longitude: "24.2"},
+	}
+	alarms := []models.Alarm{{ObjectID: 7, ResponseGroupID: "1", IsResponseGroupDispatched: true}}
+	groups := []contracts.FrontendResponseGroup{
+		{ID: "1", Status: contracts.ResponseGroupStatusDispatched, ObjectNumber: "1008", Latitude: "49.9", Longitude: "24.1"},
+		{ID: "2", Status: contracts.ResponseGroupStatusDispatched, ObjectNumber: "1008", Latitude: "49.6", Longitude: "24.3"},
+		{ID: "3", Status: contracts.ResponseGroupStatusFree, Latitude: "49.5", Longitude: "24.4"},
+	}
+
+	routes := buildOperationalMapRoutes(objects, alarms, groups)
+	if len(routes) != 2 {
+		t.Fatalf("routes = %+v, want 2", routes)
+	}
+	if routes[0].ToLatitude != 49.8 || routes[0].ToLongitude != 24.0 {
+		t.Fatalf("group assigned to alarm must point to its object: %+v", routes[0])
+	}
+	if routes[1].ToLatitude != 49.7 || routes[1].FromLatitude != 49.6 {
+		t.Fatalf("group without alarm must fall back to its object number: %+v", routes[1])
+	}
+}
+
+func TestOperationalMapGroupDetailsShowsElapsedTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	group := contracts.FrontendResponseGroup{
+		ID:              "1",
+		Status:          contracts.ResponseGroupStatusDispatched,
+		StatusChangedAt: now.Add(-83 * time.Minute),
+		ObjectNumber:    "1007",
+	}
+	got := operationalMapGroupDetails(group, now)
+	if !strings.Contains(got, "в дорозі 1 год 23 хв") || !strings.Contains(got, "№1007") {
+		t.Fatalf("operationalMapGroupDetails() = %q", got)
+	}
+
+	group.Status = contracts.ResponseGroupStatusArrived
+	group.StatusChangedAt = now.Add(-5 * time.Minute)
+	if got := operationalMapElapsedText(group, now); got != "на об'єкті 5 хв" {
+		t.Fatalf("operationalMapElapsedText() = %q", got)
+	}
+
+	group.Status = contracts.ResponseGroupStatusFree
+	if got := operationalMapElapsedText(group, now); got != "" {
+		t.Fatalf("free group must not show elapsed time, got %q", got)
+	}
+}
+
+func TestOperationalMapGroupColorByStatus(t *testing.T) {
+	if operationalMapGroupColor(contracts.ResponseGroupStatusFree) == operationalMapGroupColor(contracts.ResponseGroupStatusDispatched) ||
+		operationalMapGroupColor(contracts.ResponseGroupStatusDispatched) == operationalMapGroupColor(contracts.ResponseGroupStatusArrived) ||
+		operationalMapGroupColor(contracts.ResponseGroupStatusArrived) == operationalMapGroupColor(contracts.ResponseGroupStatusUnknown) {
+		t.Fatal("each response group status must have its own marker color")
+	}
+}
@@ -0,0 +1,136 @@
+//go:build qt
+
+package qtui
+
+import (
+	"fmt"
+	"time"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/testsupervision"
+)
+
+// OverdueTestsScan rescans objects in the background. done must be delivered on the UI thread.
+type OverdueTestsScan func(done func())
+
+// ShowOverdueTestsDialog shows expected and overdue periodic tests.
+// It returns the object chosen to open in the main window.
+func ShowOverdueTestsDialog(parent *qt.QWidget, supervisor *testsupervision.Supervisor, scan OverdueTestsScan) (int, bool) {
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle("Контроль періодичних тестів")
+	dialog.Resize(1200, 700)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	controls := qt.NewQHBoxLayout2()
+	viewSelect := qt.NewQComboBox2()
+	for _, view := range testsupervision.Views {
+		viewSelect.AddItem(view.Label())
+	}
+	search := lineEdit()
+	search.SetPlaceholderText("Пошук за номером або назвою")
+	refreshButton := qt.NewQPushButton3("Оновити")
+	controls.AddWidget(qt.NewQLabel3("Показати").QWidget)
+	controls.AddWidget(viewSelect.QWidget)
+	controls.AddWidget(search.QWidget)
+	controls.AddWidget(refreshButton.QWidget)
+	layout.AddLayout(controls.QLayout)
+
+	status := qt.NewQLabel3("")
+	status.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(status.QWidget)
+
+	headers := testsupervision.DashboardHeaders
+	model := qt.NewQStandardItemModel2(0, len(headers))
+	table := newTable(model, headers)
+	// Рядки відповідають visible за індексом, тому без сортування.
+	table.SetSortingEnabled(false)
+	table.SetSelectionMode(qt.QAbstractItemView__SingleSelection)
+	table.SetWordWrap(false)
+	layout.AddWidget(table.QWidget)
+
+	hint := qt.NewQLabel3(supervisor.Settings().Label())
+	hint.SetWordWrap(true)
+	hint.SetStyleSheet("color: " + qtMutedTextColor + ";")
+	layout.AddWidget(hint.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	openButton := buttons.AddButton2("Перейти до об'єкта", qt.QDialogButtonBox__ActionRole)
+	buttons.OnRejected(dialog.Reject)
+	layout.AddWidget(buttons.QWidget)
+	dialog.SetLayout(layout.QLayout)
+
+	var (
+		visible  []testsupervision.Expectation
+		chosen   int
+		accepted bool
+		running  bool
+		closed   bool
+	)
+
+	render := func() {
+		expectations, observedAt := supervisor.Expectations()
+		now := time.Now()
+		view := testsupervision.Views[max(viewSelect.CurrentIndex(), 0)]
+		visible = testsupervision.Filter(expectations, view, search.Text(), now)
+		model.Clear()
+		model.SetHorizontalHeaderLabels(headers)
+		for row, expectation := range visible {
+			addReadOnlyRow(model, expectation.RowValues(now))
+			if expectation.Overdue(now) {
+				model.Item(row).SetData(qt.NewQColor6("#ffc107").ToQVariant(), int(qt.BackgroundRole))
+			}
+		}
+		table.ResizeColumnsToContents()
+		table.HorizontalHeader().SetStretchLastSection(true)
+		if running {
+			return
+		}
+		text := testsupervision.Summary(expectations, now) + fmt.Sprintf(" | показано: %d", len(visible))
+		if !observedAt.IsZero() {
+			text += " | станом на " + observedAt.Format("15:04:05")
+		}
+		status.SetText(text)
+	}
+
+	refresh := func() {
+		if scan == nil || running {
+			return
+		}
+		running = true
+		refreshButton.SetEnabled(false)
+		status.SetText("Завантаження об'єктів...")
+		scan(func() {
+			if closed {
+				return
+			}
+			running = false
+			refreshButton.SetEnabled(true)
+			render()
+		})
+	}
+
+	openSelected := func() {
+		index := table.CurrentIndex()
+		if index == nil || !index.IsValid() || index.Row() < 0 || index.Row() >= len(visible) {
+			return
+		}
+		chosen = visible[index.Row()].ObjectID
+		accepted = true
+		dialog.Accept()
+	}
+
+	refreshButton.OnClicked(refresh)
+	openButton.OnClicked(openSelected)
+	table.OnDoubleClicked(func(*qt.QModelIndex) { openSelected() })
+	search.OnTextChanged(func(string) { render() })
+	viewSelect.OnCurrentIndexChanged(func(int) { render() })
+
+	render()
+	if _, observedAt := supervisor.Expectations(); observedAt.IsZero() {
+		refresh()
+	}
+	dialog.Exec()
+	closed = true
+	return chosen, accepted
+}
@@ -15,6 +15,7 @@ import (
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 	"obj_catalog_fyne_v3/pkg/utils"
 )
@@ -896,6 +897,15 @@ func indexForNormalizedSourceFilter(combo *qt.QComboBox, normalized string) int
 	return 0
 }
 
+func indexForNormalizedPultFilter(combo *qt.QComboBox, normalized string) int {
+	for i := 0; i < combo.Count(); i++ {
+		if viewmodels.NormalizeObjectPultFilter(combo.ItemText(i)) == normalized {
+			return i
+		}
+	}
+	return 0
+}
+
 func filterAlarmsBySeverity(alarms []models.Alarm, severity string) []models.Alarm {
 	switch severity {
 	case "Критичні":
@@ -934,3 +944,33 @@ func updateComboItems(combo *qt.QComboBox, options []string, current string) {
 	combo.AddItems(options)
 	combo.SetCurrentIndex(target)
 }
+
+// refreshGroupFilter оновлює фільтр груп об'єктів зі збереженням вибраної
+// групи та повертає її ID; без груп фільтр ховається і повертається "".
+func refreshGroupFilter(combo *qt.QComboBox, registry *objectgroups.Registry) string {
+	if combo == nil {
+		return ""
+	}
+	groupID := ""
+	if combo.CurrentIndex() > 0 {
+		groupID = combo.CurrentData().ToString()
+	}
+	groups := registry.Groups()
+	wasBlocked := combo.BlockSignals(true)
+	combo.Clear()
+	combo.AddItem3(viewmodels.ObjectGroupAll, qt.NewQVariant14(""))
+	selected := 0
+	for _, group := range groups {
+		combo.AddItem3(group.Label(), qt.NewQVariant14(group.ID))
+		if group.ID == groupID {
+			selected = combo.Count() - 1
+		}
+	}
+	combo.SetCurrentIndex(selected)
+	combo.BlockSignals(wasBlocked)
+	combo.SetVisible(len(groups) > 0)
+	if selected == 0 {
+		return ""
+	}
+	return groupID
+}
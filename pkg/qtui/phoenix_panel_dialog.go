@@ -0,0 +1,54 @@
+//go:build qt
+
+package qtui
+
+import (
+	"strings"
+
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// ShowPhoenixPanelDialog shows responsibles and zones of a Phoenix panel.
+// Every change is saved immediately by the provider, so the dialog only closes.
+func ShowPhoenixPanelDialog(parent *qt.QWidget, provider contracts.PhoenixObjectAdminProvider, card contracts.AdminObjectCard) {
+	if parent == nil || provider == nil || card.ObjN == 0 {
+		return
+	}
+	title := "Панель Phoenix " + strings.TrimSpace(card.PanelID)
+	if name := strings.TrimSpace(card.ShortName); name != "" {
+		title += " — " + name
+	}
+	dialog := qt.NewQDialog(parent)
+	dialog.SetWindowTitle(title)
+	dialog.Resize(820, 560)
+	layout := qt.NewQVBoxLayout(dialog.QWidget)
+
+	statusLabel := qt.NewQLabel3("")
+	personalsWidget, loadPersonals := newObjectPersonalsEditTab(dialog.QWidget, provider, card.ObjN, statusLabel)
+	zonesWidget, loadZones := newObjectZonesEditTab(dialog.QWidget, provider, card.ObjN, statusLabel)
+	tabs := qt.NewQTabWidget2()
+	tabs.AddTab(personalsWidget, "В/О")
+	tabs.AddTab(zonesWidget, "Зони")
+	tabs.OnCurrentChanged(func(index int) {
+		if index == 1 {
+			loadZones()
+			return
+		}
+		loadPersonals()
+	})
+	layout.AddWidget(tabs.QWidget)
+
+	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Close)
+	buttons.OnRejected(dialog.Reject)
+	footer := qt.NewQHBoxLayout2()
+	footer.AddWidget(statusLabel.QWidget)
+	footer.AddStretch()
+	footer.AddWidget(buttons.QWidget)
+	layout.AddLayout(footer.QLayout)
+	dialog.SetLayout(layout.QLayout)
+
+	loadPersonals()
+	dialog.Exec()
+}
@@ -0,0 +1,64 @@
+//go:build qt
+
+package qtui
+
+import (
+	qt "github.com/mappu/miqt/qt6"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// newSavedViewsButton builds the saved views menu button placed next to a filter line.
+// The menu is rebuilt each time it opens, so a view saved in another panel is listed at once.
+func newSavedViewsButton(prefs config.Preferences, current func() string, apply func(query string)) *qt.QToolButton {
+	button := qt.NewQToolButton2()
+	button.SetText("★")
+	button.SetToolTip("Збережені подання")
+	button.SetPopupMode(qt.QToolButton__InstantPopup)
+	menu := qt.NewQMenu(button.QWidget)
+	button.SetMenu(menu)
+	menu.OnAboutToShow(func() {
+		menu.Clear()
+		views := config.LoadSavedViews(prefs)
+		for _, view := range views {
+			action := menu.AddActionWithText(view.Name)
+			action.SetToolTip(view.Query)
+			action.OnTriggered(func() { apply(view.Query) })
+		}
+		if len(views) == 0 {
+			menu.AddActionWithText("Немає збережених подань").SetEnabled(false)
+		}
+		menu.AddSeparator()
+		menu.AddActionWithText("Зберегти поточний фільтр...").OnTriggered(func() {
+			saveCurrentView(button.QWidget, prefs, current())
+		})
+		if len(views) == 0 {
+			return
+		}
+		remove := menu.AddMenuWithTitle("Видалити подання")
+		for _, view := range views {
+			remove.AddActionWithText(view.Name).OnTriggered(func() {
+				if err := config.SaveSavedViews(prefs, config.RemoveSavedView(config.LoadSavedViews(prefs), view.Name)); err != nil {
+					qt.QMessageBox_Warning(button.QWidget, "Збережені подання", err.Error())
+				}
+			})
+		}
+	})
+	return button
+}
+
+func saveCurrentView(parent *qt.QWidget, prefs config.Preferences, query string) {
+	ok := false
+	name := qt.QInputDialog_GetText4(parent, "Зберегти подання", "Назва подання для фільтра\n"+query, qt.QLineEdit__Normal, "", &ok)
+	if !ok {
+		return
+	}
+	views, err := viewmodels.AddSavedView(config.LoadSavedViews(prefs), name, query)
+	if err == nil {
+		err = config.SaveSavedViews(prefs, views)
+	}
+	if err != nil {
+		qt.QMessageBox_Warning(parent, "Зберегти подання", err.Error())
+	}
+}
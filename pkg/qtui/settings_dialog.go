@@ -54,8 +54,14 @@ type settingsDialog struct {
 	caslEmail   *qt.QLineEdit
 	caslPass    *qt.QLineEdit
 	caslPultID  *qt.QSpinBox
+	caslPults   *qt.QLineEdit
 	logLevel    *qt.QComboBox
 
+	sourceInstances *qt.QTextEdit
+
+	operatorServerURL   *qt.QLineEdit
+	operatorServerToken *qt.QLineEdit
+
 	fontSizeInterface      *qt.QDoubleSpinBox
 	fontSizeObjectCard     *qt.QDoubleSpinBox
 	fontSizeObjects        *qt.QDoubleSpinBox
@@ -111,6 +117,24 @@ type settingsDialog struct {
 	amiSecret    *qt.QLineEdit
 	amiExtension *qt.QLineEdit
 	amiContext   *qt.QLineEdit
+
+	dispatchRoadDistance *qt.QCheckBox
+	dispatchRoutingURL   *qt.QLineEdit
+
+	uploadTarget            *qt.QComboBox
+	uploadPublicBaseURL     *qt.QLineEdit
+	uploadGDriveCredentials *qt.QLineEdit
+	uploadGDriveToken       *qt.QLineEdit
+	uploadLocalDir          *qt.QLineEdit
+	uploadWebDAVURL         *qt.QLineEdit
+	uploadWebDAVUser        *qt.QLineEdit
+	uploadWebDAVPassword    *qt.QLineEdit
+	uploadS3Endpoint        *qt.QLineEdit
+	uploadS3Region          *qt.QLineEdit
+	uploadS3Bucket          *qt.QLineEdit
+	uploadS3AccessKey       *qt.QLineEdit
+	uploadS3SecretKey       *qt.QLineEdit
+	uploadS3PathStyle       *qt.QCheckBox
 }
 
 func ShowSettingsDialog(parent *qt.QWidget, prefs config.Preferences, onSaved func(config.DBConfig, config.UIConfig)) {
@@ -122,6 +146,11 @@ func ShowSettingsDialog(parent *qt.QWidget, prefs config.Preferences, onSaved fu
 		return
 	}
 	dbCfg, uiCfg := d.values()
+	if instances, err := config.ParseSourceInstances(d.sourceInstances.ToPlainText()); err != nil {
+		qt.QMessageBox_Warning(parent, "Додаткові джерела", "Додаткові джерела не збережено: "+err.Error())
+	} else {
+		dbCfg.SourceInstances = instances
+	}
 	if dbCfg.PhoenixEnabled && dbCfg.PhoenixOperatorID > 0 {
 		ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
 		err := data.ValidatePhoenixOperatorCredentials(ctx, dbCfg)
@@ -134,6 +163,7 @@ func ShowSettingsDialog(parent *qt.QWidget, prefs config.Preferences, onSaved fu
 	config.SaveDBConfig(prefs, dbCfg)
 	config.SaveUIConfig(prefs, uiCfg)
 	d.saveOperatorAndCommandSettings()
+	d.saveReportUploadSettings()
 	if onSaved != nil {
 		onSaved(dbCfg, uiCfg)
 	}
@@ -153,6 +183,7 @@ func newSettingsDialog(parent *qt.QWidget, prefs config.Preferences) *settingsDi
 	tabs.AddTab(d.buildDataSourcesTab(), "Джерела даних")
 	tabs.AddTab(d.buildOperatorsTab(), "Оператори і команди")
 	tabs.AddTab(d.buildInterfaceTab(), "Інтерфейс")
+	tabs.AddTab(d.buildReportUploadTab(), "Звіти")
 	root.AddWidget(tabs.QWidget)
 
 	buttons := qt.NewQDialogButtonBox4(qt.QDialogButtonBox__Ok | qt.QDialogButtonBox__Cancel)
@@ -163,6 +194,7 @@ func newSettingsDialog(parent *qt.QWidget, prefs config.Preferences) *settingsDi
 
 	d.load(config.LoadDBConfig(prefs), config.LoadUIConfig(prefs))
 	d.loadOperatorAndCommandSettings()
+	d.loadReportUploadSettings()
 	return d
 }
 
@@ -240,11 +272,36 @@ func (d *settingsDialog) buildDataSourcesTab() *qt.QWidget {
 	form.AddRow3("CASL password", d.caslPass.QWidget)
 	d.caslPultID = spinBox(0, 1000000000)
 	form.AddRow3("CASL pult ID", d.caslPultID.QWidget)
+	d.caslPults = lineEdit()
+	d.caslPults.SetPlaceholderText("Через кому, напр. 12, 15")
+	form.AddRow3("Додаткові пульти", d.caslPults.QWidget)
 	d.logLevel = qt.NewQComboBox2()
 	d.logLevel.AddItems([]string{"debug", "info", "warn", "error"})
 	form.AddRow3("Log level", d.logLevel.QWidget)
 	tabs.AddTab(wrapForm(form), "CASL")
 
+	instancesTab := qt.NewQWidget2()
+	instancesLayout := qt.NewQVBoxLayout(instancesTab)
+	instancesHelp := qt.NewQLabel3("Додаткові БД МІСТ, сервери Phoenix чи CASL поряд з основними (JSON-список). " +
+		"Тип: firebird, phoenix або casl. Порожні поля підключення беруться з основного джерела цього типу; " +
+		"додаткові джерела підключаються лише разом з основним і працюють тільки на моніторинг.")
+	instancesHelp.SetWordWrap(true)
+	instancesLayout.AddWidget(instancesHelp.QWidget)
+	d.sourceInstances = qt.NewQTextEdit2()
+	d.sourceInstances.SetAcceptRichText(false)
+	d.sourceInstances.SetPlaceholderText(`[{"name": "Філія", "type": "firebird", "path": "D:/BASE/MOST5.FDB"}]`)
+	instancesLayout.AddWidget(d.sourceInstances.QWidget)
+	instancesTab.SetLayout(instancesLayout.QLayout)
+	tabs.AddTab(instancesTab, "Додаткові джерела")
+
+	form = qt.NewQFormLayout2()
+	d.operatorServerURL = lineEdit()
+	d.operatorServerURL.SetPlaceholderText("http://server:8090 — порожньо для прямого підключення")
+	form.AddRow3("Адреса", d.operatorServerURL.QWidget)
+	d.operatorServerToken = passwordEdit()
+	form.AddRow3("Токен", d.operatorServerToken.QWidget)
+	tabs.AddTab(wrapForm(form), "Сервер оператора")
+
 	layout.AddWidget(tabs.QWidget)
 	tab.SetLayout(layout.QLayout)
 	return tab
@@ -307,6 +364,104 @@ func (d *settingsDialog) chooseExportDirectory() {
 	}
 }
 
+func (d *settingsDialog) buildReportUploadTab() *qt.QWidget {
+	form := qt.NewQFormLayout2()
+
+	d.uploadTarget = qt.NewQComboBox2()
+	d.uploadTarget.AddItems(config.ReportUploadTargets())
+	d.uploadTarget.SetToolTip("gdrive, local (папка / SMB), webdav, s3 або none")
+	form.AddRow3("Сховище звітів", d.uploadTarget.QWidget)
+	d.uploadPublicBaseURL = lineEdit()
+	d.uploadPublicBaseURL.SetPlaceholderText("Необов'язково: адреса для посилань у звітах")
+	form.AddRow3("Публічна адреса", d.uploadPublicBaseURL.QWidget)
+
+	d.uploadGDriveCredentials = lineEdit()
+	d.uploadGDriveCredentials.SetPlaceholderText(config.DefaultReportUploadGDriveCredentials)
+	form.AddRow3("Google Drive credentials", d.uploadGDriveCredentials.QWidget)
+	d.uploadGDriveToken = lineEdit()
+	d.uploadGDriveToken.SetPlaceholderText(config.DefaultReportUploadGDriveToken)
+	form.AddRow3("Google Drive token", d.uploadGDriveToken.QWidget)
+
+	d.uploadLocalDir = lineEdit()
+	d.uploadLocalDir.SetPlaceholderText(`\\server\reports`)
+	form.AddRow3("Спільна папка", d.uploadLocalDir.QWidget)
+
+	d.uploadWebDAVURL = lineEdit()
+	form.AddRow3("WebDAV URL", d.uploadWebDAVURL.QWidget)
+	d.uploadWebDAVUser = lineEdit()
+	form.AddRow3("WebDAV user", d.uploadWebDAVUser.QWidget)
+	d.uploadWebDAVPassword = passwordEdit()
+	form.AddRow3("WebDAV password", d.uploadWebDAVPassword.QWidget)
+
+	d.uploadS3Endpoint = lineEdit()
+	form.AddRow3("S3 endpoint", d.uploadS3Endpoint.QWidget)
+	d.uploadS3Region = lineEdit()
+	d.uploadS3Region.SetPlaceholderText(config.DefaultReportUploadS3Region)
+	form.AddRow3("S3 region", d.uploadS3Region.QWidget)
+	d.uploadS3Bucket = lineEdit()
+	form.AddRow3("S3 bucket", d.uploadS3Bucket.QWidget)
+	d.uploadS3AccessKey = lineEdit()
+	form.AddRow3("S3 access key", d.uploadS3AccessKey.QWidget)
+	d.uploadS3SecretKey = passwordEdit()
+	form.AddRow3("S3 secret key", d.uploadS3SecretKey.QWidget)
+	d.uploadS3PathStyle = qt.NewQCheckBox3("Path-style адреси (MinIO, Ceph)")
+	form.AddRow3("S3 addressing", d.uploadS3PathStyle.QWidget)
+
+	return wrapForm(form)
+}
+
+// reportUploadTargetUnselected — пункт для цілі, яку ще не обирали: звіт
+// прийнятих об'єктів тоді не публікується, а звіт видалених іде в gdrive.
+const reportUploadTargetUnselected = "не обрано (видалені → gdrive)"
+
+func (d *settingsDialog) loadReportUploadSettings() {
+	cfg := config.LoadReportUploadConfig(d.prefs)
+	if cfg.TargetSelected() {
+		setComboTextFallback(d.uploadTarget, cfg.NormalizedTarget(), config.ReportUploadTargetGoogleDrive)
+	} else {
+		if d.uploadTarget.FindText(reportUploadTargetUnselected) < 0 {
+			d.uploadTarget.InsertItem(0, reportUploadTargetUnselected)
+		}
+		d.uploadTarget.SetCurrentIndex(0)
+	}
+	d.uploadPublicBaseURL.SetText(cfg.PublicBaseURL)
+	d.uploadGDriveCredentials.SetText(cfg.GDriveCredentialsPath)
+	d.uploadGDriveToken.SetText(cfg.GDriveTokenPath)
+	d.uploadLocalDir.SetText(cfg.LocalDir)
+	d.uploadWebDAVURL.SetText(cfg.WebDAVURL)
+	d.uploadWebDAVUser.SetText(cfg.WebDAVUser)
+	d.uploadWebDAVPassword.SetText(cfg.WebDAVPassword)
+	d.uploadS3Endpoint.SetText(cfg.S3Endpoint)
+	d.uploadS3Region.SetText(cfg.S3Region)
+	d.uploadS3Bucket.SetText(cfg.S3Bucket)
+	d.uploadS3AccessKey.SetText(cfg.S3AccessKey)
+	d.uploadS3SecretKey.SetText(cfg.S3SecretKey)
+	d.uploadS3PathStyle.SetChecked(cfg.S3PathStyle)
+}
+
+func (d *settingsDialog) saveReportUploadSettings() {
+	target := d.uploadTarget.CurrentText()
+	if target == reportUploadTargetUnselected {
+		target = ""
+	}
+	config.SaveReportUploadConfig(d.prefs, config.ReportUploadConfig{
+		Target:                target,
+		PublicBaseURL:         d.uploadPublicBaseURL.Text(),
+		GDriveCredentialsPath: d.uploadGDriveCredentials.Text(),
+		GDriveTokenPath:       d.uploadGDriveToken.Text(),
+		LocalDir:              d.uploadLocalDir.Text(),
+		WebDAVURL:             d.uploadWebDAVURL.Text(),
+		WebDAVUser:            d.uploadWebDAVUser.Text(),
+		WebDAVPassword:        d.uploadWebDAVPassword.Text(),
+		S3Endpoint:            d.uploadS3Endpoint.Text(),
+		S3Region:              d.uploadS3Region.Text(),
+		S3Bucket:              d.uploadS3Bucket.Text(),
+		S3AccessKey:           d.uploadS3AccessKey.Text(),
+		S3SecretKey:           d.uploadS3SecretKey.Text(),
+		S3PathStyle:           d.uploadS3PathStyle.IsChecked(),
+	})
+}
+
 func (d *settingsDialog) buildOperatorsTab() *qt.QWidget {
 	tab := qt.NewQWidget2()
 	layout := qt.NewQVBoxLayout(tab)
@@ -408,6 +563,15 @@ func (d *settingsDialog) buildOperatorsTab() *qt.QWidget {
 	form.AddRow3("Dial context", d.amiContext.QWidget)
 	tabs.AddTab(wrapForm(form), "AMI")
 
+	form = qt.NewQFormLayout2()
+	d.dispatchRoadDistance = qt.NewQCheckBox3("Рахувати відстань до об'єкта дорогою")
+	d.dispatchRoadDistance.SetToolTip("Без цього групи реагування ранжуються за відстанню по прямій")
+	form.AddRow3("Відстань МГР", d.dispatchRoadDistance.QWidget)
+	d.dispatchRoutingURL = lineEdit()
+	d.dispatchRoutingURL.SetPlaceholderText(config.DefaultDispatchRoutingURL)
+	form.AddRow3("OSRM сервер", d.dispatchRoutingURL.QWidget)
+	tabs.AddTab(wrapForm(form), "Диспетчеризація")
+
 	layout.AddWidget(tabs.QWidget)
 	tab.SetLayout(layout.QLayout)
 	return tab
@@ -444,7 +608,11 @@ func (d *settingsDialog) load(dbCfg config.DBConfig, uiCfg config.UIConfig) {
 	d.caslEmail.SetText(dbCfg.CASLEmail)
 	d.caslPass.SetText(dbCfg.CASLPass)
 	d.caslPultID.SetValue(int(dbCfg.CASLPultID))
+	d.caslPults.SetText(config.FormatCASLPultIDs(dbCfg.CASLExtraPultIDs))
+	d.sourceInstances.SetPlainText(config.FormatSourceInstances(dbCfg.SourceInstances))
 	setComboText(d.logLevel, dbCfg.LogLevel)
+	d.operatorServerURL.SetText(dbCfg.OperatorServerURL)
+	d.operatorServerToken.SetText(dbCfg.OperatorServerToken)
 
 	d.fontSizeInterface.SetValue(float64(uiCfg.FontSizeInterface))
 	d.fontSizeObjectCard.SetValue(float64(uiCfg.FontSizeObjectCard))
@@ -501,7 +669,10 @@ func (d *settingsDialog) values() (config.DBConfig, config.UIConfig) {
 	dbCfg.CASLEmail = d.caslEmail.Text()
 	dbCfg.CASLPass = d.caslPass.Text()
 	dbCfg.CASLPultID = int64(d.caslPultID.Value())
+	dbCfg.CASLExtraPultIDs = config.ParseCASLPultIDs(d.caslPults.Text())
 	dbCfg.LogLevel = d.logLevel.CurrentText()
+	dbCfg.OperatorServerURL = strings.TrimSpace(d.operatorServerURL.Text())
+	dbCfg.OperatorServerToken = d.operatorServerToken.Text()
 	dbCfg.Mode = backendModeFromEnabled(dbCfg)
 
 	uiCfg := config.LoadUIConfig(d.prefs)
@@ -626,6 +797,10 @@ func (d *settingsDialog) loadOperatorAndCommandSettings() {
 	d.amiSecret.SetText(amiCfg.Secret)
 	d.amiExtension.SetText(amiCfg.Extension)
 	d.amiContext.SetText(amiCfg.Context)
+
+	dispatchCfg := config.LoadDispatchConfig(d.prefs)
+	d.dispatchRoadDistance.SetChecked(dispatchCfg.RoadDistance)
+	d.dispatchRoutingURL.SetText(dispatchCfg.RoutingURL)
 }
 
 func (d *settingsDialog) saveOperatorAndCommandSettings() {
@@ -676,6 +851,10 @@ func (d *settingsDialog) saveOperatorAndCommandSettings() {
 		Extension: d.amiExtension.Text(),
 		Context:   d.amiContext.Text(),
 	})
+	config.SaveDispatchConfig(d.prefs, config.DispatchConfig{
+		RoadDistance: d.dispatchRoadDistance.IsChecked(),
+		RoutingURL:   d.dispatchRoutingURL.Text(),
+	})
 }
 
 func backendModeFromEnabled(cfg config.DBConfig) string {
@@ -4,6 +4,7 @@ package qtui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"slices"
@@ -1798,14 +1799,15 @@ func (panel *WorkAreaPanel) addObjectToDeleted() {
 		runCallback := func() {
 			panel.addToDeletedBtn.SetEnabled(true)
 			if err != nil {
-				if gdriveErr, ok := err.(*objexport.GoogleDriveUploadError); ok {
-					qt.QMessageBox_Warning(panel.QWidget, "Увага", fmt.Sprintf("Об'єкт додано в Excel, але не завантажено на Google Drive: %v", gdriveErr.Err))
+				var uploadErr *objexport.ReportUploadError
+				if errors.As(err, &uploadErr) {
+					qt.QMessageBox_Warning(panel.QWidget, "Увага", fmt.Sprintf("Об'єкт додано в Excel, але не завантажено на %s: %v", uploadErr.TargetName(), uploadErr.Err))
 				} else {
 					qt.QMessageBox_Critical(panel.QWidget, "Помилка", err.Error())
 				}
 				return
 			}
-			qt.QMessageBox_Information(panel.QWidget, "Готово", "Об'єкт додано до знятих/видалених Excel та Google Drive")
+			qt.QMessageBox_Information(panel.QWidget, "Готово", "Об'єкт додано до знятих/видалених Excel, PDF опубліковано")
 		}
 
 		if panel.OnRunOnMainThread != nil {
@@ -0,0 +1,127 @@
+package reportscheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	RunStatusOK    = "ok"
+	RunStatusError = "error"
+
+	defaultHistoryLimit = 500
+)
+
+// History stores the latest report runs, oldest first.
+type History struct {
+	path  string
+	limit int
+	mu    sync.Mutex
+	Runs  []RunRecord `json:"runs"`
+}
+
+// RunRecord describes one report run.
+type RunRecord struct {
+	Job        string    `json:"job"`
+	Kind       string    `json:"kind"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Status     string    `json:"status"`
+	Rows       int       `json:"rows"`
+	File       string    `json:"file,omitempty"`
+	Link       string    `json:"link,omitempty"`
+	Summary    string    `json:"summary,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// LoadHistory reads run history or creates an empty in-memory store.
+func LoadHistory(path string, limit int) (*History, error) {
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	h := &History{path: strings.TrimSpace(path), limit: limit}
+	if h.path == "" {
+		return h, nil
+	}
+	body, err := os.ReadFile(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("report scheduler: read history %q: %w", h.path, err)
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return h, nil
+	}
+	if err := json.Unmarshal(body, h); err != nil {
+		return nil, fmt.Errorf("report scheduler: read history %q: %w", h.path, err)
+	}
+	return h, nil
+}
+
+// Record appends a run and drops the oldest entries above the limit.
+func (h *History) Record(run RunRecord) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Runs = append(h.Runs, run)
+	if extra := len(h.Runs) - h.limit; extra > 0 {
+		h.Runs = append([]RunRecord(nil), h.Runs[extra:]...)
+	}
+}
+
+// Last returns the latest run of the job.
+func (h *History) Last(job string) (RunRecord, bool) {
+	if h == nil {
+		return RunRecord{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.Runs) - 1; i >= 0; i-- {
+		if h.Runs[i].Job == job {
+			return h.Runs[i], true
+		}
+	}
+	return RunRecord{}, false
+}
+
+// Snapshot returns a copy of all recorded runs.
+func (h *History) Snapshot() []RunRecord {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]RunRecord(nil), h.Runs...)
+}
+
+// Save writes history to disk.
+func (h *History) Save() error {
+	if h == nil || h.path == "" {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if dir := filepath.Dir(h.path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("report scheduler: create history directory %q: %w", dir, err)
+		}
+	}
+	body, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("report scheduler: encode history: %w", err)
+	}
+	body = append(body, '\n')
+	if err := os.WriteFile(h.path, body, 0o600); err != nil {
+		return fmt.Errorf("report scheduler: write history %q: %w", h.path, err)
+	}
+	return nil
+}
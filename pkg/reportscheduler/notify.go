@@ -0,0 +1,141 @@
+package reportscheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/omnicell"
+)
+
+// Notifier delivers a short run summary to operators.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, subject string, text string) error
+}
+
+// EmailConfig describes an SMTP relay used for report summaries.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier sends summaries through SMTP (STARTTLS is used when the server offers it).
+type EmailNotifier struct {
+	cfg      EmailConfig
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+func NewEmailNotifier(cfg EmailConfig) (*EmailNotifier, error) {
+	cfg.Host = strings.TrimSpace(cfg.Host)
+	cfg.From = strings.TrimSpace(cfg.From)
+	cfg.To = nonEmpty(cfg.To)
+	if cfg.Host == "" {
+		return nil, errors.New("не вказано SMTP-сервер")
+	}
+	if cfg.From == "" {
+		cfg.From = strings.TrimSpace(cfg.User)
+	}
+	if cfg.From == "" {
+		return nil, errors.New("не вказано адресу відправника")
+	}
+	if len(cfg.To) == 0 {
+		return nil, errors.New("не вказано отримувачів e-mail")
+	}
+	if cfg.Port <= 0 {
+		cfg.Port = 587
+	}
+	return &EmailNotifier{cfg: cfg, sendMail: smtp.SendMail, now: time.Now}, nil
+}
+
+func (n *EmailNotifier) Name() string {
+	return "e-mail"
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, subject string, text string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if strings.TrimSpace(n.cfg.User) != "" {
+		auth = smtp.PlainAuth("", strings.TrimSpace(n.cfg.User), n.cfg.Password, n.cfg.Host)
+	}
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+	if err := n.sendMail(addr, auth, n.cfg.From, n.cfg.To, n.message(subject, text)); err != nil {
+		return fmt.Errorf("send e-mail via %s: %w", addr, err)
+	}
+	return nil
+}
+
+func (n *EmailNotifier) message(subject string, text string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + n.cfg.From + "\r\n")
+	b.WriteString("To: " + strings.Join(n.cfg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + n.now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// SMSSender is implemented by *omnicell.Client.
+type SMSSender interface {
+	SendSMS(ctx context.Context, req omnicell.SendRequest) (omnicell.SendResponse, error)
+}
+
+// SMSNotifier sends the summary subject to every configured phone.
+type SMSNotifier struct {
+	sender SMSSender
+	phones []string
+}
+
+func NewSMSNotifier(sender SMSSender, phones []string) (*SMSNotifier, error) {
+	if sender == nil {
+		return nil, errors.New("SMS-шлюз не налаштовано")
+	}
+	phones = nonEmpty(phones)
+	if len(phones) == 0 {
+		return nil, errors.New("не вказано номери для SMS")
+	}
+	return &SMSNotifier{sender: sender, phones: phones}, nil
+}
+
+func (n *SMSNotifier) Name() string {
+	return "SMS"
+}
+
+// Notify sends only the subject: SMS recipients need the outcome, not the full summary.
+func (n *SMSNotifier) Notify(ctx context.Context, subject string, _ string) error {
+	var errs []error
+	for _, phone := range n.phones {
+		if _, err := n.sender.SendSMS(ctx, omnicell.SendRequest{Phone: phone, Text: subject}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", phone, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
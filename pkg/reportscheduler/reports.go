@@ -0,0 +1,299 @@
+package reportscheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectreport"
+	"obj_catalog_fyne_v3/pkg/siminventory"
+	"obj_catalog_fyne_v3/pkg/utils"
+)
+
+const (
+	KindAcceptedObjects = "accepted_objects"
+	KindNewObjects      = "new_objects"
+	KindSIMInventory    = "sim_inventory"
+	KindCASLStatistic   = "casl_statistic"
+)
+
+// Kinds returns supported report kinds.
+func Kinds() []string {
+	return []string{KindAcceptedObjects, KindNewObjects, KindSIMInventory, KindCASLStatistic}
+}
+
+// Provider is the minimal data source; other report capabilities are discovered by type assertion.
+type Provider interface {
+	GetObjects() []models.Object
+}
+
+type statisticReportProvider interface {
+	GetStatisticReport(ctx context.Context, name string, limit int) ([]map[string]any, error)
+}
+
+type adminProviderSource interface {
+	AdminProvider() contracts.AdminProvider
+}
+
+type objectByIDProvider interface {
+	GetObjectByID(id string) *models.Object
+}
+
+type simStatusProvider interface {
+	GetVodafoneSIMStatus(msisdn string) (contracts.VodafoneSIMStatus, error)
+	GetKyivstarSIMStatus(msisdn string) (contracts.KyivstarSIMStatus, error)
+}
+
+type vodafoneInventoryProvider interface {
+	ListVodafoneSIMInventory() (map[string]contracts.VodafoneSIMInventoryEntry, error)
+}
+
+type kyivstarInventoryProvider interface {
+	ListKyivstarSIMInventory(numbers []string) (map[string]contracts.KyivstarSIMInventoryEntry, error)
+}
+
+// generatedReport is a report file written to the local output directory.
+type generatedReport struct {
+	FilePath string
+	Rows     int
+	Summary  string
+}
+
+func (r *Runner) generate(ctx context.Context, job Job, filePath string, now time.Time) (generatedReport, error) {
+	switch job.Kind {
+	case KindAcceptedObjects:
+		return r.generateAcceptedObjects(filePath)
+	case KindNewObjects:
+		return r.generateNewObjects(job, filePath, now)
+	case KindSIMInventory:
+		return r.generateSIMInventory(ctx, job, filePath)
+	case KindCASLStatistic:
+		return r.generateCASLStatistic(ctx, job, filePath)
+	default:
+		return generatedReport{}, fmt.Errorf("невідомий тип звіту %q", job.Kind)
+	}
+}
+
+func (r *Runner) generateAcceptedObjects(filePath string) (generatedReport, error) {
+	reporter, ok := r.provider.(contracts.ExcelReportingProvider)
+	if !ok {
+		return generatedReport{}, errors.New("джерело даних не підтримує звіт прийнятих об'єктів")
+	}
+	if err := reporter.GenerateAcceptedObjectsReport(filePath); err != nil {
+		return generatedReport{}, err
+	}
+	return generatedReport{FilePath: filePath, Summary: "Звіт прийнятих об'єктів сформовано"}, nil
+}
+
+func (r *Runner) generateNewObjects(job Job, filePath string, now time.Time) (generatedReport, error) {
+	period := strings.TrimSpace(job.Period)
+	if period == "" || period == objectreport.PeriodCustom {
+		period = objectreport.PeriodMonth
+	}
+	from, to := objectreport.RangeForPeriod(period, now)
+	items := objectreport.Filter(r.provider.GetObjects(), from, to)
+
+	records := make([][]string, 0, len(items)+1)
+	records = append(records, []string{"Дата додавання", "Джерело", "№ об'єкта", "Назва", "Адреса", "Стан"})
+	for _, item := range items {
+		records = append(records, []string{
+			item.AddedAt.Format("02.01.2006"),
+			objectSourceLabel(item.Object.ID),
+			ids.ObjectDisplayNumber(item.Object),
+			strings.TrimSpace(item.Object.Name),
+			strings.TrimSpace(item.Object.Address),
+			item.Object.GetStatusDisplay(),
+		})
+	}
+	if err := writeCSV(filePath, records); err != nil {
+		return generatedReport{}, err
+	}
+	return generatedReport{
+		FilePath: filePath,
+		Rows:     len(items),
+		Summary: fmt.Sprintf("Нових об'єктів за період %s – %s: %d",
+			from.Format("02.01.2006"), to.Format("02.01.2006"), len(items)),
+	}, nil
+}
+
+func (r *Runner) generateSIMInventory(ctx context.Context, job Job, filePath string) (generatedReport, error) {
+	provider, err := r.simInventoryProvider()
+	if err != nil {
+		return generatedReport{}, err
+	}
+	limit := job.Limit
+	if limit <= 0 {
+		limit = siminventory.DefaultCASLReportLimit
+	}
+	result, err := siminventory.BuildReport(ctx, provider, limit)
+	if err != nil {
+		return generatedReport{}, err
+	}
+	if err := os.WriteFile(filePath, []byte(siminventory.BuildCSV(result.Rows)), 0o644); err != nil {
+		return generatedReport{}, fmt.Errorf("write report %q: %w", filePath, err)
+	}
+	return generatedReport{
+		FilePath: filePath,
+		Rows:     len(result.Rows),
+		Summary:  siminventory.FormatSummary(result),
+	}, nil
+}
+
+func (r *Runner) generateCASLStatistic(ctx context.Context, job Job, filePath string) (generatedReport, error) {
+	reporter, ok := r.provider.(statisticReportProvider)
+	if !ok {
+		return generatedReport{}, errors.New("джерело даних не підтримує статистичні звіти CASL")
+	}
+	name := strings.TrimSpace(job.Report)
+	if name == "" {
+		return generatedReport{}, errors.New("не вказано назву звіту CASL (report)")
+	}
+	limit := job.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	rows, err := reporter.GetStatisticReport(ctx, name, limit)
+	if err != nil {
+		return generatedReport{}, err
+	}
+
+	keySet := make(map[string]struct{})
+	for _, row := range rows {
+		for key := range row {
+			keySet[key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, keys)
+	for _, row := range rows {
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = utils.AsString(row[key])
+		}
+		records = append(records, values)
+	}
+	if err := writeCSV(filePath, records); err != nil {
+		return generatedReport{}, err
+	}
+	return generatedReport{
+		FilePath: filePath,
+		Rows:     len(rows),
+		Summary:  fmt.Sprintf("CASL %s: %d рядків", name, len(rows)),
+	}, nil
+}
+
+// simInventoryProvider assembles the SIM report API from the data provider and its admin provider.
+func (r *Runner) simInventoryProvider() (siminventory.ReportProvider, error) {
+	objects, ok := r.provider.(objectByIDProvider)
+	if !ok {
+		return nil, errors.New("джерело даних не підтримує пошук об'єктів за ID")
+	}
+	var admin any
+	if source, ok := r.provider.(adminProviderSource); ok {
+		if provider := source.AdminProvider(); provider != nil {
+			admin = provider
+		}
+	}
+	status, ok := admin.(simStatusProvider)
+	if !ok {
+		return nil, errors.New("звіт по SIM-картах потребує адмін-провайдера БД/МІСТ для Vodafone/Kyivstar")
+	}
+	reporter, _ := r.provider.(statisticReportProvider)
+	vodafone, _ := admin.(vodafoneInventoryProvider)
+	kyivstar, _ := admin.(kyivstarInventoryProvider)
+	return simInventorySource{
+		Provider: r.provider,
+		objects:  objects,
+		reporter: reporter,
+		status:   status,
+		vodafone: vodafone,
+		kyivstar: kyivstar,
+		withCASL: reporter != nil && r.caslEnabled,
+	}, nil
+}
+
+type simInventorySource struct {
+	Provider
+	objects  objectByIDProvider
+	reporter statisticReportProvider
+	status   simStatusProvider
+	vodafone vodafoneInventoryProvider
+	kyivstar kyivstarInventoryProvider
+	withCASL bool
+}
+
+func (s simInventorySource) GetObjectByID(id string) *models.Object {
+	return s.objects.GetObjectByID(id)
+}
+
+func (s simInventorySource) GetStatisticReport(ctx context.Context, name string, limit int) ([]map[string]any, error) {
+	if s.reporter == nil {
+		return nil, nil
+	}
+	return s.reporter.GetStatisticReport(ctx, name, limit)
+}
+
+func (s simInventorySource) GetVodafoneSIMStatus(msisdn string) (contracts.VodafoneSIMStatus, error) {
+	return s.status.GetVodafoneSIMStatus(msisdn)
+}
+
+func (s simInventorySource) GetKyivstarSIMStatus(msisdn string) (contracts.KyivstarSIMStatus, error) {
+	return s.status.GetKyivstarSIMStatus(msisdn)
+}
+
+func (s simInventorySource) SupportsCASLReports() bool {
+	return s.withCASL
+}
+
+func (s simInventorySource) ListVodafoneSIMInventory() (map[string]contracts.VodafoneSIMInventoryEntry, error) {
+	if s.vodafone == nil {
+		return nil, nil
+	}
+	return s.vodafone.ListVodafoneSIMInventory()
+}
+
+func (s simInventorySource) ListKyivstarSIMInventory(numbers []string) (map[string]contracts.KyivstarSIMInventoryEntry, error) {
+	if s.kyivstar == nil {
+		return nil, nil
+	}
+	return s.kyivstar.ListKyivstarSIMInventory(numbers)
+}
+
+func objectSourceLabel(id int) string {
+	switch {
+	case ids.IsPhoenixObjectID(id):
+		return siminventory.SourcePhoenix
+	case ids.IsCASLObjectID(id):
+		return siminventory.SourceCASL
+	default:
+		return siminventory.SourceBridge
+	}
+}
+
+func writeCSV(filePath string, records [][]string) error {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = ';'
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("encode report %q: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, buffer.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write report %q: %w", filePath, err)
+	}
+	return nil
+}
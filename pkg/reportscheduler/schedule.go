@@ -0,0 +1,198 @@
+package reportscheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression: minute hour day-of-month month day-of-week.
+// Supported syntax: "*", lists ("1,15"), ranges ("1-5"), steps ("*/10", "8-18/2")
+// and the shortcuts @hourly, @daily, @weekly, @monthly.
+type Schedule struct {
+	expr     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	anyDay   bool
+	anyWeek  bool
+}
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+var cronShortcuts = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 1",
+	"@monthly":  "0 0 1 * *",
+}
+
+// ParseSchedule parses a cron expression.
+func ParseSchedule(expr string) (Schedule, error) {
+	original := strings.TrimSpace(expr)
+	normalized := original
+	if shortcut, ok := cronShortcuts[strings.ToLower(normalized)]; ok {
+		normalized = shortcut
+	}
+	parts := strings.Fields(normalized)
+	if len(parts) != len(cronFields) {
+		return Schedule{}, fmt.Errorf("розклад %q: очікується 5 полів (хвилина година день місяць день_тижня)", original)
+	}
+
+	var masks [5]uint64
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return Schedule{}, fmt.Errorf("розклад %q: %w", original, err)
+		}
+		masks[i] = mask
+	}
+	// Неділя може бути записана як 0 або 7.
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+		masks[4] &^= 1 << 7
+	}
+
+	return Schedule{
+		expr:     original,
+		minutes:  masks[0],
+		hours:    masks[1],
+		days:     masks[2],
+		months:   masks[3],
+		weekdays: masks[4],
+		anyDay:   parts[2] == "*",
+		anyWeek:  parts[4] == "*",
+	}, nil
+}
+
+func (s Schedule) String() string {
+	return s.expr
+}
+
+// IsZero reports whether the schedule was never parsed.
+func (s Schedule) IsZero() bool {
+	return s.minutes == 0
+}
+
+// Next returns the first matching minute strictly after the given time, in its location.
+// A zero time is returned when nothing matches within five years (e.g. "0 0 31 2 *").
+func (s Schedule) Next(after time.Time) time.Time {
+	if s.IsZero() {
+		return time.Time{}
+	}
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay follows the classic cron rule: when both day fields are restricted, either may match.
+func (s Schedule) matchesDay(t time.Time) bool {
+	dayMatch := s.days&(1<<uint(t.Day())) != 0
+	weekMatch := s.weekdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return weekMatch
+	case s.anyWeek:
+		return dayMatch
+	default:
+		return dayMatch || weekMatch
+	}
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return 0, fmt.Errorf("%s: порожній елемент", field.name)
+		}
+
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(item, "/"); ok {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("%s: некоректний крок %q", field.name, stepPart)
+			}
+			step = parsed
+			item = rangePart
+		}
+
+		from, to := field.min, field.max
+		switch {
+		case item == "*":
+		case strings.Contains(item, "-"):
+			left, right, _ := strings.Cut(item, "-")
+			var err error
+			if from, err = parseCronValue(left, field); err != nil {
+				return 0, err
+			}
+			if to, err = parseCronValue(right, field); err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("%s: некоректний діапазон %q", field.name, item)
+			}
+		default:
+			parsed, err := parseCronValue(item, field)
+			if err != nil {
+				return 0, err
+			}
+			from = parsed
+			if step == 1 {
+				to = parsed
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parsed < field.min || parsed > field.max {
+		return 0, fmt.Errorf("%s: значення %q поза межами %d-%d", field.name, value, field.min, field.max)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,60 @@
+package reportscheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	// 2026-06-15 is a Monday.
+	base := time.Date(2026, 6, 15, 10, 17, 42, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "*/15 * * * *", want: time.Date(2026, 6, 15, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 8 * * *", want: time.Date(2026, 6, 16, 8, 0, 0, 0, time.UTC)},
+		{expr: "30 7 * * 1-5", want: time.Date(2026, 6, 16, 7, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 0", want: time.Date(2026, 6, 21, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2026, 6, 21, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 6 1 * *", want: time.Date(2026, 7, 1, 6, 0, 0, 0, time.UTC)},
+		{expr: "0 6 1,20 * *", want: time.Date(2026, 6, 20, 6, 0, 0, 0, time.UTC)},
+		{expr: "0 0 13 * 5", want: time.Date(2026, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2026, 6, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "17 10 * * *", want: time.Date(2026, 6, 16, 10, 17, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q): %v", tt.expr, err)
+		}
+		if got := schedule.Next(base); !got.Equal(tt.want) {
+			t.Fatalf("Next(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleNextReturnsZeroForImpossibleDate(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseSchedule("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	if got := schedule.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Fatalf("expected zero time, got %s", got)
+	}
+}
+
+func TestParseScheduleRejectsInvalidExpressions(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Fatalf("expected error for %q", expr)
+		}
+	}
+}
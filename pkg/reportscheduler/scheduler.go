@@ -0,0 +1,311 @@
+// Package reportscheduler runs reports headlessly on cron-like schedules,
+// publishes them to the configured upload target and notifies operators.
+package reportscheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/reportupload"
+)
+
+const defaultUploadFolder = "Звіти"
+
+// Job describes one scheduled report.
+type Job struct {
+	Name     string
+	Kind     string
+	Schedule string
+	// Period is the objectreport period for new_objects reports.
+	Period string
+	// Report is the CASL statistic report name for casl_statistic reports.
+	Report string
+	Limit  int
+	// Folder is the folder inside the upload target.
+	Folder string
+	Notify bool
+}
+
+// Options controls the scheduler.
+type Options struct {
+	Jobs         []Job
+	OutputDir    string
+	HistoryPath  string
+	HistoryLimit int
+	Upload       reportupload.Target
+	Notifiers    []Notifier
+	CASLEnabled  bool
+	Location     *time.Location
+}
+
+type scheduledJob struct {
+	Job
+	schedule Schedule
+	next     time.Time
+}
+
+// Runner executes report jobs when their schedules fire.
+type Runner struct {
+	provider    Provider
+	jobs        []*scheduledJob
+	history     *History
+	options     Options
+	caslEnabled bool
+	now         func() time.Time
+}
+
+// NewRunner validates jobs and loads run history.
+func NewRunner(provider Provider, opts Options) (*Runner, error) {
+	if provider == nil {
+		return nil, errors.New("report scheduler: data provider is not configured")
+	}
+	if len(opts.Jobs) == 0 {
+		return nil, errors.New("report scheduler: no jobs configured")
+	}
+	if strings.TrimSpace(opts.OutputDir) == "" {
+		opts.OutputDir = "reports"
+	}
+	if strings.TrimSpace(opts.HistoryPath) == "" {
+		opts.HistoryPath = filepath.Join("log", "report-scheduler-history.json")
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	jobs := make([]*scheduledJob, 0, len(opts.Jobs))
+	seen := make(map[string]struct{}, len(opts.Jobs))
+	for _, job := range opts.Jobs {
+		job.Name = strings.TrimSpace(job.Name)
+		job.Kind = strings.TrimSpace(job.Kind)
+		if job.Name == "" {
+			return nil, errors.New("report scheduler: job name is empty")
+		}
+		if _, ok := seen[job.Name]; ok {
+			return nil, fmt.Errorf("report scheduler: duplicate job %q", job.Name)
+		}
+		seen[job.Name] = struct{}{}
+		if !isKnownKind(job.Kind) {
+			return nil, fmt.Errorf("report scheduler: job %q has unknown kind %q", job.Name, job.Kind)
+		}
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("report scheduler: job %q: %w", job.Name, err)
+		}
+		jobs = append(jobs, &scheduledJob{Job: job, schedule: schedule})
+	}
+
+	history, err := LoadHistory(opts.HistoryPath, opts.HistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		provider:    provider,
+		jobs:        jobs,
+		history:     history,
+		options:     opts,
+		caslEnabled: opts.CASLEnabled,
+		now:         time.Now,
+	}, nil
+}
+
+// History returns the run history store.
+func (r *Runner) History() *History {
+	if r == nil {
+		return nil
+	}
+	return r.history
+}
+
+// Run waits for schedules to fire until ctx is canceled.
+func (r *Runner) Run(ctx context.Context) error {
+	if r == nil {
+		return errors.New("report scheduler: runner is nil")
+	}
+	now := r.now().In(r.options.Location)
+	for _, job := range r.jobs {
+		job.next = job.schedule.Next(now)
+		log.Info().Str("job", job.Name).Str("schedule", job.schedule.String()).Time("next", job.next).Msg("report scheduler: job planned")
+	}
+
+	for {
+		next := r.nextFire()
+		if next.IsZero() {
+			return errors.New("report scheduler: no upcoming runs")
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		r.runDue(ctx, r.now().In(r.options.Location))
+	}
+}
+
+// RunJob runs one job immediately, regardless of its schedule.
+func (r *Runner) RunJob(ctx context.Context, name string) (RunRecord, error) {
+	if r == nil {
+		return RunRecord{}, errors.New("report scheduler: runner is nil")
+	}
+	for _, job := range r.jobs {
+		if job.Name == strings.TrimSpace(name) {
+			record := r.execute(ctx, job.Job)
+			if record.Status != RunStatusOK {
+				return record, errors.New(record.Error)
+			}
+			return record, nil
+		}
+	}
+	return RunRecord{}, fmt.Errorf("report scheduler: job %q not found", name)
+}
+
+// RunAll runs every job once in configuration order.
+func (r *Runner) RunAll(ctx context.Context) error {
+	if r == nil {
+		return errors.New("report scheduler: runner is nil")
+	}
+	var errs []error
+	for _, job := range r.jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if record := r.execute(ctx, job.Job); record.Status != RunStatusOK {
+			errs = append(errs, fmt.Errorf("%s: %s", job.Name, record.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Runner) nextFire() time.Time {
+	var next time.Time
+	for _, job := range r.jobs {
+		if job.next.IsZero() {
+			continue
+		}
+		if next.IsZero() || job.next.Before(next) {
+			next = job.next
+		}
+	}
+	return next
+}
+
+func (r *Runner) runDue(ctx context.Context, now time.Time) {
+	for _, job := range r.jobs {
+		if job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+		r.execute(ctx, job.Job)
+		job.next = job.schedule.Next(r.now().In(r.options.Location))
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job Job) RunRecord {
+	started := r.now().In(r.options.Location)
+	record := RunRecord{Job: job.Name, Kind: job.Kind, StartedAt: started}
+
+	report, link, err := r.produce(ctx, job, started)
+	record.FinishedAt = r.now().In(r.options.Location)
+	record.File = report.FilePath
+	record.Rows = report.Rows
+	record.Summary = report.Summary
+	record.Link = link
+	if err != nil {
+		record.Status = RunStatusError
+		record.Error = err.Error()
+		log.Error().Err(err).Str("job", job.Name).Str("kind", job.Kind).Msg("report scheduler: run failed")
+	} else {
+		record.Status = RunStatusOK
+		log.Info().Str("job", job.Name).Str("file", record.File).Str("link", link).Int("rows", record.Rows).Msg("report scheduler: report ready")
+	}
+
+	r.history.Record(record)
+	if saveErr := r.history.Save(); saveErr != nil {
+		log.Error().Err(saveErr).Msg("report scheduler: save history failed")
+	}
+	if job.Notify {
+		r.notify(ctx, record)
+	}
+	return record
+}
+
+func (r *Runner) produce(ctx context.Context, job Job, now time.Time) (generatedReport, string, error) {
+	if err := os.MkdirAll(r.options.OutputDir, 0o755); err != nil {
+		return generatedReport{}, "", fmt.Errorf("create output directory %q: %w", r.options.OutputDir, err)
+	}
+	filePath := filepath.Join(r.options.OutputDir, reportFileName(job, now))
+	report, err := r.generate(ctx, job, filePath, now)
+	if err != nil {
+		return report, "", err
+	}
+
+	folder := strings.TrimSpace(job.Folder)
+	if folder == "" {
+		folder = defaultUploadFolder
+	}
+	link, err := export.PublishReport(ctx, r.options.Upload, report.FilePath, folder)
+	return report, link, err
+}
+
+func (r *Runner) notify(ctx context.Context, record RunRecord) {
+	subject, text := notificationText(record)
+	for _, notifier := range r.options.Notifiers {
+		if notifier == nil {
+			continue
+		}
+		if err := notifier.Notify(ctx, subject, text); err != nil {
+			log.Error().Err(err).Str("job", record.Job).Str("channel", notifier.Name()).Msg("report scheduler: notification failed")
+		}
+	}
+}
+
+func notificationText(record RunRecord) (subject string, text string) {
+	if record.Status != RunStatusOK {
+		subject = fmt.Sprintf("Звіт «%s» не сформовано", record.Job)
+		return subject, subject + ": " + record.Error
+	}
+
+	subject = fmt.Sprintf("Звіт «%s» сформовано", record.Job)
+	lines := []string{subject + " " + record.FinishedAt.Format("02.01.2006 15:04")}
+	if record.Summary != "" {
+		lines = append(lines, record.Summary)
+	}
+	if record.Link != "" {
+		lines = append(lines, "Посилання: "+record.Link)
+	} else if record.File != "" {
+		lines = append(lines, "Файл: "+record.File)
+	}
+	return subject, strings.Join(lines, "\n")
+}
+
+func reportFileName(job Job, now time.Time) string {
+	ext := ".csv"
+	if job.Kind == KindAcceptedObjects {
+		ext = ".xlsx"
+	}
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, job.Name)
+	return name + "_" + now.Format("20060102_1504") + ext
+}
+
+func isKnownKind(kind string) bool {
+	for _, known := range Kinds() {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package reportscheduler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/omnicell"
+	"obj_catalog_fyne_v3/pkg/reportupload"
+)
+
+type reportProviderStub struct {
+	objects  []models.Object
+	caslRows []map[string]any
+	caslErr  error
+}
+
+func (s reportProviderStub) GetObjects() []models.Object {
+	return append([]models.Object(nil), s.objects...)
+}
+
+func (s reportProviderStub) GetStatisticReport(_ context.Context, _ string, _ int) ([]map[string]any, error) {
+	return s.caslRows, s.caslErr
+}
+
+type notifierStub struct {
+	subjects []string
+	texts    []string
+}
+
+func (n *notifierStub) Name() string { return "stub" }
+
+func (n *notifierStub) Notify(_ context.Context, subject string, text string) error {
+	n.subjects = append(n.subjects, subject)
+	n.texts = append(n.texts, text)
+	return nil
+}
+
+func newTestRunner(t *testing.T, provider Provider, jobs []Job, notifiers ...Notifier) (*Runner, string) {
+	t.Helper()
+	dir := t.TempDir()
+	upload, err := reportupload.NewLocalFolderTarget(filepath.Join(dir, "share"), "")
+	if err != nil {
+		t.Fatalf("NewLocalFolderTarget: %v", err)
+	}
+	runner, err := NewRunner(provider, Options{
+		Jobs:        jobs,
+		OutputDir:   filepath.Join(dir, "out"),
+		HistoryPath: filepath.Join(dir, "history.json"),
+		Upload:      upload,
+		Notifiers:   notifiers,
+		Location:    time.UTC,
+	})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+	runner.now = func() time.Time { return time.Date(2026, 6, 15, 8, 0, 0, 0, time.UTC) }
+	return runner, dir
+}
+
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	return records
+}
+
+func TestRunJobNewObjectsPublishesAndRecordsHistory(t *testing.T) {
+	t.Parallel()
+
+	provider := reportProviderStub{objects: []models.Object{
+		{ID: 101, Name: "Магазин", Address: "вул. Шевченка, 1", LaunchDate: "10.06.2026"},
+		{ID: 102, Name: "Склад", LaunchDate: "01.01.2025"},
+		{ID: ids.PhoenixObjectIDNamespaceStart + 5, DisplayNumber: "L00005", Name: "Офіс", LaunchDate: "2026-06-01"},
+	}}
+	notifier := &notifierStub{}
+	runner, dir := newTestRunner(t, provider, []Job{{
+		Name: "new-weekly", Kind: KindNewObjects, Schedule: "0 8 * * 1", Period: "Місяць", Folder: "Нові", Notify: true,
+	}}, notifier)
+
+	record, err := runner.RunJob(context.Background(), "new-weekly")
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if record.Status != RunStatusOK || record.Rows != 2 {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+	wantLink := filepath.Join(dir, "share", "Нові", "new-weekly_20260615_0800.csv")
+	if record.Link != wantLink {
+		t.Fatalf("link = %q, want %q", record.Link, wantLink)
+	}
+
+	records := readCSV(t, record.File)
+	if len(records) != 3 || records[1][2] != "101" || records[2][2] != "L00005" || records[2][1] != "Phoenix" {
+		t.Fatalf("unexpected csv: %v", records)
+	}
+	if _, err := os.Stat(wantLink); err != nil {
+		t.Fatalf("published file missing: %v", err)
+	}
+
+	history, err := LoadHistory(filepath.Join(dir, "history.json"), 0)
+	if err != nil {
+		t.Fatalf("LoadHistory: %v", err)
+	}
+	last, ok := history.Last("new-weekly")
+	if !ok || last.Link != wantLink {
+		t.Fatalf("history not saved: %+v", history.Snapshot())
+	}
+
+	if len(notifier.subjects) != 1 || notifier.subjects[0] != "Звіт «new-weekly» сформовано" {
+		t.Fatalf("unexpected notifications: %v", notifier.subjects)
+	}
+	if !strings.Contains(notifier.texts[0], "Посилання: "+wantLink) {
+		t.Fatalf("summary does not contain link: %q", notifier.texts[0])
+	}
+}
+
+func TestRunJobCASLStatisticWritesSortedColumns(t *testing.T) {
+	t.Parallel()
+
+	provider := reportProviderStub{caslRows: []map[string]any{
+		{"name": "Об'єкт 1", "number": 7},
+		{"number": 8, "sim1": "0671234567"},
+	}}
+	runner, _ := newTestRunner(t, provider, []Job{{Name: "casl", Kind: KindCASLStatistic, Schedule: "@daily", Report: "stats_devices_v2"}})
+
+	record, err := runner.RunJob(context.Background(), "casl")
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	records := readCSV(t, record.File)
+	want := [][]string{{"name", "number", "sim1"}, {"Об'єкт 1", "7", ""}, {"", "8", "0671234567"}}
+	if len(records) != len(want) {
+		t.Fatalf("unexpected csv: %v", records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("row %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestRunJobFailureIsRecordedAndNotified(t *testing.T) {
+	t.Parallel()
+
+	notifier := &notifierStub{}
+	provider := reportProviderStub{caslErr: errors.New("casl offline")}
+	runner, _ := newTestRunner(t, provider, []Job{{Name: "casl", Kind: KindCASLStatistic, Schedule: "@daily", Report: "stats", Notify: true}}, notifier)
+
+	record, err := runner.RunJob(context.Background(), "casl")
+	if err == nil || record.Status != RunStatusError || record.Error != "casl offline" {
+		t.Fatalf("expected failed run, got %+v, %v", record, err)
+	}
+	if len(notifier.subjects) != 1 || notifier.subjects[0] != "Звіт «casl» не сформовано" {
+		t.Fatalf("unexpected notifications: %v", notifier.subjects)
+	}
+	if runs := runner.History().Snapshot(); len(runs) != 1 || runs[0].Status != RunStatusError {
+		t.Fatalf("unexpected history: %+v", runs)
+	}
+}
+
+func TestNewRunnerValidatesJobs(t *testing.T) {
+	t.Parallel()
+
+	provider := reportProviderStub{}
+	tests := []struct {
+		name string
+		jobs []Job
+	}{
+		{name: "no jobs"},
+		{name: "unknown kind", jobs: []Job{{Name: "a", Kind: "pdf", Schedule: "@daily"}}},
+		{name: "bad schedule", jobs: []Job{{Name: "a", Kind: KindNewObjects, Schedule: "daily"}}},
+		{name: "duplicate", jobs: []Job{
+			{Name: "a", Kind: KindNewObjects, Schedule: "@daily"},
+			{Name: "a", Kind: KindNewObjects, Schedule: "@weekly"},
+		}},
+	}
+	for _, tt := range tests {
+		if _, err := NewRunner(provider, Options{Jobs: tt.jobs, HistoryPath: filepath.Join(t.TempDir(), "h.json")}); err == nil {
+			t.Fatalf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestRunDueAdvancesOnlyFiredJobs(t *testing.T) {
+	t.Parallel()
+
+	runner, _ := newTestRunner(t, reportProviderStub{}, []Job{
+		{Name: "hourly", Kind: KindNewObjects, Schedule: "@hourly"},
+		{Name: "monthly", Kind: KindNewObjects, Schedule: "@monthly"},
+	})
+	now := time.Date(2026, 6, 15, 8, 0, 0, 0, time.UTC)
+	runner.jobs[0].next = now
+	runner.jobs[1].next = time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	runner.runDue(context.Background(), now)
+
+	if got := runner.jobs[0].next; !got.Equal(now.Add(time.Hour)) {
+		t.Fatalf("hourly next = %s", got)
+	}
+	if runs := runner.History().Snapshot(); len(runs) != 1 || runs[0].Job != "hourly" {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+}
+
+func TestHistoryKeepsLatestRuns(t *testing.T) {
+	t.Parallel()
+
+	history, err := LoadHistory("", 2)
+	if err != nil {
+		t.Fatalf("LoadHistory: %v", err)
+	}
+	for _, job := range []string{"a", "b", "c"} {
+		history.Record(RunRecord{Job: job})
+	}
+	runs := history.Snapshot()
+	if len(runs) != 2 || runs[0].Job != "b" || runs[1].Job != "c" {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+}
+
+func TestEmailNotifierBuildsUTF8Message(t *testing.T) {
+	t.Parallel()
+
+	notifier, err := NewEmailNotifier(EmailConfig{Host: "smtp.example.com", User: "reports@example.com", Password: "secret", To: []string{" ops@example.com ", ""}})
+	if err != nil {
+		t.Fatalf("NewEmailNotifier: %v", err)
+	}
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	notifier.sendMail = func(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		return nil
+	}
+	notifier.now = func() time.Time { return time.Date(2026, 6, 15, 8, 0, 0, 0, time.UTC) }
+
+	if err := notifier.Notify(context.Background(), "Звіт «sim» сформовано", "рядок 1\nрядок 2"); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" || len(gotTo) != 1 || gotTo[0] != "ops@example.com" {
+		t.Fatalf("unexpected envelope: %s %v", gotAddr, gotTo)
+	}
+	if !strings.Contains(gotMsg, "Subject: =?utf-8?q?") || !strings.Contains(gotMsg, "рядок 1\r\nрядок 2\r\n") {
+		t.Fatalf("unexpected message:\n%s", gotMsg)
+	}
+}
+
+type smsSenderStub struct {
+	phones []string
+}
+
+func (s *smsSenderStub) SendSMS(_ context.Context, req omnicell.SendRequest) (omnicell.SendResponse, error) {
+	s.phones = append(s.phones, req.Phone)
+	if req.Phone == "bad" {
+		return omnicell.SendResponse{}, errors.New("invalid")
+	}
+	return omnicell.SendResponse{StatusCode: 200}, nil
+}
+
+func TestSMSNotifierSendsToEveryPhone(t *testing.T) {
+	t.Parallel()
+
+	sender := &smsSenderStub{}
+	notifier, err := NewSMSNotifier(sender, []string{"0671234567", "bad", " "})
+	if err != nil {
+		t.Fatalf("NewSMSNotifier: %v", err)
+	}
+	err = notifier.Notify(context.Background(), "Звіт сформовано", "")
+	if err == nil || !strings.Contains(err.Error(), "bad") {
+		t.Fatalf("expected error for bad phone, got %v", err)
+	}
+	if len(sender.phones) != 2 {
+		t.Fatalf("expected two send attempts, got %v", sender.phones)
+	}
+}
@@ -0,0 +1,64 @@
+package reportupload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/gdrive"
+)
+
+// GoogleDriveTarget uploads files to Google Drive and shares them by link.
+// The OAuth client is created lazily on the first upload because it may open a browser.
+type GoogleDriveTarget struct {
+	credentialsPath string
+	tokenPath       string
+
+	mu      sync.Mutex
+	service *gdrive.Service
+}
+
+func NewGoogleDriveTarget(credentialsPath string, tokenPath string) *GoogleDriveTarget {
+	credentialsPath = strings.TrimSpace(credentialsPath)
+	if credentialsPath == "" {
+		credentialsPath = config.DefaultReportUploadGDriveCredentials
+	}
+	tokenPath = strings.TrimSpace(tokenPath)
+	if tokenPath == "" {
+		tokenPath = config.DefaultReportUploadGDriveToken
+	}
+	return &GoogleDriveTarget{credentialsPath: credentialsPath, tokenPath: tokenPath}
+}
+
+func (t *GoogleDriveTarget) Name() string {
+	return "Google Drive"
+}
+
+func (t *GoogleDriveTarget) Upload(ctx context.Context, localFilePath string, fileName string, folderName string) (string, error) {
+	srv, err := t.ensureService()
+	if err != nil {
+		return "", err
+	}
+	return srv.UploadAndShareFile(ctx, localFilePath, fileName, folderName)
+}
+
+func (t *GoogleDriveTarget) ensureService() (*gdrive.Service, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.service != nil {
+		return t.service, nil
+	}
+	if _, err := os.Stat(t.credentialsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s не знайдено", t.credentialsPath)
+	}
+	srv, err := gdrive.NewService(t.credentialsPath, t.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize google drive: %w", err)
+	}
+	t.service = srv
+	return srv, nil
+}
@@ -0,0 +1,79 @@
+package reportupload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFolderTarget copies files into a local directory or mounted SMB share (e.g. \\server\reports).
+type LocalFolderTarget struct {
+	root          string
+	publicBaseURL string
+}
+
+func NewLocalFolderTarget(root string, publicBaseURL string) (*LocalFolderTarget, error) {
+	root = strings.TrimSpace(root)
+	if root == "" {
+		return nil, fmt.Errorf("не вказано папку для звітів")
+	}
+	return &LocalFolderTarget{root: root, publicBaseURL: strings.TrimSpace(publicBaseURL)}, nil
+}
+
+func (t *LocalFolderTarget) Name() string {
+	return "спільна папка"
+}
+
+func (t *LocalFolderTarget) Upload(ctx context.Context, localFilePath string, fileName string, folderName string) (string, error) {
+	key, err := objectKey(folderName, fileName)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	targetPath := filepath.Join(t.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create report folder: %w", err)
+	}
+	if err := copyFile(localFilePath, targetPath); err != nil {
+		return "", err
+	}
+
+	if t.publicBaseURL != "" {
+		return publicLink(t.publicBaseURL, strings.Split(key, "/")...), nil
+	}
+	return targetPath, nil
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open local file %s: %w", src, err)
+	}
+	defer in.Close()
+
+	tmp := dst + ".part"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to copy report to %s: %w", dst, err)
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to close %s: %w", dst, err)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to move report to %s: %w", dst, err)
+	}
+	return nil
+}
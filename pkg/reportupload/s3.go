@@ -0,0 +1,238 @@
+package reportupload
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config describes an S3-compatible bucket (AWS S3, MinIO, Ceph RGW).
+type S3Config struct {
+	Endpoint      string
+	Region        string
+	Bucket        string
+	AccessKey     string
+	SecretKey     string
+	PathStyle     bool
+	PublicBaseURL string
+}
+
+// S3Target uploads files with a SigV4-signed PUT Object request.
+type S3Target struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3Target(cfg S3Config) (*S3Target, error) {
+	cfg.Endpoint = strings.TrimRight(strings.TrimSpace(cfg.Endpoint), "/")
+	cfg.Region = strings.TrimSpace(cfg.Region)
+	cfg.Bucket = strings.TrimSpace(cfg.Bucket)
+	cfg.AccessKey = strings.TrimSpace(cfg.AccessKey)
+	cfg.PublicBaseURL = strings.TrimSpace(cfg.PublicBaseURL)
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("не вказано адресу S3")
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("не вказано S3 bucket")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("не вказано ключі доступу S3")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("некоректна адреса S3: %q", cfg.Endpoint)
+	}
+	return &S3Target{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 2 * time.Minute},
+		now:      time.Now,
+	}, nil
+}
+
+func (t *S3Target) Name() string {
+	return "S3"
+}
+
+func (t *S3Target) Upload(ctx context.Context, localFilePath string, fileName string, folderName string) (string, error) {
+	key, err := objectKey(folderName, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	payloadHash, size, err := fileSHA256(localFilePath)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(localFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open local file %s: %w", localFilePath, err)
+	}
+	defer f.Close()
+
+	objectURL := t.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), f)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentTypeByName(fileName))
+	t.sign(req, payloadHash)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("S3 PUT failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("S3 PUT %s: %s %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if t.cfg.PublicBaseURL != "" {
+		return publicLink(t.cfg.PublicBaseURL, strings.Split(key, "/")...), nil
+	}
+	return objectURL.String(), nil
+}
+
+func (t *S3Target) objectURL(key string) *url.URL {
+	u := *t.endpoint
+	segments := strings.Split(key, "/")
+	if t.cfg.PathStyle {
+		segments = append([]string{t.cfg.Bucket}, segments...)
+	} else {
+		u.Host = t.cfg.Bucket + "." + u.Host
+	}
+	basePath := strings.TrimRight(u.Path, "/")
+	u.Path = basePath + "/" + strings.Join(segments, "/")
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, s3EscapeSegment(segment))
+	}
+	u.RawPath = basePath + "/" + strings.Join(escaped, "/")
+	return &u
+}
+
+// sign adds AWS Signature Version 4 headers for the "s3" service.
+func (t *S3Target) sign(req *http.Request, payloadHash string) {
+	now := t.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headerNames := []string{"host"}
+	for name := range req.Header {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteByte(':')
+		if name == "host" {
+			canonicalHeaders.WriteString(req.URL.Host)
+		} else {
+			canonicalHeaders.WriteString(strings.TrimSpace(req.Header.Get(name)))
+		}
+		canonicalHeaders.WriteByte('\n')
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + t.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+t.cfg.SecretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, t.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.cfg.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func fileSHA256(filePath string) (string, int64, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open local file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read local file %s: %w", filePath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapeSegment applies the URI encoding rules from the SigV4 specification.
+func s3EscapeSegment(segment string) string {
+	var b strings.Builder
+	for _, c := range []byte(segment) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func contentTypeByName(fileName string) string {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".pdf"):
+		return "application/pdf"
+	case strings.HasSuffix(lower, ".xlsx"):
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case strings.HasSuffix(lower, ".csv"):
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,74 @@
+// Package reportupload publishes generated report files to a configured storage target.
+package reportupload
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+// Target uploads a local file into a named folder and returns a link for the report.
+type Target interface {
+	// Name returns a human-readable target label for operator messages.
+	Name() string
+	// Upload stores localFilePath as folderName/fileName and returns a link or path to the stored file.
+	Upload(ctx context.Context, localFilePath string, fileName string, folderName string) (string, error)
+}
+
+// New builds the upload target selected in cfg. It returns nil when uploads are disabled.
+func New(cfg config.ReportUploadConfig) (Target, error) {
+	switch cfg.NormalizedTarget() {
+	case config.ReportUploadTargetNone:
+		return nil, nil
+	case config.ReportUploadTargetLocal:
+		return NewLocalFolderTarget(cfg.LocalDir, cfg.PublicBaseURL)
+	case config.ReportUploadTargetWebDAV:
+		return NewWebDAVTarget(cfg.WebDAVURL, cfg.WebDAVUser, cfg.WebDAVPassword, cfg.PublicBaseURL)
+	case config.ReportUploadTargetS3:
+		return NewS3Target(S3Config{
+			Endpoint:      cfg.S3Endpoint,
+			Region:        cfg.S3Region,
+			Bucket:        cfg.S3Bucket,
+			AccessKey:     cfg.S3AccessKey,
+			SecretKey:     cfg.S3SecretKey,
+			PathStyle:     cfg.S3PathStyle,
+			PublicBaseURL: cfg.PublicBaseURL,
+		})
+	default:
+		return NewGoogleDriveTarget(cfg.GDriveCredentialsPath, cfg.GDriveTokenPath), nil
+	}
+}
+
+// publicLink joins the object key onto baseURL, escaping each path segment.
+func publicLink(baseURL string, segments ...string) string {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		segment = strings.Trim(segment, "/")
+		if segment == "" {
+			continue
+		}
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+	return base + "/" + strings.Join(escaped, "/")
+}
+
+// objectKey builds a slash-separated storage key from a folder and file name.
+func objectKey(folderName string, fileName string) (string, error) {
+	name := strings.TrimSpace(fileName)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("некоректна назва файлу: %q", fileName)
+	}
+	folder := strings.Trim(strings.TrimSpace(folderName), "/")
+	if folder == "" {
+		return name, nil
+	}
+	if strings.Contains(folder, "..") {
+		return "", fmt.Errorf("некоректна назва папки: %q", folderName)
+	}
+	return path.Join(folder, name), nil
+}
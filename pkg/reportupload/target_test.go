@@ -0,0 +1,250 @@
+package reportupload
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+func writeTempReport(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp report: %v", err)
+	}
+	return path
+}
+
+func TestNew_SelectsTargetByConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      config.ReportUploadConfig
+		wantName string
+		wantNil  bool
+		wantErr  bool
+	}{
+		{name: "unselected", cfg: config.ReportUploadConfig{}, wantNil: true},
+		{name: "google drive", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetGoogleDrive}, wantName: "Google Drive"},
+		{name: "disabled", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetNone}, wantNil: true},
+		{name: "local", cfg: config.ReportUploadConfig{Target: "smb", LocalDir: t.TempDir()}, wantName: "спільна папка"},
+		{name: "local without dir", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetLocal}, wantErr: true},
+		{name: "webdav", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetWebDAV, WebDAVURL: "https://dav.example/files"}, wantName: "WebDAV"},
+		{name: "webdav bad url", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetWebDAV, WebDAVURL: "ftp://dav"}, wantErr: true},
+		{name: "s3", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetS3, S3Endpoint: "https://s3.example", S3Bucket: "reports", S3AccessKey: "ak", S3SecretKey: "sk"}, wantName: "S3"},
+		{name: "s3 without bucket", cfg: config.ReportUploadConfig{Target: config.ReportUploadTargetS3, S3Endpoint: "https://s3.example", S3AccessKey: "ak", S3SecretKey: "sk"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			target, err := New(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("New() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.wantNil {
+				if target != nil {
+					t.Fatalf("New() = %T, want nil", target)
+				}
+				return
+			}
+			if target == nil || target.Name() != tt.wantName {
+				t.Fatalf("New() target = %v, want %q", target, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestLocalFolderTarget_CopiesIntoFolder(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	target, err := NewLocalFolderTarget(root, "")
+	if err != nil {
+		t.Fatalf("NewLocalFolderTarget() error = %v", err)
+	}
+
+	src := writeTempReport(t, "pdf-body")
+	link, err := target.Upload(context.Background(), src, "object_1.pdf", "Об'єкти МОСТП")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	want := filepath.Join(root, "Об'єкти МОСТП", "object_1.pdf")
+	if link != want {
+		t.Fatalf("Upload() link = %q, want %q", link, want)
+	}
+	got, err := os.ReadFile(want)
+	if err != nil || string(got) != "pdf-body" {
+		t.Fatalf("copied file = %q, %v", got, err)
+	}
+}
+
+func TestLocalFolderTarget_RejectsPathTraversal(t *testing.T) {
+	t.Parallel()
+
+	target, _ := NewLocalFolderTarget(t.TempDir(), "")
+	src := writeTempReport(t, "x")
+	if _, err := target.Upload(context.Background(), src, "../evil.pdf", "reports"); err == nil {
+		t.Fatal("Upload() with traversal file name error = nil, want error")
+	}
+	if _, err := target.Upload(context.Background(), src, "ok.pdf", "../reports"); err == nil {
+		t.Fatal("Upload() with traversal folder error = nil, want error")
+	}
+}
+
+func TestWebDAVTarget_CreatesCollectionAndPutsFile(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "ops" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPut {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+		}
+		mu.Unlock()
+		switch r.Method {
+		case "MKCOL":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	target, err := NewWebDAVTarget(server.URL+"/dav/", "ops", "secret", "https://files.example/share")
+	if err != nil {
+		t.Fatalf("NewWebDAVTarget() error = %v", err)
+	}
+	src := writeTempReport(t, "webdav-body")
+
+	link, err := target.Upload(context.Background(), src, "Звіт 1.xlsx", "Звіти")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if link != "https://files.example/share/%D0%97%D0%B2%D1%96%D1%82%D0%B8/%D0%97%D0%B2%D1%96%D1%82%201.xlsx" {
+		t.Fatalf("Upload() link = %q", link)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	wantCalls := []string{"MKCOL /dav/Звіти/", "PUT /dav/Звіти/Звіт 1.xlsx"}
+	if strings.Join(calls, "|") != strings.Join(wantCalls, "|") {
+		t.Fatalf("calls = %v, want %v", calls, wantCalls)
+	}
+	if body != "webdav-body" {
+		t.Fatalf("PUT body = %q", body)
+	}
+}
+
+func TestWebDAVTarget_ReportsPutFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusInsufficientStorage)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	target, _ := NewWebDAVTarget(server.URL, "", "", "")
+	if _, err := target.Upload(context.Background(), writeTempReport(t, "x"), "a.pdf", "f"); err == nil {
+		t.Fatal("Upload() error = nil, want PUT failure")
+	}
+}
+
+func TestS3Target_SignsPathStylePut(t *testing.T) {
+	t.Parallel()
+
+	type captured struct {
+		method, path, auth, sha, date, body string
+	}
+	got := make(chan captured, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		got <- captured{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			auth:   r.Header.Get("Authorization"),
+			sha:    r.Header.Get("X-Amz-Content-Sha256"),
+			date:   r.Header.Get("X-Amz-Date"),
+			body:   string(data),
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	target, err := NewS3Target(S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-central-1",
+		Bucket:    "reports",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Target() error = %v", err)
+	}
+	target.now = func() time.Time { return time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC) }
+
+	link, err := target.Upload(context.Background(), writeTempReport(t, "s3-body"), "obj 1.pdf", "casl")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	req := <-got
+	if req.method != http.MethodPut || req.path != "/reports/casl/obj%201.pdf" {
+		t.Fatalf("request = %s %s", req.method, req.path)
+	}
+	if !strings.HasPrefix(req.auth, "AWS4-HMAC-SHA256 Credential=AKID/20260301/eu-central-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Fatalf("Authorization = %q", req.auth)
+	}
+	if req.sha != sha256Hex([]byte("s3-body")) || req.date != "20260301T100000Z" || req.body != "s3-body" {
+		t.Fatalf("captured = %+v", req)
+	}
+	if link != server.URL+"/reports/casl/obj%201.pdf" {
+		t.Fatalf("Upload() link = %q", link)
+	}
+}
+
+func TestS3Target_VirtualHostedURL(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewS3Target(S3Config{Endpoint: "https://s3.example.com", Bucket: "reports", AccessKey: "a", SecretKey: "b"})
+	if err != nil {
+		t.Fatalf("NewS3Target() error = %v", err)
+	}
+	if got := target.objectURL("dir/a b.pdf").String(); got != "https://reports.s3.example.com/dir/a%20b.pdf" {
+		t.Fatalf("objectURL() = %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package reportupload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// WebDAVTarget uploads files with HTTP PUT and creates folders with MKCOL.
+type WebDAVTarget struct {
+	baseURL       string
+	user          string
+	password      string
+	publicBaseURL string
+	client        *http.Client
+}
+
+func NewWebDAVTarget(baseURL string, user string, password string, publicBaseURL string) (*WebDAVTarget, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("не вказано адресу WebDAV")
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("некоректна адреса WebDAV: %q", baseURL)
+	}
+	return &WebDAVTarget{
+		baseURL:       baseURL,
+		user:          strings.TrimSpace(user),
+		password:      password,
+		publicBaseURL: strings.TrimSpace(publicBaseURL),
+		client:        &http.Client{Timeout: 2 * time.Minute},
+	}, nil
+}
+
+func (t *WebDAVTarget) Name() string {
+	return "WebDAV"
+}
+
+func (t *WebDAVTarget) Upload(ctx context.Context, localFilePath string, fileName string, folderName string) (string, error) {
+	key, err := objectKey(folderName, fileName)
+	if err != nil {
+		return "", err
+	}
+	segments := strings.Split(key, "/")
+
+	for i := 1; i < len(segments); i++ {
+		if err := t.makeCollection(ctx, publicLink(t.baseURL, segments[:i]...)); err != nil {
+			return "", err
+		}
+	}
+
+	f, err := os.Open(localFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open local file %s: %w", localFilePath, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat local file %s: %w", localFilePath, err)
+	}
+
+	fileURL := publicLink(t.baseURL, segments...)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fileURL, f)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	t.authorize(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("WebDAV PUT failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("WebDAV PUT %s: %s", fileURL, resp.Status)
+	}
+
+	if t.publicBaseURL != "" {
+		return publicLink(t.publicBaseURL, segments...), nil
+	}
+	return fileURL, nil
+}
+
+func (t *WebDAVTarget) makeCollection(ctx context.Context, collectionURL string) error {
+	req, err := http.NewRequestWithContext(ctx, "MKCOL", collectionURL+"/", nil)
+	if err != nil {
+		return err
+	}
+	t.authorize(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("WebDAV MKCOL failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode == http.StatusMethodNotAllowed:
+		// Колекція вже існує.
+		return nil
+	default:
+		return fmt.Errorf("WebDAV MKCOL %s: %s", collectionURL, resp.Status)
+	}
+}
+
+func (t *WebDAVTarget) authorize(req *http.Request) {
+	if t.user != "" {
+		req.SetBasicAuth(t.user, t.password)
+	}
+}
@@ -0,0 +1,140 @@
+package testsupervision
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// Sources — джерела з контролем тестів у порядку показу.
+var Sources = []contracts.FrontendSource{
+	contracts.FrontendSourceBridge,
+	contracts.FrontendSourcePhoenix,
+	contracts.FrontendSourceCASL,
+}
+
+// View — вибірка для панелі прострочених тестів.
+type View int
+
+const (
+	ViewOverdue View = iota
+	ViewAll
+	ViewNoData
+)
+
+// Views — порядок вибірок у UI.
+var Views = []View{ViewOverdue, ViewAll, ViewNoData}
+
+// Label повертає назву вибірки для UI.
+func (v View) Label() string {
+	switch v {
+	case ViewAll:
+		return "Усі під контролем"
+	case ViewNoData:
+		return "Без даних про тест"
+	default:
+		return "Прострочені"
+	}
+}
+
+// Filter відбирає очікування для вибірки view і пошукового запиту query.
+func Filter(expectations []Expectation, view View, query string, now time.Time) []Expectation {
+	query = strings.ToLower(strings.TrimSpace(query))
+	result := make([]Expectation, 0, len(expectations))
+	for _, expectation := range expectations {
+		switch view {
+		case ViewOverdue:
+			if !expectation.Overdue(now) {
+				continue
+			}
+		case ViewNoData:
+			if expectation.HasData() {
+				continue
+			}
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(expectation.ObjectNumber), query) &&
+			!strings.Contains(strings.ToLower(expectation.ObjectName), query) {
+			continue
+		}
+		result = append(result, expectation)
+	}
+	SortForDashboard(result, now)
+	return result
+}
+
+// Summary повертає короткий підсумок для рядка стану.
+func Summary(expectations []Expectation, now time.Time) string {
+	overdue, noData := 0, 0
+	for _, expectation := range expectations {
+		switch {
+		case !expectation.HasData():
+			noData++
+		case expectation.Overdue(now):
+			overdue++
+		}
+	}
+	return fmt.Sprintf("Під контролем: %d | прострочено: %d | без даних: %d", len(expectations), overdue, noData)
+}
+
+// StateLabel описує стан очікування на момент now.
+func (e Expectation) StateLabel(now time.Time) string {
+	switch {
+	case !e.HasData():
+		return "немає даних"
+	case e.Overdue(now):
+		return "ПРОСТРОЧЕНО"
+	case now.After(e.Expected):
+		return "очікування (допуск)"
+	default:
+		return "норма"
+	}
+}
+
+// RowValues повертає значення колонок панелі: стан, джерело, №, назва,
+// період, останній тест, очікувався, прострочено на.
+func (e Expectation) RowValues(now time.Time) []string {
+	lastTest, expected, overdue := "—", "—", ""
+	if e.HasData() {
+		lastTest = e.LastTest.Format("02.01.2006 15:04")
+		expected = e.Expected.Format("02.01.2006 15:04")
+	}
+	if e.Overdue(now) {
+		overdue = FormatDuration(e.OverdueBy(now))
+	}
+	return []string{
+		e.StateLabel(now),
+		e.Source.DisplayName(),
+		e.ObjectNumber,
+		e.ObjectName,
+		FormatDuration(e.Period),
+		lastTest,
+		expected,
+		overdue,
+	}
+}
+
+// DashboardHeaders — заголовки колонок для RowValues.
+var DashboardHeaders = []string{"Стан", "Джерело", "№", "Назва", "Період", "Останній тест", "Очікувався", "Прострочено на"}
+
+// Label перелічує контрольовані джерела з їхнім допуском.
+func (s Settings) Label() string {
+	parts := make([]string, 0, len(s))
+	for _, source := range Sources {
+		value, ok := s[source]
+		if !ok || !value.Enabled {
+			continue
+		}
+		grace := "без допуску"
+		if value.Grace >= time.Minute {
+			grace = "допуск " + FormatDuration(value.Grace)
+		}
+		parts = append(parts, source.DisplayName()+" — "+grace)
+	}
+	if len(parts) == 0 {
+		return "Контроль тестів вимкнено для всіх джерел."
+	}
+	return "Під контролем: " + strings.Join(parts, ", ") + "."
+}
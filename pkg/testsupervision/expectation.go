@@ -0,0 +1,148 @@
+// Package testsupervision локально контролює періодичні тести об'єктів:
+// за періодом тесту та часом останнього тесту рахує, коли очікується наступний,
+// і формує синтетичну тривогу «пропущено тест», якщо він не надійшов вчасно.
+// Контроль не залежить від того, чи вміє це робити саме джерело.
+package testsupervision
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// SourceSettings — контроль тестів для одного джерела.
+type SourceSettings struct {
+	Enabled bool
+	Grace   time.Duration
+}
+
+// Settings задає контроль тестів по джерелах; відсутнє джерело не контролюється.
+type Settings map[contracts.FrontendSource]SourceSettings
+
+// SettingsFromConfig переводить налаштування з преференсів у Settings.
+func SettingsFromConfig(cfg config.TestSupervisionConfig) Settings {
+	source := func(value config.TestSupervisionSourceConfig) SourceSettings {
+		return SourceSettings{Enabled: value.Enabled, Grace: time.Duration(value.GraceMinutes) * time.Minute}
+	}
+	return Settings{
+		contracts.FrontendSourceBridge:  source(cfg.MIST),
+		contracts.FrontendSourcePhoenix: source(cfg.Phoenix),
+		contracts.FrontendSourceCASL:    source(cfg.CASL),
+	}
+}
+
+// Enabled повідомляє, чи контролюється хоча б одне джерело.
+func (s Settings) Enabled() bool {
+	for _, source := range s {
+		if source.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Expectation — очікуваний тест одного об'єкта.
+type Expectation struct {
+	ObjectID     int
+	ObjectNumber string
+	ObjectName   string
+	Source       contracts.FrontendSource
+	Period       time.Duration
+	Grace        time.Duration
+	LastTest     time.Time // нульовий, якщо джерело не знає часу останнього тесту
+	Expected     time.Time // LastTest + Period
+	Deadline     time.Time // Expected + Grace
+}
+
+// HasData повідомляє, чи відомий час останнього тесту.
+func (e Expectation) HasData() bool {
+	return !e.LastTest.IsZero()
+}
+
+// Overdue повідомляє, чи прострочено тест на момент now.
+func (e Expectation) Overdue(now time.Time) bool {
+	return e.HasData() && now.After(e.Deadline)
+}
+
+// OverdueBy повертає, наскільки тест прострочено відносно очікуваного часу.
+func (e Expectation) OverdueBy(now time.Time) time.Duration {
+	if !e.Overdue(now) {
+		return 0
+	}
+	return now.Sub(e.Expected)
+}
+
+// key ідентифікує конкретний пропуск: новий тест дає новий ключ.
+func (e Expectation) key() string {
+	return strconv.Itoa(e.ObjectID) + "@" + e.LastTest.UTC().Format(time.RFC3339)
+}
+
+// TestPeriod повертає період тесту об'єкта або 0, якщо контроль тесту вимкнено.
+// Хвилинний TestTime точніший за AutoTestHours, тож має пріоритет.
+func TestPeriod(object models.Object) time.Duration {
+	if object.TestControl <= 0 {
+		return 0
+	}
+	if object.TestTime > 0 {
+		return time.Duration(object.TestTime) * time.Minute
+	}
+	if object.AutoTestHours > 0 {
+		return time.Duration(object.AutoTestHours) * time.Hour
+	}
+	return 0
+}
+
+// Evaluate будує очікування для об'єктів із контрольованих джерел, що мають
+// період тесту. Заблоковані об'єкти пропускаються: їх не моніторять.
+func Evaluate(objects []models.Object, settings Settings) []Expectation {
+	result := make([]Expectation, 0)
+	for _, object := range objects {
+		source := contracts.DetectFrontendSourceByObjectID(object.ID)
+		sourceSettings, ok := settings[source]
+		if !ok || !sourceSettings.Enabled {
+			continue
+		}
+		if object.MonitoringStatusValue() == models.MonitoringStatusBlocked {
+			continue
+		}
+		period := TestPeriod(object)
+		if period <= 0 {
+			continue
+		}
+		expectation := Expectation{
+			ObjectID:     object.ID,
+			ObjectNumber: ids.ObjectDisplayNumber(object),
+			ObjectName:   object.Name,
+			Source:       source,
+			Period:       period,
+			Grace:        sourceSettings.Grace,
+			LastTest:     object.LastTestTime,
+		}
+		if expectation.HasData() {
+			expectation.Expected = expectation.LastTest.Add(period)
+			expectation.Deadline = expectation.Expected.Add(sourceSettings.Grace)
+		}
+		result = append(result, expectation)
+	}
+	return result
+}
+
+// SortForDashboard впорядковує очікування: спершу найбільш прострочені,
+// далі найближчі очікувані, у кінці об'єкти без даних.
+func SortForDashboard(expectations []Expectation, now time.Time) {
+	sort.SliceStable(expectations, func(i, j int) bool {
+		left, right := expectations[i], expectations[j]
+		if left.HasData() != right.HasData() {
+			return left.HasData()
+		}
+		if leftOverdue, rightOverdue := left.Overdue(now), right.Overdue(now); leftOverdue != rightOverdue {
+			return leftOverdue
+		}
+		return left.Expected.Before(right.Expected)
+	})
+}
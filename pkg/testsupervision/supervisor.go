@@ -0,0 +1,213 @@
+package testsupervision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ObjectSource дає актуальний список об'єктів для перерахунку очікувань.
+type ObjectSource interface {
+	GetObjectsContext(ctx context.Context) []models.Object
+}
+
+// Ack — підтвердження оператором тривоги «пропущено тест».
+type Ack struct {
+	User string
+	Note string
+	At   time.Time
+}
+
+// Supervisor тримає очікування тестів і додає тривоги про пропуски у стрічку.
+// Тривоги мають ID з ids.MissedTestAlarmIDNamespace*; підтвердження діє до
+// наступного тесту об'єкта і живе лише в пам'яті процесу.
+type Supervisor struct {
+	settings Settings
+	now      func() time.Time
+
+	mu           sync.Mutex
+	expectations []Expectation
+	observedAt   time.Time
+	acks         map[string]Ack
+}
+
+// NewSupervisor створює Supervisor. Очікування оновлюються з кожного списку
+// об'єктів (Observe) або примусово через Scan, без окремих запитів у стрічці тривог.
+func NewSupervisor(settings Settings) *Supervisor {
+	return &Supervisor{
+		settings: settings,
+		now:      time.Now,
+		acks:     make(map[string]Ack),
+	}
+}
+
+// Settings повертає налаштування контролю.
+func (s *Supervisor) Settings() Settings {
+	if s == nil {
+		return nil
+	}
+	return s.settings
+}
+
+// Observe перераховує очікування за свіжим списком об'єктів.
+func (s *Supervisor) Observe(objects []models.Object) {
+	if s == nil || len(objects) == 0 {
+		return
+	}
+	expectations := Evaluate(objects, s.settings)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expectations = expectations
+	s.observedAt = s.now()
+
+	// Підтвердження старих пропусків більше не потрібні.
+	current := make(map[string]struct{}, len(expectations))
+	for _, expectation := range expectations {
+		current[expectation.key()] = struct{}{}
+	}
+	for key := range s.acks {
+		if _, ok := current[key]; !ok {
+			delete(s.acks, key)
+		}
+	}
+}
+
+// Scan примусово перечитує об'єкти з source, напр. за кнопкою «Оновити».
+// Неповний список (перерваний ctx) не застосовується.
+func (s *Supervisor) Scan(ctx context.Context, source ObjectSource) {
+	if s == nil || source == nil {
+		return
+	}
+	objects := source.GetObjectsContext(ctx)
+	if ctx.Err() == nil {
+		s.Observe(objects)
+	}
+}
+
+// Expectations повертає копію поточних очікувань і час їх розрахунку.
+func (s *Supervisor) Expectations() ([]Expectation, time.Time) {
+	if s == nil {
+		return nil, time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Expectation(nil), s.expectations...), s.observedAt
+}
+
+// ApplyTestSupervision додає до alarms тривоги про прострочені тести.
+// Якщо джерело вже показує для об'єкта втрату зв'язку, дубль не додається.
+func (s *Supervisor) ApplyTestSupervision(alarms []models.Alarm) []models.Alarm {
+	if s == nil {
+		return alarms
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.expectations) == 0 {
+		return alarms
+	}
+	now := s.now()
+
+	covered := make(map[int]struct{})
+	for _, alarm := range alarms {
+		if alarm.Type == models.AlarmOffline {
+			covered[alarm.ObjectID] = struct{}{}
+		}
+	}
+	for _, expectation := range s.expectations {
+		if !expectation.Overdue(now) {
+			continue
+		}
+		if _, ok := covered[expectation.ObjectID]; ok {
+			continue
+		}
+		if _, acked := s.acks[expectation.key()]; acked {
+			continue
+		}
+		alarms = append(alarms, missedTestAlarm(expectation, now))
+	}
+	return alarms
+}
+
+func missedTestAlarm(expectation Expectation, now time.Time) models.Alarm {
+	lastTest := expectation.LastTest.Format("02.01.2006 15:04")
+	return models.Alarm{
+		ID:           AlarmID(expectation),
+		ObjectID:     expectation.ObjectID,
+		ObjectNumber: expectation.ObjectNumber,
+		ObjectName:   expectation.ObjectName,
+		Time:         expectation.Deadline,
+		Details: fmt.Sprintf(
+			"Не надійшов періодичний тест: очікувався о %s (період %s), прострочено на %s. Останній тест: %s",
+			expectation.Expected.Format("02.01.2006 15:04"),
+			FormatDuration(expectation.Period),
+			FormatDuration(expectation.OverdueBy(now)),
+			lastTest,
+		),
+		Type:           models.AlarmTestMissed,
+		VisualSeverity: models.VisualSeverityWarning,
+		CanProcess:     true,
+	}
+}
+
+// AlarmID повертає стабільний ID тривоги для пропуску тесту.
+func AlarmID(expectation Expectation) int {
+	return ids.StableMissedTestAlarmID(expectation.key())
+}
+
+// IsMissedTestAlarmID повідомляє, чи належить ID тривозі про пропущений тест.
+func IsMissedTestAlarmID(id int) bool {
+	return ids.IsMissedTestAlarmID(id)
+}
+
+// Acknowledge підтверджує тривогу alarmID; вона зникне до наступного пропуску.
+func (s *Supervisor) Acknowledge(alarmID int, user string, note string) error {
+	if s == nil {
+		return errors.New("testsupervision: supervisor is not configured")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, expectation := range s.expectations {
+		if !expectation.HasData() || AlarmID(expectation) != alarmID {
+			continue
+		}
+		ack := Ack{User: strings.TrimSpace(user), Note: strings.TrimSpace(note), At: s.now()}
+		s.acks[expectation.key()] = ack
+		log.Info().
+			Int("objectID", expectation.ObjectID).
+			Str("user", ack.User).
+			Str("note", ack.Note).
+			Msg("testsupervision: пропуск тесту підтверджено")
+		return nil
+	}
+	return fmt.Errorf("тривогу про пропуск тесту %d не знайдено", alarmID)
+}
+
+// FormatDuration показує тривалість у вигляді «2 год 15 хв» / «3 д 4 год».
+func FormatDuration(value time.Duration) string {
+	value = value.Round(time.Minute)
+	if value < time.Minute {
+		return "менше хвилини"
+	}
+	days := int(value / (24 * time.Hour))
+	hours := int(value % (24 * time.Hour) / time.Hour)
+	minutes := int(value % time.Hour / time.Minute)
+	parts := make([]string, 0, 3)
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%d д", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d год", hours))
+	}
+	if minutes > 0 && days == 0 {
+		parts = append(parts, fmt.Sprintf("%d хв", minutes))
+	}
+	return strings.Join(parts, " ")
+}
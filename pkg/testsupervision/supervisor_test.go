@@ -0,0 +1,169 @@
+package testsupervision
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+type stubObjectSource struct {
+	objects []models.Object
+	calls   int
+}
+
+func (s *stubObjectSource) GetObjectsContext(context.Context) []models.Object {
+	s.calls++
+	return s.objects
+}
+
+func newTestSupervisor(now time.Time) *Supervisor {
+	supervisor := NewSupervisor(SettingsFromConfig(config.TestSupervisionConfig{
+		Phoenix: config.TestSupervisionSourceConfig{Enabled: true, GraceMinutes: 15},
+		CASL:    config.TestSupervisionSourceConfig{Enabled: true, GraceMinutes: 0},
+	}))
+	supervisor.now = func() time.Time { return now }
+	return supervisor
+}
+
+func TestTestPeriodPrefersMinutes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		object models.Object
+		want   time.Duration
+	}{
+		{object: models.Object{TestControl: 0, TestTime: 60, AutoTestHours: 1}, want: 0},
+		{object: models.Object{TestControl: 1, TestTime: 90, AutoTestHours: 1}, want: 90 * time.Minute},
+		{object: models.Object{TestControl: 1, AutoTestHours: 24}, want: 24 * time.Hour},
+		{object: models.Object{TestControl: 1}, want: 0},
+	}
+	for _, tc := range cases {
+		if got := TestPeriod(tc.object); got != tc.want {
+			t.Errorf("TestPeriod(%+v) = %v, want %v", tc.object, got, tc.want)
+		}
+	}
+}
+
+func TestEvaluateHonoursSourceSwitchAndBlockedObjects(t *testing.T) {
+	t.Parallel()
+
+	lastTest := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	objects := []models.Object{
+		{ID: 100, Name: "МІСТ", TestControl: 1, TestTime: 60, LastTestTime: lastTest},
+		{ID: ids.PhoenixObjectIDNamespaceStart + 1, Name: "Phoenix", TestControl: 1, TestTime: 60, LastTestTime: lastTest},
+		{ID: ids.PhoenixObjectIDNamespaceStart + 2, Name: "Blocked", TestControl: 1, TestTime: 60, LastTestTime: lastTest, MonitoringStatus: models.MonitoringStatusBlocked},
+		{ID: ids.CASLObjectIDNamespaceStart + 1, Name: "CASL", TestControl: 1, AutoTestHours: 24},
+	}
+	expectations := Evaluate(objects, newTestSupervisor(lastTest).settings)
+	if len(expectations) != 2 {
+		t.Fatalf("expectations = %+v", expectations)
+	}
+	phoenix := expectations[0]
+	if phoenix.Source != contracts.FrontendSourcePhoenix || !phoenix.Expected.Equal(lastTest.Add(time.Hour)) || !phoenix.Deadline.Equal(lastTest.Add(75*time.Minute)) {
+		t.Fatalf("phoenix expectation = %+v", phoenix)
+	}
+	if casl := expectations[1]; casl.HasData() || casl.Overdue(lastTest.Add(100*time.Hour)) {
+		t.Fatalf("object without last test must not be overdue: %+v", casl)
+	}
+}
+
+func TestApplyTestSupervisionRaisesAndAcknowledges(t *testing.T) {
+	t.Parallel()
+
+	lastTest := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	phoenixID := ids.PhoenixObjectIDNamespaceStart + 1
+	objects := []models.Object{{ID: phoenixID, Name: "Аптека", DisplayNumber: "0042", TestControl: 1, TestTime: 60, LastTestTime: lastTest}}
+
+	withinGrace := newTestSupervisor(lastTest.Add(70 * time.Minute))
+	withinGrace.Observe(objects)
+	if alarms := withinGrace.ApplyTestSupervision(nil); len(alarms) != 0 {
+		t.Fatalf("alarm raised within grace: %+v", alarms)
+	}
+
+	supervisor := newTestSupervisor(lastTest.Add(2 * time.Hour))
+	source := &stubObjectSource{objects: objects}
+	supervisor.Scan(context.Background(), source)
+	alarms := supervisor.ApplyTestSupervision([]models.Alarm{{ID: 1, ObjectID: 7, Type: models.AlarmFire}})
+	if len(alarms) != 2 {
+		t.Fatalf("alarms = %+v", alarms)
+	}
+	missed := alarms[1]
+	if missed.Type != models.AlarmTestMissed || missed.ObjectID != phoenixID || missed.ObjectNumber != "0042" || !IsMissedTestAlarmID(missed.ID) {
+		t.Fatalf("missed test alarm = %+v", missed)
+	}
+	if !strings.Contains(missed.Details, "прострочено на 1 год") {
+		t.Fatalf("details = %q", missed.Details)
+	}
+
+	// Поки джерело показує втрату зв'язку, дубль не потрібен.
+	if alarms := supervisor.ApplyTestSupervision([]models.Alarm{{ID: 2, ObjectID: phoenixID, Type: models.AlarmOffline}}); len(alarms) != 1 {
+		t.Fatalf("offline alarm must cover missed test: %+v", alarms)
+	}
+
+	if err := supervisor.Acknowledge(missed.ID, "оператор", "на об'єкті ремонт"); err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	if alarms := supervisor.ApplyTestSupervision(nil); len(alarms) != 0 {
+		t.Fatalf("acknowledged alarm is still raised: %+v", alarms)
+	}
+
+	// Новий тест і новий пропуск дають нову тривогу.
+	objects[0].LastTestTime = lastTest.Add(30 * time.Minute)
+	supervisor.now = func() time.Time { return lastTest.Add(3 * time.Hour) }
+	supervisor.Scan(context.Background(), source)
+	if source.calls != 2 {
+		t.Fatalf("Scan must reread objects, calls = %d", source.calls)
+	}
+	if alarms := supervisor.ApplyTestSupervision(nil); len(alarms) != 1 || alarms[0].ID == missed.ID {
+		t.Fatalf("new miss must raise a new alarm: %+v", alarms)
+	}
+}
+
+func TestFilterAndSummary(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	expectations := []Expectation{
+		{ObjectID: 1, ObjectNumber: "10", ObjectName: "Склад", LastTest: now.Add(-3 * time.Hour), Expected: now.Add(-2 * time.Hour), Deadline: now.Add(-time.Hour)},
+		{ObjectID: 2, ObjectNumber: "20", ObjectName: "Магазин", LastTest: now.Add(-time.Hour), Expected: now.Add(time.Hour), Deadline: now.Add(2 * time.Hour)},
+		{ObjectID: 3, ObjectNumber: "30", ObjectName: "Офіс"},
+	}
+	if got := Filter(expectations, ViewOverdue, "", now); len(got) != 1 || got[0].ObjectID != 1 {
+		t.Fatalf("overdue = %+v", got)
+	}
+	if got := Filter(expectations, ViewNoData, "", now); len(got) != 1 || got[0].ObjectID != 3 {
+		t.Fatalf("no data = %+v", got)
+	}
+	if got := Filter(expectations, ViewAll, "маг", now); len(got) != 1 || got[0].ObjectID != 2 {
+		t.Fatalf("search = %+v", got)
+	}
+	all := Filter(expectations, ViewAll, "", now)
+	if len(all) != 3 || all[0].ObjectID != 1 || all[2].ObjectID != 3 {
+		t.Fatalf("dashboard order = %+v", all)
+	}
+	if got := Summary(expectations, now); got != "Під контролем: 3 | прострочено: 1 | без даних: 1" {
+		t.Fatalf("Summary() = %q", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	cases := map[time.Duration]string{
+		20 * time.Second:              "менше хвилини",
+		75 * time.Minute:              "1 год 15 хв",
+		26*time.Hour + 10*time.Minute: "1 д 2 год",
+		48 * time.Hour:                "2 д",
+	}
+	for value, want := range cases {
+		if got := FormatDuration(value); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", value, got, want)
+		}
+	}
+}
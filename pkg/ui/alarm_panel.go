@@ -4,6 +4,7 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 	"obj_catalog_fyne_v3/pkg/usecases"
 )
@@ -31,6 +33,8 @@ type AlarmPanelWidget struct {
 	List          *widget.List
 	listData      binding.UntypedList
 	SourceSelect  *widget.Select
+	PultSelect    *widget.Select
+	GroupSelect   *widget.Select
 	Data          contracts.DataProvider
 	ViewModel     *viewmodels.AlarmListViewModel
 	CaseHistoryVM *viewmodels.WorkAreaCaseHistoryViewModel
@@ -41,12 +45,18 @@ type AlarmPanelWidget struct {
 	mutex                 sync.RWMutex
 	isRefreshing          bool
 	currentSource         string
+	currentPult           string
+	currentGroup          string
+	pinnedIDs             map[int]struct{}
 	selectedIndex         int
 	selectedID            int
 	lastClickTime         time.Time
 	takeBtn               *widget.Button
 	processBtn            *widget.Button
 	responseBtn           *widget.Button
+	incidentBtn           *widget.Button
+	stormLabel            *widget.Label
+	incidents             map[int][]models.Alarm
 	lastKnownIDs          map[int]struct{}
 	CaseHistoryTitle      *widget.Label
 	CaseHistoryAccordion  *widget.Accordion
@@ -71,6 +81,16 @@ type AlarmPanelWidget struct {
 	TitleText          *canvas.Text
 	lastFontSize       float32
 	listWidthGuide     *canvas.Rectangle
+
+	// Groups — спільні групи об'єктів; тривоги об'єктів зі списку
+	// спостереження закріплюються нагорі.
+	Groups *objectgroups.Registry
+
+	// Correlator групує пов'язані тривоги в інциденти; у списку інцидент
+	// згортається до рядка головної тривоги. OnProcessIncident отримує всі
+	// тривоги вибраного інциденту та всі активні тривоги.
+	Correlator        *usecases.AlarmCorrelator
+	OnProcessIncident func(incident []models.Alarm, active []models.Alarm)
 }
 
 // NewAlarmPanelWidget створює панель тривог
@@ -81,6 +101,7 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 		CaseHistoryVM: viewmodels.NewWorkAreaCaseHistoryViewModel(),
 		listData:      binding.NewUntypedList(),
 		currentSource: viewmodels.ObjectSourceAll,
+		currentPult:   viewmodels.ObjectPultAll,
 		selectedIndex: -1,
 		lastKnownIDs:  make(map[int]struct{}),
 	}
@@ -103,11 +124,33 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 	panel.SourceSelect.SetSelected(panel.SourceSelect.Options[0])
 	panel.SourceSelect.PlaceHolder = "Джерело"
 
+	// Фільтр пультів з'являється лише коли CASL обслуговує кілька пультів.
+	panel.PultSelect = widget.NewSelect(nil, func(selected string) {
+		panel.mutex.Lock()
+		panel.currentPult = viewmodels.NormalizeObjectPultFilter(selected)
+		panel.mutex.Unlock()
+		panel.Refresh()
+	})
+	panel.PultSelect.PlaceHolder = "Пульт"
+	panel.PultSelect.Hide()
+
+	panel.GroupSelect = widget.NewSelect(nil, func(selected string) {
+		groupID := viewmodels.ObjectGroupIDByOption(panel.Groups.Groups(), selected)
+		panel.mutex.Lock()
+		panel.currentGroup = groupID
+		panel.mutex.Unlock()
+		panel.Refresh()
+	})
+	panel.GroupSelect.PlaceHolder = "Група"
+	panel.GroupSelect.Hide()
+
 	titleBg := canvas.NewRectangle(color.NRGBA{R: 100, G: 0, B: 0, A: 255})
 	titleContainer := container.NewStack(titleBg, container.NewPadded(panel.TitleText))
 	header := container.NewHBox(
 		titleContainer,
 		layout.NewSpacer(),
+		panel.GroupSelect,
+		panel.PultSelect,
 		panel.SourceSelect,
 	)
 
@@ -156,6 +199,8 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 			rowBg := rowColor
 			panel.mutex.RLock()
 			isSelected := panel.selectedID > 0 && panel.selectedID == alarm.ID
+			_, isPinned := panel.pinnedIDs[alarm.ID]
+			incident := panel.incidents[alarm.ID]
 			panel.mutex.RUnlock()
 			if isSelected {
 				rowBg = adjustAlarmRowColor(rowColor)
@@ -173,6 +218,12 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 				txt.TextStyle.Bold = false
 			}
 			txt.Text = formatAlarmListText(alarm)
+			if len(incident) > 1 {
+				txt.Text = "⛓ " + alarm.Incident + " | " + txt.Text
+			}
+			if isPinned {
+				txt.Text = "📌 " + txt.Text
+			}
 
 			if panel.lastFontSize > 0 {
 				txt.TextSize = panel.lastFontSize
@@ -248,6 +299,27 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 	})
 	panel.responseBtn.Disable()
 
+	panel.incidentBtn = widget.NewButton("Завершити інцидент", func() {
+		alarm, ok := panel.selectedAlarm()
+		if !ok || panel.OnProcessIncident == nil {
+			return
+		}
+		panel.mutex.RLock()
+		incident := slices.Clone(panel.incidents[alarm.ID])
+		active := slices.Clone(panel.AllAlarms)
+		panel.mutex.RUnlock()
+		if len(incident) > 1 {
+			panel.OnProcessIncident(incident, active)
+		}
+	})
+	panel.incidentBtn.Disable()
+
+	// Банер шторму тривог показується лише під час шторму.
+	panel.stormLabel = widget.NewLabel("")
+	panel.stormLabel.TextStyle = fyne.TextStyle{Bold: true}
+	panel.stormLabel.Wrapping = fyne.TextWrapWord
+	panel.stormLabel.Hide()
+
 	panel.CaseHistoryTitle = widget.NewLabel("Хронологія вибраної тривоги")
 	panel.CaseHistoryTitle.TextStyle = fyne.TextStyle{Bold: true}
 	panel.CaseHistoryTitle.Wrapping = fyne.TextWrapWord
@@ -261,15 +333,16 @@ func NewAlarmPanelWidget(provider contracts.DataProvider) *AlarmPanelWidget {
 	panel.CaseHistorySection.Hide()
 
 	actions := container.NewPadded(container.NewGridWithColumns(
-		3,
+		4,
 		panel.takeBtn,
 		panel.responseBtn,
 		panel.processBtn,
+		panel.incidentBtn,
 	))
 	body := container.NewBorder(nil, panel.CaseHistorySection, nil, nil, alarmsScroll)
 
 	panel.Container = container.NewBorder(
-		header,
+		container.NewVBox(header, panel.stormLabel),
 		actions, nil, nil,
 		body,
 	)
@@ -294,7 +367,7 @@ func (p *AlarmPanelWidget) refreshData() {
 	if p.ViewModel == nil {
 		p.ViewModel = viewmodels.NewAlarmListViewModel()
 	}
-	useCase := usecases.NewAlarmListUseCase(p.Data)
+	useCase := usecases.NewAlarmListUseCase(p.Correlator.Repository(p.Data))
 
 	p.mutex.Lock()
 	if p.isRefreshing {
@@ -316,7 +389,11 @@ func (p *AlarmPanelWidget) refreshData() {
 	if strings.TrimSpace(p.currentSource) != "" {
 		currentSource = p.currentSource
 	}
+	currentPult := viewmodels.NormalizeObjectPultFilter(p.currentPult)
+	currentGroup := p.currentGroup
 	p.mutex.RUnlock()
+	groups := p.Groups.Groups()
+	membership := p.Groups.Membership()
 
 	p.mutex.RLock()
 	lastKnown := make(map[int]struct{}, len(p.lastKnownIDs))
@@ -329,12 +406,19 @@ func (p *AlarmPanelWidget) refreshData() {
 		Alarms:         alarms,
 		LastKnownIDs:   lastKnown,
 		SelectedSource: currentSource,
+		SelectedPult:   currentPult,
+		GroupMembers:   membership.Members(currentGroup),
+		Watched:        membership.Watched(),
+		Storm:          p.Correlator.Storm(),
 	})
+	rows, incidents := viewmodels.CollapseAlarmIncidents(result.FilteredAlarms)
 
 	p.mutex.Lock()
 	p.AllAlarms = result.CurrentAlarms
-	p.CurrentAlarms = result.FilteredAlarms
+	p.CurrentAlarms = rows
 	p.lastKnownIDs = result.KnownIDs
+	p.pinnedIDs = result.Pinned
+	p.incidents = incidents
 	selectionCleared := false
 	selectedAlarm := models.Alarm{}
 	hasSelectedAlarm := false
@@ -373,11 +457,41 @@ func (p *AlarmPanelWidget) refreshData() {
 
 		if p.SourceSelect != nil {
 			options := viewmodels.BuildObjectSourceOptions(result.CountAll, result.CountBridge, result.CountPhoenix, result.CountCASL)
+			options = viewmodels.AppendObjectSourceInstanceOptions(options, result.InstanceCounts)
 			updateSelectPreservingValue(p.SourceSelect, options, currentSource)
 		}
+		if p.PultSelect != nil {
+			options := viewmodels.BuildObjectPultOptions(result.CountAll, result.PultCounts)
+			if len(options) == 0 {
+				p.mutex.Lock()
+				p.currentPult = viewmodels.ObjectPultAll
+				p.mutex.Unlock()
+				p.PultSelect.Hide()
+			} else {
+				updateSelectPreservingValue(p.PultSelect, options, currentPult)
+				p.PultSelect.Show()
+			}
+		}
+		if p.GroupSelect != nil {
+			if len(groups) == 0 {
+				p.GroupSelect.Hide()
+			} else {
+				updateSelectPreservingValue(p.GroupSelect, viewmodels.ObjectGroupOptions(groups), viewmodels.ObjectGroupOptionByID(groups, currentGroup))
+				p.GroupSelect.Show()
+			}
+		}
 
-		_ = SetUntypedList(p.listData, result.FilteredAlarms)
-		ensureJournalListMinWidth(p.listWidthGuide, alarmListTexts(result.FilteredAlarms), p.lastFontSize, fyne.TextStyle{Bold: true})
+		if p.stormLabel != nil {
+			p.stormLabel.SetText(result.StormBanner)
+			if result.StormBanner == "" {
+				p.stormLabel.Hide()
+			} else {
+				p.stormLabel.Show()
+			}
+		}
+
+		_ = SetUntypedList(p.listData, rows)
+		ensureJournalListMinWidth(p.listWidthGuide, alarmListTexts(rows), p.lastFontSize, fyne.TextStyle{Bold: true})
 		if p.List != nil {
 			p.List.Refresh()
 			p.List.ScrollToOffset(scrollOffset)
@@ -446,9 +560,18 @@ func formatAlarmListText(alarm models.Alarm) string {
 		displayText += "-" + strconv.Itoa(alarm.ZoneNumber)
 	}
 	displayText += " " + alarm.ObjectName
+	if pult := strings.TrimSpace(alarm.Pult); pult != "" {
+		displayText += " [" + pult + "]"
+	}
+	if instance := strings.TrimSpace(alarm.SourceInstance); instance != "" {
+		displayText += " [" + instance + "]"
+	}
 	if alarm.Details != "" {
 		displayText += " — " + alarm.Details
 	}
+	if tag := alarm.MaintenanceTag(); tag != "" {
+		displayText += " — " + tag
+	}
 	if alarm.IsInProgress {
 		operator := strings.TrimSpace(alarm.InProgressBy)
 		if operator == "" {
@@ -456,6 +579,9 @@ func formatAlarmListText(alarm models.Alarm) string {
 		}
 		displayText += " — У роботі: " + operator
 	}
+	if !alarm.Priority.IsZero() {
+		displayText += " — пріоритет " + strconv.Itoa(alarm.Priority.Score)
+	}
 	return displayText
 }
 
@@ -497,6 +623,16 @@ func (p *AlarmPanelWidget) updateSelectedAlarmActions(alarm models.Alarm) {
 			p.responseBtn.Disable()
 		}
 	}
+	if p.incidentBtn != nil {
+		p.mutex.RLock()
+		inIncident := len(p.incidents[alarm.ID]) > 1
+		p.mutex.RUnlock()
+		if inIncident {
+			p.incidentBtn.Enable()
+		} else {
+			p.incidentBtn.Disable()
+		}
+	}
 }
 
 func (p *AlarmPanelWidget) disableAlarmActions() {
@@ -513,6 +649,9 @@ func (p *AlarmPanelWidget) disableAlarmActions() {
 	if p.responseBtn != nil {
 		p.responseBtn.Disable()
 	}
+	if p.incidentBtn != nil {
+		p.incidentBtn.Disable()
+	}
 }
 
 func alarmTakeActionText(alarm models.Alarm) string {
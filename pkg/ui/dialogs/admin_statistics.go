@@ -474,15 +474,18 @@ func (s *mostStatisticsDialogState) exportCSV() {
 		if uc == nil {
 			return
 		}
-		defer uc.Close()
-
-		content := s.buildCSVContent()
-		if _, err := uc.Write([]byte(content)); err != nil {
+		path := uriPathToLocalPath(uc.URI().Path())
+		_, err = uc.Write([]byte(s.buildCSVContent()))
+		if closeErr := uc.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
 			dialog.ShowError(err, s.win)
 			return
 		}
 
-		s.statusLabel.SetText(fmt.Sprintf("Експортовано: %s", uriPathToLocalPath(uc.URI().Path())))
+		s.statusLabel.SetText(fmt.Sprintf("Експортовано: %s", path))
+		publishExportedReport(s.statusLabel, path)
 	}, s.win).Show()
 }
 
@@ -46,6 +46,7 @@ func ShowAlarmResponseDialog(
 	groupLabels := make([]string, 0, len(groups))
 	currentGroupID := strings.TrimSpace(alarm.ResponseGroupID)
 	canRespond := alarmResponseActionsAllowed(alarm)
+	preferredLabel := ""
 	for _, group := range groups {
 		if !responseGroupSelectable(group, currentGroupID) {
 			continue
@@ -53,14 +54,25 @@ func ShowAlarmResponseDialog(
 		label := responseGroupLabelText(group)
 		groupLabels = append(groupLabels, label)
 		groupIDs[label] = strings.TrimSpace(group.ID)
+		if preferredLabel == "" && (group.Suggestion.Recommended || group.Suggestion.AssignedToAlarm) {
+			preferredLabel = label
+		}
 	}
 	groupSelect := widget.NewSelect(groupLabels, nil)
 	groupSelect.PlaceHolder = "Оберіть ГМР"
-	if len(groupLabels) > 0 {
+	switch {
+	case preferredLabel != "":
+		groupSelect.SetSelected(preferredLabel)
+	case len(groupLabels) > 0:
 		groupSelect.SetSelected(groupLabels[0])
-	} else {
+	default:
 		groupSelect.Disable()
 	}
+	busyLabel := widget.NewLabel(busyResponseGroupsText(groups, currentGroupID))
+	busyLabel.Wrapping = fyne.TextWrapWord
+	if busyLabel.Text == "" {
+		busyLabel.Hide()
+	}
 
 	var dlg dialog.Dialog
 	run := func(action AlarmResponseAction, groupID string) {
@@ -119,6 +131,7 @@ func ShowAlarmResponseDialog(
 		widget.NewSeparator(),
 		widget.NewLabel("Група реагування"),
 		groupSelect,
+		busyLabel,
 		container.NewGridWithColumns(3, takeButton, assignButton, arrivedButton),
 		container.NewHBox(cancelButton, layout.NewSpacer(), processButton),
 	)
@@ -196,6 +209,9 @@ func responseGroupLabelText(group contracts.FrontendResponseGroup) string {
 	if name == "" {
 		name = "ГМР " + strings.TrimSpace(group.ID)
 	}
+	if group.Suggestion.Recommended {
+		name = "★ " + name
+	}
 	parts := []string{name}
 	if callsign := strings.TrimSpace(group.Callsign); callsign != "" {
 		parts = append(parts, "позивний "+callsign)
@@ -206,8 +222,35 @@ func responseGroupLabelText(group contracts.FrontendResponseGroup) string {
 	if status := strings.TrimSpace(group.StatusText); status != "" {
 		parts = append(parts, status)
 	}
+	if note := strings.TrimSpace(group.Suggestion.Note); note != "" {
+		parts = append(parts, note)
+	}
 	if id := strings.TrimSpace(group.ID); id != "" {
 		parts = append(parts, fmt.Sprintf("ID %s", id))
 	}
 	return strings.Join(parts, " | ")
 }
+
+// busyResponseGroupsText перелічує групи, які вже направлені на інші тривоги.
+func busyResponseGroupsText(groups []contracts.FrontendResponseGroup, currentID string) string {
+	names := make([]string, 0)
+	for _, group := range groups {
+		if responseGroupSelectable(group, currentID) {
+			continue
+		}
+		name := strings.TrimSpace(group.Name)
+		if name == "" {
+			name = "ГМР " + strings.TrimSpace(group.ID)
+		}
+		if note := strings.TrimSpace(group.Suggestion.Note); note != "" {
+			name += " (" + note + ")"
+		} else if number := strings.TrimSpace(group.ObjectNumber); number != "" {
+			name += " (№" + number + ")"
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "Зайняті: " + strings.Join(names, "; ")
+}
@@ -44,6 +44,30 @@ func TestResponseGroupSelectableAllowsFreeAndCurrentGroups(t *testing.T) {
 	}
 }
 
+func TestResponseGroupLabelShowsDispatchSuggestion(t *testing.T) {
+	got := responseGroupLabelText(contracts.FrontendResponseGroup{
+		ID:   "20",
+		Name: "Група Схід",
+		Suggestion: contracts.ResponseGroupSuggestion{
+			Rank:        1,
+			Recommended: true,
+			Note:        "рекомендовано, 2.5 км по прямій",
+		},
+	})
+	want := "★ Група Схід | рекомендовано, 2.5 км по прямій | ID 20"
+	if got != want {
+		t.Fatalf("responseGroupLabelText() = %q, want %q", got, want)
+	}
+
+	busy := busyResponseGroupsText([]contracts.FrontendResponseGroup{
+		{ID: "1", Name: "Вільна", Status: contracts.ResponseGroupStatusFree},
+		{ID: "2", Name: "Зайнята", Status: contracts.ResponseGroupStatusDispatched, ObjectNumber: "77"},
+	}, "")
+	if busy != "Зайняті: Зайнята (№77)" {
+		t.Fatalf("busyResponseGroupsText() = %q", busy)
+	}
+}
+
 func TestAlarmResponseActionsRequireCASLOrPhoenixOwnership(t *testing.T) {
 	if alarmResponseActionsAllowed(models.Alarm{ObjectID: ids.CASLObjectIDNamespaceStart}) {
 		t.Fatal("unowned CASL alarm must not allow response actions")
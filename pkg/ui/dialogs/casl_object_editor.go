@@ -1209,6 +1209,7 @@ func (s *caslObjectEditorState) currentDeviceCreate() (contracts.CASLDeviceCreat
 		return contracts.CASLDeviceCreate{}, fmt.Errorf("reglament date: %w", err)
 	}
 	return contracts.CASLDeviceCreate{
+		PultID:            strings.TrimSpace(s.snapshot.Object.ReactingPultID),
 		Number:            number,
 		Name:              strings.TrimSpace(s.deviceNameEntry.Text),
 		DeviceType:        mappedOptionValue(s.deviceTypeSelect.Selected, s.deviceTypeOptionToID),
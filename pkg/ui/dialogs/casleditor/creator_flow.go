@@ -224,6 +224,7 @@ func (vm *EditorViewModel) createDraftDevice(ctx context.Context, device contrac
 		return "", fmt.Errorf("номер приладу %d вже зайнятий", device.Number)
 	}
 	return vm.provider.CreateCASLDevice(ctx, contracts.CASLDeviceCreate{
+		PultID:            strings.TrimSpace(vm.Snapshot.Object.ReactingPultID),
 		Number:            device.Number,
 		Name:              strings.TrimSpace(device.Name),
 		DeviceType:        strings.TrimSpace(device.Type),
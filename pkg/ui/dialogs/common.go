@@ -1,8 +1,15 @@
 package dialogs
 
 import (
+	"context"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	objexport "obj_catalog_fyne_v3/pkg/export"
 )
 
 func ShowInfoDialog(win fyne.Window, title, msg string) {
@@ -12,3 +19,33 @@ func ShowInfoDialog(win fyne.Window, title, msg string) {
 func ShowErrorDialog(win fyne.Window, title string, err error) {
 	dialog.ShowError(err, win)
 }
+
+// publishExportedReport відправляє експортований файл у сховище звітів з налаштувань
+// і дописує результат до статусу. Без обраної цілі файл лишається тільки локально.
+func publishExportedReport(status *widget.Label, localPath string) {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	cfg := config.LoadReportUploadConfig(app.Preferences())
+	if cfg.NormalizedTarget() == config.ReportUploadTargetNone {
+		return
+	}
+	exported := status.Text
+	status.SetText(exported + " | публікація у сховище звітів...")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		link, err := objexport.PublishConfiguredReport(ctx, cfg, localPath)
+		fyne.Do(func() {
+			switch {
+			case err != nil:
+				status.SetText(exported + " | " + err.Error())
+			case link != "":
+				status.SetText(exported + " | опубліковано: " + link)
+			default:
+				status.SetText(exported + " | опубліковано")
+			}
+		})
+	}()
+}
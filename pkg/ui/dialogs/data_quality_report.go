@@ -0,0 +1,217 @@
+package dialogs
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/dataquality"
+	objexport "obj_catalog_fyne_v3/pkg/export"
+)
+
+var dataQualitySeverityOptions = []struct {
+	label    string
+	severity string
+}{
+	{label: "Усі рівні", severity: ""},
+	{label: "Помилки", severity: dataquality.SeverityError},
+	{label: "Попередження", severity: dataquality.SeverityWarn},
+	{label: "Інфо", severity: dataquality.SeverityInfo},
+}
+
+// ShowDataQualityReport opens the cross-source data-quality issue list.
+// onFix is called for the issue picked for a quick fix in the object editor.
+func ShowDataQualityReport(
+	provider contracts.ObjectProvider,
+	opts []dataquality.Option,
+	onFix func(dataquality.Issue),
+) {
+	reportWindow := fyne.CurrentApp().NewWindow("Перевірка якості даних")
+	reportWindow.Resize(fyne.NewSize(1100, 640))
+
+	severityLabels := make([]string, 0, len(dataQualitySeverityOptions))
+	for _, option := range dataQualitySeverityOptions {
+		severityLabels = append(severityLabels, option.label)
+	}
+	severity := widget.NewSelect(severityLabels, nil)
+	severity.SetSelectedIndex(0)
+	search := widget.NewEntry()
+	search.SetPlaceHolder("Пошук за номером, назвою, полем або описом")
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	var (
+		allIssues     []dataquality.Issue
+		visibleIssues []dataquality.Issue
+		running       bool
+		cancelRun     context.CancelFunc
+	)
+
+	list := widget.NewList(
+		func() int { return len(visibleIssues) },
+		func() fyne.CanvasObject {
+			marker := canvas.NewRectangle(color.Transparent)
+			marker.SetMinSize(fyne.NewSize(6, 0))
+			text := widget.NewLabel("")
+			text.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, marker, nil, text)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(visibleIssues) {
+				return
+			}
+			row := item.(*fyne.Container)
+			text := row.Objects[0].(*widget.Label)
+			marker := row.Objects[1].(*canvas.Rectangle)
+			issue := visibleIssues[id]
+			marker.FillColor = dataQualitySeverityColor(issue.Severity)
+			marker.Refresh()
+			text.SetText(dataQualityIssueLine(issue))
+		},
+	)
+
+	applyFilter := func() {
+		selected := ""
+		if index := severity.SelectedIndex(); index >= 0 && index < len(dataQualitySeverityOptions) {
+			selected = dataQualitySeverityOptions[index].severity
+		}
+		visibleIssues = dataquality.Filter(allIssues, selected, search.Text)
+		list.UnselectAll()
+		list.Refresh()
+		if !running {
+			status.SetText(dataquality.Summary(allIssues) + fmt.Sprintf(" | показано: %d", len(visibleIssues)))
+		}
+	}
+	search.OnChanged = func(string) { applyFilter() }
+	severity.OnChanged = func(string) { applyFilter() }
+
+	var runButton *widget.Button
+	run := func() {
+		if provider == nil || running {
+			return
+		}
+		running = true
+		runButton.Disable()
+		status.SetText("Завантаження об'єктів...")
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		cancelRun = cancel
+		checkerOpts := append([]dataquality.Option{}, opts...)
+		checkerOpts = append(checkerOpts, dataquality.WithProgress(func(done int, total int) {
+			if done%25 != 0 && done != total {
+				return
+			}
+			fyne.Do(func() {
+				if running {
+					status.SetText(fmt.Sprintf("Перевірено об'єктів: %d з %d", done, total))
+				}
+			})
+		}))
+		go func() {
+			defer cancel()
+			issues, err := dataquality.NewChecker(checkerOpts...).Run(ctx, provider)
+			fyne.Do(func() {
+				running = false
+				runButton.Enable()
+				if err != nil {
+					status.SetText("Перевірку не виконано: " + err.Error())
+					return
+				}
+				allIssues = issues
+				applyFilter()
+			})
+		}()
+	}
+	runButton = widget.NewButton("Перевірити", run)
+
+	exportButton := widget.NewButton("Експорт XLSX", func() {
+		if len(visibleIssues) == 0 {
+			ShowInfoDialog(reportWindow, "Експорт XLSX", "Немає проблем для експорту.")
+			return
+		}
+		issues := append([]dataquality.Issue(nil), visibleIssues...)
+		saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				ShowErrorDialog(reportWindow, "Експорт XLSX", err)
+				return
+			}
+			if uc == nil {
+				return
+			}
+			path := uriPathToLocalPath(uc.URI().Path())
+			_ = uc.Close()
+			if err := objexport.WriteDataQualityXLSX(path, issues); err != nil {
+				ShowErrorDialog(reportWindow, "Експорт XLSX", err)
+				return
+			}
+			status.SetText(fmt.Sprintf("Експортовано %d проблем: %s", len(issues), path))
+			publishExportedReport(status, path)
+		}, reportWindow)
+		saveDialog.SetFileName("data_quality_" + time.Now().Format("2006-01-02") + ".xlsx")
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".xlsx"}))
+		saveDialog.Show()
+	})
+
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < 0 || id >= len(visibleIssues) || onFix == nil {
+			return
+		}
+		issue := visibleIssues[id]
+		list.UnselectAll()
+		onFix(issue)
+	}
+	reportWindow.SetOnClosed(func() {
+		running = false
+		if cancelRun != nil {
+			cancelRun()
+		}
+	})
+
+	controls := container.NewBorder(nil, nil,
+		container.NewHBox(widget.NewLabel("Рівень"), severity),
+		container.NewHBox(runButton, exportButton),
+		search,
+	)
+	hint := widget.NewLabel("Оберіть рядок, щоб відкрити редактор об'єкта.")
+	reportWindow.SetContent(container.NewBorder(
+		container.NewVBox(controls, status),
+		hint, nil, nil,
+		list,
+	))
+	reportWindow.Show()
+	run()
+}
+
+func dataQualityIssueLine(issue dataquality.Issue) string {
+	return fmt.Sprintf(
+		"%-12s   %-8s   №%s   %s   |   %s: %s   |   %s",
+		dataquality.SeverityLabel(issue.Severity),
+		issue.Source.DisplayName(),
+		issue.ObjectNumber,
+		issue.ObjectName,
+		issue.Field,
+		dataquality.CodeLabel(issue.Code),
+		issue.Details,
+	)
+}
+
+func dataQualitySeverityColor(severity string) color.Color {
+	switch severity {
+	case dataquality.SeverityError:
+		return color.NRGBA{R: 220, G: 53, B: 69, A: 255}
+	case dataquality.SeverityWarn:
+		return color.NRGBA{R: 255, G: 193, B: 7, A: 255}
+	case dataquality.SeverityInfo:
+		return color.NRGBA{R: 13, G: 110, B: 253, A: 255}
+	default:
+		return color.Transparent
+	}
+}
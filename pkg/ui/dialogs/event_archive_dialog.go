@@ -0,0 +1,244 @@
+package dialogs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+const eventArchivePageSize = 500
+
+// ShowEventArchiveDialog opens the Phoenix event archive browser with paging and file export.
+// object, when set, prefills the "only this object" filter.
+func ShowEventArchiveDialog(archive contracts.EventArchiveProvider, object *models.Object) {
+	win := fyne.CurrentApp().NewWindow("Архів подій Phoenix")
+	win.Resize(fyne.NewSize(1150, 720))
+
+	var (
+		events       []models.Event
+		query        contracts.EventArchiveQuery
+		nextCursor   string
+		loading      bool
+		exportCancel context.CancelFunc
+	)
+	objectNumber := ""
+	objectCheck := widget.NewCheck("Лише поточний об'єкт", nil)
+	if object != nil {
+		objectNumber = viewmodels.ObjectDisplayNumber(*object)
+		objectCheck.Text = fmt.Sprintf("Лише №%s %s", objectNumber, strings.TrimSpace(object.Name))
+		objectCheck.SetChecked(true)
+	} else {
+		objectCheck.Disable()
+	}
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("Код, напр. E130")
+	groupsEntry := widget.NewEntry()
+	groupsEntry.SetPlaceHolder("Групи: 1, 2")
+	today := time.Now()
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today.AddDate(0, 0, -30).Format(viewmodels.EventArchiveDateLayout))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today.Format(viewmodels.EventArchiveDateLayout))
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	list := widget.NewList(
+		func() int { return len(events) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(events) {
+				return
+			}
+			item.(*widget.Label).SetText(eventArchiveLine(events[id]))
+		},
+	)
+
+	var searchButton, moreButton, exportCSVButton, exportXLSXButton, cancelButton *widget.Button
+	updateButtons := func() {
+		exporting := exportCancel != nil
+		setButtonEnabled(searchButton, !loading && !exporting)
+		setButtonEnabled(moreButton, !loading && !exporting && nextCursor != "")
+		setButtonEnabled(exportCSVButton, !loading && !exporting)
+		setButtonEnabled(exportXLSXButton, !loading && !exporting)
+		setButtonEnabled(cancelButton, exporting)
+	}
+
+	buildQuery := func() (contracts.EventArchiveQuery, bool) {
+		input := viewmodels.EventArchiveFilterInput{
+			Code:   codeEntry.Text,
+			Groups: groupsEntry.Text,
+			From:   fromEntry.Text,
+			To:     toEntry.Text,
+		}
+		if object != nil && objectCheck.Checked {
+			input.ObjectID = object.ID
+		}
+		built, err := viewmodels.BuildEventArchiveQuery(input)
+		if err != nil {
+			status.SetText(err.Error())
+			return contracts.EventArchiveQuery{}, false
+		}
+		return built, true
+	}
+
+	loadPage := func(reset bool) {
+		if loading {
+			return
+		}
+		pageQuery := query
+		if reset {
+			built, ok := buildQuery()
+			if !ok {
+				return
+			}
+			query = built
+			pageQuery = built
+		} else {
+			pageQuery.Cursor = nextCursor
+		}
+		pageQuery.Limit = eventArchivePageSize
+		loading = true
+		updateButtons()
+		status.SetText("Завантаження архіву...")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			page, err := archive.QueryEventArchive(ctx, pageQuery)
+			fyne.Do(func() {
+				loading = false
+				if err != nil {
+					status.SetText("Архів недоступний: " + err.Error())
+					updateButtons()
+					return
+				}
+				if reset {
+					events = page.Events
+					list.ScrollToTop()
+				} else {
+					events = append(events, page.Events...)
+				}
+				nextCursor = page.NextCursor
+				list.Refresh()
+				more := ""
+				if nextCursor != "" {
+					more = " | є ще події — «Ще»"
+				}
+				status.SetText(fmt.Sprintf("Показано подій: %d%s", len(events), more))
+				updateButtons()
+			})
+		}()
+	}
+
+	startExport := func(ext string) {
+		exportQuery, ok := buildQuery()
+		if !ok {
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				ShowErrorDialog(win, "Експорт архіву", err)
+				return
+			}
+			if uc == nil {
+				return
+			}
+			path := uriPathToLocalPath(uc.URI().Path())
+			_ = uc.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			exportCancel = cancel
+			updateButtons()
+			status.SetText("Експорт: " + path)
+			go func() {
+				written, err := objexport.ExportEventArchive(ctx, archive, exportQuery, path, func(written int) {
+					fyne.Do(func() { status.SetText(fmt.Sprintf("Експортовано подій: %d...", written)) })
+				})
+				fyne.Do(func() {
+					cancel()
+					exportCancel = nil
+					updateButtons()
+					switch {
+					case ctx.Err() != nil:
+						status.SetText("Експорт скасовано")
+					case err != nil:
+						status.SetText("Експорт не виконано: " + err.Error())
+					default:
+						status.SetText(fmt.Sprintf("Експортовано подій: %d | %s", written, path))
+					}
+				})
+			}()
+		}, win)
+		fileObject := ""
+		if exportQuery.ObjectID != 0 {
+			fileObject = objectNumber
+		}
+		saveDialog.SetFileName(viewmodels.EventArchiveExportFileName(fileObject, ext, time.Now()))
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + ext}))
+		saveDialog.Show()
+	}
+
+	searchButton = widget.NewButton("Шукати", func() { loadPage(true) })
+	moreButton = widget.NewButton("Ще", func() { loadPage(false) })
+	exportCSVButton = widget.NewButton("Експорт CSV", func() { startExport("csv") })
+	exportXLSXButton = widget.NewButton("Експорт XLSX", func() { startExport("xlsx") })
+	cancelButton = widget.NewButton("Скасувати експорт", func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+	win.SetOnClosed(func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+
+	filters := container.NewHBox(
+		objectCheck,
+		widget.NewLabel("З"), fromEntry,
+		widget.NewLabel("По"), toEntry,
+		widget.NewLabel("Код"), codeEntry,
+		widget.NewLabel("Групи"), groupsEntry,
+	)
+	actions := container.NewHBox(searchButton, moreButton, exportCSVButton, exportXLSXButton, cancelButton)
+	hint := widget.NewLabel("Експорт читає архів сторінками і пише у файл одразу, тож підходить для запитів за роки.")
+	hint.Wrapping = fyne.TextWrapWord
+	win.SetContent(container.NewBorder(container.NewVBox(filters, actions, status), hint, nil, nil, list))
+	updateButtons()
+	win.Show()
+	loadPage(true)
+}
+
+func eventArchiveLine(event models.Event) string {
+	values := objexport.EventExportValues(event)
+	line := fmt.Sprintf("%s   №%s %s   |   %s", values[0], values[1], values[2], values[3])
+	for _, value := range values[4:7] {
+		if value != "" {
+			line += "   |   " + value
+		}
+	}
+	return line
+}
+
+func setButtonEnabled(button *widget.Button, enabled bool) {
+	if enabled {
+		button.Enable()
+	} else {
+		button.Disable()
+	}
+}
@@ -0,0 +1,187 @@
+package dialogs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/eventexport"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// eventExportProgressInterval limits how often the progress label is redrawn.
+const eventExportProgressInterval = 250 * time.Millisecond
+
+// ShowEventExportDialog opens the event journal export form. preset carries the
+// filters of the event log panel; the file is written while the window stays open.
+func ShowEventExportDialog(provider eventexport.Provider, preset viewmodels.EventExportFilterInput) {
+	win := fyne.CurrentApp().NewWindow("Експорт журналу подій")
+	win.Resize(fyne.NewSize(760, 620))
+
+	sourceGroup := widget.NewCheckGroup(viewmodels.EventExportSourceOptions, nil)
+	sourceGroup.Horizontal = true
+	sourceGroup.SetSelected(preset.Sources)
+	severitySelect := widget.NewSelect(viewmodels.EventExportSeverityOptions, nil)
+	severitySelect.SetSelected(preset.Severity)
+	objectsEntry := widget.NewEntry()
+	objectsEntry.SetPlaceHolder("Номери об'єктів через кому; порожньо — усі")
+	objectsEntry.SetText(preset.Objects)
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(preset.From)
+	toEntry := widget.NewEntry()
+	toEntry.SetText(preset.To)
+
+	typeOptions := viewmodels.EventExportTypeOptions()
+	typeLabels := make([]string, 0, len(typeOptions))
+	for _, option := range typeOptions {
+		typeLabels = append(typeLabels, option.Label)
+	}
+	typeGroup := widget.NewCheckGroup(typeLabels, nil)
+	for _, option := range typeOptions {
+		if slices.Contains(preset.Types, option.Type) {
+			typeGroup.Selected = append(typeGroup.Selected, option.Label)
+		}
+	}
+	typeScroll := container.NewVScroll(typeGroup)
+	typeScroll.SetMinSize(fyne.NewSize(0, 220))
+
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+	progress := widget.NewProgressBarInfinite()
+	progress.Hide()
+
+	var (
+		exportCancel                                    context.CancelFunc
+		exportCSVButton, exportXLSXButton, cancelButton *widget.Button
+	)
+	updateButtons := func() {
+		exporting := exportCancel != nil
+		setButtonEnabled(exportCSVButton, !exporting)
+		setButtonEnabled(exportXLSXButton, !exporting)
+		setButtonEnabled(cancelButton, exporting)
+	}
+
+	formInput := func() viewmodels.EventExportFilterInput {
+		input := viewmodels.EventExportFilterInput{
+			Sources:  sourceGroup.Selected,
+			Severity: severitySelect.Selected,
+			Objects:  objectsEntry.Text,
+			From:     fromEntry.Text,
+			To:       toEntry.Text,
+		}
+		for _, option := range typeOptions {
+			if slices.Contains(typeGroup.Selected, option.Label) {
+				input.Types = append(input.Types, option.Type)
+			}
+		}
+		return input
+	}
+
+	runExport := func(input viewmodels.EventExportFilterInput, path string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		exportCancel = cancel
+		updateButtons()
+		progress.Show()
+		progress.Start()
+		status.SetText("Експорт: " + path)
+		go func() {
+			var objects []models.Object
+			if strings.TrimSpace(input.Objects) != "" {
+				objects = provider.GetObjects()
+			}
+			filter, err := viewmodels.BuildEventExportFilter(input, objects)
+			written := 0
+			if err == nil {
+				var lastUpdate time.Time
+				exporter := eventexport.NewExporter(eventexport.WithProgress(func(n int) {
+					if time.Since(lastUpdate) < eventExportProgressInterval {
+						return
+					}
+					lastUpdate = time.Now()
+					fyne.Do(func() { status.SetText(fmt.Sprintf("Експортовано подій: %d...", n)) })
+				}))
+				written, err = exporter.ExportFile(ctx, provider, filter, path)
+			}
+			fyne.Do(func() {
+				cancel()
+				exportCancel = nil
+				progress.Stop()
+				progress.Hide()
+				updateButtons()
+				switch {
+				case ctx.Err() != nil:
+					status.SetText("Експорт скасовано")
+				case err != nil:
+					status.SetText("Експорт не виконано: " + err.Error())
+				default:
+					status.SetText(fmt.Sprintf("Експортовано подій: %d | %s", written, path))
+				}
+			})
+		}()
+	}
+
+	startExport := func(ext string) {
+		input := formInput()
+		if _, err := viewmodels.BuildEventExportFilter(viewmodels.EventExportFilterInput{From: input.From, To: input.To}, nil); err != nil {
+			status.SetText(err.Error())
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				ShowErrorDialog(win, "Експорт журналу подій", err)
+				return
+			}
+			if uc == nil {
+				return
+			}
+			path := uriPathToLocalPath(uc.URI().Path())
+			_ = uc.Close()
+			runExport(input, path)
+		}, win)
+		saveDialog.SetFileName(viewmodels.EventExportFileName(ext, time.Now()))
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + ext}))
+		saveDialog.Show()
+	}
+
+	exportCSVButton = widget.NewButton("Експорт CSV", func() { startExport("csv") })
+	exportXLSXButton = widget.NewButton("Експорт XLSX", func() { startExport("xlsx") })
+	cancelButton = widget.NewButton("Скасувати експорт", func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+	win.SetOnClosed(func() {
+		if exportCancel != nil {
+			exportCancel()
+		}
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Джерела", sourceGroup),
+		widget.NewFormItem("Важливість", severitySelect),
+		widget.NewFormItem("Об'єкти", objectsEntry),
+		widget.NewFormItem("З", fromEntry),
+		widget.NewFormItem("По", toEntry),
+	)
+	hint := widget.NewLabel("Без вибраних джерел і типів експортуються всі. Файл пишеться поступово, тож підходить для великих періодів.")
+	hint.Wrapping = fyne.TextWrapWord
+	top := container.NewVBox(form, widget.NewLabel("Типи подій"))
+	bottom := container.NewVBox(
+		hint,
+		container.NewHBox(exportCSVButton, exportXLSXButton, cancelButton),
+		progress,
+		status,
+	)
+	win.SetContent(container.NewBorder(top, bottom, nil, nil, typeScroll))
+	updateButtons()
+	win.Show()
+}
@@ -0,0 +1,284 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/maintenance"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// ShowMaintenanceWindowsDialog opens maintenance windows and the suppressed-alarm report.
+// object prefills the new window form; onChanged is called after windows are saved or deleted.
+func ShowMaintenanceWindowsDialog(
+	store *maintenance.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) {
+	win := fyne.CurrentApp().NewWindow("Вікна обслуговування")
+	win.Resize(fyne.NewSize(1000, 640))
+
+	windowsTab := maintenanceWindowsTab(win, store, object, user, onChanged)
+	reportTab := maintenanceSuppressedTab(win, store)
+	win.SetContent(container.NewAppTabs(
+		container.NewTabItem("Вікна", windowsTab),
+		container.NewTabItem("Приглушені тривоги", reportTab),
+	))
+	win.Show()
+}
+
+func maintenanceWindowsTab(
+	win fyne.Window,
+	store *maintenance.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) fyne.CanvasObject {
+	var windows []maintenance.Window
+	selected := -1
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	list := widget.NewList(
+		func() int { return len(windows) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(windows) {
+				return
+			}
+			item.(*widget.Label).SetText(maintenanceWindowLine(windows[id], time.Now()))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) { selected = id }
+	list.OnUnselected = func(widget.ListItemID) { selected = -1 }
+
+	reload := func() {
+		loaded, err := store.Windows()
+		if err != nil {
+			status.SetText("Не вдалося прочитати вікна: " + err.Error())
+			return
+		}
+		windows = loaded
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		status.SetText(fmt.Sprintf("Вікон: %d | файл: %s", len(windows), store.Path()))
+	}
+
+	objectLabel := widget.NewLabel("Об'єкт не вибрано")
+	if object != nil {
+		objectLabel.SetText(fmt.Sprintf("№%s %s (%s)", viewmodels.ObjectDisplayNumber(*object), strings.TrimSpace(object.Name), viewmodels.ObjectSourceByID(object.ID)))
+	}
+	zones := widget.NewEntry()
+	zones.SetPlaceHolder("Порожньо — увесь об'єкт, або 1, 3, 5")
+	now := time.Now().Truncate(time.Minute)
+	start := widget.NewEntry()
+	start.SetText(now.Format(maintenance.FormTimeLayout))
+	end := widget.NewEntry()
+	end.SetText(now.Add(2 * time.Hour).Format(maintenance.FormTimeLayout))
+	recurrenceLabels := make([]string, 0, len(maintenance.Recurrences))
+	for _, option := range maintenance.Recurrences {
+		recurrenceLabels = append(recurrenceLabels, option.Label())
+	}
+	recurrence := widget.NewSelect(recurrenceLabels, nil)
+	recurrence.SetSelectedIndex(0)
+	until := widget.NewEntry()
+	until.SetPlaceHolder("ДД.ММ.РРРР — без обмеження")
+	modeLabels := make([]string, 0, len(maintenance.Modes))
+	for _, option := range maintenance.Modes {
+		modeLabels = append(modeLabels, option.Label())
+	}
+	mode := widget.NewSelect(modeLabels, nil)
+	mode.SetSelectedIndex(0)
+	reason := widget.NewEntry()
+	reason.SetPlaceHolder("Напр.: заміна АКБ, ремонт приміщення")
+
+	addButton := widget.NewButton("Додати вікно", func() {
+		if object == nil {
+			ShowInfoDialog(win, "Вікно обслуговування", "Виберіть об'єкт у списку перед створенням вікна.")
+			return
+		}
+		window, err := maintenance.Form{
+			ObjectID:     object.ID,
+			ObjectNumber: viewmodels.ObjectDisplayNumber(*object),
+			ObjectName:   object.Name,
+			Zones:        zones.Text,
+			Start:        start.Text,
+			End:          end.Text,
+			Until:        until.Text,
+			Recurrence:   maintenance.Recurrences[max(recurrence.SelectedIndex(), 0)],
+			Mode:         maintenance.Modes[max(mode.SelectedIndex(), 0)],
+			Reason:       reason.Text,
+			CreatedBy:    user,
+		}.Window()
+		if err != nil {
+			ShowErrorDialog(win, "Вікно обслуговування", err)
+			return
+		}
+		if _, err := store.SaveWindow(window); err != nil {
+			ShowErrorDialog(win, "Вікно обслуговування", err)
+			return
+		}
+		reason.SetText("")
+		reload()
+		if onChanged != nil {
+			onChanged()
+		}
+	})
+	deleteButton := widget.NewButton("Видалити вибране", func() {
+		if selected < 0 || selected >= len(windows) {
+			return
+		}
+		window := windows[selected]
+		dialog.ShowConfirm("Видалити вікно", "Видалити вікно обслуговування «"+window.Reason+"»?", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := store.DeleteWindow(window.ID); err != nil {
+				ShowErrorDialog(win, "Вікно обслуговування", err)
+				return
+			}
+			reload()
+			if onChanged != nil {
+				onChanged()
+			}
+		}, win)
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Об'єкт", objectLabel),
+		widget.NewFormItem("Зони", zones),
+		widget.NewFormItem("Початок", start),
+		widget.NewFormItem("Кінець", end),
+		widget.NewFormItem("Повторення", recurrence),
+		widget.NewFormItem("Повторювати до", until),
+		widget.NewFormItem("Режим", mode),
+		widget.NewFormItem("Причина", reason),
+	)
+	reload()
+	return container.NewBorder(
+		container.NewVBox(form, container.NewHBox(addButton, deleteButton), status),
+		nil, nil, nil,
+		list,
+	)
+}
+
+func maintenanceSuppressedTab(win fyne.Window, store *maintenance.FileStore) fyne.CanvasObject {
+	var entries []maintenance.SuppressedAlarm
+	today := time.Now()
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today.AddDate(0, 0, -7).Format(maintenance.FormDateLayout))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today.Format(maintenance.FormDateLayout))
+	status := widget.NewLabel("")
+
+	list := widget.NewList(
+		func() int { return len(entries) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(entries) {
+				return
+			}
+			entry := entries[id]
+			item.(*widget.Label).SetText(fmt.Sprintf(
+				"%s   №%s %s   |   %s %s   |   ТО: %s",
+				entry.Time.Format("02.01.2006 15:04:05"),
+				entry.ObjectNumber,
+				entry.ObjectName,
+				entry.Type,
+				entry.Details,
+				entry.Reason,
+			))
+		},
+	)
+
+	load := func() {
+		from, err := time.ParseInLocation(maintenance.FormDateLayout, strings.TrimSpace(fromEntry.Text), time.Local)
+		if err != nil {
+			status.SetText("Некоректна дата «від». Формат: ДД.ММ.РРРР")
+			return
+		}
+		to, err := time.ParseInLocation(maintenance.FormDateLayout, strings.TrimSpace(toEntry.Text), time.Local)
+		if err != nil || to.Before(from) {
+			status.SetText("Некоректна дата «до» або діапазон дат")
+			return
+		}
+		loaded, err := store.Suppressed(from, to.AddDate(0, 0, 1))
+		if err != nil {
+			status.SetText("Не вдалося прочитати журнал: " + err.Error())
+			return
+		}
+		entries = loaded
+		list.Refresh()
+		status.SetText(fmt.Sprintf("Приглушено тривог: %d", len(entries)))
+	}
+
+	exportButton := widget.NewButton("Експорт CSV", func() {
+		if len(entries) == 0 {
+			ShowInfoDialog(win, "Експорт CSV", "Немає тривог для експорту.")
+			return
+		}
+		snapshot := append([]maintenance.SuppressedAlarm(nil), entries...)
+		saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				ShowErrorDialog(win, "Експорт CSV", err)
+				return
+			}
+			if uc == nil {
+				return
+			}
+			path := uriPathToLocalPath(uc.URI().Path())
+			_ = uc.Close()
+			if err := objexport.WriteSuppressedAlarmsCSV(path, snapshot); err != nil {
+				ShowErrorDialog(win, "Експорт CSV", err)
+				return
+			}
+			status.SetText(fmt.Sprintf("Експортовано %d тривог: %s", len(snapshot), path))
+		}, win)
+		saveDialog.SetFileName("suppressed_alarms_" + time.Now().Format("2006-01-02") + ".csv")
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+		saveDialog.Show()
+	})
+
+	controls := container.NewHBox(
+		widget.NewLabel("Від"), fromEntry,
+		widget.NewLabel("До"), toEntry,
+		widget.NewButton("Показати", load),
+		exportButton,
+	)
+	load()
+	return container.NewBorder(container.NewVBox(controls, status), nil, nil, nil, list)
+}
+
+func maintenanceWindowLine(window maintenance.Window, now time.Time) string {
+	return fmt.Sprintf(
+		"[%s]   №%s %s   |   %s   |   %s – %s   |   %s, %s   |   %s",
+		window.StateLabel(now),
+		window.ObjectNumber,
+		window.ObjectName,
+		window.ZonesLabel(),
+		window.Start.Format(maintenance.FormTimeLayout),
+		window.End.Format(maintenance.FormTimeLayout),
+		window.Recurrence.Label(),
+		strings.ToLower(window.Mode.Label()),
+		window.Reason,
+	)
+}
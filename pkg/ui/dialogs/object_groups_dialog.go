@@ -0,0 +1,183 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// ShowObjectGroupsDialog opens the shared object groups and watchlists editor.
+// objects returns the current objects of all sources; object is offered for a quick add.
+// onChanged is called after groups are saved or deleted.
+func ShowObjectGroupsDialog(
+	store *objectgroups.FileStore,
+	objects func() []models.Object,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) {
+	win := fyne.CurrentApp().NewWindow("Групи об'єктів")
+	win.Resize(fyne.NewSize(1000, 640))
+
+	var (
+		groups   []objectgroups.Group
+		snapshot []models.Object
+	)
+	selected := -1
+	editingID := ""
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	loadObjects := func() []models.Object {
+		if snapshot == nil && objects != nil {
+			snapshot = objects()
+		}
+		return snapshot
+	}
+
+	name := widget.NewEntry()
+	name.SetPlaceHolder("Напр.: Банки Оболоні")
+	members := widget.NewMultiLineEntry()
+	members.SetPlaceHolder("Номери об'єктів через кому або з нового рядка")
+	members.SetMinRowsVisible(4)
+	query := widget.NewEntry()
+	query.SetPlaceHolder("Або фільтр: source:casl region:Оболонь")
+	watch := widget.NewCheck("Список спостереження: тривоги цих об'єктів закріплюються нагорі", nil)
+
+	fillForm := func(form objectgroups.Form) {
+		editingID = form.ID
+		name.SetText(form.Name)
+		members.SetText(form.Objects)
+		query.SetText(form.Query)
+		watch.SetChecked(form.Watch)
+	}
+
+	list := widget.NewList(
+		func() int { return len(groups) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(groups) {
+				return
+			}
+			item.(*widget.Label).SetText(objectGroupLine(groups[id]))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < 0 || id >= len(groups) {
+			return
+		}
+		selected = id
+		fillForm(objectgroups.NewForm(groups[id], loadObjects()))
+	}
+	list.OnUnselected = func(widget.ListItemID) { selected = -1 }
+
+	reload := func() {
+		loaded, err := store.Groups()
+		if err != nil {
+			status.SetText("Не вдалося прочитати групи: " + err.Error())
+			return
+		}
+		groups = loaded
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		status.SetText(fmt.Sprintf("Груп: %d | файл: %s", len(groups), store.Path()))
+	}
+	changed := func() {
+		reload()
+		if onChanged != nil {
+			onChanged()
+		}
+	}
+
+	newButton := widget.NewButton("Нова група", func() {
+		list.UnselectAll()
+		fillForm(objectgroups.Form{})
+	})
+	addObjectButton := widget.NewButton("Додати вибраний об'єкт", func() {
+		if object == nil {
+			ShowInfoDialog(win, "Групи об'єктів", "Виберіть об'єкт у списку, щоб додати його до групи.")
+			return
+		}
+		number := viewmodels.ObjectDisplayNumber(*object)
+		text := strings.TrimSpace(members.Text)
+		if text != "" {
+			text += ", "
+		}
+		members.SetText(text + number)
+	})
+	saveButton := widget.NewButton("Зберегти", func() {
+		group, err := objectgroups.Form{
+			ID:        editingID,
+			Name:      name.Text,
+			Objects:   members.Text,
+			Query:     query.Text,
+			Watch:     watch.Checked,
+			UpdatedBy: user,
+		}.Group(loadObjects())
+		if err != nil {
+			ShowErrorDialog(win, "Група об'єктів", err)
+			return
+		}
+		saved, err := store.SaveGroup(group)
+		if err != nil {
+			ShowErrorDialog(win, "Група об'єктів", err)
+			return
+		}
+		changed()
+		fillForm(objectgroups.NewForm(saved, loadObjects()))
+	})
+	deleteButton := widget.NewButton("Видалити вибрану", func() {
+		if selected < 0 || selected >= len(groups) {
+			return
+		}
+		group := groups[selected]
+		dialog.ShowConfirm("Видалити групу", "Видалити групу «"+group.Name+"» для всіх операторів?", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := store.DeleteGroup(group.ID); err != nil {
+				ShowErrorDialog(win, "Група об'єктів", err)
+				return
+			}
+			fillForm(objectgroups.Form{})
+			changed()
+		}, win)
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Назва", name),
+		widget.NewFormItem("Об'єкти", members),
+		widget.NewFormItem("Фільтр", query),
+		widget.NewFormItem("", watch),
+	)
+	hint := widget.NewLabel("Група задається або переліком об'єктів, або виразом фільтра. Групи спільні для всіх операторів, що працюють з цим файлом.")
+	hint.Wrapping = fyne.TextWrapWord
+	reload()
+	win.SetContent(container.NewBorder(
+		container.NewVBox(form, container.NewHBox(newButton, addObjectButton, saveButton, deleteButton), hint, status),
+		nil, nil, nil,
+		list,
+	))
+	win.Show()
+}
+
+func objectGroupLine(group objectgroups.Group) string {
+	line := group.Label() + "   |   " + group.SummaryLabel()
+	if updatedBy := strings.TrimSpace(group.UpdatedBy); updatedBy != "" {
+		line += "   |   " + updatedBy + ", " + group.UpdatedAt.Format("02.01.2006 15:04")
+	}
+	return line
+}
@@ -0,0 +1,388 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	objexport "obj_catalog_fyne_v3/pkg/export"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/openclose"
+	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
+)
+
+// ShowOpenCloseSchedulesDialog opens open/close schedules, shared holidays and the compliance report.
+// object prefills the new schedule form; onChanged is called after schedules or violations change.
+func ShowOpenCloseSchedulesDialog(
+	store *openclose.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) {
+	win := fyne.CurrentApp().NewWindow("Розклад відкриття/закриття")
+	win.Resize(fyne.NewSize(1100, 720))
+
+	win.SetContent(container.NewAppTabs(
+		container.NewTabItem("Розклади", openCloseSchedulesTab(win, store, object, user, onChanged)),
+		container.NewTabItem("Святкові дні", openCloseHolidaysTab(win, store, onChanged)),
+		container.NewTabItem("Дотримання", openCloseComplianceTab(win, store)),
+	))
+	win.Show()
+}
+
+func openCloseSchedulesTab(
+	win fyne.Window,
+	store *openclose.FileStore,
+	object *models.Object,
+	user string,
+	onChanged func(),
+) fyne.CanvasObject {
+	var (
+		schedules []openclose.Schedule
+		holidays  []openclose.Holiday
+		editing   openclose.Form
+	)
+	selected := -1
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	objectLabel := widget.NewLabel("")
+	group := widget.NewEntry()
+	group.SetPlaceHolder("Порожньо — увесь об'єкт")
+	var days [7]*widget.Entry
+	dayItems := make([]*widget.FormItem, 0, len(days))
+	for i := range days {
+		days[i] = widget.NewEntry()
+		days[i].SetPlaceHolder("ГГ:ХХ-ГГ:ХХ, порожньо — вихідний")
+		dayItems = append(dayItems, widget.NewFormItem(openclose.WeekdayNames[i], days[i]))
+	}
+	openTolerance := widget.NewEntry()
+	closeTolerance := widget.NewEntry()
+	ownHolidays := widget.NewMultiLineEntry()
+	ownHolidays.SetPlaceHolder("ДД.ММ.РРРР назва — по рядку")
+	ownHolidays.SetMinRowsVisible(3)
+
+	fill := func(form openclose.Form) {
+		editing = form
+		title := "Новий розклад"
+		if form.ID != "" {
+			title = "Редагування"
+		}
+		objectLabel.SetText(fmt.Sprintf("%s: №%s %s", title, form.ObjectNumber, form.ObjectName))
+		group.SetText(form.GroupName)
+		for i := range days {
+			days[i].SetText(form.Days[i])
+		}
+		openTolerance.SetText(form.OpenTolerance)
+		closeTolerance.SetText(form.CloseTolerance)
+		ownHolidays.SetText(form.Holidays)
+	}
+	newForm := func() {
+		if object == nil {
+			objectLabel.SetText("Об'єкт не вибрано")
+			editing = openclose.Form{}
+			return
+		}
+		fill(openclose.DefaultForm(object.ID, viewmodels.ObjectDisplayNumber(*object), strings.TrimSpace(object.Name)))
+	}
+
+	list := widget.NewList(
+		func() int { return len(schedules) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(schedules) {
+				return
+			}
+			item.(*widget.Label).SetText(openCloseScheduleLine(schedules[id], holidays, time.Now()))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		if id >= 0 && id < len(schedules) {
+			fill(openclose.NewForm(schedules[id]))
+		}
+	}
+	list.OnUnselected = func(widget.ListItemID) { selected = -1 }
+
+	reload := func() {
+		loaded, err := store.Schedules()
+		if err != nil {
+			status.SetText("Не вдалося прочитати розклади: " + err.Error())
+			return
+		}
+		holidays, _ = store.Holidays()
+		schedules = loaded
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		status.SetText(fmt.Sprintf("Розкладів: %d | файл: %s", len(schedules), store.Path()))
+	}
+	changed := func() {
+		reload()
+		if onChanged != nil {
+			onChanged()
+		}
+	}
+
+	saveButton := widget.NewButton("Зберегти розклад", func() {
+		if editing.ObjectID == 0 {
+			ShowInfoDialog(win, "Розклад", "Виберіть об'єкт у списку або розклад для редагування.")
+			return
+		}
+		form := editing
+		form.GroupName = group.Text
+		for i := range days {
+			form.Days[i] = days[i].Text
+		}
+		form.OpenTolerance = openTolerance.Text
+		form.CloseTolerance = closeTolerance.Text
+		form.Holidays = ownHolidays.Text
+		if form.CreatedBy == "" {
+			form.CreatedBy = user
+		}
+		schedule, err := form.Schedule()
+		if err != nil {
+			ShowErrorDialog(win, "Розклад", err)
+			return
+		}
+		saved, err := store.SaveSchedule(schedule)
+		if err != nil {
+			ShowErrorDialog(win, "Розклад", err)
+			return
+		}
+		changed()
+		fill(openclose.NewForm(saved))
+	})
+	newButton := widget.NewButton("Новий для об'єкта", func() {
+		list.UnselectAll()
+		newForm()
+	})
+	deleteButton := widget.NewButton("Видалити вибраний", func() {
+		if selected < 0 || selected >= len(schedules) {
+			return
+		}
+		schedule := schedules[selected]
+		message := fmt.Sprintf("Видалити розклад №%s %s (%s)?", schedule.ObjectNumber, schedule.ObjectName, schedule.GroupLabel())
+		dialog.ShowConfirm("Видалити розклад", message, func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := store.DeleteSchedule(schedule.ID); err != nil {
+				ShowErrorDialog(win, "Розклад", err)
+				return
+			}
+			changed()
+			newForm()
+		}, win)
+	})
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("", objectLabel),
+		widget.NewFormItem("Група", group),
+	}
+	items = append(items, dayItems...)
+	items = append(items,
+		widget.NewFormItem("Допуск відкриття, хв", openTolerance),
+		widget.NewFormItem("Допуск закриття, хв", closeTolerance),
+		widget.NewFormItem("Свята об'єкта", ownHolidays),
+	)
+	form := widget.NewForm(items...)
+	hint := widget.NewLabel("Закриття раніше за відкриття означає нічну зміну. Спільні свята — на вкладці «Святкові дні».")
+	hint.Wrapping = fyne.TextWrapWord
+
+	reload()
+	newForm()
+	left := container.NewBorder(nil, container.NewVBox(container.NewHBox(saveButton, newButton, deleteButton), hint), nil, nil, container.NewVScroll(form))
+	right := container.NewBorder(status, nil, nil, nil, list)
+	split := container.NewHSplit(left, right)
+	split.Offset = 0.45
+	return split
+}
+
+func openCloseHolidaysTab(win fyne.Window, store *openclose.FileStore, onChanged func()) fyne.CanvasObject {
+	entry := widget.NewMultiLineEntry()
+	entry.SetPlaceHolder("ДД.ММ.РРРР назва — по рядку")
+	status := widget.NewLabel("")
+	load := func() {
+		holidays, err := store.Holidays()
+		if err != nil {
+			status.SetText("Не вдалося прочитати святкові дні: " + err.Error())
+			return
+		}
+		entry.SetText(openclose.FormatHolidays(holidays))
+		status.SetText(fmt.Sprintf("Святкових днів: %d", len(holidays)))
+	}
+	saveButton := widget.NewButton("Зберегти", func() {
+		holidays, err := openclose.ParseHolidays(entry.Text)
+		if err != nil {
+			ShowErrorDialog(win, "Святкові дні", err)
+			return
+		}
+		if err := store.SetHolidays(holidays); err != nil {
+			ShowErrorDialog(win, "Святкові дні", err)
+			return
+		}
+		load()
+		if onChanged != nil {
+			onChanged()
+		}
+	})
+	hint := widget.NewLabel("Спільні святкові дні діють для всіх розкладів: у ці дні об'єкти вважаються зачиненими.")
+	hint.Wrapping = fyne.TextWrapWord
+	load()
+	return container.NewBorder(container.NewVBox(hint, status), container.NewHBox(saveButton), nil, nil, entry)
+}
+
+func openCloseComplianceTab(win fyne.Window, store *openclose.FileStore) fyne.CanvasObject {
+	var (
+		rows       []openclose.ComplianceRow
+		violations []openclose.Violation
+	)
+	today := time.Now()
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today.AddDate(0, 0, -30).Format(openclose.FormDateLayout))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today.Format(openclose.FormDateLayout))
+	status := widget.NewLabel("")
+
+	rowList := widget.NewList(
+		func() int { return len(rows) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(rows) {
+				return
+			}
+			values := rows[id].Values()
+			item.(*widget.Label).SetText(fmt.Sprintf(
+				"%5s   №%s %s   |   днів %s, без порушень %s   |   не поставлено %s, поза розкладом %s, раннє %s, пізнє %s",
+				values[4], values[0], values[1], values[2], values[3], values[5], values[6], values[7], values[8],
+			))
+		},
+	)
+	violationList := widget.NewList(
+		func() int { return len(violations) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(violations) {
+				return
+			}
+			values := violations[id].Values()
+			line := fmt.Sprintf("%s   №%s %s   |   %s", values[0], values[1], values[2], values[5])
+			if values[6] != "" {
+				line += "   |   обробив " + values[6]
+			}
+			item.(*widget.Label).SetText(line)
+		},
+	)
+
+	load := func() {
+		from, err := time.ParseInLocation(openclose.FormDateLayout, strings.TrimSpace(fromEntry.Text), time.Local)
+		if err != nil {
+			status.SetText("Некоректна дата «від». Формат: ДД.ММ.РРРР")
+			return
+		}
+		to, err := time.ParseInLocation(openclose.FormDateLayout, strings.TrimSpace(toEntry.Text), time.Local)
+		if err != nil || to.Before(from) {
+			status.SetText("Некоректна дата «до» або діапазон дат")
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		schedules, err := store.Schedules()
+		if err != nil {
+			status.SetText("Не вдалося прочитати розклади: " + err.Error())
+			return
+		}
+		holidays, _ := store.Holidays()
+		loaded, err := store.Violations(from, to)
+		if err != nil {
+			status.SetText("Не вдалося прочитати порушення: " + err.Error())
+			return
+		}
+		violations = loaded
+		rows = openclose.Compliance(schedules, holidays, violations, from, to)
+		rowList.Refresh()
+		violationList.Refresh()
+		status.SetText(fmt.Sprintf("Об'єктів: %d | порушень: %d", len(rows), len(violations)))
+	}
+
+	exportCSV := func(fileName string, empty bool, write func(path string) error) {
+		if empty {
+			ShowInfoDialog(win, "Експорт CSV", "Немає даних для експорту.")
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				ShowErrorDialog(win, "Експорт CSV", err)
+				return
+			}
+			if uc == nil {
+				return
+			}
+			path := uriPathToLocalPath(uc.URI().Path())
+			_ = uc.Close()
+			if err := write(path); err != nil {
+				ShowErrorDialog(win, "Експорт CSV", err)
+				return
+			}
+			status.SetText("Експортовано: " + path)
+		}, win)
+		saveDialog.SetFileName(fileName + "_" + time.Now().Format("2006-01-02") + ".csv")
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+		saveDialog.Show()
+	}
+	exportRows := widget.NewButton("Звіт CSV", func() {
+		snapshot := append([]openclose.ComplianceRow(nil), rows...)
+		exportCSV("open_close_compliance", len(snapshot) == 0, func(path string) error {
+			return objexport.WriteOpenCloseComplianceCSV(path, snapshot)
+		})
+	})
+	exportViolations := widget.NewButton("Порушення CSV", func() {
+		snapshot := append([]openclose.Violation(nil), violations...)
+		exportCSV("open_close_violations", len(snapshot) == 0, func(path string) error {
+			return objexport.WriteOpenCloseViolationsCSV(path, snapshot)
+		})
+	})
+
+	controls := container.NewHBox(
+		widget.NewLabel("Від"), fromEntry,
+		widget.NewLabel("До"), toEntry,
+		widget.NewButton("Показати", load),
+		exportRows,
+		exportViolations,
+	)
+	load()
+	split := container.NewVSplit(rowList, violationList)
+	split.Offset = 0.5
+	return container.NewBorder(container.NewVBox(controls, status), nil, nil, nil, split)
+}
+
+func openCloseScheduleLine(schedule openclose.Schedule, holidays []openclose.Holiday, now time.Time) string {
+	return fmt.Sprintf(
+		"[%s]   №%s %s   |   %s   |   %s   |   допуск %d/%d хв",
+		schedule.StateLabel(now, holidays),
+		schedule.ObjectNumber,
+		schedule.ObjectName,
+		schedule.GroupLabel(),
+		schedule.WeekLabel(),
+		schedule.OpenTolerance,
+		schedule.CloseTolerance,
+	)
+}
@@ -0,0 +1,154 @@
+package dialogs
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"obj_catalog_fyne_v3/pkg/testsupervision"
+)
+
+// ShowOverdueTestsReport opens the dashboard of expected and overdue periodic tests.
+// source is rescanned on "Оновити"; onOpen is called for the object picked in the list.
+func ShowOverdueTestsReport(
+	supervisor *testsupervision.Supervisor,
+	source testsupervision.ObjectSource,
+	onOpen func(objectID int),
+) {
+	reportWindow := fyne.CurrentApp().NewWindow("Контроль періодичних тестів")
+	reportWindow.Resize(fyne.NewSize(1100, 640))
+
+	viewLabels := make([]string, 0, len(testsupervision.Views))
+	for _, view := range testsupervision.Views {
+		viewLabels = append(viewLabels, view.Label())
+	}
+	viewSelect := widget.NewSelect(viewLabels, nil)
+	viewSelect.SetSelectedIndex(0)
+	search := widget.NewEntry()
+	search.SetPlaceHolder("Пошук за номером або назвою")
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	var (
+		visible  []testsupervision.Expectation
+		renderAt time.Time
+		running  bool
+	)
+
+	list := widget.NewList(
+		func() int { return len(visible) },
+		func() fyne.CanvasObject {
+			marker := canvas.NewRectangle(color.Transparent)
+			marker.SetMinSize(fyne.NewSize(6, 0))
+			text := widget.NewLabel("")
+			text.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, marker, nil, text)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(visible) {
+				return
+			}
+			row := item.(*fyne.Container)
+			text := row.Objects[0].(*widget.Label)
+			marker := row.Objects[1].(*canvas.Rectangle)
+			expectation := visible[id]
+			marker.FillColor = overdueTestColor(expectation, renderAt)
+			marker.Refresh()
+			text.SetText(overdueTestLine(expectation, renderAt))
+		},
+	)
+
+	render := func() {
+		expectations, observedAt := supervisor.Expectations()
+		renderAt = time.Now()
+		view := testsupervision.Views[max(viewSelect.SelectedIndex(), 0)]
+		visible = testsupervision.Filter(expectations, view, search.Text, renderAt)
+		list.UnselectAll()
+		list.Refresh()
+		if running {
+			return
+		}
+		text := testsupervision.Summary(expectations, renderAt) + fmt.Sprintf(" | показано: %d", len(visible))
+		if !observedAt.IsZero() {
+			text += " | станом на " + observedAt.Format("15:04:05")
+		}
+		status.SetText(text)
+	}
+	search.OnChanged = func(string) { render() }
+	viewSelect.OnChanged = func(string) { render() }
+
+	var refreshButton *widget.Button
+	refresh := func() {
+		if source == nil || running {
+			return
+		}
+		running = true
+		refreshButton.Disable()
+		status.SetText("Завантаження об'єктів...")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			supervisor.Scan(ctx, source)
+			fyne.Do(func() {
+				running = false
+				refreshButton.Enable()
+				render()
+			})
+		}()
+	}
+	refreshButton = widget.NewButton("Оновити", refresh)
+
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < 0 || id >= len(visible) || onOpen == nil {
+			return
+		}
+		objectID := visible[id].ObjectID
+		list.UnselectAll()
+		onOpen(objectID)
+	}
+
+	controls := container.NewBorder(nil, nil,
+		container.NewHBox(widget.NewLabel("Показати"), viewSelect),
+		refreshButton,
+		search,
+	)
+	hint := widget.NewLabel("Оберіть рядок, щоб перейти до об'єкта. " + supervisor.Settings().Label())
+	hint.Wrapping = fyne.TextWrapWord
+	reportWindow.SetContent(container.NewBorder(
+		container.NewVBox(controls, status),
+		hint, nil, nil,
+		list,
+	))
+	reportWindow.Show()
+	render()
+	if _, observedAt := supervisor.Expectations(); observedAt.IsZero() {
+		refresh()
+	}
+}
+
+func overdueTestLine(expectation testsupervision.Expectation, now time.Time) string {
+	values := expectation.RowValues(now)
+	line := fmt.Sprintf("%-20s   %-10s   №%s   %s   |   період %s   |   ост. тест %s   |   очікувався %s",
+		values[0], values[1], values[2], values[3], values[4], values[5], values[6])
+	if values[7] != "" {
+		line += "   |   прострочено на " + values[7]
+	}
+	return line
+}
+
+func overdueTestColor(expectation testsupervision.Expectation, now time.Time) color.Color {
+	switch {
+	case expectation.Overdue(now):
+		return color.NRGBA{R: 255, G: 193, B: 7, A: 255}
+	case !expectation.HasData():
+		return color.NRGBA{R: 108, G: 117, B: 125, A: 255}
+	default:
+		return color.Transparent
+	}
+}
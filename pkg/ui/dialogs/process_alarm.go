@@ -279,6 +279,9 @@ func buildAlarmSummaryContent(alarm models.Alarm) fyne.CanvasObject {
 	if details := strings.TrimSpace(alarm.Details); details != "" {
 		lines = append(lines, fmt.Sprintf("Деталі: %s", details))
 	}
+	if !alarm.Priority.IsZero() {
+		lines = append(lines, fmt.Sprintf("Пріоритет: %s", alarm.Priority.Breakdown()))
+	}
 	return widget.NewLabel(strings.Join(lines, "\n"))
 }
 
@@ -48,6 +48,8 @@ type settingsDialogState struct {
 	uiCfg config.UIConfig
 	vfCfg config.VodafoneConfig
 	ksCfg config.KyivstarConfig
+	ruCfg config.ReportUploadConfig
+	dpCfg config.DispatchConfig
 
 	vfAuthVM *viewmodels.VodafoneAuthViewModel
 	ksAuthVM *viewmodels.KyivstarAuthViewModel
@@ -78,6 +80,8 @@ type settingsDialogState struct {
 	caslEmailEntry               *widget.Entry
 	caslPassEntry                *widget.Entry
 	caslPultIDEntry              *widget.Entry
+	caslExtraPultsEntry          *widget.Entry
+	sourceInstancesEntry         *widget.Entry
 	caslEnabledCheck             *widget.Check
 	vodafonePhoneEntry           *widget.Entry
 	vodafoneLoginMethodRadio     *widget.RadioGroup
@@ -112,6 +116,23 @@ type settingsDialogState struct {
 	schedulerHelpLabel           *widget.Label
 	exportDirEntry               *widget.Entry
 	logLevelSelect               *widget.Select
+	uploadTargetSelect           *widget.Select
+	uploadGDriveCredentialsEntry *widget.Entry
+	uploadGDriveTokenEntry       *widget.Entry
+	uploadLocalDirEntry          *widget.Entry
+	uploadWebDAVURLEntry         *widget.Entry
+	uploadWebDAVUserEntry        *widget.Entry
+	uploadWebDAVPasswordEntry    *widget.Entry
+	uploadS3EndpointEntry        *widget.Entry
+	uploadS3RegionEntry          *widget.Entry
+	uploadS3BucketEntry          *widget.Entry
+	uploadS3AccessKeyEntry       *widget.Entry
+	uploadS3SecretKeyEntry       *widget.Entry
+	uploadS3PathStyleCheck       *widget.Check
+	uploadPublicBaseURLEntry     *widget.Entry
+
+	dispatchRoadDistanceCheck *widget.Check
+	dispatchRoutingURLEntry   *widget.Entry
 }
 
 func ShowSettingsDialog(
@@ -152,6 +173,8 @@ func newSettingsDialogState(
 		uiCfg:           config.LoadUIConfig(pref),
 		vfCfg:           config.LoadVodafoneConfig(pref),
 		ksCfg:           config.LoadKyivstarConfig(pref),
+		ruCfg:           config.LoadReportUploadConfig(pref),
+		dpCfg:           config.LoadDispatchConfig(pref),
 		vfAuthVM:        viewmodels.NewVodafoneAuthViewModel(),
 		ksAuthVM:        viewmodels.NewKyivstarAuthViewModel(),
 	}
@@ -159,6 +182,8 @@ func newSettingsDialogState(
 	s.initDatabaseFields()
 	s.initCarrierFields()
 	s.initUIFields()
+	s.initReportUploadFields()
+	s.initDispatchFields()
 
 	return s
 }
@@ -241,6 +266,15 @@ func (s *settingsDialogState) initDatabaseFields() {
 	}
 	s.caslPultIDEntry.SetPlaceHolder("0 = авто")
 
+	s.caslExtraPultsEntry = widget.NewEntry()
+	s.caslExtraPultsEntry.SetText(config.FormatCASLPultIDs(s.dbCfg.CASLExtraPultIDs))
+	s.caslExtraPultsEntry.SetPlaceHolder("Через кому, напр. 12, 15")
+
+	s.sourceInstancesEntry = widget.NewMultiLineEntry()
+	s.sourceInstancesEntry.SetText(config.FormatSourceInstances(s.dbCfg.SourceInstances))
+	s.sourceInstancesEntry.SetPlaceHolder(`[{"name": "Філія", "type": "firebird", "path": "D:/BASE/MOST5.FDB"}]`)
+	s.sourceInstancesEntry.SetMinRowsVisible(8)
+
 	s.caslEnabledCheck = widget.NewCheck("Увімкнути CASL Cloud паралельно з БД/мостом", nil)
 	s.caslEnabledCheck.SetChecked(s.dbCfg.CASLEnabled || s.dbCfg.NormalizedMode() == config.BackendModeCASLCloud)
 }
@@ -377,6 +411,61 @@ func (s *settingsDialogState) initUIFields() {
 	}
 }
 
+func (s *settingsDialogState) initDispatchFields() {
+	s.dispatchRoadDistanceCheck = widget.NewCheck("Рахувати відстань до об'єкта дорогою (OSRM)", nil)
+	s.dispatchRoadDistanceCheck.SetChecked(s.dpCfg.RoadDistance)
+	s.dispatchRoutingURLEntry = widget.NewEntry()
+	s.dispatchRoutingURLEntry.SetText(s.dpCfg.RoutingURL)
+	s.dispatchRoutingURLEntry.SetPlaceHolder(config.DefaultDispatchRoutingURL)
+}
+
+func (s *settingsDialogState) initReportUploadFields() {
+	s.uploadTargetSelect = widget.NewSelect(reportUploadTargetOptions(s.ruCfg.TargetSelected()), nil)
+	if s.ruCfg.TargetSelected() {
+		s.uploadTargetSelect.SetSelected(reportUploadTargetLabel(s.ruCfg.NormalizedTarget()))
+	} else {
+		s.uploadTargetSelect.SetSelected(reportUploadTargetUnselectedLabel)
+	}
+
+	s.uploadGDriveCredentialsEntry = widget.NewEntry()
+	s.uploadGDriveCredentialsEntry.SetText(s.ruCfg.GDriveCredentialsPath)
+	s.uploadGDriveCredentialsEntry.SetPlaceHolder(config.DefaultReportUploadGDriveCredentials)
+	s.uploadGDriveTokenEntry = widget.NewEntry()
+	s.uploadGDriveTokenEntry.SetText(s.ruCfg.GDriveTokenPath)
+	s.uploadGDriveTokenEntry.SetPlaceHolder(config.DefaultReportUploadGDriveToken)
+
+	s.uploadLocalDirEntry = widget.NewEntry()
+	s.uploadLocalDirEntry.SetText(s.ruCfg.LocalDir)
+	s.uploadLocalDirEntry.SetPlaceHolder(`\\server\reports`)
+
+	s.uploadWebDAVURLEntry = widget.NewEntry()
+	s.uploadWebDAVURLEntry.SetText(s.ruCfg.WebDAVURL)
+	s.uploadWebDAVURLEntry.SetPlaceHolder("https://cloud.example/remote.php/dav/files/user")
+	s.uploadWebDAVUserEntry = widget.NewEntry()
+	s.uploadWebDAVUserEntry.SetText(s.ruCfg.WebDAVUser)
+	s.uploadWebDAVPasswordEntry = widget.NewPasswordEntry()
+	s.uploadWebDAVPasswordEntry.SetText(s.ruCfg.WebDAVPassword)
+
+	s.uploadS3EndpointEntry = widget.NewEntry()
+	s.uploadS3EndpointEntry.SetText(s.ruCfg.S3Endpoint)
+	s.uploadS3EndpointEntry.SetPlaceHolder("https://s3.example.com")
+	s.uploadS3RegionEntry = widget.NewEntry()
+	s.uploadS3RegionEntry.SetText(s.ruCfg.S3Region)
+	s.uploadS3RegionEntry.SetPlaceHolder(config.DefaultReportUploadS3Region)
+	s.uploadS3BucketEntry = widget.NewEntry()
+	s.uploadS3BucketEntry.SetText(s.ruCfg.S3Bucket)
+	s.uploadS3AccessKeyEntry = widget.NewEntry()
+	s.uploadS3AccessKeyEntry.SetText(s.ruCfg.S3AccessKey)
+	s.uploadS3SecretKeyEntry = widget.NewPasswordEntry()
+	s.uploadS3SecretKeyEntry.SetText(s.ruCfg.S3SecretKey)
+	s.uploadS3PathStyleCheck = widget.NewCheck("Path-style адреси (MinIO, Ceph)", nil)
+	s.uploadS3PathStyleCheck.SetChecked(s.ruCfg.S3PathStyle)
+
+	s.uploadPublicBaseURLEntry = widget.NewEntry()
+	s.uploadPublicBaseURLEntry.SetText(s.ruCfg.PublicBaseURL)
+	s.uploadPublicBaseURLEntry.SetPlaceHolder("Необов'язково: адреса для посилань у звітах")
+}
+
 func (s *settingsDialogState) buildDialog() dialog.Dialog {
 	d := dialog.NewCustomConfirm(
 		"Налаштування системи",
@@ -401,10 +490,13 @@ func (s *settingsDialogState) buildTabs() *container.AppTabs {
 		container.NewTabItem("База даних", s.buildDatabaseTab()),
 		container.NewTabItem("Phoenix", s.buildPhoenixTab()),
 		container.NewTabItem("CASL Cloud", s.buildCASLTab()),
+		container.NewTabItem("Джерела", s.buildSourceInstancesTab()),
 		container.NewTabItem("Vodafone", s.buildVodafoneTab()),
 		container.NewTabItem("Kyivstar", s.buildKyivstarTab()),
 		container.NewTabItem("Інтерфейс", s.buildInterfaceTab()),
 		container.NewTabItem("Оновлення", s.buildRefreshTab()),
+		container.NewTabItem("Звіти", s.buildReportUploadTab()),
+		container.NewTabItem("Диспетчеризація", s.buildDispatchTab()),
 	)
 }
 
@@ -453,9 +545,18 @@ func (s *settingsDialogState) buildCASLTab() fyne.CanvasObject {
 		widget.NewFormItem("Email", s.caslEmailEntry),
 		widget.NewFormItem("Password", s.caslPassEntry),
 		widget.NewFormItem("Pult ID", s.caslPultIDEntry),
+		widget.NewFormItem("Додаткові пульти", s.caslExtraPultsEntry),
 	)
 }
 
+func (s *settingsDialogState) buildSourceInstancesTab() fyne.CanvasObject {
+	help := widget.NewLabel("Додаткові БД МІСТ, сервери Phoenix чи CASL поряд з основними (JSON-список). " +
+		"Тип: firebird, phoenix або casl. Порожні поля підключення беруться з основного джерела цього типу; " +
+		"додаткові джерела підключаються лише разом з основним і працюють тільки на моніторинг.")
+	help.Wrapping = fyne.TextWrapWord
+	return container.NewBorder(help, nil, nil, nil, s.sourceInstancesEntry)
+}
+
 func (s *settingsDialogState) buildVodafoneTab() fyne.CanvasObject {
 	return container.NewVBox(
 		widget.NewLabel("Авторизація Vodafone для батьківського номера. PUK-код зберігається локально і використовується для автоматичного поновлення токена."),
@@ -525,6 +626,46 @@ func (s *settingsDialogState) buildRefreshTab() fyne.CanvasObject {
 	)
 }
 
+func (s *settingsDialogState) buildReportUploadTab() fyne.CanvasObject {
+	return container.NewVScroll(container.NewVBox(
+		widget.NewLabel("Куди публікуються згенеровані звіти та PDF знятих об'єктів."),
+		widget.NewForm(
+			widget.NewFormItem("Сховище", s.uploadTargetSelect),
+			widget.NewFormItem("Публічна адреса", s.uploadPublicBaseURLEntry),
+		),
+		widget.NewCard("Google Drive", "", widget.NewForm(
+			widget.NewFormItem("credentials.json", s.uploadGDriveCredentialsEntry),
+			widget.NewFormItem("token.json", s.uploadGDriveTokenEntry),
+		)),
+		widget.NewCard("Спільна папка", "", widget.NewForm(
+			widget.NewFormItem("Папка / SMB", s.uploadLocalDirEntry),
+		)),
+		widget.NewCard("WebDAV", "", widget.NewForm(
+			widget.NewFormItem("URL", s.uploadWebDAVURLEntry),
+			widget.NewFormItem("Користувач", s.uploadWebDAVUserEntry),
+			widget.NewFormItem("Пароль", s.uploadWebDAVPasswordEntry),
+		)),
+		widget.NewCard("S3", "", widget.NewForm(
+			widget.NewFormItem("Endpoint", s.uploadS3EndpointEntry),
+			widget.NewFormItem("Region", s.uploadS3RegionEntry),
+			widget.NewFormItem("Bucket", s.uploadS3BucketEntry),
+			widget.NewFormItem("Access key", s.uploadS3AccessKeyEntry),
+			widget.NewFormItem("Secret key", s.uploadS3SecretKeyEntry),
+			widget.NewFormItem("", s.uploadS3PathStyleCheck),
+		)),
+	))
+}
+
+func (s *settingsDialogState) buildDispatchTab() fyne.CanvasObject {
+	return container.NewVScroll(container.NewVBox(
+		widget.NewLabel("Картка реагування пропонує МГР за геозоною об'єкта та відстанню. Без маршрутизації відстань рахується по прямій."),
+		widget.NewForm(
+			widget.NewFormItem("", s.dispatchRoadDistanceCheck),
+			widget.NewFormItem("OSRM сервер", s.dispatchRoutingURLEntry),
+		),
+	))
+}
+
 func (s *settingsDialogState) buildExportDirRow() fyne.CanvasObject {
 	browseExportDirBtn := makeIconButton("Обрати...", iconFolder(), widget.MediumImportance, func() {
 		dialog.NewFolderOpen(func(uri fyne.ListableURI, err error) {
@@ -886,14 +1027,26 @@ func (s *settingsDialogState) verifyPhoenixOperator() {
 
 func (s *settingsDialogState) applySave() {
 	newDbCfg := s.buildDBConfigFromForm()
+	if instances, err := config.ParseSourceInstances(s.sourceInstancesEntry.Text); err != nil {
+		newDbCfg.SourceInstances = s.dbCfg.SourceInstances
+		dialog.ShowError(fmt.Errorf("додаткові джерела не збережено: %w", err), s.win)
+	} else {
+		newDbCfg.SourceInstances = instances
+	}
 	newUiCfg := s.buildUIConfigFromForm()
 	newVodafoneCfg := s.buildVodafoneConfigFromForm()
 	newKyivstarCfg := s.buildKyivstarConfigFromForm()
+	newReportUploadCfg := s.buildReportUploadConfigFromForm()
 
 	config.SaveDBConfig(s.pref, newDbCfg)
 	config.SaveUIConfig(s.pref, newUiCfg)
 	config.SaveVodafoneConfig(s.pref, newVodafoneCfg)
 	config.SaveKyivstarConfig(s.pref, newKyivstarCfg)
+	config.SaveReportUploadConfig(s.pref, newReportUploadCfg)
+	config.SaveDispatchConfig(s.pref, config.DispatchConfig{
+		RoadDistance: s.dispatchRoadDistanceCheck.Checked,
+		RoutingURL:   s.dispatchRoutingURLEntry.Text,
+	})
 
 	if s.onSave != nil {
 		s.onSave(newDbCfg, newUiCfg)
@@ -959,6 +1112,8 @@ func (s *settingsDialogState) buildDBConfigFromForm() config.DBConfig {
 		CASLEmail:               strings.TrimSpace(s.caslEmailEntry.Text),
 		CASLPass:                strings.TrimSpace(s.caslPassEntry.Text),
 		CASLPultID:              caslPultID,
+		CASLExtraPultIDs:        config.ParseCASLPultIDs(s.caslExtraPultsEntry.Text),
+		SourceInstances:         s.dbCfg.SourceInstances,
 		LogLevel:                strings.ToLower(strings.TrimSpace(s.logLevelSelect.Selected)),
 	}
 }
@@ -1024,6 +1179,25 @@ func (s *settingsDialogState) buildKyivstarConfigFromForm() config.KyivstarConfi
 	return newCfg
 }
 
+func (s *settingsDialogState) buildReportUploadConfigFromForm() config.ReportUploadConfig {
+	return config.ReportUploadConfig{
+		Target:                reportUploadTargetValue(s.uploadTargetSelect.Selected),
+		GDriveCredentialsPath: strings.TrimSpace(s.uploadGDriveCredentialsEntry.Text),
+		GDriveTokenPath:       strings.TrimSpace(s.uploadGDriveTokenEntry.Text),
+		LocalDir:              strings.TrimSpace(s.uploadLocalDirEntry.Text),
+		WebDAVURL:             strings.TrimSpace(s.uploadWebDAVURLEntry.Text),
+		WebDAVUser:            strings.TrimSpace(s.uploadWebDAVUserEntry.Text),
+		WebDAVPassword:        s.uploadWebDAVPasswordEntry.Text,
+		S3Endpoint:            strings.TrimSpace(s.uploadS3EndpointEntry.Text),
+		S3Region:              strings.TrimSpace(s.uploadS3RegionEntry.Text),
+		S3Bucket:              strings.TrimSpace(s.uploadS3BucketEntry.Text),
+		S3AccessKey:           strings.TrimSpace(s.uploadS3AccessKeyEntry.Text),
+		S3SecretKey:           s.uploadS3SecretKeyEntry.Text,
+		S3PathStyle:           s.uploadS3PathStyleCheck.Checked,
+		PublicBaseURL:         strings.TrimSpace(s.uploadPublicBaseURLEntry.Text),
+	}
+}
+
 func parseFloat32(raw string) float32 {
 	parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 32)
 	if err != nil {
@@ -1083,3 +1257,46 @@ func bridgeAlarmHistoryModeValue(label string) string {
 		return config.BridgeAlarmHistoryModeActiveOnly
 	}
 }
+
+// reportUploadTargetUnselectedLabel показує ціль, яку ще не обирали: звіт
+// прийнятих об'єктів тоді не публікується, а звіт видалених іде в Google Drive.
+const reportUploadTargetUnselectedLabel = "Не обрано (звіт видалених — Google Drive)"
+
+func reportUploadTargetOptions(selected bool) []string {
+	targets := config.ReportUploadTargets()
+	options := make([]string, 0, len(targets)+1)
+	if !selected {
+		options = append(options, reportUploadTargetUnselectedLabel)
+	}
+	for _, target := range targets {
+		options = append(options, reportUploadTargetLabel(target))
+	}
+	return options
+}
+
+func reportUploadTargetLabel(target string) string {
+	switch config.NormalizeReportUploadTarget(target) {
+	case config.ReportUploadTargetNone:
+		return "Не завантажувати"
+	case config.ReportUploadTargetLocal:
+		return "Спільна папка (локальна / SMB)"
+	case config.ReportUploadTargetWebDAV:
+		return "WebDAV"
+	case config.ReportUploadTargetS3:
+		return "S3-сумісне сховище"
+	default:
+		return "Google Drive"
+	}
+}
+
+func reportUploadTargetValue(label string) string {
+	if label == reportUploadTargetUnselectedLabel {
+		return ""
+	}
+	for _, target := range config.ReportUploadTargets() {
+		if reportUploadTargetLabel(target) == label {
+			return target
+		}
+	}
+	return config.ReportUploadTargetGoogleDrive
+}
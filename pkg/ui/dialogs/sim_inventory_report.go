@@ -107,14 +107,18 @@ func ShowSIMInventoryReportDialog(parent fyne.Window, provider viewmodels.SIMInv
 			if uc == nil {
 				return
 			}
-			defer uc.Close()
-
+			path := uriPathToLocalPath(uc.URI().Path())
 			statusLabel.SetText("Формую TSV для експорту...")
-			if _, err := uc.Write([]byte(vm.BuildTSV(lastRows))); err != nil {
+			_, err = uc.Write([]byte(vm.BuildTSV(lastRows)))
+			if closeErr := uc.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
 				dialog.ShowError(err, win)
 				return
 			}
-			statusLabel.SetText(fmt.Sprintf("TSV експортовано: %s", uriPathToLocalPath(uc.URI().Path())))
+			statusLabel.SetText(fmt.Sprintf("TSV експортовано: %s", path))
+			publishExportedReport(statusLabel, path)
 		}, win).Show()
 	})
 	exportTSVBtn.Disable()
@@ -132,14 +136,18 @@ func ShowSIMInventoryReportDialog(parent fyne.Window, provider viewmodels.SIMInv
 			if uc == nil {
 				return
 			}
-			defer uc.Close()
-
+			path := uriPathToLocalPath(uc.URI().Path())
 			statusLabel.SetText("Формую CSV для експорту...")
-			if _, err := uc.Write([]byte(vm.BuildCSV(lastRows))); err != nil {
+			_, err = uc.Write([]byte(vm.BuildCSV(lastRows)))
+			if closeErr := uc.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
 				dialog.ShowError(err, win)
 				return
 			}
-			statusLabel.SetText(fmt.Sprintf("CSV експортовано: %s", uriPathToLocalPath(uc.URI().Path())))
+			statusLabel.SetText(fmt.Sprintf("CSV експортовано: %s", path))
+			publishExportedReport(statusLabel, path)
 		}, win).Show()
 	})
 	exportCSVBtn.Disable()
@@ -2,11 +2,13 @@
 package ui
 
 import (
+	"errors"
 	"image/color"
 	"obj_catalog_fyne_v3/pkg/config"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -18,6 +20,7 @@ import (
 
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 	"obj_catalog_fyne_v3/pkg/usecases"
 )
@@ -33,9 +36,17 @@ type EventLogPanel struct {
 	PauseBtn        *widget.Button
 	RangeSelect     *widget.Select
 	SourceSelect    *widget.Select
+	GroupSelect     *widget.Select
 	ImportantOnly   *widget.Check
+	QueryEntry      *widget.Entry
 	OnEventSelected func(models.Event)
 	OnCountChanged  func(count int)
+	// OnExportRequested відкриває експорт журналу з поточними фільтрами панелі.
+	OnExportRequested func()
+	// OnSavedViewsRequested показує меню збережених подань для рядка фільтра.
+	OnSavedViewsRequested func(anchor fyne.CanvasObject, entry *widget.Entry)
+	// Groups — спільні групи об'єктів; nil вимикає фільтр груп.
+	Groups *objectgroups.Registry
 
 	// Кеш даних
 	AllEvents      []models.Event
@@ -50,6 +61,8 @@ type EventLogPanel struct {
 	currentObject *models.Object
 	// Перемикач режиму: всі події чи тільки по вибраному об'єкту
 	showForCurrentOnly bool
+	// ID вибраної групи об'єктів; порожній — усі об'єкти
+	currentGroup string
 }
 
 // NewEventLogPanel створює панель журналу подій
@@ -92,6 +105,16 @@ func NewEventLogPanel(provider contracts.EventProvider) *EventLogPanel {
 	panel.SourceSelect.SetSelected(panel.SourceSelect.Options[0])
 	panel.SourceSelect.PlaceHolder = "Джерело"
 
+	panel.GroupSelect = widget.NewSelect(nil, func(selected string) {
+		groupID := viewmodels.ObjectGroupIDByOption(panel.Groups.Groups(), selected)
+		panel.mutex.Lock()
+		panel.currentGroup = groupID
+		panel.mutex.Unlock()
+		panel.applyFilters()
+	})
+	panel.GroupSelect.PlaceHolder = "Група"
+	panel.GroupSelect.Hide()
+
 	panel.ImportantOnly = widget.NewCheck("Важливі", func(bool) {
 		panel.applyFilters()
 	})
@@ -102,14 +125,37 @@ func NewEventLogPanel(provider contracts.EventProvider) *EventLogPanel {
 		panel.applyFilters()
 	})
 
+	panel.QueryEntry = widget.NewEntry()
+	panel.QueryEntry.SetPlaceHolder("Фільтр: severity:critical source:casl ...")
+	panel.QueryEntry.AlwaysShowValidationError = true
+	panel.QueryEntry.OnChanged = func(string) {
+		panel.applyFilters()
+	}
+	var savedViewsBtn *widget.Button
+	savedViewsBtn = widget.NewButton("★", func() {
+		if panel.OnSavedViewsRequested != nil {
+			panel.OnSavedViewsRequested(savedViewsBtn, panel.QueryEntry)
+		}
+	})
+
+	exportBtn := widget.NewButton("⤓ Експорт", func() {
+		if panel.OnExportRequested != nil {
+			panel.OnExportRequested()
+		}
+	})
+
 	header := container.NewHBox(
 		container.NewPadded(panel.TitleText),
 		layout.NewSpacer(),
+		container.NewGridWrap(fyne.NewSize(260, panel.QueryEntry.MinSize().Height), panel.QueryEntry),
+		savedViewsBtn,
 		contextToggle,
 		panel.SourceSelect,
+		panel.GroupSelect,
 		panel.RangeSelect,
 		panel.ImportantOnly,
 		panel.PauseBtn,
+		exportBtn,
 	)
 
 	// Список подій (тепер використовує кеш)
@@ -261,7 +307,9 @@ func (p *EventLogPanel) applyFilters() {
 	all := p.AllEvents
 	currentObj := p.currentObject
 	showForCurrentOnly := p.showForCurrentOnly
+	currentGroup := p.currentGroup
 	p.mutex.RUnlock()
+	groups := p.Groups.Groups()
 
 	period := ""
 	if p.RangeSelect != nil {
@@ -276,9 +324,16 @@ func (p *EventLogPanel) applyFilters() {
 		selectedSource = viewmodels.NormalizeObjectSourceFilter(p.SourceSelect.Selected)
 	}
 
+	query := ""
+	if p.QueryEntry != nil {
+		query = p.QueryEntry.Text
+	}
+
 	uiCfg := config.LoadUIConfig(fyne.CurrentApp().Preferences())
 	input := viewmodels.EventLogFilterInput{
 		AllEvents:          all,
+		Query:              query,
+		GroupMembers:       p.Groups.Membership().Members(currentGroup),
 		Period:             period,
 		SelectedSource:     selectedSource,
 		ImportantOnly:      importantOnly,
@@ -300,7 +355,22 @@ func (p *EventLogPanel) applyFilters() {
 			options := viewmodels.BuildObjectSourceOptions(out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL)
 			updateSelectPreservingValue(p.SourceSelect, options, selectedSource)
 		}
+		if p.GroupSelect != nil {
+			if len(groups) == 0 {
+				p.GroupSelect.Hide()
+			} else {
+				updateSelectPreservingValue(p.GroupSelect, viewmodels.ObjectGroupOptions(groups), viewmodels.ObjectGroupOptionByID(groups, currentGroup))
+				p.GroupSelect.Show()
+			}
+		}
 
+		if p.QueryEntry != nil {
+			if out.QueryError != "" {
+				p.QueryEntry.SetValidationError(errors.New(out.QueryError))
+			} else {
+				p.QueryEntry.SetValidationError(nil)
+			}
+		}
 		_ = SetUntypedList(p.listData, out.Filtered)
 		ensureJournalListMinWidth(p.listWidthGuide, eventLogRowTexts(out.Filtered), p.lastFontSize, fyne.TextStyle{})
 		if p.OnCountChanged != nil {
@@ -390,6 +460,23 @@ func (p *EventLogPanel) SetCurrentObject(obj *models.Object) {
 	p.applyFilters()
 }
 
+// ExportPreset повертає фільтри панелі як початкові значення форми експорту.
+func (p *EventLogPanel) ExportPreset() viewmodels.EventExportFilterInput {
+	p.mutex.RLock()
+	var currentObj *models.Object
+	if p.showForCurrentOnly {
+		currentObj = p.currentObject
+	}
+	p.mutex.RUnlock()
+
+	selectedSource := viewmodels.ObjectSourceAll
+	if p.SourceSelect != nil {
+		selectedSource = p.SourceSelect.Selected
+	}
+	importantOnly := p.ImportantOnly != nil && p.ImportantOnly.Checked
+	return viewmodels.EventExportPreset(selectedSource, "", importantOnly, currentObj, time.Now())
+}
+
 func formatEventLogRowText(event models.Event) string {
 	objectID := strings.TrimSpace(event.ObjectNumber)
 	objectName := strings.TrimSpace(event.ObjectName)
@@ -18,6 +18,7 @@ import (
 	"obj_catalog_fyne_v3/pkg/config"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/objectgroups"
 	appTheme "obj_catalog_fyne_v3/pkg/theme"
 	"obj_catalog_fyne_v3/pkg/ui/viewmodels"
 	"obj_catalog_fyne_v3/pkg/usecases"
@@ -28,9 +29,13 @@ type ObjectListPanel struct {
 	Table        *objectListTable
 	SearchEntry  *widget.Entry
 	SearchClear  *widget.Button
+	SavedViews   *widget.Button
+	QueryError   *widget.Label
 	FilteredData binding.UntypedList
 	FilterSelect *widget.Select
 	SourceSelect *widget.Select
+	PultSelect   *widget.Select
+	GroupSelect  *widget.Select
 	Data         contracts.ObjectProvider
 	ViewModel    *viewmodels.ObjectListViewModel
 	ColumnHeader *fyne.Container
@@ -43,6 +48,8 @@ type ObjectListPanel struct {
 
 	CurrentFilter string
 	CurrentSource string
+	CurrentPult   string
+	CurrentGroup  string
 	LoadingLabel  *widget.Label
 	SelectedRow   int
 	SelectedCol   int
@@ -62,6 +69,10 @@ type ObjectListPanel struct {
 
 	// Callback при виборі об'єкта
 	OnObjectSelected func(object models.Object)
+	// OnSavedViewsRequested показує меню збережених подань для рядка пошуку.
+	OnSavedViewsRequested func(anchor fyne.CanvasObject, entry *widget.Entry)
+	// Groups — спільні групи об'єктів; nil вимикає фільтр груп.
+	Groups *objectgroups.Registry
 }
 
 const defaultObjectListSearchDebounceDelay = 250 * time.Millisecond
@@ -115,6 +126,7 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 		FilteredData:        binding.NewUntypedList(),
 		CurrentFilter:       viewmodels.FilterAll,
 		CurrentSource:       viewmodels.ObjectSourceAll,
+		CurrentPult:         viewmodels.ObjectPultAll,
 		SelectedRow:         -1,
 		SelectedCol:         0,
 		colNameWidth:        200,
@@ -130,7 +142,7 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 
 	// Поле пошуку
 	panel.SearchEntry = widget.NewEntry()
-	panel.SearchEntry.SetPlaceHolder("🔍 Пошук (№, Назва, Адреса, SIM, Тел...)")
+	panel.SearchEntry.SetPlaceHolder("🔍 Пошук (№, Назва, Адреса, SIM...) або status:offline lasttest>48h")
 	panel.SearchClear = widget.NewButtonWithIcon("", theme.ContentClearIcon(), func() {
 		if panel.SearchEntry == nil {
 			return
@@ -141,6 +153,16 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 		}
 	})
 	panel.SearchClear.Disable()
+	panel.SavedViews = widget.NewButton("★", nil)
+	panel.SavedViews.OnTapped = func() {
+		if panel.OnSavedViewsRequested != nil {
+			panel.OnSavedViewsRequested(panel.SavedViews, panel.SearchEntry)
+		}
+	}
+	panel.QueryError = widget.NewLabel("")
+	panel.QueryError.Wrapping = fyne.TextWrapWord
+	panel.QueryError.Importance = widget.DangerImportance
+	panel.QueryError.Hide()
 	panel.SearchEntry.OnChanged = func(text string) {
 		if panel.SearchClear != nil {
 			if strings.TrimSpace(text) == "" {
@@ -178,6 +200,28 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 	)
 	panel.SourceSelect.PlaceHolder = "Джерело"
 
+	// Фільтр пультів показується лише коли CASL обслуговує кілька пультів.
+	panel.PultSelect = widget.NewSelect(nil, func(selected string) {
+		if panel.isUpdating {
+			return
+		}
+		panel.CurrentPult = viewmodels.NormalizeObjectPultFilter(selected)
+		panel.scheduleFilterApply(0)
+	})
+	panel.PultSelect.PlaceHolder = "Пульт"
+	panel.PultSelect.Hide()
+
+	// Фільтр груп показується, лише коли групи створено.
+	panel.GroupSelect = widget.NewSelect(nil, func(selected string) {
+		if panel.isUpdating {
+			return
+		}
+		panel.CurrentGroup = viewmodels.ObjectGroupIDByOption(panel.Groups.Groups(), selected)
+		panel.scheduleFilterApply(0)
+	})
+	panel.GroupSelect.PlaceHolder = "Група"
+	panel.GroupSelect.Hide()
+
 	// Лейбл завантаження
 	panel.LoadingLabel = widget.NewLabel("Завантаження даних...")
 	panel.LoadingLabel.Alignment = fyne.TextAlignCenter
@@ -241,6 +285,12 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 				cellText = viewmodels.ObjectDisplayNumber(item)
 			case 1:
 				cellText = fmt.Sprintf("%s %s", viewmodels.SourceBadgeForObjectID(item.ID), item.Name)
+				if item.Pult != "" {
+					cellText += " (" + item.Pult + ")"
+				}
+				if item.SourceInstance != "" {
+					cellText += " [" + item.SourceInstance + "]"
+				}
 			case 2:
 				cellText = item.Address
 			case 3:
@@ -297,8 +347,11 @@ func NewObjectListPanel(provider contracts.ObjectProvider) *ObjectListPanel {
 	// Збираємо все разом
 	header := container.NewVBox(
 		container.NewPadded(panel.TitleText),
-		container.NewBorder(nil, nil, nil, panel.SearchClear, panel.SearchEntry),
+		container.NewBorder(nil, nil, nil, container.NewHBox(panel.SearchClear, panel.SavedViews), panel.SearchEntry),
+		panel.QueryError,
 		container.NewGridWithColumns(2, panel.FilterSelect, panel.SourceSelect),
+		panel.PultSelect,
+		panel.GroupSelect,
 		panel.ColumnHeader,
 	)
 
@@ -355,6 +408,13 @@ func (p *ObjectListPanel) RefreshData() {
 	p.scheduleFilterApply(0)
 }
 
+// Objects повертає останній завантажений список об'єктів.
+func (p *ObjectListPanel) Objects() []models.Object {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.AllObjects
+}
+
 func (p *ObjectListPanel) applyFilters() {
 	p.scheduleFilterApply(0)
 }
@@ -414,6 +474,8 @@ func (p *ObjectListPanel) runFilterRequest(version uint64) {
 		query         string
 		currentFilter string
 		currentSource string
+		currentPult   string
+		currentGroup  string
 	}
 
 	var (
@@ -429,6 +491,8 @@ func (p *ObjectListPanel) runFilterRequest(version uint64) {
 		}
 		state.currentFilter = p.CurrentFilter
 		state.currentSource = p.CurrentSource
+		state.currentPult = p.CurrentPult
+		state.currentGroup = p.CurrentGroup
 		ok = true
 	})
 	if !ok || !p.isCurrentFilterRequest(version) {
@@ -438,6 +502,9 @@ func (p *ObjectListPanel) runFilterRequest(version uint64) {
 	query := strings.ToLower(strings.TrimSpace(state.query))
 	currentFilter := state.currentFilter
 	currentSource := state.currentSource
+	currentPult := state.currentPult
+	groups := p.Groups.Groups()
+	groupMembers := p.Groups.Membership().Members(state.currentGroup)
 
 	p.mutex.RLock()
 	all := p.AllObjects
@@ -454,8 +521,10 @@ func (p *ObjectListPanel) runFilterRequest(version uint64) {
 	result := p.ViewModel.ApplyFilters(viewmodels.ObjectListFilterInput{
 		AllObjects:           all,
 		Query:                query,
+		GroupMembers:         groupMembers,
 		CurrentFilter:        currentFilter,
 		CurrentSource:        currentSource,
+		CurrentPult:          currentPult,
 		PreviousSelectedID:   prevSelectedID,
 		HadPreviousSelection: hadPrevSelection,
 		LastNotifiedID:       lastNotifiedID,
@@ -500,9 +569,36 @@ func (p *ObjectListPanel) runFilterRequest(version uint64) {
 				result.CountPhoenix,
 				result.CountCASL,
 			)
+			options = viewmodels.AppendObjectSourceInstanceOptions(options, result.InstanceCounts)
 			updateSelectPreservingValue(p.SourceSelect, options, currentSource)
 		}
+		if p.PultSelect != nil {
+			options := viewmodels.BuildObjectPultOptions(result.CountAll, result.PultCounts)
+			if len(options) == 0 {
+				p.PultSelect.Hide()
+			} else {
+				updateSelectPreservingValue(p.PultSelect, options, currentPult)
+				p.PultSelect.Show()
+			}
+		}
+		if p.GroupSelect != nil {
+			if len(groups) == 0 {
+				p.GroupSelect.Hide()
+			} else {
+				options := viewmodels.ObjectGroupOptions(groups)
+				updateSelectPreservingValue(p.GroupSelect, options, viewmodels.ObjectGroupOptionByID(groups, state.currentGroup))
+				p.GroupSelect.Show()
+			}
+		}
 
+		if p.QueryError != nil {
+			p.QueryError.SetText(result.QueryError)
+			if result.QueryError == "" {
+				p.QueryError.Hide()
+			} else {
+				p.QueryError.Show()
+			}
+		}
 		if p.TitleText != nil {
 			p.TitleText.Text = fmt.Sprintf("ОБ'ЄКТИ (%d)", result.CountAll)
 			p.TitleText.Refresh()
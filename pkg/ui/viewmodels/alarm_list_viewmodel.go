@@ -1,9 +1,13 @@
 package viewmodels
 
 import (
+	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 // AlarmListUseCase описує мінімальний use case для завантаження тривог.
@@ -12,10 +16,17 @@ type AlarmListUseCase interface {
 }
 
 // AlarmRefreshInput описує вхідні дані для оновлення стану панелі тривог.
+// GroupMembers — склад вибраної групи об'єктів (nil — усі); тривоги об'єктів
+// з Watched (списку спостереження) закріплюються нагорі. Storm — зведення
+// корелятора про шторм тривог.
 type AlarmRefreshInput struct {
 	Alarms         []models.Alarm
 	LastKnownIDs   map[int]struct{}
 	SelectedSource string
+	SelectedPult   string
+	GroupMembers   map[int]struct{}
+	Watched        map[int]struct{}
+	Storm          usecases.AlarmStorm
 }
 
 // AlarmRefreshOutput описує результат обробки списку тривог для UI.
@@ -29,8 +40,14 @@ type AlarmRefreshOutput struct {
 	CountBridge    int
 	CountPhoenix   int
 	CountCASL      int
+	PultCounts     map[string]int
+	InstanceCounts map[string]int
 	NewCritical    models.Alarm
 	HasNewCritical bool
+	// Pinned — ID закріплених тривог об'єктів зі списку спостереження.
+	Pinned map[int]struct{}
+	// StormBanner — текст банера шторму тривог; порожній, коли шторму немає.
+	StormBanner string
 }
 
 // AlarmListViewModel інкапсулює обчислення стану панелі тривог.
@@ -54,6 +71,26 @@ func (vm *AlarmListViewModel) BuildRefreshOutput(input AlarmRefreshInput) AlarmR
 		FilteredAlarms: make([]models.Alarm, 0, len(input.Alarms)),
 		KnownIDs:       make(map[int]struct{}, len(input.Alarms)),
 		Total:          len(input.Alarms),
+		PultCounts:     make(map[string]int),
+		InstanceCounts: make(map[string]int),
+		Pinned:         make(map[int]struct{}),
+		StormBanner:    AlarmStormBannerText(input.Storm),
+	}
+
+	for _, alarm := range input.Alarms {
+		countPult(out.PultCounts, alarm.Pult)
+		countSourceInstance(out.InstanceCounts, ObjectSourceByID(alarm.ObjectID), alarm.SourceInstance)
+	}
+	// Тривоги приходять і зникають: коли активні лише на одному пульті,
+	// прихований фільтр пульта не повинен ховати їх.
+	selectedPult := input.SelectedPult
+	if len(out.PultCounts) < 2 {
+		selectedPult = ObjectPultAll
+	}
+	// Так само екземпляр без активних тривог зникає з фільтра джерел.
+	selectedSource := NormalizeObjectSourceFilter(input.SelectedSource)
+	if strings.Contains(selectedSource, objectSourceInstanceSeparator) && out.InstanceCounts[selectedSource] == 0 {
+		selectedSource = ObjectSourceAll
 	}
 
 	for i := range input.Alarms {
@@ -68,8 +105,12 @@ func (vm *AlarmListViewModel) BuildRefreshOutput(input AlarmRefreshInput) AlarmR
 		default:
 			out.CountBridge++
 		}
-		if sourceMatchesFilter(source, input.SelectedSource) {
+		if sourceMatchesFilter(source, alarm.SourceInstance, selectedSource) && pultMatchesFilter(alarm.Pult, selectedPult) &&
+			groupContains(input.GroupMembers, alarm.ObjectID) {
 			out.FilteredAlarms = append(out.FilteredAlarms, alarm)
+			if _, ok := input.Watched[alarm.ObjectID]; ok {
+				out.Pinned[alarm.ID] = struct{}{}
+			}
 		}
 		if alarm.IsCritical() && !alarm.IsProcessed {
 			out.CriticalCount++
@@ -82,6 +123,69 @@ func (vm *AlarmListViewModel) BuildRefreshOutput(input AlarmRefreshInput) AlarmR
 		}
 		out.KnownIDs[alarm.ID] = struct{}{}
 	}
+	if len(out.Pinned) > 0 {
+		slices.SortStableFunc(out.FilteredAlarms, func(a, b models.Alarm) int {
+			_, aPinned := out.Pinned[a.ID]
+			_, bPinned := out.Pinned[b.ID]
+			switch {
+			case aPinned && !bPinned:
+				return -1
+			case bPinned && !aPinned:
+				return 1
+			}
+			return 0
+		})
+	}
 
 	return out
 }
+
+// AlarmStormBannerText повертає текст банера шторму тривог; "" — шторму немає.
+func AlarmStormBannerText(storm usecases.AlarmStorm) string {
+	if !storm.Active {
+		return ""
+	}
+	window := fmt.Sprintf("%d с", int(storm.Window/time.Second))
+	if storm.Window >= time.Minute && storm.Window%time.Minute == 0 {
+		window = fmt.Sprintf("%d хв", int(storm.Window/time.Minute))
+	}
+	text := fmt.Sprintf("⚠ Шторм тривог: %d за %s", storm.Recent, window)
+	if storm.Incidents > 0 {
+		text += fmt.Sprintf(" | інцидентів: %d, згорнуто тривог: %d", storm.Incidents, storm.Collapsed)
+	}
+	return text
+}
+
+// CollapseAlarmIncidents згортає тривоги кожного інциденту кореляції до
+// одного рядка на місці першої з них. Рядком стає головна тривога інциденту,
+// якщо вона є в списку. members містить для такого рядка всі тривоги
+// інциденту, головну першою; інцидент з однією видимою тривогою не згортається.
+func CollapseAlarmIncidents(alarms []models.Alarm) (rows []models.Alarm, members map[int][]models.Alarm) {
+	byIncident := make(map[int][]models.Alarm)
+	for _, alarm := range alarms {
+		if alarm.IncidentID != 0 {
+			byIncident[alarm.IncidentID] = append(byIncident[alarm.IncidentID], alarm)
+		}
+	}
+	rows = make([]models.Alarm, 0, len(alarms))
+	members = make(map[int][]models.Alarm)
+	emitted := make(map[int]struct{})
+	for _, alarm := range alarms {
+		incident := byIncident[alarm.IncidentID]
+		if alarm.IncidentID == 0 || len(incident) < 2 {
+			rows = append(rows, alarm)
+			continue
+		}
+		if _, ok := emitted[alarm.IncidentID]; ok {
+			continue
+		}
+		emitted[alarm.IncidentID] = struct{}{}
+		head := slices.IndexFunc(incident, func(item models.Alarm) bool { return item.ID == alarm.IncidentID })
+		if head > 0 {
+			incident = append([]models.Alarm{incident[head]}, append(slices.Clone(incident[:head]), incident[head+1:]...)...)
+		}
+		rows = append(rows, incident[0])
+		members[incident[0].ID] = incident
+	}
+	return rows, members
+}
@@ -2,9 +2,11 @@ package viewmodels
 
 import (
 	"testing"
+	"time"
 
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 type alarmListUseCaseStub struct {
@@ -79,3 +81,119 @@ func TestAlarmListViewModel_BuildRefreshOutput_BySource(t *testing.T) {
 		t.Fatalf("unexpected source counters: all=%d bridge=%d phoenix=%d casl=%d", out.CountAll, out.CountBridge, out.CountPhoenix, out.CountCASL)
 	}
 }
+
+func TestAlarmListViewModel_BuildRefreshOutput_ByPult(t *testing.T) {
+	vm := NewAlarmListViewModel()
+	out := vm.BuildRefreshOutput(AlarmRefreshInput{
+		Alarms: []models.Alarm{
+			{ID: 1, ObjectID: ids.CASLObjectIDNamespaceStart + 1, Pult: "Пульт 7"},
+			{ID: 2, ObjectID: ids.CASLPultObjectID(1, ids.CASLObjectIDNamespaceStart+1), Pult: "Пульт 9"},
+		},
+		SelectedPult: "Пульт 7",
+	})
+
+	if len(out.FilteredAlarms) != 1 || out.FilteredAlarms[0].ID != 1 {
+		t.Fatalf("pult filter = %+v", out.FilteredAlarms)
+	}
+	if out.PultCounts["Пульт 9"] != 1 || out.CountCASL != 2 {
+		t.Fatalf("counts = %v casl=%d", out.PultCounts, out.CountCASL)
+	}
+}
+
+func TestAlarmListViewModel_BuildRefreshOutput_BySourceInstance(t *testing.T) {
+	vm := NewAlarmListViewModel()
+	branch := ObjectSourceInstanceLabel(ObjectSourceBridge, "Філія")
+	out := vm.BuildRefreshOutput(AlarmRefreshInput{
+		Alarms: []models.Alarm{
+			{ID: 1, ObjectID: ids.BridgeInstanceObjectID(0, 15), SourceInstance: "Основне"},
+			{ID: 2, ObjectID: ids.BridgeInstanceObjectID(1, 15), SourceInstance: "Філія"},
+		},
+		SelectedSource: branch,
+	})
+
+	if len(out.FilteredAlarms) != 1 || out.FilteredAlarms[0].ID != 2 {
+		t.Fatalf("instance filter = %+v", out.FilteredAlarms)
+	}
+	if out.InstanceCounts[branch] != 1 || out.CountBridge != 2 {
+		t.Fatalf("counts = %v bridge=%d", out.InstanceCounts, out.CountBridge)
+	}
+
+	gone := vm.BuildRefreshOutput(AlarmRefreshInput{
+		Alarms:         []models.Alarm{{ID: 1, ObjectID: ids.BridgeInstanceObjectID(0, 15), SourceInstance: "Основне"}},
+		SelectedSource: branch,
+	})
+	if len(gone.FilteredAlarms) != 1 {
+		t.Fatalf("instance without alarms must not hide the rest: %+v", gone.FilteredAlarms)
+	}
+}
+
+func TestAlarmListViewModel_BuildRefreshOutput_IgnoresPultFilterForSinglePult(t *testing.T) {
+	vm := NewAlarmListViewModel()
+	out := vm.BuildRefreshOutput(AlarmRefreshInput{
+		Alarms:       []models.Alarm{{ID: 1, ObjectID: ids.CASLObjectIDNamespaceStart + 1, Pult: "Пульт 7"}},
+		SelectedPult: "Пульт 9",
+	})
+
+	if len(out.FilteredAlarms) != 1 {
+		t.Fatalf("hidden pult filter must not hide alarms: %+v", out.FilteredAlarms)
+	}
+}
+
+func TestAlarmListViewModel_BuildRefreshOutput_GroupAndWatchlist(t *testing.T) {
+	vm := NewAlarmListViewModel()
+	alarms := []models.Alarm{
+		{ID: 1, ObjectID: 10},
+		{ID: 2, ObjectID: 20},
+		{ID: 3, ObjectID: 30},
+	}
+
+	pinned := vm.BuildRefreshOutput(AlarmRefreshInput{Alarms: alarms, Watched: map[int]struct{}{30: {}}})
+	if len(pinned.FilteredAlarms) != 3 || pinned.FilteredAlarms[0].ID != 3 || pinned.FilteredAlarms[1].ID != 1 {
+		t.Fatalf("watched alarm must be pinned first, got %+v", pinned.FilteredAlarms)
+	}
+	if _, ok := pinned.Pinned[3]; !ok || len(pinned.Pinned) != 1 {
+		t.Fatalf("Pinned = %v", pinned.Pinned)
+	}
+
+	grouped := vm.BuildRefreshOutput(AlarmRefreshInput{Alarms: alarms, GroupMembers: map[int]struct{}{10: {}, 30: {}}})
+	if len(grouped.FilteredAlarms) != 2 || grouped.Total != 3 {
+		t.Fatalf("group filter = %+v, total %d", grouped.FilteredAlarms, grouped.Total)
+	}
+}
+
+func TestCollapseAlarmIncidents(t *testing.T) {
+	alarms := []models.Alarm{
+		{ID: 1, ObjectID: 10, IncidentID: 3},
+		{ID: 2, ObjectID: 20},
+		{ID: 3, ObjectID: 11, IncidentID: 3},
+		{ID: 4, ObjectID: 12, IncidentID: 3},
+		{ID: 5, ObjectID: 30, IncidentID: 9},
+	}
+
+	rows, members := CollapseAlarmIncidents(alarms)
+	if len(rows) != 3 || rows[0].ID != 3 || rows[1].ID != 2 || rows[2].ID != 5 {
+		t.Fatalf("rows = %+v, want head 3 in place of the first member, then 2 and 5", rows)
+	}
+	incident := members[3]
+	if len(incident) != 3 || incident[0].ID != 3 || incident[1].ID != 1 || incident[2].ID != 4 {
+		t.Fatalf("members[3] = %+v", incident)
+	}
+	if _, ok := members[5]; ok {
+		t.Fatal("incident with a single visible alarm must not collapse")
+	}
+}
+
+func TestAlarmListViewModel_BuildRefreshOutput_StormBanner(t *testing.T) {
+	vm := NewAlarmListViewModel()
+	calm := vm.BuildRefreshOutput(AlarmRefreshInput{Storm: usecases.AlarmStorm{Recent: 3, Window: 2 * time.Minute}})
+	if calm.StormBanner != "" {
+		t.Fatalf("StormBanner = %q, want empty without a storm", calm.StormBanner)
+	}
+
+	storm := vm.BuildRefreshOutput(AlarmRefreshInput{Storm: usecases.AlarmStorm{
+		Active: true, Recent: 64, Window: 2 * time.Minute, Incidents: 4, Collapsed: 40,
+	}})
+	if want := "⚠ Шторм тривог: 64 за 2 хв | інцидентів: 4, згорнуто тривог: 40"; storm.StormBanner != want {
+		t.Fatalf("StormBanner = %q, want %q", storm.StormBanner, want)
+	}
+}
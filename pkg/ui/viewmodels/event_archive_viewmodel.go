@@ -0,0 +1,65 @@
+package viewmodels
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+)
+
+// EventArchiveDateLayout — формат дат у фільтрі архіву подій.
+const EventArchiveDateLayout = "02.01.2006"
+
+// EventArchiveFilterInput — значення полів фільтра архіву подій, як їх ввів оператор.
+type EventArchiveFilterInput struct {
+	ObjectID int
+	Code     string
+	Groups   string
+	From     string
+	To       string
+}
+
+// BuildEventArchiveQuery перетворює поля фільтра на запит до архіву.
+// Дата "по" включає весь день; групи вводяться через кому чи пробіл.
+func BuildEventArchiveQuery(input EventArchiveFilterInput) (contracts.EventArchiveQuery, error) {
+	query := contracts.EventArchiveQuery{
+		ObjectID: input.ObjectID,
+		Code:     strings.ToUpper(strings.TrimSpace(input.Code)),
+	}
+	for _, field := range strings.FieldsFunc(input.Groups, func(r rune) bool { return r == ',' || r == ';' || r == ' ' }) {
+		group, err := strconv.Atoi(field)
+		if err != nil || group < 0 {
+			return contracts.EventArchiveQuery{}, fmt.Errorf("некоректний номер групи %q", field)
+		}
+		query.Groups = append(query.Groups, group)
+	}
+	if raw := strings.TrimSpace(input.From); raw != "" {
+		from, err := time.ParseInLocation(EventArchiveDateLayout, raw, time.Local)
+		if err != nil {
+			return contracts.EventArchiveQuery{}, fmt.Errorf("дата \"з\": очікується ДД.ММ.РРРР")
+		}
+		query.From = from
+	}
+	if raw := strings.TrimSpace(input.To); raw != "" {
+		to, err := time.ParseInLocation(EventArchiveDateLayout, raw, time.Local)
+		if err != nil {
+			return contracts.EventArchiveQuery{}, fmt.Errorf("дата \"по\": очікується ДД.ММ.РРРР")
+		}
+		query.To = to.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return contracts.EventArchiveQuery{}, fmt.Errorf("дата \"по\" раніша за дату \"з\"")
+	}
+	return query, nil
+}
+
+// EventArchiveExportFileName пропонує назву файлу експорту архіву.
+func EventArchiveExportFileName(objectNumber string, ext string, now time.Time) string {
+	name := "archive"
+	if number := strings.TrimSpace(objectNumber); number != "" {
+		name += "_" + number
+	}
+	return name + "_" + now.Format("2006-01-02") + "." + strings.TrimPrefix(ext, ".")
+}
@@ -0,0 +1,39 @@
+package viewmodels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildEventArchiveQuery(t *testing.T) {
+	query, err := BuildEventArchiveQuery(EventArchiveFilterInput{
+		ObjectID: 42,
+		Code:     " e130 ",
+		Groups:   "1, 3 5",
+		From:     "01.02.2024",
+		To:       "29.02.2024",
+	})
+	if err != nil {
+		t.Fatalf("BuildEventArchiveQuery() error = %v", err)
+	}
+	if query.ObjectID != 42 || query.Code != "E130" || len(query.Groups) != 3 || query.Groups[2] != 5 {
+		t.Fatalf("query = %+v", query)
+	}
+	wantTo := time.Date(2024, 2, 29, 23, 59, 59, 0, time.Local)
+	if !query.To.Equal(wantTo) {
+		t.Fatalf("To = %v, want whole last day %v", query.To, wantTo)
+	}
+}
+
+func TestBuildEventArchiveQuery_Invalid(t *testing.T) {
+	tests := []EventArchiveFilterInput{
+		{Groups: "1,x"},
+		{From: "2024-02-01"},
+		{From: "10.02.2024", To: "01.02.2024"},
+	}
+	for _, input := range tests {
+		if _, err := BuildEventArchiveQuery(input); err == nil {
+			t.Fatalf("BuildEventArchiveQuery(%+v) error = nil", input)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package viewmodels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventexport"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// EventExportSeverityOptions — пункти вибору важливості у фільтрі експорту журналу.
+var EventExportSeverityOptions = []string{"Всі події", "Критичні", "Попередження", "Інформаційні"}
+
+// EventExportSourceOptions — джерела, які можна вибрати для експорту журналу.
+var EventExportSourceOptions = []string{ObjectSourceBridge, ObjectSourcePhoenix, ObjectSourceCASL}
+
+var eventExportTypes = []models.EventType{
+	models.EventFire, models.EventBurglary, models.EventPanic, models.EventMedical, models.EventGas,
+	models.EventTamper, models.EventFault, models.EventRestore, models.EventArm, models.EventDisarm,
+	models.EventTest, models.EventPowerFail, models.EventPowerOK, models.EventBatteryLow,
+	models.EventOnline, models.EventOffline, models.SystemEvent, models.EventNotification,
+	models.EventAlarmNotification, models.EventOperatorAction, models.EventManagerAssigned,
+	models.EventManagerArrived, models.EventManagerCanceled, models.EventAlarmFinished,
+	models.EventDeviceBlocked, models.EventDeviceUnblocked, models.EventService,
+}
+
+// EventExportTypeOption — тип події з підписом для списку вибору.
+type EventExportTypeOption struct {
+	Type  models.EventType
+	Label string
+}
+
+// EventExportTypeOptions повертає всі типи подій з підписами журналу.
+func EventExportTypeOptions() []EventExportTypeOption {
+	options := make([]EventExportTypeOption, 0, len(eventExportTypes))
+	for _, eventType := range eventExportTypes {
+		event := models.Event{Type: eventType}
+		options = append(options, EventExportTypeOption{Type: eventType, Label: event.GetTypeDisplay()})
+	}
+	return options
+}
+
+// EventExportFilterInput — значення полів фільтра експорту журналу, як їх ввів оператор.
+// Порожні Sources і Types означають «усі»; Objects — номери об'єктів через кому чи пробіл.
+type EventExportFilterInput struct {
+	Sources  []string
+	Severity string
+	Objects  string
+	Types    []models.EventType
+	From     string
+	To       string
+}
+
+// EventExportPreset переносить фільтри журналу подій у форму експорту.
+func EventExportPreset(selectedSource string, severity string, importantOnly bool, currentObject *models.Object, now time.Time) EventExportFilterInput {
+	input := EventExportFilterInput{
+		Severity: EventExportSeverityOptions[0],
+		From:     now.Format(EventArchiveDateLayout),
+		To:       now.Format(EventArchiveDateLayout),
+	}
+	switch source := NormalizeObjectSourceFilter(selectedSource); {
+	case strings.HasPrefix(source, ObjectSourceBridge):
+		input.Sources = []string{ObjectSourceBridge}
+	case strings.HasPrefix(source, ObjectSourcePhoenix):
+		input.Sources = []string{ObjectSourcePhoenix}
+	case strings.HasPrefix(source, ObjectSourceCASL):
+		input.Sources = []string{ObjectSourceCASL}
+	}
+	switch {
+	case severity != "":
+		input.Severity = severity
+	case importantOnly:
+		input.Severity = "Критичні"
+	}
+	if currentObject != nil {
+		input.Objects = ObjectDisplayNumber(*currentObject)
+	}
+	return input
+}
+
+// BuildEventExportFilter перетворює поля форми на фільтр експорту. Номери
+// об'єктів шукаються серед objects; номер, що є в кількох джерелах, бере всі збіги.
+func BuildEventExportFilter(input EventExportFilterInput, objects []models.Object) (eventexport.Filter, error) {
+	archiveQuery, err := BuildEventArchiveQuery(EventArchiveFilterInput{From: input.From, To: input.To})
+	if err != nil {
+		return eventexport.Filter{}, err
+	}
+	if archiveQuery.From.IsZero() || archiveQuery.To.IsZero() {
+		return eventexport.Filter{}, fmt.Errorf("вкажіть дати \"з\" і \"по\"")
+	}
+	filter := eventexport.Filter{
+		Types: append([]models.EventType(nil), input.Types...),
+		From:  archiveQuery.From,
+		To:    archiveQuery.To,
+	}
+	for _, source := range input.Sources {
+		switch source {
+		case ObjectSourceBridge:
+			filter.Sources = append(filter.Sources, contracts.FrontendSourceBridge)
+		case ObjectSourcePhoenix:
+			filter.Sources = append(filter.Sources, contracts.FrontendSourcePhoenix)
+		case ObjectSourceCASL:
+			filter.Sources = append(filter.Sources, contracts.FrontendSourceCASL)
+		}
+	}
+	switch input.Severity {
+	case "Критичні":
+		filter.Severity = eventexport.SeverityCritical
+	case "Попередження":
+		filter.Severity = eventexport.SeverityWarning
+	case "Інформаційні":
+		filter.Severity = eventexport.SeverityInfo
+	}
+	for _, number := range strings.FieldsFunc(input.Objects, func(r rune) bool { return r == ',' || r == ';' || r == ' ' }) {
+		found := false
+		for _, object := range objects {
+			if strings.EqualFold(ObjectDisplayNumber(object), number) {
+				filter.ObjectIDs = append(filter.ObjectIDs, object.ID)
+				found = true
+			}
+		}
+		if !found {
+			return eventexport.Filter{}, fmt.Errorf("об'єкт №%s не знайдено", number)
+		}
+	}
+	return filter, nil
+}
+
+// EventExportFileName пропонує назву файлу експорту журналу.
+func EventExportFileName(ext string, now time.Time) string {
+	return "events_" + now.Format("2006-01-02") + "." + strings.TrimPrefix(ext, ".")
+}
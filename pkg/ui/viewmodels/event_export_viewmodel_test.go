@@ -0,0 +1,68 @@
+package viewmodels
+
+import (
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/eventexport"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestBuildEventExportFilter(t *testing.T) {
+	phoenixID := ids.PhoenixObjectIDNamespaceStart + 3
+	objects := []models.Object{
+		{ID: 7, DisplayNumber: "12"},
+		{ID: phoenixID, DisplayNumber: "12"},
+		{ID: 8, DisplayNumber: "40"},
+	}
+	filter, err := BuildEventExportFilter(EventExportFilterInput{
+		Sources:  []string{ObjectSourceBridge, ObjectSourceCASL},
+		Severity: "Попередження",
+		Objects:  "12, 40",
+		Types:    []models.EventType{models.EventPowerFail},
+		From:     "01.03.2024",
+		To:       "02.03.2024",
+	}, objects)
+	if err != nil {
+		t.Fatalf("BuildEventExportFilter() error = %v", err)
+	}
+	if len(filter.Sources) != 2 || filter.Sources[1] != contracts.FrontendSourceCASL {
+		t.Fatalf("sources = %v", filter.Sources)
+	}
+	if filter.Severity != eventexport.SeverityWarning || len(filter.Types) != 1 {
+		t.Fatalf("filter = %+v", filter)
+	}
+	if len(filter.ObjectIDs) != 3 || filter.ObjectIDs[1] != phoenixID {
+		t.Fatalf("object ids = %v, want every object with the number", filter.ObjectIDs)
+	}
+	if want := time.Date(2024, 3, 2, 23, 59, 59, 0, time.Local); !filter.To.Equal(want) {
+		t.Fatalf("To = %v, want %v", filter.To, want)
+	}
+}
+
+func TestBuildEventExportFilter_Invalid(t *testing.T) {
+	objects := []models.Object{{ID: 7, DisplayNumber: "12"}}
+	tests := []EventExportFilterInput{
+		{From: "01.03.2024"},
+		{From: "01.03.2024", To: "02.03.2024", Objects: "99"},
+	}
+	for _, input := range tests {
+		if _, err := BuildEventExportFilter(input, objects); err == nil {
+			t.Fatalf("BuildEventExportFilter(%+v) error = nil", input)
+		}
+	}
+}
+
+func TestEventExportPreset(t *testing.T) {
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.Local)
+	object := &models.Object{ID: 7, DisplayNumber: "12"}
+	input := EventExportPreset(ObjectSourcePhoenix+" (4)", "", true, object, now)
+	if len(input.Sources) != 1 || input.Sources[0] != ObjectSourcePhoenix {
+		t.Fatalf("sources = %v", input.Sources)
+	}
+	if input.Severity != "Критичні" || input.Objects != "12" || input.From != "02.03.2024" {
+		t.Fatalf("preset = %+v", input)
+	}
+}
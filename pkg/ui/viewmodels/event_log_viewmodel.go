@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
 )
 
 // EventLogUseCase описує мінімальний use case для завантаження подій журналу.
@@ -13,8 +14,12 @@ type EventLogUseCase interface {
 }
 
 // EventLogFilterInput описує вхідні дані для фільтрації журналу подій.
+// Query — вираз мови фільтрів; умови полів об'єкта в ньому журнал пропускає.
+// GroupMembers — склад вибраної групи об'єктів; nil означає «усі об'єкти».
 type EventLogFilterInput struct {
 	AllEvents          []models.Event
+	Query              string
+	GroupMembers       map[int]struct{}
 	Period             string
 	SelectedSource     string
 	SeverityFilter     string
@@ -34,6 +39,8 @@ type EventLogFilterOutput struct {
 	CountBridge  int
 	CountPhoenix int
 	CountCASL    int
+	// QueryError — помилка розбору Query; тоді журнал порожній.
+	QueryError string
 }
 
 // EventLogViewModel інкапсулює бізнес-правила формування журналу подій.
@@ -52,6 +59,10 @@ func (vm *EventLogViewModel) LoadEvents(useCase EventLogUseCase) []models.Event
 }
 
 func (vm *EventLogViewModel) ApplyFilters(input EventLogFilterInput) EventLogFilterOutput {
+	query, err := usecases.ParseFilterQuery(input.Query)
+	if err != nil {
+		return EventLogFilterOutput{QueryError: err.Error()}
+	}
 	now := input.Now
 	if now.IsZero() {
 		now = time.Now()
@@ -90,6 +101,12 @@ eventLoop:
 		if input.ShowForCurrentOnly && input.HasCurrentObject && event.ObjectID != input.CurrentObjectID {
 			continue
 		}
+		if !groupContains(input.GroupMembers, event.ObjectID) {
+			continue
+		}
+		if !query.MatchEvent(event) {
+			continue
+		}
 
 		source := EventSourceName(event)
 		countAll++
@@ -102,7 +119,7 @@ eventLoop:
 			countBridge++
 		}
 
-		if !sourceMatchesFilter(source, input.SelectedSource) {
+		if !sourceMatchesFilter(source, "", input.SelectedSource) {
 			continue
 		}
 
@@ -158,3 +158,28 @@ func TestEventLogViewModel_ApplyFiltersSortsNewestFirst(t *testing.T) {
 		t.Fatalf("unexpected sorted order: %+v", out.Filtered)
 	}
 }
+
+func TestEventLogViewModel_ApplyFiltersByQuery(t *testing.T) {
+	vm := NewEventLogViewModel()
+	now := time.Date(2026, 3, 29, 12, 0, 0, 0, time.Local)
+	events := []models.Event{
+		{ID: 1, ObjectID: ids.CASLObjectIDNamespaceStart + 1, ObjectName: "Склад", Time: now.Add(-time.Minute), Type: models.EventFire},
+		{ID: 2, ObjectID: 11, ObjectName: "Склад", Time: now.Add(-2 * time.Minute), Type: models.EventFire},
+		{ID: 3, ObjectID: ids.CASLObjectIDNamespaceStart + 1, ObjectName: "Склад", Time: now.Add(-3 * time.Minute), Type: models.EventArm},
+	}
+
+	out := vm.ApplyFilters(EventLogFilterInput{AllEvents: events, Period: "Всі", Query: "source:casl severity:critical склад", Now: now})
+	if out.Count != 1 || out.Filtered[0].ID != 1 || out.CountAll != 1 {
+		t.Fatalf("query filter = %+v", out)
+	}
+
+	out = vm.ApplyFilters(EventLogFilterInput{AllEvents: events, Period: "Всі", Query: `source:"casl`, Now: now})
+	if out.QueryError == "" || out.Count != 0 {
+		t.Fatalf("invalid query must report an error, got %+v", out)
+	}
+
+	out = vm.ApplyFilters(EventLogFilterInput{AllEvents: events, Period: "Всі", GroupMembers: map[int]struct{}{11: {}}, Now: now})
+	if out.Count != 1 || out.Filtered[0].ID != 2 {
+		t.Fatalf("group filter = %+v", out)
+	}
+}
@@ -0,0 +1,50 @@
+package viewmodels
+
+import (
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+)
+
+// ObjectGroupAll — пункт фільтра груп без обмеження складу.
+const ObjectGroupAll = "Усі групи"
+
+// ObjectGroupOptions будує пункти фільтра груп для панелей.
+func ObjectGroupOptions(groups []objectgroups.Group) []string {
+	options := make([]string, 0, len(groups)+1)
+	options = append(options, ObjectGroupAll)
+	for _, group := range groups {
+		options = append(options, group.Label())
+	}
+	return options
+}
+
+// ObjectGroupIDByOption повертає ID групи за пунктом фільтра; "" — усі об'єкти.
+func ObjectGroupIDByOption(groups []objectgroups.Group, option string) string {
+	option = strings.TrimSpace(option)
+	for _, group := range groups {
+		if group.Label() == option {
+			return group.ID
+		}
+	}
+	return ""
+}
+
+// ObjectGroupOptionByID повертає пункт фільтра вибраної групи; видалена група дає ObjectGroupAll.
+func ObjectGroupOptionByID(groups []objectgroups.Group, groupID string) string {
+	for _, group := range groups {
+		if group.ID == groupID {
+			return group.Label()
+		}
+	}
+	return ObjectGroupAll
+}
+
+// groupContains — фільтр за складом групи; nil-склад пропускає всі об'єкти.
+func groupContains(members map[int]struct{}, objectID int) bool {
+	if members == nil {
+		return true
+	}
+	_, ok := members[objectID]
+	return ok
+}
@@ -0,0 +1,29 @@
+package viewmodels
+
+import (
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/objectgroups"
+)
+
+func TestObjectGroupOptions(t *testing.T) {
+	t.Parallel()
+
+	groups := []objectgroups.Group{
+		{ID: "a", Name: "Школи"},
+		{ID: "b", Name: "VIP (центр)", Watch: true},
+	}
+	options := ObjectGroupOptions(groups)
+	if len(options) != 3 || options[0] != ObjectGroupAll || options[2] != "👁 VIP (центр)" {
+		t.Fatalf("options = %v", options)
+	}
+	if got := ObjectGroupIDByOption(groups, options[2]); got != "b" {
+		t.Fatalf("ObjectGroupIDByOption() = %q", got)
+	}
+	if got := ObjectGroupIDByOption(groups, ObjectGroupAll); got != "" {
+		t.Fatalf("all groups must map to an empty ID, got %q", got)
+	}
+	if got := ObjectGroupOptionByID(groups, "deleted"); got != ObjectGroupAll {
+		t.Fatalf("deleted group option = %q", got)
+	}
+}
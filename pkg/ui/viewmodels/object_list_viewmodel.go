@@ -4,11 +4,12 @@ import (
 	"fmt"
 	"image/color"
 	"slices"
-	"strconv"
 	"strings"
+	"time"
 
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/usecases"
 	"obj_catalog_fyne_v3/pkg/utils"
 )
 
@@ -26,11 +27,17 @@ type ObjectListUseCase interface {
 }
 
 // ObjectListFilterInput описує вхідні дані фільтрації списку об'єктів.
+// Query — вираз мови фільтрів (див. usecases.ParseFilterQuery); Now потрібен
+// для умов lasttest/lastevent, нульовий означає поточний час. GroupMembers —
+// склад вибраної групи об'єктів; nil означає «усі об'єкти».
 type ObjectListFilterInput struct {
 	AllObjects           []models.Object
 	Query                string
+	GroupMembers         map[int]struct{}
+	Now                  time.Time
 	CurrentFilter        string
 	CurrentSource        string
+	CurrentPult          string
 	PreviousSelectedID   int
 	HadPreviousSelection bool
 	LastNotifiedID       int
@@ -48,10 +55,14 @@ type ObjectListFilterOutput struct {
 	CountBridge           int
 	CountPhoenix          int
 	CountCASL             int
+	PultCounts            map[string]int
+	InstanceCounts        map[string]int
 	NewSelectedRow        int
 	SelectedObject        models.Object
 	HasSelectedObject     bool
 	ShouldNotifySelection bool
+	// QueryError — помилка розбору Query; тоді список порожній.
+	QueryError string
 }
 
 // ObjectListViewModel інкапсулює бізнес-правила фільтрації/вибору списку об'єктів.
@@ -90,7 +101,19 @@ func (vm *ObjectListViewModel) BuildFilterOptions(countAll int, countAlarm int,
 }
 
 func (vm *ObjectListViewModel) ApplyFilters(input ObjectListFilterInput) ObjectListFilterOutput {
-	query := strings.ToLower(strings.TrimSpace(input.Query))
+	query, err := usecases.ParseFilterQuery(input.Query)
+	if err != nil {
+		return ObjectListFilterOutput{
+			PultCounts:     map[string]int{},
+			InstanceCounts: map[string]int{},
+			NewSelectedRow: -1,
+			QueryError:     err.Error(),
+		}
+	}
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
 	currentFilter := NormalizeObjectListFilter(input.CurrentFilter)
 	currentSource := NormalizeObjectSourceFilter(input.CurrentSource)
 
@@ -103,12 +126,15 @@ func (vm *ObjectListViewModel) ApplyFilters(input ObjectListFilterInput) ObjectL
 	countBridge := 0
 	countPhoenix := 0
 	countCASL := 0
-
-	terms := strings.Fields(query)
+	pultCounts := make(map[string]int)
+	instanceCounts := make(map[string]int)
 
 	for _, obj := range input.AllObjects {
 		source := ObjectSourceByID(obj.ID)
-		if !matchesSearchTerms(obj, source, terms) {
+		if !groupContains(input.GroupMembers, obj.ID) {
+			continue
+		}
+		if !query.MatchObject(obj, now) {
 			continue
 		}
 
@@ -121,6 +147,8 @@ func (vm *ObjectListViewModel) ApplyFilters(input ObjectListFilterInput) ObjectL
 		default:
 			countBridge++
 		}
+		countPult(pultCounts, obj.Pult)
+		countSourceInstance(instanceCounts, source, obj.SourceInstance)
 		if obj.Status == models.StatusFire || obj.Status == models.StatusFault {
 			countAlarm++
 		}
@@ -156,7 +184,10 @@ func (vm *ObjectListViewModel) ApplyFilters(input ObjectListFilterInput) ObjectL
 		if !statusMatch {
 			continue
 		}
-		if !sourceMatchesFilter(source, currentSource) {
+		if !sourceMatchesFilter(source, obj.SourceInstance, currentSource) {
+			continue
+		}
+		if !pultMatchesFilter(obj.Pult, input.CurrentPult) {
 			continue
 		}
 		filtered = append(filtered, obj)
@@ -184,6 +215,8 @@ func (vm *ObjectListViewModel) ApplyFilters(input ObjectListFilterInput) ObjectL
 		CountBridge:        countBridge,
 		CountPhoenix:       countPhoenix,
 		CountCASL:          countCASL,
+		PultCounts:         pultCounts,
+		InstanceCounts:     instanceCounts,
 		NewSelectedRow:     newSelectedRow,
 	}
 	if newSelectedRow >= 0 {
@@ -221,68 +254,6 @@ func isDebugObject(obj models.Object, source string) bool {
 	}
 }
 
-func matchesSearchTerms(obj models.Object, source string, terms []string) bool {
-	if len(terms) == 0 {
-		return true
-	}
-
-	idText := strconv.Itoa(obj.ID)
-	displayNumberText := strings.ToLower(strings.TrimSpace(ObjectDisplayNumber(obj)))
-	nameText := strings.ToLower(strings.TrimSpace(obj.Name))
-	addressText := strings.ToLower(strings.TrimSpace(obj.Address))
-	contractText := strings.ToLower(strings.TrimSpace(obj.ContractNum))
-	phoneText := strings.ToLower(strings.TrimSpace(obj.Phone))
-	sim1Text := strings.ToLower(strings.TrimSpace(obj.SIM1))
-	sim2Text := strings.ToLower(strings.TrimSpace(obj.SIM2))
-	sourceText := strings.ToLower(strings.TrimSpace(source))
-
-	sim1Digits := utils.DigitsOnly(sim1Text)
-	sim2Digits := utils.DigitsOnly(sim2Text)
-	phoneDigits := utils.DigitsOnly(phoneText)
-
-	for _, term := range terms {
-		switch {
-		case strings.HasPrefix(term, "src:"):
-			required := NormalizeObjectSourceFilter(strings.TrimSpace(strings.TrimPrefix(term, "src:")))
-			if !sourceMatchesFilter(source, required) {
-				return false
-			}
-		case strings.HasPrefix(term, "sim:"):
-			simQuery := utils.DigitsOnly(strings.TrimSpace(strings.TrimPrefix(term, "sim:")))
-			if simQuery == "" {
-				return false
-			}
-			if !strings.Contains(sim1Digits, simQuery) && !strings.Contains(sim2Digits, simQuery) {
-				return false
-			}
-		default:
-			digitsTerm := utils.DigitsOnly(term)
-			isNumericTerm := digitsTerm != "" && utils.IsDigitsOnlyTerm(term)
-			if isNumericTerm && len(digitsTerm) >= 4 {
-				if strings.Contains(sim1Digits, digitsTerm) || strings.Contains(sim2Digits, digitsTerm) || strings.Contains(phoneDigits, digitsTerm) || strings.Contains(idText, digitsTerm) {
-					continue
-				}
-				if strings.Contains(displayNumberText, digitsTerm) {
-					continue
-				}
-			}
-			if strings.Contains(idText, term) ||
-				strings.Contains(displayNumberText, term) ||
-				strings.Contains(nameText, term) ||
-				strings.Contains(addressText, term) ||
-				strings.Contains(contractText, term) ||
-				strings.Contains(phoneText, term) ||
-				strings.Contains(sim1Text, term) ||
-				strings.Contains(sim2Text, term) ||
-				strings.Contains(sourceText, term) {
-				continue
-			}
-			return false
-		}
-	}
-	return true
-}
-
 // GetRowColors визначає кольори тексту та фону для рядка списку об'єктів.
 // Логіка перенесена з View (MVVM).
 func (vm *ObjectListViewModel) GetRowColors(item models.Object, isDark bool) (textColor, rowColor color.NRGBA) {
@@ -2,6 +2,7 @@ package viewmodels
 
 import (
 	"testing"
+	"time"
 
 	"obj_catalog_fyne_v3/pkg/ids"
 	"obj_catalog_fyne_v3/pkg/models"
@@ -125,6 +126,18 @@ func TestObjectListViewModel_ApplyFilters_BySourceAndSIMSearch(t *testing.T) {
 		t.Fatalf("expected src:casl to filter only CASL objects, got %+v", outBySourceToken.Filtered)
 	}
 
+	for _, query := range []string{"src:", "src:oracle"} {
+		out := vm.ApplyFilters(ObjectListFilterInput{
+			AllObjects:    all,
+			CurrentFilter: FilterAll,
+			CurrentSource: ObjectSourceAll,
+			Query:         query,
+		})
+		if out.QueryError == "" {
+			t.Fatalf("%q: expected a query error for the search field, got %+v", query, out.Filtered)
+		}
+	}
+
 	outByPhoenixSource := vm.ApplyFilters(ObjectListFilterInput{
 		AllObjects:    all,
 		CurrentFilter: FilterAll,
@@ -304,3 +317,50 @@ func TestObjectListViewModel_ApplyFilters_UsesNormalizedStatuses(t *testing.T) {
 		t.Fatalf("expected normalized debug object, got %+v", debug.Filtered)
 	}
 }
+
+func TestObjectListViewModel_ApplyFilters_ByPult(t *testing.T) {
+	vm := NewObjectListViewModel()
+	objects := []models.Object{
+		{ID: 1, Name: "Міст"},
+		{ID: ids.CASLObjectIDNamespaceStart + 15, Name: "Склад", Pult: "Пульт 7"},
+		{ID: ids.CASLPultObjectID(1, ids.CASLObjectIDNamespaceStart+15), Name: "Офіс", Pult: "Пульт 9"},
+	}
+
+	out := vm.ApplyFilters(ObjectListFilterInput{AllObjects: objects, CurrentPult: "Пульт 9 (1)"})
+	if len(out.Filtered) != 1 || out.Filtered[0].Name != "Офіс" {
+		t.Fatalf("pult filter = %+v", out.Filtered)
+	}
+	if out.PultCounts["Пульт 7"] != 1 || out.PultCounts["Пульт 9"] != 1 || len(out.PultCounts) != 2 {
+		t.Fatalf("PultCounts = %v", out.PultCounts)
+	}
+
+	out = vm.ApplyFilters(ObjectListFilterInput{AllObjects: objects, Query: "pult:7"})
+	if len(out.Filtered) != 1 || out.Filtered[0].Name != "Склад" {
+		t.Fatalf("pult: search = %+v", out.Filtered)
+	}
+}
+
+func TestObjectListViewModel_ApplyFilters_QueryLanguage(t *testing.T) {
+	vm := NewObjectListViewModel()
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	objects := []models.Object{
+		{ID: 1, Name: "Свіжий тест", Address: "Київ", LastTestTime: now.Add(-time.Hour)},
+		{ID: 2, Name: "Давній тест", Address: "Київ", LastTestTime: now.Add(-72 * time.Hour)},
+		{ID: 3, Name: "Давній тест", Address: "Львів", LastTestTime: now.Add(-72 * time.Hour)},
+	}
+
+	out := vm.ApplyFilters(ObjectListFilterInput{AllObjects: objects, Query: `region:"київ" lasttest>48h`, Now: now})
+	if len(out.Filtered) != 1 || out.Filtered[0].ID != 2 || out.QueryError != "" {
+		t.Fatalf("query filter = %+v, error %q", out.Filtered, out.QueryError)
+	}
+
+	out = vm.ApplyFilters(ObjectListFilterInput{AllObjects: objects, Query: "status:sleeping", Now: now})
+	if out.QueryError == "" || len(out.Filtered) != 0 || out.NewSelectedRow != -1 {
+		t.Fatalf("invalid query must report an error and show nothing, got %+v", out)
+	}
+
+	out = vm.ApplyFilters(ObjectListFilterInput{AllObjects: objects, GroupMembers: map[int]struct{}{1: {}, 3: {}}, Query: "давній", Now: now})
+	if len(out.Filtered) != 1 || out.Filtered[0].ID != 3 || out.CountAll != 1 {
+		t.Fatalf("group filter = %+v, countAll %d", out.Filtered, out.CountAll)
+	}
+}
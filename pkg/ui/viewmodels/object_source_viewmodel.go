@@ -1,6 +1,7 @@
 package viewmodels
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,13 @@ const (
 	ObjectSourceBridge  = "БД/МІСТ"
 	ObjectSourcePhoenix = "Phoenix"
 	ObjectSourceCASL    = "CASL Cloud"
+
+	// ObjectPultAll — фільтр пультів CASL без обмеження.
+	ObjectPultAll = "Всі пульти"
+
+	// objectSourceInstanceSeparator відділяє тип джерела від назви екземпляра,
+	// напр. "БД/МІСТ · Філія".
+	objectSourceInstanceSeparator = " · "
 )
 
 func ObjectSourceByID(id int) string {
@@ -42,6 +50,9 @@ func EventSourceName(event models.Event) string {
 
 func NormalizeObjectSourceFilter(selected string) string {
 	clean := utils.StripCountSuffix(selected)
+	if strings.Contains(clean, objectSourceInstanceSeparator) {
+		return clean
+	}
 	switch strings.ToLower(clean) {
 	case strings.ToLower(ObjectSourcePhoenix), "phoenix":
 		return ObjectSourcePhoenix
@@ -63,6 +74,28 @@ func BuildObjectSourceOptions(countAll int, countBridge int, countPhoenix int, c
 	}
 }
 
+// ObjectSourceInstanceLabel повертає пункт фільтра для екземпляра джерела;
+// порожній, якщо джерело цього типу одне.
+func ObjectSourceInstanceLabel(source string, instance string) string {
+	if instance = strings.TrimSpace(instance); instance == "" {
+		return ""
+	}
+	return source + objectSourceInstanceSeparator + instance
+}
+
+// AppendObjectSourceInstanceOptions додає до фільтра джерел окремі екземпляри.
+func AppendObjectSourceInstanceOptions(options []string, instanceCounts map[string]int) []string {
+	labels := make([]string, 0, len(instanceCounts))
+	for label := range instanceCounts {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	for _, label := range labels {
+		options = append(options, label+" ("+strconv.Itoa(instanceCounts[label])+")")
+	}
+	return options
+}
+
 func SourceBadgeForObjectID(id int) string {
 	if ids.IsPhoenixObjectID(id) {
 		return "[P]"
@@ -74,19 +107,7 @@ func SourceBadgeForObjectID(id int) string {
 }
 
 func ObjectDisplayNumber(object models.Object) string {
-	if strings.TrimSpace(object.DisplayNumber) != "" {
-		return object.DisplayNumber
-	}
-	if !ids.IsCASLObjectID(object.ID) && !ids.IsPhoenixObjectID(object.ID) {
-		return strconv.Itoa(object.ID)
-	}
-	if number := numberFromPanelMark(object.PanelMark); number != "" {
-		return number
-	}
-	if number := utils.LeadingDigits(strings.TrimSpace(object.Name)); number != "" {
-		return number
-	}
-	return strconv.Itoa(object.ID)
+	return ids.ObjectDisplayNumber(object)
 }
 
 // NumericObjectDisplayNumber returns the numeric display number when it can be parsed.
@@ -97,8 +118,12 @@ func NumericObjectDisplayNumber(object models.Object) int {
 	return object.ID
 }
 
-func sourceMatchesFilter(source string, selectedSource string) bool {
-	switch NormalizeObjectSourceFilter(selectedSource) {
+func sourceMatchesFilter(source string, instance string, selectedSource string) bool {
+	selected := NormalizeObjectSourceFilter(selectedSource)
+	if strings.Contains(selected, objectSourceInstanceSeparator) {
+		return ObjectSourceInstanceLabel(source, instance) == selected
+	}
+	switch selected {
 	case ObjectSourcePhoenix:
 		return source == ObjectSourcePhoenix
 	case ObjectSourceCASL:
@@ -110,16 +135,47 @@ func sourceMatchesFilter(source string, selectedSource string) bool {
 	}
 }
 
-func numberFromPanelMark(value string) string {
-	text := strings.TrimSpace(value)
-	if text == "" {
-		return ""
+// NormalizeObjectPultFilter повертає назву пульта без лічильника або ObjectPultAll.
+func NormalizeObjectPultFilter(selected string) string {
+	clean := utils.StripCountSuffix(selected)
+	if clean == "" || clean == ObjectPultAll {
+		return ObjectPultAll
+	}
+	return clean
+}
+
+// BuildObjectPultOptions будує пункти фільтра пультів; порожньо, якщо пульт один.
+func BuildObjectPultOptions(countAll int, pultCounts map[string]int) []string {
+	if len(pultCounts) < 2 {
+		return nil
+	}
+	pults := make([]string, 0, len(pultCounts))
+	for pult := range pultCounts {
+		pults = append(pults, pult)
 	}
+	slices.Sort(pults)
+
+	options := make([]string, 0, len(pults)+1)
+	options = append(options, ObjectPultAll+" ("+strconv.Itoa(countAll)+")")
+	for _, pult := range pults {
+		options = append(options, pult+" ("+strconv.Itoa(pultCounts[pult])+")")
+	}
+	return options
+}
+
+func pultMatchesFilter(pult string, selectedPult string) bool {
+	selected := NormalizeObjectPultFilter(selectedPult)
+	return selected == ObjectPultAll || strings.TrimSpace(pult) == selected
+}
+
+func countSourceInstance(counts map[string]int, source string, instance string) {
+	if label := ObjectSourceInstanceLabel(source, instance); label != "" {
+		counts[label]++
+	}
+}
 
-	if idx := strings.LastIndex(text, "#"); idx >= 0 && idx < len(text)-1 {
-		if number := utils.LeadingDigits(strings.TrimSpace(text[idx+1:])); number != "" {
-			return number
-		}
+func countPult(counts map[string]int, pult string) {
+	if pult = strings.TrimSpace(pult); pult != "" {
+		counts[pult]++
 	}
-	return utils.LeadingDigits(text)
 }
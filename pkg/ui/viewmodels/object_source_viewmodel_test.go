@@ -125,3 +125,25 @@ func TestNumericObjectDisplayNumber(t *testing.T) {
 		t.Fatalf("NumericObjectDisplayNumber(non-numeric) = %d, want fallback ID %d", got, phoenix.ID)
 	}
 }
+
+func TestBuildObjectPultOptions(t *testing.T) {
+	if got := BuildObjectPultOptions(3, map[string]int{"Пульт 7": 3}); got != nil {
+		t.Fatalf("single pult must not produce options, got %v", got)
+	}
+	got := BuildObjectPultOptions(5, map[string]int{"Пульт 9": 2, "Пульт 7": 3})
+	want := []string{"Всі пульти (5)", "Пульт 7 (3)", "Пульт 9 (2)"}
+	if len(got) != len(want) {
+		t.Fatalf("BuildObjectPultOptions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("BuildObjectPultOptions()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if got := NormalizeObjectPultFilter("Пульт 9 (2)"); got != "Пульт 9" {
+		t.Fatalf("NormalizeObjectPultFilter() = %q", got)
+	}
+	if got := NormalizeObjectPultFilter(""); got != ObjectPultAll {
+		t.Fatalf("NormalizeObjectPultFilter(empty) = %q", got)
+	}
+}
@@ -0,0 +1,31 @@
+package viewmodels
+
+import (
+	"errors"
+	"strings"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/usecases"
+)
+
+// FilterQueryHint — коротка довідка з мови фільтрів для підказок рядків пошуку.
+const FilterQueryHint = `Фільтр: слова шукаються в номері, назві, адресі, SIM і телефоні. ` +
+	`Умови: source:casl|phoenix|bridge (або source:casl:<екземпляр>), status:alarm|offline|online|disarmed|blocked|debug, ` +
+	`region:"Київ", sim:kyivstar|vodafone|lifecell або цифри, pult:, type:, object:, ` +
+	`severity:critical|warning|info (журнал), lasttest>48h, lastevent<7d; "-" перед умовою — заперечення.`
+
+// AddSavedView перевіряє назву й вираз і додає подання до списку або замінює однойменне.
+func AddSavedView(views []config.SavedView, name string, query string) ([]config.SavedView, error) {
+	name = strings.TrimSpace(name)
+	query = strings.TrimSpace(query)
+	if name == "" {
+		return nil, errors.New("вкажіть назву подання")
+	}
+	if query == "" {
+		return nil, errors.New("рядок фільтра порожній, зберігати нічого")
+	}
+	if _, err := usecases.ParseFilterQuery(query); err != nil {
+		return nil, err
+	}
+	return config.NormalizeSavedViews(config.UpsertSavedView(views, config.SavedView{Name: name, Query: query}))
+}
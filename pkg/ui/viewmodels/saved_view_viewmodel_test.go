@@ -0,0 +1,26 @@
+package viewmodels
+
+import (
+	"testing"
+
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+func TestAddSavedView(t *testing.T) {
+	views := []config.SavedView{{Name: "Київ", Query: `region:"Київ"`}}
+
+	got, err := AddSavedView(views, " Тести ", " lasttest>48h ")
+	if err != nil || len(got) != 2 || got[1].Name != "Тести" || got[1].Query != "lasttest>48h" {
+		t.Fatalf("AddSavedView() = %+v, %v", got, err)
+	}
+	got, err = AddSavedView(got, "київ", "region:Київ status:offline")
+	if err != nil || len(got) != 2 || got[0].Query != "region:Київ status:offline" {
+		t.Fatalf("same name must replace the view, got %+v, %v", got, err)
+	}
+
+	for name, query := range map[string]string{"": "status:offline", "Порожній": " ", "Помилка": "status:sleeping"} {
+		if _, err := AddSavedView(views, name, query); err == nil {
+			t.Errorf("AddSavedView(%q, %q) expected error", name, query)
+		}
+	}
+}
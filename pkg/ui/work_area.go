@@ -2,6 +2,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"slices"
@@ -225,17 +226,18 @@ func NewWorkAreaPanel(provider contracts.WorkAreaProvider, window fyne.Window) *
 					err := excelProvider.AppendObjectToDeletedReport(panel.CurrentObject, panel.Contacts, tempPDFPath, filePath)
 					fyne.Do(func() {
 						if err != nil {
-							if gdriveErr, ok := err.(*objexport.GoogleDriveUploadError); ok {
-								log.Warn().Err(gdriveErr.Err).Msg("Excel row appended, but Google Drive upload failed")
-								ShowToast(panel.Window, fmt.Sprintf("Об'єкт додано в Excel, але не завантажено на Google Drive: %v", gdriveErr.Err))
+							var uploadErr *objexport.ReportUploadError
+							if errors.As(err, &uploadErr) {
+								log.Warn().Err(uploadErr.Err).Str("target", uploadErr.TargetName()).Msg("Excel row appended, but report upload failed")
+								ShowToast(panel.Window, fmt.Sprintf("Об'єкт додано в Excel, але не завантажено на %s: %v", uploadErr.TargetName(), uploadErr.Err))
 							} else {
 								log.Error().Err(err).Msg("Failed to append object to deleted Excel report")
 								dialog.ShowError(err, panel.Window)
 							}
 							return
 						}
-						log.Info().Msg("Successfully completed deletion process: Excel updated, PDF uploaded to Google Drive")
-						ShowToast(panel.Window, "Об'єкт додано до знятих/видалених Excel та Google Drive")
+						log.Info().Msg("Successfully completed deletion process: Excel updated, PDF published")
+						ShowToast(panel.Window, "Об'єкт додано до знятих/видалених Excel, PDF опубліковано")
 					})
 				}()
 			},
@@ -0,0 +1,373 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+// AlarmStorm — зведення про шторм тривог для банера панелі.
+// Recent — кількість тривог за останні Window; Collapsed — скільки тривог
+// згорнуто в Incidents інцидентів.
+type AlarmStorm struct {
+	Active    bool
+	Recent    int
+	Window    time.Duration
+	Incidents int
+	Collapsed int
+}
+
+// AlarmCorrelator групує пов'язані тривоги в інциденти: технічні тривоги
+// одного об'єкта, технічні тривоги об'єктів одного підсервера (SubServerA/SubServerB)
+// і масову втрату зв'язку одного каналу джерела. Тривоги загрози (пожежа,
+// напад, проникнення) в інциденти не входять: інцидент завершується однією
+// дією, а кожна така тривога потребує окремого реагування. Стоїть між провайдером
+// і AlarmListUseCase; nil-корелятор пропускає тривоги без змін.
+type AlarmCorrelator struct {
+	cfg     config.AlarmCorrelationConfig
+	objects func() []models.Object
+	now     func() time.Time
+
+	mu    sync.Mutex
+	storm AlarmStorm
+}
+
+// NewAlarmCorrelator створює корелятор; objects повертає поточні об'єкти
+// і викликається лише тоді, коли потрібні підсервери об'єктів.
+func NewAlarmCorrelator(cfg config.AlarmCorrelationConfig, objects func() []models.Object) *AlarmCorrelator {
+	return &AlarmCorrelator{cfg: cfg, objects: objects, now: time.Now}
+}
+
+// Correlate повертає копію тривог, де тривоги інцидентів позначено полями
+// IncidentID (ID головної тривоги) та Incident, і зведення про шторм.
+func (c *AlarmCorrelator) Correlate(alarms []models.Alarm) ([]models.Alarm, AlarmStorm) {
+	if c == nil || !c.cfg.Enabled {
+		return alarms, AlarmStorm{}
+	}
+	result := slices.Clone(alarms)
+	for i := range result {
+		result[i].IncidentID = 0
+		result[i].Incident = ""
+	}
+	for _, incident := range c.incidents(result) {
+		head := result[incident.head]
+		for _, index := range incident.members {
+			result[index].IncidentID = head.ID
+			result[index].Incident = incident.title
+		}
+	}
+	storm := c.detectStorm(result)
+	c.mu.Lock()
+	c.storm = storm
+	c.mu.Unlock()
+	return result, storm
+}
+
+// Storm повертає зведення останнього виклику Correlate.
+func (c *AlarmCorrelator) Storm() AlarmStorm {
+	if c == nil {
+		return AlarmStorm{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.storm
+}
+
+// Repository обгортає джерело тривог кореляцією для AlarmListUseCase.
+func (c *AlarmCorrelator) Repository(repository AlarmListRepository) AlarmListRepository {
+	if c == nil || repository == nil {
+		return repository
+	}
+	return correlatedAlarmRepository{repository: repository, correlator: c}
+}
+
+type correlatedAlarmRepository struct {
+	repository AlarmListRepository
+	correlator *AlarmCorrelator
+}
+
+func (r correlatedAlarmRepository) GetAlarms() []models.Alarm {
+	alarms, _ := r.correlator.Correlate(r.repository.GetAlarms())
+	return alarms
+}
+
+type alarmIncident struct {
+	title   string
+	head    int
+	members []int
+}
+
+// incidents застосовує правила від найширшого до найвужчого: тривога, що
+// потрапила в інцидент каналу, вже не розглядається для підсервера чи об'єкта.
+func (c *AlarmCorrelator) incidents(alarms []models.Alarm) []alarmIncident {
+	window := time.Duration(c.cfg.WindowSeconds) * time.Second
+	order := make([]int, len(alarms))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if cmp := alarms[a].Time.Compare(alarms[b].Time); cmp != 0 {
+			return cmp
+		}
+		return alarms[a].ID - alarms[b].ID
+	})
+	assigned := make([]bool, len(alarms))
+	var result []alarmIncident
+	accept := func(clusters [][]int, minObjects int, title func(members []int) string) {
+		for _, members := range clusters {
+			if len(members) < 2 || distinctAlarmObjects(alarms, members) < minObjects {
+				continue
+			}
+			for _, index := range members {
+				assigned[index] = true
+			}
+			result = append(result, alarmIncident{
+				title:   title(members),
+				head:    incidentHead(alarms, members),
+				members: members,
+			})
+		}
+	}
+
+	channels := clusterAlarms(alarms, order, assigned, window, func(alarm models.Alarm) (string, bool) {
+		return alarmChannelLabel(alarm), alarm.Type == models.AlarmOffline
+	})
+	accept(channels, c.cfg.ChannelMinObjects, func(members []int) string {
+		return fmt.Sprintf("Масова втрата зв'язку (%s) — об'єктів: %d", alarmChannelLabel(alarms[members[0]]), distinctAlarmObjects(alarms, members))
+	})
+
+	if subServers := c.subServers(alarms, assigned); len(subServers) > 0 {
+		clusters := clusterAlarms(alarms, order, assigned, window, func(alarm models.Alarm) (string, bool) {
+			subServer, ok := subServers[alarm.ObjectID]
+			return subServer, ok && isTechnicalAlarm(alarm)
+		})
+		accept(clusters, c.cfg.SubServerMinObjects, func(members []int) string {
+			return fmt.Sprintf("Підсервер %s — об'єктів з технічними тривогами: %d", subServers[alarms[members[0]].ObjectID], distinctAlarmObjects(alarms, members))
+		})
+	}
+
+	objects := clusterAlarms(alarms, order, assigned, window, func(alarm models.Alarm) (string, bool) {
+		return strconv.Itoa(alarm.ObjectID), isTechnicalAlarm(alarm)
+	})
+	accept(objects, 1, func(members []int) string {
+		head := alarms[incidentHead(alarms, members)]
+		return fmt.Sprintf("№%s — пов'язаних тривог: %d", head.GetObjectNumberDisplay(), len(members))
+	})
+	return result
+}
+
+// subServers повертає підсервер кожного об'єкта з технічною тривогою. Список
+// об'єктів запитується лише коли таких тривог достатньо для інциденту.
+func (c *AlarmCorrelator) subServers(alarms []models.Alarm, assigned []bool) map[int]string {
+	if c.objects == nil {
+		return nil
+	}
+	candidates := make(map[int]struct{})
+	for i, alarm := range alarms {
+		if !assigned[i] && isTechnicalAlarm(alarm) {
+			candidates[alarm.ObjectID] = struct{}{}
+		}
+	}
+	if len(candidates) < c.cfg.SubServerMinObjects {
+		return nil
+	}
+	result := make(map[int]string, len(candidates))
+	for _, object := range c.objects() {
+		if _, ok := candidates[object.ID]; !ok {
+			continue
+		}
+		subServer := strings.TrimSpace(object.SubServerA)
+		if subServer == "" {
+			subServer = strings.TrimSpace(object.SubServerB)
+		}
+		if subServer != "" {
+			result[object.ID] = subServer
+		}
+	}
+	return result
+}
+
+func (c *AlarmCorrelator) detectStorm(alarms []models.Alarm) AlarmStorm {
+	window := time.Duration(c.cfg.StormWindowSeconds) * time.Second
+	since := c.now().Add(-window)
+	storm := AlarmStorm{Window: window}
+	incidents := make(map[int]struct{})
+	for _, alarm := range alarms {
+		if !alarm.Time.Before(since) {
+			storm.Recent++
+		}
+		if alarm.IncidentID != 0 {
+			incidents[alarm.IncidentID] = struct{}{}
+			storm.Collapsed++
+		}
+	}
+	storm.Incidents = len(incidents)
+	storm.Collapsed -= storm.Incidents
+	storm.Active = storm.Recent >= c.cfg.StormThreshold
+	return storm
+}
+
+// clusterAlarms ділить ще не згруповані тривоги за ключем key, а кожну
+// групу — на ланцюжки, де сусідні тривоги розділяє не більше window.
+func clusterAlarms(alarms []models.Alarm, order []int, assigned []bool, window time.Duration, key func(models.Alarm) (string, bool)) [][]int {
+	byKey := make(map[string][][]int)
+	var keys []string
+	for _, index := range order {
+		if assigned[index] {
+			continue
+		}
+		value, ok := key(alarms[index])
+		if !ok {
+			continue
+		}
+		chains, seen := byKey[value]
+		if !seen {
+			keys = append(keys, value)
+		}
+		if n := len(chains); n > 0 {
+			last := chains[n-1]
+			if alarms[index].Time.Sub(alarms[last[len(last)-1]].Time) <= window {
+				chains[n-1] = append(last, index)
+				byKey[value] = chains
+				continue
+			}
+		}
+		byKey[value] = append(chains, []int{index})
+	}
+	var result [][]int
+	for _, value := range keys {
+		result = append(result, byKey[value]...)
+	}
+	return result
+}
+
+// incidentHead обирає головну тривогу: спершу необроблену, потім найновішу.
+func incidentHead(alarms []models.Alarm, members []int) int {
+	head := members[0]
+	for _, index := range members[1:] {
+		if alarmHeadRank(alarms[index], alarms[head]) > 0 {
+			head = index
+		}
+	}
+	return head
+}
+
+func alarmHeadRank(a, b models.Alarm) int {
+	if a.IsProcessed != b.IsProcessed {
+		if !a.IsProcessed {
+			return 1
+		}
+		return -1
+	}
+	return a.Time.Compare(b.Time)
+}
+
+func distinctAlarmObjects(alarms []models.Alarm, members []int) int {
+	objects := make(map[int]struct{}, len(members))
+	for _, index := range members {
+		objects[alarms[index].ObjectID] = struct{}{}
+	}
+	return len(objects)
+}
+
+// isTechnicalAlarm відбирає тривоги обладнання та каналів зв'язку: лише їх
+// можна поєднувати в інциденти. Пожежа чи напад — окремі події, навіть якщо
+// збіглися в часі з несправністю того ж чи сусіднього об'єкта.
+func isTechnicalAlarm(alarm models.Alarm) bool {
+	switch alarm.Type {
+	case models.AlarmFault, models.AlarmPowerFail, models.AlarmBatteryLow, models.AlarmOffline,
+		models.AlarmAcTrouble, models.AlarmFireTrouble, models.AlarmTestMissed, models.AlarmSystemEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+var alarmChannelSourceLabels = map[contracts.FrontendSource]string{
+	contracts.FrontendSourceBridge:  "БД/МІСТ",
+	contracts.FrontendSourcePhoenix: "Phoenix",
+	contracts.FrontendSourceCASL:    "CASL Cloud",
+}
+
+// alarmChannelLabel — канал тривоги: джерело з екземпляром і пультом.
+func alarmChannelLabel(alarm models.Alarm) string {
+	parts := []string{alarmChannelSourceLabels[contracts.DetectFrontendSourceByObjectID(alarm.ObjectID)]}
+	for _, part := range []string{alarm.SourceInstance, alarm.Pult} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// AlarmIncidentProcessor завершує тривоги по одній.
+type AlarmIncidentProcessor interface {
+	ProcessAlarm(id string, user string, note string) error
+}
+
+// ProcessAlarmIncident завершує тривоги інциденту. Якщо джерело підтримує
+// групове завершення (contracts.AlarmGroupProcessProvider), об'єкт МІСТ з
+// кількома тривогами завершується одним GroupProcessAlarm — але лише коли
+// всі його активні тривоги (active) входять до інциденту, бо групове
+// завершення знімає всі тривоги об'єкта. Решта тривог завершується по одній
+// з приміткою note. Повертає кількість завершених тривог.
+func ProcessAlarmIncident(
+	ctx context.Context,
+	processor AlarmIncidentProcessor,
+	incident []models.Alarm,
+	active []models.Alarm,
+	user string,
+	note string,
+) (int, error) {
+	if processor == nil {
+		return 0, contracts.ErrFrontendBackendUnavailable
+	}
+	byObject := make(map[int][]models.Alarm)
+	var objectOrder []int
+	for _, alarm := range incident {
+		if _, ok := byObject[alarm.ObjectID]; !ok {
+			objectOrder = append(objectOrder, alarm.ObjectID)
+		}
+		byObject[alarm.ObjectID] = append(byObject[alarm.ObjectID], alarm)
+	}
+	activeByObject := make(map[int]int)
+	for _, alarm := range active {
+		activeByObject[alarm.ObjectID]++
+	}
+	groupProcessor, canGroup := processor.(contracts.AlarmGroupProcessProvider)
+
+	processed := 0
+	var errs []error
+	for _, objectID := range objectOrder {
+		alarms := byObject[objectID]
+		if canGroup && len(alarms) > 1 && activeByObject[objectID] <= len(alarms) &&
+			contracts.DetectFrontendSourceByObjectID(objectID) == contracts.FrontendSourceBridge {
+			if err := groupProcessor.GroupProcessAlarm(ctx, alarms[0], user); err != nil {
+				errs = append(errs, fmt.Errorf("№%s: %w", alarms[0].GetObjectNumberDisplay(), err))
+				continue
+			}
+			processed += len(alarms)
+			continue
+		}
+		for _, alarm := range alarms {
+			if err := ctx.Err(); err != nil {
+				return processed, errors.Join(append(errs, err)...)
+			}
+			if err := processor.ProcessAlarm(strconv.Itoa(alarm.ID), user, note); err != nil {
+				errs = append(errs, fmt.Errorf("№%s: %w", alarm.GetObjectNumberDisplay(), err))
+				continue
+			}
+			processed++
+		}
+	}
+	return processed, errors.Join(errs...)
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func testAlarmCorrelationConfig() config.AlarmCorrelationConfig {
+	return config.AlarmCorrelationConfig{
+		Enabled:             true,
+		WindowSeconds:       60,
+		SubServerMinObjects: 3,
+		ChannelMinObjects:   4,
+		StormThreshold:      10,
+		StormWindowSeconds:  120,
+	}
+}
+
+func newTestAlarmCorrelator(objects []models.Object, now time.Time) *AlarmCorrelator {
+	correlator := NewAlarmCorrelator(testAlarmCorrelationConfig(), func() []models.Object { return objects })
+	correlator.now = func() time.Time { return now }
+	return correlator
+}
+
+func incidentOf(alarms []models.Alarm, id int) int {
+	for _, alarm := range alarms {
+		if alarm.ID == id {
+			return alarm.IncidentID
+		}
+	}
+	return -1
+}
+
+func TestAlarmCorrelatorGroupsChannelSubServerAndObject(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	var alarms []models.Alarm
+	// Масова втрата зв'язку на одному пульті CASL: 5 об'єктів за 2 хвилини.
+	for i := 0; i < 5; i++ {
+		alarms = append(alarms, models.Alarm{
+			ID:       100 + i,
+			ObjectID: ids.CASLObjectIDNamespaceStart + i,
+			Type:     models.AlarmOffline,
+			Pult:     "Північ",
+			Time:     now.Add(-10*time.Minute + time.Duration(i)*30*time.Second),
+		})
+	}
+	// Технічні тривоги трьох об'єктів одного підсервера МІСТ.
+	objects := []models.Object{
+		{ID: 11, SubServerA: "SBS-2"},
+		{ID: 12, SubServerA: "SBS-2"},
+		{ID: 13, SubServerB: "SBS-2"},
+		{ID: 14, SubServerA: "SBS-2"},
+	}
+	alarms = append(alarms,
+		models.Alarm{ID: 200, ObjectID: 11, Type: models.AlarmPowerFail, Time: now.Add(-5 * time.Minute)},
+		models.Alarm{ID: 201, ObjectID: 12, Type: models.AlarmFault, Time: now.Add(-5*time.Minute + 20*time.Second)},
+		models.Alarm{ID: 202, ObjectID: 13, Type: models.AlarmAcTrouble, Time: now.Add(-5*time.Minute + 40*time.Second)},
+		// Пожежа на об'єкті того ж підсервера не поєднується з технічними тривогами.
+		models.Alarm{ID: 203, ObjectID: 14, Type: models.AlarmFire, Time: now.Add(-5*time.Minute + 50*time.Second)},
+	)
+	// Технічні тривоги одного об'єкта: головною стає найновіша.
+	alarms = append(alarms,
+		models.Alarm{ID: 300, ObjectID: 21, Type: models.AlarmFault, Time: now.Add(-2 * time.Minute)},
+		models.Alarm{ID: 301, ObjectID: 21, Type: models.AlarmPowerFail, Time: now.Add(-90 * time.Second)},
+		models.Alarm{ID: 302, ObjectID: 21, Type: models.AlarmFault, Time: now.Add(-30 * time.Minute)},
+	)
+
+	correlated, storm := newTestAlarmCorrelator(objects, now).Correlate(alarms)
+
+	channel := incidentOf(correlated, 100)
+	for i := 0; i < 5; i++ {
+		if got := incidentOf(correlated, 100+i); got != channel || got == 0 {
+			t.Fatalf("CASL offline alarm %d incident = %d, want %d", 100+i, got, channel)
+		}
+	}
+	subServer := incidentOf(correlated, 200)
+	if subServer == 0 || incidentOf(correlated, 201) != subServer || incidentOf(correlated, 202) != subServer {
+		t.Fatalf("subserver incidents = %d %d %d", subServer, incidentOf(correlated, 201), incidentOf(correlated, 202))
+	}
+	if incidentOf(correlated, 203) != 0 {
+		t.Fatal("fire must not join a subserver incident")
+	}
+	if incidentOf(correlated, 300) != 301 || incidentOf(correlated, 301) != 301 {
+		t.Fatalf("object incident = %d %d, want newest 301 as head", incidentOf(correlated, 300), incidentOf(correlated, 301))
+	}
+	if incidentOf(correlated, 302) != 0 {
+		t.Fatal("alarm outside the window must stay single")
+	}
+	if alarms[0].IncidentID != 0 {
+		t.Fatal("Correlate must not modify the input slice")
+	}
+	if storm.Active || storm.Incidents != 3 || storm.Collapsed != 7 {
+		t.Fatalf("storm = %+v, want 3 incidents with 7 collapsed alarms and no storm", storm)
+	}
+}
+
+func TestAlarmCorrelatorSmallChannelFallsBackToObjects(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	alarms := []models.Alarm{
+		{ID: 1, ObjectID: 1, Type: models.AlarmOffline, Time: now},
+		{ID: 2, ObjectID: 2, Type: models.AlarmOffline, Time: now},
+		{ID: 3, ObjectID: 2, Type: models.AlarmPowerFail, Time: now.Add(time.Second)},
+	}
+	correlated, _ := newTestAlarmCorrelator(nil, now).Correlate(alarms)
+	if incidentOf(correlated, 1) != 0 || incidentOf(correlated, 2) == 0 || incidentOf(correlated, 2) != incidentOf(correlated, 3) {
+		t.Fatalf("incidents = %d %d %d", incidentOf(correlated, 1), incidentOf(correlated, 2), incidentOf(correlated, 3))
+	}
+}
+
+func TestAlarmCorrelatorKeepsThreatsOutOfObjectIncidents(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	alarms := []models.Alarm{
+		{ID: 1, ObjectID: 7, Type: models.AlarmFire, Time: now},
+		{ID: 2, ObjectID: 7, Type: models.AlarmOffline, Time: now.Add(10 * time.Second)},
+		{ID: 3, ObjectID: 8, Type: models.AlarmBurglary, Time: now},
+		{ID: 4, ObjectID: 8, Type: models.AlarmPanic, Time: now.Add(5 * time.Second)},
+	}
+	correlated, storm := newTestAlarmCorrelator(nil, now).Correlate(alarms)
+	for _, alarm := range correlated {
+		if alarm.IncidentID != 0 {
+			t.Fatalf("alarm %d joined incident %d; fire and intrusion must stay single", alarm.ID, alarm.IncidentID)
+		}
+	}
+	if storm.Incidents != 0 {
+		t.Fatalf("storm = %+v, want no incidents", storm)
+	}
+}
+
+func TestAlarmCorrelatorDetectsStormAndFeedsUseCase(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	var alarms []models.Alarm
+	for i := 0; i < 12; i++ {
+		alarms = append(alarms, models.Alarm{ID: i + 1, ObjectID: 500 + i, Type: models.AlarmBurglary, Time: now.Add(-time.Duration(i) * 5 * time.Second)})
+	}
+	correlator := newTestAlarmCorrelator(nil, now)
+	fetched := NewAlarmListUseCase(correlator.Repository(staticAlarmRepository(alarms))).FetchAlarms()
+	if len(fetched) != len(alarms) {
+		t.Fatalf("FetchAlarms() returned %d alarms, want %d", len(fetched), len(alarms))
+	}
+	if storm := correlator.Storm(); !storm.Active || storm.Recent != 12 || storm.Window != 2*time.Minute {
+		t.Fatalf("Storm() = %+v, want an active storm of 12 alarms", storm)
+	}
+
+	var disabled *AlarmCorrelator
+	if got, storm := disabled.Correlate(alarms); len(got) != len(alarms) || storm.Active {
+		t.Fatal("nil correlator must pass alarms through")
+	}
+}
+
+type staticAlarmRepository []models.Alarm
+
+func (r staticAlarmRepository) GetAlarms() []models.Alarm { return r }
+
+type incidentProcessorStub struct {
+	processed      []string
+	groupProcessed []int
+	failID         int
+}
+
+func (s *incidentProcessorStub) ProcessAlarm(id string, user string, note string) error {
+	if id == strconv.Itoa(s.failID) {
+		return errors.New("тривогу вже завершено")
+	}
+	s.processed = append(s.processed, id)
+	return nil
+}
+
+func (s *incidentProcessorStub) GroupProcessAlarm(_ context.Context, alarm models.Alarm, user string) error {
+	s.groupProcessed = append(s.groupProcessed, alarm.ObjectID)
+	return nil
+}
+
+func TestProcessAlarmIncidentUsesGroupProcessingForWholeBridgeObjects(t *testing.T) {
+	incident := []models.Alarm{
+		{ID: 1, ObjectID: 10},
+		{ID: 2, ObjectID: 10},
+		{ID: 3, ObjectID: 20},
+		{ID: 4, ObjectID: 20},
+		{ID: 5, ObjectID: ids.CASLObjectIDNamespaceStart + 1},
+		{ID: 6, ObjectID: ids.CASLObjectIDNamespaceStart + 1},
+	}
+	// Об'єкт 20 має ще одну активну тривогу поза інцидентом.
+	active := append(incident[:len(incident):len(incident)], models.Alarm{ID: 7, ObjectID: 20})
+	stub := &incidentProcessorStub{failID: 6}
+
+	processed, err := ProcessAlarmIncident(context.Background(), stub, incident, active, "Оператор", "Інцидент")
+	if processed != 5 || err == nil {
+		t.Fatalf("ProcessAlarmIncident() = %d, %v, want 5 and one error", processed, err)
+	}
+	if len(stub.groupProcessed) != 1 || stub.groupProcessed[0] != 10 {
+		t.Fatalf("group processed objects = %v, want only object 10", stub.groupProcessed)
+	}
+	if len(stub.processed) != 3 {
+		t.Fatalf("single processed alarms = %v, want 3, 4 and 5", stub.processed)
+	}
+}
@@ -0,0 +1,516 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"obj_catalog_fyne_v3/pkg/contracts"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+	"obj_catalog_fyne_v3/pkg/simoperator"
+	"obj_catalog_fyne_v3/pkg/utils"
+)
+
+// Поля мови фільтрів. Умова має вигляд поле:значення або, для часових полів,
+// поле>тривалість / поле<тривалість; значення з пробілами беруться в лапки,
+// а "-" перед умовою її заперечує. Решта слів шукається як вільний текст.
+const (
+	FilterFieldSource    = "source"
+	FilterFieldStatus    = "status"
+	FilterFieldRegion    = "region"
+	FilterFieldSIM       = "sim"
+	FilterFieldPult      = "pult"
+	FilterFieldLastTest  = "lasttest"
+	FilterFieldLastEvent = "lastevent"
+	FilterFieldType      = "type"
+	FilterFieldSeverity  = "severity"
+	FilterFieldObject    = "object"
+)
+
+// filterFieldAliases зводить скорочення до назв полів; src: лишився від старого пошуку.
+var filterFieldAliases = map[string]string{
+	"src":     FilterFieldSource,
+	"lastmsg": FilterFieldLastEvent,
+	"obj":     FilterFieldObject,
+}
+
+type filterOp string
+
+const (
+	filterOpEqual        filterOp = ":"
+	filterOpGreater      filterOp = ">"
+	filterOpGreaterEqual filterOp = ">="
+	filterOpLess         filterOp = "<"
+	filterOpLessEqual    filterOp = "<="
+)
+
+// Значення status:, які розуміє фільтр.
+const (
+	FilterStatusAlarm    = "alarm"
+	FilterStatusFire     = "fire"
+	FilterStatusFault    = "fault"
+	FilterStatusOffline  = "offline"
+	FilterStatusOnline   = "online"
+	FilterStatusArmed    = "armed"
+	FilterStatusDisarmed = "disarmed"
+	FilterStatusBlocked  = "blocked"
+	FilterStatusDebug    = "debug"
+	FilterStatusNormal   = "normal"
+)
+
+// Значення severity: для подій.
+const (
+	FilterSeverityCritical = "critical"
+	FilterSeverityWarning  = "warning"
+	FilterSeverityInfo     = "info"
+)
+
+type filterTerm struct {
+	field    string
+	op       filterOp
+	value    string
+	duration time.Duration
+	source   contracts.FrontendSource
+	instance string
+	negate   bool
+}
+
+// filterSourceAliases — значення source:, зокрема назви зі старого пошуку src:.
+var filterSourceAliases = map[string]contracts.FrontendSource{
+	"bridge":     contracts.FrontendSourceBridge,
+	"mist":       contracts.FrontendSourceBridge,
+	"міст":       contracts.FrontendSourceBridge,
+	"db":         contracts.FrontendSourceBridge,
+	"бд":         contracts.FrontendSourceBridge,
+	"бд/міст":    contracts.FrontendSourceBridge,
+	"db/bridge":  contracts.FrontendSourceBridge,
+	"firebird":   contracts.FrontendSourceBridge,
+	"phoenix":    contracts.FrontendSourcePhoenix,
+	"фенікс":     contracts.FrontendSourcePhoenix,
+	"casl":       contracts.FrontendSourceCASL,
+	"casl cloud": contracts.FrontendSourceCASL,
+}
+
+// FilterQuery — розібраний вираз фільтра списку об'єктів чи журналу подій.
+// Умови, яких не має запис (напр. status: для події), не звужують результат,
+// тож одне збережене подання можна застосувати і до об'єктів, і до журналу.
+type FilterQuery struct {
+	raw   string
+	terms []filterTerm
+	text  []string
+}
+
+// ParseFilterQuery розбирає вираз фільтра на умови полів і слова вільного тексту.
+// Невідоме поле (напр. "вул:Шевченка") вважається звичайним текстом.
+func ParseFilterQuery(raw string) (FilterQuery, error) {
+	query := FilterQuery{raw: strings.TrimSpace(raw)}
+	tokens, err := splitFilterTokens(query.raw)
+	if err != nil {
+		return FilterQuery{}, err
+	}
+	for _, token := range tokens {
+		term, ok, err := parseFilterTerm(token)
+		if err != nil {
+			return FilterQuery{}, err
+		}
+		if ok {
+			query.terms = append(query.terms, term)
+			continue
+		}
+		if text := strings.ToLower(strings.TrimSpace(token.text)); text != "" {
+			query.text = append(query.text, text)
+		}
+	}
+	return query, nil
+}
+
+// String повертає вираз у тому вигляді, як його ввели.
+func (q FilterQuery) String() string {
+	return q.raw
+}
+
+// Empty повідомляє, що вираз нічого не відсіює.
+func (q FilterQuery) Empty() bool {
+	return len(q.terms) == 0 && len(q.text) == 0
+}
+
+// MatchObject перевіряє об'єкт на всі умови й слова виразу; now потрібен для lasttest/lastevent.
+func (q FilterQuery) MatchObject(object models.Object, now time.Time) bool {
+	for _, term := range q.terms {
+		matched, applicable := matchObjectTerm(object, term, now)
+		if applicable && matched == term.negate {
+			return false
+		}
+	}
+	for _, text := range q.text {
+		if !matchObjectText(object, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchEvent перевіряє подію журналу. Поля об'єкта (status, region, sim...) для подій не застосовуються.
+func (q FilterQuery) MatchEvent(event models.Event) bool {
+	for _, term := range q.terms {
+		matched, applicable := matchEventTerm(event, term)
+		if applicable && matched == term.negate {
+			return false
+		}
+	}
+	for _, text := range q.text {
+		if !matchEventText(event, text) {
+			return false
+		}
+	}
+	return true
+}
+
+type filterToken struct {
+	text string
+	// phrase — токен почався з лапок, тобто це фраза вільного тексту, а не умова.
+	phrase bool
+}
+
+// splitFilterTokens ділить вираз за пробілами поза лапками; лапки знімаються.
+func splitFilterTokens(raw string) ([]filterToken, error) {
+	var (
+		tokens  []filterToken
+		current strings.Builder
+		phrase  bool
+		inQuote bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, filterToken{text: current.String(), phrase: phrase})
+		}
+		current.Reset()
+		phrase = false
+	}
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			if current.Len() == 0 && !inQuote {
+				phrase = true
+			}
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("фільтр: не закрито лапки")
+	}
+	flush()
+	return tokens, nil
+}
+
+func parseFilterTerm(token filterToken) (filterTerm, bool, error) {
+	if token.phrase {
+		return filterTerm{}, false, nil
+	}
+	text := token.text
+	term := filterTerm{}
+	if strings.HasPrefix(text, "-") {
+		term.negate = true
+		text = text[1:]
+	}
+	end := strings.IndexFunc(text, func(r rune) bool { return r == ':' || r == '=' || r == '>' || r == '<' })
+	if end <= 0 {
+		return filterTerm{}, false, nil
+	}
+	field := strings.ToLower(text[:end])
+	if alias, ok := filterFieldAliases[field]; ok {
+		field = alias
+	}
+	if !isFilterField(field) {
+		return filterTerm{}, false, nil
+	}
+	term.field = field
+	rest := text[end:]
+	switch {
+	case strings.HasPrefix(rest, ">="):
+		term.op = filterOpGreaterEqual
+	case strings.HasPrefix(rest, "<="):
+		term.op = filterOpLessEqual
+	case strings.HasPrefix(rest, ">"):
+		term.op = filterOpGreater
+	case strings.HasPrefix(rest, "<"):
+		term.op = filterOpLess
+	default:
+		term.op = filterOpEqual
+	}
+	term.value = strings.ToLower(strings.TrimSpace(rest[len(term.op):]))
+	if term.value == "" {
+		return filterTerm{}, false, fmt.Errorf("фільтр %s: не вказано значення", field)
+	}
+	if err := validateFilterTerm(&term); err != nil {
+		return filterTerm{}, false, err
+	}
+	return term, true, nil
+}
+
+func isFilterField(field string) bool {
+	switch field {
+	case FilterFieldSource, FilterFieldStatus, FilterFieldRegion, FilterFieldSIM, FilterFieldPult,
+		FilterFieldLastTest, FilterFieldLastEvent, FilterFieldType, FilterFieldSeverity, FilterFieldObject:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateFilterTerm(term *filterTerm) error {
+	switch term.field {
+	case FilterFieldLastTest, FilterFieldLastEvent:
+		if term.op == filterOpEqual {
+			return fmt.Errorf("фільтр %s: вкажіть > або <, напр. %s>48h", term.field, term.field)
+		}
+		duration, err := parseFilterDuration(term.value)
+		if err != nil {
+			return fmt.Errorf("фільтр %s: некоректна тривалість %q", term.field, term.value)
+		}
+		term.duration = duration
+		return nil
+	}
+	if term.op != filterOpEqual {
+		return fmt.Errorf("фільтр %s: порівняння > і < підтримують лише lasttest і lastevent", term.field)
+	}
+	switch term.field {
+	case FilterFieldSource:
+		// source:casl:Київ звужує до екземпляра джерела з такою назвою.
+		kind, instance, hasInstance := strings.Cut(term.value, ":")
+		source, ok := filterSourceAliases[strings.TrimSpace(kind)]
+		if !ok {
+			return fmt.Errorf("фільтр source: невідоме джерело %q, вкажіть casl, phoenix або bridge", kind)
+		}
+		term.source = source
+		term.instance = strings.TrimSpace(instance)
+		if hasInstance && term.instance == "" {
+			return fmt.Errorf("фільтр source: не вказано назву екземпляра після %q", kind+":")
+		}
+	case FilterFieldStatus:
+		switch term.value {
+		case FilterStatusAlarm, FilterStatusFire, FilterStatusFault, FilterStatusOffline, FilterStatusOnline,
+			FilterStatusArmed, FilterStatusDisarmed, FilterStatusBlocked, FilterStatusDebug, FilterStatusNormal:
+		default:
+			return fmt.Errorf("фільтр status: невідомий стан %q", term.value)
+		}
+	case FilterFieldSeverity:
+		switch term.value {
+		case FilterSeverityCritical, FilterSeverityWarning, FilterSeverityInfo:
+		default:
+			return fmt.Errorf("фільтр severity: невідома важливість %q", term.value)
+		}
+	case FilterFieldSIM:
+		if utils.DigitsOnly(term.value) == "" && !isFilterSIMOperator(term.value) {
+			return fmt.Errorf("фільтр sim: вкажіть цифри номера або оператора (kyivstar, vodafone, lifecell)")
+		}
+	}
+	return nil
+}
+
+// parseFilterDuration розуміє тривалості Go (48h, 90m) і дні: 7d, 1d12h.
+func parseFilterDuration(raw string) (time.Duration, error) {
+	days := time.Duration(0)
+	if index := strings.Index(raw, "d"); index > 0 {
+		count, err := strconv.Atoi(raw[:index])
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid days %q", raw)
+		}
+		days = time.Duration(count) * 24 * time.Hour
+		raw = raw[index+1:]
+		if raw == "" {
+			return days, nil
+		}
+	}
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q", raw)
+	}
+	return days + duration, nil
+}
+
+func matchObjectTerm(object models.Object, term filterTerm, now time.Time) (matched bool, applicable bool) {
+	switch term.field {
+	case FilterFieldSource:
+		return matchFilterSource(contracts.DetectFrontendSourceByObjectID(object.ID), object.SourceInstance, term), true
+	case FilterFieldStatus:
+		return matchObjectStatus(object, term.value), true
+	case FilterFieldRegion:
+		return containsFold(object.Address, term.value) || containsFold(object.Location1, term.value), true
+	case FilterFieldSIM:
+		return matchObjectSIM(object, term.value), true
+	case FilterFieldPult:
+		return containsFold(object.Pult, term.value), true
+	case FilterFieldLastTest:
+		return matchFilterAge(object.LastTestTime, term, now), true
+	case FilterFieldLastEvent:
+		return matchFilterAge(object.LastMessageTime, term, now), true
+	case FilterFieldType:
+		return containsFold(object.DeviceType, term.value) || containsFold(object.PanelMark, term.value), true
+	case FilterFieldObject:
+		return matchObjectNumber(object.ID, ids.ObjectDisplayNumber(object), term.value), true
+	default:
+		return false, false
+	}
+}
+
+func matchEventTerm(event models.Event, term filterTerm) (matched bool, applicable bool) {
+	switch term.field {
+	case FilterFieldSource:
+		return eventFrontendSource(event) == term.source, true
+	case FilterFieldType:
+		return strings.EqualFold(string(event.Type), term.value) || containsFold(event.GetTypeDisplay(), term.value), true
+	case FilterFieldSeverity:
+		switch term.value {
+		case FilterSeverityCritical:
+			return event.IsCritical(), true
+		case FilterSeverityWarning:
+			return event.IsWarning(), true
+		default:
+			return !event.IsCritical() && !event.IsWarning(), true
+		}
+	case FilterFieldObject:
+		return matchObjectNumber(event.ObjectID, event.ObjectNumber, term.value), true
+	default:
+		return false, false
+	}
+}
+
+func eventFrontendSource(event models.Event) contracts.FrontendSource {
+	switch event.Source {
+	case models.EventSourceCASL:
+		return contracts.FrontendSourceCASL
+	case models.EventSourcePhoenix:
+		return contracts.FrontendSourcePhoenix
+	case models.EventSourceBridge:
+		return contracts.FrontendSourceBridge
+	default:
+		return contracts.DetectFrontendSourceByObjectID(event.ObjectID)
+	}
+}
+
+func matchFilterSource(source contracts.FrontendSource, instance string, term filterTerm) bool {
+	if source != term.source {
+		return false
+	}
+	return term.instance == "" || strings.EqualFold(strings.TrimSpace(instance), term.instance)
+}
+
+func matchObjectStatus(object models.Object, value string) bool {
+	switch value {
+	case FilterStatusAlarm:
+		return object.Status == models.StatusFire || object.Status == models.StatusFault
+	case FilterStatusFire:
+		return object.Status == models.StatusFire
+	case FilterStatusFault:
+		return object.Status == models.StatusFault
+	case FilterStatusOffline:
+		return object.ConnectionStatusValue() == models.ConnectionStatusOffline
+	case FilterStatusOnline:
+		return object.ConnectionStatusValue() == models.ConnectionStatusOnline
+	case FilterStatusArmed:
+		return object.GuardStatusValue() == models.GuardStatusGuarded
+	case FilterStatusDisarmed:
+		return object.GuardStatusValue() == models.GuardStatusDisarmed
+	case FilterStatusBlocked:
+		return object.MonitoringStatusValue() == models.MonitoringStatusBlocked
+	case FilterStatusDebug:
+		return object.MonitoringStatusValue() == models.MonitoringStatusDebug
+	default:
+		return object.Status == models.StatusNormal
+	}
+}
+
+func matchObjectSIM(object models.Object, value string) bool {
+	if isFilterSIMOperator(value) {
+		operator := simoperator.Operator(value)
+		return simoperator.Detect(object.SIM1) == operator || simoperator.Detect(object.SIM2) == operator
+	}
+	digits := utils.DigitsOnly(value)
+	return strings.Contains(utils.DigitsOnly(object.SIM1), digits) || strings.Contains(utils.DigitsOnly(object.SIM2), digits)
+}
+
+func isFilterSIMOperator(value string) bool {
+	switch simoperator.Operator(value) {
+	case simoperator.Vodafone, simoperator.Kyivstar, simoperator.Lifecell:
+		return true
+	default:
+		return false
+	}
+}
+
+// matchFilterAge порівнює давність моменту з тривалістю умови. Об'єкт без
+// тесту вважається як завгодно давнім.
+func matchFilterAge(at time.Time, term filterTerm, now time.Time) bool {
+	if at.IsZero() {
+		return term.op == filterOpGreater || term.op == filterOpGreaterEqual
+	}
+	age := now.Sub(at)
+	switch term.op {
+	case filterOpGreater:
+		return age > term.duration
+	case filterOpGreaterEqual:
+		return age >= term.duration
+	case filterOpLess:
+		return age < term.duration
+	default:
+		return age <= term.duration
+	}
+}
+
+func matchObjectNumber(objectID int, displayNumber string, value string) bool {
+	return strings.EqualFold(strings.TrimSpace(displayNumber), value) || strconv.Itoa(objectID) == value
+}
+
+// objectSourceSearchText — підписи джерел, за якими їх знаходить вільний текст.
+var objectSourceSearchText = map[contracts.FrontendSource]string{
+	contracts.FrontendSourceBridge:  "бд/міст",
+	contracts.FrontendSourcePhoenix: "phoenix",
+	contracts.FrontendSourceCASL:    "casl cloud",
+}
+
+// matchObjectText шукає слово в номері, назві, адресі, договорі, телефоні й SIM;
+// від чотирьох цифр слово порівнюється і з цифрами телефонів без форматування.
+func matchObjectText(object models.Object, text string) bool {
+	idText := strconv.Itoa(object.ID)
+	displayNumber := strings.ToLower(strings.TrimSpace(ids.ObjectDisplayNumber(object)))
+	if digits := utils.DigitsOnly(text); digits != "" && utils.IsDigitsOnlyTerm(text) && len(digits) >= 4 {
+		if strings.Contains(utils.DigitsOnly(object.SIM1), digits) || strings.Contains(utils.DigitsOnly(object.SIM2), digits) ||
+			strings.Contains(utils.DigitsOnly(object.Phone), digits) || strings.Contains(idText, digits) ||
+			strings.Contains(displayNumber, digits) {
+			return true
+		}
+	}
+	return strings.Contains(idText, text) ||
+		strings.Contains(displayNumber, text) ||
+		containsFold(object.Name, text) ||
+		containsFold(object.Address, text) ||
+		containsFold(object.ContractNum, text) ||
+		containsFold(object.Phone, text) ||
+		containsFold(object.SIM1, text) ||
+		containsFold(object.SIM2, text) ||
+		strings.Contains(objectSourceSearchText[contracts.DetectFrontendSourceByObjectID(object.ID)], text)
+}
+
+// matchEventText шукає слово в номері й назві об'єкта, типі, зоні та деталях події.
+func matchEventText(event models.Event, text string) bool {
+	return strings.Contains(strconv.Itoa(event.ObjectID), text) ||
+		containsFold(event.ObjectNumber, text) ||
+		containsFold(event.ObjectName, text) ||
+		containsFold(event.GetTypeDisplay(), text) ||
+		containsFold(event.ZoneName, text) ||
+		containsFold(event.Details, text) ||
+		containsFold(event.UserName, text)
+}
+
+// containsFold — strings.Contains без урахування регістру; value вже в нижньому регістрі.
+func containsFold(haystack string, value string) bool {
+	return strings.Contains(strings.ToLower(strings.TrimSpace(haystack)), value)
+}
@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"obj_catalog_fyne_v3/pkg/config"
+	"obj_catalog_fyne_v3/pkg/ids"
+	"obj_catalog_fyne_v3/pkg/models"
+)
+
+func TestParseFilterQuery_ObjectConditions(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	kyivOffline := models.Object{
+		ID:               ids.CASLObjectIDNamespaceStart + 1,
+		Name:             "Аптека",
+		Address:          "м. Київ, вул. Січових Стрільців, 5",
+		SIM1:             "+380671234567",
+		ConnectionStatus: models.ConnectionStatusOffline,
+		LastTestTime:     now.Add(-72 * time.Hour),
+	}
+	kyivOnline := kyivOffline
+	kyivOnline.ID++
+	kyivOnline.ConnectionStatus = models.ConnectionStatusOnline
+	lvivOffline := kyivOffline
+	lvivOffline.ID += 2
+	lvivOffline.Address = "м. Львів"
+	vodafone := kyivOffline
+	vodafone.ID += 3
+	vodafone.SIM1 = "+380501234567"
+	recentTest := kyivOffline
+	recentTest.ID += 4
+	recentTest.LastTestTime = now.Add(-time.Hour)
+	bridge := kyivOffline
+	bridge.ID = 100
+
+	query, err := ParseFilterQuery(`source:casl status:offline region:"Київ" sim:kyivstar lasttest>48h`)
+	if err != nil {
+		t.Fatalf("ParseFilterQuery() error = %v", err)
+	}
+	if !query.MatchObject(kyivOffline, now) {
+		t.Fatal("expected offline CASL object in Kyiv to match")
+	}
+	for name, object := range map[string]models.Object{
+		"online":      kyivOnline,
+		"other city":  lvivOffline,
+		"vodafone":    vodafone,
+		"recent test": recentTest,
+		"bridge":      bridge,
+	} {
+		if query.MatchObject(object, now) {
+			t.Errorf("%s: expected no match", name)
+		}
+	}
+}
+
+func TestParseFilterQuery_NegationTextAndDays(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	object := models.Object{ID: 42, DisplayNumber: "0042", Name: "Склад Пролог", LastTestTime: now.Add(-36 * time.Hour)}
+
+	cases := map[string]bool{
+		"пролог":                 true,
+		`"склад пролог"`:         true,
+		"-status:offline":        true,
+		"status:offline":         false,
+		"lasttest<2d":            true,
+		"lasttest>1d":            true,
+		"lasttest>=1d12h":        true,
+		"object:0042":            true,
+		"obj:42 пролог":          true,
+		"вул:Шевченка":           false,
+		"src:bridge -type:тірас": true,
+	}
+	for raw, want := range cases {
+		query, err := ParseFilterQuery(raw)
+		if err != nil {
+			t.Fatalf("%q: ParseFilterQuery() error = %v", raw, err)
+		}
+		if got := query.MatchObject(object, now); got != want {
+			t.Errorf("%q: MatchObject() = %v, want %v", raw, got, want)
+		}
+	}
+
+	never := models.Object{ID: 43}
+	query, _ := ParseFilterQuery("lasttest>48h")
+	if !query.MatchObject(never, now) {
+		t.Fatal("object without tests must count as overdue")
+	}
+}
+
+func TestParseFilterQuery_Errors(t *testing.T) {
+	for _, raw := range []string{
+		`region:"Київ`,
+		"status:sleeping",
+		"lasttest:48h",
+		"lasttest>soon",
+		"status>1h",
+		"sim:mts",
+		"severity:loud",
+		"pult:",
+		"src:",
+		"status:",
+		"-source:",
+		"src:oracle",
+		"source:casl:",
+		`source:"casl cloud:"`,
+	} {
+		if _, err := ParseFilterQuery(raw); err == nil {
+			t.Errorf("%q: expected parse error", raw)
+		}
+	}
+	query, err := ParseFilterQuery("   ")
+	if err != nil || !query.Empty() {
+		t.Fatalf("blank query = %+v, %v", query, err)
+	}
+}
+
+func TestParseFilterQuery_SourceAliases(t *testing.T) {
+	bridge := models.Object{ID: 100}
+	phoenix := models.Object{ID: ids.PhoenixObjectIDNamespaceStart + 1}
+	caslKyiv := models.Object{ID: ids.CASLObjectIDNamespaceStart + 1, SourceInstance: "Київ"}
+	caslLviv := models.Object{ID: ids.CASLObjectIDNamespaceStart + 2, SourceInstance: "Львів"}
+
+	tests := []struct {
+		raw  string
+		want []models.Object
+	}{
+		{raw: "src:bridge", want: []models.Object{bridge}},
+		{raw: "src:mist", want: []models.Object{bridge}},
+		{raw: "src:firebird", want: []models.Object{bridge}},
+		{raw: "src:db", want: []models.Object{bridge}},
+		{raw: "src:міст", want: []models.Object{bridge}},
+		{raw: "src:БД/МІСТ", want: []models.Object{bridge}},
+		{raw: "src:db/bridge", want: []models.Object{bridge}},
+		{raw: "src:phoenix", want: []models.Object{phoenix}},
+		{raw: "source:фенікс", want: []models.Object{phoenix}},
+		{raw: "src:casl", want: []models.Object{caslKyiv, caslLviv}},
+		{raw: `src:"casl cloud"`, want: []models.Object{caslKyiv, caslLviv}},
+		{raw: "source:casl:київ", want: []models.Object{caslKyiv}},
+		{raw: "source:phoenix:київ", want: nil},
+		{raw: "-src:casl", want: []models.Object{bridge, phoenix}},
+	}
+	all := []models.Object{bridge, phoenix, caslKyiv, caslLviv}
+	for _, tt := range tests {
+		query, err := ParseFilterQuery(tt.raw)
+		if err != nil {
+			t.Errorf("%q: ParseFilterQuery() error = %v", tt.raw, err)
+			continue
+		}
+		var got []models.Object
+		for _, object := range all {
+			if query.MatchObject(object, time.Time{}) {
+				got = append(got, object)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: matched %+v, want %+v", tt.raw, got, tt.want)
+			continue
+		}
+		for index := range got {
+			if got[index].ID != tt.want[index].ID {
+				t.Errorf("%q: matched %+v, want %+v", tt.raw, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFilterQuery_MatchEventIgnoresObjectFields(t *testing.T) {
+	fire := models.Event{ObjectID: ids.PhoenixObjectIDNamespaceStart + 3, ObjectNumber: "L0003", ObjectName: "Офіс", Type: models.EventFire}
+	restore := fire
+	restore.Type = models.EventRestore
+
+	query, err := ParseFilterQuery("source:phoenix severity:critical status:offline офіс")
+	if err != nil {
+		t.Fatalf("ParseFilterQuery() error = %v", err)
+	}
+	if !query.MatchEvent(fire) {
+		t.Fatal("critical Phoenix event must match; status: does not apply to events")
+	}
+	if query.MatchEvent(restore) {
+		t.Fatal("restore is not critical")
+	}
+
+	byType, _ := ParseFilterQuery("type:fire object:l0003")
+	if !byType.MatchEvent(fire) || byType.MatchEvent(restore) {
+		t.Fatal("type: must match the event type code")
+	}
+}
+
+func TestNormalizeSavedViewsValidatesQueries(t *testing.T) {
+	views, err := NormalizeSavedViews([]config.SavedView{{Name: " Офлайн CASL ", Query: "source:casl status:offline"}})
+	if err != nil {
+		t.Fatalf("NormalizeSavedViews() error = %v", err)
+	}
+	if views[0].Name != "Офлайн CASL" {
+		t.Fatalf("name = %q, want trimmed", views[0].Name)
+	}
+	if _, err := NormalizeSavedViews([]config.SavedView{{Name: "Зламане", Query: "status:sleeping"}}); err == nil {
+		t.Fatal("expected an error for an invalid expression")
+	}
+}
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"fmt"
+
+	"obj_catalog_fyne_v3/pkg/config"
+)
+
+// NormalizeSavedViews перевіряє назви подань (config.NormalizeSavedViews)
+// і синтаксис кожного виразу мови фільтрів.
+func NormalizeSavedViews(views []config.SavedView) ([]config.SavedView, error) {
+	views, err := config.NormalizeSavedViews(views)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		if _, err := ParseFilterQuery(view.Query); err != nil {
+			return nil, fmt.Errorf("подання %q: %w", view.Name, err)
+		}
+	}
+	return views, nil
+}
@@ -177,6 +177,30 @@ func (s *FrontendV1Service) ListResponseGroups() ([]frontendv1.ResponseGroup, er
 	return items, nil
 }
 
+// ListResponseGroupsForAlarm returns response groups ranked for dispatch to the alarm.
+func (s *FrontendV1Service) ListResponseGroupsForAlarm(alarmID int) ([]frontendv1.ResponseGroup, error) {
+	backend, err := s.backendOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []contracts.FrontendResponseGroup
+	if alarmBackend, ok := backend.(contracts.FrontendAlarmResponseGroupsBackend); ok {
+		result, err = alarmBackend.ListResponseGroupsForAlarm(context.Background(), alarmID)
+	} else {
+		result, err = backend.ListResponseGroups(context.Background())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]frontendv1.ResponseGroup, 0, len(result))
+	for _, item := range result {
+		items = append(items, frontendv1.ToResponseGroup(item))
+	}
+	return items, nil
+}
+
 func (s *FrontendV1Service) AssignResponseGroup(alarmID int, request frontendv1.AlarmGroupActionRequest) error {
 	backend, err := s.backendOrErr()
 	if err != nil {
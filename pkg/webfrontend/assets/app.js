@@ -9,8 +9,13 @@ const state = {
   alarms: [],
   activeMainTab: "object",
   activeObjectTab: "info",
+  savedViews: [],
+  objectGroups: [],
+  objectGroupID: "",
 };
 
+const filterQueryDebounceMs = 300;
+
 const elements = {
   refreshAllButton: document.getElementById("refreshAllButton"),
   refreshAllButtonSidebar: document.getElementById("refreshAllButtonSidebar"),
@@ -19,6 +24,10 @@ const elements = {
   refreshEventsButton: document.getElementById("refreshEventsButton"),
   refreshAlarmsButton: document.getElementById("refreshAlarmsButton"),
   objectSearchInput: document.getElementById("objectSearchInput"),
+  eventsQueryInput: document.getElementById("eventsQueryInput"),
+  savedViewsSelect: document.getElementById("savedViewsSelect"),
+  saveViewButton: document.getElementById("saveViewButton"),
+  objectGroupSelect: document.getElementById("objectGroupSelect"),
   objectListBody: document.getElementById("objectListBody"),
   objectsMeta: document.getElementById("objectsMeta"),
   eventsMeta: document.getElementById("eventsMeta"),
@@ -64,11 +73,16 @@ function init() {
   updateClock();
   window.setInterval(updateClock, 1000);
   loadInitialData();
+  loadSavedViews();
+  loadObjectGroups();
   window.setInterval(refreshJournals, 15000);
 }
 
 function bindEvents() {
-  const refreshAll = () => loadInitialData();
+  const refreshAll = () => {
+    loadObjectGroups();
+    loadInitialData();
+  };
   elements.refreshAllButton.addEventListener("click", refreshAll);
   elements.refreshAllButtonSidebar.addEventListener("click", refreshAll);
   elements.refreshObjectsButton.addEventListener("click", () => loadObjects());
@@ -79,8 +93,13 @@ function bindEvents() {
   });
   elements.refreshEventsButton.addEventListener("click", () => loadGeneralEvents());
   elements.refreshAlarmsButton.addEventListener("click", () => loadAlarms());
-  elements.objectSearchInput.addEventListener("input", () => {
-    applyObjectFilter(elements.objectSearchInput.value);
+  elements.objectSearchInput.addEventListener("input", debounce(() => loadObjects(), filterQueryDebounceMs));
+  elements.eventsQueryInput.addEventListener("input", debounce(() => loadGeneralEvents(), filterQueryDebounceMs));
+  elements.savedViewsSelect.addEventListener("change", () => applySavedView(elements.savedViewsSelect.value));
+  elements.saveViewButton.addEventListener("click", () => saveCurrentView());
+  elements.objectGroupSelect.addEventListener("change", () => {
+    state.objectGroupID = elements.objectGroupSelect.value;
+    loadInitialData();
   });
   elements.mainTabs.forEach((button) => {
     button.addEventListener("click", () => activateMainTab(button.dataset.mainTab));
@@ -114,10 +133,11 @@ async function refreshJournals() {
 async function loadObjects() {
   setMeta(elements.objectsMeta, "Завантаження списку об'єктів...");
   try {
-    const payload = await fetchJSON(`${config.apiBasePath}/objects`);
+    const query = elements.objectSearchInput.value.trim();
+    const payload = await fetchFiltered(`${config.apiBasePath}/objects`, query, elements.objectSearchInput);
     state.objects = Array.isArray(payload.items) ? payload.items : [];
-    applyObjectFilter(elements.objectSearchInput.value);
-    setMeta(elements.objectsMeta, `Об'єктів: ${state.objects.length}`);
+    applyObjectFilter(payload.filtered ? "" : query);
+    setMeta(elements.objectsMeta, `Об'єктів: ${state.filteredObjects.length}`);
     if (state.selectedObjectID && !state.objects.some((item) => item.ID === state.selectedObjectID)) {
       clearSelectedObject();
     }
@@ -153,7 +173,8 @@ async function loadObjectDetails(objectID, silent = false) {
 async function loadGeneralEvents() {
   setMeta(elements.eventsMeta, "Завантаження подій...");
   try {
-    const payload = await fetchJSON(`${config.apiBasePath}/events`);
+    const query = elements.eventsQueryInput.value.trim();
+    const payload = await fetchFiltered(`${config.apiBasePath}/events`, query, elements.eventsQueryInput);
     state.events = Array.isArray(payload.items) ? payload.items : [];
     renderEventsTable(elements.eventsTableContainer, state.events, false);
     setMeta(elements.eventsMeta, `Подій: ${state.events.length}`);
@@ -167,7 +188,7 @@ async function loadGeneralEvents() {
 async function loadAlarms() {
   setMeta(elements.alarmsMeta, "Завантаження тривог...");
   try {
-    const payload = await fetchJSON(`${config.apiBasePath}/alarms`);
+    const payload = await fetchJSON(withParams(`${config.apiBasePath}/alarms`, { group: state.objectGroupID }));
     state.alarms = Array.isArray(payload.items) ? payload.items : [];
     renderAlarmsTable(elements.alarmsTableContainer, state.alarms);
     setMeta(elements.alarmsMeta, `Тривог: ${state.alarms.length}`);
@@ -178,6 +199,126 @@ async function loadAlarms() {
   }
 }
 
+async function fetchFiltered(url, query, input) {
+  const box = input.closest(".search-box");
+  if (box) {
+    box.classList.remove("invalid");
+  }
+  input.title = input.dataset.hint || input.title;
+  const groupURL = withParams(url, { group: state.objectGroupID });
+  if (!query) {
+    return fetchJSON(groupURL);
+  }
+  try {
+    const payload = await fetchJSON(withParams(url, { q: query, group: state.objectGroupID }));
+    payload.filtered = true;
+    return payload;
+  } catch (error) {
+    if (error.status === 501) {
+      return fetchJSON(groupURL);
+    }
+    if (error.status === 400 && box) {
+      box.classList.add("invalid");
+      input.dataset.hint = input.dataset.hint || input.title;
+      input.title = error.message;
+    }
+    throw error;
+  }
+}
+
+async function loadSavedViews() {
+  try {
+    const payload = await fetchJSON(`${config.apiBasePath}/saved-views`);
+    state.savedViews = Array.isArray(payload.items) ? payload.items : [];
+  } catch {
+    return;
+  }
+  elements.savedViewsSelect.classList.remove("hidden");
+  elements.saveViewButton.classList.remove("hidden");
+  renderSavedViews();
+}
+
+function renderSavedViews() {
+  const select = elements.savedViewsSelect;
+  select.innerHTML = "";
+  select.appendChild(new Option(state.savedViews.length ? "★ Подання" : "Немає збережених подань", ""));
+  state.savedViews.forEach((view) => {
+    select.appendChild(new Option(view.name, view.name));
+  });
+}
+
+function applySavedView(name) {
+  const view = state.savedViews.find((item) => item.name === name);
+  elements.savedViewsSelect.value = "";
+  if (!view) {
+    return;
+  }
+  if (state.activeMainTab === "events") {
+    elements.eventsQueryInput.value = view.query;
+    loadGeneralEvents();
+    return;
+  }
+  elements.objectSearchInput.value = view.query;
+  loadObjects();
+}
+
+async function saveCurrentView() {
+  const input = state.activeMainTab === "events" ? elements.eventsQueryInput : elements.objectSearchInput;
+  const query = input.value.trim();
+  if (!query) {
+    window.alert("Рядок фільтра порожній, зберігати нічого");
+    return;
+  }
+  const name = (window.prompt("Назва подання", "") || "").trim();
+  if (!name) {
+    return;
+  }
+  const items = state.savedViews.filter((view) => view.name.toLowerCase() !== name.toLowerCase());
+  items.push({ name, query });
+  try {
+    await sendJSON("PUT", `${config.apiBasePath}/saved-views`, { items });
+    state.savedViews = items;
+    renderSavedViews();
+  } catch (error) {
+    window.alert(`Подання не збережено: ${error.message}`);
+  }
+}
+
+function withParams(url, params) {
+  const search = new URLSearchParams();
+  Object.entries(params).forEach(([key, value]) => {
+    if (value) {
+      search.set(key, value);
+    }
+  });
+  const query = search.toString();
+  return query ? `${url}?${query}` : url;
+}
+
+async function loadObjectGroups() {
+  try {
+    const payload = await fetchJSON(`${config.apiBasePath}/object-groups`);
+    state.objectGroups = Array.isArray(payload.items) ? payload.items : [];
+  } catch {
+    return;
+  }
+  renderObjectGroups();
+}
+
+function renderObjectGroups() {
+  const select = elements.objectGroupSelect;
+  select.innerHTML = "";
+  select.appendChild(new Option("Усі групи", ""));
+  state.objectGroups.forEach((group) => {
+    select.appendChild(new Option(group.watch ? `📌 ${group.name}` : group.name, group.id));
+  });
+  if (!state.objectGroups.some((group) => group.id === state.objectGroupID)) {
+    state.objectGroupID = "";
+  }
+  select.value = state.objectGroupID;
+  select.classList.toggle("hidden", state.objectGroups.length === 0);
+}
+
 function applyObjectFilter(rawQuery) {
   const query = String(rawQuery || "").trim().toLowerCase();
   if (!query) {
@@ -403,12 +544,13 @@ function renderAlarmsTable(container, items) {
     items,
     [
       { label: "Час", render: (item) => `<span class="mono dim">${escapeHTML(formatDate(item.Time))}</span>` },
-      { label: "Об'єкт", render: (item) => escapeHTML(stringifyValue(item.ObjectName || item.ObjectNumber || "—")) },
+      { label: "Об'єкт", render: (item) => `${item.Watched ? '<span title="Список спостереження">📌</span> ' : ""}${escapeHTML(stringifyValue(item.ObjectName || item.ObjectNumber || "—"))}` },
       { label: "Адреса", render: (item) => `<span class="dim">${escapeHTML(stringifyValue(item.Address || "—"))}</span>` },
       { label: "Тип", render: (item) => escapeHTML(stringifyValue(item.TypeText || item.TypeCode || "—")) },
       { label: "Зона", render: (item) => `<span class="mono">${escapeHTML(stringifyValue(item.ZoneName || item.ZoneNumber || "—"))}</span>` },
-      { label: "Деталі", render: (item) => `<span class="dim">${escapeHTML(stringifyValue(item.Details || "—"))}</span>` },
+      { label: "Деталі", render: (item) => `<span class="dim">${escapeHTML(stringifyValue(item.Details || "—"))}</span>${item.MaintenanceReason ? ` <span class="dim">— ТО: ${escapeHTML(item.MaintenanceReason)}</span>` : ""}` },
       { label: "Рівень", render: (item) => renderStatusPill(item.VisualSeverity, severityLabel(item.VisualSeverity)) },
+      { label: "Пріоритет", render: (item) => renderAlarmPriority(item.Priority) },
     ],
     "Активних тривог немає",
     (item) => {
@@ -420,6 +562,36 @@ function renderAlarmsTable(container, items) {
   );
 }
 
+function renderAlarmPriority(priority) {
+  if (!priority) {
+    return '<span class="dim">—</span>';
+  }
+  return `<span class="mono" title="${escapeHTML(alarmPriorityBreakdown(priority))}">${escapeHTML(String(priority.Score))}</span>`;
+}
+
+function alarmPriorityBreakdown(priority) {
+  const factors = [
+    ["тип", priority.Type],
+    ["об'єкт", priority.ObjectType],
+    ["договір", priority.Contract],
+    ["повтори", priority.Repeats],
+    ["без реакції", priority.Unattended],
+    ["МГР", priority.Dispatched],
+  ];
+  const parts = [];
+  factors.forEach(([label, value]) => {
+    if (!value) {
+      return;
+    }
+    if (parts.length === 0) {
+      parts.push(`${label} ${value}`);
+    } else {
+      parts.push(`${value < 0 ? "-" : "+"} ${label} ${Math.abs(value)}`);
+    }
+  });
+  return parts.length === 0 ? String(priority.Score) : `${priority.Score} = ${parts.join(" ")}`;
+}
+
 function renderDataTable(container, items, columns, emptyText, onRowClick = null, rowAlarmPredicate = null) {
   if (!Array.isArray(items) || items.length === 0) {
     renderErrorState(container, emptyText);
@@ -560,11 +732,42 @@ async function fetchJSON(url) {
   }
   if (!response.ok) {
     const message = payload && payload.error ? payload.error : `HTTP ${response.status}`;
-    throw new Error(message);
+    const error = new Error(message);
+    error.status = response.status;
+    throw error;
   }
   return payload;
 }
 
+async function sendJSON(method, url, body) {
+  const response = await fetch(url, {
+    method,
+    headers: {
+      Accept: "application/json",
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(body),
+  });
+  if (response.ok) {
+    return;
+  }
+  let payload = null;
+  try {
+    payload = await response.json();
+  } catch {
+    payload = null;
+  }
+  throw new Error(payload && payload.error ? payload.error : `HTTP ${response.status}`);
+}
+
+function debounce(fn, delayMs) {
+  let timer = null;
+  return () => {
+    window.clearTimeout(timer);
+    timer = window.setTimeout(fn, delayMs);
+  };
+}
+
 function renderStatusPill(rawSeverity, label) {
   const severity = String(rawSeverity || "unknown").toLowerCase();
   return `<span class="status-pill ${escapeHTML(severity)}">${escapeHTML(stringifyValue(label))}</span>`;
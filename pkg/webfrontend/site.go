@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/websocket"
 
+	"obj_catalog_fyne_v3/pkg/adminhttp"
 	"obj_catalog_fyne_v3/pkg/caslcompat"
 	"obj_catalog_fyne_v3/pkg/contracts"
 	"obj_catalog_fyne_v3/pkg/frontendhttp"
@@ -23,6 +24,17 @@ func NewSiteHandlerFull(
 	dialer contracts.PhoneDialer,
 	amiSettings contracts.AMISettingsProvider,
 	dialBuilder func(contracts.AMISettings) contracts.PhoneDialer,
+) (http.Handler, error) {
+	return NewSiteHandlerWithAdmin(backend, dialer, amiSettings, dialBuilder, nil)
+}
+
+// NewSiteHandlerWithAdmin додатково монтує адмін-API (adminhttp) під adminhttp.APIV1BasePath.
+func NewSiteHandlerWithAdmin(
+	backend contracts.FrontendBackend,
+	dialer contracts.PhoneDialer,
+	amiSettings contracts.AMISettingsProvider,
+	dialBuilder func(contracts.AMISettings) contracts.PhoneDialer,
+	adminHandler http.Handler,
 ) (http.Handler, error) {
 	uiHandler, err := NewHandler(frontendhttp.APIV1BasePath)
 	if err != nil {
@@ -34,6 +46,12 @@ func NewSiteHandlerFull(
 	mux := http.NewServeMux()
 	mux.Handle(frontendhttp.APIV1BasePath, apiHandler)
 	mux.Handle(frontendhttp.APIV1BasePath+"/", apiHandler)
+	mux.Handle(frontendhttp.OpenAPIAliasPath, apiHandler)
+	mux.Handle(frontendhttp.EventsExportAliasPath, apiHandler)
+	if adminHandler != nil {
+		mux.Handle(adminhttp.APIV1BasePath, adminHandler)
+		mux.Handle(adminhttp.APIV1BasePath+"/", adminHandler)
+	}
 	mux.Handle("/captchaShow", caslHandler)
 	mux.Handle("/get_time_server", caslHandler)
 	mux.Handle("/login", caslHandler)
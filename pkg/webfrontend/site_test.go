@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"obj_catalog_fyne_v3/pkg/adminhttp"
 	"obj_catalog_fyne_v3/pkg/contracts"
 )
 
@@ -120,3 +121,20 @@ func TestNewSiteHandlerRoutesUIAndAPI(t *testing.T) {
 		t.Fatalf("casl body = %q", caslRec.Body.String())
 	}
 }
+
+func TestNewSiteHandlerWithAdminMountsAdminAPI(t *testing.T) {
+	admin := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler, err := NewSiteHandlerWithAdmin(siteBackendStub{}, nil, nil, nil, admin)
+	if err != nil {
+		t.Fatalf("NewSiteHandlerWithAdmin error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, adminhttp.APIV1BasePath+"/statistics", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("admin status = %d", rec.Code)
+	}
+}